@@ -23,6 +23,7 @@ import (
 
 	alberr "github.com/trickstercache/trickster/v2/pkg/backends/alb/errors"
 	"github.com/trickstercache/trickster/v2/pkg/backends/alb/options"
+	"github.com/trickstercache/trickster/v2/pkg/backends/graphite"
 	"github.com/trickstercache/trickster/v2/pkg/backends/prometheus"
 	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
 	rt "github.com/trickstercache/trickster/v2/pkg/backends/providers/registry/types"
@@ -75,6 +76,20 @@ func TestNew(t *testing.T) {
 			t.Fatalf("Name() = %q, want %q", m.Name(), ShortName)
 		}
 	})
+
+	t.Run("valid graphite provider", func(t *testing.T) {
+		t.Parallel()
+		m, err := New(
+			&options.Options{OutputFormat: providers.Graphite},
+			rt.Lookup{providers.Graphite: graphite.NewClient},
+		)
+		if err != nil {
+			t.Fatalf("New() unexpected error: %v", err)
+		}
+		if h := m.(*handler); len(h.mergePaths) != 1 || h.mergePaths[0] != "/render" {
+			t.Fatalf("mergePaths = %v, want [/render]", h.mergePaths)
+		}
+	})
 }
 
 func TestHandlerStopPool(t *testing.T) {
@@ -26,12 +26,13 @@ const formatHeader = "X-Clickhouse-Format"
 // NewModeler returns a collection of modeling functions for clickhouse interoperability
 func NewModeler() *timeseries.Modeler {
 	return &timeseries.Modeler{
-		WireUnmarshalerReader: UnmarshalTimeseriesReader,
-		WireMarshaler:         MarshalTimeseries,
-		WireMarshalWriter:     MarshalTimeseriesWriter,
-		WireUnmarshaler:       UnmarshalTimeseries,
-		CacheMarshaler:        dataset.MarshalDataSet,
-		CacheUnmarshaler:      dataset.UnmarshalDataSet,
+		WireUnmarshalerReader:  UnmarshalTimeseriesReader,
+		WireMarshaler:          MarshalTimeseries,
+		WireMarshalWriter:      MarshalTimeseriesWriter,
+		WireUnmarshaler:        UnmarshalTimeseries,
+		CacheMarshaler:         dataset.MarshalDataSet,
+		ColumnarCacheMarshaler: dataset.MarshalDataSetColumnar,
+		CacheUnmarshaler:       dataset.UnmarshalDataSet,
 	}
 }
 
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package graphite provides the Graphite Backend provider
+package graphite
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	"github.com/trickstercache/trickster/v2/pkg/backends/graphite/model"
+	gro "github.com/trickstercache/trickster/v2/pkg/backends/graphite/options"
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/backends/providers/registry/types"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/errors"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/params"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+var _ backends.TimeseriesBackend = (*Client)(nil)
+var _ backends.MergeableTimeseriesBackend = (*Client)(nil)
+
+// Graphite API
+const (
+	mnRender      = "render"
+	mnMetrics     = "metrics"
+	mnFind        = "find"
+	mnExpand      = "expand"
+	mnTags        = "tags"
+	mnFunctions   = "functions"
+	mnVersion     = "version"
+	formatJSON    = "json"
+	defaultFrom   = 24 * time.Hour
+	targetDivider = "\n"
+)
+
+// Common URL Parameter Names
+const (
+	upTarget        = "target"
+	upFrom          = "from"
+	upUntil         = "until"
+	upFormat        = "format"
+	upMaxDataPoints = "maxDataPoints"
+	upTZ            = "tz"
+	upQuery         = "query"
+)
+
+// Client Implements Proxy Client Interface
+type Client struct {
+	backends.TimeseriesBackend
+}
+
+var _ types.NewBackendClientFunc = NewClient
+
+// NewClient returns a new Client Instance. Graphite has no instant query with
+// which to fetch fast forward data, so Fast Forward is always disabled.
+func NewClient(name string, o *bo.Options, router http.Handler,
+	cache cache.Cache, _ backends.Backends,
+	_ types.Lookup,
+) (backends.Backend, error) {
+	if o != nil {
+		o.FastForwardDisable = true
+		if o.Graphite == nil {
+			o.Graphite = gro.New()
+		}
+	}
+	c := &Client{}
+	b, err := backends.NewTimeseriesBackend(name, o, c.RegisterHandlers, router,
+		cache, model.NewModeler())
+	c.TimeseriesBackend = b
+	return c, err
+}
+
+// step returns the configured step of the Graphite series behind the backend
+func (c *Client) step() time.Duration {
+	if o := c.Configuration(); o != nil && o.Graphite != nil && o.Graphite.Step > 0 {
+		return time.Duration(o.Graphite.Step)
+	}
+	return gro.DefaultStep
+}
+
+// isJSONRender returns true if the render request values ask for the json
+// format, which is the only format that can be delta cached
+func isJSONRender(qp map[string][]string) bool {
+	v := qp[upFormat]
+	return len(v) > 0 && strings.EqualFold(v[0], formatJSON)
+}
+
+// ParseTimeRangeQuery parses the key parts of a TimeRangeQuery from the inbound HTTP Request
+func (c *Client) ParseTimeRangeQuery(r *http.Request) (*timeseries.TimeRangeQuery,
+	*timeseries.RequestOptions, bool, error,
+) {
+	trq := &timeseries.TimeRangeQuery{Extent: timeseries.Extent{}}
+	rlo := &timeseries.RequestOptions{FastForwardDisable: true}
+	qp, b, isBody := params.GetRequestValues(r)
+	if isBody {
+		trq.OriginalBody = b
+	}
+
+	targets := qp[upTarget]
+	if len(targets) == 0 {
+		return nil, nil, false, errors.MissingURLParam(upTarget)
+	}
+	if !isJSONRender(qp) {
+		return nil, nil, false, errors.ErrNotTimeRangeQuery
+	}
+	trq.Statement = strings.Join(targets, targetDivider)
+
+	loc := time.UTC
+	if p := qp.Get(upTZ); p != "" {
+		l, err := time.LoadLocation(p)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		loc = l
+	}
+	now := time.Now().In(loc)
+	trq.Extent.Start = now.Add(-defaultFrom)
+	trq.Extent.End = now
+	if p := qp.Get(upFrom); p != "" {
+		t, err := parseTime(p, now)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		trq.Extent.Start = t
+	}
+	if p := qp.Get(upUntil); p != "" {
+		t, err := parseTime(p, now)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		trq.Extent.End = t
+	}
+	if !trq.Extent.Start.Before(trq.Extent.End) {
+		return nil, nil, false, errors.ErrNotTimeRangeQuery
+	}
+	trq.Step = c.step()
+
+	rlo.ProviderRequest = &model.RenderRequest{Extent: &trq.Extent}
+	trq.ExtractBackfillTolerance(trq.Statement)
+
+	return trq, rlo, true, nil
+}
+
+// maxDataPoints returns the maxDataPoints of the render request values, or 0
+func maxDataPoints(qp map[string][]string) int {
+	v := qp[upMaxDataPoints]
+	if len(v) == 0 {
+		return 0
+	}
+	i, err := strconv.Atoi(v[0])
+	if err != nil || i < 0 {
+		return 0
+	}
+	return i
+}
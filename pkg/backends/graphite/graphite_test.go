@@ -0,0 +1,200 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphite
+
+import (
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/graphite/model"
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/errors"
+)
+
+func TestNewClient(t *testing.T) {
+	c, err := NewClient("test", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.(*Client).Modeler() == nil {
+		t.Error("expected non-nil modeler")
+	}
+	o := bo.New()
+	if _, err = NewClient("test", o, nil, nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !o.FastForwardDisable {
+		t.Error("expected fast forward to be disabled")
+	}
+	if o.Graphite == nil {
+		t.Error("expected default graphite options")
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2020, 9, 13, 12, 26, 40, 0, time.UTC)
+	tests := []struct {
+		input    string
+		expected time.Time
+		err      bool
+	}{
+		{"1600000000", time.Unix(1600000000, 0), false},
+		{"now", now, false},
+		{"-1h", now.Add(-time.Hour), false},
+		{"-24hours", now.Add(-24 * time.Hour), false},
+		{"-2d", now.Add(-48 * time.Hour), false},
+		{"-1w", now.Add(-7 * 24 * time.Hour), false},
+		{"-10min", now.Add(-10 * time.Minute), false},
+		{"-30s", now.Add(-30 * time.Second), false},
+		{"-1mon", now.Add(-30 * 24 * time.Hour), false},
+		{"-1y", now.Add(-365 * 24 * time.Hour), false},
+		{"-1d12h", now.Add(-36 * time.Hour), false},
+		{"now-1d", now.Add(-24 * time.Hour), false},
+		{"+1h", now.Add(time.Hour), false},
+		{"20200101", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"04:00_20200101", time.Date(2020, 1, 1, 4, 0, 0, 0, time.UTC), false},
+		{"4:30pm_20200101", time.Date(2020, 1, 1, 16, 30, 0, 0, time.UTC), false},
+		{"01/02/20", time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"01/02/1999", time.Date(1999, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"today", time.Date(2020, 9, 13, 0, 0, 0, 0, time.UTC), false},
+		{"yesterday", time.Date(2020, 9, 12, 0, 0, 0, 0, time.UTC), false},
+		{"tomorrow", time.Date(2020, 9, 14, 0, 0, 0, 0, time.UTC), false},
+		{"midnight", time.Date(2020, 9, 13, 0, 0, 0, 0, time.UTC), false},
+		{"noon yesterday", time.Date(2020, 9, 12, 12, 0, 0, 0, time.UTC), false},
+		{"teatime", time.Date(2020, 9, 13, 16, 0, 0, 0, time.UTC), false},
+		{"midnight+2h", time.Date(2020, 9, 13, 2, 0, 0, 0, time.UTC), false},
+		{"", time.Time{}, true},
+		{"-1m", time.Time{}, true},
+		{"-h", time.Time{}, true},
+		{"-", time.Time{}, true},
+		{"lastweek", time.Time{}, true},
+		{"25:00_20200101", time.Time{}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			v, err := parseTime(test.input, now)
+			if test.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !v.Equal(test.expected) {
+				t.Errorf("expected %s got %s", test.expected, v)
+			}
+		})
+	}
+}
+
+func TestParseTimeRangeQuery(t *testing.T) {
+	c, err := NewClient("test", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	qp := url.Values{upTarget: {"servers.a.cpu", "servers.b.cpu"},
+		upFrom: {"1600000000"}, upUntil: {"1600003600"}, upFormat: {"json"},
+		upMaxDataPoints: {"100"}}
+	r := httptest.NewRequest(http.MethodGet, "http://graphite/render?"+qp.Encode(), nil)
+	trq, rlo, canOPC, err := c.(*Client).ParseTimeRangeQuery(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !canOPC {
+		t.Error("expected true")
+	}
+	if trq.Statement != "servers.a.cpu\nservers.b.cpu" {
+		t.Errorf("unexpected statement: %s", trq.Statement)
+	}
+	if !trq.Extent.Start.Equal(time.Unix(1600000000, 0)) ||
+		!trq.Extent.End.Equal(time.Unix(1600003600, 0)) {
+		t.Errorf("unexpected extent: %s", trq.Extent.String())
+	}
+	if trq.Step != time.Minute {
+		t.Errorf("expected %s got %s", time.Minute, trq.Step)
+	}
+	if !rlo.FastForwardDisable {
+		t.Error("expected fast forward to be disabled")
+	}
+	rr, ok := rlo.ProviderRequest.(*model.RenderRequest)
+	if !ok || rr.Extent != &trq.Extent {
+		t.Error("expected render request for the time range query's extent")
+	}
+
+	// from and until default to the last 24 hours
+	qp = url.Values{upTarget: {"servers.a.cpu"}, upFormat: {"json"}}
+	r = httptest.NewRequest(http.MethodGet, "http://graphite/render?"+qp.Encode(), nil)
+	trq, _, _, err = c.(*Client).ParseTimeRangeQuery(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := trq.Extent.End.Sub(trq.Extent.Start); d != defaultFrom {
+		t.Errorf("expected %s got %s", defaultFrom, d)
+	}
+
+	tests := []struct {
+		query    string
+		expected error
+	}{
+		{"format=json", errors.MissingURLParam(upTarget)},
+		{"target=x", errors.ErrNotTimeRangeQuery},
+		{"target=x&format=png", errors.ErrNotTimeRangeQuery},
+		{"target=x&format=json&from=-1h&until=-2h", errors.ErrNotTimeRangeQuery},
+		{"target=x&format=json&from=invalid", errInvalidTime},
+		{"target=x&format=json&tz=Invalid/Zone", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://graphite/render?"+test.query, nil)
+			_, _, _, err := c.(*Client).ParseTimeRangeQuery(r)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if test.expected != nil && err.Error() != test.expected.Error() &&
+				!stderrors.Is(err, test.expected) {
+				t.Errorf("expected %v got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestMaxDataPoints(t *testing.T) {
+	tests := []struct {
+		input    []string
+		expected int
+	}{
+		{nil, 0},
+		{[]string{"500"}, 500},
+		{[]string{"-1"}, 0},
+		{[]string{"x"}, 0},
+	}
+	for _, test := range tests {
+		qp := url.Values{}
+		if test.input != nil {
+			qp[upMaxDataPoints] = test.input
+		}
+		if v := maxDataPoints(qp); v != test.expected {
+			t.Errorf("expected %d got %d", test.expected, v)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphite
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/engines"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/params"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/urls"
+)
+
+// findRound is the interval to which the epoch from and until values of find
+// requests are rounded down
+const findRound = time.Minute
+
+// FindHandler proxies requests for path /metrics/find to the origin by way of
+// the object proxy cache. Any epoch from and until values are rounded down for
+// cacheability.
+func (c *Client) FindHandler(w http.ResponseWriter, r *http.Request) {
+	u := urls.BuildUpstreamURL(r, c.BaseUpstreamURL())
+	qp, _, _ := params.GetRequestValues(r)
+	for _, p := range []string{upFrom, upUntil} {
+		if v := qp.Get(p); isDigits(v) {
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				qp.Set(p, strconv.FormatInt(time.Unix(i, 0).Truncate(findRound).Unix(), 10))
+			}
+		}
+	}
+	r.URL = u
+	params.SetRequestValues(r, qp)
+	engines.ObjectProxyCacheRequest(w, r)
+}
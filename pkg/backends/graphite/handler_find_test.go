@@ -0,0 +1,65 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+	tu "github.com/trickstercache/trickster/v2/pkg/testutil"
+)
+
+func TestFindHandler(t *testing.T) {
+	backendClient, err := NewClient("test", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts, w, r, _, err := tu.NewTestInstance("", backendClient.DefaultPathConfigs,
+		200, "[]", nil, providers.Graphite,
+		"/metrics/find?query=servers.*&from=1700000010&until=-1h", "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ts.Close()
+	rsc := request.GetResources(r)
+	backendClient, err = NewClient("test", rsc.BackendOptions, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := backendClient.(*Client)
+	rsc.BackendClient = client
+	rsc.BackendOptions.HTTPClient = backendClient.HTTPClient()
+
+	client.FindHandler(w, r)
+	resp := w.Result()
+	if resp.StatusCode != 200 {
+		t.Errorf("expected 200 got %d.", resp.StatusCode)
+	}
+	if v := resp.Header.Get(headers.NameTricksterResult); !strings.Contains(v, "engine=ObjectProxyCache") {
+		t.Errorf("expected %s got %s", "engine=ObjectProxyCache", v)
+	}
+	qp := r.URL.Query()
+	if v := qp.Get(upFrom); v != "1699999980" {
+		t.Errorf("expected %s got %s", "1699999980", v)
+	}
+	if v := qp.Get(upUntil); v != "-1h" {
+		t.Errorf("expected %s got %s", "-1h", v)
+	}
+}
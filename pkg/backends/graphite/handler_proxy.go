@@ -0,0 +1,37 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphite
+
+import (
+	"net/http"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/engines"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/urls"
+)
+
+// ProxyHandler sends a request through the basic reverse proxy to the origin,
+// and services non-cacheable Graphite API calls.
+func (c *Client) ProxyHandler(w http.ResponseWriter, r *http.Request) {
+	r.URL = urls.BuildUpstreamURL(r, c.BaseUpstreamURL())
+	engines.DoProxy(w, r, true)
+}
+
+// ObjectProxyCacheHandler routes requests to the origin by way of the object proxy cache
+func (c *Client) ObjectProxyCacheHandler(w http.ResponseWriter, r *http.Request) {
+	r.URL = urls.BuildUpstreamURL(r, c.BaseUpstreamURL())
+	engines.ObjectProxyCacheRequest(w, r)
+}
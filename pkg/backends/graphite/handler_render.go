@@ -0,0 +1,65 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphite
+
+import (
+	"net/http"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/graphite/model"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/engines"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/params"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/response/merge"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/urls"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+)
+
+// RenderHandler handles render requests for Graphite. Requests for the json
+// format are processed through the delta proxy cache, while other formats,
+// like png or csv, are proxied.
+func (c *Client) RenderHandler(w http.ResponseWriter, r *http.Request) {
+	qp, _, _ := params.GetRequestValues(r)
+	if !isJSONRender(qp) {
+		c.ProxyHandler(w, r)
+		return
+	}
+	rsc := request.GetResources(r)
+	if rsc != nil {
+		// the cache holds series at full resolution, so they are consolidated
+		// to the client's maxDataPoints before each response
+		if n := maxDataPoints(qp); n > 0 {
+			rsc.TSTransformer = func(ts timeseries.Timeseries) {
+				if ds, ok := ts.(*dataset.DataSet); ok && rsc.TimeRangeQuery != nil {
+					model.Consolidate(ds, rsc.TimeRangeQuery.Extent, n)
+				}
+			}
+		}
+		// if this request is part of a scatter/gather, provide a reconstitution function
+		if rsc.IsMergeMember {
+			m := c.Modeler()
+			rsc.MergeFunc = merge.TimeseriesMergeFuncTolerant(m.WireUnmarshaler,
+				rsc.TSDedupToleranceNanos)
+			rsc.BatchMergeFunc = merge.TimeseriesBatchMergeFuncTolerant(
+				rsc.TSDedupToleranceNanos)
+			rsc.MergeRespondFunc = merge.TimeseriesRespondFunc(m.WireMarshalWriter,
+				rsc.TSReqestOptions)
+		}
+	}
+	r.URL = urls.BuildUpstreamURL(r, c.BaseUpstreamURL())
+	engines.DeltaProxyCacheRequest(w, r, c.Modeler())
+}
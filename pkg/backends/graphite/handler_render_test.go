@@ -0,0 +1,113 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphite
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+	tu "github.com/trickstercache/trickster/v2/pkg/testutil"
+)
+
+// testRenderBody returns a Graphite render response with points at start and
+// each of the following two minutes
+func testRenderBody(start time.Time) string {
+	return fmt.Sprintf(`[{"target":"servers.a.cpu","tags":{"name":"servers.a.cpu"},`+
+		`"datapoints":[[1,%d],[2,%d],[3,%d]]}]`, start.Unix(),
+		start.Add(time.Minute).Unix(), start.Add(2*time.Minute).Unix())
+}
+
+func testRender(t *testing.T, qp url.Values, start time.Time) (string, string, string) {
+	t.Helper()
+	backendClient, err := NewClient("test", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := testRenderBody(start)
+	ts, w, r, _, err := tu.NewTestInstance("", backendClient.DefaultPathConfigs,
+		200, body, nil, providers.Graphite, "/"+mnRender+"?"+qp.Encode(), "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ts.Close()
+	rsc := request.GetResources(r)
+	backendClient, err = NewClient("test", rsc.BackendOptions, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := backendClient.(*Client)
+	rsc.BackendClient = client
+	rsc.BackendOptions.HTTPClient = backendClient.HTTPClient()
+
+	client.RenderHandler(w, r)
+	resp := w.Result()
+	if resp.StatusCode != 200 {
+		t.Errorf("expected 200 got %d.", resp.StatusCode)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Error(err)
+	}
+	return string(b), resp.Header.Get(headers.NameTricksterResult), body
+}
+
+func TestRenderHandler(t *testing.T) {
+	start := time.Now().Truncate(3 * time.Minute).Add(-9 * time.Minute)
+	qp := url.Values{upTarget: {"servers.a.cpu"}, upFormat: {"json"},
+		upFrom: {fmt.Sprint(start.Unix())}, upUntil: {fmt.Sprint(start.Add(2 * time.Minute).Unix())}}
+	body, result, expected := testRender(t, qp, start)
+	if !strings.Contains(result, "engine=DeltaProxyCache") {
+		t.Errorf("expected delta proxy cache result, got %s", result)
+	}
+	if body != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, body)
+	}
+}
+
+func TestRenderHandlerMaxDataPoints(t *testing.T) {
+	start := time.Now().Truncate(3 * time.Minute).Add(-9 * time.Minute)
+	qp := url.Values{upTarget: {"servers.a.cpu"}, upFormat: {"json"},
+		upFrom: {fmt.Sprint(start.Unix())}, upUntil: {fmt.Sprint(start.Add(2 * time.Minute).Unix())},
+		upMaxDataPoints: {"1"}}
+	body, result, _ := testRender(t, qp, start)
+	if !strings.Contains(result, "engine=DeltaProxyCache") {
+		t.Errorf("expected delta proxy cache result, got %s", result)
+	}
+	expected := fmt.Sprintf(`"datapoints":[[2,%d]]`, start.Unix())
+	if !strings.Contains(body, expected) {
+		t.Errorf("expected %s in %s", expected, body)
+	}
+}
+
+func TestRenderHandlerNonJSON(t *testing.T) {
+	start := time.Now().Truncate(time.Minute).Add(-10 * time.Minute)
+	qp := url.Values{upTarget: {"servers.a.cpu"}, upFormat: {"png"}}
+	body, result, expected := testRender(t, qp, start)
+	if !strings.Contains(result, "engine=HTTPProxy") {
+		t.Errorf("expected proxy result, got %s", result)
+	}
+	if body != expected {
+		t.Errorf("expected unmodified body, got %s", body)
+	}
+}
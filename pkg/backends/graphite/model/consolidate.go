@@ -0,0 +1,93 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/epoch"
+)
+
+// Consolidate reduces each series in the DataSet to at most maxDataPoints
+// points across the extent, by averaging the non-null values of consecutive
+// steps, as Graphite does for render requests that provide maxDataPoints. Each
+// consolidated point is aligned to its own width, so that subsequent requests
+// for a moving extent yield the same points.
+func Consolidate(ds *dataset.DataSet, extent timeseries.Extent, maxDataPoints int) {
+	if ds == nil || ds.TimeRangeQuery == nil || maxDataPoints <= 0 {
+		return
+	}
+	step := ds.TimeRangeQuery.Step
+	if step <= 0 || extent.End.Before(extent.Start) {
+		return
+	}
+	n := int(extent.End.Sub(extent.Start)/step) + 1
+	if n <= maxDataPoints {
+		return
+	}
+	valuesPerPoint := (n + maxDataPoints - 1) / maxDataPoints
+	width := epoch.Epoch(step) * epoch.Epoch(valuesPerPoint)
+	for _, r := range ds.Results {
+		if r == nil {
+			continue
+		}
+		for _, s := range r.SeriesList {
+			if s == nil {
+				continue
+			}
+			s.Points, s.PointSize = consolidatePoints(s.Points, width)
+		}
+	}
+	trq := ds.TimeRangeQuery.Clone()
+	trq.Step = time.Duration(width)
+	ds.TimeRangeQuery = trq
+}
+
+func consolidatePoints(pts dataset.Points, width epoch.Epoch) (dataset.Points, int64) {
+	if !slices.IsSortedFunc(pts, pointCmp) {
+		slices.SortFunc(pts, pointCmp)
+	}
+	out := make(dataset.Points, 0, len(pts))
+	var size int64 = 16
+	for i := 0; i < len(pts); {
+		bucket := pts[i].Epoch / width * width
+		var sum float64
+		var count int
+		for ; i < len(pts) && pts[i].Epoch < bucket+width; i++ {
+			if v, ok := pointValue(pts[i]); ok {
+				sum += v
+				count++
+			}
+		}
+		if count == 0 {
+			continue
+		}
+		v := strconv.FormatFloat(sum/float64(count), 'f', -1, 64)
+		pt := dataset.Point{
+			Epoch:  bucket,
+			Size:   len(v) + 32,
+			Values: []any{v},
+		}
+		out = append(out, pt)
+		size += int64(pt.Size)
+	}
+	return out, size
+}
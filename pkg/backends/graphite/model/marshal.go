@@ -0,0 +1,200 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/errors"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/epoch"
+)
+
+// MarshalTimeseries converts a Timeseries into a JSON blob
+func MarshalTimeseries(ts timeseries.Timeseries, rlo *timeseries.RequestOptions, status int) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	err := MarshalTimeseriesWriter(ts, rlo, status, buf)
+	return buf.Bytes(), err
+}
+
+// MarshalTimeseriesWriter converts a Timeseries into a JSON blob via an io.Writer.
+// Each series is laid out on the step of the DataSet across the requested time
+// range, with null points filling any gaps, as Graphite does.
+func MarshalTimeseriesWriter(ts timeseries.Timeseries, rlo *timeseries.RequestOptions,
+	_ int, w io.Writer,
+) error {
+	if w == nil {
+		return errors.ErrNilWriter
+	}
+	ds, ok := ts.(*dataset.DataSet)
+	if !ok || ds == nil {
+		return timeseries.ErrUnknownFormat
+	}
+	var rr *RenderRequest
+	if rlo != nil {
+		rr, _ = rlo.ProviderRequest.(*RenderRequest)
+	}
+	var step time.Duration
+	if ds.TimeRangeQuery != nil {
+		step = ds.TimeRangeQuery.Step
+	}
+	var start, end epoch.Epoch
+	if rr != nil && rr.Extent != nil && !rr.Extent.Start.IsZero() {
+		start = epoch.Epoch(rr.Extent.Start.UnixNano())
+		end = epoch.Epoch(rr.Extent.End.UnixNano())
+	} else {
+		start, end = pointsRange(ds)
+	}
+
+	w.Write([]byte("["))
+	var buf [64]byte
+	var seriesSep bool
+	for _, r := range ds.Results {
+		if r == nil {
+			continue
+		}
+		for _, s := range r.SeriesList {
+			if s == nil {
+				continue
+			}
+			if seriesSep {
+				w.Write([]byte(","))
+			} else {
+				seriesSep = true
+			}
+			w.Write([]byte(`{"target":`))
+			b, _ := json.Marshal(s.Header.Name)
+			w.Write(b)
+			w.Write([]byte(`,"tags":`))
+			w.Write([]byte(s.Header.Tags.JSON()))
+			w.Write([]byte(`,"datapoints":[`))
+			writeDatapoints(w, s.Points, start, end, step, buf)
+			w.Write([]byte("]}"))
+		}
+	}
+	w.Write([]byte("]"))
+	return nil
+}
+
+// pointsRange returns the earliest and latest point epochs in the DataSet
+func pointsRange(ds *dataset.DataSet) (epoch.Epoch, epoch.Epoch) {
+	var start, end epoch.Epoch
+	for _, r := range ds.Results {
+		if r == nil {
+			continue
+		}
+		for _, s := range r.SeriesList {
+			if s == nil {
+				continue
+			}
+			for _, p := range s.Points {
+				if start == 0 || p.Epoch < start {
+					start = p.Epoch
+				}
+				if p.Epoch > end {
+					end = p.Epoch
+				}
+			}
+		}
+	}
+	return start, end
+}
+
+func pointCmp(a, b dataset.Point) int {
+	if a.Epoch < b.Epoch {
+		return -1
+	}
+	if a.Epoch > b.Epoch {
+		return 1
+	}
+	return 0
+}
+
+func pointValue(p dataset.Point) (float64, bool) {
+	if len(p.Values) == 0 {
+		return 0, false
+	}
+	var f float64
+	switch v := p.Values[0].(type) {
+	case string:
+		var err error
+		if f, err = strconv.ParseFloat(v, 64); err != nil {
+			return 0, false
+		}
+	case float64:
+		f = v
+	default:
+		return 0, false
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+func writeDatapoint(w io.Writer, v float64, ok bool, e epoch.Epoch, sep bool, buf [64]byte) {
+	if sep {
+		w.Write([]byte(",["))
+	} else {
+		w.Write([]byte("["))
+	}
+	if ok {
+		w.Write(strconv.AppendFloat(buf[:0], v, 'f', -1, 64))
+	} else {
+		w.Write([]byte("null"))
+	}
+	w.Write([]byte(","))
+	w.Write(strconv.AppendInt(buf[:0], int64(e)/1e9, 10))
+	w.Write([]byte("]"))
+}
+
+// writeDatapoints writes the points of a series as Graphite datapoints, laid
+// out on the step between start and end, with null points filling any gaps.
+// When the step or time range is unknown, the points are written as-is.
+func writeDatapoints(w io.Writer, pts dataset.Points, start, end epoch.Epoch,
+	step time.Duration, buf [64]byte,
+) {
+	if !slices.IsSortedFunc(pts, pointCmp) {
+		slices.SortFunc(pts, pointCmp)
+	}
+	if step <= 0 || end < start || start == 0 {
+		for i, p := range pts {
+			v, ok := pointValue(p)
+			writeDatapoint(w, v, ok, p.Epoch, i > 0, buf)
+		}
+		return
+	}
+	s := epoch.Epoch(step)
+	var i int
+	for t := start / s * s; t <= end; t += s {
+		v, ok := 0.0, false
+		// the last point in the step wins, should any be off the step
+		for ; i < len(pts) && pts[i].Epoch < t+s; i++ {
+			if pts[i].Epoch >= t {
+				v, ok = pointValue(pts[i])
+			}
+		}
+		writeDatapoint(w, v, ok, t, t > start/s*s, buf)
+	}
+}
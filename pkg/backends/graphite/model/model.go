@@ -0,0 +1,63 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package model provides the Graphite render API wire format and its
+// conversion to and from the common DataSet format
+package model
+
+import (
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+)
+
+// NewModeler returns a collection of modeling functions for graphite interoperability
+func NewModeler() *timeseries.Modeler {
+	return &timeseries.Modeler{
+		WireUnmarshalerReader:  UnmarshalTimeseriesReader,
+		WireMarshaler:          MarshalTimeseries,
+		WireMarshalWriter:      MarshalTimeseriesWriter,
+		WireUnmarshaler:        UnmarshalTimeseries,
+		CacheMarshaler:         dataset.MarshalDataSet,
+		ColumnarCacheMarshaler: dataset.MarshalDataSetColumnar,
+		CacheUnmarshaler:       dataset.UnmarshalDataSet,
+	}
+}
+
+// WFDocument is the Wire Format Document for a Graphite render API response
+// in the json format
+type WFDocument []*WFSeries
+
+// WFSeries is a single series in the WFDocument
+type WFSeries struct {
+	Target     string            `json:"target"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	Datapoints []WFDatapoint     `json:"datapoints"`
+}
+
+// WFDatapoint is a [value, timestamp] pair in a WFSeries, where the value is
+// nil for a null point, and the timestamp is in Unix epoch seconds
+type WFDatapoint [2]*float64
+
+// RenderRequest holds the parts of the client's render request that shape the
+// response document, and is provided to the marshaler via
+// RequestOptions.ProviderRequest
+type RenderRequest struct {
+	// Extent is the time range of the request, over which the datapoints of
+	// each series are laid out, with null points filling any gaps
+	Extent *timeseries.Extent
+}
+
+const fieldNameValue = "value"
@@ -0,0 +1,168 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+)
+
+const testDoc = `[{"target":"servers.a.cpu","tags":{"name":"servers.a.cpu"},` +
+	`"datapoints":[[1.5,1600000020],[null,1600000080],[3,1600000140]]},` +
+	`{"target":"servers.b.cpu","tags":{"name":"servers.b.cpu"},` +
+	`"datapoints":[[2,1600000020],[4,1600000080],[6,1600000140]]}]`
+
+func testTRQ() *timeseries.TimeRangeQuery {
+	return &timeseries.TimeRangeQuery{
+		Statement: "servers.*.cpu",
+		Step:      time.Minute,
+		Extent: timeseries.Extent{
+			Start: time.Unix(1600000020, 0),
+			End:   time.Unix(1600000140, 0),
+		},
+	}
+}
+
+func TestNewModeler(t *testing.T) {
+	m := NewModeler()
+	if m.WireUnmarshaler == nil || m.WireMarshalWriter == nil ||
+		m.CacheMarshaler == nil || m.CacheUnmarshaler == nil {
+		t.Error("expected non-nil modeling functions")
+	}
+}
+
+func TestUnmarshalTimeseries(t *testing.T) {
+	ts, err := UnmarshalTimeseries([]byte(testDoc), testTRQ())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := ts.(*dataset.DataSet)
+	if len(ds.Results) != 1 || len(ds.Results[0].SeriesList) != 2 {
+		t.Fatal("expected 1 result with 2 series")
+	}
+	s := ds.Results[0].SeriesList[0]
+	if s.Header.Name != "servers.a.cpu" {
+		t.Errorf("expected %s got %s", "servers.a.cpu", s.Header.Name)
+	}
+	if s.Header.Tags["name"] != "servers.a.cpu" {
+		t.Errorf("expected %s got %s", "servers.a.cpu", s.Header.Tags["name"])
+	}
+	// the null point is not stored
+	if len(s.Points) != 2 {
+		t.Errorf("expected %d got %d", 2, len(s.Points))
+	}
+	if ds.ValueCount() != 5 {
+		t.Errorf("expected %d got %d", 5, ds.ValueCount())
+	}
+
+	if _, err = UnmarshalTimeseries([]byte(testDoc), nil); err != timeseries.ErrNoTimerangeQuery {
+		t.Errorf("expected %v got %v", timeseries.ErrNoTimerangeQuery, err)
+	}
+	if _, err = UnmarshalTimeseries([]byte("not json"), testTRQ()); err == nil {
+		t.Error("expected error for invalid document")
+	}
+}
+
+func TestMarshalTimeseries(t *testing.T) {
+	ts, err := UnmarshalTimeseries([]byte(testDoc), testTRQ())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := MarshalTimeseries(ts, nil, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != testDoc {
+		t.Errorf("expected\n%s\ngot\n%s", testDoc, string(b))
+	}
+
+	// the requested extent is filled with null points
+	trq := testTRQ()
+	e := timeseries.Extent{
+		Start: time.Unix(1599999960, 0),
+		End:   time.Unix(1600000140, 0),
+	}
+	rlo := &timeseries.RequestOptions{
+		ProviderRequest: &RenderRequest{Extent: &e},
+	}
+	ts, _ = UnmarshalTimeseries([]byte(testDoc), trq)
+	b, _ = MarshalTimeseries(ts, rlo, 200)
+	const expectedFilled = `[{"target":"servers.a.cpu","tags":{"name":"servers.a.cpu"},` +
+		`"datapoints":[[null,1599999960],[1.5,1600000020],[null,1600000080],[3,1600000140]]},` +
+		`{"target":"servers.b.cpu","tags":{"name":"servers.b.cpu"},` +
+		`"datapoints":[[null,1599999960],[2,1600000020],[4,1600000080],[6,1600000140]]}]`
+	if string(b) != expectedFilled {
+		t.Errorf("expected\n%s\ngot\n%s", expectedFilled, string(b))
+	}
+}
+
+func TestConsolidate(t *testing.T) {
+	trq := testTRQ()
+	ts, err := UnmarshalTimeseries([]byte(testDoc), trq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := ts.(*dataset.DataSet)
+	// no consolidation is needed when the extent fits in maxDataPoints
+	Consolidate(ds, trq.Extent, 3)
+	if ds.TimeRangeQuery.Step != time.Minute {
+		t.Errorf("expected %s got %s", time.Minute, ds.TimeRangeQuery.Step)
+	}
+	Consolidate(ds, trq.Extent, 2)
+	if ds.TimeRangeQuery.Step != 2*time.Minute {
+		t.Errorf("expected %s got %s", 2*time.Minute, ds.TimeRangeQuery.Step)
+	}
+	if trq.Step != time.Minute {
+		t.Error("expected the original time range query to be unchanged")
+	}
+	rlo := &timeseries.RequestOptions{
+		ProviderRequest: &RenderRequest{Extent: &trq.Extent},
+	}
+	b, err := MarshalTimeseries(ds, rlo, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 3 points consolidate to 2 points of 120s, aligned to their width
+	const expected = `[{"target":"servers.a.cpu","tags":{"name":"servers.a.cpu"},` +
+		`"datapoints":[[1.5,1599999960],[3,1600000080]]},` +
+		`{"target":"servers.b.cpu","tags":{"name":"servers.b.cpu"},` +
+		`"datapoints":[[2,1599999960],[5,1600000080]]}]`
+	if string(b) != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, string(b))
+	}
+}
+
+func TestMarshalTimeseriesWriter(t *testing.T) {
+	if err := MarshalTimeseriesWriter(&dataset.DataSet{}, nil, 200, nil); err == nil {
+		t.Error("expected error for nil writer")
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := MarshalTimeseriesWriter(nil, nil, 200, buf); err != timeseries.ErrUnknownFormat {
+		t.Errorf("expected %v got %v", timeseries.ErrUnknownFormat, err)
+	}
+	buf.Reset()
+	if err := MarshalTimeseriesWriter(&dataset.DataSet{}, nil, 200, buf); err != nil {
+		t.Error(err)
+	}
+	if buf.String() != "[]" {
+		t.Errorf("expected %s got %s", "[]", buf.String())
+	}
+}
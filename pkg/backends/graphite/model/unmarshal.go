@@ -0,0 +1,91 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/epoch"
+)
+
+// UnmarshalTimeseries converts a JSON blob into a Timeseries
+func UnmarshalTimeseries(data []byte, trq *timeseries.TimeRangeQuery) (timeseries.Timeseries, error) {
+	return UnmarshalTimeseriesReader(bytes.NewReader(data), trq)
+}
+
+// UnmarshalTimeseriesReader converts a JSON blob into a Timeseries via io.Reader
+func UnmarshalTimeseriesReader(reader io.Reader, trq *timeseries.TimeRangeQuery) (timeseries.Timeseries, error) {
+	if trq == nil {
+		return nil, timeseries.ErrNoTimerangeQuery
+	}
+	if reader == nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	var wfd WFDocument
+	if err := json.NewDecoder(reader).Decode(&wfd); err != nil {
+		return nil, err
+	}
+	ds := &dataset.DataSet{
+		TimeRangeQuery: trq,
+		ExtentList:     timeseries.ExtentList{trq.Extent},
+		Results: []*dataset.Result{{
+			SeriesList: make([]*dataset.Series, 0, len(wfd)),
+		}},
+	}
+	fd := timeseries.FieldDefinition{
+		Name:     fieldNameValue,
+		DataType: timeseries.String,
+	}
+	for _, wfs := range wfd {
+		if wfs == nil {
+			continue
+		}
+		sh := dataset.SeriesHeader{
+			Name:            wfs.Target,
+			Tags:            dataset.Tags(wfs.Tags),
+			QueryStatement:  trq.Statement,
+			ValueFieldsList: []timeseries.FieldDefinition{fd},
+		}
+		sh.CalculateSize()
+		s := &dataset.Series{
+			Header:    sh,
+			Points:    make(dataset.Points, 0, len(wfs.Datapoints)),
+			PointSize: 16,
+		}
+		// null points are not stored, and are restored by the marshaler
+		for _, dp := range wfs.Datapoints {
+			if dp[0] == nil || dp[1] == nil {
+				continue
+			}
+			v := strconv.FormatFloat(*dp[0], 'f', -1, 64)
+			pt := dataset.Point{
+				Epoch:  epoch.Epoch(int64(*dp[1]) * 1e9),
+				Size:   len(v) + 32,
+				Values: []any{v},
+			}
+			s.Points = append(s.Points, pt)
+			s.PointSize += int64(pt.Size)
+		}
+		ds.Results[0].SeriesList = append(ds.Results[0].SeriesList, s)
+	}
+	return ds, nil
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestClone(t *testing.T) {
+	const expected = timeconv.Duration(10 * time.Second)
+	o := &Options{Step: expected}
+	o2 := o.Clone()
+	if o2.Step != expected {
+		t.Errorf("expected %d got %d", expected, o2.Step)
+	}
+	o2.Step = 0
+	if o.Step != expected {
+		t.Error("expected clone to be independent of the original")
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	o := &Options{}
+	if err := yaml.Unmarshal([]byte("{}"), o); err != nil {
+		t.Fatal(err)
+	}
+	if time.Duration(o.Step) != DefaultStep {
+		t.Errorf("expected %s got %s", DefaultStep, time.Duration(o.Step))
+	}
+	if err := yaml.Unmarshal([]byte("step: 10s"), o); err != nil {
+		t.Fatal(err)
+	}
+	if time.Duration(o.Step) != 10*time.Second {
+		t.Errorf("expected %s got %s", 10*time.Second, time.Duration(o.Step))
+	}
+}
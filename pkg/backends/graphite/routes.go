@@ -0,0 +1,128 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphite
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/handlers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/methods"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/paths/matching"
+	po "github.com/trickstercache/trickster/v2/pkg/proxy/paths/options"
+)
+
+func (c *Client) RegisterHandlers(handlers.Lookup) {
+	c.TimeseriesBackend.RegisterHandlers(
+		handlers.Lookup{
+			"health":     http.HandlerFunc(c.HealthHandler),
+			mnRender:     http.HandlerFunc(c.RenderHandler),
+			mnFind:       http.HandlerFunc(c.FindHandler),
+			"proxycache": http.HandlerFunc(c.ObjectProxyCacheHandler),
+			"proxy":      http.HandlerFunc(c.ProxyHandler),
+		},
+	)
+}
+
+// MergeablePaths returns the list of Graphite Paths for which Trickster supports
+// merging multiple documents into a single response
+func MergeablePaths() []string {
+	return []string{"/" + mnRender}
+}
+
+// MergeablePaths returns the list of Graphite Paths for which Trickster supports
+// merging multiple documents into a single response
+func (c *Client) MergeablePaths() []string {
+	return MergeablePaths()
+}
+
+// DefaultPathConfigs returns the default PathConfigs for the given Provider
+func (c *Client) DefaultPathConfigs(o *bo.Options) po.List {
+	var rhts map[string]string
+	if o != nil {
+		rhts = map[string]string{
+			headers.NameCacheControl: fmt.Sprintf("%s=%d", headers.ValueSharedMaxAge, time.Duration(o.TimeseriesTTL)/(1*time.Second)),
+		}
+	}
+	rhinst := map[string]string{
+		headers.NameCacheControl: fmt.Sprintf("%s=%d", headers.ValueSharedMaxAge, 30),
+	}
+	paths := po.List{
+		{
+			Path:            "/" + mnRender,
+			HandlerName:     mnRender,
+			Methods:         methods.GetAndPost(),
+			CacheKeyParams:  []string{upTarget, upFormat},
+			CacheKeyHeaders: []string{},
+			ResponseHeaders: rhts,
+			MatchTypeName:   matching.PathMatchNameExact,
+			MatchType:       matching.PathMatchTypeExact,
+		},
+		{
+			Path:            "/" + mnMetrics + "/" + mnFind,
+			HandlerName:     mnFind,
+			Methods:         methods.GetAndPost(),
+			CacheKeyParams:  []string{upQuery, upFormat, upFrom, upUntil, "wildcards", "jsonp"},
+			CacheKeyHeaders: []string{},
+			ResponseHeaders: rhinst,
+			MatchTypeName:   matching.PathMatchNameExact,
+			MatchType:       matching.PathMatchTypeExact,
+		},
+		{
+			Path:            "/" + mnMetrics + "/" + mnExpand,
+			HandlerName:     "proxycache",
+			Methods:         methods.GetAndPost(),
+			CacheKeyParams:  []string{upQuery, "groupByExpr", "leavesOnly", "jsonp"},
+			CacheKeyHeaders: []string{},
+			ResponseHeaders: rhinst,
+			MatchTypeName:   matching.PathMatchNameExact,
+			MatchType:       matching.PathMatchTypeExact,
+		},
+		{
+			Path:            "/" + mnTags,
+			HandlerName:     "proxycache",
+			Methods:         []string{http.MethodGet},
+			CacheKeyParams:  []string{"*"},
+			CacheKeyHeaders: []string{},
+			ResponseHeaders: rhinst,
+			MatchTypeName:   matching.PathMatchNamePrefix,
+			MatchType:       matching.PathMatchTypePrefix,
+		},
+		{
+			Path:            "/" + mnFunctions,
+			HandlerName:     "proxycache",
+			Methods:         []string{http.MethodGet},
+			CacheKeyParams:  []string{},
+			CacheKeyHeaders: []string{},
+			ResponseHeaders: rhinst,
+			MatchTypeName:   matching.PathMatchNamePrefix,
+			MatchType:       matching.PathMatchTypePrefix,
+		},
+		{
+			Path:          "/",
+			HandlerName:   providers.Proxy,
+			Methods:       methods.GetAndPost(),
+			MatchType:     matching.PathMatchTypePrefix,
+			MatchTypeName: matching.PathMatchNamePrefix,
+		},
+	}
+	return paths
+}
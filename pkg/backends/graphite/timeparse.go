@@ -0,0 +1,223 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphite
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errInvalidTime = errors.New("invalid graphite time value")
+
+// parseTime converts a Graphite from/until URL parameter to time.Time, relative
+// to now, and in now's location. Graphite accepts Unix epochs in seconds,
+// relative offsets like -1h or -24hours, and absolute references like
+// HH:MM_YYYYMMDD, YYYYMMDD, MM/DD/YY, now, today or yesterday, which may be
+// followed by an offset, as in now-1d or midnight+2h.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	in := s
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.NewReplacer("_", "", ",", "", " ", "").Replace(s)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("%w: %q", errInvalidTime, in)
+	}
+	if isDigits(s) {
+		if t, ok := parseYYYYMMDD(s, now.Location()); ok {
+			return t, nil
+		}
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %q", errInvalidTime, in)
+		}
+		return time.Unix(i, 0).In(now.Location()), nil
+	}
+	ref, offset := s, ""
+	if i := strings.IndexAny(s, "+-"); i >= 0 {
+		ref, offset = s[:i], s[i:]
+	}
+	t, err := parseTimeReference(ref, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", errInvalidTime, in)
+	}
+	d, err := parseTimeOffset(offset)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", errInvalidTime, in)
+	}
+	return t.Add(d), nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+// parseYYYYMMDD parses s as a YYYYMMDD date, using the same heuristic as
+// Graphite to distinguish it from a Unix epoch
+func parseYYYYMMDD(s string, loc *time.Location) (time.Time, bool) {
+	if len(s) != 8 {
+		return time.Time{}, false
+	}
+	y, _ := strconv.Atoi(s[:4])
+	m, _ := strconv.Atoi(s[4:6])
+	d, _ := strconv.Atoi(s[6:])
+	if y <= 1900 || m < 1 || m > 12 || d < 1 || d > 31 {
+		return time.Time{}, false
+	}
+	return time.Date(y, time.Month(m), d, 0, 0, 0, 0, loc), true
+}
+
+// parseTimeReference parses the absolute part of a Graphite time value, which
+// is an optional time of day followed by an optional date
+func parseTimeReference(ref string, now time.Time) (time.Time, error) {
+	if ref == "" || ref == "now" {
+		return now, nil
+	}
+	hour, minute := 0, 0
+	switch {
+	case strings.Contains(ref, ":"):
+		i := strings.Index(ref, ":")
+		if i == 0 || len(ref) < i+3 {
+			return time.Time{}, errInvalidTime
+		}
+		var err1, err2 error
+		hour, err1 = strconv.Atoi(ref[:i])
+		minute, err2 = strconv.Atoi(ref[i+1 : i+3])
+		if err1 != nil || err2 != nil || hour > 23 || minute > 59 {
+			return time.Time{}, errInvalidTime
+		}
+		ref = ref[i+3:]
+		switch {
+		case strings.HasPrefix(ref, "am"):
+			ref = ref[2:]
+		case strings.HasPrefix(ref, "pm"):
+			hour = (hour + 12) % 24
+			ref = ref[2:]
+		}
+	case strings.HasPrefix(ref, "noon"):
+		hour, ref = 12, ref[4:]
+	case strings.HasPrefix(ref, "midnight"):
+		ref = ref[8:]
+	case strings.HasPrefix(ref, "teatime"):
+		hour, ref = 16, ref[7:]
+	}
+
+	loc := now.Location()
+	y, m, d := now.Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	switch {
+	case ref == "" || ref == "today":
+	case ref == "yesterday":
+		date = date.AddDate(0, 0, -1)
+	case ref == "tomorrow":
+		date = date.AddDate(0, 0, 1)
+	case strings.Count(ref, "/") == 2:
+		parts := strings.Split(ref, "/")
+		m, err1 := strconv.Atoi(parts[0])
+		d, err2 := strconv.Atoi(parts[1])
+		y, err3 := strconv.Atoi(parts[2])
+		if err1 != nil || err2 != nil || err3 != nil {
+			return time.Time{}, errInvalidTime
+		}
+		if y < 100 {
+			y += 1900
+			if y < 1970 {
+				y += 100
+			}
+		}
+		date = time.Date(y, time.Month(m), d, 0, 0, 0, 0, loc)
+	default:
+		t, ok := parseYYYYMMDD(ref, loc)
+		if !ok {
+			return time.Time{}, errInvalidTime
+		}
+		date = t
+	}
+	return date.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute), nil
+}
+
+// parseTimeOffset parses a signed Graphite offset like -1h, +2days or
+// -1d12hours into a time.Duration
+func parseTimeOffset(offset string) (time.Duration, error) {
+	if offset == "" {
+		return 0, nil
+	}
+	sign := time.Duration(1)
+	switch offset[0] {
+	case '-':
+		sign = -1
+		offset = offset[1:]
+	case '+':
+		offset = offset[1:]
+	}
+	if offset == "" {
+		return 0, errInvalidTime
+	}
+	var out time.Duration
+	for offset != "" {
+		i := 0
+		for i < len(offset) && offset[i] >= '0' && offset[i] <= '9' {
+			i++
+		}
+		if i == 0 {
+			return 0, errInvalidTime
+		}
+		n, _ := strconv.Atoi(offset[:i])
+		offset = offset[i:]
+		j := 0
+		for j < len(offset) && offset[j] >= 'a' && offset[j] <= 'z' {
+			j++
+		}
+		unit, err := unitDuration(offset[:j])
+		if err != nil {
+			return 0, err
+		}
+		offset = offset[j:]
+		out += time.Duration(n) * unit
+	}
+	return sign * out, nil
+}
+
+// unitDuration returns the duration of a Graphite offset unit, which is
+// identified by its prefix, as in Graphite
+func unitDuration(s string) (time.Duration, error) {
+	switch {
+	case s == "":
+		return 0, errInvalidTime
+	case strings.HasPrefix(s, "s"):
+		return time.Second, nil
+	case strings.HasPrefix(s, "min"):
+		return time.Minute, nil
+	case strings.HasPrefix(s, "h"):
+		return time.Hour, nil
+	case strings.HasPrefix(s, "d"):
+		return 24 * time.Hour, nil
+	case strings.HasPrefix(s, "w"):
+		return 7 * 24 * time.Hour, nil
+	case strings.HasPrefix(s, "mon"):
+		return 30 * 24 * time.Hour, nil
+	case strings.HasPrefix(s, "y"):
+		return 365 * 24 * time.Hour, nil
+	}
+	return 0, errInvalidTime
+}
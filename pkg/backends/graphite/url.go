@@ -0,0 +1,40 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphite
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/params"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+// SetExtent will change the upstream request query to use the provided Extent.
+// Graphite excludes the from time and includes the until time, so from is set
+// just before the start of the Extent. Since Trickster consolidates cached
+// series itself, maxDataPoints is not sent upstream.
+func (c *Client) SetExtent(r *http.Request, _ *timeseries.TimeRangeQuery,
+	extent *timeseries.Extent,
+) error {
+	v, _, _ := params.GetRequestValues(r)
+	v.Set(upFrom, strconv.FormatInt(extent.Start.Unix()-1, 10))
+	v.Set(upUntil, strconv.FormatInt(extent.End.Unix(), 10))
+	v.Del(upMaxDataPoints)
+	params.SetRequestValues(r, v)
+	return nil
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphite
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func TestSetExtent(t *testing.T) {
+	c := &Client{}
+	r := httptest.NewRequest(http.MethodGet,
+		"http://graphite/render?target=x&format=json&from=-1h&maxDataPoints=100", nil)
+	e := &timeseries.Extent{Start: time.Unix(1700000000, 0), End: time.Unix(1700003600, 0)}
+	if err := c.SetExtent(r, nil, e); err != nil {
+		t.Fatal(err)
+	}
+	qp := r.URL.Query()
+	if v := qp.Get(upFrom); v != "1699999999" {
+		t.Errorf("expected %s got %s", "1699999999", v)
+	}
+	if v := qp.Get(upUntil); v != "1700003600" {
+		t.Errorf("expected %s got %s", "1700003600", v)
+	}
+	if qp.Has(upMaxDataPoints) {
+		t.Errorf("unexpected param %s in %s", upMaxDataPoints, r.URL.RawQuery)
+	}
+	if v := qp.Get(upTarget); v != "x" {
+		t.Errorf("expected %s got %s", "x", v)
+	}
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package apiv3 provides support for the InfluxDB 3 Query API, which accepts
+// SQL and InfluxQL statements at /api/v3/query_sql and /api/v3/query_influxql
+package apiv3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/iofmt"
+	te "github.com/trickstercache/trickster/v2/pkg/errors"
+	pe "github.com/trickstercache/trickster/v2/pkg/proxy/errors"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+)
+
+// Upstream Endpoints
+const (
+	PathQuerySQL      = "/api/v3/query_sql"
+	PathQueryInfluxQL = "/api/v3/query_influxql"
+)
+
+// Common Request Parameter Names
+const (
+	ParamDB     = "db"
+	ParamQuery  = "q"
+	ParamFormat = "format"
+	ParamParams = "params"
+)
+
+// upstreamFormat is the output format requested from InfluxDB when a query
+// is delta cached, regardless of the output format requested by the client
+const upstreamFormat = "jsonl"
+
+var (
+	// ErrUnsupportedFormat indicates that the requested output format is unknown
+	ErrUnsupportedFormat = errors.New("unsupported InfluxDB 3 output format")
+	// ErrNonTabularFormat indicates that the requested output format cannot be
+	// modeled as a timeseries, so the query can't be delta cached
+	ErrNonTabularFormat = errors.New("InfluxDB 3 output format cannot be delta cached")
+)
+
+// Detect returns the query language Format of an InfluxDB 3 Query API request
+// based on its path, or iofmt.Unknown if the path is not a Query API path
+func Detect(r *http.Request) iofmt.Format {
+	if r == nil || r.URL == nil {
+		return iofmt.Unknown
+	}
+	switch {
+	case strings.HasSuffix(r.URL.Path, PathQuerySQL):
+		return iofmt.V3SQL
+	case strings.HasSuffix(r.URL.Path, PathQueryInfluxQL):
+		return iofmt.V3Influxql
+	}
+	return iofmt.Unknown
+}
+
+// queryRequest is an InfluxDB 3 Query API request, provided either as URL
+// parameters of a GET request or as a JSON document in the body of a POST
+type queryRequest struct {
+	db     string
+	query  string
+	format string
+	params string
+	// document is the decoded body of a POST request
+	document map[string]json.RawMessage
+}
+
+// Statement returns the statement provided in an InfluxDB 3 Query API request,
+// or an empty string if the request is invalid
+func Statement(r *http.Request) string {
+	qr, err := parseRequest(r)
+	if err != nil {
+		return ""
+	}
+	return qr.query
+}
+
+func parseRequest(r *http.Request) (*queryRequest, error) {
+	qr := &queryRequest{}
+	switch r.Method {
+	case http.MethodGet:
+		qp := r.URL.Query()
+		qr.db = qp.Get(ParamDB)
+		qr.query = qp.Get(ParamQuery)
+		qr.format = qp.Get(ParamFormat)
+		qr.params = qp.Get(ParamParams)
+	case http.MethodPost:
+		b, err := request.GetBody(r)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &qr.document); err != nil {
+			return nil, te.ErrBadRequest
+		}
+		qr.db = qr.documentString(ParamDB)
+		qr.query = qr.documentString(ParamQuery)
+		qr.format = qr.documentString(ParamFormat)
+		if v, ok := qr.document[ParamParams]; ok && string(v) != "null" {
+			qr.params = compactJSON(v)
+		}
+	default:
+		return nil, te.ErrInvalidMethod
+	}
+	if qr.query == "" {
+		return nil, pe.MissingURLParam(ParamQuery)
+	}
+	return qr, nil
+}
+
+func (qr *queryRequest) documentString(key string) string {
+	v, ok := qr.document[key]
+	if !ok {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(v, &s); err != nil {
+		return ""
+	}
+	return s
+}
+
+func compactJSON(v json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, v); err != nil {
+		return string(v)
+	}
+	return buf.String()
+}
+
+// cacheKeyElements returns the request values that identify the query in the
+// cache, using the provided statement and output format
+func (qr *queryRequest) cacheKeyElements(statement, format string) map[string]string {
+	out := map[string]string{
+		ParamDB:     qr.db,
+		ParamQuery:  statement,
+		ParamFormat: format,
+	}
+	if qr.params != "" {
+		out[ParamParams] = qr.params
+	}
+	return out
+}
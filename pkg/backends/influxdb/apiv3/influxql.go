@@ -0,0 +1,188 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiv3
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+
+	"github.com/influxdata/influxql"
+)
+
+// measurementColumn is the column in which InfluxDB 3 returns the measurement
+// name of each row of an InfluxQL query result
+const measurementColumn = "iox::measurement"
+
+// timeColumn is the column in which InfluxDB 3 returns the timestamp of each
+// row of an InfluxQL query result
+const timeColumn = "time"
+
+var (
+	errUnsupportedInfluxQL = errors.New("unsupported InfluxQL statement")
+	errNoTimeBucket        = errors.New("no GROUP BY time interval found")
+	errUnsupportedFill     = errors.New("fill option cannot be delta cached")
+	errUnsupportedGroupBy  = errors.New("unsupported GROUP BY dimension")
+	errUnsupportedOrder    = errors.New("unsupported ORDER BY clause")
+	errUnsupportedLimit    = errors.New("limit queries are not supported")
+	errNoLowerBound        = errors.New("no lower bound found in time range query")
+	errTimeDisjunction     = errors.New("time condition cannot be part of a disjunction")
+)
+
+type influxQLAnalyzer struct{}
+
+// influxQLDialect analyzes the InfluxQL statements of InfluxDB 3 Query API
+// requests. Like InfluxQL queries to InfluxDB 1.x, the time range of a query
+// is not required to align with its GROUP BY interval, since it is replaced
+// entirely by SetTimeRange when rendering an extent.
+var influxQLDialect sqlanalyzer.DialectAnalyzer = influxQLAnalyzer{}
+
+func (influxQLAnalyzer) Analyze(statement string, now time.Time) sqlanalyzer.Analysis {
+	q, err := influxql.ParseQuery(statement)
+	if err != nil {
+		mode := sqlanalyzer.CacheModeNone
+		if strings.Contains(strings.ToLower(statement), "select ") {
+			mode = sqlanalyzer.CacheModeObject
+		}
+		return sqlanalyzer.Analysis{Mode: mode, Reason: sqlanalyzer.ReasonInvalidSQL, Err: err}
+	}
+	var sel *influxql.SelectStatement
+	if len(q.Statements) == 1 {
+		sel, _ = q.Statements[0].(*influxql.SelectStatement)
+	}
+	if sel == nil || sel.Target != nil {
+		return sqlanalyzer.Analysis{
+			Mode: sqlanalyzer.CacheModeNone, Reason: sqlanalyzer.ReasonUnsupportedStatement,
+			Err: errUnsupportedInfluxQL,
+		}
+	}
+	if sel.Limit > 0 || sel.Offset > 0 || sel.SLimit > 0 || sel.SOffset > 0 {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedLimit, errUnsupportedLimit)
+	}
+	step, err := sel.GroupByInterval()
+	if err == nil && step <= 0 {
+		err = errNoTimeBucket
+	}
+	if err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedBucket, err)
+	}
+	phase, err := sel.GroupByOffset()
+	if err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedBucket, err)
+	}
+	// previous and linear fills depend on values outside of the bucket
+	if sel.Fill == influxql.PreviousFill || sel.Fill == influxql.LinearFill {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedBucket, errUnsupportedFill)
+	}
+	groups := []string{measurementColumn}
+	for _, d := range sel.Dimensions {
+		switch e := d.Expr.(type) {
+		case *influxql.Call:
+			continue
+		case *influxql.VarRef:
+			groups = append(groups, e.Val)
+		default:
+			return objectAnalysis(sqlanalyzer.ReasonUnsupportedGrouping, errUnsupportedGroupBy)
+		}
+	}
+	if len(sel.SortFields) > 0 && !sel.SortFields[0].Ascending {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedStatement, errUnsupportedOrder)
+	}
+	if sel.Condition == nil {
+		return objectAnalysis(sqlanalyzer.ReasonNotTimeRange, errNoLowerBound)
+	}
+	if hasTimeDisjunction(sel.Condition) {
+		return objectAnalysis(sqlanalyzer.ReasonUnsafePredicate, errTimeDisjunction)
+	}
+	_, tr, err := influxql.ConditionExpr(sel.Condition, &influxql.NowValuer{Now: now})
+	if err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsafePredicate, err)
+	}
+	if tr.Min.IsZero() {
+		return objectAnalysis(sqlanalyzer.ReasonNotTimeRange, errNoLowerBound)
+	}
+
+	base := sel.Clone()
+	// this sets a zero time range to normalize the statement for cache keying
+	if err := base.SetTimeRange(time.Time{}, time.Time{}); err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsafePredicate, err)
+	}
+	plan := &sqlanalyzer.QueryPlan{
+		CanonicalSQL: base.String(),
+		TimeColumn:   timeColumn,
+		OutputColumn: timeColumn,
+		Step:         step,
+		Phase:        phase,
+		OutputUnit:   timeseries.DateTimeRFC3339Nano,
+		InputUnit:    timeseries.DateTimeRFC3339Nano,
+		LowerBound:   &sqlanalyzer.Bound{Value: tr.Min, Inclusive: true},
+		GroupColumns: groups,
+		Renderer:     &influxQLRenderer{statement: base, step: step},
+	}
+	if !tr.Max.IsZero() {
+		plan.UpperBound = &sqlanalyzer.Bound{Value: tr.Max, Inclusive: true}
+	}
+	return sqlanalyzer.Analysis{
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Plan: plan,
+	}
+}
+
+// hasTimeDisjunction reports whether a time condition is part of a disjunction,
+// which can't be replaced by a single time range
+func hasTimeDisjunction(expr influxql.Expr) bool {
+	var found bool
+	influxql.WalkFunc(expr, func(n influxql.Node) {
+		if b, ok := n.(*influxql.BinaryExpr); ok && b.Op == influxql.OR && referencesTime(b) {
+			found = true
+		}
+	})
+	return found
+}
+
+func referencesTime(expr influxql.Expr) bool {
+	var found bool
+	influxql.WalkFunc(expr, func(n influxql.Node) {
+		if ref, ok := n.(*influxql.VarRef); ok && strings.EqualFold(ref.Val, timeColumn) {
+			found = true
+		}
+	})
+	return found
+}
+
+func objectAnalysis(reason sqlanalyzer.AnalysisReason, err error) sqlanalyzer.Analysis {
+	return sqlanalyzer.Analysis{Mode: sqlanalyzer.CacheModeObject, Reason: reason, Err: err}
+}
+
+// influxQLRenderer renders an extent into a copy of a normalized statement,
+// so it is safe for concurrent use
+type influxQLRenderer struct {
+	statement *influxql.SelectStatement
+	step      time.Duration
+}
+
+func (r *influxQLRenderer) RenderExtent(extent timeseries.Extent) (string, error) {
+	sel := r.statement.Clone()
+	// since setting the time range results in a clause of '>= start AND < end',
+	// the size of 1 step is added onto the end time so that it is included
+	if err := sel.SetTimeRange(extent.Start, extent.End.Add(r.step)); err != nil {
+		return "", err
+	}
+	return sel.String(), nil
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiv3
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+var testNow = time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)
+
+func TestInfluxQLAnalyze(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		mode   sqlanalyzer.CacheMode
+		reason sqlanalyzer.AnalysisReason
+		phase  time.Duration
+		groups []string
+	}{
+		{
+			name: "grouped by time and tag", query: testInfluxQL,
+			mode: sqlanalyzer.CacheModeDelta, reason: sqlanalyzer.ReasonDeltaCacheable,
+			groups: []string{measurementColumn, "host"},
+		},
+		{
+			name: "relative time range with offset",
+			query: "SELECT max(usage) FROM cpu WHERE time > now() - 1h " +
+				"GROUP BY time(1h, 15m) fill(none)",
+			mode: sqlanalyzer.CacheModeDelta, reason: sqlanalyzer.ReasonDeltaCacheable,
+			phase: 15 * time.Minute, groups: []string{measurementColumn},
+		},
+		{
+			name:  "no time bucket",
+			query: "SELECT usage FROM cpu WHERE time > now() - 1h",
+			mode:  sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedBucket,
+		},
+		{
+			name:  "previous fill",
+			query: "SELECT mean(usage) FROM cpu WHERE time > now() - 1h GROUP BY time(1m) fill(previous)",
+			mode:  sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedBucket,
+		},
+		{
+			name:  "wildcard dimension",
+			query: "SELECT mean(usage) FROM cpu WHERE time > now() - 1h GROUP BY time(1m), *",
+			mode:  sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedGrouping,
+		},
+		{
+			name:  "descending order",
+			query: "SELECT mean(usage) FROM cpu WHERE time > now() - 1h GROUP BY time(1m) ORDER BY time DESC",
+			mode:  sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedStatement,
+		},
+		{
+			name:  "limit",
+			query: "SELECT mean(usage) FROM cpu WHERE time > now() - 1h GROUP BY time(1m) LIMIT 5",
+			mode:  sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedLimit,
+		},
+		{
+			name:  "no lower bound",
+			query: "SELECT mean(usage) FROM cpu WHERE host = 'a' GROUP BY time(1m)",
+			mode:  sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonNotTimeRange,
+		},
+		{
+			name:  "time disjunction",
+			query: "SELECT mean(usage) FROM cpu WHERE time > now() - 1h OR host = 'a' GROUP BY time(1m)",
+			mode:  sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsafePredicate,
+		},
+		{
+			name:  "invalid select",
+			query: "SELECT mean(usage FROM cpu",
+			mode:  sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonInvalidSQL,
+		},
+		{
+			name:  "show statement",
+			query: "SHOW MEASUREMENTS",
+			mode:  sqlanalyzer.CacheModeNone, reason: sqlanalyzer.ReasonUnsupportedStatement,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := influxQLDialect.Analyze(test.query, testNow)
+			if a.Mode != test.mode || a.Reason != test.reason {
+				t.Fatalf("expected %s/%s got %s/%s (%v)", test.mode, test.reason,
+					a.Mode, a.Reason, a.Err)
+			}
+			if a.Mode != sqlanalyzer.CacheModeDelta {
+				if a.Err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if a.Plan.Phase != test.phase {
+				t.Errorf("expected phase %s got %s", test.phase, a.Plan.Phase)
+			}
+			if strings.Join(a.Plan.GroupColumns, ",") != strings.Join(test.groups, ",") {
+				t.Errorf("expected groups %v got %v", test.groups, a.Plan.GroupColumns)
+			}
+		})
+	}
+}
+
+func TestInfluxQLCanonicalIdentity(t *testing.T) {
+	a1 := influxQLDialect.Analyze(testInfluxQL, testNow)
+	a2 := influxQLDialect.Analyze(strings.ReplaceAll(testInfluxQL, "01:00:00", "02:00:00"), testNow)
+	if a1.Plan == nil || a2.Plan == nil {
+		t.Fatal("expected plans")
+	}
+	if a1.Plan.CanonicalSQL != a2.Plan.CanonicalSQL {
+		t.Errorf("expected identical canonical statements:\n%s\n%s",
+			a1.Plan.CanonicalSQL, a2.Plan.CanonicalSQL)
+	}
+}
+
+func TestInfluxQLRenderExtent(t *testing.T) {
+	a := influxQLDialect.Analyze(testInfluxQL, testNow)
+	if a.Plan == nil {
+		t.Fatal(a.Err)
+	}
+	const workers = 16
+	out := make([]string, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Go(func() {
+			start := time.Date(2024, 1, 1, i, 0, 0, 0, time.UTC)
+			out[i], _ = a.Plan.RenderExtent(timeseries.Extent{
+				Start: start, End: start.Add(59 * time.Minute),
+			})
+		})
+	}
+	wg.Wait()
+	for i := range workers {
+		start := time.Date(2024, 1, 1, i, 0, 0, 0, time.UTC)
+		expected := "time >= '" + start.Format(time.RFC3339Nano) + "' AND time < '" +
+			start.Add(time.Hour).Format(time.RFC3339Nano) + "'"
+		if !strings.Contains(out[i], expected) {
+			t.Errorf("expected %s in %s", expected, out[i])
+		}
+		if !strings.HasPrefix(out[i], `SELECT mean(usage) FROM cpu WHERE`) ||
+			!strings.HasSuffix(out[i], "GROUP BY time(1m), host") {
+			t.Errorf("unexpected statement %s", out[i])
+		}
+	}
+}
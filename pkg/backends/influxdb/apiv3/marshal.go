@@ -0,0 +1,236 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiv3
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/iofmt"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/epoch"
+)
+
+// Content Types of the InfluxDB 3 tabular output formats
+const (
+	contentTypeJSONL = "application/jsonl"
+	contentTypeCSV   = "text/csv"
+)
+
+// MarshalTimeseries converts a Timeseries into an InfluxDB 3 query result
+func MarshalTimeseries(ts timeseries.Timeseries, rlo *timeseries.RequestOptions,
+	status int,
+) ([]byte, error) {
+	w := new(bytes.Buffer)
+	err := MarshalTimeseriesWriter(ts, rlo, status, w)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// MarshalTimeseriesWriter converts a Timeseries into an InfluxDB 3 query result
+// via an io.Writer, in the output format of the request options
+func MarshalTimeseriesWriter(ts timeseries.Timeseries,
+	rlo *timeseries.RequestOptions, status int, w io.Writer,
+) error {
+	ds, ok := ts.(*dataset.DataSet)
+	if !ok || ds == nil || rlo == nil {
+		return timeseries.ErrUnknownFormat
+	}
+	f := iofmt.Format(rlo.OutputFormat)
+	if !f.IsV3Tabular() {
+		return timeseries.ErrUnknownFormat
+	}
+	columns, _, _, _ := ds.FieldDefinitions()
+	rows := outputRows(ds, f)
+	switch f.V3Output() {
+	case iofmt.V3OutputJSONL:
+		setContentType(w, contentTypeJSONL, status)
+		return writeJSON(w, columns, rows, false)
+	case iofmt.V3OutputCSV:
+		setContentType(w, contentTypeCSV, status)
+		return writeCSV(w, columns, rows)
+	}
+	setContentType(w, headers.ValueApplicationJSON, status)
+	return writeJSON(w, columns, rows, true)
+}
+
+func setContentType(w io.Writer, contentType string, status int) {
+	if hw, ok := w.(http.ResponseWriter); ok {
+		hw.Header().Set(headers.NameContentType, contentType)
+		if status > 0 {
+			hw.WriteHeader(status)
+		}
+	}
+}
+
+// outputRows returns the rows of the DataSet in the order InfluxDB 3 returns
+// them: ordered by time for SQL, and by series and then time for InfluxQL
+func outputRows(ds *dataset.DataSet, f iofmt.Format) []dataset.Row {
+	var series dataset.SeriesList
+	for _, r := range ds.Results {
+		for _, s := range r.SeriesList {
+			if s != nil {
+				series = append(series, s)
+			}
+		}
+	}
+	if f.IsV3InfluxQL() {
+		tags := ds.TimeRangeQuery.TagFieldDefintions
+		slices.SortStableFunc(series, func(a, b *dataset.Series) int {
+			for _, fd := range tags {
+				if c := strings.Compare(a.Header.Tags[fd.Name],
+					b.Header.Tags[fd.Name]); c != 0 {
+					return c
+				}
+			}
+			return 0
+		})
+	}
+	rows := series.Rows()
+	if f.IsV3SQL() {
+		dataset.SortRowsByTime(rows)
+	}
+	return rows
+}
+
+// cells returns the formatted cells of the row for each column, and whether
+// each cell is a string value. Null cells are empty.
+func cells(r dataset.Row, columns timeseries.FieldDefinitions) ([]string, []bool) {
+	out := make([]string, len(columns))
+	quoted := make([]bool, len(columns))
+	for i, fd := range columns {
+		if fd.Role == timeseries.RoleTimestamp {
+			out[i] = formatTimestamp(r.Point.Epoch)
+			quoted[i] = true
+			continue
+		}
+		out[i], quoted[i] = formatValue(r.Value(fd))
+	}
+	return out, quoted
+}
+
+func formatTimestamp(e epoch.Epoch) string {
+	return time.Unix(0, int64(e)).UTC().Format(timestampLayout)
+}
+
+// formatValue returns the JSON representation of a value, or an empty string
+// if it is null, and whether the representation is a string
+func formatValue(v any) (string, bool) {
+	switch t := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return t, true
+	case int64:
+		return strconv.FormatInt(t, 10), false
+	case uint64:
+		return strconv.FormatUint(t, 10), false
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return "", false
+		}
+		s := strconv.FormatFloat(t, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s, false
+	case bool:
+		return strconv.FormatBool(t), false
+	}
+	return "", false
+}
+
+// writeJSON writes the rows as JSON objects, omitting null values, either
+// as a JSON array or as JSONL
+func writeJSON(w io.Writer, columns timeseries.FieldDefinitions,
+	rows []dataset.Row, array bool,
+) error {
+	bw := bufio.NewWriter(w)
+	names := make([][]byte, len(columns))
+	for i, fd := range columns {
+		names[i], _ = json.Marshal(fd.Name)
+	}
+	if array {
+		bw.WriteByte('[')
+	}
+	for i, row := range rows {
+		if array && i > 0 {
+			bw.WriteByte(',')
+		}
+		cells, quoted := cells(row, columns)
+		bw.WriteByte('{')
+		var sep bool
+		for j, cell := range cells {
+			if cell == "" && !quoted[j] {
+				continue
+			}
+			if sep {
+				bw.WriteByte(',')
+			}
+			bw.Write(names[j])
+			bw.WriteByte(':')
+			if quoted[j] {
+				b, _ := json.Marshal(cell)
+				bw.Write(b)
+			} else {
+				bw.WriteString(cell)
+			}
+			sep = true
+		}
+		bw.WriteByte('}')
+		if !array {
+			bw.WriteByte('\n')
+		}
+	}
+	if array {
+		bw.WriteByte(']')
+	}
+	return bw.Flush()
+}
+
+// writeCSV writes the rows as CSV with a header row of column names
+func writeCSV(w io.Writer, columns timeseries.FieldDefinitions, rows []dataset.Row) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, fd := range columns {
+		header[i] = fd.Name
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		cells, _ := cells(row, columns)
+		if err := cw.Write(cells); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiv3
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/iofmt"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func TestMarshalTimeseries(t *testing.T) {
+	ts, err := UnmarshalTimeseries([]byte(testJSONL), testTRQ())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		format      iofmt.Format
+		contentType string
+		expected    string
+	}{
+		{
+			format:      iofmt.V3SQL | iofmt.V3OutputJSONL,
+			contentType: contentTypeJSONL,
+			expected: `{"_time":"2024-01-01T00:00:00","host":"a","avg(cpu.usage)":1.5,"n":1}` + "\n" +
+				`{"_time":"2024-01-01T00:00:00","host":"b","avg(cpu.usage)":2.0}` + "\n" +
+				`{"_time":"2024-01-01T00:01:00","host":"a","avg(cpu.usage)":3.25,"n":2}` + "\n" +
+				`{"_time":"2024-01-01T00:01:00","host":"b","n":3}` + "\n",
+		},
+		{
+			format:      iofmt.V3SQL | iofmt.V3OutputJSON,
+			contentType: headers.ValueApplicationJSON,
+			expected: `[{"_time":"2024-01-01T00:00:00","host":"a","avg(cpu.usage)":1.5,"n":1},` +
+				`{"_time":"2024-01-01T00:00:00","host":"b","avg(cpu.usage)":2.0},` +
+				`{"_time":"2024-01-01T00:01:00","host":"a","avg(cpu.usage)":3.25,"n":2},` +
+				`{"_time":"2024-01-01T00:01:00","host":"b","n":3}]`,
+		},
+		{
+			format:      iofmt.V3SQL | iofmt.V3OutputCSV,
+			contentType: contentTypeCSV,
+			expected: "_time,host,avg(cpu.usage),n\n" +
+				"2024-01-01T00:00:00,a,1.5,1\n" +
+				"2024-01-01T00:00:00,b,2.0,\n" +
+				"2024-01-01T00:01:00,a,3.25,2\n" +
+				"2024-01-01T00:01:00,b,,3\n",
+		},
+		{
+			format:      iofmt.V3Influxql | iofmt.V3OutputCSV,
+			contentType: contentTypeCSV,
+			expected: "_time,host,avg(cpu.usage),n\n" +
+				"2024-01-01T00:00:00,a,1.5,1\n" +
+				"2024-01-01T00:01:00,a,3.25,2\n" +
+				"2024-01-01T00:00:00,b,2.0,\n" +
+				"2024-01-01T00:01:00,b,,3\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.format.V3OutputName(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			rlo := &timeseries.RequestOptions{OutputFormat: byte(test.format)}
+			if err := MarshalTimeseriesWriter(ts, rlo, http.StatusOK, w); err != nil {
+				t.Fatal(err)
+			}
+			if ct := w.Header().Get(headers.NameContentType); ct != test.contentType {
+				t.Errorf("expected %s got %s", test.contentType, ct)
+			}
+			if out := w.Body.String(); out != test.expected {
+				t.Errorf("expected\n%s\ngot\n%s", test.expected, out)
+			}
+		})
+	}
+}
+
+func TestMarshalTimeseriesUnsupported(t *testing.T) {
+	ts, err := UnmarshalTimeseries([]byte(testJSONL), testTRQ())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []iofmt.Format{iofmt.V3SQL | iofmt.V3OutputParquet, iofmt.InfluxqlGet} {
+		rlo := &timeseries.RequestOptions{OutputFormat: byte(f)}
+		if _, err := MarshalTimeseries(ts, rlo, http.StatusOK); err != timeseries.ErrUnknownFormat {
+			t.Errorf("expected %v got %v", timeseries.ErrUnknownFormat, err)
+		}
+	}
+	if _, err := MarshalTimeseries(nil, &timeseries.RequestOptions{}, 200); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiv3
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/iofmt"
+	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/sql"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/urls"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+// ParseTimeRangeQuery parses the key parts of a TimeRangeQuery from an inbound
+// InfluxDB 3 Query API request. observe, when not nil, is called with the
+// cache eligibility analysis of the statement. A SELECT that can't be delta
+// cached remains eligible for the object proxy cache.
+func ParseTimeRangeQuery(r *http.Request, f iofmt.Format,
+	observe func(sqlanalyzer.Analysis),
+) (*timeseries.TimeRangeQuery, *timeseries.RequestOptions, bool, error) {
+	if r == nil || !f.IsV3() {
+		return nil, nil, false, iofmt.ErrSupportedQueryLanguage
+	}
+	qr, err := parseRequest(r)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	output, ok := iofmt.V3OutputFormat(qr.format)
+	if !ok {
+		return nil, nil, false, ErrUnsupportedFormat
+	}
+	dialect := sql.Analyzer
+	if f.IsV3InfluxQL() {
+		dialect = influxQLDialect
+	}
+	now := time.Now()
+	analysis := dialect.Analyze(qr.query, now)
+	if observe != nil {
+		observe(analysis)
+	}
+
+	trq := &timeseries.TimeRangeQuery{
+		Statement:        qr.query,
+		CacheKeyElements: qr.cacheKeyElements(qr.query, qr.format),
+	}
+	if r.Method == http.MethodPost {
+		trq.OriginalBody, _ = request.GetBody(r)
+	}
+	if analysis.Mode != sqlanalyzer.CacheModeDelta || analysis.Plan == nil {
+		if analysis.Mode < sqlanalyzer.CacheModeObject {
+			return nil, nil, false, analysis.Err
+		}
+		return trq, nil, true, analysis.Err
+	}
+	if !(f | output).IsV3Tabular() {
+		return trq, nil, true, ErrNonTabularFormat
+	}
+
+	plan := analysis.Plan
+	trq.Statement = plan.CanonicalSQL
+	// delta cached queries always request JSONL from the upstream, so the
+	// cached results are shared by each of the tabular output formats
+	trq.CacheKeyElements = qr.cacheKeyElements(plan.CanonicalSQL, upstreamFormat)
+	trq.Step = plan.Step
+	trq.StepNS = plan.Step.Nanoseconds()
+	trq.Phase = plan.Phase
+	trq.Extent.Start = plan.LowerBound.Value
+	if !plan.LowerBound.Inclusive {
+		trq.Extent.Start = trq.Extent.Start.Add(plan.Step)
+	}
+	if plan.UpperBound == nil {
+		trq.Extent.End = now
+	} else {
+		trq.Extent.End = plan.UpperBound.Value
+		if !plan.UpperBound.Inclusive {
+			trq.Extent.End = trq.Extent.End.Add(-plan.Step)
+		}
+	}
+	trq.TimestampDefinition = timeseries.FieldDefinition{
+		Name:          plan.OutputColumn,
+		DataType:      plan.OutputUnit,
+		Role:          timeseries.RoleTimestamp,
+		ProviderData1: byte(plan.InputUnit),
+	}
+	trq.TagFieldDefintions = make(timeseries.FieldDefinitions, len(plan.GroupColumns))
+	for i, name := range plan.GroupColumns {
+		trq.TagFieldDefintions[i] = timeseries.FieldDefinition{Name: name, Role: timeseries.RoleTag}
+	}
+	trq.ParsedQuery = plan
+	trq.ExtractBackfillTolerance(qr.query)
+	trq.TemplateURL = urls.Clone(r.URL)
+	if r.Method == http.MethodGet {
+		// Swap in the Tokenized Query in the Url Params
+		qp := r.URL.Query()
+		qp.Set(ParamQuery, plan.CanonicalSQL)
+		qp.Set(ParamFormat, upstreamFormat)
+		trq.TemplateURL.RawQuery = qp.Encode()
+	}
+
+	rlo := &timeseries.RequestOptions{
+		OutputFormat:           byte(f | output),
+		BaseTimestampFieldName: plan.TimeColumn,
+	}
+	rlo.ExtractFastForwardDisabled(qr.query)
+	return trq, rlo, true, nil
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiv3
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/iofmt"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+)
+
+const (
+	testSQL = "SELECT date_bin(INTERVAL '1 minute', time) AS _time, host, avg(usage) " +
+		"FROM cpu WHERE time >= '2024-01-01T00:00:00Z' AND time < '2024-01-01T01:00:00Z' " +
+		"GROUP BY 1, host ORDER BY 1"
+	testInfluxQL = `SELECT mean("usage") FROM "cpu" WHERE time >= '2024-01-01T00:00:00Z' ` +
+		`AND time < '2024-01-01T01:00:00Z' GROUP BY time(1m), "host"`
+)
+
+func newGetRequest(path string, values url.Values) *http.Request {
+	return httptest.NewRequest(http.MethodGet, "http://0/"+strings.TrimPrefix(path, "/")+
+		"?"+values.Encode(), nil)
+}
+
+func newPostRequest(path, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "http://0"+path, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected iofmt.Format
+	}{
+		{PathQuerySQL, iofmt.V3SQL},
+		{"/prefix" + PathQueryInfluxQL, iofmt.V3Influxql},
+		{"/query", iofmt.Unknown},
+		{"/api/v2/query", iofmt.Unknown},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			if f := Detect(newGetRequest(test.path, nil)); f != test.expected {
+				t.Errorf("expected %d got %d", test.expected, f)
+			}
+		})
+	}
+	if f := Detect(nil); f != iofmt.Unknown {
+		t.Errorf("expected %d got %d", iofmt.Unknown, f)
+	}
+}
+
+func TestStatement(t *testing.T) {
+	r := newPostRequest(PathQuerySQL, `{"db":"test","q":"SELECT 1"}`)
+	if s := Statement(r); s != "SELECT 1" {
+		t.Errorf("expected SELECT 1 got %s", s)
+	}
+	r = newPostRequest(PathQuerySQL, `not json`)
+	if s := Statement(r); s != "" {
+		t.Errorf("expected empty statement got %s", s)
+	}
+}
+
+func TestParseTimeRangeQuerySQL(t *testing.T) {
+	var observed sqlanalyzer.Analysis
+	r := newGetRequest(PathQuerySQL, url.Values{ParamDB: {"test"}, ParamQuery: {testSQL},
+		ParamFormat: {"csv"}})
+	trq, rlo, canOPC, err := ParseTimeRangeQuery(r, iofmt.V3SQL,
+		func(a sqlanalyzer.Analysis) { observed = a })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !canOPC {
+		t.Error("expected object cache eligibility")
+	}
+	if observed.Mode != sqlanalyzer.CacheModeDelta {
+		t.Errorf("expected observed delta analysis got %s", observed.Mode)
+	}
+	if trq.Step != time.Minute {
+		t.Errorf("expected %s got %s", time.Minute, trq.Step)
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !trq.Extent.Start.Equal(start) || !trq.Extent.End.Equal(start.Add(59*time.Minute)) {
+		t.Errorf("unexpected extent %s", trq.Extent)
+	}
+	if trq.TimestampDefinition.Name != "_time" {
+		t.Errorf("expected _time got %s", trq.TimestampDefinition.Name)
+	}
+	if len(trq.TagFieldDefintions) != 1 || trq.TagFieldDefintions[0].Name != "host" {
+		t.Errorf("unexpected tags %v", trq.TagFieldDefintions)
+	}
+	if trq.CacheKeyElements[ParamFormat] != upstreamFormat ||
+		trq.CacheKeyElements[ParamQuery] != trq.Statement ||
+		trq.CacheKeyElements[ParamDB] != "test" {
+		t.Errorf("unexpected cache key elements %v", trq.CacheKeyElements)
+	}
+	qp := trq.TemplateURL.Query()
+	if qp.Get(ParamQuery) != trq.Statement || qp.Get(ParamFormat) != upstreamFormat {
+		t.Errorf("unexpected template url %s", trq.TemplateURL)
+	}
+	if f := iofmt.Format(rlo.OutputFormat); !f.IsV3SQL() || f.V3Output() != iofmt.V3OutputCSV {
+		t.Errorf("unexpected output format %d", rlo.OutputFormat)
+	}
+}
+
+func TestParseTimeRangeQueryInfluxQL(t *testing.T) {
+	r := newPostRequest(PathQueryInfluxQL, `{"db": "test", "q": "SELECT mean(usage) FROM cpu `+
+		`WHERE time >= '2024-01-01T00:00:30Z' AND time <= '2024-01-01T01:00:00Z' `+
+		`GROUP BY time(1m), host", "params": {"a": 1}}`)
+	trq, rlo, _, err := ParseTimeRangeQuery(r, iofmt.V3Influxql, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 30, 0, time.UTC)
+	if !trq.Extent.Start.Equal(start) || !trq.Extent.End.Equal(start.Add(59*time.Minute+30*time.Second)) {
+		t.Errorf("unexpected extent %s", trq.Extent)
+	}
+	if len(trq.TagFieldDefintions) != 2 || trq.TagFieldDefintions[0].Name != measurementColumn {
+		t.Errorf("unexpected tags %v", trq.TagFieldDefintions)
+	}
+	if trq.CacheKeyElements[ParamParams] != `{"a":1}` {
+		t.Errorf("unexpected params key element %s", trq.CacheKeyElements[ParamParams])
+	}
+	if len(trq.OriginalBody) == 0 {
+		t.Error("expected original body")
+	}
+	if f := iofmt.Format(rlo.OutputFormat); !f.IsV3InfluxQL() || f.V3Output() != iofmt.V3OutputJSON {
+		t.Errorf("unexpected output format %d", rlo.OutputFormat)
+	}
+}
+
+func TestParseTimeRangeQueryObjectCache(t *testing.T) {
+	tests := []struct {
+		name   string
+		r      *http.Request
+		f      iofmt.Format
+		canOPC bool
+		err    error
+	}{
+		{
+			name: "parquet output",
+			r: newGetRequest(PathQuerySQL, url.Values{ParamQuery: {testSQL},
+				ParamFormat: {"parquet"}}),
+			f: iofmt.V3SQL, canOPC: true, err: ErrNonTabularFormat,
+		},
+		{
+			name: "not a time range query",
+			r:    newGetRequest(PathQuerySQL, url.Values{ParamQuery: {"SELECT * FROM cpu"}}),
+			f:    iofmt.V3SQL, canOPC: true,
+		},
+		{
+			name: "show statement",
+			r:    newGetRequest(PathQueryInfluxQL, url.Values{ParamQuery: {"SHOW DATABASES"}}),
+			f:    iofmt.V3Influxql,
+		},
+		{
+			name: "unknown output",
+			r: newGetRequest(PathQuerySQL, url.Values{ParamQuery: {testSQL},
+				ParamFormat: {"xml"}}),
+			f: iofmt.V3SQL, err: ErrUnsupportedFormat,
+		},
+		{
+			name: "missing query",
+			r:    newGetRequest(PathQuerySQL, url.Values{ParamDB: {"test"}}),
+			f:    iofmt.V3SQL,
+		},
+		{
+			name: "invalid method",
+			r:    httptest.NewRequest(http.MethodPut, "http://0"+PathQuerySQL, nil),
+			f:    iofmt.V3SQL,
+		},
+		{
+			name: "not a v3 format",
+			r:    newGetRequest(PathQuerySQL, url.Values{ParamQuery: {testSQL}}),
+			f:    iofmt.InfluxqlGet, err: iofmt.ErrSupportedQueryLanguage,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trq, _, canOPC, err := ParseTimeRangeQuery(test.r, test.f, nil)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if test.err != nil && !errors.Is(err, test.err) {
+				t.Errorf("expected %v got %v", test.err, err)
+			}
+			if canOPC != test.canOPC {
+				t.Errorf("expected %t got %t", test.canOPC, canOPC)
+			}
+			if canOPC && trq.CacheKeyElements[ParamQuery] != test.r.URL.Query().Get(ParamQuery) {
+				t.Errorf("expected the raw query in the cache key got %v", trq.CacheKeyElements)
+			}
+		})
+	}
+}
+
+func TestParseTimeRangeQueryCacheKeyElements(t *testing.T) {
+	elements := func(r *http.Request) map[string]string {
+		t.Helper()
+		trq, _, _, err := ParseTimeRangeQuery(r, iofmt.V3SQL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return trq.CacheKeyElements
+	}
+	body, _ := json.Marshal(map[string]string{"db": "test", "q": testSQL, "format": "jsonl"})
+	expected := elements(newGetRequest(PathQuerySQL, url.Values{ParamDB: {"test"},
+		ParamQuery: {testSQL}}))
+	ranged := strings.ReplaceAll(testSQL, "01:00:00", "02:00:00")
+	for name, r := range map[string]*http.Request{
+		"post": newPostRequest(PathQuerySQL, string(body)),
+		"csv output": newGetRequest(PathQuerySQL, url.Values{ParamDB: {"test"},
+			ParamQuery: {testSQL}, ParamFormat: {"csv"}}),
+		"different range": newGetRequest(PathQuerySQL, url.Values{ParamDB: {"test"},
+			ParamQuery: {ranged}}),
+	} {
+		if got := elements(r); !maps.Equal(got, expected) {
+			t.Errorf("%s: expected %v got %v", name, expected, got)
+		}
+	}
+	for name, r := range map[string]*http.Request{
+		"different database": newGetRequest(PathQuerySQL, url.Values{ParamDB: {"other"},
+			ParamQuery: {testSQL}}),
+		"different predicate": newGetRequest(PathQuerySQL, url.Values{ParamDB: {"test"},
+			ParamQuery: {strings.Replace(testSQL, "GROUP BY", "AND host = 'a' GROUP BY", 1)}}),
+	} {
+		if got := elements(r); maps.Equal(got, expected) {
+			t.Errorf("%s: expected cache key elements to differ from %v", name, expected)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiv3
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	dcsv "github.com/trickstercache/trickster/v2/pkg/timeseries/dataset/csv"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/epoch"
+)
+
+// dataStartRow is the index in the matrix built from a JSONL document at
+// which the header rows have ended and the data rows have started. The first
+// row holds the column names and the second holds the inferred column types.
+const dataStartRow = 2
+
+// Column types inferred from the values of a JSONL document
+const (
+	typeString  = "string"
+	typeInt64   = "int64"
+	typeFloat64 = "float64"
+	typeBool    = "bool"
+)
+
+// timestampLayout is the layout of the timezone-less timestamps returned in
+// InfluxDB 3 query results
+const timestampLayout = "2006-01-02T15:04:05.999999999"
+
+// parser is safe for concurrency
+var parser = dcsv.NewParserMust(buildFieldDefinitions, typeToFieldDataType,
+	parseTimeField, dataStartRow)
+
+// UnmarshalTimeseries converts a JSONL blob into a Timeseries
+func UnmarshalTimeseries(data []byte, trq *timeseries.TimeRangeQuery) (timeseries.Timeseries, error) {
+	return UnmarshalTimeseriesReader(bytes.NewReader(data), trq)
+}
+
+// UnmarshalTimeseriesReader converts a JSONL blob into a Timeseries via io.Reader.
+// A JSON array of row objects is also accepted.
+func UnmarshalTimeseriesReader(reader io.Reader, trq *timeseries.TimeRangeQuery) (timeseries.Timeseries, error) {
+	if reader == nil || trq == nil {
+		return nil, timeseries.ErrInvalidBody
+	}
+	rows, err := decodeRows(reader)
+	if err != nil {
+		return nil, err
+	}
+	return parser.ToDataSet(rows, trq)
+}
+
+// decodeRows decodes a stream of JSON row objects into a matrix of cells,
+// preceded by a row of column names and a row of column types. Columns are
+// ordered by their first appearance, and null or missing values are empty.
+func decodeRows(reader io.Reader) ([][]string, error) {
+	dec := json.NewDecoder(reader)
+	dec.UseNumber()
+	columns := make(map[string]int)
+	names := make([]string, 0, 8)
+	types := make([]string, 0, 8)
+	rows := [][]string{nil, nil}
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, timeseries.ErrInvalidBody
+		}
+		switch t {
+		case json.Delim('['), json.Delim(']'):
+			// rows may be provided as a JSON array rather than as JSONL
+			continue
+		case json.Delim('{'):
+		default:
+			return nil, timeseries.ErrInvalidBody
+		}
+		row := make([]string, len(names))
+		for dec.More() {
+			kt, err := dec.Token()
+			if err != nil {
+				return nil, timeseries.ErrInvalidBody
+			}
+			name, _ := kt.(string)
+			vt, err := dec.Token()
+			if err != nil {
+				return nil, timeseries.ErrInvalidBody
+			}
+			cell, typ, ok := cellValue(vt)
+			if !ok {
+				return nil, timeseries.ErrInvalidBody
+			}
+			i, ok := columns[name]
+			if !ok {
+				i = len(names)
+				columns[name] = i
+				names = append(names, name)
+				types = append(types, "")
+			}
+			for len(row) <= i {
+				row = append(row, "")
+			}
+			row[i] = cell
+			types[i] = mergeTypes(types[i], typ)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, timeseries.ErrInvalidBody
+		}
+		rows = append(rows, row)
+	}
+	rows[0], rows[1] = names, types
+	for i := dataStartRow; i < len(rows); i++ {
+		for len(rows[i]) < len(names) {
+			rows[i] = append(rows[i], "")
+		}
+	}
+	return rows, nil
+}
+
+// cellValue returns the cell and type of a scalar JSON token
+func cellValue(t json.Token) (string, string, bool) {
+	switch v := t.(type) {
+	case nil:
+		return "", "", true
+	case string:
+		return v, typeString, true
+	case bool:
+		if v {
+			return "true", typeBool, true
+		}
+		return "false", typeBool, true
+	case json.Number:
+		s := v.String()
+		if strings.ContainsAny(s, ".eE") {
+			return s, typeFloat64, true
+		}
+		return s, typeInt64, true
+	}
+	return "", "", false
+}
+
+// mergeTypes returns the type of a column holding values of both types
+func mergeTypes(current, next string) string {
+	switch {
+	case current == "" || current == next:
+		return next
+	case next == "":
+		return current
+	case (current == typeInt64 && next == typeFloat64) ||
+		(current == typeFloat64 && next == typeInt64):
+		return typeFloat64
+	}
+	return typeString
+}
+
+// buildFieldDefinitions is the FieldParserFunc passed to the Parser
+func buildFieldDefinitions(rows [][]string,
+	trq *timeseries.TimeRangeQuery,
+) (timeseries.SeriesFields, error) {
+	l := len(rows[0])
+	if len(rows[1]) != l {
+		return timeseries.SeriesFields{}, timeseries.ErrInvalidBody
+	}
+	var j, k int
+	outTags := make(timeseries.FieldDefinitions, l)
+	outVals := make(timeseries.FieldDefinitions, l)
+	tfd := timeseries.FieldDefinition{OutputPosition: -1}
+	for i := range l {
+		fd := loadFieldDef(rows[0][i], rows[1][i], i, trq)
+		switch fd.Role {
+		case timeseries.RoleTag:
+			outTags[j] = fd
+			j++
+		case timeseries.RoleTimestamp:
+			tfd = fd
+		case timeseries.RoleValue:
+			outVals[k] = fd
+			k++
+		}
+	}
+	return timeseries.SeriesFields{
+		Timestamp: tfd, Tags: outTags[:j],
+		Values: outVals[:k], Untracked: make(timeseries.FieldDefinitions, 0),
+		ResultNameCol: -1,
+	}, nil
+}
+
+// loadFieldDef returns a field definition from the name and datatype.
+func loadFieldDef(fieldName, dataType string, col int,
+	trq *timeseries.TimeRangeQuery,
+) timeseries.FieldDefinition {
+	fd := timeseries.FieldDefinition{
+		Name:           fieldName,
+		DataType:       typeToFieldDataType(dataType),
+		SDataType:      dataType,
+		OutputPosition: col,
+		Role:           timeseries.RoleValue,
+	}
+	if fieldName == trq.TimestampDefinition.Name {
+		fd.Role = timeseries.RoleTimestamp
+		fd.DataType = trq.TimestampDefinition.DataType
+		return fd
+	}
+	for _, tag := range trq.TagFieldDefintions {
+		if tag.Name == fieldName {
+			fd.Role = timeseries.RoleTag
+			break
+		}
+	}
+	return fd
+}
+
+// typeToFieldDataType is the DataTypeParserFunc passed to the Parser
+func typeToFieldDataType(input string) timeseries.FieldDataType {
+	switch input {
+	case typeString:
+		return timeseries.String
+	case typeInt64:
+		return timeseries.Int64
+	case typeFloat64:
+		return timeseries.Float64
+	case typeBool:
+		return timeseries.Bool
+	}
+	return timeseries.Null
+}
+
+// parseTimeField parses a timestamp, which InfluxDB 3 returns without a
+// timezone, although an RFC3339 timestamp is also accepted
+func parseTimeField(input string, _ timeseries.FieldDefinition) (epoch.Epoch, error) {
+	t, err := time.Parse(timestampLayout, input)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339Nano, input)
+		if err != nil {
+			return 0, timeseries.ErrInvalidTimeFormat
+		}
+	}
+	return epoch.Epoch(t.UnixNano()), nil
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiv3
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+)
+
+const testJSONL = `{"_time":"2024-01-01T00:00:00","host":"a","avg(cpu.usage)":1.5,"n":1}
+{"_time":"2024-01-01T00:00:00","host":"b","avg(cpu.usage)":2.0}
+{"_time":"2024-01-01T00:01:00","host":"a","avg(cpu.usage)":3.25,"n":2}
+{"_time":"2024-01-01T00:01:00","host":"b","avg(cpu.usage)":null,"n":3}
+`
+
+func testTRQ() *timeseries.TimeRangeQuery {
+	return &timeseries.TimeRangeQuery{
+		Extent: timeseries.Extent{
+			Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC),
+		},
+		Step: time.Minute,
+		TimestampDefinition: timeseries.FieldDefinition{
+			Name: "_time", DataType: timeseries.DateTimeRFC3339Nano,
+			Role: timeseries.RoleTimestamp,
+		},
+		TagFieldDefintions: timeseries.FieldDefinitions{
+			{Name: "host", Role: timeseries.RoleTag},
+		},
+	}
+}
+
+func TestDecodeRows(t *testing.T) {
+	rows, err := decodeRows(strings.NewReader(testJSONL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 6 {
+		t.Fatalf("expected 6 rows got %d", len(rows))
+	}
+	if strings.Join(rows[0], ",") != "_time,host,avg(cpu.usage),n" {
+		t.Errorf("unexpected names %v", rows[0])
+	}
+	if strings.Join(rows[1], ",") != "string,string,float64,int64" {
+		t.Errorf("unexpected types %v", rows[1])
+	}
+	if strings.Join(rows[3], ",") != "2024-01-01T00:00:00,b,2.0," {
+		t.Errorf("unexpected row %v", rows[3])
+	}
+	// a JSON array of rows is decoded identically
+	arrayRows, err := decodeRows(strings.NewReader("[" +
+		strings.ReplaceAll(strings.TrimSpace(testJSONL), "\n", ",") + "]"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(arrayRows) != len(rows) {
+		t.Errorf("expected %d rows got %d", len(rows), len(arrayRows))
+	}
+	for _, input := range []string{`{"a":[1]}`, `{"a":1`, `"a"`} {
+		if _, err := decodeRows(strings.NewReader(input)); err != timeseries.ErrInvalidBody {
+			t.Errorf("expected %v for %s got %v", timeseries.ErrInvalidBody, input, err)
+		}
+	}
+}
+
+func TestMergeTypes(t *testing.T) {
+	tests := []struct{ current, next, expected string }{
+		{"", typeInt64, typeInt64},
+		{typeInt64, "", typeInt64},
+		{typeInt64, typeFloat64, typeFloat64},
+		{typeFloat64, typeInt64, typeFloat64},
+		{typeBool, typeInt64, typeString},
+	}
+	for _, test := range tests {
+		if out := mergeTypes(test.current, test.next); out != test.expected {
+			t.Errorf("expected %s got %s", test.expected, out)
+		}
+	}
+}
+
+func TestUnmarshalTimeseries(t *testing.T) {
+	ts, err := UnmarshalTimeseries([]byte(testJSONL), testTRQ())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := ts.(*dataset.DataSet)
+	if len(ds.Results) != 1 || len(ds.Results[0].SeriesList) != 2 {
+		t.Fatalf("unexpected results %v", ds.Results)
+	}
+	a := ds.Results[0].SeriesList[0]
+	if a.Header.Tags["host"] != "a" || len(a.Points) != 2 {
+		t.Fatalf("unexpected series %v", a.Header)
+	}
+	if v, ok := a.Points[1].Values[0].(float64); !ok || v != 3.25 {
+		t.Errorf("expected 3.25 got %v", a.Points[1].Values[0])
+	}
+	if v, ok := a.Points[1].Values[1].(int64); !ok || v != 2 {
+		t.Errorf("expected 2 got %v", a.Points[1].Values[1])
+	}
+	if e := time.Unix(0, int64(a.Points[1].Epoch)).UTC(); !e.Equal(ds.TimeRangeQuery.Extent.End) {
+		t.Errorf("expected %s got %s", ds.TimeRangeQuery.Extent.End, e)
+	}
+	if _, err := UnmarshalTimeseries([]byte(testJSONL), nil); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestParseTimeField(t *testing.T) {
+	expected := time.Date(2024, 1, 1, 0, 0, 0, 500000000, time.UTC).UnixNano()
+	for _, input := range []string{"2024-01-01T00:00:00.5", "2024-01-01T00:00:00.5Z"} {
+		e, err := parseTimeField(input, timeseries.FieldDefinition{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(e) != expected {
+			t.Errorf("expected %d got %d", expected, e)
+		}
+	}
+	if _, err := parseTimeField("yesterday", timeseries.FieldDefinition{}); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiv3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+var errInvalidRewriteInput = errors.New("invalid InfluxDB 3 extent rewrite input")
+
+// SetExtent changes the upstream request query to the provided cache-miss
+// extent, and requests the results in the upstream output format
+func SetExtent(r *http.Request, trq *timeseries.TimeRangeQuery,
+	extent *timeseries.Extent, plan *sqlanalyzer.QueryPlan,
+) error {
+	if r == nil || r.URL == nil || trq == nil || extent == nil || plan == nil {
+		return errInvalidRewriteInput
+	}
+	statement, err := plan.RenderExtent(*extent)
+	if err != nil {
+		return fmt.Errorf("render InfluxDB 3 extent: %w", err)
+	}
+	if r.Method != http.MethodPost {
+		qp := r.URL.Query()
+		qp.Set(ParamQuery, statement)
+		qp.Set(ParamFormat, upstreamFormat)
+		r.URL.RawQuery = qp.Encode()
+		return nil
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(trq.OriginalBody, &doc); err != nil {
+		return fmt.Errorf("decode InfluxDB 3 request body: %w", err)
+	}
+	doc[ParamQuery], _ = json.Marshal(statement)
+	doc[ParamFormat], _ = json.Marshal(upstreamFormat)
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	request.SetBody(r, b)
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiv3
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/iofmt"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func TestSetExtent(t *testing.T) {
+	extent := &timeseries.Extent{
+		Start: time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 1, 1, 2, 59, 0, 0, time.UTC),
+	}
+	const expected = "time >= '2024-01-01T02:00:00Z' AND time < '2024-01-01T03:00:00Z'"
+
+	t.Run("get", func(t *testing.T) {
+		r := newGetRequest(PathQuerySQL, url.Values{ParamDB: {"test"}, ParamQuery: {testSQL}})
+		trq, _, _, err := ParseTimeRangeQuery(r, iofmt.V3SQL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = SetExtent(r, trq, extent, trq.ParsedQuery.(*sqlanalyzer.QueryPlan))
+		if err != nil {
+			t.Fatal(err)
+		}
+		qp := r.URL.Query()
+		if !strings.Contains(qp.Get(ParamQuery), expected) {
+			t.Errorf("expected %s in %s", expected, qp.Get(ParamQuery))
+		}
+		if qp.Get(ParamFormat) != upstreamFormat || qp.Get(ParamDB) != "test" {
+			t.Errorf("unexpected params %v", qp)
+		}
+	})
+
+	t.Run("post", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]any{"db": "test", "q": testSQL, "format": "csv",
+			"params": map[string]any{"host": "a"}})
+		r := newPostRequest(PathQuerySQL, string(body))
+		trq, _, _, err := ParseTimeRangeQuery(r, iofmt.V3SQL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = SetExtent(r, trq, extent, trq.ParsedQuery.(*sqlanalyzer.QueryPlan))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(r.Body)
+		var doc map[string]any
+		if err := json.Unmarshal(b, &doc); err != nil {
+			t.Fatal(err)
+		}
+		if q, _ := doc[ParamQuery].(string); !strings.Contains(q, expected) {
+			t.Errorf("expected %s in %s", expected, q)
+		}
+		if doc[ParamFormat] != upstreamFormat || doc[ParamDB] != "test" ||
+			doc[ParamParams] == nil {
+			t.Errorf("unexpected body %s", b)
+		}
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		r := newGetRequest(PathQuerySQL, nil)
+		if err := SetExtent(r, &timeseries.TimeRangeQuery{}, extent, nil); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
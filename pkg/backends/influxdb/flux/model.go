@@ -24,11 +24,12 @@ import (
 // NewModeler returns a collection of modeling functions for influxdb interoperability
 func NewModeler() *timeseries.Modeler {
 	return &timeseries.Modeler{
-		WireUnmarshalerReader: UnmarshalTimeseriesReader,
-		WireMarshaler:         MarshalTimeseries,
-		WireMarshalWriter:     MarshalTimeseriesWriter,
-		WireUnmarshaler:       UnmarshalTimeseries,
-		CacheMarshaler:        dataset.MarshalDataSet,
-		CacheUnmarshaler:      dataset.UnmarshalDataSet,
+		WireUnmarshalerReader:  UnmarshalTimeseriesReader,
+		WireMarshaler:          MarshalTimeseries,
+		WireMarshalWriter:      MarshalTimeseriesWriter,
+		WireUnmarshaler:        UnmarshalTimeseries,
+		CacheMarshaler:         dataset.MarshalDataSet,
+		ColumnarCacheMarshaler: dataset.MarshalDataSetColumnar,
+		CacheUnmarshaler:       dataset.UnmarshalDataSet,
 	}
 }
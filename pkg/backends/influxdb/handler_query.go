@@ -20,6 +20,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/apiv3"
 	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/flux"
 	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/influxql"
 	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/iofmt"
@@ -33,8 +34,17 @@ import (
 
 // QueryHandler handles timeseries requests for InfluxDB and processes them through the delta proxy cache
 func (c *Client) QueryHandler(w http.ResponseWriter, r *http.Request) {
-	f := iofmt.Detect(r)
+	f := apiv3.Detect(r)
+	if f == iofmt.Unknown {
+		f = iofmt.Detect(r)
+	}
 	switch {
+	case f.IsV3():
+		// skip non-selects
+		if q := apiv3.Statement(r); !strings.Contains(strings.ToLower(q), "select") {
+			c.ProxyHandler(w, r)
+			return
+		}
 	case f.IsInfluxQL():
 		qp, _, _ := params.GetRequestValues(r)
 		// skip non-selects
@@ -58,8 +68,13 @@ func (c *Client) QueryHandler(w http.ResponseWriter, r *http.Request) {
 func (c *Client) ParseTimeRangeQuery(r *http.Request) (*timeseries.TimeRangeQuery,
 	*timeseries.RequestOptions, bool, error,
 ) {
-	f := iofmt.Detect(r)
+	f := apiv3.Detect(r)
+	if f == iofmt.Unknown {
+		f = iofmt.Detect(r)
+	}
 	switch {
+	case f.IsV3():
+		return apiv3.ParseTimeRangeQuery(r, f, c.observeAnalysis)
 	case f.IsInfluxQL():
 		return influxql.ParseTimeRangeQuery(r, f)
 	case f.IsFlux():
@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
 	"github.com/trickstercache/trickster/v2/pkg/errors"
@@ -162,3 +163,39 @@ func TestParseTimeRangeQueryBadDuration(t *testing.T) {
 		t.Errorf(`Expected "%s", got "%s"`, expected.Error(), err.Error())
 	}
 }
+
+func TestQueryHandlerV3(t *testing.T) {
+	// the range must be recent, since older ranges are proxied rather than cached
+	start := time.Now().UTC().Truncate(time.Minute).Add(-10 * time.Minute)
+	ts1 := start.Format("2006-01-02T15:04:05")
+	ts2 := start.Add(time.Minute).Format("2006-01-02T15:04:05")
+	upstream := `{"_time":"` + ts1 + `","host":"a","n":1}` + "\n" +
+		`{"_time":"` + ts2 + `","host":"a","n":2}` + "\n"
+	q := url.Values{
+		"db":     {"test"},
+		"format": {"csv"},
+		"q": {"SELECT date_bin(INTERVAL '1 minute', time) AS _time, host, count(*) AS n " +
+			"FROM cpu WHERE time >= '" + start.Format(time.RFC3339) + "' AND time < '" +
+			start.Add(2*time.Minute).Format(time.RFC3339) + "' GROUP BY 1, host"},
+	}
+	backendClient, err := NewClient("test", nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	ts, w, r, _, err := tu.NewTestInstance("", backendClient.DefaultPathConfigs, 200,
+		upstream, nil, providers.InfluxDB, "/api/v3/query_sql?"+q.Encode(), "debug")
+	require.NoError(t, err)
+	defer ts.Close()
+	rsc := request.GetResources(r)
+	backendClient, err = NewClient("test", rsc.BackendOptions, nil, nil, nil, nil)
+	require.NoError(t, err)
+	client := backendClient.(*Client)
+	rsc.BackendClient = client
+	rsc.BackendOptions.HTTPClient = backendClient.HTTPClient()
+
+	client.QueryHandler(w, r)
+
+	resp := w.Result()
+	require.Equal(t, 200, resp.StatusCode)
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "_time,host,n\n"+ts1+",a,1\n"+ts2+",a,2\n", string(bodyBytes))
+}
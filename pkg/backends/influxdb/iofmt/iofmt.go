@@ -21,6 +21,7 @@ package iofmt
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
 )
@@ -35,6 +36,8 @@ const (
 	isFlux                  // 4
 	isFluxInputJSON         // 8
 	isFluxOutputJSON        // 16
+	isV3
+	isV3SQL
 
 	InfluxqlGet  = isInfluxql
 	InfluxqlPost = isInfluxql + isInfluxqlPost
@@ -44,28 +47,99 @@ const (
 
 	FluxRawJSON = isFlux + isFluxOutputJSON
 	FluxRawCsv  = isFlux
+
+	V3Influxql = isV3
+	V3SQL      = isV3 + isV3SQL
+)
+
+// InfluxDB 3 output formats occupy the low bits of a V3 Format, which are
+// otherwise only used by the InfluxDB 1.x and 2.x formats
+const (
+	V3OutputJSON Format = iota
+	V3OutputJSONL
+	V3OutputCSV
+	V3OutputParquet
+	V3OutputPretty
+
+	v3OutputMask Format = 7
 )
 
+var v3Outputs = map[string]Format{
+	"json":    V3OutputJSON,
+	"jsonl":   V3OutputJSONL,
+	"csv":     V3OutputCSV,
+	"parquet": V3OutputParquet,
+	"pretty":  V3OutputPretty,
+}
+
+var v3OutputNames = []string{"json", "jsonl", "csv", "parquet", "pretty"}
+
 var ErrSupportedQueryLanguage = errors.New("unsupported query language")
 
 func (f Format) IsInfluxQL() bool {
-	return f&isInfluxql == isInfluxql
+	return !f.IsV3() && f&isInfluxql == isInfluxql
 }
 
 func (f Format) IsFlux() bool {
-	return f&isFlux == isFlux
+	return !f.IsV3() && f&isFlux == isFlux
 }
 
 func (f Format) IsFluxInputJSON() bool {
-	return f&isFluxInputJSON == isFluxInputJSON
+	return !f.IsV3() && f&isFluxInputJSON == isFluxInputJSON
 }
 
 func (f Format) IsFluxOutputJSON() bool {
-	return f&isFluxOutputJSON == isFluxOutputJSON
+	return !f.IsV3() && f&isFluxOutputJSON == isFluxOutputJSON
 }
 
 func (f Format) IsPost() bool {
-	return f&isFlux == isFlux || f&isInfluxqlPost == isInfluxqlPost
+	return !f.IsV3() && (f&isFlux == isFlux || f&isInfluxqlPost == isInfluxqlPost)
+}
+
+// IsV3 returns true if the Format is for the InfluxDB 3 Query API
+func (f Format) IsV3() bool {
+	return f&isV3 == isV3
+}
+
+// IsV3SQL returns true if the Format is for an InfluxDB 3 SQL query
+func (f Format) IsV3SQL() bool {
+	return f.IsV3() && f&isV3SQL == isV3SQL
+}
+
+// IsV3InfluxQL returns true if the Format is for an InfluxDB 3 InfluxQL query
+func (f Format) IsV3InfluxQL() bool {
+	return f.IsV3() && f&isV3SQL == 0
+}
+
+// V3Output returns the InfluxDB 3 output format portion of the Format
+func (f Format) V3Output() Format {
+	return f & v3OutputMask
+}
+
+// V3OutputName returns the InfluxDB 3 name of the Format's output format,
+// as used in the format parameter of a query
+func (f Format) V3OutputName() string {
+	if o := int(f.V3Output()); o < len(v3OutputNames) {
+		return v3OutputNames[o]
+	}
+	return ""
+}
+
+// IsV3Tabular returns true if the Format's InfluxDB 3 output format can be
+// modeled as a timeseries, which is true for the JSON, JSONL and CSV outputs
+func (f Format) IsV3Tabular() bool {
+	o := f.V3Output()
+	return f.IsV3() && (o == V3OutputJSON || o == V3OutputJSONL || o == V3OutputCSV)
+}
+
+// V3OutputFormat returns the InfluxDB 3 output format for the provided format
+// parameter value, which defaults to JSON when empty
+func V3OutputFormat(name string) (Format, bool) {
+	if name == "" {
+		return V3OutputJSON, true
+	}
+	f, ok := v3Outputs[strings.ToLower(name)]
+	return f, ok
 }
 
 func Detect(r *http.Request) Format {
@@ -154,3 +154,51 @@ func TestDetect(t *testing.T) {
 		})
 	}
 }
+
+func TestV3Formats(t *testing.T) {
+	f := V3SQL | V3OutputCSV
+	if !f.IsV3() || !f.IsV3SQL() || f.IsV3InfluxQL() {
+		t.Error("expected v3 sql format")
+	}
+	if f.IsInfluxQL() || f.IsFlux() || f.IsFluxInputJSON() ||
+		f.IsFluxOutputJSON() || f.IsPost() {
+		t.Error("expected v3 format to not be a v1 or v2 format")
+	}
+	if f.V3Output() != V3OutputCSV || f.V3OutputName() != "csv" {
+		t.Errorf("unexpected output format %d", f.V3Output())
+	}
+	if !f.IsV3Tabular() {
+		t.Error("expected true")
+	}
+	f = V3Influxql | V3OutputParquet
+	if !f.IsV3InfluxQL() || f.IsV3SQL() || f.IsV3Tabular() {
+		t.Error("expected v3 influxql parquet format")
+	}
+	if InfluxqlPost.IsV3() || FluxJSONJSON.IsV3() {
+		t.Error("expected false")
+	}
+}
+
+func TestV3OutputFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Format
+		ok       bool
+	}{
+		{"", V3OutputJSON, true},
+		{"json", V3OutputJSON, true},
+		{"JSONL", V3OutputJSONL, true},
+		{"csv", V3OutputCSV, true},
+		{"parquet", V3OutputParquet, true},
+		{"pretty", V3OutputPretty, true},
+		{"xml", Unknown, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, ok := V3OutputFormat(test.name)
+			if ok != test.ok || f != test.expected {
+				t.Errorf("expected %d %t got %d %t", test.expected, test.ok, f, ok)
+			}
+		})
+	}
+}
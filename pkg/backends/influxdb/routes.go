@@ -19,6 +19,7 @@ package influxdb
 import (
 	"net/http"
 
+	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/apiv3"
 	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/influxql"
 	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
 	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
@@ -61,6 +62,30 @@ func (c *Client) DefaultPathConfigs(_ *bo.Options) po.List {
 			MatchTypeName:   matching.PathMatchNameExact,
 			MatchType:       matching.PathMatchTypeExact,
 		},
+		{
+			Path:        apiv3.PathQuerySQL,
+			HandlerName: mnQuery,
+			Methods:     methods.GetAndPost(),
+			CacheKeyParams: []string{apiv3.ParamDB, apiv3.ParamQuery,
+				apiv3.ParamFormat, apiv3.ParamParams},
+			CacheKeyFormFields: []string{apiv3.ParamDB, apiv3.ParamQuery,
+				apiv3.ParamFormat, apiv3.ParamParams},
+			CacheKeyHeaders: []string{},
+			MatchTypeName:   matching.PathMatchNameExact,
+			MatchType:       matching.PathMatchTypeExact,
+		},
+		{
+			Path:        apiv3.PathQueryInfluxQL,
+			HandlerName: mnQuery,
+			Methods:     methods.GetAndPost(),
+			CacheKeyParams: []string{apiv3.ParamDB, apiv3.ParamQuery,
+				apiv3.ParamFormat, apiv3.ParamParams},
+			CacheKeyFormFields: []string{apiv3.ParamDB, apiv3.ParamQuery,
+				apiv3.ParamFormat, apiv3.ParamParams},
+			CacheKeyHeaders: []string{},
+			MatchTypeName:   matching.PathMatchNameExact,
+			MatchType:       matching.PathMatchTypeExact,
+		},
 		{
 			Path:          "/",
 			HandlerName:   providers.Proxy,
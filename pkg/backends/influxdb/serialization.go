@@ -20,10 +20,12 @@ import (
 	"io"
 	"strings"
 
+	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/apiv3"
 	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/flux"
 	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/influxql"
 	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/iofmt"
 	"github.com/trickstercache/trickster/v2/pkg/errors"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
 	"github.com/trickstercache/trickster/v2/pkg/timeseries"
 	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
 )
@@ -31,12 +33,13 @@ import (
 // NewModeler returns a collection of modeling functions for influxdb interoperability
 func NewModeler() *timeseries.Modeler {
 	return &timeseries.Modeler{
-		WireUnmarshalerReader: UnmarshalTimeseriesReader,
-		WireMarshaler:         MarshalTimeseries,
-		WireMarshalWriter:     MarshalTimeseriesWriter,
-		WireUnmarshaler:       UnmarshalTimeseries,
-		CacheMarshaler:        dataset.MarshalDataSet,
-		CacheUnmarshaler:      dataset.UnmarshalDataSet,
+		WireUnmarshalerReader:  UnmarshalTimeseriesReader,
+		WireMarshaler:          MarshalTimeseries,
+		WireMarshalWriter:      MarshalTimeseriesWriter,
+		WireUnmarshaler:        UnmarshalTimeseries,
+		CacheMarshaler:         dataset.MarshalDataSet,
+		ColumnarCacheMarshaler: dataset.MarshalDataSetColumnar,
+		CacheUnmarshaler:       dataset.UnmarshalDataSet,
 	}
 }
 
@@ -46,6 +49,9 @@ func UnmarshalTimeseries(data []byte,
 	if len(data) == 0 || trq == nil {
 		return nil, errors.ErrBadRequest
 	}
+	if _, ok := trq.ParsedQuery.(*sqlanalyzer.QueryPlan); ok {
+		return apiv3.UnmarshalTimeseries(data, trq)
+	}
 	if strings.Contains(strings.ToLower(trq.Statement), flux.FuncRange) {
 		return flux.UnmarshalTimeseries(data, trq)
 	}
@@ -58,6 +64,9 @@ func UnmarshalTimeseriesReader(reader io.Reader,
 	if reader == nil || trq == nil {
 		return nil, errors.ErrBadRequest
 	}
+	if _, ok := trq.ParsedQuery.(*sqlanalyzer.QueryPlan); ok {
+		return apiv3.UnmarshalTimeseriesReader(reader, trq)
+	}
 	if strings.Contains(strings.ToLower(trq.Statement), flux.FuncRange) {
 		return flux.UnmarshalTimeseriesReader(reader, trq)
 	}
@@ -70,7 +79,11 @@ func MarshalTimeseries(ts timeseries.Timeseries,
 	if ts == nil || rlo == nil {
 		return nil, errors.ErrBadRequest
 	}
-	if iofmt.Format(rlo.OutputFormat).IsInfluxQL() {
+	f := iofmt.Format(rlo.OutputFormat)
+	if f.IsV3() {
+		return apiv3.MarshalTimeseries(ts, rlo, status)
+	}
+	if f.IsInfluxQL() {
 		return influxql.MarshalTimeseries(ts, rlo, status)
 	}
 	return flux.MarshalTimeseries(ts, rlo, status)
@@ -82,6 +95,9 @@ func MarshalTimeseriesWriter(ts timeseries.Timeseries,
 	if ts == nil || rlo == nil || w == nil {
 		return errors.ErrBadRequest
 	}
+	if iofmt.Format(rlo.OutputFormat).IsV3() {
+		return apiv3.MarshalTimeseriesWriter(ts, rlo, status, w)
+	}
 	if rlo.OutputFormat < 4 {
 		return influxql.MarshalTimeseriesWriter(ts, rlo, status, w)
 	}
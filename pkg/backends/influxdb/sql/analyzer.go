@@ -0,0 +1,689 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sql provides the InfluxDB 3 SQL dialect adapter, which analyzes
+// SQL statements for delta cache eligibility and renders them for cache-miss
+// extents. The InfluxDB 3 SQL dialect is not supported by an AST parser that
+// Trickster can use, so the adapter works on a token stream and supports a
+// narrow subset of statement shapes, failing closed to the object cache for
+// everything else.
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+type analyzer struct{}
+
+// Analyzer is the InfluxDB 3 SQL dialect analyzer
+var Analyzer sqlanalyzer.DialectAnalyzer = analyzer{}
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+func (analyzer) Analyze(statement string, now time.Time) sqlanalyzer.Analysis {
+	if strings.TrimSpace(statement) == "" {
+		return sqlanalyzer.Analysis{Reason: sqlanalyzer.ReasonInvalidSQL, Err: ErrNotTimeRangeQuery}
+	}
+	tokens, err := lex(statement)
+	if err == nil {
+		tokens, err = trimTerminator(tokens)
+	}
+	if err != nil {
+		mode := sqlanalyzer.CacheModeNone
+		if isSelectQuery(statement) {
+			mode = sqlanalyzer.CacheModeObject
+		}
+		return sqlanalyzer.Analysis{Mode: mode, Reason: sqlanalyzer.ReasonInvalidSQL, Err: err}
+	}
+	if tokens[0].is("with") {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedStatement, ErrUnsupportedStatement)
+	}
+	if !tokens[0].is("select") {
+		return sqlanalyzer.Analysis{
+			Mode: sqlanalyzer.CacheModeNone, Reason: sqlanalyzer.ReasonUnsupportedStatement,
+			Err: ErrUnsupportedStatement,
+		}
+	}
+	q, err := splitClauses(tokens)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrLimitUnsupported):
+			return objectAnalysis(sqlanalyzer.ReasonUnsupportedLimit, err)
+		case errors.Is(err, ErrInvalidGroupByClause):
+			return objectAnalysis(sqlanalyzer.ReasonUnsupportedGrouping, err)
+		case errors.Is(err, ErrUnsupportedStatement):
+			return objectAnalysis(sqlanalyzer.ReasonUnsupportedStatement, err)
+		}
+		return objectAnalysis(sqlanalyzer.ReasonInvalidSQL, err)
+	}
+	items := selectItems(q.clause(clauseSelect))
+	bucket, err := analyzeSelectList(items)
+	if err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedBucket, err)
+	}
+	groups, err := analyzeGroupBy(q.clause(clauseGroupBy), items, bucket)
+	if err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedGrouping, err)
+	}
+	if err := analyzeOrderBy(q.clause(clauseOrderBy), items, bucket); err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedStatement, err)
+	}
+	ranges, err := analyzeRanges(q, bucket, now)
+	if err != nil {
+		reason := sqlanalyzer.ReasonNotTimeRange
+		if errors.Is(err, ErrUnsafePredicate) {
+			reason = sqlanalyzer.ReasonUnsafePredicate
+		} else if errors.Is(err, ErrAmbiguousTimeAxis) {
+			reason = sqlanalyzer.ReasonAmbiguousTimeAxis
+		}
+		return objectAnalysis(reason, err)
+	}
+
+	canonical, renderer := buildQueryArtifacts(q, ranges, bucket)
+	plan := &sqlanalyzer.QueryPlan{
+		CanonicalSQL: canonical,
+		TimeColumn:   bucket.timeColumn,
+		OutputColumn: bucket.outputColumn,
+		Step:         bucket.step,
+		Phase:        bucket.phase,
+		OutputUnit:   timeseries.DateTimeRFC3339Nano,
+		InputUnit:    ranges.lower.style.inputUnit(),
+		LowerBound: &sqlanalyzer.Bound{
+			Value: ranges.lower.value, Inclusive: ranges.lower.inclusive,
+		},
+		GroupColumns: groups,
+		Renderer:     renderer,
+	}
+	if ranges.upper != nil {
+		plan.UpperBound = &sqlanalyzer.Bound{
+			Value: ranges.upper.value, Inclusive: ranges.upper.inclusive,
+		}
+	}
+	return sqlanalyzer.Analysis{
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Plan: plan,
+	}
+}
+
+func objectAnalysis(reason sqlanalyzer.AnalysisReason, err error) sqlanalyzer.Analysis {
+	return sqlanalyzer.Analysis{Mode: sqlanalyzer.CacheModeObject, Reason: reason, Err: err}
+}
+
+// isSelectQuery reports whether the SQL query contains a SELECT keyword.
+func isSelectQuery(statement string) bool {
+	for f := range strings.FieldsSeq(strings.ToLower(statement)) {
+		if f == "select" {
+			return true
+		}
+	}
+	return false
+}
+
+// trimTerminator removes any trailing statement terminators, and fails if the
+// input contains more than one statement
+func trimTerminator(tokens []token) ([]token, error) {
+	for len(tokens) > 0 && tokens[len(tokens)-1].isOp(";") {
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty statement", ErrInvalidSQL)
+	}
+	for _, t := range tokens {
+		if t.isOp(";") {
+			return nil, fmt.Errorf("%w: expected one statement", ErrInvalidSQL)
+		}
+	}
+	return tokens, nil
+}
+
+type clauseID int
+
+const (
+	clauseSelect clauseID = iota
+	clauseFrom
+	clauseWhere
+	clauseGroupBy
+	clauseHaving
+	clauseOrderBy
+	clauseLimit
+	clauseCount
+)
+
+// query holds the top-level clause boundaries of a SELECT statement. bounds
+// holds the [start, end) token indexes of each clause's contents, or -1s when
+// the clause is not present.
+type query struct {
+	tokens []token
+	bounds [clauseCount][2]int
+}
+
+func (q *query) clause(id clauseID) []token {
+	b := q.bounds[id]
+	if b[0] < 0 {
+		return nil
+	}
+	return q.tokens[b[0]:b[1]]
+}
+
+// splitClauses locates the top-level clauses of a SELECT statement
+func splitClauses(tokens []token) (*query, error) {
+	q := &query{tokens: tokens}
+	for i := range q.bounds {
+		q.bounds[i] = [2]int{-1, -1}
+	}
+	current := clauseSelect
+	q.bounds[clauseSelect][0] = 1
+	start := func(id clauseID, at int) error {
+		if id <= current || q.bounds[id][0] >= 0 {
+			return fmt.Errorf("%w: unexpected clause order", ErrInvalidSQL)
+		}
+		q.bounds[current][1] = at
+		current = id
+		return nil
+	}
+	for i := 1; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.kind != tokenWord {
+			continue
+		}
+		// subqueries and window functions are unsupported at any depth
+		if t.is("select") || t.is("over") {
+			return nil, ErrUnsupportedStatement
+		}
+		if t.depth > 0 {
+			continue
+		}
+		switch strings.ToLower(t.text) {
+		case "union", "intersect", "except", "window", "qualify":
+			return nil, ErrUnsupportedStatement
+		case "limit", "offset", "fetch":
+			return nil, ErrLimitUnsupported
+		case "distinct":
+			if i == 1 {
+				return nil, ErrInvalidGroupByClause
+			}
+		case "from":
+			if err := start(clauseFrom, i); err != nil {
+				return nil, err
+			}
+			q.bounds[clauseFrom][0] = i + 1
+		case "where":
+			if err := start(clauseWhere, i); err != nil {
+				return nil, err
+			}
+			q.bounds[clauseWhere][0] = i + 1
+		case "having":
+			if err := start(clauseHaving, i); err != nil {
+				return nil, err
+			}
+			q.bounds[clauseHaving][0] = i + 1
+		case "group", "order":
+			if i+1 >= len(tokens) || !tokens[i+1].is("by") {
+				return nil, fmt.Errorf("%w: expected BY", ErrInvalidSQL)
+			}
+			id := clauseGroupBy
+			if tokens[i].is("order") {
+				id = clauseOrderBy
+			}
+			if err := start(id, i); err != nil {
+				return nil, err
+			}
+			q.bounds[id][0] = i + 2
+			i++
+		}
+	}
+	q.bounds[current][1] = len(tokens)
+	if q.bounds[clauseFrom][0] < 0 {
+		return nil, ErrNotTimeRangeQuery
+	}
+	return q, nil
+}
+
+// splitList splits tokens on commas at the provided depth
+func splitList(tokens []token, depth int) [][]token {
+	if len(tokens) == 0 {
+		return nil
+	}
+	out := make([][]token, 0, 8)
+	var start int
+	for i, t := range tokens {
+		if t.depth == depth && t.isOp(",") {
+			out = append(out, tokens[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, tokens[start:])
+}
+
+type selectItem struct {
+	expr  []token
+	alias string
+}
+
+// selectItems splits the select list into its items and their aliases
+func selectItems(tokens []token) []selectItem {
+	list := splitList(tokens, 0)
+	out := make([]selectItem, len(list))
+	for i, item := range list {
+		out[i].expr = item
+		n := len(item)
+		switch {
+		case n >= 3 && item[n-2].is("as"):
+			if alias, ok := item[n-1].identifier(); ok {
+				out[i].expr = item[:n-2]
+				out[i].alias = alias
+			}
+		case n >= 2 && isImplicitAlias(item[n-2], item[n-1]):
+			out[i].alias, _ = item[n-1].identifier()
+			out[i].expr = item[:n-1]
+		}
+	}
+	return out
+}
+
+func isImplicitAlias(prev, t token) bool {
+	if t.kind == tokenWord {
+		switch strings.ToLower(t.text) {
+		case "end", "null", "true", "false":
+			return false
+		}
+	} else if t.kind != tokenIdent {
+		return false
+	}
+	return prev.isOp(")") || prev.kind == tokenWord || prev.kind == tokenIdent
+}
+
+// columnName returns the name of a column reference, which is one or more
+// identifiers joined by dots
+func columnName(tokens []token) (string, bool) {
+	if len(tokens) == 0 || len(tokens)%2 == 0 {
+		return "", false
+	}
+	parts := make([]string, 0, (len(tokens)+1)/2)
+	for i, t := range tokens {
+		if i%2 == 1 {
+			if !t.isOp(".") {
+				return "", false
+			}
+			continue
+		}
+		name, ok := t.identifier()
+		if !ok {
+			return "", false
+		}
+		parts = append(parts, name)
+	}
+	return strings.Join(parts, "."), true
+}
+
+// outputName returns the name of the output column for a column reference
+func outputName(column string) string {
+	if i := strings.LastIndexByte(column, '.'); i >= 0 {
+		return column[i+1:]
+	}
+	return column
+}
+
+func canonicalTokens(tokens []token) string {
+	return join(tokens, token.normalized)
+}
+
+type bucketSpec struct {
+	item         int
+	timeColumn   string
+	columnTokens []token
+	outputColumn string
+	step         time.Duration
+	phase        time.Duration
+}
+
+func (b bucketSpec) cadence() sqlanalyzer.Cadence {
+	return sqlanalyzer.Cadence{Step: b.step, Phase: b.phase}
+}
+
+func analyzeSelectList(items []selectItem) (bucketSpec, error) {
+	var found *bucketSpec
+	for i, item := range items {
+		bucket, ok, err := matchBucket(item.expr)
+		if err != nil {
+			return bucketSpec{}, err
+		}
+		if !ok {
+			continue
+		}
+		if found != nil {
+			return bucketSpec{}, ErrAmbiguousTimeAxis
+		}
+		if item.alias == "" {
+			return bucketSpec{}, fmt.Errorf("%w: time bucket expression requires an alias",
+				ErrMissingTimeseries)
+		}
+		bucket.item = i
+		bucket.outputColumn = item.alias
+		found = &bucket
+	}
+	if found == nil {
+		return bucketSpec{}, ErrMissingTimeseries
+	}
+	return *found, nil
+}
+
+var truncDurations = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    day,
+	"week":   week,
+}
+
+// matchBucket returns a bucketSpec if the expression is a supported time
+// bucket expression: date_bin(INTERVAL '<stride>', <column>[, <origin>]),
+// date_bin_gapfill with the same arguments, or date_trunc('<unit>', <column>)
+func matchBucket(expr []token) (bucketSpec, bool, error) {
+	name, args, ok := functionCall(expr)
+	if !ok {
+		return bucketSpec{}, false, nil
+	}
+	switch name {
+	case "date_bin", "date_bin_gapfill":
+		if len(args) != 2 && len(args) != 3 {
+			return bucketSpec{}, false, ErrMissingTimeseries
+		}
+		step, ok := intervalValue(args[0])
+		if !ok || step <= 0 {
+			return bucketSpec{}, false, ErrMissingTimeseries
+		}
+		column, ok := columnName(args[1])
+		if !ok {
+			return bucketSpec{}, false, ErrMissingTimeseries
+		}
+		bucket := bucketSpec{timeColumn: column, columnTokens: args[1], step: step}
+		if len(args) == 3 {
+			origin, ok := timestampLiteral(args[2])
+			if !ok {
+				return bucketSpec{}, false, ErrMissingTimeseries
+			}
+			bucket.phase = time.Duration(origin.UnixNano() % step.Nanoseconds())
+			if bucket.phase < 0 {
+				bucket.phase += step
+			}
+		}
+		return bucket, true, nil
+	case "date_trunc", "datetrunc":
+		if len(args) != 2 || len(args[0]) != 1 {
+			return bucketSpec{}, false, ErrMissingTimeseries
+		}
+		unit, _ := args[0][0].stringValue()
+		step, ok := truncDurations[strings.ToLower(unit)]
+		if !ok {
+			return bucketSpec{}, false, ErrMissingTimeseries
+		}
+		column, ok := columnName(args[1])
+		if !ok {
+			return bucketSpec{}, false, ErrMissingTimeseries
+		}
+		bucket := bucketSpec{timeColumn: column, columnTokens: args[1], step: step}
+		if step == week {
+			// weeks are truncated to Monday, and 1970-01-01 was a Thursday
+			bucket.phase = 4 * day
+		}
+		return bucket, true, nil
+	}
+	return bucketSpec{}, false, nil
+}
+
+// functionCall returns the lowercased name and the arguments of an expression
+// that consists solely of a function call
+func functionCall(expr []token) (string, [][]token, bool) {
+	if len(expr) < 3 || expr[0].kind != tokenWord || !expr[1].isOp("(") ||
+		!expr[len(expr)-1].isOp(")") || closingParen(expr, 1) != len(expr)-1 {
+		return "", nil, false
+	}
+	return strings.ToLower(expr[0].text), splitList(expr[2:len(expr)-1], expr[1].depth+1), true
+}
+
+// closingParen returns the index of the parenthesis closing the one at i
+func closingParen(tokens []token, i int) int {
+	for j := i + 1; j < len(tokens); j++ {
+		if tokens[j].depth == tokens[i].depth && tokens[j].isOp(")") {
+			return j
+		}
+	}
+	return -1
+}
+
+var intervalUnits = map[string]time.Duration{
+	"ns": time.Nanosecond, "nanosecond": time.Nanosecond, "nanoseconds": time.Nanosecond,
+	"us": time.Microsecond, "microsecond": time.Microsecond, "microseconds": time.Microsecond,
+	"ms": time.Millisecond, "millisecond": time.Millisecond, "milliseconds": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "secs": time.Second,
+	"second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute,
+	"minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": day, "day": day, "days": day,
+	"w": week, "week": week, "weeks": week,
+}
+
+// intervalValue returns the duration of an INTERVAL '<n> <unit> ...' literal.
+// Intervals in months or years do not have a fixed duration and fail.
+func intervalValue(tokens []token) (time.Duration, bool) {
+	if len(tokens) != 2 || !tokens[0].is("interval") {
+		return 0, false
+	}
+	s, ok := tokens[1].stringValue()
+	if !ok {
+		return 0, false
+	}
+	return parseInterval(s)
+}
+
+func parseInterval(s string) (time.Duration, bool) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return 0, false
+	}
+	var out time.Duration
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		j := 0
+		for j < len(f) && isDigit(f[j]) {
+			j++
+		}
+		if j == 0 {
+			return 0, false
+		}
+		n, err := strconv.ParseInt(f[:j], 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		unit := f[j:]
+		if unit == "" {
+			if i+1 >= len(fields) {
+				return 0, false
+			}
+			i++
+			unit = fields[i]
+		}
+		d, ok := intervalUnits[unit]
+		if !ok {
+			return 0, false
+		}
+		out += time.Duration(n) * d
+	}
+	return out, true
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// timestampLiteral returns the time of a '<time>' or TIMESTAMP '<time>' literal
+func timestampLiteral(tokens []token) (time.Time, bool) {
+	if len(tokens) == 2 && tokens[0].is("timestamp") {
+		tokens = tokens[1:]
+	}
+	if len(tokens) != 1 {
+		return time.Time{}, false
+	}
+	s, ok := tokens[0].stringValue()
+	if !ok {
+		return time.Time{}, false
+	}
+	return parseTime(s)
+}
+
+// analyzeGroupBy verifies that the statement groups by the time bucket and
+// every selected column, and returns the output names of the selected columns
+func analyzeGroupBy(tokens []token, items []selectItem, bucket bucketSpec) ([]string, error) {
+	if len(tokens) == 0 {
+		return nil, ErrInvalidGroupByClause
+	}
+	for _, t := range tokens {
+		if t.depth > 0 {
+			continue
+		}
+		switch strings.ToLower(t.text) {
+		case "all", "rollup", "cube", "grouping", "sets":
+			return nil, ErrInvalidGroupByClause
+		}
+	}
+	tagOutputs := make(map[int]string, len(items))
+	for i, item := range items {
+		if i == bucket.item {
+			continue
+		}
+		column, ok := columnName(item.expr)
+		if !ok {
+			continue
+		}
+		if item.alias != "" {
+			tagOutputs[i] = item.alias
+		} else {
+			tagOutputs[i] = outputName(column)
+		}
+	}
+	bucketExpr := canonicalTokens(items[bucket.item].expr)
+	var timestampGrouped bool
+	groups := make([]string, 0, len(tagOutputs))
+	seen := make(map[int]struct{}, len(tagOutputs))
+	for _, expr := range splitList(tokens, 0) {
+		i, ok := groupedItem(expr, items, bucketExpr, bucket.item)
+		if !ok {
+			return nil, ErrInvalidGroupByClause
+		}
+		if i == bucket.item {
+			timestampGrouped = true
+			continue
+		}
+		output, ok := tagOutputs[i]
+		if !ok {
+			return nil, ErrInvalidGroupByClause
+		}
+		if _, ok := seen[i]; !ok {
+			groups = append(groups, output)
+			seen[i] = struct{}{}
+		}
+	}
+	if !timestampGrouped || len(seen) != len(tagOutputs) {
+		return nil, ErrInvalidGroupByClause
+	}
+	return groups, nil
+}
+
+// analyzeOrderBy verifies that the statement is unordered or ordered only by
+// the ascending time bucket, which is the order of a cached result
+func analyzeOrderBy(tokens []token, items []selectItem, bucket bucketSpec) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	exprs := splitList(tokens, 0)
+	if len(exprs) != 1 {
+		return ErrInvalidOrderByClause
+	}
+	expr := exprs[0]
+	if n := len(expr); n > 2 && expr[n-2].is("nulls") {
+		expr = expr[:n-2]
+	}
+	if n := len(expr); n > 1 {
+		if expr[n-1].is("desc") {
+			return ErrInvalidOrderByClause
+		}
+		if expr[n-1].is("asc") {
+			expr = expr[:n-1]
+		}
+	}
+	i, ok := groupedItem(expr, items, canonicalTokens(items[bucket.item].expr), bucket.item)
+	if !ok || i != bucket.item {
+		return ErrInvalidOrderByClause
+	}
+	return nil
+}
+
+// groupedItem returns the index of the select item referenced by a GROUP BY
+// expression, which may be an ordinal, an alias, a column or the expression
+func groupedItem(expr []token, items []selectItem, bucketExpr string,
+	bucketItem int,
+) (int, bool) {
+	if len(expr) == 1 && expr[0].kind == tokenNumber {
+		n, err := strconv.Atoi(expr[0].text)
+		if err != nil || n < 1 || n > len(items) {
+			return 0, false
+		}
+		return n - 1, true
+	}
+	if canonicalTokens(expr) == bucketExpr {
+		return bucketItem, true
+	}
+	name, ok := columnName(expr)
+	if !ok {
+		return 0, false
+	}
+	for i, item := range items {
+		if item.alias == name {
+			return i, true
+		}
+	}
+	for i, item := range items {
+		if column, ok := columnName(item.expr); ok &&
+			(column == name || outputName(column) == name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,309 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+)
+
+var testNow = time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)
+
+// influxDBCompatibilityCorpus is the maintained compatibility contract for
+// the InfluxDB 3 SQL analyzer. Add production and dashboard query shapes here
+// with their expected classification before expanding the accepted surface.
+var influxDBCompatibilityCorpus = []struct {
+	name   string
+	query  string
+	mode   sqlanalyzer.CacheMode
+	reason sqlanalyzer.AnalysisReason
+	step   time.Duration
+	phase  time.Duration
+	groups []string
+}{
+	{
+		name: "date_bin with rfc3339 bounds",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS _time, host, avg(usage_user) " +
+			"FROM cpu WHERE time >= '2024-01-01T00:00:00Z' AND time < '2024-01-01T01:00:00Z' " +
+			"AND host = 'a' GROUP BY 1, host ORDER BY 1",
+		mode: sqlanalyzer.CacheModeDelta, reason: sqlanalyzer.ReasonDeltaCacheable,
+		step: time.Minute, groups: []string{"host"},
+	},
+	{
+		name: "quoted identifiers and epoch bounds",
+		query: `select DATE_BIN(interval '5 minutes', "time") as "time", "cpu"."host" h, ` +
+			`avg("usage") from "cpu" where "time" >= to_timestamp(1704067200) ` +
+			`and "time" < to_timestamp(1704070800) group by "time", h;`,
+		mode: sqlanalyzer.CacheModeDelta, reason: sqlanalyzer.ReasonDeltaCacheable,
+		step: 5 * time.Minute, groups: []string{"h"},
+	},
+	{
+		name: "date_bin_gapfill with origin",
+		query: "SELECT date_bin_gapfill(INTERVAL '1 hour', time, '1970-01-01T00:30:00Z') AS t, " +
+			"avg(temp) FROM home WHERE time >= TIMESTAMP '2024-01-01T00:30:00Z' " +
+			"AND time < TIMESTAMP '2024-01-01T06:30:00Z' GROUP BY t",
+		mode: sqlanalyzer.CacheModeDelta, reason: sqlanalyzer.ReasonDeltaCacheable,
+		step: time.Hour, phase: 30 * time.Minute,
+	},
+	{
+		name: "date_trunc week without upper bound",
+		query: "SELECT date_trunc('week', time) AS t, count(*) FROM cpu " +
+			"WHERE time >= '2024-01-01' GROUP BY t",
+		mode: sqlanalyzer.CacheModeDelta, reason: sqlanalyzer.ReasonDeltaCacheable,
+		step: 7 * 24 * time.Hour, phase: 4 * 24 * time.Hour,
+	},
+	{
+		name: "parenthesized predicates and disjunction of tags",
+		query: "SELECT date_bin(INTERVAL '1m', time) AS t, host, max(v) FROM cpu " +
+			"WHERE (time >= '2024-01-01T00:00:00Z' AND time < '2024-01-01T01:00:00Z') " +
+			"AND (host = 'a' OR host = 'b') GROUP BY t, host",
+		mode: sqlanalyzer.CacheModeDelta, reason: sqlanalyzer.ReasonDeltaCacheable,
+		step: time.Minute, groups: []string{"host"},
+	},
+	{
+		name: "relative unaligned lower bound",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, avg(v) FROM cpu " +
+			"WHERE time >= now() - INTERVAL '1 hour' GROUP BY t",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		name: "inclusive raw upper bound",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, avg(v) FROM cpu " +
+			"WHERE time >= '2024-01-01T00:00:00Z' AND time <= '2024-01-01T01:00:00Z' GROUP BY t",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		name: "raw BETWEEN",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, avg(v) FROM cpu " +
+			"WHERE time BETWEEN '2024-01-01T00:00:00Z' AND '2024-01-01T01:00:00Z' GROUP BY t",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		name: "time in a disjunction",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, avg(v) FROM cpu " +
+			"WHERE time >= '2024-01-01T00:00:00Z' OR host = 'a' GROUP BY t",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		name: "duplicate lower bounds",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, avg(v) FROM cpu " +
+			"WHERE time >= '2024-01-01T00:00:00Z' AND time > '2023-01-01T00:00:00Z' GROUP BY t",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonAmbiguousTimeAxis,
+	},
+	{
+		name: "month bucket",
+		query: "SELECT date_bin(INTERVAL '1 month', time) AS t, avg(v) FROM cpu " +
+			"WHERE time >= '2024-01-01T00:00:00Z' GROUP BY t",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedBucket,
+	},
+	{
+		name: "bucket without alias",
+		query: "SELECT date_bin(INTERVAL '1 minute', time), avg(v) FROM cpu " +
+			"WHERE time >= '2024-01-01T00:00:00Z' GROUP BY 1",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedBucket,
+	},
+	{
+		name:  "raw points",
+		query: "SELECT time, v FROM cpu WHERE time >= '2024-01-01T00:00:00Z'",
+		mode:  sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedBucket,
+	},
+	{
+		name: "ungrouped tag",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, host, avg(v) FROM cpu " +
+			"WHERE time >= '2024-01-01T00:00:00Z' GROUP BY t",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedGrouping,
+	},
+	{
+		name: "grouped by unselected column",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, avg(v) FROM cpu " +
+			"WHERE time >= '2024-01-01T00:00:00Z' GROUP BY t, region",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedGrouping,
+	},
+	{
+		name: "limit",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, avg(v) FROM cpu " +
+			"WHERE time >= '2024-01-01T00:00:00Z' GROUP BY t LIMIT 10",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedLimit,
+	},
+	{
+		name: "ascending order by bucket",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, avg(v) FROM cpu " +
+			"WHERE time >= '2024-01-01T00:00:00Z' GROUP BY t ORDER BY t ASC NULLS LAST",
+		mode: sqlanalyzer.CacheModeDelta, reason: sqlanalyzer.ReasonDeltaCacheable,
+		step: time.Minute,
+	},
+	{
+		name: "descending order by bucket",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, avg(v) FROM cpu " +
+			"WHERE time >= '2024-01-01T00:00:00Z' GROUP BY t ORDER BY t DESC",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		name: "order by value",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, host, avg(v) AS a FROM cpu " +
+			"WHERE time >= '2024-01-01T00:00:00Z' GROUP BY t, host ORDER BY a",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		name: "window function",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, " +
+			"round(sum(avg(v)) OVER (ORDER BY date_bin(INTERVAL '1 minute', time))) FROM cpu " +
+			"WHERE time >= '2024-01-01T00:00:00Z' GROUP BY t",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		name: "subquery",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, avg(v) FROM " +
+			"(SELECT * FROM cpu) WHERE time >= '2024-01-01T00:00:00Z' GROUP BY t",
+		mode: sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		name:  "common table expression",
+		query: "WITH c AS (SELECT * FROM cpu) SELECT * FROM c",
+		mode:  sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		name:  "no where clause",
+		query: "SELECT date_bin(INTERVAL '1 minute', time) AS t, avg(v) FROM cpu GROUP BY t",
+		mode:  sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonNotTimeRange,
+	},
+	{
+		name:  "unterminated literal",
+		query: "SELECT * FROM cpu WHERE host = 'a",
+		mode:  sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonInvalidSQL,
+	},
+	{
+		name:  "multiple statements",
+		query: "SELECT 1 FROM a; SELECT 2 FROM b",
+		mode:  sqlanalyzer.CacheModeObject, reason: sqlanalyzer.ReasonInvalidSQL,
+	},
+	{
+		name:  "non-select statement",
+		query: "SHOW TABLES",
+		mode:  sqlanalyzer.CacheModeNone, reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		name:  "empty statement",
+		query: "  ",
+		mode:  sqlanalyzer.CacheModeNone, reason: sqlanalyzer.ReasonInvalidSQL,
+	},
+}
+
+func TestCompatibilityCorpus(t *testing.T) {
+	for _, test := range influxDBCompatibilityCorpus {
+		t.Run(test.name, func(t *testing.T) {
+			a := Analyzer.Analyze(test.query, testNow)
+			if a.Mode != test.mode || a.Reason != test.reason {
+				t.Fatalf("expected %s/%s got %s/%s (%v)", test.mode, test.reason,
+					a.Mode, a.Reason, a.Err)
+			}
+			if test.mode != sqlanalyzer.CacheModeDelta {
+				if a.Plan != nil {
+					t.Error("expected nil plan")
+				}
+				return
+			}
+			if a.Plan.Step != test.step || a.Plan.Phase != test.phase {
+				t.Errorf("expected step %s phase %s got %s %s", test.step, test.phase,
+					a.Plan.Step, a.Plan.Phase)
+			}
+			if len(a.Plan.GroupColumns) != len(test.groups) {
+				t.Fatalf("expected groups %v got %v", test.groups, a.Plan.GroupColumns)
+			}
+			for i := range test.groups {
+				if a.Plan.GroupColumns[i] != test.groups[i] {
+					t.Errorf("expected groups %v got %v", test.groups, a.Plan.GroupColumns)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyzePlan(t *testing.T) {
+	a := Analyzer.Analyze("SELECT date_bin(INTERVAL '1 minute', time) AS _time, "+
+		"avg(v) FROM cpu WHERE time >= '2024-01-01T00:00:00Z' GROUP BY _time", testNow)
+	if a.Plan == nil {
+		t.Fatal(a.Err)
+	}
+	p := a.Plan
+	if p.TimeColumn != "time" || p.OutputColumn != "_time" {
+		t.Errorf("unexpected columns %s %s", p.TimeColumn, p.OutputColumn)
+	}
+	if !p.LowerBound.Value.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) ||
+		!p.LowerBound.Inclusive {
+		t.Errorf("unexpected lower bound %v", p.LowerBound)
+	}
+	if p.UpperBound != nil {
+		t.Errorf("expected nil upper bound got %v", p.UpperBound)
+	}
+	const expected = "select date_bin(interval '1 minute', time) as _time, avg(v) " +
+		"from cpu where time >= <$TS1$> and time < <$TS2$> group by _time"
+	if p.CanonicalSQL != expected {
+		t.Errorf("expected %s\ngot      %s", expected, p.CanonicalSQL)
+	}
+}
+
+func TestCanonicalIdentity(t *testing.T) {
+	const base = "SELECT date_bin(INTERVAL '1 minute', time) AS t, avg(v) FROM cpu " +
+		"WHERE time >= '2024-01-01T00:00:00Z' AND time < '2024-01-01T01:00:00Z' " +
+		"AND host = 'a' GROUP BY t"
+	canonical := func(q string) string {
+		a := Analyzer.Analyze(q, testNow)
+		if a.Plan == nil {
+			t.Fatal(a.Err)
+		}
+		return a.Plan.CanonicalSQL
+	}
+	c := canonical(base)
+	reformatted := "select   date_bin( interval '1 minute' , time ) as t,avg(v)\n" +
+		"from cpu /* comment */ where time >= '2024-01-01T02:00:00Z' -- comment\n" +
+		"and time < '2024-01-01T03:00:00Z' and host = 'a' group by t"
+	if got := canonical(reformatted); got != c {
+		t.Errorf("expected formatting and range changes to converge:\n%s\n%s", c, got)
+	}
+	if got := canonical(base[:len(base)-len("'a' GROUP BY t")] + "'b' GROUP BY t"); got == c {
+		t.Error("expected a changed predicate to change the canonical statement")
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"1 minute", time.Minute, true},
+		{"15 MINUTES", 15 * time.Minute, true},
+		{"1 hour 30 minutes", 90 * time.Minute, true},
+		{"10s", 10 * time.Second, true},
+		{"2 days", 48 * time.Hour, true},
+		{"1 month", 0, false},
+		{"minute", 0, false},
+		{"1", 0, false},
+		{"", 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			d, ok := parseInterval(test.input)
+			if d != test.expected || ok != test.ok {
+				t.Errorf("expected %s %t got %s %t", test.expected, test.ok, d, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import "errors"
+
+var (
+	// ErrInvalidSQL indicates that the statement could not be tokenized or has
+	// an invalid structure
+	ErrInvalidSQL = errors.New("invalid InfluxDB SQL")
+	// ErrNotTimeRangeQuery indicates that the statement cannot use delta caching.
+	ErrNotTimeRangeQuery = errors.New("query could not be identified as a time range query")
+	// ErrMissingTimeseries indicates that no supported bucket expression was found.
+	ErrMissingTimeseries = errors.New("no supported timeseries expression found")
+	// ErrNoLowerBound indicates that the query has no usable lower time bound.
+	ErrNoLowerBound = errors.New("no lower bound found in time range query")
+	// ErrInvalidGroupByClause indicates that GROUP BY is unsafe for delta caching.
+	ErrInvalidGroupByClause = errors.New("invalid or unsupported GROUP BY clause")
+	// ErrInvalidOrderByClause indicates that ORDER BY does not match the order
+	// in which cached results are written.
+	ErrInvalidOrderByClause = errors.New("invalid or unsupported ORDER BY clause")
+	// ErrUnsafePredicate indicates that a time predicate cannot be safely rewritten.
+	ErrUnsafePredicate = errors.New("time predicate cannot be safely rewritten")
+	// ErrAmbiguousTimeAxis indicates that more than one primary time range was found.
+	ErrAmbiguousTimeAxis = errors.New("query has multiple or ambiguous time axes")
+	// ErrUnsupportedStatement indicates a statement outside the analyzer subset.
+	ErrUnsupportedStatement = errors.New("unsupported InfluxDB SQL statement")
+	// ErrLimitUnsupported indicates the input has a LIMIT or OFFSET clause,
+	// which is currently unsupported in the caching layer
+	ErrLimitUnsupported = errors.New("limit queries are not supported")
+)
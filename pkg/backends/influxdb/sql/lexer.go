@@ -0,0 +1,311 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind uint8
+
+const (
+	tokenWord     tokenKind = iota // unquoted identifier or keyword
+	tokenIdent                     // "quoted identifier"
+	tokenString                    // 'string literal'
+	tokenNumber                    // numeric literal
+	tokenParam                     // $1 or $name placeholder
+	tokenOperator                  // operators and punctuation
+	tokenSlot                      // a time bound, which is only created during rendering
+)
+
+// token is a lexical element of a statement. depth is the parenthesis depth at
+// which the token appears, and adjacent is true when no whitespace or comment
+// separates the token from the one preceding it.
+type token struct {
+	kind     tokenKind
+	text     string
+	depth    int
+	adjacent bool
+	slot     int
+}
+
+// multiCharOperators are matched longest-first before single-char operators
+var multiCharOperators = []string{
+	"!~*", "->>", "::", ">=", "<=", "<>", "!=", "==", "||", "->", "=>", "@>",
+	"<@", "!~", "~*", "~~", "<<", ">>",
+}
+
+const singleCharOperators = "+-*/%<>=!~^&|#@?:;,.()[]{}"
+
+// lex splits an InfluxDB SQL statement into tokens, discarding comments
+func lex(statement string) ([]token, error) {
+	out := make([]token, 0, 64)
+	var depth int
+	adjacent := true
+	for i := 0; i < len(statement); {
+		c := statement[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			adjacent = false
+			i++
+			continue
+		case strings.HasPrefix(statement[i:], "--"):
+			for i < len(statement) && statement[i] != '\n' {
+				i++
+			}
+			adjacent = false
+			continue
+		case strings.HasPrefix(statement[i:], "/*"):
+			end, err := blockCommentEnd(statement, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+			adjacent = false
+			continue
+		}
+		t := token{depth: depth, adjacent: adjacent && len(out) > 0}
+		adjacent = true
+		var end int
+		switch {
+		case c == '\'':
+			t.kind = tokenString
+			end = quotedEnd(statement, i, '\'')
+		case c == '"' || c == '`':
+			t.kind = tokenIdent
+			end = quotedEnd(statement, i, c)
+		case isDigit(c) || (c == '.' && i+1 < len(statement) && isDigit(statement[i+1])):
+			t.kind = tokenNumber
+			end = numberEnd(statement, i)
+		case c == '$' && i+1 < len(statement) &&
+			(isDigit(statement[i+1]) || isWordByte(statement, i+1)):
+			t.kind = tokenParam
+			end = wordEnd(statement, i+1)
+		case isWordByte(statement, i):
+			t.kind = tokenWord
+			end = wordEnd(statement, i)
+		default:
+			t.kind = tokenOperator
+			end = operatorEnd(statement, i)
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated quote at position %d", ErrInvalidSQL, i)
+		}
+		if end == i {
+			return nil, fmt.Errorf("%w: unexpected character at position %d", ErrInvalidSQL, i)
+		}
+		t.text = statement[i:end]
+		switch t.text {
+		case "(", "[":
+			depth++
+		case ")", "]":
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("%w: unbalanced parentheses", ErrInvalidSQL)
+			}
+			t.depth = depth
+		}
+		out = append(out, t)
+		i = end
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("%w: unbalanced parentheses", ErrInvalidSQL)
+	}
+	return out, nil
+}
+
+// blockCommentEnd returns the index following the (possibly nested) block
+// comment starting at i
+func blockCommentEnd(statement string, i int) (int, error) {
+	var nesting int
+	for i < len(statement) {
+		switch {
+		case strings.HasPrefix(statement[i:], "/*"):
+			nesting++
+			i += 2
+		case strings.HasPrefix(statement[i:], "*/"):
+			nesting--
+			i += 2
+			if nesting == 0 {
+				return i, nil
+			}
+		default:
+			i++
+		}
+	}
+	return 0, fmt.Errorf("%w: unterminated comment", ErrInvalidSQL)
+}
+
+// quotedEnd returns the index following the quoted element starting at i, or
+// -1 if it is unterminated. A doubled quote character is an escaped quote.
+func quotedEnd(statement string, i int, quote byte) int {
+	for j := i + 1; j < len(statement); j++ {
+		if statement[j] != quote {
+			continue
+		}
+		if j+1 < len(statement) && statement[j+1] == quote {
+			j++
+			continue
+		}
+		return j + 1
+	}
+	return -1
+}
+
+func numberEnd(statement string, i int) int {
+	for i < len(statement) && isDigit(statement[i]) {
+		i++
+	}
+	if i < len(statement) && statement[i] == '.' {
+		i++
+		for i < len(statement) && isDigit(statement[i]) {
+			i++
+		}
+	}
+	if i < len(statement) && (statement[i] == 'e' || statement[i] == 'E') {
+		j := i + 1
+		if j < len(statement) && (statement[j] == '+' || statement[j] == '-') {
+			j++
+		}
+		if j < len(statement) && isDigit(statement[j]) {
+			i = j
+			for i < len(statement) && isDigit(statement[i]) {
+				i++
+			}
+		}
+	}
+	return i
+}
+
+func wordEnd(statement string, i int) int {
+	for i < len(statement) && (isWordByte(statement, i) || isDigit(statement[i]) ||
+		statement[i] == '$') {
+		_, size := utf8.DecodeRuneInString(statement[i:])
+		i += size
+	}
+	return i
+}
+
+func operatorEnd(statement string, i int) int {
+	for _, op := range multiCharOperators {
+		if strings.HasPrefix(statement[i:], op) {
+			return i + len(op)
+		}
+	}
+	if strings.IndexByte(singleCharOperators, statement[i]) >= 0 {
+		return i + 1
+	}
+	return i
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordByte(statement string, i int) bool {
+	c := statement[i]
+	if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		return true
+	}
+	if c < utf8.RuneSelf {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(statement[i:])
+	return unicode.IsLetter(r)
+}
+
+// is returns true if the token is the provided keyword
+func (t token) is(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+// isOp returns true if the token is the provided operator
+func (t token) isOp(op string) bool {
+	return t.kind == tokenOperator && t.text == op
+}
+
+// normalized returns the token's text for the canonical statement. Unquoted
+// identifiers and keywords are case-insensitive, so they are lowercased.
+func (t token) normalized() string {
+	if t.kind == tokenWord {
+		return strings.ToLower(t.text)
+	}
+	return t.text
+}
+
+// identifier returns the name represented by a word or quoted identifier token
+func (t token) identifier() (string, bool) {
+	switch t.kind {
+	case tokenWord:
+		return strings.ToLower(t.text), true
+	case tokenIdent:
+		q := t.text[:1]
+		return strings.ReplaceAll(t.text[1:len(t.text)-1], q+q, q), true
+	}
+	return "", false
+}
+
+// stringValue returns the unquoted value of a string literal token
+func (t token) stringValue() (string, bool) {
+	if t.kind != tokenString {
+		return "", false
+	}
+	return strings.ReplaceAll(t.text[1:len(t.text)-1], "''", "'"), true
+}
+
+// separator returns the whitespace to write between two tokens when joining
+// them into a statement. Formatting differences are collapsed, except where
+// removing or adding whitespace could change the meaning of the tokens.
+func separator(prev, next token) string {
+	switch {
+	case next.adjacent && prev.kind == tokenOperator && next.kind == tokenOperator &&
+		!isPunctuation(prev) && !isPunctuation(next):
+		return ""
+	case next.adjacent && prev.kind == tokenWord && next.kind == tokenString:
+		return ""
+	case prev.isOp(".") || next.isOp("."):
+		return ""
+	case prev.isOp("(") || next.isOp(")") || next.isOp(","):
+		return ""
+	case next.isOp("(") && next.adjacent && (prev.kind == tokenWord || prev.kind == tokenIdent):
+		return ""
+	}
+	return " "
+}
+
+func isPunctuation(t token) bool {
+	switch t.text {
+	case "(", ")", "[", "]", ",", ".", ";":
+		return true
+	}
+	return false
+}
+
+// join writes tokens into a statement using the provided text function
+func join(tokens []token, text func(token) string) string {
+	var sb strings.Builder
+	for i, t := range tokens {
+		if i > 0 {
+			sb.WriteString(separator(tokens[i-1], t))
+		}
+		sb.WriteString(text(t))
+	}
+	return sb.String()
+}
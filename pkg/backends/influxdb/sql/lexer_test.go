@@ -0,0 +1,80 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLex(t *testing.T) {
+	tokens, err := lex(`SELECT "a""b", 'it''s', 1.5e3, $1 FROM t -- c
+	WHERE (x::int >= -2) /* a /* nested */ comment */`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		kind  tokenKind
+		text  string
+		depth int
+	}{
+		{tokenWord, "SELECT", 0}, {tokenIdent, `"a""b"`, 0}, {tokenOperator, ",", 0},
+		{tokenString, "'it''s'", 0}, {tokenOperator, ",", 0}, {tokenNumber, "1.5e3", 0},
+		{tokenOperator, ",", 0}, {tokenParam, "$1", 0}, {tokenWord, "FROM", 0},
+		{tokenWord, "t", 0}, {tokenWord, "WHERE", 0}, {tokenOperator, "(", 0},
+		{tokenWord, "x", 1}, {tokenOperator, "::", 1}, {tokenWord, "int", 1},
+		{tokenOperator, ">=", 1}, {tokenOperator, "-", 1}, {tokenNumber, "2", 1},
+		{tokenOperator, ")", 0},
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens got %d", len(expected), len(tokens))
+	}
+	for i, e := range expected {
+		if tokens[i].kind != e.kind || tokens[i].text != e.text || tokens[i].depth != e.depth {
+			t.Errorf("token %d: expected %v got %v", i, e, tokens[i])
+		}
+	}
+	if id, _ := tokens[1].identifier(); id != `a"b` {
+		t.Errorf("expected a\"b got %s", id)
+	}
+	if s, _ := tokens[3].stringValue(); s != "it's" {
+		t.Errorf("expected it's got %s", s)
+	}
+}
+
+func TestLexErrors(t *testing.T) {
+	for _, input := range []string{
+		"SELECT 'a", `SELECT "a`, "SELECT (1", "SELECT 1)", "SELECT /* a", "SELECT \\",
+	} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := lex(input); !errors.Is(err, ErrInvalidSQL) {
+				t.Errorf("expected %v got %v", ErrInvalidSQL, err)
+			}
+		})
+	}
+}
+
+func TestJoin(t *testing.T) {
+	tokens, err := lex("SELECT  count( * ),E'\\n' ,a.b FROM x WHERE y!~z AND f (1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expected = "SELECT count(*), E'\\n', a.b FROM x WHERE y !~ z AND f (1)"
+	if out := join(tokens, func(t token) string { return t.text }); out != expected {
+		t.Errorf("expected %s got %s", expected, out)
+	}
+}
@@ -0,0 +1,348 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+type boundStyle uint8
+
+const (
+	boundString      boundStyle = iota // '2024-01-01T00:00:00Z'
+	boundTimestamp                     // TIMESTAMP '2024-01-01T00:00:00Z'
+	boundCast                          // '2024-01-01T00:00:00Z'::timestamp
+	boundUnixSeconds                   // to_timestamp(1704067200)
+	boundUnixMilli                     // to_timestamp_millis(1704067200000)
+	boundUnixMicro                     // to_timestamp_micros(1704067200000000)
+	boundUnixNano                      // to_timestamp_nanos(1704067200000000000)
+)
+
+var epochFunctions = map[string]boundStyle{
+	"to_timestamp":         boundUnixSeconds,
+	"to_timestamp_seconds": boundUnixSeconds,
+	"to_timestamp_millis":  boundUnixMilli,
+	"to_timestamp_micros":  boundUnixMicro,
+	"to_timestamp_nanos":   boundUnixNano,
+}
+
+func (s boundStyle) inputUnit() timeseries.FieldDataType {
+	switch s {
+	case boundUnixSeconds:
+		return timeseries.DateTimeUnixSecs
+	case boundUnixMilli:
+		return timeseries.DateTimeUnixMilli
+	case boundUnixMicro:
+		return timeseries.DateTimeUnixMicro
+	case boundUnixNano:
+		return timeseries.DateTimeUnixNano
+	default:
+		return timeseries.DateTimeRFC3339Nano
+	}
+}
+
+type endpoint uint8
+
+const (
+	endpointLower endpoint = iota
+	endpointUpper
+)
+
+// analyzedBound is a time bound found in the WHERE clause. start and end are
+// the [start, end) token indexes of the bound's expression in the statement.
+type analyzedBound struct {
+	value     time.Time
+	inclusive bool
+	style     boundStyle
+	function  string
+	start     int
+	end       int
+}
+
+func (b analyzedBound) bound() sqlanalyzer.Bound {
+	return sqlanalyzer.Bound{Value: b.value, Inclusive: b.inclusive}
+}
+
+type rangeAnalysis struct {
+	lower    analyzedBound
+	upper    *analyzedBound
+	whereEnd int
+}
+
+// analyzeRanges finds the time range of the statement. Every WHERE conjunct
+// that references the time column must be a comparison of the raw column to
+// a constant time, and conjuncts are only separated by AND; anything else
+// fails closed. Raw timestamp predicates must describe complete buckets, so
+// the lower bound must be an aligned >= and the upper bound an aligned <.
+func analyzeRanges(q *query, bucket bucketSpec, now time.Time) (rangeAnalysis, error) {
+	b := q.bounds[clauseWhere]
+	if b[0] < 0 {
+		return rangeAnalysis{}, ErrNotTimeRangeQuery
+	}
+	result := rangeAnalysis{whereEnd: b[1]}
+	conjuncts, err := splitConjunction(q.tokens, b[0], b[1], bucket.timeColumn)
+	if err != nil {
+		return result, err
+	}
+	var lower *analyzedBound
+	for _, c := range conjuncts {
+		lb, ub, err := analyzePredicate(q.tokens, c[0], c[1], bucket, now)
+		if err != nil {
+			return result, err
+		}
+		if lb != nil {
+			if lower != nil {
+				return result, ErrAmbiguousTimeAxis
+			}
+			lower = lb
+		}
+		if ub != nil {
+			if result.upper != nil {
+				return result, ErrAmbiguousTimeAxis
+			}
+			result.upper = ub
+		}
+	}
+	if lower == nil {
+		return result, fmt.Errorf("%w: time column %q did not match a lower range predicate",
+			ErrNoLowerBound, bucket.timeColumn)
+	}
+	result.lower = *lower
+	if _, ok := sqlanalyzer.NormalizeLower(result.lower.bound(), false, bucket.cadence()); !ok {
+		return result, ErrUnsafePredicate
+	}
+	if result.upper != nil {
+		if _, ok := sqlanalyzer.NormalizeUpper(result.upper.bound(), false, bucket.cadence(), 0); !ok {
+			return result, ErrUnsafePredicate
+		}
+	}
+	return result, nil
+}
+
+// splitConjunction returns the [start, end) token indexes of each conjunct in
+// tokens[start:end], descending into conjunctions wrapped in parentheses. A
+// disjunction is returned as a single conjunct, unless it references the time
+// column, which is unsafe.
+func splitConjunction(tokens []token, start, end int, column string) ([][2]int, error) {
+	if start >= end {
+		return nil, fmt.Errorf("%w: empty predicate", ErrInvalidSQL)
+	}
+	if tokens[start].isOp("(") && start+closingParen(tokens[start:end], 0) == end-1 {
+		return splitConjunction(tokens, start+1, end-1, column)
+	}
+	depth := tokens[start].depth
+	out := make([][2]int, 0, 4)
+	var inBetween bool
+	from := start
+	for i := start; i < end; i++ {
+		t := tokens[i]
+		if t.depth != depth {
+			continue
+		}
+		switch {
+		case t.is("or"):
+			if referencesColumn(tokens[start:end], column) {
+				return nil, ErrUnsafePredicate
+			}
+			return [][2]int{{start, end}}, nil
+		case t.is("between"):
+			inBetween = true
+		case t.is("and"):
+			if inBetween {
+				inBetween = false
+				continue
+			}
+			parts, err := splitConjunction(tokens, from, i, column)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, parts...)
+			from = i + 1
+		}
+	}
+	if from == start {
+		return append(out, [2]int{start, end}), nil
+	}
+	parts, err := splitConjunction(tokens, from, end, column)
+	if err != nil {
+		return nil, err
+	}
+	return append(out, parts...), nil
+}
+
+// referencesColumn returns true if any identifier in the tokens could refer
+// to the column
+func referencesColumn(tokens []token, column string) bool {
+	name := outputName(column)
+	for _, t := range tokens {
+		if id, ok := t.identifier(); ok && id == name {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzePredicate returns the bounds described by the conjunct in
+// tokens[start:end], which are nil when it does not reference the time column
+func analyzePredicate(tokens []token, start, end int, bucket bucketSpec,
+	now time.Time,
+) (*analyzedBound, *analyzedBound, error) {
+	conjunct := tokens[start:end]
+	if !referencesColumn(conjunct, bucket.timeColumn) {
+		return nil, nil, nil
+	}
+	depth := conjunct[0].depth
+	op := -1
+	for i, t := range conjunct {
+		if t.depth != depth {
+			continue
+		}
+		if t.kind == tokenWord && !t.is("interval") && !t.is("timestamp") {
+			if _, ok := t.identifier(); ok && i+1 < len(conjunct) && conjunct[i+1].isOp("(") {
+				continue // function name
+			}
+			switch strings.ToLower(t.text) {
+			case "between", "in", "not", "is", "like", "ilike", "similar", "at":
+				return nil, nil, ErrUnsafePredicate
+			}
+		}
+		if t.kind != tokenOperator {
+			continue
+		}
+		switch t.text {
+		case ">", ">=", "<", "<=":
+		case "=", "==", "<>", "!=":
+			return nil, nil, ErrUnsafePredicate
+		default:
+			continue
+		}
+		if op >= 0 {
+			return nil, nil, ErrUnsafePredicate
+		}
+		op = i
+	}
+	if op < 0 {
+		return nil, nil, ErrUnsafePredicate
+	}
+	operator := conjunct[op].text
+	bs, be := start+op+1, end
+	if column, ok := columnName(conjunct[:op]); !ok || column != bucket.timeColumn {
+		column, ok = columnName(conjunct[op+1:])
+		if !ok || column != bucket.timeColumn {
+			return nil, nil, ErrUnsafePredicate
+		}
+		bs, be = start, start+op
+		operator = sqlanalyzer.InvertComparator(operator)
+	}
+	if referencesColumn(tokens[bs:be], bucket.timeColumn) {
+		return nil, nil, ErrUnsafePredicate
+	}
+	bound, ok := evaluateBound(tokens[bs:be], now)
+	if !ok {
+		return nil, nil, ErrUnsafePredicate
+	}
+	bound.start, bound.end = bs, be
+	bound.inclusive = operator == ">=" || operator == "<="
+	if operator == ">" || operator == ">=" {
+		return &bound, nil, nil
+	}
+	return nil, &bound, nil
+}
+
+// evaluateBound evaluates a constant time expression, which is a time literal,
+// now(), or an epoch converted by a to_timestamp function, optionally followed
+// by the addition or subtraction of intervals
+func evaluateBound(tokens []token, now time.Time) (analyzedBound, bool) {
+	if len(tokens) == 0 {
+		return analyzedBound{}, false
+	}
+	depth := tokens[0].depth
+	terms := make([][]token, 0, 2)
+	signs := make([]time.Duration, 0, 2)
+	from := 0
+	for i, t := range tokens {
+		if t.depth == depth && (t.isOp("+") || t.isOp("-")) {
+			terms = append(terms, tokens[from:i])
+			from = i + 1
+			sign := time.Duration(1)
+			if t.isOp("-") {
+				sign = -1
+			}
+			signs = append(signs, sign)
+		}
+	}
+	terms = append(terms, tokens[from:])
+	out, ok := evaluateTime(terms[0], now)
+	if !ok {
+		return analyzedBound{}, false
+	}
+	for i, term := range terms[1:] {
+		d, ok := intervalValue(term)
+		if !ok {
+			return analyzedBound{}, false
+		}
+		out.value = out.value.Add(signs[i] * d)
+	}
+	return out, true
+}
+
+func evaluateTime(tokens []token, now time.Time) (analyzedBound, bool) {
+	switch {
+	case len(tokens) == 1 && tokens[0].kind == tokenString:
+		t, ok := timestampLiteral(tokens)
+		return analyzedBound{value: t, style: boundString}, ok
+	case len(tokens) == 2 && tokens[0].is("timestamp"):
+		t, ok := timestampLiteral(tokens)
+		return analyzedBound{value: t, style: boundTimestamp}, ok
+	case len(tokens) == 3 && tokens[1].isOp("::") && tokens[2].is("timestamp"):
+		t, ok := timestampLiteral(tokens[:1])
+		return analyzedBound{value: t, style: boundCast}, ok
+	}
+	name, args, ok := functionCall(tokens)
+	if !ok {
+		return analyzedBound{}, false
+	}
+	if name == "now" && len(args) == 0 {
+		return analyzedBound{value: now, style: boundString}, true
+	}
+	style, ok := epochFunctions[name]
+	if !ok || len(args) != 1 || len(args[0]) != 1 || args[0][0].kind != tokenNumber {
+		return analyzedBound{}, false
+	}
+	v, err := strconv.ParseInt(args[0][0].text, 10, 64)
+	if err != nil {
+		return analyzedBound{}, false
+	}
+	var t time.Time
+	switch style {
+	case boundUnixMilli:
+		t = time.UnixMilli(v)
+	case boundUnixMicro:
+		t = time.UnixMicro(v)
+	case boundUnixNano:
+		t = time.Unix(0, v)
+	default:
+		t = time.Unix(v, 0)
+	}
+	return analyzedBound{value: t, style: style, function: strings.ToLower(tokens[0].text)}, true
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+// renderer renders a statement for an extent. It holds an immutable copy of
+// the statement's tokens, with each time bound's expression replaced by a
+// slot token, so rendering never modifies or re-parses the statement, and user
+// literals resembling placeholders can never be mistaken for a bound.
+type renderer struct {
+	tokens []token
+	bounds []rendererBound
+}
+
+type rendererBound struct {
+	endpoint endpoint
+	style    boundStyle
+	function string
+	offset   time.Duration
+}
+
+// RenderExtent renders the statement for the extent, whose Start and End are
+// the first and final included buckets
+func (r *renderer) RenderExtent(extent timeseries.Extent) (string, error) {
+	return join(r.tokens, func(t token) string {
+		if t.kind != tokenSlot {
+			return t.text
+		}
+		b := r.bounds[t.slot]
+		value := extent.Start
+		if b.endpoint == endpointUpper {
+			value = extent.End
+		}
+		return formatBound(value.Add(b.offset), b.style, b.function)
+	}), nil
+}
+
+func formatBound(value time.Time, style boundStyle, function string) string {
+	literal := "'" + value.UTC().Format(time.RFC3339Nano) + "'"
+	switch style {
+	case boundTimestamp:
+		return "TIMESTAMP " + literal
+	case boundCast:
+		return literal + "::timestamp"
+	case boundUnixSeconds:
+		if function == "" {
+			function = "to_timestamp"
+		}
+		return function + "(" + strconv.FormatInt(value.Unix(), 10) + ")"
+	case boundUnixMilli:
+		return "to_timestamp_millis(" + strconv.FormatInt(value.UnixMilli(), 10) + ")"
+	case boundUnixMicro:
+		return "to_timestamp_micros(" + strconv.FormatInt(value.UnixMicro(), 10) + ")"
+	case boundUnixNano:
+		return "to_timestamp_nanos(" + strconv.FormatInt(value.UnixNano(), 10) + ")"
+	}
+	return literal
+}
+
+func placeholderFor(target endpoint) string {
+	if target == endpointLower {
+		return "<$TS1$>"
+	}
+	return "<$TS2$>"
+}
+
+// buildQueryArtifacts returns the canonical statement, in which the time
+// bounds are replaced by fixed placeholders, and the statement's renderer.
+// Extents are inclusive of their End bucket, while the upper bound is an
+// exclusive comparison, so the upper bound is rendered one step after the End.
+// When the statement has no upper bound, one is added to the WHERE clause.
+func buildQueryArtifacts(q *query, ranges rangeAnalysis, bucket bucketSpec) (string, *renderer) {
+	r := &renderer{
+		tokens: make([]token, 0, len(q.tokens)+len(bucket.columnTokens)+3),
+		bounds: make([]rendererBound, 0, 2),
+	}
+	addSlot := func(b analyzedBound, target endpoint, offset time.Duration, depth int) {
+		r.tokens = append(r.tokens, token{kind: tokenSlot, slot: len(r.bounds), depth: depth})
+		r.bounds = append(r.bounds, rendererBound{
+			endpoint: target, style: b.style, function: b.function, offset: offset,
+		})
+	}
+	for i := 0; i <= len(q.tokens); i++ {
+		if i == ranges.whereEnd && ranges.upper == nil {
+			depth := q.tokens[ranges.whereEnd-1].depth
+			r.tokens = append(r.tokens, token{kind: tokenWord, text: "AND", depth: depth})
+			for j, t := range bucket.columnTokens {
+				t.depth = depth
+				t.adjacent = j > 0
+				r.tokens = append(r.tokens, t)
+			}
+			r.tokens = append(r.tokens, token{kind: tokenOperator, text: "<", depth: depth})
+			addSlot(ranges.lower, endpointUpper, bucket.step, depth)
+		}
+		if i == len(q.tokens) {
+			break
+		}
+		switch {
+		case i == ranges.lower.start:
+			addSlot(ranges.lower, endpointLower, 0, q.tokens[i].depth)
+			i = ranges.lower.end - 1
+		case ranges.upper != nil && i == ranges.upper.start:
+			addSlot(*ranges.upper, endpointUpper, bucket.step, q.tokens[i].depth)
+			i = ranges.upper.end - 1
+		default:
+			r.tokens = append(r.tokens, q.tokens[i])
+		}
+	}
+	canonical := join(r.tokens, func(t token) string {
+		if t.kind == tokenSlot {
+			return placeholderFor(r.bounds[t.slot].endpoint)
+		}
+		return t.normalized()
+	})
+	return canonical, r
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func testExtent(startHour, endHour int) timeseries.Extent {
+	return timeseries.Extent{
+		Start: time.Date(2024, 1, 1, startHour, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 1, 1, endHour, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestRenderExtent(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name: "rfc3339 bounds",
+			query: "SELECT date_bin(INTERVAL '1 hour', time) AS t, avg(v) FROM cpu " +
+				"WHERE time >= '2024-01-01T00:00:00Z' AND time < '2024-01-01T01:00:00Z' GROUP BY t",
+			expected: "SELECT date_bin(INTERVAL '1 hour', time) AS t, avg(v) FROM cpu " +
+				"WHERE time >= '2024-01-01T02:00:00Z' AND time < '2024-01-01T05:00:00Z' GROUP BY t",
+		},
+		{
+			name: "reversed operands and typed bounds",
+			query: "SELECT date_bin(INTERVAL '1 hour', time) AS t, avg(v) FROM cpu " +
+				"WHERE TIMESTAMP '2024-01-01T00:00:00Z' <= time " +
+				"AND '2024-01-01T01:00:00Z'::timestamp > time GROUP BY t ORDER BY t",
+			expected: "SELECT date_bin(INTERVAL '1 hour', time) AS t, avg(v) FROM cpu " +
+				"WHERE TIMESTAMP '2024-01-01T02:00:00Z' <= time " +
+				"AND '2024-01-01T05:00:00Z'::timestamp > time GROUP BY t ORDER BY t",
+		},
+		{
+			name: "epoch bounds",
+			query: "SELECT date_bin(INTERVAL '1 hour', time) AS t, avg(v) FROM cpu " +
+				"WHERE time >= to_timestamp_millis(1704067200000) " +
+				"AND time < to_timestamp_seconds(1704070800) GROUP BY t",
+			expected: "SELECT date_bin(INTERVAL '1 hour', time) AS t, avg(v) FROM cpu " +
+				"WHERE time >= to_timestamp_millis(1704074400000) " +
+				"AND time < to_timestamp_seconds(1704085200) GROUP BY t",
+		},
+		{
+			name: "synthetic upper bound",
+			query: "SELECT date_bin(INTERVAL '1 hour', cpu.time) AS t, avg(v) FROM cpu " +
+				"WHERE cpu.time >= '2024-01-01T00:00:00Z' AND host = 'a' GROUP BY t",
+			expected: "SELECT date_bin(INTERVAL '1 hour', cpu.time) AS t, avg(v) FROM cpu " +
+				"WHERE cpu.time >= '2024-01-01T02:00:00Z' AND host = 'a' " +
+				"AND cpu.time < '2024-01-01T05:00:00Z' GROUP BY t",
+		},
+		{
+			name: "synthetic upper bound before group by",
+			query: "SELECT date_bin(INTERVAL '1 hour', time) AS t, count(*) FROM cpu " +
+				"WHERE time >= '2024-01-01T00:00:00Z' GROUP BY 1",
+			expected: "SELECT date_bin(INTERVAL '1 hour', time) AS t, count(*) FROM cpu " +
+				"WHERE time >= '2024-01-01T02:00:00Z' AND time < '2024-01-01T05:00:00Z' GROUP BY 1",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := Analyzer.Analyze(test.query, testNow)
+			if a.Plan == nil {
+				t.Fatal(a.Err)
+			}
+			out, err := a.Plan.RenderExtent(testExtent(2, 4))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != test.expected {
+				t.Errorf("expected %s\ngot      %s", test.expected, out)
+			}
+		})
+	}
+}
+
+func TestRenderPlaceholderCollision(t *testing.T) {
+	a := Analyzer.Analyze("SELECT date_bin(INTERVAL '1 hour', time) AS t, count(*) FROM cpu "+
+		"WHERE time >= '2024-01-01T00:00:00Z' AND time < '2024-01-01T01:00:00Z' "+
+		"AND note = '<$TS1$> <$TS2$>' GROUP BY t", testNow)
+	if a.Plan == nil {
+		t.Fatal(a.Err)
+	}
+	out, err := a.Plan.RenderExtent(testExtent(2, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "note = '<$TS1$> <$TS2$>'") {
+		t.Errorf("expected user literal to survive rendering: %s", out)
+	}
+	if !strings.Contains(a.Plan.CanonicalSQL, "note = '<$TS1$> <$TS2$>'") {
+		t.Errorf("expected user literal in canonical statement: %s", a.Plan.CanonicalSQL)
+	}
+}
+
+func TestRenderConcurrency(t *testing.T) {
+	a := Analyzer.Analyze("SELECT date_bin(INTERVAL '1 hour', time) AS t, count(*) FROM cpu "+
+		"WHERE time >= '2024-01-01T00:00:00Z' GROUP BY t", testNow)
+	if a.Plan == nil {
+		t.Fatal(a.Err)
+	}
+	const workers = 24
+	out := make([]string, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Go(func() {
+			out[i], _ = a.Plan.RenderExtent(testExtent(0, i))
+		})
+	}
+	wg.Wait()
+	for i := range workers {
+		expected := fmt.Sprintf("time < '2024-01-%02dT%02d:00:00Z'", 1+(i+1)/24, (i+1)%24)
+		if !strings.Contains(out[i], expected) {
+			t.Errorf("expected %s in %s", expected, out[i])
+		}
+	}
+}
@@ -21,8 +21,10 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/apiv3"
 	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb/flux"
 	ti "github.com/trickstercache/trickster/v2/pkg/backends/influxdb/influxql"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
 	"github.com/trickstercache/trickster/v2/pkg/timeseries"
 
 	"github.com/influxdata/influxql"
@@ -56,6 +58,11 @@ func (c *Client) SetExtent(r *http.Request, trq *timeseries.TimeRangeQuery,
 		ti.SetExtent(r, trq, extent, q)
 	case *flux.Query:
 		flux.SetExtent(r, trq, extent, q)
+	case *sqlanalyzer.QueryPlan:
+		if err := apiv3.SetExtent(r, trq, extent, q); err != nil {
+			c.observeRewriteFailure("render_error")
+			return err
+		}
 	default:
 		return fmt.Errorf("unsupported InfluxDB parsed query type %T", trq.ParsedQuery)
 	}
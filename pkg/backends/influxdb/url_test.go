@@ -89,6 +89,26 @@ func TestSetExtent(t *testing.T) {
 		}
 	})
 
+	t.Run("v3 sql with query plan", func(t *testing.T) {
+		qs := url.Values{"db": {"test"}, "q": {"SELECT date_bin(INTERVAL '1 second', time) AS t, " +
+			"count(*) FROM cpu WHERE time >= '" + start.Format(time.RFC3339) + "' GROUP BY t"}}.Encode()
+		r, _ := http.NewRequest(http.MethodGet, "http://example.com/api/v3/query_sql?"+qs, nil)
+		trq, _, _, err := c.ParseTimeRangeQuery(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.SetExtent(r, trq, ext); err != nil {
+			t.Fatal(err)
+		}
+		expected := "time < '" + end.Add(time.Second).Format(time.RFC3339) + "'"
+		if got := r.URL.Query().Get("q"); !strings.Contains(got, expected) {
+			t.Errorf("expected rewritten query with %s, got %q", expected, got)
+		}
+		if got := r.URL.Query().Get("format"); got != "jsonl" {
+			t.Errorf("expected jsonl format, got %q", got)
+		}
+	})
+
 	t.Run("parse failure is a no-op", func(t *testing.T) {
 		r, _ := http.NewRequest(http.MethodGet, "http://example.com/?q=not-a-query", nil)
 		before := r.URL.RawQuery
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loki
+
+import (
+	"net/http"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/engines"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/params"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/urls"
+)
+
+// LabelsHandler proxies requests for path /label and /labels to the origin by way of the object proxy cache
+func (c *Client) LabelsHandler(w http.ResponseWriter, r *http.Request) {
+	c.roundedObjectProxyCacheRequest(w, r)
+}
+
+// SeriesHandler proxies requests for path /series to the origin by way of the object proxy cache
+func (c *Client) SeriesHandler(w http.ResponseWriter, r *http.Request) {
+	c.roundedObjectProxyCacheRequest(w, r)
+}
+
+// roundedObjectProxyCacheRequest rounds the start and end of the request to
+// the minute for cacheability, and serves it through the object proxy cache
+func (c *Client) roundedObjectProxyCacheRequest(w http.ResponseWriter, r *http.Request) {
+	u := urls.BuildUpstreamURL(r, c.BaseUpstreamURL())
+	qp, _, _ := params.GetRequestValues(r)
+
+	// start rounds down, end rounds up — see roundTimestampsToMinute
+	roundTimestampsToMinute(qp)
+
+	r.URL = u
+	params.SetRequestValues(r, qp)
+
+	engines.ObjectProxyCacheRequest(w, r)
+}
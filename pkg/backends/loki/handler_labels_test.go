@@ -0,0 +1,96 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loki
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+	tu "github.com/trickstercache/trickster/v2/pkg/testutil"
+)
+
+func TestHandlers(t *testing.T) {
+	tests := []struct {
+		path     string
+		handler  func(*Client) func(w http.ResponseWriter, r *http.Request)
+		expected string
+		query    string
+	}{
+		{
+			path:     APIPath + mnLabels + "?start=1700000010000000000&end=1700000070000000000",
+			handler:  func(c *Client) func(w http.ResponseWriter, r *http.Request) { return c.LabelsHandler },
+			expected: "engine=ObjectProxyCache",
+			query:    "end=1700000100000000000&start=1699999980000000000",
+		},
+		{
+			path:     APIPath + mnSeries + "?start=1700000010&end=1700000070",
+			handler:  func(c *Client) func(w http.ResponseWriter, r *http.Request) { return c.SeriesHandler },
+			expected: "engine=ObjectProxyCache",
+			query:    "end=1700000100000000000&start=1699999980000000000",
+		},
+		{
+			path:     APIPath + mnQuery + "?query=count_over_time(%7Bapp%3D%22foo%22%7D%5B1m%5D)&time=1700000010",
+			handler:  func(c *Client) func(w http.ResponseWriter, r *http.Request) { return c.QueryHandler },
+			expected: "engine=ObjectProxyCache",
+			query:    "time=1700000010000000000",
+		},
+		{
+			path:     APIPath + mnQuery + "?query=%7Bapp%3D%22foo%22%7D&time=1700000010",
+			handler:  func(c *Client) func(w http.ResponseWriter, r *http.Request) { return c.QueryHandler },
+			expected: "engine=HTTPProxy",
+			query:    "time=1700000010",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			backendClient, err := NewClient("test", nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ts, w, r, _, err := tu.NewTestInstance("", backendClient.DefaultPathConfigs,
+				200, "{}", nil, providers.Loki, test.path, "debug")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer ts.Close()
+			rsc := request.GetResources(r)
+			backendClient, err = NewClient("test", rsc.BackendOptions, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client := backendClient.(*Client)
+			rsc.BackendClient = client
+			rsc.BackendOptions.HTTPClient = backendClient.HTTPClient()
+
+			test.handler(client)(w, r)
+			resp := w.Result()
+			if resp.StatusCode != 200 {
+				t.Errorf("expected 200 got %d.", resp.StatusCode)
+			}
+			if v := resp.Header.Get(headers.NameTricksterResult); !strings.Contains(v, test.expected) {
+				t.Errorf("expected %s got %s", test.expected, v)
+			}
+			if !strings.Contains(r.URL.RawQuery, test.query) {
+				t.Errorf("expected %s in %s", test.query, r.URL.RawQuery)
+			}
+		})
+	}
+}
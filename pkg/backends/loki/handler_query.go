@@ -0,0 +1,44 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loki
+
+import (
+	"net/http"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/engines"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/params"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/urls"
+)
+
+// QueryHandler handles instant queries for Loki. Metric queries are processed
+// through the object proxy cache, while log queries are proxied.
+func (c *Client) QueryHandler(w http.ResponseWriter, r *http.Request) {
+	qp, _, _ := params.GetRequestValues(r)
+	if isLogQuery(qp.Get(upQuery)) {
+		c.ProxyHandler(w, r)
+		return
+	}
+	// Round time param down to the nearest 15 seconds if it exists
+	if p := qp.Get(upTime); p != "" {
+		if t, err := parseTime(p); err == nil {
+			qp.Set(upTime, formatTime(t.Truncate(instantRound)))
+		}
+	}
+	r.URL = urls.BuildUpstreamURL(r, c.BaseUpstreamURL())
+	params.SetRequestValues(r, qp)
+	engines.ObjectProxyCacheRequest(w, r)
+}
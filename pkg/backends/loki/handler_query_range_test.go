@@ -0,0 +1,98 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loki
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+	tu "github.com/trickstercache/trickster/v2/pkg/testutil"
+)
+
+// testMatrixBody returns a Loki matrix response with points at start and
+// one minute later
+func testMatrixBody(start time.Time) string {
+	return fmt.Sprintf(`{"status":"success","data":{"resultType":"matrix",`+
+		`"result":[{"metric":{"app":"foo"},"values":[[%d,"1"],[%d,"2"]]}],`+
+		`"stats":{}}}`, start.Unix(), start.Add(time.Minute).Unix())
+}
+
+func testQueryRange(t *testing.T, query string) (string, string, string) {
+	t.Helper()
+	backendClient, err := NewClient("test", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now().Truncate(time.Minute).Add(-10 * time.Minute)
+	qp := url.Values{upQuery: {query}, upStart: {formatTime(start)},
+		upEnd: {formatTime(start.Add(time.Minute))}, upStep: {"60"}}
+	body := testMatrixBody(start)
+	ts, w, r, _, err := tu.NewTestInstance("", backendClient.DefaultPathConfigs,
+		200, body, nil, providers.Loki,
+		APIPath+mnQueryRange+"?"+qp.Encode(), "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ts.Close()
+	rsc := request.GetResources(r)
+	backendClient, err = NewClient("test", rsc.BackendOptions, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := backendClient.(*Client)
+	rsc.BackendClient = client
+	rsc.BackendOptions.HTTPClient = backendClient.HTTPClient()
+
+	client.QueryRangeHandler(w, r)
+	resp := w.Result()
+	if resp.StatusCode != 200 {
+		t.Errorf("expected 200 got %d.", resp.StatusCode)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Error(err)
+	}
+	return string(b), resp.Header.Get(headers.NameTricksterResult), body
+}
+
+func TestQueryRangeHandler(t *testing.T) {
+	body, result, _ := testQueryRange(t, `rate({app="foo"}[1m])`)
+	if !strings.Contains(result, "engine=DeltaProxyCache") {
+		t.Errorf("expected delta proxy cache result, got %s", result)
+	}
+	if !strings.Contains(body, `"resultType":"matrix"`) ||
+		!strings.Contains(body, `"app":"foo"`) {
+		t.Errorf("unexpected body: %s", body)
+	}
+}
+
+func TestQueryRangeHandlerLogQuery(t *testing.T) {
+	body, result, expected := testQueryRange(t, `{app="foo"} |= "error"`)
+	if !strings.Contains(result, "engine=HTTPProxy") {
+		t.Errorf("expected proxy result, got %s", result)
+	}
+	if body != expected {
+		t.Errorf("expected unmodified body, got %s", body)
+	}
+}
@@ -0,0 +1,258 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package loki provides the Grafana Loki Backend provider
+package loki
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	modelprom "github.com/trickstercache/trickster/v2/pkg/backends/prometheus/model"
+	"github.com/trickstercache/trickster/v2/pkg/backends/providers/registry/types"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	tt "github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/errors"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/params"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+var _ backends.TimeseriesBackend = (*Client)(nil)
+
+// Loki API
+const (
+	APIPath      = "/loki/api/v1/"
+	mnQueryRange = "query_range"
+	mnQuery      = "query"
+	mnLabels     = "labels"
+	mnLabel      = "label"
+	mnSeries     = "series"
+	mnIndex      = "index/"
+	mnTail       = "tail"
+	mnPush       = "push"
+)
+
+// Common URL Parameter Names
+const (
+	upQuery     = "query"
+	upStart     = "start"
+	upEnd       = "end"
+	upStep      = "step"
+	upTime      = "time"
+	upLimit     = "limit"
+	upDirection = "direction"
+	upMatch     = "match[]"
+)
+
+// instantRound is the interval to which instant query evaluation times are
+// rounded down for cacheability
+const instantRound = 15 * time.Second
+
+// maxDefaultSteps is the number of points Loki targets when a range query
+// omits the step, per Loki's defaultQueryRangeStep
+const maxDefaultSteps = 250
+
+// Client Implements Proxy Client Interface
+type Client struct {
+	backends.TimeseriesBackend
+}
+
+var _ types.NewBackendClientFunc = NewClient
+
+// NewClient returns a new Client Instance. Loki metric query results share the
+// Prometheus wire format, so they are modeled with the Prometheus Modeler.
+func NewClient(name string, o *bo.Options, router http.Handler,
+	cache cache.Cache, _ backends.Backends,
+	_ types.Lookup,
+) (backends.Backend, error) {
+	c := &Client{}
+	b, err := backends.NewTimeseriesBackend(name, o, c.RegisterHandlers, router,
+		cache, modelprom.NewModeler())
+	c.TimeseriesBackend = b
+	return c, err
+}
+
+// isLogQuery returns true if the LogQL statement is a log query, which
+// returns log line streams, rather than a metric query, which returns samples.
+// Log queries always begin with a stream selector.
+func isLogQuery(stmt string) bool {
+	return strings.HasPrefix(strings.TrimSpace(stmt), "{")
+}
+
+// containsOffsetKeyword reports whether stmt contains the LogQL " offset "
+// keyword outside of curly-brace selectors and quoted strings.
+func containsOffsetKeyword(stmt string) bool {
+	const target = " offset "
+	depth := 0
+	var quote byte
+	for i := 0; i < len(stmt); i++ {
+		switch {
+		case quote == '"' && stmt[i] == '\\':
+			i++ // skip escaped character
+		case quote != 0:
+			if stmt[i] == quote {
+				quote = 0
+			}
+		case stmt[i] == '"' || stmt[i] == '`':
+			quote = stmt[i]
+		case stmt[i] == '{':
+			depth++
+		case stmt[i] == '}' && depth > 0:
+			depth--
+		case depth == 0 && i+len(target) <= len(stmt) &&
+			stmt[i:i+len(target)] == target:
+			return true
+		}
+	}
+	return false
+}
+
+// parseTime converts a Loki time URL parameter to time.Time. Loki accepts
+// integer Unix epochs in seconds or nanoseconds, float Unix epochs in seconds,
+// and RFC3339 timestamps.
+func parseTime(s string) (time.Time, error) {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if len(s) <= 10 {
+			return time.Unix(i, 0), nil
+		}
+		return time.Unix(0, i), nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		s, ns := math.Modf(f)
+		ns = math.Round(ns*1000) / 1000
+		return time.Unix(int64(s), int64(ns*float64(time.Second))), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q to a valid timestamp", s)
+}
+
+// formatTime formats t as a Unix epoch in nanoseconds, which Loki accepts for
+// all time URL parameters
+func formatTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// parseDuration parses Loki step parameters, which can be float64 seconds or
+// durations like 1m, 5m, etc.
+func parseDuration(input string) (time.Duration, error) {
+	v, err := strconv.ParseFloat(input, 64)
+	if err != nil {
+		return tt.ParseDuration(input)
+	}
+	return time.Duration(v * float64(time.Second)), nil
+}
+
+// formatDuration formats d as float64 seconds
+func formatDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+// defaultStep returns the step Loki uses for a range query without one
+func defaultStep(e timeseries.Extent) time.Duration {
+	step := math.Max(math.Floor(e.End.Sub(e.Start).Seconds()/maxDefaultSteps), 1)
+	return time.Duration(step) * time.Second
+}
+
+// roundTimestampsToMinute aligns start and end timestamps to minute boundaries
+// for cacheability: start rounds down, end rounds up so the queried window
+// still covers all entries the caller asked about.
+func roundTimestampsToMinute(qp url.Values) {
+	if p := qp.Get(upStart); p != "" {
+		if t, err := parseTime(p); err == nil {
+			qp.Set(upStart, formatTime(t.Truncate(time.Minute)))
+		}
+	}
+	if p := qp.Get(upEnd); p != "" {
+		if t, err := parseTime(p); err == nil {
+			rounded := t.Truncate(time.Minute)
+			if !rounded.Equal(t) {
+				rounded = rounded.Add(time.Minute)
+			}
+			qp.Set(upEnd, formatTime(rounded))
+		}
+	}
+}
+
+// ParseTimeRangeQuery parses the key parts of a TimeRangeQuery from the inbound HTTP Request
+func (c *Client) ParseTimeRangeQuery(r *http.Request) (*timeseries.TimeRangeQuery,
+	*timeseries.RequestOptions, bool, error,
+) {
+	trq := &timeseries.TimeRangeQuery{Extent: timeseries.Extent{}}
+	rlo := &timeseries.RequestOptions{}
+	qp, b, isBody := params.GetRequestValues(r)
+	if isBody {
+		trq.OriginalBody = b
+	}
+
+	trq.Statement = qp.Get(upQuery)
+	if trq.Statement == "" {
+		return nil, nil, false, errors.MissingURLParam(upQuery)
+	}
+	p := qp.Get(upStart)
+	if p == "" {
+		return nil, nil, false, errors.MissingURLParam(upStart)
+	}
+	t, err := parseTime(p)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	trq.Extent.Start = t
+
+	p = qp.Get(upEnd)
+	if p == "" {
+		return nil, nil, false, errors.MissingURLParam(upEnd)
+	}
+	t, err = parseTime(p)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	trq.Extent.End = t
+
+	if p = qp.Get(upStep); p != "" {
+		step, err := parseDuration(p)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		trq.Step = step
+	} else {
+		trq.Step = defaultStep(trq.Extent)
+	}
+	if trq.Step <= 0 {
+		return nil, nil, false, errors.ErrStepParse
+	}
+	// the step is always provided to the origin by SetExtent, so the cache
+	// key uses the parsed step, whether or not the client provided one
+	trq.CacheKeyElements = map[string]string{upStep: formatDuration(trq.Step)}
+
+	rlo.ExtractFastForwardDisabled(trq.Statement)
+	trq.ExtractBackfillTolerance(trq.Statement)
+
+	if containsOffsetKeyword(trq.Statement) {
+		trq.IsOffset = true
+		rlo.FastForwardDisable = true
+	}
+
+	return trq, rlo, true, nil
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loki
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func TestNewClient(t *testing.T) {
+	c, err := NewClient("test", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.(*Client).Modeler() == nil {
+		t.Error("expected non-nil modeler")
+	}
+}
+
+func TestIsLogQuery(t *testing.T) {
+	tests := []struct {
+		stmt     string
+		expected bool
+	}{
+		{`{app="foo"} |= "error"`, true},
+		{`  {app="foo"}`, true},
+		{`rate({app="foo"}[5m])`, false},
+		{`sum by (level) (count_over_time({app="foo"} | json [1m]))`, false},
+		{``, false},
+	}
+	for _, test := range tests {
+		if v := isLogQuery(test.stmt); v != test.expected {
+			t.Errorf("%s: expected %t got %t", test.stmt, test.expected, v)
+		}
+	}
+}
+
+func TestContainsOffsetKeyword(t *testing.T) {
+	tests := []struct {
+		stmt     string
+		expected bool
+	}{
+		{`rate({app="foo"}[5m] offset 1h)`, true},
+		{`rate({app="foo"}[5m])`, false},
+		{`rate({app="foo"} |= " offset " [5m])`, false},
+		{"rate({app=\"foo\"} |= ` offset ` [5m])", false},
+		{`rate({app="foo", x=" offset "}[5m])`, false},
+		{`rate({app="foo"} |= "a \" offset " [5m])`, false},
+	}
+	for _, test := range tests {
+		if v := containsOffsetKeyword(test.stmt); v != test.expected {
+			t.Errorf("%s: expected %t got %t", test.stmt, test.expected, v)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Time
+		err      bool
+	}{
+		{"1700000000", time.Unix(1700000000, 0), false},
+		{"1700000000000000000", time.Unix(1700000000, 0), false},
+		{"1700000000.5", time.Unix(1700000000, int64(500*time.Millisecond)), false},
+		{"2023-11-14T22:13:20Z", time.Unix(1700000000, 0), false},
+		{"a", time.Time{}, true},
+	}
+	for _, test := range tests {
+		v, err := parseTime(test.input)
+		if (err != nil) != test.err {
+			t.Errorf("%s: unexpected error: %v", test.input, err)
+		}
+		if !v.Equal(test.expected) {
+			t.Errorf("%s: expected %s got %s", test.input, test.expected, v)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		err      bool
+	}{
+		{"15", 15 * time.Second, false},
+		{"0.5", 500 * time.Millisecond, false},
+		{"1m", time.Minute, false},
+		{"a", 0, true},
+	}
+	for _, test := range tests {
+		v, err := parseDuration(test.input)
+		if (err != nil) != test.err {
+			t.Errorf("%s: unexpected error: %v", test.input, err)
+		}
+		if v != test.expected {
+			t.Errorf("%s: expected %s got %s", test.input, test.expected, v)
+		}
+	}
+}
+
+func TestRoundTimestampsToMinute(t *testing.T) {
+	qp := url.Values{
+		upStart: {"1700000010000000000"},
+		upEnd:   {"1700000070"},
+	}
+	roundTimestampsToMinute(qp)
+	if v := qp.Get(upStart); v != "1699999980000000000" {
+		t.Errorf("expected %s got %s", "1699999980000000000", v)
+	}
+	if v := qp.Get(upEnd); v != "1700000100000000000" {
+		t.Errorf("expected %s got %s", "1700000100000000000", v)
+	}
+	// already-aligned and unparseable values are unchanged
+	qp = url.Values{upStart: {"a"}, upEnd: {"1699999980000000000"}}
+	roundTimestampsToMinute(qp)
+	if v := qp.Get(upStart); v != "a" {
+		t.Errorf("expected %s got %s", "a", v)
+	}
+	if v := qp.Get(upEnd); v != "1699999980000000000" {
+		t.Errorf("expected %s got %s", "1699999980000000000", v)
+	}
+}
+
+func TestParseTimeRangeQuery(t *testing.T) {
+	c := &Client{}
+	tests := []struct {
+		query    string
+		step     time.Duration
+		offset   bool
+		expected string
+		err      bool
+	}{
+		{
+			query: "query=rate({app=\"foo\"}[1m])&start=1700000000000000000&end=1700003600000000000&step=60",
+			step:  time.Minute, expected: "60",
+		},
+		{
+			query: "query=rate({app=\"foo\"}[1m])&start=1700000000&end=1700003600&step=1m",
+			step:  time.Minute, expected: "60",
+		},
+		{
+			// without a step, Loki targets 250 points
+			query: "query=rate({app=\"foo\"}[1m])&start=1700000000&end=1700025000",
+			step:  100 * time.Second, expected: "100",
+		},
+		{
+			query: "query=rate({app=\"foo\"}[1m] offset 1h)&start=1700000000&end=1700003600&step=60",
+			step:  time.Minute, expected: "60", offset: true,
+		},
+		{query: "start=1700000000&end=1700003600&step=60", err: true},
+		{query: "query=x&end=1700003600&step=60", err: true},
+		{query: "query=x&start=1700000000&step=60", err: true},
+		{query: "query=x&start=a&end=1700003600&step=60", err: true},
+		{query: "query=x&start=1700000000&end=a&step=60", err: true},
+		{query: "query=x&start=1700000000&end=1700003600&step=a", err: true},
+		{query: "query=x&start=1700000000&end=1700003600&step=0", err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			qp, _ := url.ParseQuery(test.query)
+			r := httptest.NewRequest(http.MethodGet,
+				"http://loki/loki/api/v1/query_range?"+qp.Encode(), nil)
+			trq, rlo, _, err := c.ParseTimeRangeQuery(r)
+			if (err != nil) != test.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if test.err {
+				return
+			}
+			if trq.Step != test.step {
+				t.Errorf("expected %s got %s", test.step, trq.Step)
+			}
+			if v := trq.CacheKeyElements[upStep]; v != test.expected {
+				t.Errorf("expected %s got %s", test.expected, v)
+			}
+			if trq.IsOffset != test.offset || rlo.FastForwardDisable != test.offset {
+				t.Errorf("expected offset %t", test.offset)
+			}
+			if trq.Extent.Start.Unix() != 1700000000 {
+				t.Errorf("unexpected start %s", trq.Extent.Start)
+			}
+		})
+	}
+}
+
+func TestDefaultStep(t *testing.T) {
+	e := timeseries.Extent{Start: time.Unix(0, 0), End: time.Unix(60, 0)}
+	if v := defaultStep(e); v != time.Second {
+		t.Errorf("expected %s got %s", time.Second, v)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loki
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/handlers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/methods"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/paths/matching"
+	po "github.com/trickstercache/trickster/v2/pkg/proxy/paths/options"
+)
+
+// hnOrgID is the header by which a multi-tenant Loki identifies the tenant,
+// which must be part of the cache key of any cached response
+const hnOrgID = "X-Scope-OrgID"
+
+func (c *Client) RegisterHandlers(handlers.Lookup) {
+	c.TimeseriesBackend.RegisterHandlers(
+		handlers.Lookup{
+			"health":      http.HandlerFunc(c.HealthHandler),
+			"query_range": http.HandlerFunc(c.QueryRangeHandler),
+			"query":       http.HandlerFunc(c.QueryHandler),
+			"series":      http.HandlerFunc(c.SeriesHandler),
+			"labels":      http.HandlerFunc(c.LabelsHandler),
+			"proxycache":  http.HandlerFunc(c.ObjectProxyCacheHandler),
+			"proxy":       http.HandlerFunc(c.ProxyHandler),
+		},
+	)
+}
+
+// DefaultPathConfigs returns the default PathConfigs for the given Provider
+func (c *Client) DefaultPathConfigs(o *bo.Options) po.List {
+	var rhts map[string]string
+	if o != nil {
+		rhts = map[string]string{
+			headers.NameCacheControl: fmt.Sprintf("%s=%d", headers.ValueSharedMaxAge, time.Duration(o.TimeseriesTTL)/(1*time.Second)),
+		}
+	}
+	rhinst := map[string]string{
+		headers.NameCacheControl: fmt.Sprintf("%s=%d", headers.ValueSharedMaxAge, 30),
+	}
+	paths := po.List{
+		{
+			Path:            APIPath + mnQueryRange,
+			HandlerName:     mnQueryRange,
+			Methods:         methods.GetAndPost(),
+			CacheKeyParams:  []string{upQuery, upStep},
+			CacheKeyHeaders: []string{hnOrgID},
+			ResponseHeaders: rhts,
+			MatchTypeName:   matching.PathMatchNameExact,
+			MatchType:       matching.PathMatchTypeExact,
+		},
+		{
+			Path:            APIPath + mnQuery,
+			HandlerName:     mnQuery,
+			Methods:         methods.GetAndPost(),
+			CacheKeyParams:  []string{upQuery, upTime, upLimit, upDirection},
+			CacheKeyHeaders: []string{hnOrgID},
+			ResponseHeaders: rhinst,
+			MatchTypeName:   matching.PathMatchNameExact,
+			MatchType:       matching.PathMatchTypeExact,
+		},
+		{
+			Path:            APIPath + mnSeries,
+			HandlerName:     mnSeries,
+			Methods:         methods.GetAndPost(),
+			CacheKeyParams:  []string{upMatch, upStart, upEnd},
+			CacheKeyHeaders: []string{hnOrgID},
+			ResponseHeaders: rhinst,
+			MatchTypeName:   matching.PathMatchNameExact,
+			MatchType:       matching.PathMatchTypeExact,
+		},
+		{
+			Path:            APIPath + mnLabels,
+			HandlerName:     mnLabels,
+			Methods:         methods.GetAndPost(),
+			CacheKeyParams:  []string{upQuery, upStart, upEnd},
+			CacheKeyHeaders: []string{hnOrgID},
+			ResponseHeaders: rhinst,
+			MatchTypeName:   matching.PathMatchNameExact,
+			MatchType:       matching.PathMatchTypeExact,
+		},
+		{
+			Path:            APIPath + mnLabel + "/",
+			HandlerName:     mnLabels,
+			Methods:         []string{http.MethodGet},
+			CacheKeyParams:  []string{upQuery, upStart, upEnd},
+			CacheKeyHeaders: []string{hnOrgID},
+			ResponseHeaders: rhinst,
+			MatchTypeName:   matching.PathMatchNamePrefix,
+			MatchType:       matching.PathMatchTypePrefix,
+		},
+		{
+			Path:            APIPath + mnIndex,
+			HandlerName:     mnLabels,
+			Methods:         methods.GetAndPost(),
+			CacheKeyParams:  []string{upQuery, upStart, upEnd, upLimit},
+			CacheKeyHeaders: []string{hnOrgID},
+			ResponseHeaders: rhinst,
+			MatchTypeName:   matching.PathMatchNamePrefix,
+			MatchType:       matching.PathMatchTypePrefix,
+		},
+		{
+			Path:          APIPath + mnTail,
+			HandlerName:   providers.Proxy,
+			Methods:       []string{http.MethodGet},
+			MatchTypeName: matching.PathMatchNameExact,
+			MatchType:     matching.PathMatchTypeExact,
+		},
+		{
+			Path:          APIPath + mnPush,
+			HandlerName:   providers.Proxy,
+			Methods:       []string{http.MethodPost},
+			MatchTypeName: matching.PathMatchNameExact,
+			MatchType:     matching.PathMatchTypeExact,
+		},
+		{
+			Path:          "/",
+			HandlerName:   providers.Proxy,
+			Methods:       methods.GetAndPost(),
+			MatchType:     matching.PathMatchTypePrefix,
+			MatchTypeName: matching.PathMatchNamePrefix,
+		},
+	}
+	o.FastForwardPath = paths[1].Clone()
+	return paths
+}
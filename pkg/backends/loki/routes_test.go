@@ -0,0 +1,64 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loki
+
+import (
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+	tu "github.com/trickstercache/trickster/v2/pkg/testutil"
+)
+
+func TestRegisterHandlers(t *testing.T) {
+	c, err := NewClient("test", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Error(err)
+	}
+	c.RegisterHandlers(nil)
+	if _, ok := c.Handlers()[mnQueryRange]; !ok {
+		t.Errorf("expected to find handler named: %s", mnQueryRange)
+	}
+}
+
+func TestDefaultPathConfigs(t *testing.T) {
+	backendClient, err := NewClient("test", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Error(err)
+	}
+	ts, _, r, _, err := tu.NewTestInstance("", backendClient.DefaultPathConfigs,
+		200, "{}", nil, providers.Loki, "/ready", "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ts.Close()
+	rsc := request.GetResources(r)
+	dpc := backendClient.DefaultPathConfigs(rsc.BackendOptions)
+	const expectedLen = 9
+	if len(dpc) != expectedLen {
+		t.Errorf("expected ordered length to be: %d got %d", expectedLen, len(dpc))
+	}
+	if rsc.BackendOptions.FastForwardPath == nil ||
+		rsc.BackendOptions.FastForwardPath.Path != APIPath+mnQuery {
+		t.Error("expected fast forward path to be the instant query path")
+	}
+	for _, p := range dpc[:6] {
+		if len(p.CacheKeyHeaders) != 1 || p.CacheKeyHeaders[0] != hnOrgID {
+			t.Errorf("expected %s to be keyed by %s", p.Path, hnOrgID)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loki
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func TestSetExtent(t *testing.T) {
+	c := &Client{}
+	r := httptest.NewRequest(http.MethodGet,
+		"http://loki/loki/api/v1/query_range?query=x&start=1&end=2", nil)
+	trq := &timeseries.TimeRangeQuery{Step: 30 * time.Second}
+	e := &timeseries.Extent{Start: time.Unix(1700000000, 0), End: time.Unix(1700003600, 0)}
+	if err := c.SetExtent(r, trq, e); err != nil {
+		t.Fatal(err)
+	}
+	qp := r.URL.Query()
+	if v := qp.Get(upStart); v != "1700000000000000000" {
+		t.Errorf("expected %s got %s", "1700000000000000000", v)
+	}
+	if v := qp.Get(upEnd); v != "1700003600000000000" {
+		t.Errorf("expected %s got %s", "1700003600000000000", v)
+	}
+	if v := qp.Get(upStep); v != "30" {
+		t.Errorf("expected %s got %s", "30", v)
+	}
+}
+
+func TestFastForwardRequest(t *testing.T) {
+	c := &Client{}
+	r := httptest.NewRequest(http.MethodGet,
+		"http://loki/loki/api/v1/query_range?query=x&start=1&end=2&step=15", nil)
+	nr, err := c.FastForwardRequest(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nr.URL.Path != "/loki/api/v1/query" {
+		t.Errorf("expected %s got %s", "/loki/api/v1/query", nr.URL.Path)
+	}
+	qp := nr.URL.Query()
+	if v := qp.Get(upTime); v != "2" {
+		t.Errorf("expected %s got %s", "2", v)
+	}
+	if qp.Has(upStart) || qp.Has(upEnd) || qp.Has(upStep) {
+		t.Errorf("unexpected params: %s", nr.URL.RawQuery)
+	}
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/testutil/sqlcorpus"
+)
+
+// mySQLCompatibilityCorpus is the maintained parser-compatibility contract
+// for the TiDB parser version pinned in go.mod. Add production and Grafana
+// query shapes here with their expected classification before expanding the
+// analyzer's accepted SQL surface or upgrading the parser.
+var mySQLCompatibilityCorpus = []sqlcorpus.Case{
+	{
+		Name: "grafana unixEpochGroup macro",
+		Query: "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS \"time\", host, AVG(cpu) AS cpu " +
+			"FROM metrics WHERE ts >= FROM_UNIXTIME(1699990200) AND ts < FROM_UNIXTIME(1699996200) " +
+			"GROUP BY 1, host ORDER BY 1",
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Step: time.Minute,
+	},
+	{
+		Name: "integer division bucket with aligned inclusive BETWEEN",
+		Query: "SELECT UNIX_TIMESTAMP(ts) DIV 300 * 300 AS t, COUNT(*) AS cnt FROM events " +
+			"WHERE ts BETWEEN FROM_UNIXTIME(1699990200) AND FROM_UNIXTIME(1699995899.999999) GROUP BY t",
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Step: 5 * time.Minute,
+	},
+	{
+		Name: "epoch seconds column with open upper bound",
+		Query: "SELECT 60*FLOOR(epoch/60) AS t, service, SUM(bytes) AS bytes FROM db.flows " +
+			"WHERE epoch >= 1699990200 AND environment = 'prod' GROUP BY t, service ORDER BY t",
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Step: time.Minute,
+	},
+	{
+		Name: "bucket expression predicate",
+		Query: "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, COUNT(*) FROM events " +
+			"WHERE FLOOR(UNIX_TIMESTAMP(ts)/60)*60 > 1699990210 AND ts < FROM_UNIXTIME(1699996200) GROUP BY t",
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Step: time.Minute,
+	},
+	{
+		Name: "grafana timeFilter BETWEEN",
+		Query: "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, COUNT(*) FROM events " +
+			"WHERE ts BETWEEN FROM_UNIXTIME(1699990200) AND FROM_UNIXTIME(1699996200) GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		Name: "session timezone literal",
+		Query: "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, COUNT(*) FROM events " +
+			"WHERE ts >= '2023-11-14 19:30:00' GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		Name: "unaligned lower bound",
+		Query: "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, COUNT(*) FROM events " +
+			"WHERE ts >= FROM_UNIXTIME(1699990210) GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		Name: "placeholder bound",
+		Query: "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, COUNT(*) FROM events " +
+			"WHERE ts >= FROM_UNIXTIME(1699990200) AND ts < ? GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		Name: "disjunctive time predicate",
+		Query: "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, COUNT(*) FROM events " +
+			"WHERE ts >= FROM_UNIXTIME(1699990200) OR host = 'a' GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		Name: "having on bucket",
+		Query: "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, COUNT(*) FROM events " +
+			"WHERE ts >= FROM_UNIXTIME(1699990200) GROUP BY t HAVING t > 5",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		Name: "limit",
+		Query: "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, COUNT(*) FROM events " +
+			"WHERE ts >= FROM_UNIXTIME(1699990200) GROUP BY t LIMIT 5",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedLimit,
+	},
+	{
+		Name: "descending order",
+		Query: "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, COUNT(*) FROM events " +
+			"WHERE ts >= FROM_UNIXTIME(1699990200) GROUP BY t ORDER BY t DESC",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		Name: "ungrouped column",
+		Query: "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, host, COUNT(*) FROM events " +
+			"WHERE ts >= FROM_UNIXTIME(1699990200) GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedGrouping,
+	},
+	{
+		Name: "multiple buckets",
+		Query: "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, FLOOR(UNIX_TIMESTAMP(ts2)/60)*60 AS t2, " +
+			"COUNT(*) FROM events WHERE ts >= FROM_UNIXTIME(1699990200) GROUP BY t, t2",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonAmbiguousTimeAxis,
+	},
+	{
+		Name: "common table expression",
+		Query: "WITH e AS (SELECT * FROM events) SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, " +
+			"COUNT(*) FROM e WHERE ts >= FROM_UNIXTIME(1699990200) GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		Name: "snapshot read",
+		Query: "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, COUNT(*) FROM events " +
+			"AS OF TIMESTAMP NOW() - INTERVAL 5 SECOND WHERE ts >= FROM_UNIXTIME(1699990200) GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		Name: "optimizer hint",
+		Query: "SELECT /*+ MAX_EXECUTION_TIME(1000) */ FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, " +
+			"COUNT(*) FROM events WHERE ts >= FROM_UNIXTIME(1699990200) GROUP BY t",
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Step: time.Minute,
+	},
+	{
+		Name:  "raw rows",
+		Query: "SELECT ts, cpu FROM metrics WHERE ts >= FROM_UNIXTIME(1699990200)",
+		Mode:  sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedBucket,
+	},
+	{
+		Name:  "union",
+		Query: "SELECT 1 UNION SELECT 2",
+		Mode:  sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		Name:  "invalid select",
+		Query: "SELECT !!! FROM",
+		Mode:  sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonInvalidSQL,
+	},
+	{
+		Name:   "non-select statement",
+		Query:  "INSERT INTO events VALUES (1)",
+		Mode:   sqlanalyzer.CacheModeNone,
+		Reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+}
+
+func TestMySQLCompatibilityCorpus(t *testing.T) {
+	sqlcorpus.Run(t, dialectAnalyzer, time.Unix(1_700_000_000, 0), mySQLCompatibilityCorpus)
+}
+
+func TestCorpusRestoreIsStable(t *testing.T) {
+	for _, test := range mySQLCompatibilityCorpus {
+		if test.Mode != sqlanalyzer.CacheModeDelta {
+			continue
+		}
+		t.Run(test.Name, func(t *testing.T) {
+			first, err := parseStatement(test.Query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			restored, err := restore(first)
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := parseStatement(restored)
+			if err != nil {
+				t.Fatalf("restored SQL did not parse: %v\n%s", err, restored)
+			}
+			if rerestored, _ := restore(second); rerestored != restored {
+				t.Errorf("restore was not stable:\n%s\n%s", restored, rerestored)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	te "github.com/trickstercache/trickster/v2/pkg/errors"
+	pe "github.com/trickstercache/trickster/v2/pkg/proxy/errors"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+)
+
+// Gateway Endpoints
+const (
+	PathQueryTuples = "/api/v2/query/tuples"
+	PathQueryRows   = "/api/v2/query/rows"
+	PathPing        = "/ping"
+)
+
+// Common Request Document Field Names
+const (
+	ParamSQL      = "sql"
+	ParamArgs     = "args"
+	ParamDatabase = "database"
+)
+
+// queryRequest is a gateway query request, which is provided as a JSON
+// document in the body of a POST
+type queryRequest struct {
+	sql      string
+	args     string
+	database string
+	// document is the decoded body of the request
+	document map[string]json.RawMessage
+}
+
+// isTuplesRequest returns true if the request is made to the tuples endpoint,
+// whose results can be delta cached
+func isTuplesRequest(r *http.Request) bool {
+	return r != nil && r.URL != nil && strings.HasSuffix(r.URL.Path, PathQueryTuples)
+}
+
+// statement returns the SQL statement provided in a gateway query request, or
+// an empty string if the request is invalid
+func statement(r *http.Request) string {
+	qr, err := parseRequest(r)
+	if err != nil {
+		return ""
+	}
+	return qr.sql
+}
+
+func parseRequest(r *http.Request) (*queryRequest, error) {
+	if r == nil || r.Method != http.MethodPost {
+		return nil, te.ErrInvalidMethod
+	}
+	b, err := request.GetBody(r)
+	if err != nil {
+		return nil, err
+	}
+	qr := &queryRequest{}
+	if err := json.Unmarshal(b, &qr.document); err != nil {
+		return nil, te.ErrBadRequest
+	}
+	qr.sql = qr.documentString(ParamSQL)
+	qr.database = qr.documentString(ParamDatabase)
+	if v, ok := qr.document[ParamArgs]; ok && string(v) != "null" {
+		qr.args = compactJSON(v)
+	}
+	if qr.sql == "" {
+		return nil, pe.MissingURLParam(ParamSQL)
+	}
+	return qr, nil
+}
+
+func (qr *queryRequest) documentString(key string) string {
+	v, ok := qr.document[key]
+	if !ok {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(v, &s); err != nil {
+		return ""
+	}
+	return s
+}
+
+func compactJSON(v json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, v); err != nil {
+		return string(v)
+	}
+	return buf.String()
+}
+
+// cacheKeyElements returns the request values that identify the query in the
+// cache, using the provided statement
+func (qr *queryRequest) cacheKeyElements(statement string) map[string]string {
+	out := map[string]string{
+		ParamSQL:      statement,
+		ParamDatabase: qr.database,
+	}
+	if qr.args != "" {
+		out[ParamArgs] = qr.args
+	}
+	return out
+}
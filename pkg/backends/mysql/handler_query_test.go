@@ -0,0 +1,219 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+	tu "github.com/trickstercache/trickster/v2/pkg/testutil"
+)
+
+func TestIsSelectQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{"space", "SELECT col FROM t", true},
+		{"newline", "SELECT\ncol FROM t", true},
+		{"lowercase", "select col from t", true},
+		{"with clause", "WITH x AS (SELECT 1) SELECT col FROM t", true},
+		{"insert", "INSERT INTO t VALUES (1)", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSelectQuery(tt.query); got != tt.want {
+				t.Errorf("isSelectQuery(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+var testBoundPattern = regexp.MustCompile(`FROM_UNIXTIME\((\d+)\)`)
+
+// testGateway is a stand-in for a MySQL HTTP gateway. It answers each query
+// with a row per minute between the two FROM_UNIXTIME bounds of its statement,
+// and counts the queries it receives.
+func testGateway(t *testing.T, queries *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			SQL string `json:"sql"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("gateway could not decode request: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		queries.Add(1)
+		bounds := testBoundPattern.FindAllStringSubmatch(body.SQL, -1)
+		if len(bounds) != 2 {
+			t.Errorf("gateway received unexpected statement: %s", body.SQL)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		start, _ := strconv.ParseInt(bounds[0][1], 10, 64)
+		end, _ := strconv.ParseInt(bounds[1][1], 10, 64)
+		rows := make([]string, 0, 16)
+		for ts := start; ts < end; ts += 60 {
+			rows = append(rows, fmt.Sprintf(`[%d,"a",%d]`, ts, ts/60%100))
+		}
+		w.Header().Set(headers.NameContentType, headers.ValueApplicationJSON)
+		fmt.Fprintf(w, `{"results":[{"columns":[{"name":"t","dataType":"BIGINT","nullable":true},`+
+			`{"name":"host","dataType":"VARCHAR","nullable":false},`+
+			`{"name":"cpu","dataType":"DOUBLE","nullable":true}],"rows":[%s]}]}`,
+			strings.Join(rows, ","))
+	}))
+}
+
+func testQueryRequest(start, end time.Time) string {
+	b, _ := json.Marshal(map[string]any{
+		ParamDatabase: "telemetry",
+		ParamSQL: fmt.Sprintf("SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, host, AVG(cpu) AS cpu "+
+			"FROM metrics WHERE ts >= FROM_UNIXTIME(%d) AND ts < FROM_UNIXTIME(%d) "+
+			"GROUP BY t, host ORDER BY t", start.Unix(), end.Unix()),
+	})
+	return string(b)
+}
+
+// doTestQuery makes a gateway query request through the QueryHandler, with a
+// new Resources collection for the path, as the router would provide
+func doTestQuery(t *testing.T, client *Client, rsc *request.Resources, path, body string) (string, string) {
+	t.Helper()
+	pc := rsc.PathConfig
+	for _, p := range client.Configuration().Paths {
+		if p.Path == path {
+			pc = p
+		}
+	}
+	req := request.SetResources(httptest.NewRequest(http.MethodPost, "http://trickster"+path,
+		strings.NewReader(body)), request.NewResources(rsc.BackendOptions, pc, rsc.CacheConfig,
+		rsc.CacheClient, client, rsc.Tracer))
+	w := httptest.NewRecorder()
+	client.QueryHandler(w, req)
+	resp := w.Result()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 got %d.", resp.StatusCode)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Error(err)
+	}
+	return string(b), resp.Header.Get(headers.NameTricksterResult)
+}
+
+func TestQueryHandler(t *testing.T) {
+	var queries atomic.Int32
+	gw := testGateway(t, &queries)
+	defer gw.Close()
+	backendClient, err := NewClient("test", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts, _, r, _, err := tu.NewTestInstance("", backendClient.DefaultPathConfigs,
+		200, "{}", nil, providers.MySQL, PathQueryTuples, "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ts.Close()
+	rsc := request.GetResources(r)
+	rsc.BackendOptions.Host = strings.TrimPrefix(gw.URL, "http://")
+	backendClient, err = NewClient("test", rsc.BackendOptions, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := backendClient.(*Client)
+	rsc.BackendClient = client
+	rsc.BackendOptions.HTTPClient = backendClient.HTTPClient()
+
+	end := time.Now().Truncate(time.Minute).Add(-10 * time.Minute)
+	start := end.Add(-5 * time.Minute)
+	body, result := doTestQuery(t, client, rsc, PathQueryTuples, testQueryRequest(start, end))
+	if !strings.Contains(result, "engine=DeltaProxyCache") {
+		t.Errorf("expected delta proxy cache result, got %s", result)
+	}
+	expected := fmt.Sprintf(`[%d,"a",%d]`, start.Unix(), start.Unix()/60%100)
+	if !strings.Contains(body, expected) ||
+		!strings.HasPrefix(body, `{"results":[{"columns":[{"name":"t","dataType":"BIGINT","nullable":true}`) {
+		t.Errorf("expected %s in %s", expected, body)
+	}
+	if n := strings.Count(body, `"a"`); n != 5 {
+		t.Errorf("expected 5 rows got %d: %s", n, body)
+	}
+
+	// extending the range backward requests only the missing buckets
+	before := queries.Load()
+	body, result = doTestQuery(t, client, rsc, PathQueryTuples,
+		testQueryRequest(start.Add(-3*time.Minute), end))
+	if !strings.Contains(result, "status=phit") {
+		t.Errorf("expected partial hit, got %s", result)
+	}
+	if n := strings.Count(body, `"a"`); n != 8 {
+		t.Errorf("expected 8 rows got %d: %s", n, body)
+	}
+	if queries.Load() != before+1 {
+		t.Errorf("expected one upstream query got %d", queries.Load()-before)
+	}
+
+	// the rows endpoint is object cached
+	_, result = doTestQuery(t, client, rsc, PathQueryRows, testQueryRequest(start, end))
+	if !strings.Contains(result, "engine=ObjectProxyCache") {
+		t.Errorf("expected object proxy cache result, got %s", result)
+	}
+}
+
+func TestQueryHandlerNonSelect(t *testing.T) {
+	backendClient, err := NewClient("test", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts, _, r, _, err := tu.NewTestInstance("", backendClient.DefaultPathConfigs,
+		200, "{}", nil, providers.MySQL, PathQueryTuples, "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ts.Close()
+	rsc := request.GetResources(r)
+	backendClient, err = NewClient("test", rsc.BackendOptions, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := backendClient.(*Client)
+	rsc.BackendClient = client
+	rsc.BackendOptions.HTTPClient = backendClient.HTTPClient()
+
+	body, result := doTestQuery(t, client, rsc, PathQueryTuples, `{"sql":"DELETE FROM metrics"}`)
+	if body != "{}" {
+		t.Errorf("expected '{}' got %s.", body)
+	}
+	if !strings.Contains(result, "engine=HTTPProxy") {
+		t.Errorf("expected proxy result, got %s", result)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+)
+
+// MarshalTimeseries converts a Timeseries into a tuples document
+func MarshalTimeseries(ts timeseries.Timeseries, rlo *timeseries.RequestOptions,
+	status int,
+) ([]byte, error) {
+	w := new(bytes.Buffer)
+	err := MarshalTimeseriesWriter(ts, rlo, status, w)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// MarshalTimeseriesWriter converts a Timeseries into a tuples document via an
+// io.Writer. Rows are ordered by time, and each column's values are written
+// in the representation the gateway originally provided.
+func MarshalTimeseriesWriter(ts timeseries.Timeseries,
+	_ *timeseries.RequestOptions, status int, w io.Writer,
+) error {
+	ds, ok := ts.(*dataset.DataSet)
+	if !ok || ds == nil {
+		return timeseries.ErrUnknownFormat
+	}
+	if hw, ok := w.(http.ResponseWriter); ok {
+		hw.Header().Set(headers.NameContentType, headers.ValueApplicationJSON)
+		if status > 0 {
+			hw.WriteHeader(status)
+		}
+	}
+	columns, _, _, _ := ds.FieldDefinitions()
+	rows := ds.Rows()
+	// rows are ordered by time, as required by the bucket-only ORDER BY of a
+	// delta cached query
+	dataset.SortRowsByTime(rows)
+	bw := bufio.NewWriter(w)
+	bw.WriteString(`{"results":[{"columns":[`)
+	for i, fd := range columns {
+		if i > 0 {
+			bw.WriteByte(',')
+		}
+		b, _ := json.Marshal(WFColumn{
+			Name:     fd.Name,
+			DataType: fd.SDataType,
+			Nullable: fd.ProviderData1&flagNullable != 0,
+		})
+		bw.Write(b)
+	}
+	bw.WriteString(`],"rows":[`)
+	for i, row := range rows {
+		if i > 0 {
+			bw.WriteByte(',')
+		}
+		bw.WriteByte('[')
+		for j, fd := range columns {
+			if j > 0 {
+				bw.WriteByte(',')
+			}
+			writeCell(bw, cell(row, fd), fd.ProviderData1&flagQuoted != 0)
+		}
+		bw.WriteByte(']')
+	}
+	bw.WriteString(`]}]}`)
+	return bw.Flush()
+}
+
+// cell returns the value of the row for the field, or nil if it is null
+func cell(r dataset.Row, fd timeseries.FieldDefinition) any {
+	if fd.Role == timeseries.RoleTimestamp {
+		return int64(r.Point.Epoch) / 1e9
+	}
+	return r.Value(fd)
+}
+
+// writeCell writes a value as a JSON string when quoted is true, and
+// otherwise as the JSON literal it was decoded from
+func writeCell(bw *bufio.Writer, v any, quoted bool) {
+	var s string
+	switch t := v.(type) {
+	case string:
+		s = t
+	case int64:
+		s = strconv.FormatInt(t, 10)
+	case uint64:
+		s = strconv.FormatUint(t, 10)
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			bw.WriteString("null")
+			return
+		}
+		s = strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		s = strconv.FormatBool(t)
+	default:
+		bw.WriteString("null")
+		return
+	}
+	if quoted {
+		b, _ := json.Marshal(s)
+		bw.Write(b)
+		return
+	}
+	bw.WriteString(s)
+}
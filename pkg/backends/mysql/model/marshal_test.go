@@ -0,0 +1,53 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func TestMarshalTimeseries(t *testing.T) {
+	ts, err := UnmarshalTimeseries([]byte(testTuples), testTRQ())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := MarshalTimeseries(ts, nil, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// rows are written in time order, with values in their original
+	// representations, so the document round trips
+	if string(b) != testTuples {
+		t.Errorf("expected\n%s\ngot\n%s", testTuples, b)
+	}
+
+	w := httptest.NewRecorder()
+	if err := MarshalTimeseriesWriter(ts, nil, 200, w); err != nil {
+		t.Fatal(err)
+	}
+	if ct := w.Header().Get(headers.NameContentType); ct != headers.ValueApplicationJSON {
+		t.Errorf("expected %s got %s", headers.ValueApplicationJSON, ct)
+	}
+
+	if _, err := MarshalTimeseries(nil, nil, 200); err != timeseries.ErrUnknownFormat {
+		t.Errorf("expected %v got %v", timeseries.ErrUnknownFormat, err)
+	}
+}
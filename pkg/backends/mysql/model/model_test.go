@@ -0,0 +1,58 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func TestNewModeler(t *testing.T) {
+	m := NewModeler()
+	if m == nil || m.CacheMarshaler == nil || m.WireUnmarshaler == nil {
+		t.Error("failed to get valid modeler")
+	}
+}
+
+const testTuples = `{"results":[{"columns":[` +
+	`{"name":"T","dataType":"BIGINT","nullable":true},` +
+	`{"name":"host","dataType":"VARCHAR","nullable":false},` +
+	`{"name":"cpu","dataType":"DOUBLE","nullable":true},` +
+	`{"name":"total","dataType":"DECIMAL(32,2)","nullable":true},` +
+	`{"name":"up","dataType":"TINYINT","nullable":true}],"rows":[` +
+	`[1699990200,"a",1.5,"10.50",true],` +
+	`[1699990200,"b",null,"2.00",false],` +
+	`[1699990260,"a",2,"11.25",true]]}]}`
+
+func testTRQ() *timeseries.TimeRangeQuery {
+	return &timeseries.TimeRangeQuery{
+		Extent: timeseries.Extent{
+			Start: time.Unix(1699990200, 0),
+			End:   time.Unix(1699990260, 0),
+		},
+		Step: time.Minute,
+		TimestampDefinition: timeseries.FieldDefinition{
+			Name: "t", DataType: timeseries.DateTimeUnixSecs,
+			Role: timeseries.RoleTimestamp,
+		},
+		TagFieldDefintions: timeseries.FieldDefinitions{
+			{Name: "HOST", Role: timeseries.RoleTag},
+		},
+	}
+}
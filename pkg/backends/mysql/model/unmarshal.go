@@ -0,0 +1,221 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	dcsv "github.com/trickstercache/trickster/v2/pkg/timeseries/dataset/csv"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/epoch"
+)
+
+// dataStartRow is the index in the matrix built from a tuples document at
+// which the header rows have ended and the data rows have started. The rows
+// before it hold the column names, the column data types and the column flags.
+const dataStartRow = 3
+
+// Column flags describing how the gateway represented a column's values, so
+// they can be represented the same way when the column is marshaled
+const (
+	flagQuoted   byte = 1 << iota // values were provided as JSON strings
+	flagBool                      // values were provided as JSON booleans
+	flagNullable                  // the column was described as nullable
+)
+
+// parser is safe for concurrency
+var parser = dcsv.NewParserMust(buildFieldDefinitions, typeToFieldDataType,
+	parseTimeField, dataStartRow)
+
+// UnmarshalTimeseries converts a tuples document into a Timeseries
+func UnmarshalTimeseries(data []byte, trq *timeseries.TimeRangeQuery) (timeseries.Timeseries, error) {
+	return UnmarshalTimeseriesReader(bytes.NewReader(data), trq)
+}
+
+// UnmarshalTimeseriesReader converts a tuples document into a Timeseries via
+// io.Reader
+func UnmarshalTimeseriesReader(reader io.Reader, trq *timeseries.TimeRangeQuery) (timeseries.Timeseries, error) {
+	if reader == nil || trq == nil {
+		return nil, timeseries.ErrInvalidBody
+	}
+	rows, err := decodeRows(reader)
+	if err != nil {
+		return nil, err
+	}
+	return parser.ToDataSet(rows, trq)
+}
+
+// decodeRows decodes a tuples document into a matrix of cells, preceded by
+// rows of column names, data types and flags. The document must hold the
+// result of exactly one statement, and null values are empty.
+func decodeRows(reader io.Reader) ([][]string, error) {
+	dec := json.NewDecoder(reader)
+	dec.UseNumber()
+	var doc WFDocument
+	if err := dec.Decode(&doc); err != nil || len(doc.Results) != 1 {
+		return nil, timeseries.ErrInvalidBody
+	}
+	res := doc.Results[0]
+	l := len(res.Columns)
+	if l == 0 {
+		return nil, timeseries.ErrInvalidBody
+	}
+	names := make([]string, l)
+	types := make([]string, l)
+	flags := make([]byte, l)
+	for i, col := range res.Columns {
+		names[i] = col.Name
+		types[i] = col.DataType
+		if col.Nullable {
+			flags[i] |= flagNullable
+		}
+	}
+	out := make([][]string, dataStartRow, len(res.Rows)+dataStartRow)
+	for _, r := range res.Rows {
+		if len(r) != l {
+			return nil, timeseries.ErrInvalidBody
+		}
+		row := make([]string, l)
+		for i, v := range r {
+			switch t := v.(type) {
+			case nil:
+			case string:
+				row[i] = t
+				flags[i] |= flagQuoted
+			case json.Number:
+				row[i] = t.String()
+			case bool:
+				row[i] = strconv.FormatBool(t)
+				flags[i] |= flagBool
+			default:
+				return nil, timeseries.ErrInvalidBody
+			}
+		}
+		out = append(out, row)
+	}
+	fr := make([]string, l)
+	for i, f := range flags {
+		fr[i] = strconv.Itoa(int(f))
+	}
+	out[0], out[1], out[2] = names, types, fr
+	return out, nil
+}
+
+// buildFieldDefinitions is the FieldParserFunc passed to the Parser
+func buildFieldDefinitions(rows [][]string,
+	trq *timeseries.TimeRangeQuery,
+) (timeseries.SeriesFields, error) {
+	l := len(rows[0])
+	if len(rows[1]) != l || len(rows[2]) != l {
+		return timeseries.SeriesFields{}, timeseries.ErrInvalidBody
+	}
+	var j, k int
+	outTags := make(timeseries.FieldDefinitions, l)
+	outVals := make(timeseries.FieldDefinitions, l)
+	tfd := timeseries.FieldDefinition{OutputPosition: -1}
+	for i := range l {
+		fd := loadFieldDef(rows[0][i], rows[1][i], rows[2][i], i, trq)
+		switch fd.Role {
+		case timeseries.RoleTag:
+			outTags[j] = fd
+			j++
+		case timeseries.RoleTimestamp:
+			tfd = fd
+		case timeseries.RoleValue:
+			outVals[k] = fd
+			k++
+		}
+	}
+	return timeseries.SeriesFields{
+		Timestamp: tfd, Tags: outTags[:j],
+		Values: outVals[:k], Untracked: make(timeseries.FieldDefinitions, 0),
+		ResultNameCol: -1,
+	}, nil
+}
+
+// loadFieldDef returns a field definition from the name, datatype and flags.
+// MySQL column names are case-insensitive, so they are matched to the
+// timestamp and tag fields of the query without regard to case.
+func loadFieldDef(fieldName, dataType, flags string, col int,
+	trq *timeseries.TimeRangeQuery,
+) timeseries.FieldDefinition {
+	f, _ := strconv.Atoi(flags)
+	fd := timeseries.FieldDefinition{
+		Name:           fieldName,
+		DataType:       typeToFieldDataType(dataType),
+		SDataType:      dataType,
+		ProviderData1:  byte(f),
+		OutputPosition: col,
+		Role:           timeseries.RoleValue,
+	}
+	if byte(f)&flagBool != 0 {
+		fd.DataType = timeseries.Bool
+	}
+	if strings.EqualFold(fieldName, trq.TimestampDefinition.Name) {
+		fd.Role = timeseries.RoleTimestamp
+		fd.DataType = trq.TimestampDefinition.DataType
+		return fd
+	}
+	for _, tag := range trq.TagFieldDefintions {
+		if strings.EqualFold(tag.Name, fieldName) {
+			fd.Role = timeseries.RoleTag
+			break
+		}
+	}
+	return fd
+}
+
+// typeToFieldDataType is the DataTypeParserFunc passed to the Parser. Only
+// integer and floating point columns are parsed into numbers, so the values
+// of DECIMAL and all other columns retain their representation.
+func typeToFieldDataType(input string) timeseries.FieldDataType {
+	t := strings.ToUpper(strings.TrimSpace(input))
+	unsigned := strings.Contains(t, "UNSIGNED")
+	if i := strings.IndexAny(t, "( "); i >= 0 {
+		t = t[:i]
+	}
+	switch t {
+	case "BIGINT":
+		if unsigned {
+			return timeseries.Uint64
+		}
+		return timeseries.Int64
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "YEAR", "BOOL",
+		"BOOLEAN":
+		return timeseries.Int64
+	case "FLOAT", "DOUBLE", "REAL":
+		return timeseries.Float64
+	}
+	return timeseries.String
+}
+
+// parseTimeField parses a bucket timestamp in Unix epoch seconds, which may
+// be provided as a DECIMAL with a fractional part
+func parseTimeField(input string, _ timeseries.FieldDefinition) (epoch.Epoch, error) {
+	if i, err := strconv.ParseInt(input, 10, 64); err == nil {
+		return epoch.Epoch(i * 1e9), nil
+	}
+	f, err := strconv.ParseFloat(input, 64)
+	if err != nil {
+		return 0, timeseries.ErrInvalidTimeFormat
+	}
+	return epoch.Epoch(f * 1e9), nil
+}
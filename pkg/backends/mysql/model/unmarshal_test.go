@@ -0,0 +1,112 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+)
+
+func TestDecodeRows(t *testing.T) {
+	rows, err := decodeRows(strings.NewReader(testTuples))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 6 {
+		t.Fatalf("expected 6 rows got %d", len(rows))
+	}
+	if strings.Join(rows[0], ",") != "T,host,cpu,total,up" {
+		t.Errorf("unexpected names %v", rows[0])
+	}
+	if strings.Join(rows[2], ",") != "4,1,4,5,6" {
+		t.Errorf("unexpected flags %v", rows[2])
+	}
+	if strings.Join(rows[4], ",") != "1699990200,b,,2.00,false" {
+		t.Errorf("unexpected row %v", rows[4])
+	}
+	for _, input := range []string{`{"results":[]}`, `{"results":[{"columns":[]}]}`,
+		`{"results":[{"columns":[{"name":"a"}],"rows":[[1,2]]}]}`,
+		`{"results":[{"columns":[{"name":"a"}],"rows":[[[1]]]}]}`, `[`} {
+		if _, err := decodeRows(strings.NewReader(input)); err != timeseries.ErrInvalidBody {
+			t.Errorf("expected %v for %s got %v", timeseries.ErrInvalidBody, input, err)
+		}
+	}
+}
+
+func TestUnmarshalTimeseries(t *testing.T) {
+	ts, err := UnmarshalTimeseries([]byte(testTuples), testTRQ())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := ts.(*dataset.DataSet)
+	if len(ds.Results) != 1 || len(ds.Results[0].SeriesList) != 2 {
+		t.Fatalf("unexpected results %v", ds.Results)
+	}
+	s := ds.Results[0].SeriesList[0]
+	if s.Header.Tags["host"] != "a" || len(s.Points) != 2 {
+		t.Errorf("unexpected series %+v", s)
+	}
+	if s.Points[0].Epoch != 1699990200*1e9 {
+		t.Errorf("unexpected epoch %d", s.Points[0].Epoch)
+	}
+	if s.Points[0].Values[0] != 1.5 || s.Points[0].Values[1] != "10.50" ||
+		s.Points[0].Values[2] != true {
+		t.Errorf("unexpected values %v", s.Points[0].Values)
+	}
+	if _, err := UnmarshalTimeseriesReader(nil, testTRQ()); err != timeseries.ErrInvalidBody {
+		t.Errorf("expected %v got %v", timeseries.ErrInvalidBody, err)
+	}
+}
+
+func TestTypeToFieldDataType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected timeseries.FieldDataType
+	}{
+		{"BIGINT", timeseries.Int64},
+		{"bigint unsigned", timeseries.Uint64},
+		{"INT(11)", timeseries.Int64},
+		{"DOUBLE", timeseries.Float64},
+		{"FLOAT", timeseries.Float64},
+		{"DECIMAL(10,2)", timeseries.String},
+		{"DATETIME", timeseries.String},
+		{"", timeseries.String},
+	}
+	for _, test := range tests {
+		if got := typeToFieldDataType(test.input); got != test.expected {
+			t.Errorf("expected %d for %s got %d", test.expected, test.input, got)
+		}
+	}
+}
+
+func TestParseTimeField(t *testing.T) {
+	for input, expected := range map[string]int64{
+		"1699990200":        1699990200 * 1e9,
+		"1699990200.000000": 1699990200 * 1e9,
+	} {
+		e, err := parseTimeField(input, timeseries.FieldDefinition{})
+		if err != nil || int64(e) != expected {
+			t.Errorf("expected %d for %s got %d, %v", expected, input, e, err)
+		}
+	}
+	if _, err := parseTimeField("2023-11-14", timeseries.FieldDefinition{}); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package mysql provides the MySQL backend provider, which accelerates
+// time series queries made to a MySQL HTTP gateway
+package mysql
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	modelmy "github.com/trickstercache/trickster/v2/pkg/backends/mysql/model"
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/backends/providers/registry/types"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+var _ backends.TimeseriesBackend = (*Client)(nil)
+
+// Client Implements the Proxy Client Interface
+type Client struct {
+	backends.TimeseriesBackend
+}
+
+var _ types.NewBackendClientFunc = NewClient
+
+// NewClient returns a new Client Instance
+func NewClient(name string, o *bo.Options, router http.Handler,
+	cache cache.Cache, _ backends.Backends,
+	_ types.Lookup,
+) (backends.Backend, error) {
+	if o != nil {
+		o.FastForwardDisable = true
+	}
+	c := &Client{}
+	b, err := backends.NewTimeseriesBackend(name, o, c.RegisterHandlers, router, cache, modelmy.NewModeler())
+	c.TimeseriesBackend = b
+	return c, err
+}
+
+// ParseTimeRangeQuery parses the key parts of a TimeRangeQuery from the inbound
+// gateway query request. A SELECT that can't be delta cached remains eligible
+// for the object proxy cache.
+func (c *Client) ParseTimeRangeQuery(r *http.Request) (*timeseries.TimeRangeQuery, *timeseries.RequestOptions, bool, error) {
+	qr, err := parseRequest(r)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	now := time.Now()
+	analysis := dialectAnalyzer.Analyze(qr.sql, now)
+	c.observeAnalysis(analysis)
+
+	trq := &timeseries.TimeRangeQuery{
+		Statement:        qr.sql,
+		CacheKeyElements: qr.cacheKeyElements(qr.sql),
+	}
+	trq.OriginalBody, _ = request.GetBody(r)
+	if analysis.Mode != sqlanalyzer.CacheModeDelta || analysis.Plan == nil {
+		if analysis.Mode < sqlanalyzer.CacheModeObject {
+			return nil, nil, false, analysis.Err
+		}
+		return trq, nil, true, analysis.Err
+	}
+	if !isTuplesRequest(r) {
+		return trq, nil, true, ErrRowsFormat
+	}
+
+	plan := analysis.Plan
+	trq.Statement = plan.CanonicalSQL
+	trq.CacheKeyElements = qr.cacheKeyElements(plan.CanonicalSQL)
+	trq.Step = plan.Step
+	trq.StepNS = plan.Step.Nanoseconds()
+	trq.Extent.Start = plan.LowerBound.Value
+	if !plan.LowerBound.Inclusive {
+		trq.Extent.Start = trq.Extent.Start.Add(plan.Step)
+	}
+	if plan.UpperBound == nil {
+		trq.Extent.End = now
+	} else {
+		trq.Extent.End = plan.UpperBound.Value
+		if !plan.UpperBound.Inclusive {
+			trq.Extent.End = trq.Extent.End.Add(-plan.Step)
+		}
+	}
+	trq.TimestampDefinition = timeseries.FieldDefinition{
+		Name:          plan.OutputColumn,
+		DataType:      plan.OutputUnit,
+		Role:          timeseries.RoleTimestamp,
+		ProviderData1: byte(plan.InputUnit),
+	}
+	trq.TagFieldDefintions = make(timeseries.FieldDefinitions, len(plan.GroupColumns))
+	for i, name := range plan.GroupColumns {
+		trq.TagFieldDefintions[i] = timeseries.FieldDefinition{Name: name, Role: timeseries.RoleTag}
+	}
+	trq.ParsedQuery = plan
+	trq.ExtractBackfillTolerance(qr.sql)
+
+	rlo := &timeseries.RequestOptions{
+		BaseTimestampFieldName: plan.TimeColumn,
+	}
+	rlo.ExtractFastForwardDisabled(qr.sql)
+	return trq, rlo, true, nil
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	te "github.com/trickstercache/trickster/v2/pkg/errors"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func newTestRequest(path, body string) *http.Request {
+	r, _ := http.NewRequest(http.MethodPost, "http://example"+path, strings.NewReader(body))
+	return r
+}
+
+func TestNewClient(t *testing.T) {
+	o := bo.New()
+	o.Provider = "TEST_CLIENT"
+	c, err := NewClient("default", o, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name() != "default" {
+		t.Errorf("expected %s got %s", "default", c.Name())
+	}
+	if _, ok := c.(backends.TimeseriesBackend); !ok {
+		t.Error("expected a TimeseriesBackend")
+	}
+	if !o.FastForwardDisable {
+		t.Error("expected fast forward to be disabled")
+	}
+}
+
+func TestParseTimeRangeQuery(t *testing.T) {
+	client := &Client{}
+	body := `{"sql":"SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, host, AVG(cpu) AS cpu ` +
+		`FROM metrics WHERE ts >= FROM_UNIXTIME(1699990200) AND ts < FROM_UNIXTIME(1699996200) ` +
+		`GROUP BY t, host","database":"telemetry","args":[ 1, "a" ]}`
+	trq, rlo, canOPC, err := client.ParseTimeRangeQuery(newTestRequest(PathQueryTuples, body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !canOPC || rlo == nil || rlo.BaseTimestampFieldName != "ts" {
+		t.Errorf("unexpected request options %+v", rlo)
+	}
+	if trq.Step != time.Minute {
+		t.Errorf("expected %s got %s", time.Minute, trq.Step)
+	}
+	if want := time.Unix(1699990200, 0); !trq.Extent.Start.Equal(want) {
+		t.Errorf("expected %s got %s", want, trq.Extent.Start)
+	}
+	if want := time.Unix(1699996140, 0); !trq.Extent.End.Equal(want) {
+		t.Errorf("expected %s got %s", want, trq.Extent.End)
+	}
+	if trq.TimestampDefinition.Name != "t" ||
+		trq.TimestampDefinition.DataType != timeseries.DateTimeUnixSecs {
+		t.Errorf("unexpected timestamp definition %+v", trq.TimestampDefinition)
+	}
+	if len(trq.TagFieldDefintions) != 1 || trq.TagFieldDefintions[0].Name != "host" {
+		t.Errorf("unexpected tag definitions %+v", trq.TagFieldDefintions)
+	}
+	if trq.CacheKeyElements[ParamSQL] != trq.Statement ||
+		!strings.Contains(trq.Statement, "<$TS1$>") ||
+		trq.CacheKeyElements[ParamDatabase] != "telemetry" ||
+		trq.CacheKeyElements[ParamArgs] != `[1,"a"]` {
+		t.Errorf("unexpected cache key elements %v", trq.CacheKeyElements)
+	}
+
+	// the rows endpoint is only object cached
+	_, _, canOPC, err = client.ParseTimeRangeQuery(newTestRequest(PathQueryRows, body))
+	if !canOPC || !errors.Is(err, ErrRowsFormat) {
+		t.Errorf("expected %v got %v", ErrRowsFormat, err)
+	}
+
+	// a query that is not a time series remains eligible for the object cache
+	_, _, canOPC, err = client.ParseTimeRangeQuery(newTestRequest(PathQueryTuples,
+		`{"sql":"SELECT host FROM metrics"}`))
+	if !canOPC || err == nil {
+		t.Errorf("expected object cache eligibility and an error, got %t, %v", canOPC, err)
+	}
+
+	// a non-SELECT statement is not cacheable
+	_, _, canOPC, err = client.ParseTimeRangeQuery(newTestRequest(PathQueryTuples,
+		`{"sql":"DELETE FROM metrics"}`))
+	if canOPC || err == nil {
+		t.Errorf("expected no cache eligibility and an error, got %t, %v", canOPC, err)
+	}
+
+	tests := []struct {
+		name string
+		r    *http.Request
+		err  error
+	}{
+		{"get", func() *http.Request {
+			r, _ := http.NewRequest(http.MethodGet, "http://example"+PathQueryTuples, nil)
+			return r
+		}(), te.ErrInvalidMethod},
+		{"invalid json", newTestRequest(PathQueryTuples, `{"sql":`), te.ErrBadRequest},
+		{"missing sql", newTestRequest(PathQueryTuples, `{"database":"telemetry"}`), nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, _, err := client.ParseTimeRangeQuery(test.r)
+			if err == nil || (test.err != nil && !errors.Is(err, test.err)) {
+				t.Errorf("expected %v got %v", test.err, err)
+			}
+		})
+	}
+}
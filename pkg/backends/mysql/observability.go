@@ -0,0 +1,36 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import "github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+
+const mysqlDialect = "mysql"
+
+func (c *Client) observeAnalysis(analysis sqlanalyzer.Analysis) {
+	sqlanalyzer.ObserveAnalysis(c.observabilityBackendName(), mysqlDialect, analysis)
+}
+
+func (c *Client) observeRewriteFailure(reason string) {
+	sqlanalyzer.ObserveRewriteFailure(c.observabilityBackendName(), mysqlDialect, reason)
+}
+
+func (c *Client) observabilityBackendName() string {
+	if c == nil || c.TimeseriesBackend == nil {
+		return ""
+	}
+	return c.Name()
+}
@@ -0,0 +1,482 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	// test_driver provides the literal value expressions of the standalone parser
+	_ "github.com/pingcap/tidb/pkg/parser/test_driver"
+)
+
+type analyzer struct{}
+
+var dialectAnalyzer sqlanalyzer.DialectAnalyzer = analyzer{}
+
+// restoreFlags format statements with quoted names, backslash-escaped string
+// literals and explicit non-default character set introducers, so a formatted
+// statement is equivalent to the original under the default SQL mode.
+const restoreFlags = format.DefaultRestoreFlags | format.RestoreStringEscapeBackslash |
+	format.RestoreStringWithoutDefaultCharset | format.RestoreSpacesAroundBinaryOperation
+
+// parsers are not safe for concurrent use, so they are pooled
+var parsers = sync.Pool{New: func() any { return parser.New() }}
+
+func parseStatement(statement string) (ast.StmtNode, error) {
+	p := parsers.Get().(*parser.Parser)
+	defer parsers.Put(p)
+	statements, _, err := p.Parse(statement, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSQL, err)
+	}
+	if len(statements) != 1 {
+		return nil, fmt.Errorf("%w: expected one statement, got %d", ErrInvalidSQL, len(statements))
+	}
+	return statements[0], nil
+}
+
+func restore(node ast.Node) (string, error) {
+	var sb strings.Builder
+	if err := node.Restore(format.NewRestoreCtx(restoreFlags, &sb)); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// expressionKey returns the formatted expression, so that expressions differing
+// only in formatting or keyword case can be compared
+func expressionKey(expression ast.ExprNode) string {
+	key, err := restore(expression)
+	if err != nil {
+		return ""
+	}
+	return key
+}
+
+func (analyzer) Analyze(statement string, now time.Time) sqlanalyzer.Analysis {
+	if strings.TrimSpace(statement) == "" {
+		return sqlanalyzer.Analysis{Reason: sqlanalyzer.ReasonInvalidSQL, Err: ErrNotTimeRangeQuery}
+	}
+	node, err := parseStatement(statement)
+	if err != nil {
+		mode := sqlanalyzer.CacheModeNone
+		if isSelectQuery(statement) {
+			mode = sqlanalyzer.CacheModeObject
+		}
+		return sqlanalyzer.Analysis{Mode: mode, Reason: sqlanalyzer.ReasonInvalidSQL, Err: err}
+	}
+	var stmt *ast.SelectStmt
+	switch n := node.(type) {
+	case *ast.SelectStmt:
+		stmt = n
+	case *ast.SetOprStmt:
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedStatement, ErrUnsupportedStatement)
+	}
+	if stmt == nil || stmt.SelectIntoOpt != nil {
+		return sqlanalyzer.Analysis{
+			Mode: sqlanalyzer.CacheModeNone, Reason: sqlanalyzer.ReasonUnsupportedStatement,
+			Err: ErrUnsupportedStatement,
+		}
+	}
+	if err := checkShape(stmt); err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedStatement, err)
+	}
+	if stmt.Limit != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedLimit, ErrLimitUnsupported)
+	}
+
+	bucket, err := analyzeSelectList(stmt.Fields)
+	if err != nil {
+		if errors.Is(err, ErrAmbiguousTimeAxis) {
+			return objectAnalysis(sqlanalyzer.ReasonAmbiguousTimeAxis, err)
+		}
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedBucket, err)
+	}
+	groups, err := analyzeGroupBy(stmt, bucket)
+	if err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedGrouping, err)
+	}
+	if err := analyzeOrderBy(stmt.OrderBy, stmt.Fields.Fields, bucket); err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedStatement, err)
+	}
+	if stmt.Having != nil && referencesTimeAxis(stmt.Having.Expr, bucket, true) {
+		return objectAnalysis(sqlanalyzer.ReasonUnsafePredicate, ErrUnsafePredicate)
+	}
+	ranges, err := analyzeRanges(stmt, bucket)
+	if err != nil {
+		reason := sqlanalyzer.ReasonNotTimeRange
+		if errors.Is(err, ErrUnsafePredicate) {
+			reason = sqlanalyzer.ReasonUnsafePredicate
+		} else if errors.Is(err, ErrAmbiguousTimeAxis) {
+			reason = sqlanalyzer.ReasonAmbiguousTimeAxis
+		}
+		return objectAnalysis(reason, err)
+	}
+
+	canonical, renderer, err := buildQueryArtifacts(stmt, ranges, bucket)
+	if err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedStatement,
+			fmt.Errorf("%w: %w", ErrUnsupportedStatement, err))
+	}
+	inputUnit := timeseries.DateTimeUnixSecs
+	if bucket.temporal {
+		inputUnit = timeseries.DateTimeSQL
+	}
+	plan := &sqlanalyzer.QueryPlan{
+		CanonicalSQL: canonical,
+		TimeColumn:   bucket.timeColumn,
+		OutputColumn: bucket.outputColumn,
+		Step:         bucket.step,
+		OutputUnit:   timeseries.DateTimeUnixSecs,
+		InputUnit:    inputUnit,
+		LowerBound: &sqlanalyzer.Bound{
+			Value: ranges.lower.value, Inclusive: ranges.lower.inclusive,
+		},
+		GroupColumns: groups,
+		Renderer:     renderer,
+	}
+	if ranges.upper != nil {
+		plan.UpperBound = &sqlanalyzer.Bound{
+			Value: ranges.upper.value, Inclusive: ranges.upper.inclusive,
+		}
+	}
+	return sqlanalyzer.Analysis{
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Plan: plan,
+	}
+}
+
+func objectAnalysis(reason sqlanalyzer.AnalysisReason, err error) sqlanalyzer.Analysis {
+	return sqlanalyzer.Analysis{Mode: sqlanalyzer.CacheModeObject, Reason: reason, Err: err}
+}
+
+// checkShape returns an error if the statement reads from anything other than
+// a single table, or uses CTEs, subqueries, window functions, variables,
+// locking reads, or the snapshot and sampling clauses of the TiDB grammar,
+// none of which the analyzer can verify.
+func checkShape(stmt *ast.SelectStmt) error {
+	if stmt.Kind != ast.SelectStmtKindSelect || stmt.With != nil || len(stmt.WindowSpecs) > 0 ||
+		(stmt.LockInfo != nil && stmt.LockInfo.LockType != ast.SelectLockNone) {
+		return ErrUnsupportedStatement
+	}
+	if stmt.From != nil {
+		join := stmt.From.TableRefs
+		if join == nil || join.Right != nil {
+			return ErrUnsupportedStatement
+		}
+		source, ok := join.Left.(*ast.TableSource)
+		if !ok {
+			return ErrUnsupportedStatement
+		}
+		table, ok := source.Source.(*ast.TableName)
+		if !ok || table.AsOf != nil || table.TableSample != nil {
+			return ErrUnsupportedStatement
+		}
+	}
+	unsupported := containsNode(stmt, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.SubqueryExpr, *ast.WindowFuncExpr, *ast.VariableExpr:
+			return true
+		}
+		return false
+	})
+	if unsupported {
+		return ErrUnsupportedStatement
+	}
+	return nil
+}
+
+// nodeVisitor visits nodes until match returns true
+type nodeVisitor struct {
+	match func(ast.Node) bool
+	found bool
+}
+
+func (v *nodeVisitor) Enter(n ast.Node) (ast.Node, bool) {
+	if !v.found && v.match(n) {
+		v.found = true
+	}
+	return n, v.found
+}
+
+func (v *nodeVisitor) Leave(n ast.Node) (ast.Node, bool) {
+	return n, !v.found
+}
+
+// containsNode returns true if match returns true for the node or any of its
+// descendants
+func containsNode(node ast.Node, match func(ast.Node) bool) bool {
+	v := &nodeVisitor{match: match}
+	node.Accept(v)
+	return v.found
+}
+
+func unwrapParentheses(expression ast.ExprNode) ast.ExprNode {
+	for {
+		p, ok := expression.(*ast.ParenthesesExpr)
+		if !ok {
+			return expression
+		}
+		expression = p.Expr
+	}
+}
+
+type bucketSpec struct {
+	// column is the raw timestamp column of the bucket expression
+	column       *ast.ColumnName
+	timeColumn   string
+	outputColumn string
+	step         time.Duration
+	// temporal is true when the timestamp column is a DATETIME or TIMESTAMP
+	// converted by UNIX_TIMESTAMP, rather than a column of epoch seconds
+	temporal bool
+	// key is the formatted bucket expression
+	key string
+	// field is the index of the bucket in the select list
+	field int
+}
+
+func analyzeSelectList(fields *ast.FieldList) (bucketSpec, error) {
+	var found *bucketSpec
+	for i, field := range fields.Fields {
+		if field.Expr == nil {
+			continue
+		}
+		bucket, ok := matchBucket(field.Expr)
+		if !ok {
+			continue
+		}
+		if found != nil {
+			return bucketSpec{}, ErrAmbiguousTimeAxis
+		}
+		bucket.outputColumn = fieldName(field)
+		bucket.field = i
+		found = &bucket
+	}
+	if found == nil {
+		return bucketSpec{}, ErrMissingTimeseries
+	}
+	return *found, nil
+}
+
+// fieldName returns the name MySQL gives to the result column of a select field
+func fieldName(field *ast.SelectField) string {
+	if field.AsName.O != "" {
+		return field.AsName.O
+	}
+	if column, ok := field.Expr.(*ast.ColumnNameExpr); ok {
+		return column.Name.Name.O
+	}
+	return field.Text()
+}
+
+// matchBucket matches the epoch seconds bucket expressions FLOOR(x / n) * n and
+// x DIV n * n, in either operand order, where x is UNIX_TIMESTAMP(column) or a
+// column of epoch seconds.
+func matchBucket(expression ast.ExprNode) (bucketSpec, bool) {
+	multiplication, ok := unwrapParentheses(expression).(*ast.BinaryOperationExpr)
+	if !ok || multiplication.Op != opcode.Mul {
+		return bucketSpec{}, false
+	}
+	operands := [][2]ast.ExprNode{
+		{multiplication.L, multiplication.R},
+		{multiplication.R, multiplication.L},
+	}
+	for _, operand := range operands {
+		multiplier, ok := integerValue(operand[1])
+		if !ok || multiplier <= 0 {
+			continue
+		}
+		dividend, divisor, ok := matchQuotient(operand[0])
+		if !ok {
+			continue
+		}
+		if n, ok := integerValue(divisor); !ok || n != multiplier {
+			continue
+		}
+		column, temporal, ok := bucketSource(dividend)
+		if !ok {
+			continue
+		}
+		return bucketSpec{
+			column:     column,
+			timeColumn: column.Name.L,
+			step:       time.Duration(multiplier) * time.Second,
+			temporal:   temporal,
+			key:        expressionKey(expression),
+		}, true
+	}
+	return bucketSpec{}, false
+}
+
+// matchQuotient returns the operands of FLOOR(x / n) or x DIV n
+func matchQuotient(expression ast.ExprNode) (ast.ExprNode, ast.ExprNode, bool) {
+	switch e := unwrapParentheses(expression).(type) {
+	case *ast.FuncCallExpr:
+		if e.FnName.L != "floor" || len(e.Args) != 1 {
+			return nil, nil, false
+		}
+		division, ok := unwrapParentheses(e.Args[0]).(*ast.BinaryOperationExpr)
+		if !ok || division.Op != opcode.Div {
+			return nil, nil, false
+		}
+		return division.L, division.R, true
+	case *ast.BinaryOperationExpr:
+		if e.Op != opcode.IntDiv {
+			return nil, nil, false
+		}
+		return e.L, e.R, true
+	}
+	return nil, nil, false
+}
+
+// bucketSource returns the timestamp column of UNIX_TIMESTAMP(column) or of a
+// bare column, and whether the column is converted by UNIX_TIMESTAMP
+func bucketSource(expression ast.ExprNode) (*ast.ColumnName, bool, bool) {
+	expression = unwrapParentheses(expression)
+	temporal := false
+	if function, ok := expression.(*ast.FuncCallExpr); ok {
+		if function.FnName.L != "unix_timestamp" || len(function.Args) != 1 {
+			return nil, false, false
+		}
+		expression = unwrapParentheses(function.Args[0])
+		temporal = true
+	}
+	column, ok := expression.(*ast.ColumnNameExpr)
+	if !ok {
+		return nil, false, false
+	}
+	return column.Name, temporal, true
+}
+
+func analyzeGroupBy(stmt *ast.SelectStmt, bucket bucketSpec) ([]string, error) {
+	if stmt.GroupBy == nil || stmt.GroupBy.Rollup {
+		return nil, ErrInvalidGroupByClause
+	}
+	fields := stmt.Fields.Fields
+	grouped := make([]bool, len(fields))
+	for _, item := range stmt.GroupBy.Items {
+		i, ok := resolveField(item.Expr, fields, bucket)
+		if !ok {
+			return nil, ErrInvalidGroupByClause
+		}
+		grouped[i] = true
+	}
+	if !grouped[bucket.field] {
+		return nil, ErrInvalidGroupByClause
+	}
+	var groups []string
+	for i, field := range fields {
+		switch {
+		case field.Expr == nil:
+			return nil, ErrInvalidGroupByClause
+		case i == bucket.field:
+		case grouped[i]:
+			if _, ok := unwrapParentheses(field.Expr).(*ast.ColumnNameExpr); !ok {
+				return nil, ErrInvalidGroupByClause
+			}
+			groups = append(groups, fieldName(field))
+		case !containsNode(field.Expr, isAggregate):
+			return nil, ErrInvalidGroupByClause
+		}
+	}
+	return groups, nil
+}
+
+func isAggregate(n ast.Node) bool {
+	_, ok := n.(*ast.AggregateFuncExpr)
+	return ok
+}
+
+// resolveField returns the index of the select field referenced by a GROUP BY
+// or ORDER BY expression, which may be a position, a column, an alias or a
+// repeat of the field's expression. MySQL resolves GROUP BY names to table
+// columns before aliases, so an alias that shadows the timestamp column is
+// ambiguous and is not resolved.
+func resolveField(expression ast.ExprNode, fields []*ast.SelectField,
+	bucket bucketSpec,
+) (int, bool) {
+	expression = unwrapParentheses(expression)
+	if position, ok := expression.(*ast.PositionExpr); ok {
+		if position.P != nil || position.N < 1 || position.N > len(fields) {
+			return 0, false
+		}
+		return position.N - 1, true
+	}
+	if column, ok := expression.(*ast.ColumnNameExpr); ok {
+		for i, field := range fields {
+			if c, ok := field.Expr.(*ast.ColumnNameExpr); ok && c.Name.Name.L == column.Name.Name.L {
+				return i, true
+			}
+		}
+		if column.Name.Table.L == "" && column.Name.Name.L != bucket.timeColumn {
+			for i, field := range fields {
+				if field.AsName.L == column.Name.Name.L {
+					return i, true
+				}
+			}
+		}
+	}
+	key := expressionKey(expression)
+	for i, field := range fields {
+		if field.Expr != nil && key != "" && expressionKey(field.Expr) == key {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// analyzeOrderBy accepts only an ascending order by the bucket, which is the
+// order that results are written from the cache
+func analyzeOrderBy(orderBy *ast.OrderByClause, fields []*ast.SelectField,
+	bucket bucketSpec,
+) error {
+	if orderBy == nil {
+		return nil
+	}
+	if len(orderBy.Items) != 1 || orderBy.Items[0].Desc {
+		return ErrInvalidOrderByClause
+	}
+	if i, ok := resolveField(orderBy.Items[0].Expr, fields, bucket); !ok || i != bucket.field {
+		return ErrInvalidOrderByClause
+	}
+	return nil
+}
+
+// referencesTimeAxis returns true if the expression references the timestamp
+// column, or the bucket alias when alias is true
+func referencesTimeAxis(expression ast.ExprNode, bucket bucketSpec, alias bool) bool {
+	output := strings.ToLower(bucket.outputColumn)
+	return containsNode(expression, func(n ast.Node) bool {
+		column, ok := n.(*ast.ColumnNameExpr)
+		if !ok {
+			return false
+		}
+		return column.Name.Name.L == bucket.timeColumn ||
+			(alias && column.Name.Name.L == output)
+	})
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+const testQuery = "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, host, AVG(cpu) AS cpu " +
+	"FROM metrics WHERE ts >= FROM_UNIXTIME(1699990200) AND ts < FROM_UNIXTIME(1699996200) " +
+	"AND host = 'a\\'b' GROUP BY t, host ORDER BY t"
+
+func analyzeDelta(t *testing.T, query string) *sqlanalyzer.QueryPlan {
+	t.Helper()
+	analysis := dialectAnalyzer.Analyze(query, time.Unix(1_700_000_000, 0))
+	if analysis.Mode != sqlanalyzer.CacheModeDelta || analysis.Plan == nil {
+		t.Fatalf("analysis = (%s, %s, %v), want delta", analysis.Mode.String(),
+			analysis.Reason, analysis.Err)
+	}
+	return analysis.Plan
+}
+
+func TestAnalyzeBuildsPlan(t *testing.T) {
+	plan := analyzeDelta(t, testQuery)
+	if plan.TimeColumn != "ts" || plan.OutputColumn != "t" {
+		t.Errorf("columns = (%s, %s), want (ts, t)", plan.TimeColumn, plan.OutputColumn)
+	}
+	if plan.InputUnit != timeseries.DateTimeSQL || plan.OutputUnit != timeseries.DateTimeUnixSecs {
+		t.Errorf("units = (%d, %d)", plan.InputUnit, plan.OutputUnit)
+	}
+	if !slices.Equal(plan.GroupColumns, []string{"host"}) {
+		t.Errorf("group columns = %v, want [host]", plan.GroupColumns)
+	}
+	if !plan.LowerBound.Value.Equal(time.Unix(1699990200, 0)) || !plan.LowerBound.Inclusive {
+		t.Errorf("lower bound = %+v", plan.LowerBound)
+	}
+	if plan.UpperBound == nil || !plan.UpperBound.Value.Equal(time.Unix(1699996200, 0)) ||
+		plan.UpperBound.Inclusive {
+		t.Errorf("upper bound = %+v", plan.UpperBound)
+	}
+	const expected = "SELECT FLOOR(UNIX_TIMESTAMP(`ts`) / 60) * 60 AS `t`,`host`,AVG(`cpu`) AS `cpu` " +
+		"FROM `metrics` WHERE `ts` >= <$TS1$> AND `ts` < <$TS2$> AND `host` = 'a''b' " +
+		"GROUP BY `t`,`host` ORDER BY `t`"
+	if plan.CanonicalSQL != expected {
+		t.Errorf("canonical SQL =\n%s\nwant\n%s", plan.CanonicalSQL, expected)
+	}
+}
+
+func TestAnalyzeNumericTimeColumn(t *testing.T) {
+	const query = "SELECT epoch DIV 60 * 60 AS t, COUNT(*) FROM flows " +
+		"WHERE epoch >= 1699990200 AND epoch < 1699996200 GROUP BY t"
+	plan := analyzeDelta(t, query)
+	if plan.InputUnit != timeseries.DateTimeUnixSecs {
+		t.Errorf("input unit = %d, want %d", plan.InputUnit, timeseries.DateTimeUnixSecs)
+	}
+	rendered, err := plan.RenderExtent(timeseries.Extent{
+		Start: time.Unix(1699990260, 0), End: time.Unix(1699990320, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(rendered, "`epoch` >= 1699990260 AND `epoch` < 1699990380") {
+		t.Errorf("unexpected rendered query: %s", rendered)
+	}
+	// a numeric column may hold fractional seconds, so an inclusive upper
+	// bound can't be converted to an exclusive one
+	analysis := dialectAnalyzer.Analyze(strings.Replace(query, "epoch < 1699996200",
+		"epoch <= 1699996199", 1), time.Unix(1_700_000_000, 0))
+	if analysis.Reason != sqlanalyzer.ReasonUnsafePredicate {
+		t.Errorf("reason = %s, want %s", analysis.Reason, sqlanalyzer.ReasonUnsafePredicate)
+	}
+}
+
+func TestBoundSemantics(t *testing.T) {
+	const prefix = "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, COUNT(*) FROM events WHERE "
+	extent := timeseries.Extent{Start: time.Unix(1699990200, 0), End: time.Unix(1699990260, 0)}
+	tests := []struct {
+		name      string
+		predicate string
+		start     int64
+		end       int64
+		rendered  []string
+	}{
+		{
+			name:      "half open",
+			predicate: "ts >= FROM_UNIXTIME(1699990200) AND ts < FROM_UNIXTIME(1699990800)",
+			start:     1699990200, end: 1699990740,
+			rendered: []string{"`ts` >= FROM_UNIXTIME(1699990200)", "`ts` < FROM_UNIXTIME(1699990320)"},
+		},
+		{
+			name:      "reversed operands",
+			predicate: "FROM_UNIXTIME(1699990200) <= ts AND FROM_UNIXTIME(1699990800) > ts",
+			start:     1699990200, end: 1699990740,
+			rendered: []string{"FROM_UNIXTIME(1699990200) <= `ts`", "FROM_UNIXTIME(1699990320) > `ts`"},
+		},
+		{
+			name:      "inclusive microsecond upper",
+			predicate: "ts >= FROM_UNIXTIME(1699990200) AND ts <= FROM_UNIXTIME(1699990799.999999)",
+			start:     1699990200, end: 1699990740,
+			rendered: []string{"`ts` <= FROM_UNIXTIME(1699990319.999999)"},
+		},
+		{
+			name:      "bucket expression comparisons",
+			predicate: "FLOOR(UNIX_TIMESTAMP(ts)/60)*60 > 1699990150 AND FLOOR(UNIX_TIMESTAMP(ts)/60)*60 <= 1699990750",
+			start:     1699990200, end: 1699990740,
+			rendered: []string{"> 1699990140", "<= 1699990260"},
+		},
+		{
+			name:      "open upper",
+			predicate: "ts >= FROM_UNIXTIME(1699990200)",
+			start:     1699990200, end: 1699999980,
+			rendered: []string{"`ts` >= FROM_UNIXTIME(1699990200) AND `ts` < FROM_UNIXTIME(1699990320)"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			analysis := dialectAnalyzer.Analyze(prefix+test.predicate+" GROUP BY t",
+				time.Unix(1700000000, 0))
+			if analysis.Mode != sqlanalyzer.CacheModeDelta {
+				t.Fatalf("analysis = (%s, %s, %v)", analysis.Mode.String(), analysis.Reason, analysis.Err)
+			}
+			start, end := effectiveExtent(analysis.Plan, time.Unix(1699999980, 0))
+			if start != test.start || end != test.end {
+				t.Errorf("extent = (%d, %d), want (%d, %d)", start, end, test.start, test.end)
+			}
+			rendered, err := analysis.Plan.RenderExtent(extent)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range test.rendered {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("rendered query lacks %q: %s", want, rendered)
+				}
+			}
+		})
+	}
+}
+
+// effectiveExtent returns the first and last bucket selected by the plan, as
+// ParseTimeRangeQuery derives them
+func effectiveExtent(plan *sqlanalyzer.QueryPlan, now time.Time) (int64, int64) {
+	start := plan.LowerBound.Value
+	if !plan.LowerBound.Inclusive {
+		start = start.Add(plan.Step)
+	}
+	end := now
+	if plan.UpperBound != nil {
+		end = plan.UpperBound.Value
+		if !plan.UpperBound.Inclusive {
+			end = end.Add(-plan.Step)
+		}
+	}
+	return start.Unix(), end.Unix()
+}
+
+func TestCanonicalCacheIdentityIgnoresExtent(t *testing.T) {
+	a := analyzeDelta(t, testQuery)
+	b := analyzeDelta(t, strings.NewReplacer("1699990200", "1699900200",
+		"1699996200", "1699906200").Replace(testQuery))
+	if a.CanonicalSQL != b.CanonicalSQL {
+		t.Errorf("canonical SQL differs:\n%s\n%s", a.CanonicalSQL, b.CanonicalSQL)
+	}
+}
+
+func TestRendererPrivatePlaceholdersAreCollisionSafe(t *testing.T) {
+	query := "SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, COUNT(*) FROM events " +
+		"WHERE note = '`<$TRICKSTER_TS1_0$>`' AND `<$TRICKSTER_TS2_1$>` = 1 AND marker = '<$TS1$>' " +
+		"AND ts >= FROM_UNIXTIME(1699990200) AND ts < FROM_UNIXTIME(1699990800) GROUP BY t"
+	plan := analyzeDelta(t, query)
+	if !strings.Contains(plan.CanonicalSQL, "`note` = '`<$TRICKSTER_TS1_0$>`'") ||
+		!strings.Contains(plan.CanonicalSQL, "`<$TRICKSTER_TS2_1$>` = 1") ||
+		!strings.Contains(plan.CanonicalSQL, "`marker` = '<$TS1$>'") {
+		t.Fatalf("canonicalization modified user SQL: %s", plan.CanonicalSQL)
+	}
+	rendered, err := plan.RenderExtent(timeseries.Extent{
+		Start: time.Unix(1699990260, 0), End: time.Unix(1699990320, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"`note` = '`<$TRICKSTER_TS1_0$>`'", "`<$TRICKSTER_TS2_1$>` = 1",
+		"`marker` = '<$TS1$>'", "`ts` >= FROM_UNIXTIME(1699990260)", "`ts` < FROM_UNIXTIME(1699990380)"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("rendered query lacks %q: %s", want, rendered)
+		}
+	}
+}
+
+func TestRendererIsConcurrentAndImmutable(t *testing.T) {
+	const workers = 64
+	plan := analyzeDelta(t, testQuery)
+	results := make([]string, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Go(func() {
+			start := int64(1699990200 + i*120)
+			results[i], errs[i] = plan.RenderExtent(timeseries.Extent{
+				Start: time.Unix(start, 0), End: time.Unix(start+60, 0),
+			})
+		})
+	}
+	wg.Wait()
+
+	for i := range workers {
+		if errs[i] != nil {
+			t.Fatalf("render %d: %v", i, errs[i])
+		}
+		start := int64(1699990200 + i*120)
+		for _, want := range []string{
+			"`ts` >= FROM_UNIXTIME(" + strconv.FormatInt(start, 10) + ")",
+			"`ts` < FROM_UNIXTIME(" + strconv.FormatInt(start+120, 10) + ")",
+		} {
+			if !strings.Contains(results[i], want) {
+				t.Errorf("render %d lacks %q: %s", i, want, results[i])
+			}
+		}
+	}
+}
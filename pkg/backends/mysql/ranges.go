@@ -0,0 +1,323 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+)
+
+type endpoint uint8
+
+const (
+	endpointLower endpoint = iota
+	endpointUpper
+)
+
+type boundStyle uint8
+
+const (
+	// boundSeconds is an epoch seconds literal
+	boundSeconds boundStyle = iota
+	// boundFromUnixtime is FROM_UNIXTIME(epoch seconds)
+	boundFromUnixtime
+)
+
+type boundTarget struct {
+	endpoint endpoint
+	style    boundStyle
+	offset   time.Duration
+	set      func(ast.ExprNode)
+}
+
+type timeBound struct {
+	value     time.Time
+	inclusive bool
+	// onBucket is true when the predicate compares the bucket expression,
+	// rather than the raw timestamp column
+	onBucket bool
+	target   *boundTarget
+}
+
+type rangeAnalysis struct {
+	lower        *timeBound
+	upper        *timeBound
+	addSynthetic func(ast.ExprNode)
+}
+
+// analyzeRanges finds the lower and optional upper bounds of the time range
+// among the top-level conjuncts of the WHERE clause. Every predicate touching
+// the timestamp column must be one of these bounds.
+func analyzeRanges(stmt *ast.SelectStmt, bucket bucketSpec) (rangeAnalysis, error) {
+	var result rangeAnalysis
+	if stmt.Where == nil {
+		return result, ErrNoLowerBound
+	}
+	for _, conjunct := range flattenConjunction(stmt.Where, nil) {
+		if !referencesTimeAxis(conjunct, bucket, false) {
+			continue
+		}
+		lower, upper, err := analyzePredicate(conjunct, bucket)
+		if err != nil {
+			return result, err
+		}
+		if lower != nil {
+			if result.lower != nil {
+				return result, ErrAmbiguousTimeAxis
+			}
+			result.lower = lower
+		}
+		if upper != nil {
+			if result.upper != nil {
+				return result, ErrAmbiguousTimeAxis
+			}
+			result.upper = upper
+		}
+	}
+	if result.lower == nil {
+		return result, ErrNoLowerBound
+	}
+	if err := normalizeBounds(&result, bucket); err != nil {
+		return result, err
+	}
+	if result.upper == nil {
+		result.addSynthetic = func(expression ast.ExprNode) {
+			stmt.Where = &ast.BinaryOperationExpr{Op: opcode.LogicAnd, L: stmt.Where, R: expression}
+		}
+	}
+	return result, nil
+}
+
+func flattenConjunction(expression ast.ExprNode, out []ast.ExprNode) []ast.ExprNode {
+	expression = unwrapParentheses(expression)
+	if binary, ok := expression.(*ast.BinaryOperationExpr); ok && binary.Op == opcode.LogicAnd {
+		out = flattenConjunction(binary.L, out)
+		return flattenConjunction(binary.R, out)
+	}
+	return append(out, expression)
+}
+
+// analyzePredicate returns the bounds described by a comparison or BETWEEN
+// predicate on the timestamp column or on the bucket expression. Any other
+// predicate touching the timestamp column, including OR, XOR and NOT forms,
+// is unsafe.
+func analyzePredicate(expression ast.ExprNode, bucket bucketSpec) (*timeBound, *timeBound, error) {
+	switch e := expression.(type) {
+	case *ast.BetweenExpr:
+		if e.Not {
+			return nil, nil, ErrUnsafePredicate
+		}
+		onBucket, ok := timeOperand(e.Expr, bucket)
+		if !ok {
+			return nil, nil, ErrUnsafePredicate
+		}
+		lower, ok := evaluateBound(e.Left, onBucket, bucket)
+		if !ok {
+			return nil, nil, ErrUnsafePredicate
+		}
+		upper, ok := evaluateBound(e.Right, onBucket, bucket)
+		if !ok {
+			return nil, nil, ErrUnsafePredicate
+		}
+		lower.inclusive, upper.inclusive = true, true
+		lower.target.endpoint, upper.target.endpoint = endpointLower, endpointUpper
+		lower.target.set = func(n ast.ExprNode) { e.Left = n }
+		upper.target.set = func(n ast.ExprNode) { e.Right = n }
+		return lower, upper, nil
+	case *ast.BinaryOperationExpr:
+		operator, ok := comparators[e.Op]
+		if !ok {
+			return nil, nil, ErrUnsafePredicate
+		}
+		onBucket, ok := timeOperand(e.L, bucket)
+		boundExpression := e.R
+		set := func(n ast.ExprNode) { e.R = n }
+		if !ok {
+			onBucket, ok = timeOperand(e.R, bucket)
+			boundExpression = e.L
+			set = func(n ast.ExprNode) { e.L = n }
+			operator = sqlanalyzer.InvertComparator(operator)
+		}
+		if !ok {
+			return nil, nil, ErrUnsafePredicate
+		}
+		bound, ok := evaluateBound(boundExpression, onBucket, bucket)
+		if !ok {
+			return nil, nil, ErrUnsafePredicate
+		}
+		bound.target.set = set
+		bound.inclusive = operator == ">=" || operator == "<="
+		if operator == ">" || operator == ">=" {
+			bound.target.endpoint = endpointLower
+			return bound, nil, nil
+		}
+		bound.target.endpoint = endpointUpper
+		return nil, bound, nil
+	}
+	return nil, nil, ErrUnsafePredicate
+}
+
+// comparators maps the range comparison opcodes to their SQL comparators
+var comparators = map[opcode.Op]string{
+	opcode.GE: ">=",
+	opcode.GT: ">",
+	opcode.LE: "<=",
+	opcode.LT: "<",
+}
+
+// timeOperand returns true if the expression is the timestamp column or the
+// bucket expression, and whether it is the bucket expression
+func timeOperand(expression ast.ExprNode, bucket bucketSpec) (bool, bool) {
+	expression = unwrapParentheses(expression)
+	if column, ok := expression.(*ast.ColumnNameExpr); ok {
+		return false, column.Name.Name.L == bucket.timeColumn
+	}
+	if key := expressionKey(expression); key != "" && key == bucket.key {
+		return true, true
+	}
+	return false, false
+}
+
+// evaluateBound returns the time of a bound value. Bucket expressions and
+// epoch seconds columns are compared with integer epoch seconds, and DATETIME
+// and TIMESTAMP columns with FROM_UNIXTIME(epoch seconds). Other forms, like
+// string literals, depend on the session time zone and are not evaluated.
+func evaluateBound(expression ast.ExprNode, onBucket bool, bucket bucketSpec) (*timeBound, bool) {
+	bound := &timeBound{onBucket: onBucket, target: &boundTarget{style: boundSeconds}}
+	if onBucket || !bucket.temporal {
+		seconds, ok := integerValue(expression)
+		if !ok {
+			return nil, false
+		}
+		bound.value = time.Unix(seconds, 0)
+		return bound, true
+	}
+	function, ok := unwrapParentheses(expression).(*ast.FuncCallExpr)
+	if !ok || function.FnName.L != "from_unixtime" || len(function.Args) != 1 {
+		return nil, false
+	}
+	value, ok := epochValue(function.Args[0])
+	if !ok {
+		return nil, false
+	}
+	bound.value = value
+	bound.target.style = boundFromUnixtime
+	return bound, true
+}
+
+// integerValue returns the value of an integer literal
+func integerValue(expression ast.ExprNode) (int64, bool) {
+	value, ok := unwrapParentheses(expression).(ast.ValueExpr)
+	if !ok {
+		return 0, false
+	}
+	switch v := value.GetValue().(type) {
+	case int64:
+		return v, true
+	case uint64:
+		if v <= math.MaxInt64 {
+			return int64(v), true
+		}
+	}
+	return 0, false
+}
+
+// epochValue returns the time of an integer or decimal epoch seconds literal
+func epochValue(expression ast.ExprNode) (time.Time, bool) {
+	if seconds, ok := integerValue(expression); ok {
+		return time.Unix(seconds, 0), true
+	}
+	value, ok := unwrapParentheses(expression).(ast.ValueExpr)
+	if !ok {
+		return time.Time{}, false
+	}
+	decimal, ok := value.GetValue().(fmt.Stringer)
+	if !ok {
+		return time.Time{}, false
+	}
+	whole, fraction, _ := strings.Cut(decimal.String(), ".")
+	if len(fraction) > 9 {
+		return time.Time{}, false
+	}
+	seconds, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}, false
+	}
+	var nanoseconds int64
+	if fraction != "" {
+		nanoseconds, err = strconv.ParseInt(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+	}
+	return time.Unix(seconds, nanoseconds), true
+}
+
+// normalizeBounds converts SQL predicates into Trickster's inclusive bucket
+// extent convention. Raw timestamp predicates must describe complete buckets;
+// otherwise a partial aggregate could be cached as a complete bucket.
+// Predicates on the bucket expression are discrete and can safely move by one
+// cadence for strict comparisons.
+//
+// MySQL temporal values have at most microsecond precision, so an inclusive
+// upper bound one microsecond before a bucket boundary, as in
+// ts BETWEEN FROM_UNIXTIME(1700000000) AND FROM_UNIXTIME(1700003599.999999),
+// is equivalent to an exclusive upper bound at the boundary.
+func normalizeBounds(result *rangeAnalysis, bucket bucketSpec) error {
+	cadence := sqlanalyzer.Cadence{Step: bucket.step}
+	lower := result.lower
+	if lower.onBucket {
+		if lower.inclusive {
+			lower.value = cadence.Ceil(lower.value)
+		} else {
+			lower.value = cadence.Floor(lower.value)
+			lower.target.offset = -bucket.step
+		}
+	} else if !lower.inclusive || !cadence.Aligned(lower.value) {
+		return ErrUnsafePredicate
+	}
+
+	upper := result.upper
+	if upper == nil {
+		return nil
+	}
+	switch {
+	case upper.onBucket && upper.inclusive:
+		upper.value = cadence.Floor(upper.value)
+	case upper.onBucket:
+		upper.value = cadence.Ceil(upper.value)
+		upper.target.offset = bucket.step
+	case !upper.inclusive && cadence.Aligned(upper.value):
+		upper.target.offset = bucket.step
+	case upper.inclusive && upper.target.style == boundFromUnixtime &&
+		cadence.Aligned(upper.value.Add(time.Microsecond)):
+		upper.value = upper.value.Add(time.Microsecond)
+		upper.inclusive = false
+		upper.target.offset = bucket.step - time.Microsecond
+	default:
+		return ErrUnsafePredicate
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+)
+
+type mysqlRenderer struct {
+	template string
+	bounds   []rendererBound
+}
+
+func (r *mysqlRenderer) RenderExtent(extent timeseries.Extent) (string, error) {
+	statement := r.template
+	for _, bound := range r.bounds {
+		value := extent.Start
+		if bound.endpoint == endpointUpper {
+			value = extent.End
+		}
+		replacement := boundExpression(bound.style, value.Add(bound.offset))
+		statement = strings.ReplaceAll(statement, bound.token, replacement)
+	}
+	return statement, nil
+}
+
+type rendererBound struct {
+	token    string
+	endpoint endpoint
+	style    boundStyle
+	offset   time.Duration
+}
+
+// buildQueryArtifacts replaces the bound values of the statement with private
+// placeholder columns, and formats the statement once into the canonical SQL
+// and the immutable renderer template. Placeholders are probed against the
+// formatted statement, so they never match user identifiers or literals.
+func buildQueryArtifacts(stmt *ast.SelectStmt, ranges rangeAnalysis,
+	bucket bucketSpec,
+) (string, *mysqlRenderer, error) {
+	occupied, err := restore(stmt)
+	if err != nil {
+		return "", nil, err
+	}
+	bounds := make([]rendererBound, 0, 2)
+	addBound := func(target endpoint, style boundStyle, offset time.Duration) ast.ExprNode {
+		index := len(bounds)
+		token := fmt.Sprintf("<$TRICKSTER_TS%d_%d$>", target+1, index)
+		for strings.Contains(occupied, token) {
+			index++
+			token = fmt.Sprintf("<$TRICKSTER_TS%d_%d$>", target+1, index)
+		}
+		occupied += token
+		// the placeholder is formatted as a quoted identifier
+		bounds = append(bounds, rendererBound{
+			token: "`" + token + "`", endpoint: target, style: style, offset: offset,
+		})
+		return &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: ast.NewCIStr(token)}}
+	}
+	for _, bound := range []*timeBound{ranges.lower, ranges.upper} {
+		if bound == nil {
+			continue
+		}
+		target := bound.target
+		target.set(addBound(target.endpoint, target.style, target.offset))
+	}
+	if ranges.addSynthetic != nil {
+		style := boundSeconds
+		if bucket.temporal {
+			style = boundFromUnixtime
+		}
+		ranges.addSynthetic(&ast.BinaryOperationExpr{
+			Op: opcode.LT,
+			L:  &ast.ColumnNameExpr{Name: bucket.column},
+			R:  addBound(endpointUpper, style, bucket.step),
+		})
+	}
+
+	template, err := restore(stmt)
+	if err != nil {
+		return "", nil, err
+	}
+	canonical := template
+	for _, bound := range bounds {
+		canonical = strings.ReplaceAll(canonical, bound.token, placeholderFor(bound.endpoint))
+	}
+	return canonical, &mysqlRenderer{template: template, bounds: bounds}, nil
+}
+
+func placeholderFor(target endpoint) string {
+	if target == endpointLower {
+		return "<$TS1$>"
+	}
+	return "<$TS2$>"
+}
+
+func boundExpression(style boundStyle, value time.Time) string {
+	seconds := strconv.FormatInt(value.Unix(), 10)
+	if style == boundSeconds {
+		return seconds
+	}
+	if micros := value.Nanosecond() / int(time.Microsecond); micros > 0 {
+		seconds += fmt.Sprintf(".%06d", micros)
+	}
+	return "FROM_UNIXTIME(" + seconds + ")"
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func TestSetExtent(t *testing.T) {
+	const body = `{"sql":"SELECT FLOOR(UNIX_TIMESTAMP(ts)/60)*60 AS t, COUNT(*) FROM events ` +
+		`WHERE ts >= FROM_UNIXTIME(1699990200) GROUP BY t","database":"telemetry","args":null}`
+	client := &Client{}
+	trq, _, _, err := client.ParseTimeRangeQuery(newTestRequest(PathQueryTuples, body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := newTestRequest(PathQueryTuples, body)
+	extent := &timeseries.Extent{Start: time.Unix(1699990260, 0), End: time.Unix(1699990320, 0)}
+	if err := client.SetExtent(r, trq, extent); err != nil {
+		t.Fatal(err)
+	}
+	b, err := request.GetBody(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc[ParamDatabase] != "telemetry" {
+		t.Errorf("request document was not preserved: %s", b)
+	}
+	rendered, _ := doc[ParamSQL].(string)
+	if !strings.Contains(rendered, "`ts` >= FROM_UNIXTIME(1699990260) AND `ts` < FROM_UNIXTIME(1699990380)") {
+		t.Errorf("extent was not rendered: %s", rendered)
+	}
+	if r.ContentLength != int64(len(b)) {
+		t.Errorf("expected content length %d got %d", len(b), r.ContentLength)
+	}
+}
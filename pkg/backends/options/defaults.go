@@ -35,6 +35,9 @@ const (
 	DefaultRevalidationFactor = 2
 	// DefaultMaxObjectSizeBytes is the default Max Size of any Cache Object
 	DefaultMaxObjectSizeBytes = 524288
+	// DefaultMaxVaryVariants is the default Max number of variants cached for an object
+	// whose origin response includes a Vary header
+	DefaultMaxVaryVariants = 16
 	// DefaultMaxCaptureBytes is the default per-response capture-buffer cap,
 	// applied to Trickster's internal response captures (ALB fanout, Prometheus
 	// label/transform handlers, etc.). Set to 256 MiB to protect against a
@@ -34,6 +34,24 @@ var ErrInvalidMaxShardSizeTime = errors.New(
 var ErrInvalidMaxShardSize = errors.New(
 	"'shard_max_size_time' and 'shard_max_size_points' cannot both be non-zero")
 
+// ErrInvalidStaleDuration is an error for when 'stale_while_revalidate' or
+// 'stale_if_error' is negative
+var ErrInvalidStaleDuration = errors.New(
+	"'stale_while_revalidate' and 'stale_if_error' cannot be negative")
+
+// ErrInvalidMaxVaryVariants is an error for when 'max_vary_variants' is negative
+var ErrInvalidMaxVaryVariants = errors.New("'max_vary_variants' cannot be negative")
+
+// ErrInvalidTimeseriesCacheEncoding is an error for when 'timeseries_cache_encoding'
+// is not a supported encoding
+var ErrInvalidTimeseriesCacheEncoding = errors.New(
+	"'timeseries_cache_encoding' must be 'msgpack' or 'columnar'")
+
+// ErrInvalidDistributedForwardingCache is an error for when 'distributed_forwarding'
+// is configured for a backend whose cache does not support leases
+var ErrInvalidDistributedForwardingCache = errors.New(
+	"'distributed_forwarding' requires the backend to use a redis cache")
+
 // ErrMissingProvider is an error type for missing provider
 type ErrMissingProvider struct {
 	error
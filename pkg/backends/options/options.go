@@ -27,6 +27,7 @@ import (
 	"time"
 
 	ao "github.com/trickstercache/trickster/v2/pkg/backends/alb/options"
+	gro "github.com/trickstercache/trickster/v2/pkg/backends/graphite/options"
 	ho "github.com/trickstercache/trickster/v2/pkg/backends/healthcheck/options"
 	prop "github.com/trickstercache/trickster/v2/pkg/backends/prometheus/options"
 	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
@@ -35,19 +36,23 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/cache/evictionmethods"
 	"github.com/trickstercache/trickster/v2/pkg/cache/negative"
 	co "github.com/trickstercache/trickster/v2/pkg/cache/options"
+	cp "github.com/trickstercache/trickster/v2/pkg/cache/providers"
 	"github.com/trickstercache/trickster/v2/pkg/config/listener"
 	"github.com/trickstercache/trickster/v2/pkg/config/types"
 	yamlencoding "github.com/trickstercache/trickster/v2/pkg/encoding/yaml"
 	tro "github.com/trickstercache/trickster/v2/pkg/observability/tracing/options"
 	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	admo "github.com/trickstercache/trickster/v2/pkg/proxy/admission/options"
 	autho "github.com/trickstercache/trickster/v2/pkg/proxy/authenticator/options"
 	corso "github.com/trickstercache/trickster/v2/pkg/proxy/cors/options"
+	fwdo "github.com/trickstercache/trickster/v2/pkg/proxy/forwarding/options"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
 	po "github.com/trickstercache/trickster/v2/pkg/proxy/paths/options"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/request/rewriter"
 	rwopts "github.com/trickstercache/trickster/v2/pkg/proxy/request/rewriter/options"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/router"
 	to "github.com/trickstercache/trickster/v2/pkg/proxy/tls/options"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
 	"github.com/trickstercache/trickster/v2/pkg/util/pointers"
 	"github.com/trickstercache/trickster/v2/pkg/util/sets"
 
@@ -89,6 +94,9 @@ type Options struct {
 	CacheName string `yaml:"cache_name,omitempty"`
 	// CacheKeyPrefix defines the cache key prefix the backend will use when writing objects to the cache
 	CacheKeyPrefix string `yaml:"cache_key_prefix,omitempty"`
+	// SurrogateKeyHeader names an optional upstream response header (e.g., Surrogate-Key) whose
+	// space-separated values are attached as tags to the cached object, for tag-based purging
+	SurrogateKeyHeader string `yaml:"surrogate_key_header,omitempty"`
 	// ChunkReadConcurrencyLimit defines the concurrency limit while reading a chunked object
 	ChunkReadConcurrencyLimit int `yaml:"chunk_read_concurrency_limit,omitempty"`
 	// FetchConcurrencyLimit defines the max concurrent upstream requests when fetching
@@ -105,6 +113,9 @@ type Options struct {
 	// TimeseriesEvictionMethodName specifies which methodology ("oldest", "lru") is used to identify
 	// timeseries to evict from a full cache object
 	TimeseriesEvictionMethodName string `yaml:"timeseries_eviction_method,omitempty"`
+	// TimeseriesCacheEncoding specifies how timeseries are encoded in the cache
+	// ("msgpack", "columnar"). Either encoding can be read regardless of this setting
+	TimeseriesCacheEncoding string `yaml:"timeseries_cache_encoding,omitempty"`
 	// BackfillTolerance prevents values with timestamps newer than the provided number of
 	// milliseconds from being cached. this allows propagation of upstream backfill operations
 	// that modify recently-cached data
@@ -126,8 +137,20 @@ type Options struct {
 	// RevalidationFactor specifies how many times to multiply the object freshness lifetime
 	// by to calculate an absolute cache TTL
 	RevalidationFactor float64 `yaml:"revalidation_factor,omitempty"`
+	// StaleWhileRevalidate specifies how long past its freshness lifetime an object may be
+	// served from cache while it is revalidated in the background, for responses that do
+	// not include a 'stale-while-revalidate' Cache-Control directive
+	StaleWhileRevalidate timeconv.Duration `yaml:"stale_while_revalidate,omitempty"`
+	// StaleIfError specifies how long past its freshness lifetime an object may be served
+	// from cache when the origin responds with a 5xx or can't be reached, for responses
+	// that do not include a 'stale-if-error' Cache-Control directive
+	StaleIfError timeconv.Duration `yaml:"stale_if_error,omitempty"`
 	// MaxObjectSizeBytes specifies the max objectsize to be accepted for any given cache object
 	MaxObjectSizeBytes int `yaml:"max_object_size_bytes,omitempty"`
+	// MaxVaryVariants specifies the max number of variants the object proxy cache stores
+	// for an object whose origin response includes a Vary header. When a new variant
+	// exceeds the limit, the oldest variant is evicted.
+	MaxVaryVariants int `yaml:"max_vary_variants,omitempty"`
 	// MaxCaptureBytes caps the per-response in-memory capture buffer that
 	// Trickster's internal capture writer allocates when an ALB mechanism
 	// fans out to pool members or a backend handler transforms an upstream
@@ -172,6 +195,8 @@ type Options struct {
 	ALBOptions *ao.Options `yaml:"alb,omitempty"`
 	// Prometheus holds options specific to prometheus backends
 	Prometheus *prop.Options `yaml:"prometheus,omitempty"`
+	// Graphite holds options specific to graphite backends
+	Graphite *gro.Options `yaml:"graphite,omitempty"`
 
 	// TLS is the TLS Configuration for the Frontend and Backend
 	TLS *to.Options `yaml:"tls,omitempty"`
@@ -180,6 +205,11 @@ type Options struct {
 	ForwardedHeaders string `yaml:"forwarded_headers,omitempty"`
 	// CORS configures downstream CORS response headers for this backend
 	CORS *corso.Options `yaml:"cors,omitempty"`
+	// Admission configures the rules a response must satisfy to be cached for this backend
+	Admission *admo.Options `yaml:"admission,omitempty"`
+	// DistributedForwarding collapses cache misses across the Trickster instances
+	// sharing this backend's cache, which must be a redis cache
+	DistributedForwarding *fwdo.Options `yaml:"distributed_forwarding,omitempty"`
 
 	// IsDefault indicates if this is the d.Default backend for any request not matching a configured route
 	IsDefault bool `yaml:"is_default,omitempty"`
@@ -279,6 +309,7 @@ func New() *Options {
 		MaxCaptureBytes:              DefaultMaxCaptureBytes,
 		MaxFanoutCaptureBytes:        DefaultMaxFanoutCaptureBytes,
 		MaxObjectSizeBytes:           DefaultMaxObjectSizeBytes,
+		MaxVaryVariants:              DefaultMaxVaryVariants,
 		MaxTTL:                       timeconv.Duration(DefaultMaxTTL),
 		NegativeCache:                make(map[int]time.Duration),
 		NegativeCacheName:            DefaultBackendNegativeCacheName,
@@ -322,6 +353,8 @@ func (o *Options) Clone() *Options {
 	if o.CORS != nil {
 		out.CORS = o.CORS.Clone()
 	}
+	out.Admission = o.Admission.Clone()
+	out.DistributedForwarding = o.DistributedForwarding.Clone()
 
 	if o.FastForwardPath != nil {
 		out.FastForwardPath = o.FastForwardPath.Clone()
@@ -339,6 +372,10 @@ func (o *Options) Clone() *Options {
 		out.Prometheus = o.Prometheus.Clone()
 	}
 
+	if o.Graphite != nil {
+		out.Graphite = o.Graphite.Clone()
+	}
+
 	if o.AuthOptions != nil {
 		out.AuthOptions = o.AuthOptions.Clone()
 	}
@@ -370,6 +407,20 @@ func (o *Options) Validate() (bool, error) {
 		return false, ErrInvalidMaxShardSizeTime
 	}
 
+	if o.StaleWhileRevalidate < 0 || o.StaleIfError < 0 {
+		return false, ErrInvalidStaleDuration
+	}
+
+	if o.MaxVaryVariants < 0 {
+		return false, ErrInvalidMaxVaryVariants
+	}
+
+	switch o.TimeseriesCacheEncoding {
+	case "", timeseries.CacheEncodingMsgpack, timeseries.CacheEncodingColumnar:
+	default:
+		return false, ErrInvalidTimeseriesCacheEncoding
+	}
+
 	if len(o.Paths) > 0 {
 		if err := o.Paths.Validate(); err != nil {
 			return false, err
@@ -380,6 +431,12 @@ func (o *Options) Validate() (bool, error) {
 			return false, err
 		}
 	}
+	if err := o.Admission.Validate(); err != nil {
+		return false, fmt.Errorf("invalid admission for backend %s: %w", o.Name, err)
+	}
+	if err := o.DistributedForwarding.Validate(); err != nil {
+		return false, fmt.Errorf("invalid distributed_forwarding for backend %s: %w", o.Name, err)
+	}
 
 	if o.HealthCheck != nil {
 		_, err := o.HealthCheck.Validate()
@@ -495,6 +552,13 @@ func (l Lookup) ValidateConfigMappings(c co.Lookup, ncl negative.Lookups,
 						o.Name+"/"+p.Path)
 				}
 			}
+			if p.NegativeCacheName != "" {
+				nc, ok := ncl[p.NegativeCacheName]
+				if !ok || nc == nil {
+					return NewErrInvalidNegativeCacheName(p.NegativeCacheName)
+				}
+				p.NegativeCache = nc
+			}
 		}
 		// ensure negative_cache_name values map to a defined Negative Cache
 		if o.NegativeCacheName != "" {
@@ -527,9 +591,13 @@ func (l Lookup) ValidateConfigMappings(c co.Lookup, ncl negative.Lookups,
 		}
 
 		if !providers.NonCacheBackends().Contains(o.Provider) {
-			if _, ok := c[o.CacheName]; !ok {
+			cc, ok := c[o.CacheName]
+			if !ok {
 				return NewErrInvalidCacheName(o.CacheName, o.Name)
 			}
+			if o.DistributedForwarding != nil && (cc == nil || cc.Provider != cp.Redis) {
+				return fmt.Errorf("backend %s: %w", o.Name, ErrInvalidDistributedForwardingCache)
+			}
 		}
 	}
 	albs := make(map[string]*ao.Options)
@@ -17,6 +17,7 @@
 package options
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	co "github.com/trickstercache/trickster/v2/pkg/cache/options"
 	tro "github.com/trickstercache/trickster/v2/pkg/observability/tracing/options"
 	autho "github.com/trickstercache/trickster/v2/pkg/proxy/authenticator/options"
+	fwdo "github.com/trickstercache/trickster/v2/pkg/proxy/forwarding/options"
 	po "github.com/trickstercache/trickster/v2/pkg/proxy/paths/options"
 	rwopts "github.com/trickstercache/trickster/v2/pkg/proxy/request/rewriter/options"
 )
@@ -99,6 +101,7 @@ func TestValidateConfigMappingsSuccessPaths(t *testing.T) {
 		Path:              "/secure",
 		AuthenticatorName: "auth",
 		ReqRewriterName:   "rw",
+		NegativeCacheName: "neg",
 	}}
 
 	l := Lookup{"backend": o}
@@ -119,6 +122,9 @@ func TestValidateConfigMappingsSuccessPaths(t *testing.T) {
 	if len(o.NegativeCache) == 0 {
 		t.Fatal("expected NegativeCache map to be populated")
 	}
+	if len(o.Paths[0].NegativeCache) == 0 {
+		t.Fatal("expected path NegativeCache map to be populated")
+	}
 }
 
 func TestValidateConfigMappingsALBAndCycles(t *testing.T) {
@@ -155,6 +161,30 @@ func TestValidateConfigMappingsALBAndCycles(t *testing.T) {
 	}
 }
 
+func TestValidateConfigMappingsDistributedForwarding(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Name = "backend"
+	o.Provider = providers.Prometheus
+	o.OriginURL = "http://example.com"
+	o.TracingConfigName = ""
+	o.NegativeCacheName = ""
+	o.DistributedForwarding = fwdo.New()
+
+	l := Lookup{"backend": o}
+	err := l.ValidateConfigMappings(co.Lookup{"default": &co.Options{Provider: "memory"}},
+		negative.Lookups{}, ro.Lookup{}, rwopts.Lookup{}, autho.Lookup{}, tro.Lookup{})
+	if !errors.Is(err, ErrInvalidDistributedForwardingCache) {
+		t.Fatalf("expected %v got %v", ErrInvalidDistributedForwardingCache, err)
+	}
+	err = l.ValidateConfigMappings(co.Lookup{"default": &co.Options{Provider: "redis"}},
+		negative.Lookups{}, ro.Lookup{}, rwopts.Lookup{}, autho.Lookup{}, tro.Lookup{})
+	if err != nil {
+		t.Fatalf("ValidateConfigMappings: %v", err)
+	}
+}
+
 func TestValidateConfigMappingsInvalidReferences(t *testing.T) {
 	t.Parallel()
 
@@ -179,6 +209,13 @@ func TestValidateConfigMappingsInvalidReferences(t *testing.T) {
 		t.Fatal("expected invalid path authenticator error")
 	}
 
+	o.Paths = po.List{{Path: "/x", NegativeCacheName: "missing"}}
+	err = l.ValidateConfigMappings(co.Lookup{"default": nil}, negative.Lookups{},
+		ro.Lookup{}, rwopts.Lookup{}, autho.Lookup{}, tro.Lookup{})
+	if err == nil {
+		t.Fatal("expected invalid path negative cache error")
+	}
+
 	o.Paths = nil
 	o.Provider = providers.ALB
 	o.ALBOptions = nil
@@ -439,6 +439,27 @@ func TestValidate(t *testing.T) {
 		to := &testOptions{Backends: Lookup{o.Name: &opts}}
 		require.ErrorIs(t, Lookup(to.Backends).Validate(), ErrInvalidMaxShardSize)
 	})
+
+	t.Run("negative stale durations", func(t *testing.T) {
+		opts := *o
+		opts.StaleIfError = timeconv.Duration(-1 * time.Second)
+		to := &testOptions{Backends: Lookup{o.Name: &opts}}
+		require.ErrorIs(t, Lookup(to.Backends).Validate(), ErrInvalidStaleDuration)
+	})
+
+	t.Run("negative max vary variants", func(t *testing.T) {
+		opts := *o
+		opts.MaxVaryVariants = -1
+		to := &testOptions{Backends: Lookup{o.Name: &opts}}
+		require.ErrorIs(t, Lookup(to.Backends).Validate(), ErrInvalidMaxVaryVariants)
+	})
+
+	t.Run("invalid timeseries cache encoding", func(t *testing.T) {
+		opts := *o
+		opts.TimeseriesCacheEncoding = "protobuf"
+		to := &testOptions{Backends: Lookup{o.Name: &opts}}
+		require.ErrorIs(t, Lookup(to.Backends).Validate(), ErrInvalidTimeseriesCacheEncoding)
+	})
 }
 
 func TestInitialize(t *testing.T) {
@@ -0,0 +1,255 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/testutil/sqlcorpus"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+// postgresCompatibilityCorpus is the maintained compatibility contract for the
+// PostgreSQL token analyzer. Add production and Grafana query shapes here with
+// their expected classification before expanding the analyzer's accepted SQL
+// surface.
+var postgresCompatibilityCorpus = []sqlcorpus.Case{
+	{
+		Name: "grafana timeGroupAlias macro",
+		Query: `SELECT floor(extract(epoch from ts)/60)*60 AS "time", host, avg(cpu) AS cpu ` +
+			"FROM metrics WHERE ts >= to_timestamp(1699990200) AND ts < to_timestamp(1699996200) " +
+			"GROUP BY 1, 2 ORDER BY 1",
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Step: time.Minute,
+	},
+	{
+		Name: "grafana timeGroupAlias macro with TimescaleDB",
+		Query: `SELECT time_bucket('300s',ts) AS "time", avg(cpu) FROM metrics ` +
+			"WHERE ts >= '2023-11-14T19:30:00Z' AND ts < '2023-11-14T21:10:00Z' GROUP BY 1 ORDER BY 1",
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Step: 5 * time.Minute,
+	},
+	{
+		Name: "grafana unixEpochGroupAlias macro",
+		Query: `SELECT floor((epoch)/60)*60 AS "time", sum(bytes) FROM flows ` +
+			"WHERE epoch >= 1699990200 AND epoch < 1699996200 GROUP BY 1 ORDER BY 1",
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Step: time.Minute,
+	},
+	{
+		Name: "grafana timeFilter on the bucket expression",
+		Query: `SELECT time_bucket('1m', ts) AS "time", avg(cpu) FROM metrics ` +
+			"WHERE time_bucket('1m', ts) BETWEEN '2023-11-14T19:30:17Z' AND '2023-11-14T21:10:17Z' " +
+			"GROUP BY 1 ORDER BY 1",
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Step: time.Minute,
+	},
+	{
+		Name: "weekly buckets from the TimescaleDB origin",
+		Query: "SELECT time_bucket(INTERVAL '1 week', ts) AS w, count(*) FROM events " +
+			"WHERE ts >= TIMESTAMPTZ '2023-11-13 00:00:00+00' GROUP BY w ORDER BY w ASC",
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable,
+		Step: 7 * 24 * time.Hour, Phase: 4 * 24 * time.Hour,
+	},
+	{
+		Name: "date_bin with an origin",
+		Query: "SELECT date_bin('15 minutes', ts, TIMESTAMP '2001-01-01 00:05:00') AS b, max(v) " +
+			"FROM m WHERE ts >= '2023-11-14T19:05:00Z' GROUP BY b",
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable,
+		Step: 15 * time.Minute, Phase: 5 * time.Minute,
+	},
+	{
+		Name: "date_trunc with cast bounds",
+		Query: "SELECT date_trunc('hour', ts) AS h, sum(v) FROM m " +
+			"WHERE ts >= '2023-11-14 19:00:00+00'::timestamptz AND ts < '2023-11-14 23:00:00+00'::timestamptz " +
+			"GROUP BY h",
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Step: time.Hour,
+	},
+	{
+		Name: "ordered-set aggregate and filter clause",
+		Query: "SELECT time_bucket('1m', ts) AS t, percentile_cont(0.5) WITHIN GROUP (ORDER BY cpu), " +
+			"count(*) FILTER (WHERE ok) FROM ONLY metrics WHERE ts >= '2023-11-14T19:30:00Z' " +
+			"AND host IS DISTINCT FROM 'x' GROUP BY t;",
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Step: time.Minute,
+	},
+	{
+		Name: "grafana timeFilter on the raw column",
+		Query: `SELECT time_bucket('1m', ts) AS "time", avg(cpu) FROM metrics ` +
+			"WHERE ts BETWEEN '2023-11-14T19:30:17Z' AND '2023-11-14T21:10:17Z' GROUP BY 1 ORDER BY 1",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		Name: "grafana unixEpochFilter macro",
+		Query: `SELECT floor((epoch)/60)*60 AS "time", sum(bytes) FROM flows ` +
+			"WHERE epoch >= 1699990200 AND epoch <= 1699996200 GROUP BY 1",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		Name: "session time zone literal",
+		Query: "SELECT time_bucket('1m', ts) AS t, count(*) FROM events " +
+			"WHERE ts >= '2023-11-14 19:30:00' GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		Name: "relative bound",
+		Query: "SELECT time_bucket('1m', ts) AS t, count(*) FROM events " +
+			"WHERE ts >= now() - interval '1 hour' GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		Name: "placeholder bound",
+		Query: "SELECT time_bucket('1m', ts) AS t, count(*) FROM events " +
+			"WHERE ts >= '2023-11-14T19:30:00Z' AND ts < $1 GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		Name: "disjunctive time predicate",
+		Query: "SELECT time_bucket('1m', ts) AS t, count(*) FROM events " +
+			"WHERE ts >= '2023-11-14T19:30:00Z' OR host = 'a' GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		Name: "having on bucket",
+		Query: "SELECT time_bucket('1m', ts) AS t, count(*) FROM events " +
+			"WHERE ts >= '2023-11-14T19:30:00Z' GROUP BY t HAVING t > '2023-11-14T19:30:00Z'",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsafePredicate,
+	},
+	{
+		Name: "fetch first",
+		Query: "SELECT time_bucket('1m', ts) AS t, count(*) FROM events " +
+			"WHERE ts >= '2023-11-14T19:30:00Z' GROUP BY t FETCH FIRST 5 ROWS ONLY",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedLimit,
+	},
+	{
+		Name: "descending order",
+		Query: "SELECT time_bucket('1m', ts) AS t, count(*) FROM events " +
+			"WHERE ts >= '2023-11-14T19:30:00Z' GROUP BY t ORDER BY t DESC",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		Name: "ungrouped column",
+		Query: "SELECT time_bucket('1m', ts) AS t, host, count(*) FROM events " +
+			"WHERE ts >= '2023-11-14T19:30:00Z' GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedGrouping,
+	},
+	{
+		Name: "bucket alias shadowing the time column",
+		Query: "SELECT time_bucket('1m', ts) AS ts, count(*) FROM events " +
+			"WHERE ts >= '2023-11-14T19:30:00Z' GROUP BY ts",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedGrouping,
+	},
+	{
+		Name: "multiple buckets",
+		Query: "SELECT time_bucket('1m', ts) AS t, time_bucket('1m', ts2) AS t2, count(*) FROM events " +
+			"WHERE ts >= '2023-11-14T19:30:00Z' GROUP BY t, t2",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonAmbiguousTimeAxis,
+	},
+	{
+		Name: "common table expression",
+		Query: "WITH e AS (SELECT * FROM events) SELECT time_bucket('1m', ts) AS t, count(*) FROM e " +
+			"WHERE ts >= '2023-11-14T19:30:00Z' GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		Name: "join",
+		Query: "SELECT time_bucket('1m', ts) AS t, count(*) FROM events JOIN hosts USING (host) " +
+			"WHERE ts >= '2023-11-14T19:30:00Z' GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		Name: "window function",
+		Query: "SELECT time_bucket('1m', ts) AS t, count(*) OVER () FROM events " +
+			"WHERE ts >= '2023-11-14T19:30:00Z' GROUP BY t",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		Name: "calendar bucket",
+		Query: "SELECT date_trunc('month', ts) AS m, count(*) FROM events " +
+			"WHERE ts >= '2023-11-01T00:00:00Z' GROUP BY m",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedBucket,
+	},
+	{
+		Name:  "raw rows",
+		Query: "SELECT ts, cpu FROM metrics WHERE ts >= '2023-11-14T19:30:00Z'",
+		Mode:  sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedBucket,
+	},
+	{
+		Name:  "union",
+		Query: "SELECT 1 UNION SELECT 2",
+		Mode:  sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		Name: "multiple statements",
+		Query: "SELECT time_bucket('1m', ts) AS t, count(*) FROM events " +
+			"WHERE ts >= '2023-11-14T19:30:00Z'; DELETE FROM events",
+		Mode: sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonInvalidSQL,
+	},
+	{
+		Name:  "invalid select",
+		Query: "SELECT 'unterminated",
+		Mode:  sqlanalyzer.CacheModeObject, Reason: sqlanalyzer.ReasonInvalidSQL,
+	},
+	{
+		Name: "select into",
+		Query: "SELECT time_bucket('1m', ts) AS t, count(*) INTO archive FROM events " +
+			"WHERE ts >= '2023-11-14T19:30:00Z' GROUP BY t",
+		Mode: sqlanalyzer.CacheModeNone, Reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+	{
+		Name:   "non-select statement",
+		Query:  "INSERT INTO events VALUES (1)",
+		Mode:   sqlanalyzer.CacheModeNone,
+		Reason: sqlanalyzer.ReasonUnsupportedStatement,
+	},
+}
+
+func TestPostgresCompatibilityCorpus(t *testing.T) {
+	sqlcorpus.Run(t, dialectAnalyzer, time.Unix(1_700_000_000, 0), postgresCompatibilityCorpus)
+}
+
+// TestCorpusRenderIsStable verifies that a rendered statement is analyzed
+// into the same cache identity and the rendered extent
+func TestCorpusRenderIsStable(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	for _, test := range postgresCompatibilityCorpus {
+		if test.Mode != sqlanalyzer.CacheModeDelta {
+			continue
+		}
+		t.Run(test.Name, func(t *testing.T) {
+			first := dialectAnalyzer.Analyze(test.Query, now).Plan
+			start := first.LowerBound.Value
+			if !first.LowerBound.Inclusive {
+				start = start.Add(first.Step)
+			}
+			extent := timeseries.Extent{Start: start.Add(first.Step), End: start.Add(3 * first.Step)}
+			rendered, err := first.RenderExtent(extent)
+			if err != nil {
+				t.Fatal(err)
+			}
+			second := dialectAnalyzer.Analyze(rendered, now)
+			if second.Plan == nil {
+				t.Fatalf("rendered SQL was not delta cacheable: %v\n%s", second.Err, rendered)
+			}
+			if second.Plan.CanonicalSQL != first.CanonicalSQL {
+				t.Errorf("canonical SQL differs:\n%s\n%s", first.CanonicalSQL, second.Plan.CanonicalSQL)
+			}
+			if s, e := effectiveExtent(second.Plan, now); s != extent.Start.Unix() ||
+				e != extent.End.Unix() {
+				t.Errorf("extent = (%d, %d), want (%d, %d)", s, e, extent.Start.Unix(),
+					extent.End.Unix())
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	te "github.com/trickstercache/trickster/v2/pkg/errors"
+	pe "github.com/trickstercache/trickster/v2/pkg/proxy/errors"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+)
+
+// Gateway Endpoints
+const (
+	PathSQL = "/sql"
+)
+
+// Common Request Document Field Names
+const (
+	ParamQuery  = "query"
+	ParamParams = "params"
+)
+
+// Gateway Request Headers that select the database and shape the response,
+// and are therefore part of the cache key
+const (
+	HeaderConnectionString = "Neon-Connection-String"
+	HeaderArrayMode        = "Neon-Array-Mode"
+	HeaderRawTextOutput    = "Neon-Raw-Text-Output"
+)
+
+// queryRequest is a gateway query request, which is provided as a JSON
+// document in the body of a POST
+type queryRequest struct {
+	query  string
+	params string
+	// document is the decoded body of the request
+	document map[string]json.RawMessage
+}
+
+// statement returns the SQL statement provided in a gateway query request, or
+// an empty string if the request is invalid
+func statement(r *http.Request) string {
+	qr, err := parseRequest(r)
+	if err != nil {
+		return ""
+	}
+	return qr.query
+}
+
+// parseRequest parses a single-statement gateway query request. Transaction
+// requests, which provide a list of queries, are not parsed.
+func parseRequest(r *http.Request) (*queryRequest, error) {
+	if r == nil || r.Method != http.MethodPost {
+		return nil, te.ErrInvalidMethod
+	}
+	b, err := request.GetBody(r)
+	if err != nil {
+		return nil, err
+	}
+	qr := &queryRequest{}
+	if err := json.Unmarshal(b, &qr.document); err != nil {
+		return nil, te.ErrBadRequest
+	}
+	if v, ok := qr.document[ParamQuery]; ok {
+		json.Unmarshal(v, &qr.query)
+	}
+	if v, ok := qr.document[ParamParams]; ok && string(v) != "null" {
+		qr.params = compactJSON(v)
+	}
+	if qr.query == "" {
+		return nil, pe.MissingURLParam(ParamQuery)
+	}
+	return qr, nil
+}
+
+func compactJSON(v json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, v); err != nil {
+		return string(v)
+	}
+	return buf.String()
+}
+
+// cacheKeyElements returns the request values that identify the query in the
+// cache, using the provided statement
+func (qr *queryRequest) cacheKeyElements(statement string) map[string]string {
+	out := map[string]string{
+		ParamQuery: statement,
+	}
+	if qr.params != "" {
+		out[ParamParams] = qr.params
+	}
+	return out
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"net/http"
+	"testing"
+
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultHealthCheckConfig(t *testing.T) {
+	o := bo.New()
+	o.Scheme = "http"
+	o.Host = "gateway:8080"
+	o.PathPrefix = "/postgres"
+	c, _ := NewClient("test", o, nil, nil, nil, nil)
+
+	dho := c.DefaultHealthCheckConfig()
+	require.NotNil(t, dho)
+	require.Equal(t, "gateway:8080", dho.Host)
+	require.Equal(t, "/postgres"+PathSQL, dho.Path)
+	require.Equal(t, http.MethodPost, dho.Verb)
+	require.JSONEq(t, `{"query":"SELECT 1"}`, dho.Body)
+	require.Equal(t, headers.ValueApplicationJSON, dho.Headers[headers.NameContentType])
+}
@@ -0,0 +1,395 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind uint8
+
+const (
+	tokenWord     tokenKind = iota // unquoted identifier or keyword
+	tokenIdent                     // "quoted identifier"
+	tokenString                    // 'string', E'string' or $$string$$ literal
+	tokenNumber                    // numeric literal
+	tokenParam                     // $1 positional parameter
+	tokenOperator                  // operators and punctuation
+	tokenSlot                      // a time bound, which is only created during rendering
+)
+
+// token is a lexical element of a statement. depth is the parenthesis depth at
+// which the token appears, and adjacent is true when no whitespace or comment
+// separates the token from the one preceding it.
+type token struct {
+	kind     tokenKind
+	text     string
+	depth    int
+	adjacent bool
+	slot     int
+}
+
+// operatorChars are the characters that PostgreSQL operators are composed of
+const operatorChars = "+-*/<>=~!@#%^&|`?"
+
+// punctuation are the single-character tokens that are never part of an
+// operator
+const punctuation = "()[],;:."
+
+// lex splits a PostgreSQL statement into tokens, discarding comments
+func lex(statement string) ([]token, error) {
+	out := make([]token, 0, 64)
+	var depth int
+	adjacent := true
+	for i := 0; i < len(statement); {
+		c := statement[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			adjacent = false
+			i++
+			continue
+		case strings.HasPrefix(statement[i:], "--"):
+			for i < len(statement) && statement[i] != '\n' {
+				i++
+			}
+			adjacent = false
+			continue
+		case strings.HasPrefix(statement[i:], "/*"):
+			end, err := blockCommentEnd(statement, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+			adjacent = false
+			continue
+		}
+		t := token{depth: depth, adjacent: adjacent && len(out) > 0}
+		adjacent = true
+		var end int
+		switch {
+		case c == '\'':
+			t.kind = tokenString
+			end = quotedEnd(statement, i, '\'')
+		case (c == 'e' || c == 'E') && i+1 < len(statement) && statement[i+1] == '\'':
+			t.kind = tokenString
+			end = escapedQuotedEnd(statement, i+1)
+		case c == '"':
+			t.kind = tokenIdent
+			end = quotedEnd(statement, i, c)
+		case isDigit(c) || (c == '.' && i+1 < len(statement) && isDigit(statement[i+1])):
+			t.kind = tokenNumber
+			end = numberEnd(statement, i)
+			if end < len(statement) && isWordByte(statement, end) {
+				return nil, fmt.Errorf("%w: trailing junk after numeric literal at position %d",
+					ErrInvalidSQL, i)
+			}
+		case c == '$' && i+1 < len(statement) && isDigit(statement[i+1]):
+			t.kind = tokenParam
+			end = wordEnd(statement, i+1)
+		case c == '$':
+			t.kind = tokenString
+			end = dollarQuotedEnd(statement, i)
+		case isWordByte(statement, i):
+			t.kind = tokenWord
+			end = wordEnd(statement, i)
+		default:
+			t.kind = tokenOperator
+			end = operatorEnd(statement, i)
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated quote at position %d", ErrInvalidSQL, i)
+		}
+		if end == i {
+			return nil, fmt.Errorf("%w: unexpected character at position %d", ErrInvalidSQL, i)
+		}
+		t.text = statement[i:end]
+		// adjacent string literals are only concatenated when a newline
+		// separates them, which would not survive rewriting
+		if t.kind == tokenString && len(out) > 0 && out[len(out)-1].kind == tokenString {
+			return nil, fmt.Errorf("%w: consecutive string literals at position %d", ErrInvalidSQL, i)
+		}
+		switch t.text {
+		case "(", "[":
+			depth++
+		case ")", "]":
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("%w: unbalanced parentheses", ErrInvalidSQL)
+			}
+			t.depth = depth
+		}
+		out = append(out, t)
+		i = end
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("%w: unbalanced parentheses", ErrInvalidSQL)
+	}
+	return out, nil
+}
+
+// blockCommentEnd returns the index following the (possibly nested) block
+// comment starting at i
+func blockCommentEnd(statement string, i int) (int, error) {
+	var nesting int
+	for i < len(statement) {
+		switch {
+		case strings.HasPrefix(statement[i:], "/*"):
+			nesting++
+			i += 2
+		case strings.HasPrefix(statement[i:], "*/"):
+			nesting--
+			i += 2
+			if nesting == 0 {
+				return i, nil
+			}
+		default:
+			i++
+		}
+	}
+	return 0, fmt.Errorf("%w: unterminated comment", ErrInvalidSQL)
+}
+
+// quotedEnd returns the index following the quoted element starting at i, or
+// -1 if it is unterminated. A doubled quote character is an escaped quote.
+func quotedEnd(statement string, i int, quote byte) int {
+	for j := i + 1; j < len(statement); j++ {
+		if statement[j] != quote {
+			continue
+		}
+		if j+1 < len(statement) && statement[j+1] == quote {
+			j++
+			continue
+		}
+		return j + 1
+	}
+	return -1
+}
+
+// escapedQuotedEnd returns the index following the escape string starting
+// with the quote at i, in which a backslash also escapes the next character,
+// or -1 if it is unterminated
+func escapedQuotedEnd(statement string, i int) int {
+	for j := i + 1; j < len(statement); j++ {
+		switch statement[j] {
+		case '\\':
+			j++
+		case '\'':
+			if j+1 < len(statement) && statement[j+1] == '\'' {
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return -1
+}
+
+// dollarQuotedEnd returns the index following the dollar-quoted string
+// starting at i, like $$text$$ or $tag$text$tag$, or -1 if it is unterminated
+// or i does not start a dollar quote
+func dollarQuotedEnd(statement string, i int) int {
+	j := i + 1
+	for j < len(statement) && statement[j] != '$' {
+		if !isWordByte(statement, j) && !isDigit(statement[j]) {
+			return -1
+		}
+		j++
+	}
+	if j >= len(statement) {
+		return -1
+	}
+	tag := statement[i : j+1]
+	k := strings.Index(statement[j+1:], tag)
+	if k < 0 {
+		return -1
+	}
+	return j + 1 + k + len(tag)
+}
+
+func numberEnd(statement string, i int) int {
+	digits := func(i int) int {
+		for i < len(statement) && (isDigit(statement[i]) ||
+			(statement[i] == '_' && i+1 < len(statement) && isDigit(statement[i+1]))) {
+			i++
+		}
+		return i
+	}
+	i = digits(i)
+	if i < len(statement) && statement[i] == '.' &&
+		(i+1 >= len(statement) || statement[i+1] != '.') {
+		i = digits(i + 1)
+	}
+	if i < len(statement) && (statement[i] == 'e' || statement[i] == 'E') {
+		j := i + 1
+		if j < len(statement) && (statement[j] == '+' || statement[j] == '-') {
+			j++
+		}
+		if j < len(statement) && isDigit(statement[j]) {
+			i = digits(j)
+		}
+	}
+	return i
+}
+
+func wordEnd(statement string, i int) int {
+	for i < len(statement) && (isWordByte(statement, i) || isDigit(statement[i]) ||
+		statement[i] == '$') {
+		_, size := utf8.DecodeRuneInString(statement[i:])
+		i += size
+	}
+	return i
+}
+
+// operatorEnd returns the index following the operator or punctuation at i.
+// Like PostgreSQL, an operator is the longest run of operator characters that
+// does not start a comment, and a multiple-character operator can only end in
+// + or - when it contains one of ~ ! @ # % ^ & | ` or ?.
+func operatorEnd(statement string, i int) int {
+	if strings.HasPrefix(statement[i:], "::") {
+		return i + 2
+	}
+	if strings.IndexByte(punctuation, statement[i]) >= 0 {
+		return i + 1
+	}
+	j := i
+	for j < len(statement) && strings.IndexByte(operatorChars, statement[j]) >= 0 {
+		if j > i && (strings.HasPrefix(statement[j:], "--") ||
+			strings.HasPrefix(statement[j:], "/*")) {
+			break
+		}
+		j++
+	}
+	if j-i > 1 && !strings.ContainsAny(statement[i:j], "~!@#%^&|`?") {
+		for j-i > 1 && (statement[j-1] == '+' || statement[j-1] == '-') {
+			j--
+		}
+	}
+	return j
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordByte(statement string, i int) bool {
+	c := statement[i]
+	if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		return true
+	}
+	if c < utf8.RuneSelf {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(statement[i:])
+	return unicode.IsLetter(r)
+}
+
+// is returns true if the token is the provided keyword
+func (t token) is(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+// isOp returns true if the token is the provided operator
+func (t token) isOp(op string) bool {
+	return t.kind == tokenOperator && t.text == op
+}
+
+// normalized returns the token's text for the canonical statement. Unquoted
+// identifiers and keywords are case-insensitive, so they are lowercased.
+func (t token) normalized() string {
+	if t.kind == tokenWord {
+		return strings.ToLower(t.text)
+	}
+	return t.text
+}
+
+// identifier returns the name represented by a word or quoted identifier
+// token. PostgreSQL folds unquoted identifiers to lower case.
+func (t token) identifier() (string, bool) {
+	switch t.kind {
+	case tokenWord:
+		return strings.ToLower(t.text), true
+	case tokenIdent:
+		return strings.ReplaceAll(t.text[1:len(t.text)-1], `""`, `"`), true
+	}
+	return "", false
+}
+
+// stringValue returns the unquoted value of a standard string literal token.
+// Escape and dollar-quoted strings are not evaluated.
+func (t token) stringValue() (string, bool) {
+	if t.kind != tokenString || t.text[0] != '\'' {
+		return "", false
+	}
+	return strings.ReplaceAll(t.text[1:len(t.text)-1], "''", "'"), true
+}
+
+// separator returns the whitespace to write between two tokens when joining
+// them into a statement. Formatting differences are collapsed, except where
+// removing or adding whitespace could change the meaning of the tokens.
+func separator(prev, next token) string {
+	if next.adjacent && !separable(prev, next) {
+		return ""
+	}
+	switch {
+	case prev.isOp("::") || next.isOp("::"):
+		return ""
+	case prev.isOp(".") || next.isOp("."):
+		return ""
+	case prev.isOp("(") || next.isOp(")") || next.isOp(","):
+		return ""
+	case next.isOp("(") && next.adjacent && (prev.kind == tokenWord || prev.kind == tokenIdent):
+		return ""
+	}
+	return " "
+}
+
+// separable returns false when whitespace between two adjacent tokens would
+// change their meaning
+func separable(prev, next token) bool {
+	switch {
+	case prev.kind == tokenOperator && next.kind == tokenOperator:
+		return isPunctuation(prev) || isPunctuation(next)
+	case prev.kind == tokenWord && next.kind == tokenString:
+		// typed literals like B'101' and X'1F'
+		return false
+	case prev.is("u") && next.isOp("&"),
+		prev.isOp("&") && (next.kind == tokenString || next.kind == tokenIdent):
+		// Unicode escape literals like U&'...' and U&"..."
+		return false
+	}
+	return true
+}
+
+func isPunctuation(t token) bool {
+	return t.text == "::" ||
+		(len(t.text) == 1 && strings.IndexByte(punctuation, t.text[0]) >= 0)
+}
+
+// join writes tokens into a statement using the provided text function
+func join(tokens []token, text func(token) string) string {
+	var sb strings.Builder
+	for i, t := range tokens {
+		if i > 0 {
+			sb.WriteString(separator(tokens[i-1], t))
+		}
+		sb.WriteString(text(t))
+	}
+	return sb.String()
+}
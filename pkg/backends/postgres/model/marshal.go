@@ -0,0 +1,178 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/epoch"
+)
+
+// MarshalTimeseries converts a Timeseries into a query response
+func MarshalTimeseries(ts timeseries.Timeseries, rlo *timeseries.RequestOptions,
+	status int,
+) ([]byte, error) {
+	w := new(bytes.Buffer)
+	err := MarshalTimeseriesWriter(ts, rlo, status, w)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// MarshalTimeseriesWriter converts a Timeseries into a query response via an
+// io.Writer. Rows are ordered by time, and each column's values are written
+// in the representation the gateway originally provided.
+func MarshalTimeseriesWriter(ts timeseries.Timeseries,
+	_ *timeseries.RequestOptions, status int, w io.Writer,
+) error {
+	ds, ok := ts.(*dataset.DataSet)
+	if !ok || ds == nil {
+		return timeseries.ErrUnknownFormat
+	}
+	if hw, ok := w.(http.ResponseWriter); ok {
+		hw.Header().Set(headers.NameContentType, headers.ValueApplicationJSON)
+		if status > 0 {
+			hw.WriteHeader(status)
+		}
+	}
+	columns, _, _, _ := ds.FieldDefinitions()
+	asArray := len(columns) == 0 || columns[0].ProviderData1&flagObjectRows == 0
+	rows := ds.Rows()
+	// rows are ordered by time, as required by the bucket-only ORDER BY of a
+	// delta cached query
+	dataset.SortRowsByTime(rows)
+	bw := bufio.NewWriter(w)
+	bw.WriteString(`{"command":"SELECT","rowCount":`)
+	bw.WriteString(strconv.Itoa(len(rows)))
+	bw.WriteString(`,"rows":[`)
+	for i, row := range rows {
+		if i > 0 {
+			bw.WriteByte(',')
+		}
+		if asArray {
+			bw.WriteByte('[')
+		} else {
+			bw.WriteByte('{')
+		}
+		for j, fd := range columns {
+			if j > 0 {
+				bw.WriteByte(',')
+			}
+			if !asArray {
+				b, _ := json.Marshal(fd.Name)
+				bw.Write(b)
+				bw.WriteByte(':')
+			}
+			writeCell(bw, cell(row, fd), fd.ProviderData1)
+		}
+		if asArray {
+			bw.WriteByte(']')
+		} else {
+			bw.WriteByte('}')
+		}
+	}
+	bw.WriteString(`],"fields":[`)
+	for i, fd := range columns {
+		if i > 0 {
+			bw.WriteByte(',')
+		}
+		if fd.SDataType == "" {
+			b, _ := json.Marshal(WFField{Name: fd.Name})
+			bw.Write(b)
+			continue
+		}
+		bw.WriteString(fd.SDataType)
+	}
+	bw.WriteString(`],"rowAsArray":`)
+	bw.WriteString(strconv.FormatBool(asArray))
+	bw.WriteByte('}')
+	return bw.Flush()
+}
+
+// cell returns the value of the row for the field, or nil if it is null
+func cell(r dataset.Row, fd timeseries.FieldDefinition) any {
+	if fd.Role == timeseries.RoleTimestamp {
+		return formatTimestamp(r.Point.Epoch, fd)
+	}
+	return r.Value(fd)
+}
+
+// formatTimestamp returns a bucket timestamp as Unix epoch seconds for epoch
+// buckets, and otherwise as the text PostgreSQL outputs for the column's type
+// with a UTC session time zone
+func formatTimestamp(e epoch.Epoch, fd timeseries.FieldDefinition) any {
+	if fd.DataType == timeseries.DateTimeUnixSecs {
+		return int64(e) / 1e9
+	}
+	t := time.Unix(0, int64(e)).UTC()
+	switch {
+	case fd.ProviderData1&flagDate != 0:
+		return t.Format("2006-01-02")
+	case fd.ProviderData1&flagTimeZone != 0:
+		return t.Format("2006-01-02 15:04:05.999999") + "+00"
+	}
+	return t.Format("2006-01-02 15:04:05.999999")
+}
+
+// writeCell writes a value in the representation described by the column
+// flags: as a JSON string when the column was quoted, verbatim when it held
+// JSON values, and otherwise as the JSON literal it was decoded from
+func writeCell(bw *bufio.Writer, v any, flags byte) {
+	var s string
+	switch t := v.(type) {
+	case string:
+		s = t
+	case int64:
+		s = strconv.FormatInt(t, 10)
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			bw.WriteString("null")
+			return
+		}
+		s = strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		s = strconv.FormatBool(t)
+	default:
+		bw.WriteString("null")
+		return
+	}
+	if flags&flagJSON != 0 && isJSONContainer(s) {
+		bw.WriteString(s)
+		return
+	}
+	if flags&flagQuoted != 0 || isSpecialFloat(s) {
+		b, _ := json.Marshal(s)
+		bw.Write(b)
+		return
+	}
+	bw.WriteString(s)
+}
+
+func isJSONContainer(s string) bool {
+	return s != "" && (s[0] == '{' || s[0] == '[') && json.Valid([]byte(s))
+}
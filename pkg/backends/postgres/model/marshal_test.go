@@ -0,0 +1,108 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func TestMarshalTimeseries(t *testing.T) {
+	// rows are written in time order, with values in their original
+	// representations, so the documents round trip
+	for _, doc := range []string{testDocument, testObjectDocument} {
+		ts, err := UnmarshalTimeseries([]byte(doc), testTRQ())
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := MarshalTimeseries(ts, nil, 200)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != doc {
+			t.Errorf("expected\n%s\ngot\n%s", doc, b)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	ts, _ := UnmarshalTimeseries([]byte(testDocument), testTRQ())
+	if err := MarshalTimeseriesWriter(ts, nil, 200, w); err != nil {
+		t.Fatal(err)
+	}
+	if ct := w.Header().Get(headers.NameContentType); ct != headers.ValueApplicationJSON {
+		t.Errorf("expected %s got %s", headers.ValueApplicationJSON, ct)
+	}
+
+	if _, err := MarshalTimeseries(nil, nil, 200); err != timeseries.ErrUnknownFormat {
+		t.Errorf("expected %v got %v", timeseries.ErrUnknownFormat, err)
+	}
+}
+
+func TestMarshalTimestamps(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		unit     timeseries.FieldDataType
+		expected string
+	}{
+		{
+			name:     "epoch numeric",
+			doc:      `{"rows":[["1699990200","a"]],"fields":[{"name":"time","dataTypeID":1700},{"name":"host","dataTypeID":25}],"rowAsArray":true}`,
+			unit:     timeseries.DateTimeUnixSecs,
+			expected: `["1699990200","a"]`,
+		},
+		{
+			name:     "epoch integer",
+			doc:      `{"rows":[[1699990200,"a"]],"fields":[{"name":"time","dataTypeID":20},{"name":"host","dataTypeID":25}],"rowAsArray":true}`,
+			unit:     timeseries.DateTimeUnixSecs,
+			expected: `[1699990200,"a"]`,
+		},
+		{
+			name:     "timestamp without time zone",
+			doc:      `{"rows":[["2023-11-14 19:30:00","a"]],"fields":[{"name":"time","dataTypeID":1114},{"name":"host","dataTypeID":25}],"rowAsArray":true}`,
+			unit:     timeseries.DateTimeSQL,
+			expected: `["2023-11-14 19:30:00","a"]`,
+		},
+		{
+			name:     "date",
+			doc:      `{"rows":[["2023-11-14","a"]],"fields":[{"name":"time","dataTypeID":1082},{"name":"host","dataTypeID":25}],"rowAsArray":true}`,
+			unit:     timeseries.DateTimeSQL,
+			expected: `["2023-11-14","a"]`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trq := testTRQ()
+			trq.TimestampDefinition.DataType = test.unit
+			ts, err := UnmarshalTimeseries([]byte(test.doc), trq)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := MarshalTimeseries(ts, nil, 200)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), `"rows":[`+test.expected+`]`) {
+				t.Errorf("expected %s in %s", test.expected, b)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package model provides the wire format modeling for PostgreSQL HTTP gateway
+// query results
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+)
+
+// NewModeler returns a collection of modeling functions for PostgreSQL
+// gateway interoperability
+func NewModeler() *timeseries.Modeler {
+	return &timeseries.Modeler{
+		WireUnmarshalerReader:  UnmarshalTimeseriesReader,
+		WireMarshaler:          MarshalTimeseries,
+		WireMarshalWriter:      MarshalTimeseriesWriter,
+		WireUnmarshaler:        UnmarshalTimeseries,
+		CacheMarshaler:         dataset.MarshalDataSet,
+		ColumnarCacheMarshaler: dataset.MarshalDataSetColumnar,
+		CacheUnmarshaler:       dataset.UnmarshalDataSet,
+	}
+}
+
+// WFDocument represents the Wire Format structure of a query response. Rows
+// are arrays of values when RowAsArray is true, and otherwise objects keyed by
+// field name.
+type WFDocument struct {
+	Command    string            `json:"command"`
+	RowCount   int               `json:"rowCount"`
+	Rows       []json.RawMessage `json:"rows"`
+	Fields     []json.RawMessage `json:"fields"`
+	RowAsArray bool              `json:"rowAsArray"`
+}
+
+// WFField holds the attributes of a field descriptor in the Wire Format
+// Document that are used by Trickster. Descriptors are otherwise cached and
+// written as they were provided.
+type WFField struct {
+	Name       string `json:"name"`
+	DataTypeID int    `json:"dataTypeID"`
+}
+
+// PostgreSQL type OIDs that determine how a field is cached
+const (
+	oidInt8        = 20
+	oidInt2        = 21
+	oidInt4        = 23
+	oidFloat4      = 700
+	oidFloat8      = 701
+	oidDate        = 1082
+	oidTimestamptz = 1184
+)
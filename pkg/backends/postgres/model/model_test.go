@@ -0,0 +1,65 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func TestNewModeler(t *testing.T) {
+	m := NewModeler()
+	if m == nil || m.CacheMarshaler == nil || m.WireUnmarshaler == nil {
+		t.Error("failed to get valid modeler")
+	}
+}
+
+const testFields = `"fields":[` +
+	`{"name":"time","dataTypeID":1184,"tableID":0,"columnID":0,"dataTypeSize":8,"dataTypeModifier":-1,"format":"text"},` +
+	`{"name":"host","dataTypeID":25},{"name":"cpu","dataTypeID":701},` +
+	`{"name":"total","dataTypeID":1700},{"name":"up","dataTypeID":16},` +
+	`{"name":"meta","dataTypeID":3802}]`
+
+const testDocument = `{"command":"SELECT","rowCount":3,"rows":[` +
+	`["2023-11-14 19:30:00+00","a",1.5,"10.50",true,{"k":1}],` +
+	`["2023-11-14 19:30:00+00","b","NaN",null,false,null],` +
+	`["2023-11-14 19:31:00+00","a",2,"11.25",true,[1,2]]],` +
+	testFields + `,"rowAsArray":true}`
+
+const testObjectDocument = `{"command":"SELECT","rowCount":2,"rows":[` +
+	`{"time":"2023-11-14 19:30:00+00","host":"a","cpu":1.5,"total":"10.50","up":true,"meta":null},` +
+	`{"time":"2023-11-14 19:31:00+00","host":"a","cpu":"-Infinity","total":"11.25","up":true,"meta":null}],` +
+	testFields + `,"rowAsArray":false}`
+
+func testTRQ() *timeseries.TimeRangeQuery {
+	return &timeseries.TimeRangeQuery{
+		Extent: timeseries.Extent{
+			Start: time.Unix(1699990200, 0),
+			End:   time.Unix(1699990260, 0),
+		},
+		Step: time.Minute,
+		TimestampDefinition: timeseries.FieldDefinition{
+			Name: "time", DataType: timeseries.DateTimeSQL,
+			Role: timeseries.RoleTimestamp,
+		},
+		TagFieldDefintions: timeseries.FieldDefinitions{
+			{Name: "host", Role: timeseries.RoleTag},
+		},
+	}
+}
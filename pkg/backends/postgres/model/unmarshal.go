@@ -0,0 +1,310 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	dcsv "github.com/trickstercache/trickster/v2/pkg/timeseries/dataset/csv"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/epoch"
+)
+
+// dataStartRow is the index in the matrix built from a query response at
+// which the header rows have ended and the data rows have started. The rows
+// before it hold the field names, the field type OIDs, the field descriptors
+// and the column flags.
+const dataStartRow = 4
+
+// Column flags describing how the gateway represented a column's values, so
+// they can be represented the same way when the column is marshaled
+const (
+	flagQuoted     byte = 1 << iota // values were provided as JSON strings
+	flagBool                        // values were provided as JSON booleans
+	flagJSON                        // values were provided as JSON objects or arrays
+	flagObjectRows                  // rows were provided as objects keyed by field name
+	flagTimeZone                    // the column is a timestamp with time zone
+	flagDate                        // the column is a date
+)
+
+// timestampLayouts are the layouts of PostgreSQL's ISO DateStyle output for
+// the date and timestamp types
+var timestampLayouts = []string{
+	"2006-01-02 15:04:05.999999-07",
+	"2006-01-02 15:04:05.999999-07:00",
+	"2006-01-02 15:04:05.999999-07:00:00",
+	"2006-01-02 15:04:05.999999",
+	"2006-01-02",
+}
+
+// parser is safe for concurrency
+var parser = dcsv.NewParserMust(buildFieldDefinitions, typeToFieldDataType,
+	parseTimeField, dataStartRow)
+
+// UnmarshalTimeseries converts a query response into a Timeseries
+func UnmarshalTimeseries(data []byte, trq *timeseries.TimeRangeQuery) (timeseries.Timeseries, error) {
+	return UnmarshalTimeseriesReader(bytes.NewReader(data), trq)
+}
+
+// UnmarshalTimeseriesReader converts a query response into a Timeseries via
+// io.Reader
+func UnmarshalTimeseriesReader(reader io.Reader, trq *timeseries.TimeRangeQuery) (timeseries.Timeseries, error) {
+	if reader == nil || trq == nil {
+		return nil, timeseries.ErrInvalidBody
+	}
+	rows, err := decodeRows(reader)
+	if err != nil {
+		return nil, err
+	}
+	return parser.ToDataSet(rows, trq)
+}
+
+// decodeRows decodes a query response into a matrix of cells, preceded by
+// rows of field names, type OIDs, descriptors and flags. Null values are
+// empty.
+func decodeRows(reader io.Reader) ([][]string, error) {
+	var doc WFDocument
+	if err := json.NewDecoder(reader).Decode(&doc); err != nil {
+		return nil, timeseries.ErrInvalidBody
+	}
+	l := len(doc.Fields)
+	if l == 0 {
+		return nil, timeseries.ErrInvalidBody
+	}
+	names := make([]string, l)
+	types := make([]string, l)
+	descs := make([]string, l)
+	flags := make([]byte, l)
+	positions := make(map[string]int, l)
+	for i, raw := range doc.Fields {
+		var f WFField
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return nil, timeseries.ErrInvalidBody
+		}
+		// object rows can't represent duplicate field names unambiguously
+		if _, ok := positions[f.Name]; ok && !doc.RowAsArray {
+			return nil, timeseries.ErrInvalidBody
+		}
+		positions[f.Name] = i
+		var b bytes.Buffer
+		if err := json.Compact(&b, raw); err != nil {
+			return nil, timeseries.ErrInvalidBody
+		}
+		names[i] = f.Name
+		types[i] = strconv.Itoa(f.DataTypeID)
+		descs[i] = b.String()
+		switch f.DataTypeID {
+		case oidTimestamptz:
+			flags[i] |= flagTimeZone
+		case oidDate:
+			flags[i] |= flagDate
+		}
+		if !doc.RowAsArray {
+			flags[i] |= flagObjectRows
+		}
+	}
+	out := make([][]string, dataStartRow, len(doc.Rows)+dataStartRow)
+	cells := make([]json.RawMessage, l)
+	for _, raw := range doc.Rows {
+		if err := rowCells(raw, doc.RowAsArray, positions, cells); err != nil {
+			return nil, err
+		}
+		row := make([]string, l)
+		for i, c := range cells {
+			v, f, err := decodeCell(c, types[i])
+			if err != nil {
+				return nil, err
+			}
+			row[i] = v
+			flags[i] |= f
+		}
+		out = append(out, row)
+	}
+	fr := make([]string, l)
+	for i, f := range flags {
+		fr[i] = strconv.Itoa(int(f))
+	}
+	out[0], out[1], out[2], out[3] = names, types, descs, fr
+	return out, nil
+}
+
+// rowCells loads the cells of a row into cells, in field order
+func rowCells(raw json.RawMessage, asArray bool, positions map[string]int,
+	cells []json.RawMessage,
+) error {
+	if asArray {
+		var r []json.RawMessage
+		if err := json.Unmarshal(raw, &r); err != nil || len(r) != len(cells) {
+			return timeseries.ErrInvalidBody
+		}
+		copy(cells, r)
+		return nil
+	}
+	var r map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &r); err != nil || len(r) != len(cells) {
+		return timeseries.ErrInvalidBody
+	}
+	for k, v := range r {
+		i, ok := positions[k]
+		if !ok {
+			return timeseries.ErrInvalidBody
+		}
+		cells[i] = v
+	}
+	return nil
+}
+
+// decodeCell returns the text of a cell and the flags describing its
+// representation. The special floating point values, which JSON numbers can't
+// represent, don't mark a float column as quoted.
+func decodeCell(c json.RawMessage, dataType string) (string, byte, error) {
+	if len(c) == 0 {
+		return "", 0, timeseries.ErrInvalidBody
+	}
+	switch c[0] {
+	case 'n':
+		return "", 0, nil
+	case 't', 'f':
+		return string(c), flagBool, nil
+	case '{', '[':
+		var b bytes.Buffer
+		if err := json.Compact(&b, c); err != nil {
+			return "", 0, timeseries.ErrInvalidBody
+		}
+		return b.String(), flagJSON, nil
+	case '"':
+		var s string
+		if err := json.Unmarshal(c, &s); err != nil {
+			return "", 0, timeseries.ErrInvalidBody
+		}
+		if isSpecialFloat(s) && isFloatType(dataType) {
+			return s, 0, nil
+		}
+		return s, flagQuoted, nil
+	}
+	return string(c), 0, nil
+}
+
+func isFloatType(dataType string) bool {
+	return dataType == strconv.Itoa(oidFloat4) || dataType == strconv.Itoa(oidFloat8)
+}
+
+func isSpecialFloat(s string) bool {
+	return s == "NaN" || s == "Infinity" || s == "-Infinity"
+}
+
+// buildFieldDefinitions is the FieldParserFunc passed to the Parser
+func buildFieldDefinitions(rows [][]string,
+	trq *timeseries.TimeRangeQuery,
+) (timeseries.SeriesFields, error) {
+	l := len(rows[0])
+	if len(rows[1]) != l || len(rows[2]) != l || len(rows[3]) != l {
+		return timeseries.SeriesFields{}, timeseries.ErrInvalidBody
+	}
+	var j, k int
+	outTags := make(timeseries.FieldDefinitions, l)
+	outVals := make(timeseries.FieldDefinitions, l)
+	tfd := timeseries.FieldDefinition{OutputPosition: -1}
+	for i := range l {
+		fd := loadFieldDef(rows[0][i], rows[1][i], rows[2][i], rows[3][i], i, trq)
+		switch fd.Role {
+		case timeseries.RoleTag:
+			outTags[j] = fd
+			j++
+		case timeseries.RoleTimestamp:
+			tfd = fd
+		case timeseries.RoleValue:
+			outVals[k] = fd
+			k++
+		}
+	}
+	return timeseries.SeriesFields{
+		Timestamp: tfd, Tags: outTags[:j],
+		Values: outVals[:k], Untracked: make(timeseries.FieldDefinitions, 0),
+		ResultNameCol: -1,
+	}, nil
+}
+
+// loadFieldDef returns a field definition from the name, type OID, descriptor
+// and flags. The gateway reports field names as PostgreSQL folded them, so
+// they are matched to the timestamp and tag fields of the query exactly.
+func loadFieldDef(fieldName, dataType, desc, flags string, col int,
+	trq *timeseries.TimeRangeQuery,
+) timeseries.FieldDefinition {
+	f, _ := strconv.Atoi(flags)
+	fd := timeseries.FieldDefinition{
+		Name:           fieldName,
+		DataType:       typeToFieldDataType(dataType),
+		SDataType:      desc,
+		ProviderData1:  byte(f),
+		OutputPosition: col,
+		Role:           timeseries.RoleValue,
+	}
+	if byte(f)&flagBool != 0 {
+		fd.DataType = timeseries.Bool
+	}
+	if fieldName == trq.TimestampDefinition.Name {
+		fd.Role = timeseries.RoleTimestamp
+		fd.DataType = trq.TimestampDefinition.DataType
+		return fd
+	}
+	for _, tag := range trq.TagFieldDefintions {
+		if tag.Name == fieldName {
+			fd.Role = timeseries.RoleTag
+			break
+		}
+	}
+	return fd
+}
+
+// typeToFieldDataType is the DataTypeParserFunc passed to the Parser. Only
+// integer columns are parsed into numbers, so the values of floating point,
+// numeric and all other columns retain their text representation exactly.
+func typeToFieldDataType(input string) timeseries.FieldDataType {
+	oid, _ := strconv.Atoi(input)
+	switch oid {
+	case oidInt2, oidInt4, oidInt8:
+		return timeseries.Int64
+	}
+	return timeseries.String
+}
+
+// parseTimeField parses a bucket timestamp, which is in Unix epoch seconds for
+// epoch buckets, and is otherwise the text of a date or timestamp. Timestamps
+// without a time zone are interpreted as UTC.
+func parseTimeField(input string, fd timeseries.FieldDefinition) (epoch.Epoch, error) {
+	if fd.DataType == timeseries.DateTimeUnixSecs {
+		if i, err := strconv.ParseInt(input, 10, 64); err == nil {
+			return epoch.Epoch(i * 1e9), nil
+		}
+		f, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			return 0, timeseries.ErrInvalidTimeFormat
+		}
+		return epoch.Epoch(f * 1e9), nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, input); err == nil {
+			return epoch.Epoch(t.UnixNano()), nil
+		}
+	}
+	return 0, timeseries.ErrInvalidTimeFormat
+}
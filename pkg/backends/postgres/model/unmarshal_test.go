@@ -0,0 +1,146 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+)
+
+func TestDecodeRows(t *testing.T) {
+	rows, err := decodeRows(strings.NewReader(testDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 7 {
+		t.Fatalf("expected 7 rows got %d", len(rows))
+	}
+	if strings.Join(rows[0], ",") != "time,host,cpu,total,up,meta" {
+		t.Errorf("unexpected names %v", rows[0])
+	}
+	if strings.Join(rows[1], ",") != "1184,25,701,1700,16,3802" {
+		t.Errorf("unexpected types %v", rows[1])
+	}
+	if rows[2][1] != `{"name":"host","dataTypeID":25}` {
+		t.Errorf("unexpected descriptor %s", rows[2][1])
+	}
+	if strings.Join(rows[3], ",") != "17,1,0,1,2,4" {
+		t.Errorf("unexpected flags %v", rows[3])
+	}
+	if strings.Join(rows[5], "|") != "2023-11-14 19:30:00+00|b|NaN||false|" {
+		t.Errorf("unexpected row %v", rows[5])
+	}
+	if strings.Join(rows[6], "|") != "2023-11-14 19:31:00+00|a|2|11.25|true|[1,2]" {
+		t.Errorf("unexpected row %v", rows[6])
+	}
+
+	rows, err = decodeRows(strings.NewReader(testObjectDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(rows[3], ",") != "25,9,8,9,10,8" {
+		t.Errorf("unexpected flags %v", rows[3])
+	}
+
+	for _, input := range []string{`{"rows":[]}`, `{"fields":[]}`,
+		`{"fields":[{"name":"a"}],"rows":[[1,2]],"rowAsArray":true}`,
+		`{"fields":[{"name":"a"}],"rows":[{"b":1}]}`,
+		`{"fields":[{"name":"a"},{"name":"a"}],"rows":[]}`,
+		`{"fields":[{"name":"a"}],"rows":[[1]]}`, `[`} {
+		if _, err := decodeRows(strings.NewReader(input)); err != timeseries.ErrInvalidBody {
+			t.Errorf("expected %v for %s got %v", timeseries.ErrInvalidBody, input, err)
+		}
+	}
+}
+
+func TestUnmarshalTimeseries(t *testing.T) {
+	ts, err := UnmarshalTimeseries([]byte(testDocument), testTRQ())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := ts.(*dataset.DataSet)
+	if len(ds.Results) != 1 || len(ds.Results[0].SeriesList) != 2 {
+		t.Fatalf("unexpected results %v", ds.Results)
+	}
+	s := ds.Results[0].SeriesList[0]
+	if s.Header.Tags["host"] != "a" || len(s.Points) != 2 {
+		t.Errorf("unexpected series %+v", s)
+	}
+	if s.Points[0].Epoch != 1699990200*1e9 {
+		t.Errorf("unexpected epoch %d", s.Points[0].Epoch)
+	}
+	if s.Points[0].Values[0] != "1.5" || s.Points[0].Values[1] != "10.50" ||
+		s.Points[0].Values[2] != true || s.Points[0].Values[3] != `{"k":1}` {
+		t.Errorf("unexpected values %v", s.Points[0].Values)
+	}
+	if _, err := UnmarshalTimeseriesReader(nil, testTRQ()); err != timeseries.ErrInvalidBody {
+		t.Errorf("expected %v got %v", timeseries.ErrInvalidBody, err)
+	}
+}
+
+func TestTypeToFieldDataType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected timeseries.FieldDataType
+	}{
+		{"20", timeseries.Int64},
+		{"21", timeseries.Int64},
+		{"23", timeseries.Int64},
+		{"701", timeseries.String},
+		{"1700", timeseries.String},
+		{"1184", timeseries.String},
+		{"", timeseries.String},
+	}
+	for _, test := range tests {
+		if got := typeToFieldDataType(test.input); got != test.expected {
+			t.Errorf("expected %d for %s got %d", test.expected, test.input, got)
+		}
+	}
+}
+
+func TestParseTimeField(t *testing.T) {
+	epochs := timeseries.FieldDefinition{DataType: timeseries.DateTimeUnixSecs}
+	timestamps := timeseries.FieldDefinition{DataType: timeseries.DateTimeSQL}
+	tests := []struct {
+		input    string
+		fd       timeseries.FieldDefinition
+		expected int64
+	}{
+		{"1699990200", epochs, 1699990200 * 1e9},
+		{"1699990200.000000", epochs, 1699990200 * 1e9},
+		{"2023-11-14 19:30:00+00", timestamps, 1699990200 * 1e9},
+		{"2023-11-14 20:30:00.5+01", timestamps, 1699990200*1e9 + 5e8},
+		{"2023-11-14 19:00:00-00:30", timestamps, 1699990200 * 1e9},
+		{"2023-11-14 19:30:00", timestamps, 1699990200 * 1e9},
+		{"2023-11-14", timestamps, 1699920000 * 1e9},
+	}
+	for _, test := range tests {
+		e, err := parseTimeField(test.input, test.fd)
+		if err != nil || int64(e) != test.expected {
+			t.Errorf("expected %d for %s got %d, %v", test.expected, test.input, e, err)
+		}
+	}
+	if _, err := parseTimeField("2023-11-14", epochs); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := parseTimeField("1699990200", timestamps); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,897 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+type analyzer struct{}
+
+var dialectAnalyzer sqlanalyzer.DialectAnalyzer = analyzer{}
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+// timescaleOrigin is the default origin of TimescaleDB's time_bucket function,
+// which is a Monday, so that weekly buckets start on Mondays
+var timescaleOrigin = time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC)
+
+// errSelectInto indicates a SELECT INTO statement, which creates a table
+var errSelectInto = errors.New("SELECT INTO statements are not read queries")
+
+func (analyzer) Analyze(statement string, _ time.Time) sqlanalyzer.Analysis {
+	if strings.TrimSpace(statement) == "" {
+		return sqlanalyzer.Analysis{Reason: sqlanalyzer.ReasonInvalidSQL, Err: ErrNotTimeRangeQuery}
+	}
+	tokens, err := lex(statement)
+	if err == nil {
+		tokens, err = trimTerminator(tokens)
+	}
+	if err != nil {
+		mode := sqlanalyzer.CacheModeNone
+		if isSelectQuery(statement) {
+			mode = sqlanalyzer.CacheModeObject
+		}
+		return sqlanalyzer.Analysis{Mode: mode, Reason: sqlanalyzer.ReasonInvalidSQL, Err: err}
+	}
+	if tokens[0].is("with") {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedStatement, ErrUnsupportedStatement)
+	}
+	if !tokens[0].is("select") {
+		return noneAnalysis(ErrUnsupportedStatement)
+	}
+	q, err := splitClauses(tokens)
+	if err != nil {
+		switch {
+		case errors.Is(err, errSelectInto):
+			return noneAnalysis(err)
+		case errors.Is(err, ErrLimitUnsupported):
+			return objectAnalysis(sqlanalyzer.ReasonUnsupportedLimit, err)
+		case errors.Is(err, ErrInvalidGroupByClause):
+			return objectAnalysis(sqlanalyzer.ReasonUnsupportedGrouping, err)
+		case errors.Is(err, ErrUnsupportedStatement):
+			return objectAnalysis(sqlanalyzer.ReasonUnsupportedStatement, err)
+		case errors.Is(err, ErrNotTimeRangeQuery):
+			return objectAnalysis(sqlanalyzer.ReasonNotTimeRange, err)
+		}
+		return objectAnalysis(sqlanalyzer.ReasonInvalidSQL, err)
+	}
+	if err := checkFrom(q.clause(clauseFrom)); err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedStatement, err)
+	}
+	items := selectItems(q.clause(clauseSelect))
+	bucket, err := analyzeSelectList(items)
+	if err != nil {
+		if errors.Is(err, ErrAmbiguousTimeAxis) {
+			return objectAnalysis(sqlanalyzer.ReasonAmbiguousTimeAxis, err)
+		}
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedBucket, err)
+	}
+	groups, err := analyzeGroupBy(q.clause(clauseGroupBy), items, bucket)
+	if err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedGrouping, err)
+	}
+	if err := analyzeOrderBy(q.clause(clauseOrderBy), items, bucket); err != nil {
+		return objectAnalysis(sqlanalyzer.ReasonUnsupportedStatement, err)
+	}
+	if having := q.clause(clauseHaving); referencesColumn(having, bucket.timeColumn) ||
+		referencesColumn(having, bucket.outputColumn) {
+		return objectAnalysis(sqlanalyzer.ReasonUnsafePredicate, ErrUnsafePredicate)
+	}
+	ranges, err := analyzeRanges(q, bucket)
+	if err != nil {
+		reason := sqlanalyzer.ReasonNotTimeRange
+		if errors.Is(err, ErrUnsafePredicate) {
+			reason = sqlanalyzer.ReasonUnsafePredicate
+		} else if errors.Is(err, ErrAmbiguousTimeAxis) {
+			reason = sqlanalyzer.ReasonAmbiguousTimeAxis
+		}
+		return objectAnalysis(reason, err)
+	}
+
+	canonical, renderer := buildQueryArtifacts(q, ranges, bucket)
+	plan := &sqlanalyzer.QueryPlan{
+		CanonicalSQL: canonical,
+		TimeColumn:   bucket.timeColumn,
+		OutputColumn: bucket.outputColumn,
+		Step:         bucket.step,
+		Phase:        bucket.phase,
+		OutputUnit:   bucket.outputUnit(),
+		InputUnit:    bucket.inputUnit(),
+		LowerBound:   &ranges.lower,
+		UpperBound:   ranges.upper,
+		GroupColumns: groups,
+		Renderer:     renderer,
+	}
+	return sqlanalyzer.Analysis{
+		Mode: sqlanalyzer.CacheModeDelta, Reason: sqlanalyzer.ReasonDeltaCacheable, Plan: plan,
+	}
+}
+
+func objectAnalysis(reason sqlanalyzer.AnalysisReason, err error) sqlanalyzer.Analysis {
+	return sqlanalyzer.Analysis{Mode: sqlanalyzer.CacheModeObject, Reason: reason, Err: err}
+}
+
+func noneAnalysis(err error) sqlanalyzer.Analysis {
+	return sqlanalyzer.Analysis{
+		Mode: sqlanalyzer.CacheModeNone, Reason: sqlanalyzer.ReasonUnsupportedStatement, Err: err,
+	}
+}
+
+// trimTerminator removes any trailing statement terminators, and fails if the
+// input contains more than one statement
+func trimTerminator(tokens []token) ([]token, error) {
+	for len(tokens) > 0 && tokens[len(tokens)-1].isOp(";") {
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty statement", ErrInvalidSQL)
+	}
+	for _, t := range tokens {
+		if t.isOp(";") {
+			return nil, fmt.Errorf("%w: expected one statement", ErrInvalidSQL)
+		}
+	}
+	return tokens, nil
+}
+
+type clauseID int
+
+const (
+	clauseSelect clauseID = iota
+	clauseFrom
+	clauseWhere
+	clauseGroupBy
+	clauseHaving
+	clauseOrderBy
+	clauseCount
+)
+
+// query holds the top-level clause boundaries of a SELECT statement. bounds
+// holds the [start, end) token indexes of each clause's contents, or -1s when
+// the clause is not present.
+type query struct {
+	tokens []token
+	bounds [clauseCount][2]int
+}
+
+func (q *query) clause(id clauseID) []token {
+	b := q.bounds[id]
+	if b[0] < 0 {
+		return nil
+	}
+	return q.tokens[b[0]:b[1]]
+}
+
+// splitClauses locates the top-level clauses of a SELECT statement
+func splitClauses(tokens []token) (*query, error) {
+	q := &query{tokens: tokens}
+	for i := range q.bounds {
+		q.bounds[i] = [2]int{-1, -1}
+	}
+	current := clauseSelect
+	q.bounds[clauseSelect][0] = 1
+	start := func(id clauseID, at, contents int) error {
+		if id <= current || q.bounds[id][0] >= 0 {
+			return fmt.Errorf("%w: unexpected clause order", ErrInvalidSQL)
+		}
+		q.bounds[current][1] = at
+		q.bounds[id][0] = contents
+		current = id
+		return nil
+	}
+	for i := 1; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.kind != tokenWord {
+			continue
+		}
+		// subqueries and window functions are unsupported at any depth
+		if t.is("select") || t.is("over") {
+			return nil, ErrUnsupportedStatement
+		}
+		if t.depth > 0 {
+			continue
+		}
+		var err error
+		switch strings.ToLower(t.text) {
+		case "into":
+			return nil, errSelectInto
+		case "union", "intersect", "except", "window", "for", "join", "lateral",
+			"tablesample":
+			return nil, ErrUnsupportedStatement
+		case "limit", "offset", "fetch":
+			return nil, ErrLimitUnsupported
+		case "distinct":
+			if i == 1 {
+				return nil, ErrInvalidGroupByClause
+			}
+		case "from":
+			// IS [NOT] DISTINCT FROM is a comparison
+			if tokens[i-1].is("distinct") {
+				continue
+			}
+			err = start(clauseFrom, i, i+1)
+		case "where":
+			err = start(clauseWhere, i, i+1)
+		case "having":
+			err = start(clauseHaving, i, i+1)
+		case "group", "order":
+			// WITHIN GROUP (ORDER BY ...) belongs to an ordered-set aggregate
+			if tokens[i-1].is("within") {
+				continue
+			}
+			if i+1 >= len(tokens) || !tokens[i+1].is("by") {
+				return nil, fmt.Errorf("%w: expected BY", ErrInvalidSQL)
+			}
+			id := clauseGroupBy
+			if t.is("order") {
+				id = clauseOrderBy
+			}
+			err = start(id, i, i+2)
+			i++
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	q.bounds[current][1] = len(tokens)
+	if q.bounds[clauseFrom][0] < 0 {
+		return nil, ErrNotTimeRangeQuery
+	}
+	return q, nil
+}
+
+// checkFrom verifies that the FROM clause reads from a single table, which may
+// be qualified and aliased
+func checkFrom(tokens []token) error {
+	if len(tokens) > 0 && tokens[0].is("only") {
+		tokens = tokens[1:]
+	}
+	n := len(tokens)
+	switch {
+	case n >= 3 && tokens[n-2].is("as"):
+		if _, ok := tokens[n-1].identifier(); !ok {
+			return ErrUnsupportedStatement
+		}
+		tokens = tokens[:n-2]
+	case n >= 2 && !tokens[n-2].isOp(".") && !tokens[n-1].isOp("."):
+		if _, ok := tokens[n-1].identifier(); !ok {
+			return ErrUnsupportedStatement
+		}
+		tokens = tokens[:n-1]
+	}
+	if _, ok := columnName(tokens); !ok {
+		return ErrUnsupportedStatement
+	}
+	return nil
+}
+
+// splitList splits tokens on commas at the provided depth
+func splitList(tokens []token, depth int) [][]token {
+	if len(tokens) == 0 {
+		return nil
+	}
+	out := make([][]token, 0, 8)
+	var start int
+	for i, t := range tokens {
+		if t.depth == depth && t.isOp(",") {
+			out = append(out, tokens[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, tokens[start:])
+}
+
+type selectItem struct {
+	expr  []token
+	alias string
+}
+
+// selectItems splits the select list into its items and their aliases
+func selectItems(tokens []token) []selectItem {
+	if len(tokens) > 0 && tokens[0].is("all") {
+		tokens = tokens[1:]
+	}
+	list := splitList(tokens, 0)
+	out := make([]selectItem, len(list))
+	for i, item := range list {
+		out[i].expr = item
+		n := len(item)
+		switch {
+		case n >= 3 && item[n-2].is("as"):
+			if alias, ok := item[n-1].identifier(); ok {
+				out[i].expr = item[:n-2]
+				out[i].alias = alias
+			}
+		case n >= 2 && isImplicitAlias(item[n-2], item[n-1]):
+			out[i].alias, _ = item[n-1].identifier()
+			out[i].expr = item[:n-1]
+		}
+	}
+	return out
+}
+
+func isImplicitAlias(prev, t token) bool {
+	if t.kind == tokenWord {
+		switch strings.ToLower(t.text) {
+		case "end", "null", "true", "false":
+			return false
+		}
+	} else if t.kind != tokenIdent {
+		return false
+	}
+	return prev.isOp(")") || prev.kind == tokenWord || prev.kind == tokenIdent
+}
+
+// columnName returns the name of a column reference, which is one or more
+// identifiers joined by dots
+func columnName(tokens []token) (string, bool) {
+	if len(tokens) == 0 || len(tokens)%2 == 0 {
+		return "", false
+	}
+	parts := make([]string, 0, (len(tokens)+1)/2)
+	for i, t := range tokens {
+		if i%2 == 1 {
+			if !t.isOp(".") {
+				return "", false
+			}
+			continue
+		}
+		name, ok := t.identifier()
+		if !ok {
+			return "", false
+		}
+		parts = append(parts, name)
+	}
+	return strings.Join(parts, "."), true
+}
+
+// outputName returns the name of the output column for a column reference
+func outputName(column string) string {
+	if i := strings.LastIndexByte(column, '.'); i >= 0 {
+		return column[i+1:]
+	}
+	return column
+}
+
+func canonicalTokens(tokens []token) string {
+	return join(tokens, token.normalized)
+}
+
+type bucketSpec struct {
+	item         int
+	timeColumn   string
+	columnTokens []token
+	outputColumn string
+	step         time.Duration
+	phase        time.Duration
+	// expression is the canonical text of the bucket expression
+	expression string
+	// epochOutput is true when the bucket is output in Unix epoch seconds,
+	// rather than as a timestamp
+	epochOutput bool
+	// epochColumn is true when the time column holds Unix epoch seconds, rather
+	// than timestamps
+	epochColumn bool
+}
+
+func (b bucketSpec) cadence() sqlanalyzer.Cadence {
+	return sqlanalyzer.Cadence{Step: b.step, Phase: b.phase}
+}
+
+func (b bucketSpec) outputUnit() timeseries.FieldDataType {
+	if b.epochOutput {
+		return timeseries.DateTimeUnixSecs
+	}
+	return timeseries.DateTimeSQL
+}
+
+func (b bucketSpec) inputUnit() timeseries.FieldDataType {
+	if b.epochColumn {
+		return timeseries.DateTimeUnixSecs
+	}
+	return timeseries.DateTimeSQL
+}
+
+func analyzeSelectList(items []selectItem) (bucketSpec, error) {
+	var found *bucketSpec
+	for i, item := range items {
+		bucket, ok, err := matchBucket(item.expr)
+		if err != nil {
+			return bucketSpec{}, err
+		}
+		if !ok {
+			continue
+		}
+		if found != nil {
+			return bucketSpec{}, ErrAmbiguousTimeAxis
+		}
+		if item.alias == "" {
+			return bucketSpec{}, fmt.Errorf("%w: time bucket expression requires an alias",
+				ErrMissingTimeseries)
+		}
+		bucket.item = i
+		bucket.outputColumn = item.alias
+		bucket.expression = canonicalTokens(item.expr)
+		found = &bucket
+	}
+	if found == nil {
+		return bucketSpec{}, ErrMissingTimeseries
+	}
+	return *found, nil
+}
+
+var truncDurations = map[string]time.Duration{
+	"second": time.Second, "seconds": time.Second,
+	"minute": time.Minute, "minutes": time.Minute,
+	"hour": time.Hour, "hours": time.Hour,
+	"day": day, "days": day,
+	"week": week, "weeks": week,
+}
+
+// matchBucket returns a bucketSpec if the expression is a supported time
+// bucket expression: time_bucket('<width>', <column>), time_bucket_gapfill
+// with the same arguments, date_bin('<stride>', <column>, <origin>),
+// date_trunc('<unit>', <column>), or floor(<epoch>/<n>)*<n>, where <epoch> is
+// extract(epoch from <column>) or a column of Unix epoch seconds
+func matchBucket(expr []token) (bucketSpec, bool, error) {
+	if bucket, ok, err := matchEpochBucket(expr); ok || err != nil {
+		return bucket, ok, err
+	}
+	name, args, ok := functionCall(expr)
+	if !ok {
+		return bucketSpec{}, false, nil
+	}
+	var bucket bucketSpec
+	switch name {
+	case "time_bucket", "time_bucket_gapfill":
+		if len(args) != 2 {
+			return bucketSpec{}, false, ErrMissingTimeseries
+		}
+		step, ok := intervalArgument(args[0])
+		if !ok || step <= 0 {
+			return bucketSpec{}, false, ErrMissingTimeseries
+		}
+		bucket = bucketSpec{step: step, phase: phaseOf(timescaleOrigin, step)}
+	case "date_bin":
+		if len(args) != 3 {
+			return bucketSpec{}, false, ErrMissingTimeseries
+		}
+		step, ok := intervalArgument(args[0])
+		if !ok || step <= 0 {
+			return bucketSpec{}, false, ErrMissingTimeseries
+		}
+		origin, ok := originTime(args[2])
+		if !ok {
+			return bucketSpec{}, false, ErrMissingTimeseries
+		}
+		bucket = bucketSpec{step: step, phase: phaseOf(origin, step)}
+	case "date_trunc":
+		if len(args) != 2 || len(args[0]) != 1 {
+			return bucketSpec{}, false, ErrMissingTimeseries
+		}
+		unit, _ := args[0][0].stringValue()
+		step, ok := truncDurations[strings.ToLower(unit)]
+		if !ok {
+			return bucketSpec{}, false, ErrMissingTimeseries
+		}
+		bucket = bucketSpec{step: step}
+		if step == week {
+			// weeks are truncated to Monday, and 1970-01-01 was a Thursday
+			bucket.phase = 4 * day
+		}
+	default:
+		return bucketSpec{}, false, nil
+	}
+	column, ok := columnName(args[1])
+	if !ok {
+		return bucketSpec{}, false, ErrMissingTimeseries
+	}
+	bucket.timeColumn = column
+	bucket.columnTokens = args[1]
+	return bucket, true, nil
+}
+
+// matchEpochBucket matches floor(<epoch>/<n>)*<n> and <n>*floor(<epoch>/<n>),
+// which are the forms of Grafana's $__timeGroup and $__unixEpochGroup macros
+func matchEpochBucket(expr []token) (bucketSpec, bool, error) {
+	var floor []token
+	var multiplier token
+	switch n := len(expr); {
+	case n > 2 && expr[0].is("floor") && expr[n-2].isOp("*"):
+		floor, multiplier = expr[:n-2], expr[n-1]
+	case n > 2 && expr[2].is("floor") && expr[1].isOp("*"):
+		floor, multiplier = expr[2:], expr[0]
+	default:
+		return bucketSpec{}, false, nil
+	}
+	name, args, ok := functionCall(floor)
+	if !ok || name != "floor" || len(args) != 1 {
+		return bucketSpec{}, false, nil
+	}
+	arg := args[0]
+	div := -1
+	for i, t := range arg {
+		if t.depth == floor[1].depth+1 && t.isOp("/") {
+			div = i
+		}
+	}
+	if div != len(arg)-2 {
+		return bucketSpec{}, false, nil
+	}
+	divisor := arg[div+1]
+	if divisor.kind != tokenNumber || multiplier.kind != tokenNumber ||
+		divisor.text != multiplier.text {
+		return bucketSpec{}, false, nil
+	}
+	n, err := strconv.ParseInt(divisor.text, 10, 64)
+	if err != nil || n <= 0 {
+		return bucketSpec{}, false, ErrMissingTimeseries
+	}
+	bucket := bucketSpec{step: time.Duration(n) * time.Second, epochOutput: true}
+	source := unwrapParens(arg[:div])
+	if column, ok := extractEpochColumn(source); ok {
+		bucket.timeColumn = column
+		bucket.columnTokens = source[4 : len(source)-1]
+		return bucket, true, nil
+	}
+	column, ok := columnName(source)
+	if !ok {
+		return bucketSpec{}, false, nil
+	}
+	bucket.timeColumn = column
+	bucket.columnTokens = source
+	bucket.epochColumn = true
+	return bucket, true, nil
+}
+
+// unwrapParens removes the parentheses enclosing an entire expression, like
+// those around the column in the expansion of $__unixEpochGroup
+func unwrapParens(tokens []token) []token {
+	for len(tokens) > 2 && tokens[0].isOp("(") && closingParen(tokens, 0) == len(tokens)-1 {
+		tokens = tokens[1 : len(tokens)-1]
+	}
+	return tokens
+}
+
+// extractEpochColumn returns the column of an extract(epoch from <column>)
+// expression
+func extractEpochColumn(tokens []token) (string, bool) {
+	if len(tokens) < 6 || !tokens[0].is("extract") || !tokens[1].isOp("(") ||
+		!tokens[2].is("epoch") || !tokens[3].is("from") ||
+		closingParen(tokens, 1) != len(tokens)-1 {
+		return "", false
+	}
+	return columnName(tokens[4 : len(tokens)-1])
+}
+
+// originTime evaluates the origin of a date_bin expression, which may also be
+// a timestamp without time zone, like TIMESTAMP '2001-01-01'
+func originTime(tokens []token) (time.Time, bool) {
+	if b, ok := evaluateTime(tokens); ok {
+		return b.value, true
+	}
+	switch {
+	case len(tokens) == 2 && tokens[0].is("timestamp"):
+		tokens = tokens[1:]
+	case len(tokens) == 3 && tokens[1].isOp("::") && tokens[2].is("timestamp"):
+		tokens = tokens[:1]
+	default:
+		return time.Time{}, false
+	}
+	s, ok := tokens[0].stringValue()
+	if !ok {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05.999999", "2006-01-02T15:04:05.999999",
+		"2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// phaseOf returns the offset of buckets of the step that are aligned to the
+// origin from buckets that are aligned to the Unix epoch
+func phaseOf(origin time.Time, step time.Duration) time.Duration {
+	phase := time.Duration(origin.UnixNano() % step.Nanoseconds())
+	if phase < 0 {
+		phase += step
+	}
+	return phase
+}
+
+// functionCall returns the lowercased name and the arguments of an expression
+// that consists solely of a function call
+func functionCall(expr []token) (string, [][]token, bool) {
+	if len(expr) < 3 || expr[0].kind != tokenWord || !expr[1].isOp("(") ||
+		!expr[len(expr)-1].isOp(")") || closingParen(expr, 1) != len(expr)-1 {
+		return "", nil, false
+	}
+	return strings.ToLower(expr[0].text), splitList(expr[2:len(expr)-1], expr[1].depth+1), true
+}
+
+// closingParen returns the index of the parenthesis closing the one at i
+func closingParen(tokens []token, i int) int {
+	for j := i + 1; j < len(tokens); j++ {
+		if tokens[j].depth == tokens[i].depth && tokens[j].isOp(")") {
+			return j
+		}
+	}
+	return -1
+}
+
+// intervalArgument returns the duration of an interval argument, which is an
+// '<interval>' literal, INTERVAL '<interval>', or '<interval>'::interval
+func intervalArgument(tokens []token) (time.Duration, bool) {
+	switch {
+	case len(tokens) == 2 && tokens[0].is("interval"):
+		tokens = tokens[1:]
+	case len(tokens) == 3 && tokens[1].isOp("::") && tokens[2].is("interval"):
+		tokens = tokens[:1]
+	}
+	if len(tokens) != 1 {
+		return 0, false
+	}
+	s, ok := tokens[0].stringValue()
+	if !ok {
+		return 0, false
+	}
+	return parseInterval(s)
+}
+
+var intervalUnits = map[string]time.Duration{
+	"us": time.Microsecond, "usec": time.Microsecond, "usecs": time.Microsecond,
+	"microsecond": time.Microsecond, "microseconds": time.Microsecond,
+	"ms": time.Millisecond, "msec": time.Millisecond, "msecs": time.Millisecond,
+	"millisecond": time.Millisecond, "milliseconds": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "secs": time.Second,
+	"second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute,
+	"minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": day, "day": day, "days": day,
+	"w": week, "week": week, "weeks": week,
+}
+
+// parseInterval returns the duration of a PostgreSQL interval string, like
+// '1 minute', '5m', '300.000s' or '00:05:00'. A number without a unit is in
+// seconds. Intervals in months or years do not have a fixed duration and fail.
+func parseInterval(s string) (time.Duration, bool) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return 0, false
+	}
+	var out time.Duration
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if strings.Contains(f, ":") {
+			d, ok := parseClock(f)
+			if !ok {
+				return 0, false
+			}
+			out += d
+			continue
+		}
+		j := 0
+		for j < len(f) && (isDigit(f[j]) || f[j] == '.') {
+			j++
+		}
+		if j == 0 {
+			return 0, false
+		}
+		n, err := strconv.ParseFloat(f[:j], 64)
+		if err != nil {
+			return 0, false
+		}
+		unit := f[j:]
+		if unit == "" && i+1 < len(fields) {
+			if _, ok := intervalUnits[fields[i+1]]; ok {
+				i++
+				unit = fields[i]
+			}
+		}
+		d := time.Second
+		if unit != "" {
+			var ok bool
+			if d, ok = intervalUnits[unit]; !ok {
+				return 0, false
+			}
+		}
+		out += time.Duration(math.Round(n * float64(d)))
+	}
+	// PostgreSQL intervals have microsecond precision
+	if out%time.Microsecond != 0 {
+		return 0, false
+	}
+	return out, true
+}
+
+// parseClock returns the duration of an hh:mm[:ss[.ffffff]] interval field
+func parseClock(s string) (time.Duration, bool) {
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, false
+	}
+	var out time.Duration
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	for i, p := range parts {
+		if p == "" {
+			return 0, false
+		}
+		if i < 2 {
+			n, err := strconv.ParseUint(p, 10, 32)
+			if err != nil {
+				return 0, false
+			}
+			out += time.Duration(n) * units[i]
+			continue
+		}
+		n, err := strconv.ParseFloat(p, 64)
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		out += time.Duration(math.Round(n * float64(time.Second)))
+	}
+	return out, true
+}
+
+// analyzeGroupBy verifies that the statement groups by the time bucket and
+// every selected column, and returns the output names of the selected columns
+func analyzeGroupBy(tokens []token, items []selectItem, bucket bucketSpec) ([]string, error) {
+	if len(tokens) == 0 {
+		return nil, ErrInvalidGroupByClause
+	}
+	for _, t := range tokens {
+		if t.depth > 0 {
+			continue
+		}
+		switch strings.ToLower(t.text) {
+		case "all", "distinct", "rollup", "cube", "grouping", "sets":
+			return nil, ErrInvalidGroupByClause
+		}
+	}
+	tagOutputs := make(map[int]string, len(items))
+	for i, item := range items {
+		if i == bucket.item {
+			continue
+		}
+		column, ok := columnName(item.expr)
+		if !ok {
+			continue
+		}
+		if item.alias != "" {
+			tagOutputs[i] = item.alias
+		} else {
+			tagOutputs[i] = outputName(column)
+		}
+	}
+	var timestampGrouped bool
+	groups := make([]string, 0, len(tagOutputs))
+	seen := make(map[int]struct{}, len(tagOutputs))
+	for _, expr := range splitList(tokens, 0) {
+		i, ok := groupedItem(expr, items, bucket, true)
+		if !ok {
+			return nil, ErrInvalidGroupByClause
+		}
+		if i == bucket.item {
+			timestampGrouped = true
+			continue
+		}
+		output, ok := tagOutputs[i]
+		if !ok {
+			return nil, ErrInvalidGroupByClause
+		}
+		if _, ok := seen[i]; !ok {
+			groups = append(groups, output)
+			seen[i] = struct{}{}
+		}
+	}
+	if !timestampGrouped || len(seen) != len(tagOutputs) {
+		return nil, ErrInvalidGroupByClause
+	}
+	return groups, nil
+}
+
+// analyzeOrderBy verifies that the statement is unordered or ordered only by
+// the ascending time bucket, which is the order of a cached result
+func analyzeOrderBy(tokens []token, items []selectItem, bucket bucketSpec) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	exprs := splitList(tokens, 0)
+	if len(exprs) != 1 {
+		return ErrInvalidOrderByClause
+	}
+	expr := exprs[0]
+	if n := len(expr); n > 2 && expr[n-2].is("nulls") {
+		expr = expr[:n-2]
+	}
+	if n := len(expr); n > 1 {
+		if expr[n-1].is("desc") {
+			return ErrInvalidOrderByClause
+		}
+		if expr[n-1].is("asc") {
+			expr = expr[:n-1]
+		}
+	}
+	i, ok := groupedItem(expr, items, bucket, false)
+	if !ok || i != bucket.item {
+		return ErrInvalidOrderByClause
+	}
+	return nil
+}
+
+// groupedItem returns the index of the select item referenced by a GROUP BY
+// or ORDER BY expression, which may be an ordinal, an output name, a column
+// or the bucket expression. In a GROUP BY, PostgreSQL prefers input columns
+// to output names, so a name that could refer to either the time column or
+// an output column is ambiguous.
+func groupedItem(expr []token, items []selectItem, bucket bucketSpec,
+	groupBy bool,
+) (int, bool) {
+	if len(expr) == 1 && expr[0].kind == tokenNumber {
+		n, err := strconv.Atoi(expr[0].text)
+		if err != nil || n < 1 || n > len(items) {
+			return 0, false
+		}
+		return n - 1, true
+	}
+	if canonicalTokens(expr) == bucket.expression {
+		return bucket.item, true
+	}
+	name, ok := columnName(expr)
+	if !ok || (groupBy && name == outputName(bucket.timeColumn)) {
+		return 0, false
+	}
+	if !strings.Contains(name, ".") {
+		for i, item := range items {
+			if item.alias == name {
+				return i, true
+			}
+		}
+	}
+	for i, item := range items {
+		if column, ok := columnName(item.expr); ok &&
+			(column == name || outputName(column) == name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// isSelectQuery reports whether the SQL query contains a SELECT keyword.
+func isSelectQuery(statement string) bool {
+	for f := range strings.FieldsSeq(strings.ToLower(statement)) {
+		if f == "select" {
+			return true
+		}
+	}
+	return false
+}
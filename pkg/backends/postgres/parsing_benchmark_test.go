@@ -0,0 +1,40 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/testutil/sqlcorpus"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+// BenchmarkPostgresAnalyze measures tokenization and semantic analysis of a
+// representative Grafana query.
+func BenchmarkPostgresAnalyze(b *testing.B) {
+	sqlcorpus.BenchmarkAnalyze(b, dialectAnalyzer, testQuery)
+}
+
+// BenchmarkPostgresRenderExtent measures immutable cache-miss template
+// rendering independently from initial parsing and analysis.
+func BenchmarkPostgresRenderExtent(b *testing.B) {
+	sqlcorpus.BenchmarkRenderExtent(b, dialectAnalyzer, testQuery, timeseries.Extent{
+		Start: time.Unix(1699990200, 0),
+		End:   time.Unix(1699996140, 0),
+	})
+}
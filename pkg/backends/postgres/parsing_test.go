@@ -0,0 +1,287 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+const testQuery = `SELECT time_bucket('1 minute', ts) AS "time", host, avg(cpu) AS cpu ` +
+	`FROM metrics WHERE ts >= '2023-11-14T19:30:00Z' AND ts < '2023-11-14T21:10:00Z' ` +
+	`AND host = 'a''b' GROUP BY 1, host ORDER BY 1`
+
+func analyzeDelta(t *testing.T, query string) *sqlanalyzer.QueryPlan {
+	t.Helper()
+	analysis := dialectAnalyzer.Analyze(query, time.Unix(1_700_000_000, 0))
+	if analysis.Mode != sqlanalyzer.CacheModeDelta || analysis.Plan == nil {
+		t.Fatalf("analysis = (%s, %s, %v), want delta", analysis.Mode.String(),
+			analysis.Reason, analysis.Err)
+	}
+	return analysis.Plan
+}
+
+func TestAnalyzeBuildsPlan(t *testing.T) {
+	plan := analyzeDelta(t, testQuery)
+	if plan.TimeColumn != "ts" || plan.OutputColumn != "time" {
+		t.Errorf("columns = (%s, %s), want (ts, time)", plan.TimeColumn, plan.OutputColumn)
+	}
+	if plan.InputUnit != timeseries.DateTimeSQL || plan.OutputUnit != timeseries.DateTimeSQL {
+		t.Errorf("units = (%d, %d)", plan.InputUnit, plan.OutputUnit)
+	}
+	if !slices.Equal(plan.GroupColumns, []string{"host"}) {
+		t.Errorf("group columns = %v, want [host]", plan.GroupColumns)
+	}
+	if !plan.LowerBound.Value.Equal(time.Unix(1699990200, 0)) || !plan.LowerBound.Inclusive {
+		t.Errorf("lower bound = %+v", plan.LowerBound)
+	}
+	if plan.UpperBound == nil || !plan.UpperBound.Value.Equal(time.Unix(1699996200, 0)) ||
+		plan.UpperBound.Inclusive {
+		t.Errorf("upper bound = %+v", plan.UpperBound)
+	}
+	const expected = `select time_bucket('1 minute', ts) as "time", host, avg(cpu) as cpu ` +
+		`from metrics where ts >= <$TS1$> and ts < <$TS2$> and host = 'a''b' ` +
+		`group by 1, host order by 1`
+	if plan.CanonicalSQL != expected {
+		t.Errorf("canonical SQL =\n%s\nwant\n%s", plan.CanonicalSQL, expected)
+	}
+}
+
+func TestAnalyzeBucketForms(t *testing.T) {
+	tests := []struct {
+		name   string
+		bucket string
+		step   time.Duration
+		phase  time.Duration
+		unit   timeseries.FieldDataType
+		lower  string
+	}{
+		{"time_bucket", "time_bucket('5m', ts)", 5 * time.Minute, 0, timeseries.DateTimeSQL, "2023-11-13T00:00:00Z"},
+		{"time_bucket interval", "time_bucket(INTERVAL '1 hour', ts)", time.Hour, 0, timeseries.DateTimeSQL, "2023-11-13T00:00:00Z"},
+		{"time_bucket cast", "time_bucket('00:00:30'::interval, ts)", 30 * time.Second, 0, timeseries.DateTimeSQL, "2023-11-13T00:00:00Z"},
+		{"time_bucket week", "time_bucket('1 week', ts)", 7 * 24 * time.Hour, 4 * 24 * time.Hour, timeseries.DateTimeSQL, "2023-11-13T00:00:00Z"},
+		{"time_bucket_gapfill", "time_bucket_gapfill('1m', ts)", time.Minute, 0, timeseries.DateTimeSQL, "2023-11-13T00:00:00Z"},
+		{"date_bin", "date_bin('15 minutes', ts, TIMESTAMP '2001-01-01 00:05:00')", 15 * time.Minute, 5 * time.Minute, timeseries.DateTimeSQL, "2023-11-13T00:05:00Z"},
+		{"date_trunc", "date_trunc('hour', ts)", time.Hour, 0, timeseries.DateTimeSQL, "2023-11-13T00:00:00Z"},
+		{"date_trunc week", "date_trunc('week', ts)", 7 * 24 * time.Hour, 4 * 24 * time.Hour, timeseries.DateTimeSQL, "2023-11-13T00:00:00Z"},
+		{"epoch", "floor(extract(epoch from ts)/60)*60", time.Minute, 0, timeseries.DateTimeUnixSecs, "2023-11-13T00:00:00Z"},
+		{"epoch reversed", "60*floor(extract(epoch from ts)/60)", time.Minute, 0, timeseries.DateTimeUnixSecs, "2023-11-13T00:00:00Z"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plan := analyzeDelta(t, "SELECT "+test.bucket+" AS b, count(*) FROM events "+
+				"WHERE ts >= '"+test.lower+"' GROUP BY b")
+			if plan.Step != test.step || plan.Phase != test.phase || plan.OutputUnit != test.unit {
+				t.Errorf("bucket = (%s, %s, %d), want (%s, %s, %d)", plan.Step, plan.Phase,
+					plan.OutputUnit, test.step, test.phase, test.unit)
+			}
+		})
+	}
+}
+
+func TestAnalyzeNumericTimeColumn(t *testing.T) {
+	const query = "SELECT floor(epoch/60)*60 AS t, count(*) FROM flows " +
+		"WHERE epoch >= 1699990200 AND epoch < 1699996200 GROUP BY t"
+	plan := analyzeDelta(t, query)
+	if plan.InputUnit != timeseries.DateTimeUnixSecs {
+		t.Errorf("input unit = %d, want %d", plan.InputUnit, timeseries.DateTimeUnixSecs)
+	}
+	rendered, err := plan.RenderExtent(timeseries.Extent{
+		Start: time.Unix(1699990260, 0), End: time.Unix(1699990320, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(rendered, "epoch >= 1699990260 AND epoch < 1699990380") {
+		t.Errorf("unexpected rendered query: %s", rendered)
+	}
+	// a numeric column may hold fractional seconds, so an inclusive upper
+	// bound can't be converted to an exclusive one
+	analysis := dialectAnalyzer.Analyze(strings.Replace(query, "epoch < 1699996200",
+		"epoch <= 1699996199", 1), time.Unix(1_700_000_000, 0))
+	if analysis.Reason != sqlanalyzer.ReasonUnsafePredicate {
+		t.Errorf("reason = %s, want %s", analysis.Reason, sqlanalyzer.ReasonUnsafePredicate)
+	}
+}
+
+func TestBoundSemantics(t *testing.T) {
+	const prefix = "SELECT time_bucket('1m', ts) AS t, count(*) FROM events WHERE "
+	extent := timeseries.Extent{Start: time.Unix(1699990200, 0), End: time.Unix(1699990260, 0)}
+	tests := []struct {
+		name      string
+		predicate string
+		start     int64
+		end       int64
+		rendered  []string
+	}{
+		{
+			name:      "half open",
+			predicate: "ts >= '2023-11-14T19:30:00Z' AND ts < '2023-11-14T19:40:00Z'",
+			start:     1699990200, end: 1699990740,
+			rendered: []string{"ts >= '2023-11-14T19:30:00Z'", "ts < '2023-11-14T19:32:00Z'"},
+		},
+		{
+			name: "typed and cast literals",
+			predicate: "ts >= TIMESTAMPTZ '2023-11-14 19:30:00+00' " +
+				"AND ts < '2023-11-14 19:40:00+00:00'::timestamptz",
+			start: 1699990200, end: 1699990740,
+			rendered: []string{"ts >= timestamptz '2023-11-14T19:30:00Z'",
+				"ts < '2023-11-14T19:32:00Z'::timestamptz"},
+		},
+		{
+			name:      "reversed operands",
+			predicate: "to_timestamp(1699990200) <= ts AND to_timestamp(1699990800) > ts",
+			start:     1699990200, end: 1699990740,
+			rendered: []string{"to_timestamp(1699990200) <= ts", "to_timestamp(1699990320) > ts"},
+		},
+		{
+			name:      "inclusive microsecond upper",
+			predicate: "ts BETWEEN '2023-11-14T19:30:00Z' AND '2023-11-14T19:39:59.999999Z'",
+			start:     1699990200, end: 1699990740,
+			rendered: []string{"BETWEEN '2023-11-14T19:30:00Z' AND '2023-11-14T19:31:59.999999Z'"},
+		},
+		{
+			name: "bucket expression comparisons",
+			predicate: "time_bucket('1m', ts) > '2023-11-14T19:29:10Z' " +
+				"AND time_bucket('1m', ts) <= '2023-11-14T19:39:10Z'",
+			start: 1699990200, end: 1699990740,
+			rendered: []string{"> '2023-11-14T19:29:00Z'", "<= '2023-11-14T19:31:00Z'"},
+		},
+		{
+			name:      "open upper",
+			predicate: "ts >= to_timestamp(1699990200)",
+			start:     1699990200, end: 1699999980,
+			rendered: []string{"ts >= to_timestamp(1699990200) AND ts < to_timestamp(1699990320)"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			analysis := dialectAnalyzer.Analyze(prefix+test.predicate+" GROUP BY t",
+				time.Unix(1700000000, 0))
+			if analysis.Mode != sqlanalyzer.CacheModeDelta {
+				t.Fatalf("analysis = (%s, %s, %v)", analysis.Mode.String(), analysis.Reason, analysis.Err)
+			}
+			start, end := effectiveExtent(analysis.Plan, time.Unix(1699999980, 0))
+			if start != test.start || end != test.end {
+				t.Errorf("extent = (%d, %d), want (%d, %d)", start, end, test.start, test.end)
+			}
+			rendered, err := analysis.Plan.RenderExtent(extent)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range test.rendered {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("rendered query lacks %q: %s", want, rendered)
+				}
+			}
+		})
+	}
+}
+
+// effectiveExtent returns the first and last bucket selected by the plan, as
+// ParseTimeRangeQuery derives them
+func effectiveExtent(plan *sqlanalyzer.QueryPlan, now time.Time) (int64, int64) {
+	start := plan.LowerBound.Value
+	if !plan.LowerBound.Inclusive {
+		start = start.Add(plan.Step)
+	}
+	end := now
+	if plan.UpperBound != nil {
+		end = plan.UpperBound.Value
+		if !plan.UpperBound.Inclusive {
+			end = end.Add(-plan.Step)
+		}
+	}
+	return start.Unix(), end.Unix()
+}
+
+func TestCanonicalCacheIdentityIgnoresExtent(t *testing.T) {
+	a := analyzeDelta(t, testQuery)
+	b := analyzeDelta(t, strings.NewReplacer("19:30:00Z", "18:30:00Z",
+		"21:10:00Z", "20:10:00Z").Replace(testQuery))
+	if a.CanonicalSQL != b.CanonicalSQL {
+		t.Errorf("canonical SQL differs:\n%s\n%s", a.CanonicalSQL, b.CanonicalSQL)
+	}
+	// formatting and keyword case don't change the cache identity
+	c := analyzeDelta(t, strings.NewReplacer("SELECT", "select\n ", "avg(", "AVG(",
+		", host", ",host").Replace(testQuery))
+	if a.CanonicalSQL != c.CanonicalSQL {
+		t.Errorf("canonical SQL differs:\n%s\n%s", a.CanonicalSQL, c.CanonicalSQL)
+	}
+}
+
+func TestRendererPrivatePlaceholdersAreCollisionSafe(t *testing.T) {
+	query := "SELECT time_bucket('1m', ts) AS t, count(*) FROM events " +
+		`WHERE note = '<$TS1$>' AND "<$TS2$>" = 1 AND marker = $$<$TS1$>$$ ` +
+		"AND ts >= '2023-11-14T19:30:00Z' AND ts < '2023-11-14T19:40:00Z' GROUP BY t"
+	plan := analyzeDelta(t, query)
+	if !strings.Contains(plan.CanonicalSQL, "note = '<$TS1$>'") ||
+		!strings.Contains(plan.CanonicalSQL, `"<$TS2$>" = 1`) ||
+		!strings.Contains(plan.CanonicalSQL, "marker = $$<$TS1$>$$") {
+		t.Fatalf("canonicalization modified user SQL: %s", plan.CanonicalSQL)
+	}
+	rendered, err := plan.RenderExtent(timeseries.Extent{
+		Start: time.Unix(1699990260, 0), End: time.Unix(1699990320, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"note = '<$TS1$>'", `"<$TS2$>" = 1`, "marker = $$<$TS1$>$$",
+		"ts >= '2023-11-14T19:31:00Z'", "ts < '2023-11-14T19:33:00Z'"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("rendered query lacks %q: %s", want, rendered)
+		}
+	}
+}
+
+func TestRendererIsConcurrentAndImmutable(t *testing.T) {
+	const workers = 64
+	plan := analyzeDelta(t, testQuery)
+	results := make([]string, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Go(func() {
+			start := time.Unix(int64(1699990200+i*120), 0)
+			results[i], errs[i] = plan.RenderExtent(timeseries.Extent{
+				Start: start, End: start.Add(time.Minute),
+			})
+		})
+	}
+	wg.Wait()
+
+	for i := range workers {
+		if errs[i] != nil {
+			t.Fatalf("render %d: %v", i, errs[i])
+		}
+		start := time.Unix(int64(1699990200+i*120), 0).UTC()
+		for _, want := range []string{
+			"ts >= '" + start.Format(time.RFC3339) + "'",
+			"ts < '" + start.Add(2*time.Minute).Format(time.RFC3339) + "'",
+		} {
+			if !strings.Contains(results[i], want) {
+				t.Errorf("render %d lacks %q: %s", i, want, results[i])
+			}
+		}
+	}
+}
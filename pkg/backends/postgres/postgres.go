@@ -0,0 +1,119 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package postgres provides the PostgreSQL backend provider, which accelerates
+// time series queries, including those for TimescaleDB, made to a PostgreSQL
+// HTTP gateway
+package postgres
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	modelpg "github.com/trickstercache/trickster/v2/pkg/backends/postgres/model"
+	"github.com/trickstercache/trickster/v2/pkg/backends/providers/registry/types"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+var _ backends.TimeseriesBackend = (*Client)(nil)
+
+// Client Implements the Proxy Client Interface
+type Client struct {
+	backends.TimeseriesBackend
+}
+
+var _ types.NewBackendClientFunc = NewClient
+
+// NewClient returns a new Client Instance
+func NewClient(name string, o *bo.Options, router http.Handler,
+	cache cache.Cache, _ backends.Backends,
+	_ types.Lookup,
+) (backends.Backend, error) {
+	if o != nil {
+		o.FastForwardDisable = true
+	}
+	c := &Client{}
+	b, err := backends.NewTimeseriesBackend(name, o, c.RegisterHandlers, router, cache, modelpg.NewModeler())
+	c.TimeseriesBackend = b
+	return c, err
+}
+
+// ParseTimeRangeQuery parses the key parts of a TimeRangeQuery from the inbound
+// gateway query request. A SELECT that can't be delta cached remains eligible
+// for the object proxy cache.
+func (c *Client) ParseTimeRangeQuery(r *http.Request) (*timeseries.TimeRangeQuery, *timeseries.RequestOptions, bool, error) {
+	qr, err := parseRequest(r)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	now := time.Now()
+	analysis := dialectAnalyzer.Analyze(qr.query, now)
+	c.observeAnalysis(analysis)
+
+	trq := &timeseries.TimeRangeQuery{
+		Statement:        qr.query,
+		CacheKeyElements: qr.cacheKeyElements(qr.query),
+	}
+	trq.OriginalBody, _ = request.GetBody(r)
+	if analysis.Mode != sqlanalyzer.CacheModeDelta || analysis.Plan == nil {
+		if analysis.Mode < sqlanalyzer.CacheModeObject {
+			return nil, nil, false, analysis.Err
+		}
+		return trq, nil, true, analysis.Err
+	}
+
+	plan := analysis.Plan
+	trq.Statement = plan.CanonicalSQL
+	trq.CacheKeyElements = qr.cacheKeyElements(plan.CanonicalSQL)
+	trq.Step = plan.Step
+	trq.StepNS = plan.Step.Nanoseconds()
+	trq.Phase = plan.Phase
+	trq.Extent.Start = plan.LowerBound.Value
+	if !plan.LowerBound.Inclusive {
+		trq.Extent.Start = trq.Extent.Start.Add(plan.Step)
+	}
+	if plan.UpperBound == nil {
+		trq.Extent.End = now
+	} else {
+		trq.Extent.End = plan.UpperBound.Value
+		if !plan.UpperBound.Inclusive {
+			trq.Extent.End = trq.Extent.End.Add(-plan.Step)
+		}
+	}
+	trq.TimestampDefinition = timeseries.FieldDefinition{
+		Name:          plan.OutputColumn,
+		DataType:      plan.OutputUnit,
+		Role:          timeseries.RoleTimestamp,
+		ProviderData1: byte(plan.InputUnit),
+	}
+	trq.TagFieldDefintions = make(timeseries.FieldDefinitions, len(plan.GroupColumns))
+	for i, name := range plan.GroupColumns {
+		trq.TagFieldDefintions[i] = timeseries.FieldDefinition{Name: name, Role: timeseries.RoleTag}
+	}
+	trq.ParsedQuery = plan
+	trq.ExtractBackfillTolerance(qr.query)
+
+	rlo := &timeseries.RequestOptions{
+		BaseTimestampFieldName: plan.TimeColumn,
+	}
+	rlo.ExtractFastForwardDisabled(qr.query)
+	return trq, rlo, true, nil
+}
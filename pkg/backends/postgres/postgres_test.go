@@ -0,0 +1,135 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	te "github.com/trickstercache/trickster/v2/pkg/errors"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func newTestRequest(body string) *http.Request {
+	r, _ := http.NewRequest(http.MethodPost, "http://example"+PathSQL, strings.NewReader(body))
+	return r
+}
+
+func TestNewClient(t *testing.T) {
+	o := bo.New()
+	o.Provider = "TEST_CLIENT"
+	c, err := NewClient("default", o, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name() != "default" {
+		t.Errorf("expected %s got %s", "default", c.Name())
+	}
+	if _, ok := c.(backends.TimeseriesBackend); !ok {
+		t.Error("expected a TimeseriesBackend")
+	}
+	if !o.FastForwardDisable {
+		t.Error("expected fast forward to be disabled")
+	}
+}
+
+func TestParseTimeRangeQuery(t *testing.T) {
+	client := &Client{}
+	body := `{"query":"SELECT time_bucket('1 minute', ts) AS \"time\", host, avg(cpu) AS cpu ` +
+		`FROM metrics WHERE ts >= '2023-11-14T19:30:00Z' AND ts < '2023-11-14T21:10:00Z' ` +
+		`GROUP BY 1, host ORDER BY 1","params":[ 1, "a" ]}`
+	trq, rlo, canOPC, err := client.ParseTimeRangeQuery(newTestRequest(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !canOPC || rlo == nil || rlo.BaseTimestampFieldName != "ts" {
+		t.Errorf("unexpected request options %+v", rlo)
+	}
+	if trq.Step != time.Minute {
+		t.Errorf("expected %s got %s", time.Minute, trq.Step)
+	}
+	if want := time.Unix(1699990200, 0); !trq.Extent.Start.Equal(want) {
+		t.Errorf("expected %s got %s", want, trq.Extent.Start)
+	}
+	if want := time.Unix(1699996140, 0); !trq.Extent.End.Equal(want) {
+		t.Errorf("expected %s got %s", want, trq.Extent.End)
+	}
+	if trq.TimestampDefinition.Name != "time" ||
+		trq.TimestampDefinition.DataType != timeseries.DateTimeSQL {
+		t.Errorf("unexpected timestamp definition %+v", trq.TimestampDefinition)
+	}
+	if len(trq.TagFieldDefintions) != 1 || trq.TagFieldDefintions[0].Name != "host" {
+		t.Errorf("unexpected tag definitions %+v", trq.TagFieldDefintions)
+	}
+	if trq.CacheKeyElements[ParamQuery] != trq.Statement ||
+		!strings.Contains(trq.Statement, "<$TS1$>") ||
+		trq.CacheKeyElements[ParamParams] != `[1,"a"]` {
+		t.Errorf("unexpected cache key elements %v", trq.CacheKeyElements)
+	}
+
+	// weekly buckets keep the phase of their origin
+	trq, _, _, err = client.ParseTimeRangeQuery(newTestRequest(`{"query":"SELECT ` +
+		`time_bucket('1 week', ts) AS w, count(*) FROM metrics WHERE ts >= '2023-11-13T00:00:00Z' ` +
+		`GROUP BY w"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trq.Phase != 4*24*time.Hour {
+		t.Errorf("expected %s got %s", 4*24*time.Hour, trq.Phase)
+	}
+
+	// a query that is not a time series remains eligible for the object cache
+	_, _, canOPC, err = client.ParseTimeRangeQuery(newTestRequest(
+		`{"query":"SELECT host FROM metrics"}`))
+	if !canOPC || err == nil {
+		t.Errorf("expected object cache eligibility and an error, got %t, %v", canOPC, err)
+	}
+
+	// a non-SELECT statement is not cacheable
+	_, _, canOPC, err = client.ParseTimeRangeQuery(newTestRequest(
+		`{"query":"DELETE FROM metrics"}`))
+	if canOPC || err == nil {
+		t.Errorf("expected no cache eligibility and an error, got %t, %v", canOPC, err)
+	}
+
+	tests := []struct {
+		name string
+		r    *http.Request
+		err  error
+	}{
+		{"get", func() *http.Request {
+			r, _ := http.NewRequest(http.MethodGet, "http://example"+PathSQL, nil)
+			return r
+		}(), te.ErrInvalidMethod},
+		{"invalid json", newTestRequest(`{"query":`), te.ErrBadRequest},
+		{"missing query", newTestRequest(`{"params":[]}`), nil},
+		{"transaction", newTestRequest(`{"queries":[{"query":"SELECT 1"}]}`), nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, _, err := client.ParseTimeRangeQuery(test.r)
+			if err == nil || (test.err != nil && !errors.Is(err, test.err)) {
+				t.Errorf("expected %v got %v", test.err, err)
+			}
+		})
+	}
+}
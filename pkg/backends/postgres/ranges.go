@@ -0,0 +1,432 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+)
+
+type boundStyle uint8
+
+const (
+	boundString      boundStyle = iota // '2024-01-01T00:00:00Z'
+	boundTyped                         // TIMESTAMPTZ '2024-01-01T00:00:00Z'
+	boundCast                          // '2024-01-01T00:00:00Z'::timestamptz
+	boundUnixSeconds                   // to_timestamp(1704067200)
+	boundNumber                        // 1704067200
+)
+
+type endpoint uint8
+
+const (
+	endpointLower endpoint = iota
+	endpointUpper
+)
+
+// analyzedBound is a time bound found in the WHERE clause. start and end are
+// the [start, end) token indexes of the bound's expression in the statement.
+type analyzedBound struct {
+	value     time.Time
+	inclusive bool
+	style     boundStyle
+	// typeName is the type of a typed or cast literal
+	typeName string
+	// onBucket is true when the bound compares the bucket expression, rather
+	// than the raw time column
+	onBucket bool
+	start    int
+	end      int
+}
+
+func (b analyzedBound) bound() sqlanalyzer.Bound {
+	return sqlanalyzer.Bound{Value: b.value, Inclusive: b.inclusive}
+}
+
+// rangeAnalysis holds the normalized bounds of the statement, and the bound
+// expressions to replace when rendering. lower.Value is the first included
+// bucket. upper is nil when the statement has no upper bound.
+type rangeAnalysis struct {
+	lower     sqlanalyzer.Bound
+	upper     *sqlanalyzer.Bound
+	lowerExpr analyzedBound
+	upperExpr *analyzedBound
+	whereEnd  int
+}
+
+// analyzeRanges finds the time range of the statement. Every WHERE conjunct
+// that references the time column must compare the raw column or the bucket
+// expression to a constant, and conjuncts are only separated by AND; anything
+// else fails closed. Raw time column predicates must describe complete
+// buckets, while bucket expression predicates are discrete, and are
+// normalized to the first and last included buckets.
+func analyzeRanges(q *query, bucket bucketSpec) (rangeAnalysis, error) {
+	b := q.bounds[clauseWhere]
+	if b[0] < 0 {
+		return rangeAnalysis{}, ErrNotTimeRangeQuery
+	}
+	result := rangeAnalysis{whereEnd: b[1]}
+	conjuncts, err := splitConjunction(q.tokens, b[0], b[1], bucket.timeColumn)
+	if err != nil {
+		return result, err
+	}
+	var lower *analyzedBound
+	for _, c := range conjuncts {
+		lb, ub, err := analyzePredicate(q.tokens, c[0], c[1], bucket)
+		if err != nil {
+			return result, err
+		}
+		if lb != nil {
+			if lower != nil {
+				return result, ErrAmbiguousTimeAxis
+			}
+			lower = lb
+		}
+		if ub != nil {
+			if result.upperExpr != nil {
+				return result, ErrAmbiguousTimeAxis
+			}
+			result.upperExpr = ub
+		}
+	}
+	if lower == nil {
+		return result, fmt.Errorf("%w: time column %q did not match a lower range predicate",
+			ErrNoLowerBound, bucket.timeColumn)
+	}
+	result.lowerExpr = *lower
+	if result.lower, err = normalizeLower(*lower, bucket); err != nil {
+		return result, err
+	}
+	if result.upperExpr != nil {
+		upper, err := normalizeUpper(*result.upperExpr, bucket)
+		if err != nil {
+			return result, err
+		}
+		result.upper = &upper
+	}
+	return result, nil
+}
+
+// normalizeLower returns the first bucket included by a lower bound
+func normalizeLower(b analyzedBound, bucket bucketSpec) (sqlanalyzer.Bound, error) {
+	bound, ok := sqlanalyzer.NormalizeLower(b.bound(), b.onBucket, bucket.cadence())
+	if !ok {
+		return sqlanalyzer.Bound{}, ErrUnsafePredicate
+	}
+	return bound, nil
+}
+
+// normalizeUpper returns the upper bound of the range. A raw time column
+// bound is exclusive, and an inclusive bound on a temporal column is accepted
+// when it is one microsecond, the precision of PostgreSQL timestamps, before
+// a bucket boundary. A bucket expression bound is the last included bucket.
+func normalizeUpper(b analyzedBound, bucket bucketSpec) (sqlanalyzer.Bound, error) {
+	precision := time.Microsecond
+	if bucket.epochColumn {
+		precision = 0
+	}
+	bound, ok := sqlanalyzer.NormalizeUpper(b.bound(), b.onBucket, bucket.cadence(), precision)
+	if !ok {
+		return sqlanalyzer.Bound{}, ErrUnsafePredicate
+	}
+	return bound, nil
+}
+
+// splitConjunction returns the [start, end) token indexes of each conjunct in
+// tokens[start:end], descending into conjunctions wrapped in parentheses. A
+// disjunction or negation is returned as a single conjunct, unless it
+// references the time column, which is unsafe.
+func splitConjunction(tokens []token, start, end int, column string) ([][2]int, error) {
+	if start >= end {
+		return nil, fmt.Errorf("%w: empty predicate", ErrInvalidSQL)
+	}
+	if tokens[start].isOp("(") && start+closingParen(tokens[start:end], 0) == end-1 {
+		return splitConjunction(tokens, start+1, end-1, column)
+	}
+	depth := tokens[start].depth
+	out := make([][2]int, 0, 4)
+	var inBetween bool
+	from := start
+	for i := start; i < end; i++ {
+		t := tokens[i]
+		if t.depth != depth {
+			continue
+		}
+		switch {
+		case t.is("or"):
+			if referencesColumn(tokens[start:end], column) {
+				return nil, ErrUnsafePredicate
+			}
+			return [][2]int{{start, end}}, nil
+		case t.is("between"):
+			inBetween = true
+		case t.is("and"):
+			if inBetween {
+				inBetween = false
+				continue
+			}
+			parts, err := splitConjunction(tokens, from, i, column)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, parts...)
+			from = i + 1
+		}
+	}
+	if from == start {
+		return append(out, [2]int{start, end}), nil
+	}
+	parts, err := splitConjunction(tokens, from, end, column)
+	if err != nil {
+		return nil, err
+	}
+	return append(out, parts...), nil
+}
+
+// referencesColumn returns true if any identifier in the tokens could refer
+// to the column
+func referencesColumn(tokens []token, column string) bool {
+	name := outputName(column)
+	for _, t := range tokens {
+		if id, ok := t.identifier(); ok && id == name {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzePredicate returns the bounds described by the conjunct in
+// tokens[start:end], which are nil when it does not reference the time column.
+// The conjunct must be a comparison or a BETWEEN of the raw time column or the
+// bucket expression and constants.
+func analyzePredicate(tokens []token, start, end int, bucket bucketSpec,
+) (*analyzedBound, *analyzedBound, error) {
+	conjunct := tokens[start:end]
+	if !referencesColumn(conjunct, bucket.timeColumn) {
+		return nil, nil, nil
+	}
+	depth := conjunct[0].depth
+	op, between, and := -1, -1, -1
+	for i, t := range conjunct {
+		if t.depth != depth {
+			continue
+		}
+		if t.kind == tokenWord {
+			if i+1 < len(conjunct) && conjunct[i+1].isOp("(") && conjunct[i+1].adjacent {
+				continue // function name
+			}
+			switch strings.ToLower(t.text) {
+			case "between":
+				if between >= 0 || op >= 0 {
+					return nil, nil, ErrUnsafePredicate
+				}
+				between = i
+				continue
+			case "and":
+				and = i
+				continue
+			case "not", "symmetric", "in", "is", "like", "ilike", "similar", "at",
+				"overlaps", "any", "all", "some", "isnull", "notnull":
+				return nil, nil, ErrUnsafePredicate
+			}
+		}
+		if t.kind != tokenOperator {
+			continue
+		}
+		switch t.text {
+		case ">", ">=", "<", "<=":
+		case "=", "<>", "!=":
+			return nil, nil, ErrUnsafePredicate
+		default:
+			continue
+		}
+		if op >= 0 || between >= 0 {
+			return nil, nil, ErrUnsafePredicate
+		}
+		op = i
+	}
+	if between >= 0 {
+		if and < between {
+			return nil, nil, ErrUnsafePredicate
+		}
+		onBucket, ok := timeTarget(conjunct[:between], bucket)
+		if !ok {
+			return nil, nil, ErrUnsafePredicate
+		}
+		lower, err := boundValue(tokens, start+between+1, start+and, bucket, onBucket)
+		if err != nil {
+			return nil, nil, err
+		}
+		upper, err := boundValue(tokens, start+and+1, end, bucket, onBucket)
+		if err != nil {
+			return nil, nil, err
+		}
+		lower.inclusive, upper.inclusive = true, true
+		return lower, upper, nil
+	}
+	if op < 0 || and >= 0 {
+		return nil, nil, ErrUnsafePredicate
+	}
+	operator := conjunct[op].text
+	bs, be := start+op+1, end
+	onBucket, ok := timeTarget(conjunct[:op], bucket)
+	if !ok {
+		onBucket, ok = timeTarget(conjunct[op+1:], bucket)
+		if !ok {
+			return nil, nil, ErrUnsafePredicate
+		}
+		bs, be = start, start+op
+		operator = sqlanalyzer.InvertComparator(operator)
+	}
+	bound, err := boundValue(tokens, bs, be, bucket, onBucket)
+	if err != nil {
+		return nil, nil, err
+	}
+	bound.inclusive = operator == ">=" || operator == "<="
+	if operator == ">" || operator == ">=" {
+		return bound, nil, nil
+	}
+	return nil, bound, nil
+}
+
+// timeTarget returns true when the tokens are the raw time column or the
+// bucket expression, and whether they are the bucket expression
+func timeTarget(tokens []token, bucket bucketSpec) (bool, bool) {
+	if column, ok := columnName(tokens); ok && column == bucket.timeColumn {
+		return false, true
+	}
+	if canonicalTokens(tokens) == bucket.expression {
+		return true, true
+	}
+	return false, false
+}
+
+// boundValue evaluates the constant in tokens[start:end] that a time target is
+// compared to. Timestamps are compared to timestamps, and Unix epoch seconds
+// to integers.
+func boundValue(tokens []token, start, end int, bucket bucketSpec,
+	onBucket bool,
+) (*analyzedBound, error) {
+	expr := tokens[start:end]
+	if referencesColumn(expr, bucket.timeColumn) {
+		return nil, ErrUnsafePredicate
+	}
+	var bound analyzedBound
+	var ok bool
+	if (onBucket && bucket.epochOutput) || (!onBucket && bucket.epochColumn) {
+		bound, ok = evaluateEpoch(expr)
+	} else {
+		bound, ok = evaluateTime(expr)
+	}
+	if !ok {
+		return nil, ErrUnsafePredicate
+	}
+	bound.start, bound.end, bound.onBucket = start, end, onBucket
+	return &bound, nil
+}
+
+// evaluateEpoch evaluates an integer of Unix epoch seconds
+func evaluateEpoch(tokens []token) (analyzedBound, bool) {
+	if len(tokens) != 1 || tokens[0].kind != tokenNumber {
+		return analyzedBound{}, false
+	}
+	v, err := strconv.ParseInt(tokens[0].text, 10, 64)
+	if err != nil {
+		return analyzedBound{}, false
+	}
+	return analyzedBound{value: time.Unix(v, 0), style: boundNumber}, true
+}
+
+// evaluateTime evaluates a constant timestamp: a string literal, optionally
+// typed or cast as timestamptz, or the to_timestamp of Unix epoch seconds.
+// The session time zone would determine the meaning of a string without a UTC
+// offset, and PostgreSQL ignores the offset when converting a string to a
+// timestamp without time zone, so strings must have a UTC offset of zero.
+func evaluateTime(tokens []token) (analyzedBound, bool) {
+	var out analyzedBound
+	var literal token
+	switch {
+	case len(tokens) == 1:
+		literal = tokens[0]
+	case len(tokens) == 2 && tokens[0].is("timestamptz"):
+		literal, out.style, out.typeName = tokens[1], boundTyped, "timestamptz"
+	case len(tokens) == 3 && tokens[1].isOp("::") && tokens[2].is("timestamptz"):
+		literal, out.style, out.typeName = tokens[0], boundCast, "timestamptz"
+	default:
+		return evaluateToTimestamp(tokens)
+	}
+	s, ok := literal.stringValue()
+	if !ok {
+		return analyzedBound{}, false
+	}
+	out.value, ok = parseUTCTime(s)
+	return out, ok
+}
+
+// evaluateToTimestamp evaluates to_timestamp(<seconds>), in which the seconds
+// may have up to six decimal places
+func evaluateToTimestamp(tokens []token) (analyzedBound, bool) {
+	name, args, ok := functionCall(tokens)
+	if !ok || name != "to_timestamp" || len(args) != 1 || len(args[0]) != 1 ||
+		args[0][0].kind != tokenNumber {
+		return analyzedBound{}, false
+	}
+	secs, frac, _ := strings.Cut(args[0][0].text, ".")
+	if len(frac) > 6 {
+		return analyzedBound{}, false
+	}
+	s, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return analyzedBound{}, false
+	}
+	var us int64
+	if frac != "" {
+		if us, err = strconv.ParseInt(frac+strings.Repeat("0", 6-len(frac)), 10, 64); err != nil {
+			return analyzedBound{}, false
+		}
+	}
+	return analyzedBound{
+		value: time.Unix(s, us*int64(time.Microsecond)), style: boundUnixSeconds,
+	}, true
+}
+
+var utcLayouts = []string{
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999Z07",
+}
+
+// parseUTCTime parses a timestamp that has an explicit UTC offset of zero and
+// at most microsecond precision
+func parseUTCTime(s string) (time.Time, bool) {
+	for _, layout := range utcLayouts {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+		if _, offset := t.Zone(); offset != 0 || t.Nanosecond()%1000 != 0 {
+			return time.Time{}, false
+		}
+		return t.UTC(), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+// renderer renders a statement for an extent. It holds an immutable copy of
+// the statement's tokens, with each time bound's expression replaced by a
+// slot token, so rendering never modifies or re-parses the statement, and user
+// literals resembling placeholders can never be mistaken for a bound.
+type renderer struct {
+	tokens []token
+	bounds []rendererBound
+}
+
+// rendererBound describes how a slot is rendered: the value of the extent
+// endpoint, plus the offset, in the bound's style
+type rendererBound struct {
+	endpoint endpoint
+	style    boundStyle
+	typeName string
+	offset   time.Duration
+}
+
+// RenderExtent renders the statement for the extent, whose Start and End are
+// the first and final included buckets
+func (r *renderer) RenderExtent(extent timeseries.Extent) (string, error) {
+	return join(r.tokens, func(t token) string {
+		if t.kind != tokenSlot {
+			return t.text
+		}
+		b := r.bounds[t.slot]
+		value := extent.Start
+		if b.endpoint == endpointUpper {
+			value = extent.End
+		}
+		return formatBound(value.Add(b.offset), b.style, b.typeName)
+	}), nil
+}
+
+func formatBound(value time.Time, style boundStyle, typeName string) string {
+	switch style {
+	case boundNumber:
+		return strconv.FormatInt(value.Unix(), 10)
+	case boundUnixSeconds:
+		return "to_timestamp(" + formatSeconds(value) + ")"
+	}
+	literal := "'" + value.UTC().Format(time.RFC3339Nano) + "'"
+	switch style {
+	case boundTyped:
+		return typeName + " " + literal
+	case boundCast:
+		return literal + "::" + typeName
+	}
+	return literal
+}
+
+// formatSeconds formats the Unix epoch seconds of the value, with a fraction
+// of microseconds when the value is not a whole second
+func formatSeconds(value time.Time) string {
+	us := value.UnixMicro()
+	if us%1e6 == 0 {
+		return strconv.FormatInt(us/1e6, 10)
+	}
+	var sign string
+	if us < 0 {
+		sign, us = "-", -us
+	}
+	frac := strconv.FormatInt(us%1e6+1e6, 10)[1:]
+	return sign + strconv.FormatInt(us/1e6, 10) + "." + frac
+}
+
+func placeholderFor(target endpoint) string {
+	if target == endpointLower {
+		return "<$TS1$>"
+	}
+	return "<$TS2$>"
+}
+
+// slotOffset returns the offset from the extent endpoint at which a bound is
+// rendered, so the rendered comparison includes exactly the buckets of the
+// extent. Extents are inclusive of their End bucket, so an exclusive upper
+// bound is rendered one step after the End, and an inclusive upper bound on
+// the raw time column one microsecond before that.
+func slotOffset(b analyzedBound, target endpoint, step time.Duration) time.Duration {
+	switch {
+	case target == endpointLower && b.onBucket && !b.inclusive:
+		return -step
+	case target == endpointLower:
+		return 0
+	case b.onBucket && b.inclusive:
+		return 0
+	case b.inclusive:
+		return step - time.Microsecond
+	}
+	return step
+}
+
+// buildQueryArtifacts returns the canonical statement, in which the time
+// bounds are replaced by fixed placeholders, and the statement's renderer.
+// When the statement has no upper bound, one is added to the WHERE clause.
+func buildQueryArtifacts(q *query, ranges rangeAnalysis, bucket bucketSpec) (string, *renderer) {
+	r := &renderer{
+		tokens: make([]token, 0, len(q.tokens)+len(bucket.columnTokens)+3),
+		bounds: make([]rendererBound, 0, 2),
+	}
+	addSlot := func(b analyzedBound, target endpoint, depth int) {
+		r.tokens = append(r.tokens, token{kind: tokenSlot, slot: len(r.bounds), depth: depth})
+		r.bounds = append(r.bounds, rendererBound{
+			endpoint: target, style: b.style, typeName: b.typeName,
+			offset: slotOffset(b, target, bucket.step),
+		})
+	}
+	lower, upper := ranges.lowerExpr, ranges.upperExpr
+	for i := 0; i <= len(q.tokens); i++ {
+		if i == ranges.whereEnd && upper == nil {
+			depth := q.tokens[ranges.whereEnd-1].depth
+			r.tokens = append(r.tokens, token{kind: tokenWord, text: "AND", depth: depth})
+			for j, t := range bucket.columnTokens {
+				t.depth = depth
+				t.adjacent = j > 0
+				r.tokens = append(r.tokens, t)
+			}
+			r.tokens = append(r.tokens, token{kind: tokenOperator, text: "<", depth: depth})
+			appended := analyzedBound{style: boundString}
+			switch {
+			case bucket.epochColumn:
+				appended.style = boundNumber
+			case !lower.onBucket:
+				appended.style, appended.typeName = lower.style, lower.typeName
+			}
+			addSlot(appended, endpointUpper, depth)
+		}
+		if i == len(q.tokens) {
+			break
+		}
+		switch {
+		case i == lower.start:
+			addSlot(lower, endpointLower, q.tokens[i].depth)
+			i = lower.end - 1
+		case upper != nil && i == upper.start:
+			addSlot(*upper, endpointUpper, q.tokens[i].depth)
+			i = upper.end - 1
+		default:
+			r.tokens = append(r.tokens, q.tokens[i])
+		}
+	}
+	canonical := join(r.tokens, func(t token) string {
+		if t.kind == tokenSlot {
+			return placeholderFor(r.bounds[t.slot].endpoint)
+		}
+		return t.normalized()
+	})
+	return canonical, r
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
+	po "github.com/trickstercache/trickster/v2/pkg/proxy/paths/options"
+)
+
+func TestRegisterHandlers(t *testing.T) {
+	c, err := NewClient("test", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Error(err)
+	}
+	c.RegisterHandlers(nil)
+	for _, name := range []string{"health", "query", providers.Proxy} {
+		if _, ok := c.Handlers()[name]; !ok {
+			t.Errorf("expected to find handler named: %s", name)
+		}
+	}
+}
+
+func TestDefaultPathConfigs(t *testing.T) {
+	c, err := NewClient("test", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := c.DefaultPathConfigs(nil)
+	const expectedLen = 2
+	if len(paths) != expectedLen {
+		t.Errorf("expected %d got %d", expectedLen, len(paths))
+	}
+	i := slices.IndexFunc([]*po.Options(paths), func(p *po.Options) bool {
+		return p.Path == PathSQL
+	})
+	if i < 0 {
+		t.Fatalf("expected to find path named: %s", PathSQL)
+	}
+	if paths[i].HandlerName != "query" ||
+		!slices.Contains(paths[i].CacheKeyFormFields, ParamQuery) ||
+		!slices.Contains(paths[i].CacheKeyHeaders, HeaderConnectionString) {
+		t.Errorf("unexpected path config for %s: %+v", PathSQL, paths[i])
+	}
+}
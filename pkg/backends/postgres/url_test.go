@@ -0,0 +1,61 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func TestSetExtent(t *testing.T) {
+	const body = `{"query":"SELECT time_bucket('1 minute', ts) AS t, count(*) FROM events ` +
+		`WHERE ts >= TIMESTAMPTZ '2023-11-14T19:30:00Z' GROUP BY t","params":null,"arrayMode":true}`
+	client := &Client{}
+	trq, _, _, err := client.ParseTimeRangeQuery(newTestRequest(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := newTestRequest(body)
+	extent := &timeseries.Extent{Start: time.Unix(1699990260, 0), End: time.Unix(1699990320, 0)}
+	if err := client.SetExtent(r, trq, extent); err != nil {
+		t.Fatal(err)
+	}
+	b, err := request.GetBody(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["arrayMode"] != true {
+		t.Errorf("request document was not preserved: %s", b)
+	}
+	rendered, _ := doc[ParamQuery].(string)
+	if !strings.Contains(rendered, "ts >= timestamptz '2023-11-14T19:31:00Z' "+
+		"AND ts < timestamptz '2023-11-14T19:33:00Z'") {
+		t.Errorf("extent was not rendered: %s", rendered)
+	}
+	if r.ContentLength != int64(len(b)) {
+		t.Errorf("expected content length %d got %d", len(b), r.ContentLength)
+	}
+}
@@ -85,12 +85,13 @@ type WFResultScalar []any
 // NewModeler returns a collection of modeling functions for prometheus interoperability
 func NewModeler() *timeseries.Modeler {
 	return &timeseries.Modeler{
-		WireUnmarshalerReader: UnmarshalTimeseriesReader,
-		WireMarshaler:         MarshalTimeseries,
-		WireMarshalWriter:     MarshalTimeseriesWriter,
-		WireUnmarshaler:       UnmarshalTimeseries,
-		CacheMarshaler:        dataset.MarshalDataSet,
-		CacheUnmarshaler:      dataset.UnmarshalDataSet,
+		WireUnmarshalerReader:  UnmarshalTimeseriesReader,
+		WireMarshaler:          MarshalTimeseries,
+		WireMarshalWriter:      MarshalTimeseriesWriter,
+		WireUnmarshaler:        UnmarshalTimeseries,
+		CacheMarshaler:         dataset.MarshalDataSet,
+		ColumnarCacheMarshaler: dataset.MarshalDataSetColumnar,
+		CacheUnmarshaler:       dataset.UnmarshalDataSet,
 	}
 }
 
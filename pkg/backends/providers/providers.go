@@ -40,6 +40,14 @@ const (
 	InfluxDBID
 	// ClickHouse represents the ClickHouse backend provider
 	ClickHouseID
+	// Loki represents the Grafana Loki backend provider
+	LokiID
+	// Graphite represents the Graphite backend provider
+	GraphiteID
+	// MySQL represents the MySQL backend provider
+	MySQLID
+	// Postgres represents the PostgreSQL backend provider
+	PostgresID
 
 	Backends = "backends"
 
@@ -55,6 +63,10 @@ const (
 	Prometheus = "prometheus"
 	ClickHouse = "clickhouse"
 	InfluxDB   = "influxdb"
+	Loki       = "loki"
+	Graphite   = "graphite"
+	MySQL      = "mysql"
+	Postgres   = "postgres"
 )
 
 // Names is a map of Providers keyed by string name
@@ -66,6 +78,10 @@ var Names = map[string]Provider{
 	Prometheus:             PrometheusID,
 	InfluxDB:               InfluxDBID,
 	ClickHouse:             ClickHouseID,
+	Loki:                   LokiID,
+	Graphite:               GraphiteID,
+	MySQL:                  MySQLID,
+	Postgres:               PostgresID,
 	Proxy:                  RPID,
 	ReverseProxy:           RPID,
 	ReverseProxyShort:      RPID,
@@ -88,6 +104,10 @@ var supportedTimeSeries = map[string]Provider{
 	Prometheus: PrometheusID,
 	InfluxDB:   InfluxDBID,
 	ClickHouse: ClickHouseID,
+	Loki:       LokiID,
+	Graphite:   GraphiteID,
+	MySQL:      MySQLID,
+	Postgres:   PostgresID,
 }
 
 // IsSupportedTimeSeriesProvider returns true if the provided time series is supported by Trickster
@@ -98,6 +118,7 @@ func IsSupportedTimeSeriesProvider(name string) bool {
 
 var supportedTimeSeriesMerge = map[string]Provider{
 	Prometheus: PrometheusID,
+	Graphite:   GraphiteID,
 }
 
 // IsSupportedTimeSeriesMergeProvider returns true if the provided time series is
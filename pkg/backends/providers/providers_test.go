@@ -49,6 +49,10 @@ func TestIsValidProvider(t *testing.T) {
 		{"", false},
 		{"invalid", false},
 		{InfluxDB, true},
+		{Loki, true},
+		{Graphite, true},
+		{MySQL, true},
+		{Postgres, true},
 	}
 
 	for i, test := range tests {
@@ -74,3 +78,23 @@ func TestIsSupportedTimeSeriesProvider(t *testing.T) {
 		t.Error("expected true")
 	}
 }
+
+func TestIsSupportedTimeSeriesMergeProvider(t *testing.T) {
+	tests := []struct {
+		o        string
+		expected bool
+	}{
+		{Prometheus, true},
+		{Graphite, true},
+		{InfluxDB, false},
+		{"invalid", false},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			res := IsSupportedTimeSeriesMergeProvider(test.o)
+			if test.expected != res {
+				t.Errorf("expected %t got %t", test.expected, res)
+			}
+		})
+	}
+}
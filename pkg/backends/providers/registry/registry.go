@@ -19,7 +19,11 @@ package registry
 import (
 	"github.com/trickstercache/trickster/v2/pkg/backends/alb"
 	"github.com/trickstercache/trickster/v2/pkg/backends/clickhouse"
+	"github.com/trickstercache/trickster/v2/pkg/backends/graphite"
 	"github.com/trickstercache/trickster/v2/pkg/backends/influxdb"
+	"github.com/trickstercache/trickster/v2/pkg/backends/loki"
+	"github.com/trickstercache/trickster/v2/pkg/backends/mysql"
+	"github.com/trickstercache/trickster/v2/pkg/backends/postgres"
 	"github.com/trickstercache/trickster/v2/pkg/backends/prometheus"
 	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
 	"github.com/trickstercache/trickster/v2/pkg/backends/providers/registry/types"
@@ -32,7 +36,11 @@ func SupportedProviders() types.Lookup {
 	return types.Lookup{
 		providers.ALB:                    alb.NewClient,
 		providers.ClickHouse:             clickhouse.NewClient,
+		providers.Graphite:               graphite.NewClient,
 		providers.InfluxDB:               influxdb.NewClient,
+		providers.Loki:                   loki.NewClient,
+		providers.MySQL:                  mysql.NewClient,
+		providers.Postgres:               postgres.NewClient,
 		providers.Prometheus:             prometheus.NewClient,
 		providers.Rule:                   rule.NewClient,
 		providers.Proxy:                  reverseproxy.NewClient,
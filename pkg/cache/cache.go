@@ -26,8 +26,17 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/cache/status"
 )
 
-// ErrKNF represents the error "key not found in cache"
-var ErrKNF = errors.New("key not found in cache")
+var (
+	// ErrKNF represents the error "key not found in cache"
+	ErrKNF = errors.New("key not found in cache")
+	// ErrLeasesUnsupported is returned when a lease is requested from a cache
+	// whose client does not support leases
+	ErrLeasesUnsupported = errors.New("cache does not support leases")
+	// ErrTagIndexUnavailable is returned when tagged keys are requested from a
+	// cache whose tag index is empty and is not persisted, such as after a
+	// restart, so it can't be known which objects have the tags
+	ErrTagIndexUnavailable = errors.New("cache tag index is empty and not persisted")
+)
 
 // Cache is the interface for the supported caching fabrics
 // When making new cache providers, Retrieve() must return an error on cache miss
@@ -56,6 +65,29 @@ type ReferenceObject interface {
 	Size() int
 }
 
+// ReferenceMarshaler is an optional interface for a ReferenceObject that can
+// serialize itself, so that it can also be written to a cache that does not
+// store references (e.g., a lower tier of a Tiered cache). The output must be
+// readable by the same code that reads values written with Store.
+type ReferenceMarshaler interface {
+	ReferenceObject
+	MarshalReference() ([]byte, error)
+}
+
+// Tagger is an optional interface for a Cache that can attach tags to cache
+// keys, so that every key having a tag can be purged in a single operation
+type Tagger interface {
+	// Tag attaches the tags to the cache key for the duration of the ttl
+	Tag(cacheKey string, ttl time.Duration, tags ...string)
+	// TaggedKeys returns the cache keys that have all of the provided tags and
+	// start with prefix
+	TaggedKeys(tags []string, prefix string) ([]string, error)
+	// PurgeTagged removes every cache key that has all of the provided tags and
+	// starts with prefix, along with the chunks of those keys, and returns the
+	// number of keys that were removed
+	PurgeTagged(tags []string, prefix string) (int, error)
+}
+
 // Client is an interface that defines the methods required for a cache client
 // to be used by cache.Cache implementations
 type Client interface {
@@ -65,3 +97,72 @@ type Client interface {
 	Remove(cacheKeys ...string) error
 	Close() error
 }
+
+// MultiClient is an optional interface for a Client that can store and
+// retrieve multiple cache keys in a single round trip
+type MultiClient interface {
+	// MultiStore stores each value in data under its cache key for the ttl
+	MultiStore(data map[string][]byte, ttl time.Duration) error
+	// MultiRetrieve returns the values of the cache keys that were found.
+	// Cache keys that were not found are omitted from the returned map.
+	MultiRetrieve(cacheKeys ...string) (map[string][]byte, error)
+}
+
+// Leaser is an optional interface for a Client that can grant exclusive,
+// expiring leases on cache keys, which are shared by every Trickster instance
+// using the cache, so that only one instance fetches a missing object
+type Leaser interface {
+	// AcquireLease attempts to take the lease on the cache key for the ttl. It
+	// returns the lease's token and true when the lease was acquired, and false
+	// when the lease is held by another caller.
+	AcquireLease(cacheKey string, ttl time.Duration) (string, bool, error)
+	// ReleaseLease releases the lease on the cache key, if it is still held
+	// with the token
+	ReleaseLease(cacheKey, token string) error
+}
+
+// MultiCache is an optional interface for a Cache that provides the batch
+// operations of a MultiClient
+type MultiCache interface {
+	MultiClient
+	// SupportsMulti returns true if the underlying Client performs the batch
+	// operations natively, rather than one cache key at a time
+	SupportsMulti() bool
+}
+
+// MultiStore stores the data with the Client's MultiStore when it is a
+// MultiClient, and otherwise stores each value individually
+func MultiStore(c Client, data map[string][]byte, ttl time.Duration) error {
+	if mc, ok := c.(MultiClient); ok {
+		return mc.MultiStore(data, ttl)
+	}
+	for k, v := range data {
+		if err := c.Store(k, v, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MultiRetrieve retrieves the cache keys with the Client's MultiRetrieve when
+// it is a MultiClient, and otherwise retrieves each cache key individually.
+// Cache keys that were not found are omitted from the returned map.
+func MultiRetrieve(c Client, cacheKeys ...string) (map[string][]byte, error) {
+	if mc, ok := c.(MultiClient); ok {
+		return mc.MultiRetrieve(cacheKeys...)
+	}
+	out := make(map[string][]byte, len(cacheKeys))
+	for _, k := range cacheKeys {
+		b, s, err := c.Retrieve(k)
+		if errors.Is(err, ErrKNF) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if s == status.LookupStatusHit {
+			out[k] = b
+		}
+	}
+	return out, nil
+}
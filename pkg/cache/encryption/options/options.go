@@ -0,0 +1,152 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/trickstercache/trickster/v2/pkg/config/types"
+)
+
+// MaxKeyIDLength is the maximum length of a key ID, which is embedded in each
+// encrypted value
+const MaxKeyIDLength = 255
+
+var (
+	// ErrNoActiveKey is returned when 'active_key_id' does not name a configured key
+	ErrNoActiveKey = errors.New("'active_key_id' must name a configured key")
+	// ErrInvalidKeyID is returned when a key ID is empty or too long
+	ErrInvalidKeyID = fmt.Errorf("key ids must be 1 to %d characters", MaxKeyIDLength)
+	// ErrInvalidKeySource is returned when a key does not provide exactly one
+	// of 'key' or 'key_file'
+	ErrInvalidKeySource = errors.New("each key requires exactly one of 'key' or 'key_file'")
+	// ErrInvalidKeySize is returned when a decoded key is not 16, 24 or 32 bytes
+	ErrInvalidKeySize = errors.New("keys must be base64-encoded 16, 24 or 32-byte values")
+)
+
+// Options is a collection of Configurations for encrypting cache values at rest
+type Options struct {
+	// ActiveKeyID is the ID of the key used to encrypt newly stored values
+	ActiveKeyID string `yaml:"active_key_id,omitempty"`
+	// Keys maps each key ID to its key. Values encrypted with any listed key can
+	// be decrypted, so a retired key should remain listed until the values it
+	// encrypted have expired from the cache
+	Keys map[string]*KeyOptions `yaml:"keys,omitempty"`
+
+	//  Synthetic Values
+
+	// KeyMaterial maps each key ID to its decoded key, and is populated by Validate
+	KeyMaterial map[string][]byte `yaml:"-"`
+}
+
+// KeyOptions provides the source of an encryption key
+type KeyOptions struct {
+	// Key is the base64-encoded 16, 24 or 32-byte AES key
+	Key types.EnvString `yaml:"key,omitempty"`
+	// KeyFile is the path to a file holding the base64-encoded key
+	KeyFile string `yaml:"key_file,omitempty"`
+}
+
+// New returns a new Encryption Options Reference with default values set
+func New() *Options {
+	return &Options{Keys: make(map[string]*KeyOptions)}
+}
+
+// Clone returns an exact copy of the Options
+func (o *Options) Clone() *Options {
+	if o == nil {
+		return nil
+	}
+	out := &Options{ActiveKeyID: o.ActiveKeyID}
+	if o.Keys != nil {
+		out.Keys = make(map[string]*KeyOptions, len(o.Keys))
+		for id, k := range o.Keys {
+			if k != nil {
+				kc := *k
+				k = &kc
+			}
+			out.Keys[id] = k
+		}
+	}
+	if o.KeyMaterial != nil {
+		out.KeyMaterial = maps.Clone(o.KeyMaterial)
+	}
+	return out
+}
+
+// Equal returns true if all values in the Options are identical
+func (o *Options) Equal(o2 *Options) bool {
+	if o == nil || o2 == nil {
+		return o == o2
+	}
+	return o.ActiveKeyID == o2.ActiveKeyID &&
+		maps.EqualFunc(o.Keys, o2.Keys, func(k1, k2 *KeyOptions) bool {
+			if k1 == nil || k2 == nil {
+				return k1 == k2
+			}
+			return *k1 == *k2
+		})
+}
+
+// Validate returns an error if the Options are invalid, and otherwise loads
+// and decodes each key into KeyMaterial
+func (o *Options) Validate() error {
+	if _, ok := o.Keys[o.ActiveKeyID]; !ok {
+		return ErrNoActiveKey
+	}
+	km := make(map[string][]byte, len(o.Keys))
+	for id, k := range o.Keys {
+		if id == "" || len(id) > MaxKeyIDLength {
+			return ErrInvalidKeyID
+		}
+		b, err := k.load()
+		if err != nil {
+			return fmt.Errorf("encryption key %s: %w", id, err)
+		}
+		km[id] = b
+	}
+	o.KeyMaterial = km
+	return nil
+}
+
+func (k *KeyOptions) load() ([]byte, error) {
+	if k == nil || (k.Key == "") == (k.KeyFile == "") {
+		return nil, ErrInvalidKeySource
+	}
+	s := string(k.Key)
+	if k.KeyFile != "" {
+		b, err := os.ReadFile(k.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		s = string(b)
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, ErrInvalidKeySize
+	}
+	switch len(b) {
+	case 16, 24, 32:
+		return b, nil
+	}
+	return nil, ErrInvalidKeySize
+}
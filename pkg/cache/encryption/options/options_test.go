@@ -0,0 +1,70 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/config/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(make([]byte, 32))
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(key+"\n"), 0o600))
+
+	o := New()
+	require.ErrorIs(t, o.Validate(), ErrNoActiveKey)
+
+	o.ActiveKeyID = "k1"
+	o.Keys["k1"] = &KeyOptions{Key: types.EnvString(key)}
+	o.Keys["k2"] = &KeyOptions{KeyFile: keyFile}
+	require.NoError(t, o.Validate())
+	require.Len(t, o.KeyMaterial, 2)
+	require.Equal(t, o.KeyMaterial["k1"], o.KeyMaterial["k2"])
+
+	o.Keys["k3"] = &KeyOptions{Key: types.EnvString(key), KeyFile: keyFile}
+	require.ErrorIs(t, o.Validate(), ErrInvalidKeySource)
+
+	o.Keys["k3"] = &KeyOptions{Key: "c2hvcnQ="}
+	require.ErrorIs(t, o.Validate(), ErrInvalidKeySize)
+
+	delete(o.Keys, "k3")
+	o.Keys[""] = &KeyOptions{Key: types.EnvString(key)}
+	require.ErrorIs(t, o.Validate(), ErrInvalidKeyID)
+}
+
+func TestCloneEqual(t *testing.T) {
+	o := New()
+	o.ActiveKeyID = "k1"
+	o.Keys["k1"] = &KeyOptions{KeyFile: "/tmp/key"}
+	o2 := o.Clone()
+	require.True(t, o.Equal(o2))
+	o2.Keys["k1"].KeyFile = "/tmp/other"
+	require.False(t, o.Equal(o2))
+	require.Equal(t, "/tmp/key", o.Keys["k1"].KeyFile)
+
+	var n *Options
+	require.Nil(t, n.Clone())
+	require.True(t, n.Equal(nil))
+	require.False(t, n.Equal(o))
+}
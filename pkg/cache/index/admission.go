@@ -0,0 +1,77 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index
+
+import (
+	"sync/atomic"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache/index/options"
+	"github.com/trickstercache/trickster/v2/pkg/util/sketch"
+)
+
+// AdmissionFilter decides whether the Index stores a new object in the cache
+type AdmissionFilter interface {
+	// Name returns the name of the filter
+	Name() string
+	// Record records a request for the key
+	Record(key string)
+	// Admit returns true if a new object with the key should be stored
+	Admit(key string) bool
+	// Evicted records the objects evicted to keep the cache within its size
+	// limits during a reap cycle, which are nil when no objects were evicted
+	Evicted(objects []*Object)
+}
+
+// NewAdmissionFilter returns the AdmissionFilter with the provided name, or
+// nil if all objects are to be admitted
+func NewAdmissionFilter(name string) AdmissionFilter {
+	if name == options.AdmissionFilterTinyLFU {
+		return &tinyLFUFilter{sketch: sketch.NewCountMin(sketch.DefaultWidth, 0)}
+	}
+	return nil
+}
+
+// tinyLFUFilter estimates the request frequency of keys with a count-min sketch.
+// While the cache is evicting objects to stay within its size limits, a new
+// object is admitted only when its key has been requested more frequently than
+// the key of the last object evicted.
+type tinyLFUFilter struct {
+	sketch    *sketch.CountMin
+	evicting  atomic.Bool
+	threshold atomic.Int64
+}
+
+func (f *tinyLFUFilter) Name() string {
+	return options.AdmissionFilterTinyLFU
+}
+
+func (f *tinyLFUFilter) Record(key string) {
+	f.sketch.Increment(key)
+}
+
+func (f *tinyLFUFilter) Admit(key string) bool {
+	return !f.evicting.Load() || int64(f.sketch.Estimate(key)) > f.threshold.Load()
+}
+
+func (f *tinyLFUFilter) Evicted(objects []*Object) {
+	if len(objects) == 0 {
+		f.evicting.Store(false)
+		return
+	}
+	f.threshold.Store(int64(f.sketch.Estimate(objects[len(objects)-1].Key)))
+	f.evicting.Store(true)
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache/index/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTinyLFUFilter(t *testing.T) {
+	require.Nil(t, NewAdmissionFilter(options.AdmissionFilterNone))
+	f := NewAdmissionFilter(options.AdmissionFilterTinyLFU)
+	require.Equal(t, options.AdmissionFilterTinyLFU, f.Name())
+
+	// all objects are admitted while the cache is not evicting
+	require.True(t, f.Admit("new"))
+
+	f.Record("victim")
+	f.Record("frequent")
+	f.Record("frequent")
+	f.Evicted([]*Object{{Key: "victim"}})
+	require.False(t, f.Admit("new"))
+	require.False(t, f.Admit("victim"))
+	require.True(t, f.Admit("frequent"))
+
+	// a key requested once is admitted over a victim that was never requested
+	f.Record("once")
+	f.Evicted([]*Object{{Key: "unrequested"}})
+	require.True(t, f.Admit("once"))
+	require.False(t, f.Admit("new"))
+
+	f.Evicted(nil)
+	require.True(t, f.Admit("new"))
+}
+
+func TestIndexedClientAdmission(t *testing.T) {
+	mc := newMapClient()
+	o := defaultIndexOpts()
+	o.AdmissionFilter = options.AdmissionFilterTinyLFU
+	o.MaxSizeObjects = 2
+	o.MaxSizeBackoffObjects = 0
+	ic := NewIndexedClient("test", "map", o, mc)
+	t.Cleanup(func() { _ = ic.Close() })
+	require.NotNil(t, ic.loadEviction().filter)
+
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, ic.Store(key, []byte("value"), time.Hour))
+	}
+	ic.reap()
+	require.Equal(t, int64(2), ic.ObjectCount)
+
+	// a one-off object is not stored while the cache is evicting
+	require.NoError(t, ic.Store("one-off", []byte("value"), time.Hour))
+	_, ok := ic.Objects.Load("one-off")
+	require.False(t, ok)
+
+	// an object that has been requested repeatedly is stored
+	for range 3 {
+		_, s, _ := ic.Retrieve("popular")
+		require.Equal(t, status.LookupStatusKeyMiss, s)
+	}
+	require.NoError(t, ic.Store("popular", []byte("value"), time.Hour))
+	_, ok = ic.Objects.Load("popular")
+	require.True(t, ok)
+
+	// existing objects can always be updated
+	require.NoError(t, ic.Store("c", []byte("value2"), time.Hour))
+}
@@ -19,6 +19,8 @@ package index
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -67,6 +69,7 @@ func NewIndexedClient(
 	ctx, cancel := context.WithCancel(context.Background())
 	idx := &IndexedClient{
 		Client:        client,
+		TagIndex:      NewTagIndex(),
 		name:          cacheName,
 		cacheProvider: cacheProvider,
 		cancel:        cancel,
@@ -96,6 +99,9 @@ func NewIndexedClient(
 					logging.Pairs{"cacheName": cacheName, "bytes": len(b), "max": maxIndexBytes})
 			} else {
 				idx.UnmarshalMsg(b)
+				if idx.TagIndex == nil {
+					idx.TagIndex = NewTagIndex()
+				}
 				if time.Since(idx.LastFlush.Load()) > time.Duration(indexExpiry) {
 					idx.Clear()
 				}
@@ -110,6 +116,9 @@ func NewIndexedClient(
 		}
 	}
 
+	idx.setPartitions(o)
+	idx.setEviction(o)
+
 	if o.ReapInterval > 0 {
 		idx.wg.Add(1)
 		go idx.reaper(ctx)
@@ -136,13 +145,17 @@ type IndexedClient struct {
 	Objects SyncObjects `msg:"objects"`
 	// Time the index was last flushed
 	LastFlush atomicx.Time `msg:"LastFlush,extension"`
+	// TagIndex maps the tags attached to objects in the Cache to their keys
+	TagIndex *TagIndex `msg:"tags"`
 
 	// internal index configuration
-	name          string               `msg:"-"`
-	cacheProvider string               `msg:"-"`
-	options       atomic.Value         `msg:"-"`
-	ico           IndexedClientOptions `msg:"-"`
-	lastWrite     atomicx.Time         `msg:"-"`
+	name          string                     `msg:"-"`
+	cacheProvider string                     `msg:"-"`
+	options       atomic.Value               `msg:"-"`
+	partitions    atomic.Pointer[partitions] `msg:"-"`
+	eviction      atomic.Pointer[eviction]   `msg:"-"`
+	ico           IndexedClientOptions       `msg:"-"`
+	lastWrite     atomicx.Time               `msg:"-"`
 	isClosing     atomic.Bool
 	cancel        context.CancelFunc
 	wg            sync.WaitGroup
@@ -159,13 +172,32 @@ type IndexedClient struct {
 // Clear the index from its currently tracked cache objects
 func (idx *IndexedClient) Clear() {
 	idx.Objects.Clear()
+	idx.TagIndex.Clear()
 	atomic.StoreInt64(&idx.CacheSize, 0)
 	atomic.StoreInt64(&idx.ObjectCount, 0)
+	idx.clearPartitions()
+}
+
+// Select returns the metadata of each object in the index whose key starts
+// with prefix, sorted by key. The returned Objects are copies and do not
+// include the object values.
+func (idx *IndexedClient) Select(prefix string) []*Object {
+	out := make([]*Object, 0)
+	idx.Objects.Range(func(k, v any) bool {
+		if key := k.(string); strings.HasPrefix(key, prefix) {
+			out = append(out, v.(*Object).metadata())
+		}
+		return true
+	})
+	slices.SortFunc(out, func(a, b *Object) int { return strings.Compare(a.Key, b.Key) })
+	return out
 }
 
 // UpdateOptions updates the existing IndexedClient with a new Options reference
 func (idx *IndexedClient) UpdateOptions(o *options.Options) {
 	idx.options.Store(o)
+	idx.setPartitions(o)
+	idx.setEviction(o)
 }
 
 // No-op -- implements the cache.Client interface
@@ -191,15 +223,41 @@ func (idx *IndexedClient) updateIndex(cacheKey string, size int64, la, lw, e tim
 		oldObj := o.(*Object)
 		cacheSize = atomic.AddInt64(&idx.CacheSize, obj.Size-oldObj.Size)
 		count = atomic.LoadInt64(&idx.ObjectCount)
+		obj.hits.Store(oldObj.hits.Load())
+		obj.segment.Store(oldObj.segment.Load())
+		idx.updatePartition(cacheKey, obj.Size-oldObj.Size, 0)
 	} else {
 		cacheSize = atomic.AddInt64(&idx.CacheSize, obj.Size)
 		count = atomic.AddInt64(&idx.ObjectCount, 1)
+		idx.updatePartition(cacheKey, obj.Size, 1)
 	}
 	metrics.ObserveCacheSizeChange(idx.name, idx.cacheProvider, cacheSize, count)
+	idx.loadEviction().policy.Touch(obj)
 	idx.lastWrite.Store(time.Now())
 	idx.Objects.Store(cacheKey, obj)
 }
 
+// admit returns true if the object should be stored. Objects that are already
+// in the index are always admitted.
+func (idx *IndexedClient) admit(cacheKey string) bool {
+	f := idx.loadEviction().filter
+	if f == nil {
+		return true
+	}
+	if _, ok := idx.Objects.Load(cacheKey); ok || f.Admit(cacheKey) {
+		return true
+	}
+	metrics.ObserveCacheEvent(idx.name, idx.cacheProvider, "admission", "rejected")
+	return false
+}
+
+// record records a request for the object with the admission filter
+func (idx *IndexedClient) record(cacheKey string) {
+	if f := idx.loadEviction().filter; f != nil {
+		f.Record(cacheKey)
+	}
+}
+
 func (idx *IndexedClient) StoreReference(cacheKey string, data cache.ReferenceObject, ttl time.Duration) error {
 	if cacheKey == IndexKey {
 		return ErrIndexInvalidCacheKey
@@ -208,6 +266,9 @@ func (idx *IndexedClient) StoreReference(cacheKey string, data cache.ReferenceOb
 	if !ok {
 		return ErrInvalidCacheBackend
 	}
+	if !idx.admit(cacheKey) {
+		return nil
+	}
 	if err := mc.StoreReference(cacheKey, data, ttl); err != nil {
 		return err
 	}
@@ -224,6 +285,9 @@ func (idx *IndexedClient) Store(cacheKey string, byteData []byte, ttl time.Durat
 	if cacheKey == IndexKey {
 		return ErrIndexInvalidCacheKey
 	}
+	if !idx.admit(cacheKey) {
+		return nil
+	}
 	// wrap input value with Object + timing/size information
 	obj := &Object{
 		Key:   cacheKey,
@@ -258,6 +322,7 @@ func (idx *IndexedClient) updateAccessTime(cacheKey string) {
 	obj := o.(*Object)
 	now := time.Now()
 	obj.LastAccess.Store(now)
+	idx.loadEviction().policy.Touch(obj)
 }
 
 func (idx *IndexedClient) RetrieveReference(cacheKey string) (any, status.LookupStatus, error) {
@@ -268,6 +333,7 @@ func (idx *IndexedClient) RetrieveReference(cacheKey string) (any, status.Lookup
 	if !ok {
 		return nil, status.LookupStatusError, ErrInvalidCacheBackend
 	}
+	idx.record(cacheKey)
 	idx.updateAccessTime(cacheKey)
 	return mc.RetrieveReference(cacheKey)
 }
@@ -277,6 +343,7 @@ func (idx *IndexedClient) Retrieve(cacheKey string) ([]byte, status.LookupStatus
 	if cacheKey == IndexKey {
 		return nil, status.LookupStatusError, ErrIndexInvalidCacheKey
 	}
+	idx.record(cacheKey)
 	data, s, err := idx.Client.Retrieve(cacheKey)
 	if err != nil {
 		return nil, s, err
@@ -302,9 +369,11 @@ func (idx *IndexedClient) Remove(cacheKeys ...string) error {
 			count := atomic.AddInt64(&idx.ObjectCount, -1)
 			metrics.ObserveCacheOperation(idx.name, idx.cacheProvider, "del", "none", float64(obj.Size))
 			idx.Objects.Delete(key)
+			idx.updatePartition(key, -obj.Size, -1)
 			metrics.ObserveCacheSizeChange(idx.name, idx.cacheProvider, size, count)
 		}
 	}
+	idx.TagIndex.Remove(cacheKeys...)
 	idx.lastWrite.Store(time.Now())
 	return idx.Client.Remove(cacheKeys...)
 }
@@ -371,6 +440,7 @@ func (idx *IndexedClient) clone() *IndexedClient {
 	clone := &IndexedClient{
 		CacheSize:   atomic.LoadInt64(&idx.CacheSize),
 		ObjectCount: atomic.LoadInt64(&idx.ObjectCount),
+		TagIndex:    idx.TagIndex,
 	}
 	clone.LastFlush.Store(idx.LastFlush.Load())
 	idx.Objects.Range(func(key, value any) bool {
@@ -418,7 +488,8 @@ func (idx *IndexedClient) reaper(ctx context.Context) {
 }
 
 // reap makes a single iteration through the cache index to to find and remove expired elements
-// and evict least-recently-accessed elements to maintain the Maximum allowed Cache Size
+// and evict elements, in the order of the eviction policy, to maintain the partition quotas
+// and the Maximum allowed Cache Size
 func (idx *IndexedClient) reap() {
 	cacheSize := atomic.LoadInt64(&idx.CacheSize)
 	objectCount := max(atomic.LoadInt64(&idx.ObjectCount), 0)
@@ -447,13 +518,26 @@ func (idx *IndexedClient) reap() {
 		cacheChanged = true
 		cacheSize = atomic.LoadInt64(&idx.CacheSize)
 	}
+
+	e := idx.loadEviction()
+
+	// partitions that exceed their quotas are evicted from before the global limits are checked
+	victims, evicted := idx.reapPartitions(remainders, e.policy)
+	if len(evicted) > 0 {
+		remainders = slices.DeleteFunc(remainders, func(o *Object) bool {
+			return evicted.Contains(o.Key)
+		})
+		cacheChanged = true
+		cacheSize = atomic.LoadInt64(&idx.CacheSize)
+	}
 	objectCount = atomic.LoadInt64(&idx.ObjectCount)
 	opts := idx.options.Load().(*options.Options)
 
-	evictionType, removals := reap(cacheSize, objectCount, remainders, *opts)
-	if len(removals) > 0 {
+	evictionType, globalVictims := reap(cacheSize, objectCount, remainders, *opts, e.policy)
+	if len(globalVictims) > 0 {
+		victims = append(victims, globalVictims...)
 		metrics.ObserveCacheEvent(idx.name, idx.cacheProvider, "eviction", evictionType)
-		if err := idx.Remove(removals...); err != nil {
+		if err := idx.Remove(objectKeys(globalVictims)...); err != nil {
 			logger.Error("reap remove error", logging.Pairs{"cacheName": idx.name, "error": err})
 		}
 		cacheChanged = true
@@ -465,6 +549,9 @@ func (idx *IndexedClient) reap() {
 				"cacheSizeObjects": objectCount, "maxSizeObjects": opts.MaxSizeObjects,
 			})
 	}
+	if e.filter != nil {
+		e.filter.Evicted(victims)
+	}
 	if cacheChanged {
 		idx.lastWrite.Store(time.Now())
 	}
@@ -64,6 +64,24 @@ func (z *IndexedClient) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "LastFlush")
 				return
 			}
+		case "tags":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "TagIndex")
+					return
+				}
+				z.TagIndex = nil
+			} else {
+				if z.TagIndex == nil {
+					z.TagIndex = new(TagIndex)
+				}
+				err = z.TagIndex.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "TagIndex")
+					return
+				}
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -77,9 +95,9 @@ func (z *IndexedClient) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *IndexedClient) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 4
+	// map header, size 5
 	// write "cache_size"
-	err = en.Append(0x84, 0xaa, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65)
+	err = en.Append(0x85, 0xaa, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65)
 	if err != nil {
 		return
 	}
@@ -118,15 +136,32 @@ func (z *IndexedClient) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "LastFlush")
 		return
 	}
+	// write "tags"
+	err = en.Append(0xa4, 0x74, 0x61, 0x67, 0x73)
+	if err != nil {
+		return
+	}
+	if z.TagIndex == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		err = z.TagIndex.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "TagIndex")
+			return
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *IndexedClient) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 4
+	// map header, size 5
 	// string "cache_size"
-	o = append(o, 0x84, 0xaa, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65)
+	o = append(o, 0x85, 0xaa, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65)
 	o = msgp.AppendInt64(o, z.CacheSize)
 	// string "object_count"
 	o = append(o, 0xac, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74)
@@ -145,6 +180,17 @@ func (z *IndexedClient) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "LastFlush")
 		return
 	}
+	// string "tags"
+	o = append(o, 0xa4, 0x74, 0x61, 0x67, 0x73)
+	if z.TagIndex == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.TagIndex.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "TagIndex")
+			return
+		}
+	}
 	return
 }
 
@@ -190,6 +236,23 @@ func (z *IndexedClient) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "LastFlush")
 				return
 			}
+		case "tags":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.TagIndex = nil
+			} else {
+				if z.TagIndex == nil {
+					z.TagIndex = new(TagIndex)
+				}
+				bts, err = z.TagIndex.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "TagIndex")
+					return
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -204,7 +267,12 @@ func (z *IndexedClient) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *IndexedClient) Msgsize() (s int) {
-	s = 1 + 11 + msgp.Int64Size + 13 + msgp.Int64Size + 8 + z.Objects.Msgsize() + 10 + msgp.ExtensionPrefixSize + z.LastFlush.Len()
+	s = 1 + 11 + msgp.Int64Size + 13 + msgp.Int64Size + 8 + z.Objects.Msgsize() + 10 + msgp.ExtensionPrefixSize + z.LastFlush.Len() + 5
+	if z.TagIndex == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.TagIndex.Msgsize()
+	}
 	return
 }
 
@@ -565,6 +565,47 @@ func TestStoreUpdateExistingKey(t *testing.T) {
 	require.Equal(t, int64(4), state.CacheSize)
 }
 
+func TestSelect(t *testing.T) {
+	mc := newMapClient()
+	ic := NewIndexedClient("test", "map", defaultIndexOpts(), mc)
+	t.Cleanup(func() { _ = ic.Close() })
+
+	require.NoError(t, ic.Store("b.1", []byte("abc"), time.Minute))
+	require.NoError(t, ic.Store("a.2", []byte("ab"), 0))
+	require.NoError(t, ic.Store("a.1", []byte("a"), time.Minute))
+
+	objs := ic.Select("a.")
+	require.Len(t, objs, 2)
+	require.Equal(t, "a.1", objs[0].Key)
+	require.Equal(t, int64(1), objs[0].Size)
+	require.Nil(t, objs[0].Value)
+	require.False(t, objs[0].Expiration.Load().IsZero())
+	require.Equal(t, "a.2", objs[1].Key)
+	require.True(t, objs[1].Expiration.Load().IsZero())
+
+	require.Len(t, ic.Select(""), 3)
+	require.Empty(t, ic.Select("c."))
+}
+
+func TestTagIndexPersisted(t *testing.T) {
+	mc := newMapClient()
+	ic := NewIndexedClient("test", "map", defaultIndexOpts(), mc)
+	require.NoError(t, ic.Store("a.1", []byte("a"), time.Minute))
+	require.NoError(t, ic.Store("a.2", []byte("a"), time.Minute))
+	ic.TagIndex.Add("a.1", time.Now().Add(time.Minute), "t1")
+	ic.TagIndex.Add("a.2", time.Now().Add(time.Minute), "t1")
+	require.NoError(t, ic.Remove("a.2"))
+	ic.flushOnce()
+	require.NoError(t, ic.Close())
+
+	ic = NewIndexedClient("test", "map", defaultIndexOpts(), mc,
+		func(ico *IndexedClientOptions) { ico.NeedsFlushInterval = true })
+	t.Cleanup(func() { _ = ic.Close() })
+	require.Equal(t, []string{"a.1"}, ic.TagIndex.Select([]string{"t1"}, ""))
+	ic.Clear()
+	require.Zero(t, ic.TagIndex.Len())
+}
+
 func TestRetrieveCorruptObject(t *testing.T) {
 	mc := newMapClient()
 	require.NoError(t, mc.Store("k", []byte("not-msgpack"), 0))
@@ -19,7 +19,8 @@ package index
 
 import (
 	"bytes"
-	"slices"
+	"math"
+	"sync/atomic"
 
 	"github.com/trickstercache/trickster/v2/pkg/cache"
 	"github.com/trickstercache/trickster/v2/pkg/cache/index/options"
@@ -52,6 +53,14 @@ type Object struct {
 	// DirectValue is an interface value for storing objects by reference to a memory cache
 	// Since we'd never recover a memory cache index from memory on startup, no need to msgpk
 	ReferenceValue cache.ReferenceObject `msg:"-"`
+
+	// hits is the number of times the object was written or read, as counted by
+	// the frequency-based eviction policies
+	hits atomic.Int64
+	// priority is the eviction priority assigned by the eviction policy, as float64 bits
+	priority atomic.Uint64
+	// segment is the segment of the wtinylfu eviction policy that the object is in
+	segment atomic.Uint32
 }
 
 func (o *Object) Equal(other *Object) bool {
@@ -63,6 +72,32 @@ func (o *Object) Equal(other *Object) bool {
 		((o.ReferenceValue != nil && o.ReferenceValue == other.ReferenceValue) || bytes.Equal(o.Value, other.Value))
 }
 
+// metadata returns a copy of the Object without its value
+func (o *Object) metadata() *Object {
+	out := &Object{Key: o.Key, Size: o.Size}
+	out.Expiration.Store(o.Expiration.Load())
+	out.LastWrite.Store(o.LastWrite.Load())
+	out.LastAccess.Store(o.LastAccess.Load())
+	return out
+}
+
+func (o *Object) loadPriority() float64 {
+	return math.Float64frombits(o.priority.Load())
+}
+
+func (o *Object) storePriority(p float64) {
+	o.priority.Store(math.Float64bits(p))
+}
+
+// Lister is implemented by caches that can list the objects they hold
+type Lister interface {
+	// IndexedObjects returns the metadata of each listed object whose key
+	// starts with prefix, sorted by key. It returns true when the list is
+	// complete, and false when only the keys and expirations of objects
+	// tracked by a TagIndex are known.
+	IndexedObjects(prefix string) ([]*Object, bool)
+}
+
 // ToBytes returns a serialized byte slice representing the Object
 func (o *Object) ToBytes() ([]byte, error) {
 	return o.MarshalMsg(nil)
@@ -75,7 +110,10 @@ func ObjectFromBytes(data []byte) (*Object, error) {
 	return o, err
 }
 
-func reap(cacheSize int64, objectCount int64, remainders objectsAtime, opts options.Options) (evictionType string, removals []string) {
+// reap selects the objects to evict, in the order of the eviction policy, to bring the
+// cache size within the limits of opts
+func reap(cacheSize int64, objectCount int64, remainders objectsAtime, opts options.Options,
+	policy EvictionPolicy) (evictionType string, victims []*Object) {
 	if len(remainders) == 0 ||
 		((opts.MaxSizeBytes == 0 || cacheSize <= opts.MaxSizeBytes) &&
 			(opts.MaxSizeObjects == 0 || objectCount <= opts.MaxSizeObjects)) {
@@ -91,17 +129,15 @@ func reap(cacheSize int64, objectCount int64, remainders objectsAtime, opts opti
 	}
 
 	logger.Debug(
-		"max cache size reached. evicting least-recently-accessed records",
+		"max cache size reached. evicting records",
 		logging.Pairs{
-			"reason":         evictionType,
+			"reason": evictionType, "policy": policy.Name(),
 			"cacheSizeBytes": cacheSize, "maxSizeBytes": opts.MaxSizeBytes,
 			"cacheSizeObjects": objectCount, "maxSizeObjects": opts.MaxSizeObjects,
 		},
 	)
 
-	removals = make([]string, 0)
-
-	slices.SortFunc(remainders, objectAtimeCmp)
+	policy.Order(remainders)
 
 	var i int
 	j := len(remainders)
@@ -113,7 +149,6 @@ func reap(cacheSize int64, objectCount int64, remainders objectsAtime, opts opti
 		}
 		bytesSelected := int64(0)
 		for bytesSelected < bytesNeeded && i < j {
-			removals = append(removals, remainders[i].Key)
 			bytesSelected += remainders[i].Size
 			i++
 		}
@@ -124,11 +159,21 @@ func reap(cacheSize int64, objectCount int64, remainders objectsAtime, opts opti
 		}
 		objectsSelected := int64(0)
 		for objectsSelected < objectsNeeded && i < j {
-			removals = append(removals, remainders[i].Key)
 			objectsSelected++
 			i++
 		}
 	}
 
+	victims = remainders[:i]
+	policy.Evicted(victims)
 	return
 }
+
+// objectKeys returns the keys of the provided objects
+func objectKeys(objects []*Object) []string {
+	out := make([]string, len(objects))
+	for i, o := range objects {
+		out[i] = o.Key
+	}
+	return out
+}
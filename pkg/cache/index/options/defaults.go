@@ -31,6 +31,10 @@ const (
 	DefaultMaxSizeObjects = 0
 	// DefaultMaxSizeBackoffObjects is the default Max Cache Backoff Object Count
 	DefaultMaxSizeBackoffObjects = 100
+	// DefaultEvictionPolicy is the default Eviction Policy
+	DefaultEvictionPolicy = EvictionPolicyLRU
+	// DefaultAdmissionFilter is the default Admission Filter
+	DefaultAdmissionFilter = AdmissionFilterNone
 	// DefaultIndexExpiry is the default Index Expiry
 	DefaultIndexExpiry = time.Hour * 24 * 365 // 1 year
 )
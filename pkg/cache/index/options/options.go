@@ -17,6 +17,10 @@
 package options
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+
 	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
 
 	"go.yaml.in/yaml/v3"
@@ -42,6 +46,112 @@ type Options struct {
 	// MaxSizeBackoffObjects indicates how far under max_size_objects the cache size must
 	// be to complete object-size-based eviction exercise.
 	MaxSizeBackoffObjects int64 `yaml:"max_size_backoff_objects,omitempty"`
+	// EvictionPolicy selects the order in which objects are evicted when the cache
+	// exceeds its size limits. Options are lru, lfu, wtinylfu and gdsf.
+	EvictionPolicy string `yaml:"eviction_policy,omitempty"`
+	// AdmissionFilter selects a filter that declines to store new objects that are
+	// unlikely to be read again while the cache is at its size limits. Options are
+	// none and tinylfu.
+	AdmissionFilter string `yaml:"admission_filter,omitempty"`
+	// Quotas defines size limits for partitions of the cache, keyed by partition name,
+	// so that a single backend can't evict the objects of every other backend
+	Quotas map[string]*QuotaOptions `yaml:"quotas,omitempty"`
+}
+
+// QuotaOptions defines the size limits of a partition of the cache, comprising
+// the objects whose keys begin with the partition's prefix
+type QuotaOptions struct {
+	// Backend is the name of the backend whose objects are in the partition
+	Backend string `yaml:"backend,omitempty"`
+	// KeyPrefix is the cache key prefix of the objects in the partition,
+	// and is used when Backend is not set
+	KeyPrefix string `yaml:"key_prefix,omitempty"`
+	// MaxSizeBytes indicates how large the partition can grow in bytes before the Index
+	// evicts its least-recently-accessed items.
+	MaxSizeBytes int64 `yaml:"max_size_bytes,omitempty"`
+	// MaxSizeBackoffBytes indicates how far below max_size_bytes the partition size must be
+	// to complete a byte-size-based eviction exercise.
+	MaxSizeBackoffBytes int64 `yaml:"max_size_backoff_bytes,omitempty"`
+	// MaxSizeObjects indicates how large the partition can grow in objects before the Index
+	// evicts its least-recently-accessed items.
+	MaxSizeObjects int64 `yaml:"max_size_objects,omitempty"`
+	// MaxSizeBackoffObjects indicates how far under max_size_objects the partition size must
+	// be to complete an object-size-based eviction exercise.
+	MaxSizeBackoffObjects int64 `yaml:"max_size_backoff_objects,omitempty"`
+}
+
+const (
+	// EvictionPolicyLRU evicts the least-recently-accessed objects first
+	EvictionPolicyLRU = "lru"
+	// EvictionPolicyLFU evicts the least-frequently-accessed objects first, with aging
+	EvictionPolicyLFU = "lfu"
+	// EvictionPolicyWTinyLFU evicts objects using Window TinyLFU, which admits new
+	// objects to the main cache only when they are requested more frequently than
+	// the main cache's eviction candidates
+	EvictionPolicyWTinyLFU = "wtinylfu"
+	// EvictionPolicyGDSF evicts objects using Greedy-Dual Size-Frequency, which
+	// evicts large, infrequently-accessed objects first
+	EvictionPolicyGDSF = "gdsf"
+
+	// AdmissionFilterNone admits all new objects
+	AdmissionFilterNone = "none"
+	// AdmissionFilterTinyLFU admits new objects only when they are requested more
+	// frequently than the objects being evicted
+	AdmissionFilterTinyLFU = "tinylfu"
+)
+
+var (
+	// ErrInvalidEvictionPolicy is returned when the eviction policy is not supported
+	ErrInvalidEvictionPolicy = errors.New("invalid eviction_policy")
+	// ErrInvalidAdmissionFilter is returned when the admission filter is not supported
+	ErrInvalidAdmissionFilter = errors.New("invalid admission_filter")
+	// ErrInvalidQuotaPartition is returned when a quota does not identify exactly one
+	// of a backend or a key prefix
+	ErrInvalidQuotaPartition = errors.New("quota must set exactly one of backend or key_prefix")
+	// ErrDuplicateQuotaPrefix is returned when two quotas partition the same key prefix
+	ErrDuplicateQuotaPrefix = errors.New("quota key prefix is already in use")
+	// ErrQuotaNoLimit is returned when a quota sets neither max_size_bytes nor max_size_objects
+	ErrQuotaNoLimit = errors.New("quota must set max_size_bytes or max_size_objects")
+	// ErrQuotaBackoffBytesTooBig is returned when a quota's MaxSizeBackoffBytes exceeds its MaxSizeBytes
+	ErrQuotaBackoffBytesTooBig = errors.New("quota max_size_backoff_bytes can't be larger than max_size_bytes")
+	// ErrQuotaBackoffObjectsTooBig is returned when a quota's MaxSizeBackoffObjects exceeds its MaxSizeObjects
+	ErrQuotaBackoffObjectsTooBig = errors.New("quota max_size_backoff_objects can't be larger than max_size_objects")
+)
+
+// Prefix returns the cache key prefix of the objects in the partition
+func (q *QuotaOptions) Prefix() string {
+	if q.Backend != "" {
+		// backend cache keys are prefixed with the backend name
+		return q.Backend + "."
+	}
+	return q.KeyPrefix
+}
+
+// Limits returns Options holding only the size limits of the quota
+func (q *QuotaOptions) Limits() Options {
+	return Options{
+		MaxSizeBytes:          q.MaxSizeBytes,
+		MaxSizeBackoffBytes:   q.MaxSizeBackoffBytes,
+		MaxSizeObjects:        q.MaxSizeObjects,
+		MaxSizeBackoffObjects: q.MaxSizeBackoffObjects,
+	}
+}
+
+// Validate returns an error if the quota is invalid
+func (q *QuotaOptions) Validate() error {
+	if (q.Backend == "") == (q.KeyPrefix == "") {
+		return ErrInvalidQuotaPartition
+	}
+	if q.MaxSizeBytes <= 0 && q.MaxSizeObjects <= 0 {
+		return ErrQuotaNoLimit
+	}
+	if q.MaxSizeBytes > 0 && q.MaxSizeBackoffBytes > q.MaxSizeBytes {
+		return ErrQuotaBackoffBytesTooBig
+	}
+	if q.MaxSizeObjects > 0 && q.MaxSizeBackoffObjects > q.MaxSizeObjects {
+		return ErrQuotaBackoffObjectsTooBig
+	}
+	return nil
 }
 
 // New returns a new Cache Index Options Reference with default values set
@@ -54,6 +164,8 @@ func New() *Options {
 		MaxSizeBackoffBytes:   DefaultMaxSizeBackoffBytes,
 		MaxSizeObjects:        DefaultMaxSizeObjects,
 		MaxSizeBackoffObjects: DefaultMaxSizeBackoffObjects,
+		EvictionPolicy:        DefaultEvictionPolicy,
+		AdmissionFilter:       DefaultAdmissionFilter,
 	}
 }
 
@@ -69,7 +181,57 @@ func (o *Options) Equal(o2 *Options) bool {
 		o.MaxSizeBytes == o2.MaxSizeBytes &&
 		o.MaxSizeBackoffBytes == o2.MaxSizeBackoffBytes &&
 		o.MaxSizeObjects == o2.MaxSizeObjects &&
-		o.MaxSizeBackoffObjects == o2.MaxSizeBackoffObjects
+		o.MaxSizeBackoffObjects == o2.MaxSizeBackoffObjects &&
+		o.EvictionPolicy == o2.EvictionPolicy &&
+		o.AdmissionFilter == o2.AdmissionFilter &&
+		maps.EqualFunc(o.Quotas, o2.Quotas, func(q1, q2 *QuotaOptions) bool {
+			return q1 != nil && q2 != nil && *q1 == *q2
+		})
+}
+
+// Clone returns an exact copy of the subject Options
+func (o *Options) Clone() *Options {
+	out := *o
+	if o.Quotas != nil {
+		out.Quotas = make(map[string]*QuotaOptions, len(o.Quotas))
+		for k, q := range o.Quotas {
+			if q != nil {
+				qc := *q
+				q = &qc
+			}
+			out.Quotas[k] = q
+		}
+	}
+	return &out
+}
+
+// Validate returns an error if the eviction policy, admission filter or Quotas are invalid
+func (o *Options) Validate() error {
+	switch o.EvictionPolicy {
+	case "", EvictionPolicyLRU, EvictionPolicyLFU, EvictionPolicyWTinyLFU, EvictionPolicyGDSF:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidEvictionPolicy, o.EvictionPolicy)
+	}
+	switch o.AdmissionFilter {
+	case "", AdmissionFilterNone, AdmissionFilterTinyLFU:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidAdmissionFilter, o.AdmissionFilter)
+	}
+	prefixes := make(map[string]string, len(o.Quotas))
+	for k, q := range o.Quotas {
+		if q == nil {
+			return fmt.Errorf("quota %s: %w", k, ErrInvalidQuotaPartition)
+		}
+		if err := q.Validate(); err != nil {
+			return fmt.Errorf("quota %s: %w", k, err)
+		}
+		p := q.Prefix()
+		if n, ok := prefixes[p]; ok {
+			return fmt.Errorf("quota %s: %w by quota %s", k, ErrDuplicateQuotaPrefix, n)
+		}
+		prefixes[p] = k
+	}
+	return nil
 }
 
 func (o *Options) UnmarshalYAML(value *yaml.Node) error {
@@ -16,7 +16,10 @@
 
 package options
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestNew(t *testing.T) {
 	o := New()
@@ -36,3 +39,64 @@ func TestEqual(t *testing.T) {
 		t.Error("expected true")
 	}
 }
+
+func TestQuotas(t *testing.T) {
+	o := New()
+	o.Quotas = map[string]*QuotaOptions{
+		"prom": {Backend: "prom", MaxSizeBytes: 1024, MaxSizeBackoffBytes: 64},
+	}
+	if err := o.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	o2 := o.Clone()
+	if !o.Equal(o2) {
+		t.Error("expected true")
+	}
+	o2.Quotas["prom"].MaxSizeBytes = 2048
+	if o.Equal(o2) || o.Quotas["prom"].MaxSizeBytes != 1024 {
+		t.Error("expected clone to be independent")
+	}
+
+	tests := []struct {
+		q        *QuotaOptions
+		expected error
+	}{
+		{&QuotaOptions{MaxSizeObjects: 1}, ErrInvalidQuotaPartition},
+		{&QuotaOptions{Backend: "a", KeyPrefix: "a.", MaxSizeObjects: 1}, ErrInvalidQuotaPartition},
+		{&QuotaOptions{Backend: "a"}, ErrQuotaNoLimit},
+		{&QuotaOptions{Backend: "a", MaxSizeBytes: 1, MaxSizeBackoffBytes: 2}, ErrQuotaBackoffBytesTooBig},
+		{&QuotaOptions{Backend: "a", MaxSizeObjects: 1, MaxSizeBackoffObjects: 2}, ErrQuotaBackoffObjectsTooBig},
+		{&QuotaOptions{KeyPrefix: "prom.", MaxSizeObjects: 1}, ErrDuplicateQuotaPrefix},
+	}
+	for _, test := range tests {
+		o3 := o.Clone()
+		o3.Quotas["test"] = test.q
+		if err := o3.Validate(); !errors.Is(err, test.expected) {
+			t.Errorf("expected %v got %v", test.expected, err)
+		}
+	}
+}
+
+func TestValidateEviction(t *testing.T) {
+	o := New()
+	for _, p := range []string{EvictionPolicyLRU, EvictionPolicyLFU, EvictionPolicyWTinyLFU, EvictionPolicyGDSF} {
+		o.EvictionPolicy = p
+		if err := o.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+	o.EvictionPolicy = "fifo"
+	if err := o.Validate(); !errors.Is(err, ErrInvalidEvictionPolicy) {
+		t.Errorf("expected %v got %v", ErrInvalidEvictionPolicy, err)
+	}
+	o.EvictionPolicy = EvictionPolicyLRU
+	o.AdmissionFilter = AdmissionFilterTinyLFU
+	if err := o.Validate(); err != nil {
+		t.Error(err)
+	}
+	o.AdmissionFilter = "bloom"
+	if err := o.Validate(); !errors.Is(err, ErrInvalidAdmissionFilter) {
+		t.Errorf("expected %v got %v", ErrInvalidAdmissionFilter, err)
+	}
+}
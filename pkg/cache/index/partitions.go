@@ -0,0 +1,165 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache/index/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/metrics"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
+	"github.com/trickstercache/trickster/v2/pkg/util/sets"
+)
+
+// partition tracks the usage of the objects in a quota partition of the cache
+type partition struct {
+	name   string
+	prefix string
+	limits options.Options
+	size   atomic.Int64
+	count  atomic.Int64
+}
+
+// partitions is a list of quota partitions sorted by descending prefix length,
+// so that an object belongs to the partition with the longest matching prefix
+type partitions []*partition
+
+func newPartitions(quotas map[string]*options.QuotaOptions) partitions {
+	if len(quotas) == 0 {
+		return nil
+	}
+	out := make(partitions, 0, len(quotas))
+	for name, q := range quotas {
+		if q == nil {
+			continue
+		}
+		out = append(out, &partition{name: name, prefix: q.Prefix(), limits: q.Limits()})
+	}
+	slices.SortFunc(out, func(a, b *partition) int {
+		if c := cmp.Compare(len(b.prefix), len(a.prefix)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.name, b.name)
+	})
+	return out
+}
+
+// match returns the index of the partition holding cacheKey, or -1 if the key
+// does not belong to a partition
+func (ps partitions) match(cacheKey string) int {
+	for i, p := range ps {
+		if strings.HasPrefix(cacheKey, p.prefix) {
+			return i
+		}
+	}
+	return -1
+}
+
+// setPartitions rebuilds the quota partitions from the provided options and
+// recounts their usage from the objects in the index
+func (idx *IndexedClient) setPartitions(o *options.Options) {
+	ps := newPartitions(o.Quotas)
+	idx.Objects.Range(func(_, value any) bool {
+		obj := value.(*Object)
+		if i := ps.match(obj.Key); i >= 0 {
+			ps[i].size.Add(obj.Size)
+			ps[i].count.Add(1)
+		}
+		return true
+	})
+	for _, p := range ps {
+		metrics.ObserveCachePartitionSizeChange(idx.name, idx.cacheProvider,
+			p.name, p.size.Load(), p.count.Load())
+	}
+	idx.partitions.Store(&ps)
+}
+
+// updatePartition adjusts the usage of the quota partition holding cacheKey
+func (idx *IndexedClient) updatePartition(cacheKey string, bytes, objects int64) {
+	ps := idx.partitions.Load()
+	if ps == nil {
+		return
+	}
+	i := ps.match(cacheKey)
+	if i < 0 {
+		return
+	}
+	p := (*ps)[i]
+	metrics.ObserveCachePartitionSizeChange(idx.name, idx.cacheProvider,
+		p.name, p.size.Add(bytes), p.count.Add(objects))
+}
+
+// clearPartitions resets the usage of each quota partition
+func (idx *IndexedClient) clearPartitions() {
+	ps := idx.partitions.Load()
+	if ps == nil {
+		return
+	}
+	for _, p := range *ps {
+		p.size.Store(0)
+		p.count.Store(0)
+		metrics.ObserveCachePartitionSizeChange(idx.name, idx.cacheProvider, p.name, 0, 0)
+	}
+}
+
+// reapPartitions evicts objects, in the order of the eviction policy, from each quota
+// partition that exceeds its limits, and returns the evicted objects and their keys
+func (idx *IndexedClient) reapPartitions(remainders objectsAtime,
+	policy EvictionPolicy) ([]*Object, sets.Set[string]) {
+	ps := idx.partitions.Load()
+	if ps == nil || len(*ps) == 0 {
+		return nil, nil
+	}
+	members := make([]objectsAtime, len(*ps))
+	sizes := make([]int64, len(*ps))
+	for _, o := range remainders {
+		if i := ps.match(o.Key); i >= 0 {
+			members[i] = append(members[i], o)
+			sizes[i] += o.Size
+		}
+	}
+	var victims []*Object
+	var evicted sets.Set[string]
+	for i, p := range *ps {
+		count := int64(len(members[i]))
+		evictionType, pv := reap(sizes[i], count, members[i], p.limits, policy)
+		if len(pv) == 0 {
+			continue
+		}
+		removals := objectKeys(pv)
+		metrics.ObserveCacheEvent(idx.name, idx.cacheProvider, "eviction", "quota_"+evictionType)
+		if err := idx.Remove(removals...); err != nil {
+			logger.Error("reap remove error", logging.Pairs{"cacheName": idx.name, "error": err})
+		}
+		logger.Debug("quota-based cache eviction exercise completed",
+			logging.Pairs{
+				"partition": p.name, "reason": evictionType,
+				"partitionSizeBytes": sizes[i], "maxSizeBytes": p.limits.MaxSizeBytes,
+				"partitionSizeObjects": count, "maxSizeObjects": p.limits.MaxSizeObjects,
+			})
+		if evicted == nil {
+			evicted = sets.NewStringSet()
+		}
+		evicted.SetAll(removals)
+		victims = append(victims, pv...)
+	}
+	return victims, evicted
+}
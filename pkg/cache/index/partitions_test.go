@@ -0,0 +1,120 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache/index/options"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionsMatch(t *testing.T) {
+	ps := newPartitions(map[string]*options.QuotaOptions{
+		"prom":    {Backend: "prom", MaxSizeObjects: 1},
+		"prom-qr": {KeyPrefix: "prom.qr.", MaxSizeObjects: 1},
+	})
+	require.Len(t, ps, 2)
+	require.Equal(t, "prom-qr", ps[ps.match("prom.qr.abc")].name)
+	require.Equal(t, "prom", ps[ps.match("prom.abc")].name)
+	require.Equal(t, -1, ps.match("promxyz"))
+	require.Equal(t, -1, ps.match("other.abc"))
+	require.Nil(t, newPartitions(nil))
+}
+
+func TestPartitionQuotas(t *testing.T) {
+	mc := newMapClient()
+	o := defaultIndexOpts()
+	o.Quotas = map[string]*options.QuotaOptions{
+		"noisy": {Backend: "prom1", MaxSizeObjects: 3, MaxSizeBackoffObjects: 1},
+		"quiet": {KeyPrefix: "prom2.", MaxSizeBytes: 1024},
+	}
+	ic := NewIndexedClient("test", "map", o, mc)
+	t.Cleanup(func() { _ = ic.Close() })
+
+	store := func(key string, atime time.Time) {
+		require.NoError(t, ic.Store(key, []byte("value"), time.Hour))
+		v, ok := ic.Objects.Load(key)
+		require.True(t, ok)
+		v.(*Object).LastAccess.Store(atime)
+	}
+	now := time.Now()
+	for i := range 5 {
+		store(fmt.Sprintf("prom1.key%d", i), now.Add(time.Duration(i)*time.Second))
+	}
+	for i := range 2 {
+		store(fmt.Sprintf("prom2.key%d", i), now.Add(-time.Hour))
+	}
+	store("other.key", now.Add(-time.Hour))
+
+	usage := func(name string) (int64, int64) {
+		ps := *ic.partitions.Load()
+		for _, p := range ps {
+			if p.name == name {
+				return p.size.Load(), p.count.Load()
+			}
+		}
+		t.Fatalf("partition %s not found", name)
+		return 0, 0
+	}
+	size, count := usage("noisy")
+	require.Equal(t, int64(25), size)
+	require.Equal(t, int64(5), count)
+
+	ic.reap()
+
+	// the noisy partition is evicted down to its quota less the backoff, oldest first,
+	// and the objects of the other partitions are untouched
+	for i := range 5 {
+		_, ok := ic.Objects.Load(fmt.Sprintf("prom1.key%d", i))
+		require.Equal(t, i >= 3, ok, "prom1.key%d", i)
+	}
+	for _, key := range []string{"prom2.key0", "prom2.key1", "other.key"} {
+		_, ok := ic.Objects.Load(key)
+		require.True(t, ok, key)
+	}
+	size, count = usage("noisy")
+	require.Equal(t, int64(10), size)
+	require.Equal(t, int64(2), count)
+	size, count = usage("quiet")
+	require.Equal(t, int64(10), size)
+	require.Equal(t, int64(2), count)
+
+	t.Run("update options recounts usage", func(t *testing.T) {
+		o2 := defaultIndexOpts()
+		o2.Quotas = map[string]*options.QuotaOptions{
+			"all": {KeyPrefix: "prom", MaxSizeObjects: 100},
+		}
+		ic.UpdateOptions(o2)
+		size, count := usage("all")
+		require.Equal(t, int64(20), size)
+		require.Equal(t, int64(4), count)
+
+		require.NoError(t, ic.Remove("prom1.key3"))
+		size, count = usage("all")
+		require.Equal(t, int64(15), size)
+		require.Equal(t, int64(3), count)
+
+		ic.Clear()
+		size, count = usage("all")
+		require.Zero(t, size)
+		require.Zero(t, count)
+	})
+}
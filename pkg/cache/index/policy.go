@@ -0,0 +1,145 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sync/atomic"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache/index/options"
+)
+
+// EvictionPolicy determines the order in which the Index evicts objects when
+// the cache exceeds its size limits
+type EvictionPolicy interface {
+	// Name returns the name of the policy
+	Name() string
+	// Touch records a write or read of the object
+	Touch(o *Object)
+	// Order sorts the objects in the order in which they are to be evicted
+	Order(objects []*Object)
+	// Evicted records the eviction of the objects, which are in eviction order
+	Evicted(objects []*Object)
+}
+
+// NewEvictionPolicy returns the EvictionPolicy with the provided name, or an
+// lru policy if the name is not recognized
+func NewEvictionPolicy(name string) EvictionPolicy {
+	switch name {
+	case options.EvictionPolicyLFU:
+		return &agingPolicy{name: name}
+	case options.EvictionPolicyGDSF:
+		return &agingPolicy{name: name, sizeAware: true}
+	case options.EvictionPolicyWTinyLFU:
+		return newWTinyLFUPolicy()
+	}
+	return lruPolicy(options.EvictionPolicyLRU)
+}
+
+// lruPolicy evicts the least-recently-accessed objects first
+type lruPolicy string
+
+func (p lruPolicy) Name() string {
+	return string(p)
+}
+
+func (lruPolicy) Touch(*Object) {}
+
+func (lruPolicy) Order(objects []*Object) {
+	slices.SortFunc(objects, objectAtimeCmp)
+}
+
+func (lruPolicy) Evicted([]*Object) {}
+
+// agingPolicy evicts the objects with the lowest priority first. An object's
+// priority is the cache age plus its access count, which is divided by its
+// size when the policy is size-aware (Greedy-Dual Size-Frequency). The cache
+// age advances to the priority of each evicted object, so that objects that
+// were popular long ago do not remain in the cache indefinitely.
+type agingPolicy struct {
+	name      string
+	sizeAware bool
+	age       atomic.Uint64 // float64 bits
+}
+
+func (p *agingPolicy) Name() string {
+	return p.name
+}
+
+func (p *agingPolicy) Touch(o *Object) {
+	v := float64(o.hits.Add(1))
+	if p.sizeAware {
+		v /= float64(max(o.Size, 1))
+	}
+	o.storePriority(math.Float64frombits(p.age.Load()) + v)
+}
+
+func (p *agingPolicy) Order(objects []*Object) {
+	slices.SortFunc(objects, func(a, b *Object) int {
+		if c := cmp.Compare(a.loadPriority(), b.loadPriority()); c != 0 {
+			return c
+		}
+		return objectAtimeCmp(a, b)
+	})
+}
+
+func (p *agingPolicy) Evicted(objects []*Object) {
+	if len(objects) == 0 {
+		return
+	}
+	// objects are in ascending priority order, so the last is the highest
+	if v := objects[len(objects)-1].loadPriority(); v > math.Float64frombits(p.age.Load()) {
+		p.age.Store(math.Float64bits(v))
+	}
+}
+
+// eviction holds the eviction policy and admission filter of an IndexedClient
+type eviction struct {
+	policy EvictionPolicy
+	filter AdmissionFilter // nil when all objects are admitted
+}
+
+var defaultEviction = &eviction{policy: lruPolicy(options.EvictionPolicyLRU)}
+
+// setEviction configures the eviction policy and admission filter from the
+// options, retaining the existing ones and their state when they are unchanged
+func (idx *IndexedClient) setEviction(o *options.Options) {
+	filterName := o.AdmissionFilter
+	e := &eviction{}
+	cur := idx.eviction.Load()
+	if cur != nil && cur.policy.Name() == o.EvictionPolicy {
+		e.policy = cur.policy
+	} else {
+		e.policy = NewEvictionPolicy(o.EvictionPolicy)
+	}
+	if cur != nil && cur.filter != nil && cur.filter.Name() == filterName {
+		e.filter = cur.filter
+	} else {
+		e.filter = NewAdmissionFilter(filterName)
+	}
+	idx.eviction.Store(e)
+}
+
+// loadEviction returns the eviction policy and admission filter of the IndexedClient
+func (idx *IndexedClient) loadEviction() *eviction {
+	if e := idx.eviction.Load(); e != nil {
+		return e
+	}
+	return defaultEviction
+}
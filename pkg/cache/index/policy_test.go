@@ -0,0 +1,201 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache/index/options"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testPolicyObjects() []*Object {
+	now := time.Now()
+	// "a" is the least recently accessed, "b" the most frequently accessed,
+	// and "c" the largest
+	objects := []*Object{
+		{Key: "a", Size: 10},
+		{Key: "b", Size: 10},
+		{Key: "c", Size: 1000},
+	}
+	for i, o := range objects {
+		o.LastAccess.Store(now.Add(time.Duration(i) * time.Second))
+	}
+	return objects
+}
+
+func TestEvictionPolicies(t *testing.T) {
+	t.Run("lru", func(t *testing.T) {
+		p := NewEvictionPolicy(options.EvictionPolicyLRU)
+		require.Equal(t, options.EvictionPolicyLRU, p.Name())
+		objects := testPolicyObjects()
+		objects[0], objects[2] = objects[2], objects[0]
+		p.Order(objects)
+		require.Equal(t, []string{"a", "b", "c"}, objectKeys(objects))
+		require.Equal(t, options.EvictionPolicyLRU, NewEvictionPolicy("unknown").Name())
+		require.Equal(t, options.EvictionPolicyWTinyLFU,
+			NewEvictionPolicy(options.EvictionPolicyWTinyLFU).Name())
+	})
+
+	t.Run("wtinylfu", func(t *testing.T) {
+		p := newWTinyLFUPolicy()
+		now := time.Now()
+		objects := make(map[string]*Object)
+		var ordered []*Object
+		for i, key := range []string{"probation", "hot", "protected", "one-off", "frequent", "newest"} {
+			o := &Object{Key: key, Size: 10}
+			o.LastAccess.Store(now.Add(time.Duration(i) * time.Second))
+			p.Touch(o)
+			objects[key] = o
+			ordered = append(ordered, o)
+		}
+		for _, key := range []string{"probation", "hot", "protected"} {
+			objects[key].segment.Store(segmentProbation)
+		}
+		// accessing a probation object promotes it to the protected segment
+		p.Touch(objects["protected"])
+		require.Equal(t, segmentProtected, objects["protected"].segment.Load())
+		for range 3 {
+			p.sketch.Increment("hot")
+		}
+		for range 2 {
+			p.sketch.Increment("frequent")
+		}
+
+		p.Order(ordered)
+		// the window holds only the newest object, so the older window objects
+		// compete with the probation segment: the one-off object loses to
+		// "probation", which loses to "frequent", which loses to "hot"
+		require.Equal(t, []string{"one-off", "probation", "frequent", "hot", "newest", "protected"},
+			objectKeys(ordered))
+
+		// the candidate that was not evicted is admitted to probation
+		p.Evicted(ordered[:2])
+		require.Equal(t, segmentProbation, objects["frequent"].segment.Load())
+		require.Equal(t, segmentWindow, objects["newest"].segment.Load())
+
+		// protected objects over the protected segment's share are demoted
+		a, b := &Object{Key: "a"}, &Object{Key: "b"}
+		a.segment.Store(segmentProtected)
+		b.segment.Store(segmentProtected)
+		p.Order([]*Object{a, b})
+		require.Equal(t, segmentProbation, a.segment.Load())
+		require.Equal(t, segmentProbation, b.segment.Load())
+	})
+
+	t.Run("lfu", func(t *testing.T) {
+		p := NewEvictionPolicy(options.EvictionPolicyLFU)
+		objects := testPolicyObjects()
+		for _, o := range objects {
+			p.Touch(o)
+		}
+		p.Touch(objects[1])
+		p.Touch(objects[2])
+		p.Order(objects)
+		require.Equal(t, []string{"a", "b", "c"}, objectKeys(objects))
+
+		// evicting "a" and "b" ages the cache to the priority of "b", so a
+		// single new access of "a" outranks the past popularity of "c"
+		p.Evicted(objects[:2])
+		a := &Object{Key: "a", Size: 10}
+		p.Touch(a)
+		objects = []*Object{a, objects[2]}
+		p.Order(objects)
+		require.Equal(t, []string{"c", "a"}, objectKeys(objects))
+	})
+
+	t.Run("gdsf", func(t *testing.T) {
+		p := NewEvictionPolicy(options.EvictionPolicyGDSF)
+		objects := testPolicyObjects()
+		for _, o := range objects {
+			p.Touch(o)
+			p.Touch(o)
+		}
+		p.Touch(objects[1])
+		p.Order(objects)
+		// the large object is evicted first despite its frequency
+		require.Equal(t, []string{"c", "a", "b"}, objectKeys(objects))
+	})
+}
+
+func TestIndexedClientEvictionPolicy(t *testing.T) {
+	mc := newMapClient()
+	o := defaultIndexOpts()
+	o.EvictionPolicy = options.EvictionPolicyLFU
+	o.MaxSizeObjects = 3
+	o.MaxSizeBackoffObjects = 0
+	ic := NewIndexedClient("test", "map", o, mc)
+	t.Cleanup(func() { _ = ic.Close() })
+
+	for _, key := range []string{"hot", "cold1", "cold2", "cold3"} {
+		require.NoError(t, ic.Store(key, []byte("value"), time.Hour))
+	}
+	for range 3 {
+		_, _, err := ic.Retrieve("hot")
+		require.NoError(t, err)
+	}
+	ic.reap()
+
+	_, ok := ic.Objects.Load("hot")
+	require.True(t, ok)
+	require.Equal(t, int64(3), ic.ObjectCount)
+
+	// rewriting an object retains its access count
+	require.NoError(t, ic.Store("hot", []byte("value2"), time.Hour))
+	v, _ := ic.Objects.Load("hot")
+	require.Equal(t, int64(5), v.(*Object).hits.Load())
+
+	// updating the options retains the policy state when the policy is unchanged
+	e := ic.loadEviction()
+	ic.UpdateOptions(o)
+	require.Same(t, e.policy, ic.loadEviction().policy)
+	o2 := defaultIndexOpts()
+	ic.UpdateOptions(o2)
+	require.Equal(t, options.EvictionPolicyLRU, ic.loadEviction().policy.Name())
+}
+
+func TestIndexedClientWTinyLFU(t *testing.T) {
+	mc := newMapClient()
+	o := defaultIndexOpts()
+	o.EvictionPolicy = options.EvictionPolicyWTinyLFU
+	o.MaxSizeObjects = 10
+	o.MaxSizeBackoffObjects = 0
+	ic := NewIndexedClient("test", "map", o, mc)
+	t.Cleanup(func() { _ = ic.Close() })
+
+	hot := []string{"hot1", "hot2", "hot3", "hot4", "hot5"}
+	for _, key := range hot {
+		require.NoError(t, ic.Store(key, []byte("value"), time.Hour))
+		for range 3 {
+			_, _, err := ic.Retrieve(key)
+			require.NoError(t, err)
+		}
+	}
+	// a scan of one-off objects doesn't push the frequently-read objects out
+	for i := range 20 {
+		require.NoError(t, ic.Store("scan"+strconv.Itoa(i), []byte("value"), time.Hour))
+		ic.reap()
+	}
+	require.Equal(t, int64(10), ic.ObjectCount)
+	for _, key := range hot {
+		_, ok := ic.Objects.Load(key)
+		require.True(t, ok, key)
+	}
+}
@@ -0,0 +1,339 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/util/sets"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+//go:generate go tool msgp -unexported
+
+//msgp:ignore TagIndex taggedKey
+
+const (
+	// TagPrefixBackend is the prefix of the tag that is automatically attached
+	// to every object written to the cache by a backend
+	TagPrefixBackend = "backend:"
+	// TagPrefixPath is the prefix of the tag that is automatically attached
+	// to every object written to the cache, identifying the request path
+	TagPrefixPath = "path:"
+	// TagPrefixChunk is the prefix of the tag that is attached to each chunk
+	// of a chunked object, identifying the cache key of the object
+	TagPrefixChunk = "chunk:"
+)
+
+// tagPruneInterval is the number of Add calls between sweeps for expired keys
+const tagPruneInterval = 1024
+
+// BackendTag returns the tag that identifies objects written by the named backend
+func BackendTag(backendName string) string {
+	return TagPrefixBackend + backendName
+}
+
+// PathTag returns the tag that identifies objects written for the request path
+func PathTag(path string) string {
+	return TagPrefixPath + path
+}
+
+// ChunkTag returns the tag that identifies the chunks of the object cached
+// under the cache key
+func ChunkTag(cacheKey string) string {
+	return TagPrefixChunk + cacheKey
+}
+
+type taggedKey struct {
+	tags    []string
+	expires time.Time
+}
+
+func (tk *taggedKey) expired(now time.Time) bool {
+	return !tk.expires.IsZero() && !tk.expires.After(now)
+}
+
+// tagEntry is the encoded form of the tags attached to a cache key
+type tagEntry struct {
+	Tags    []string `msg:"tags"`
+	Expires int64    `msg:"expires,omitempty"`
+}
+
+// tagEntries are the tagged cache keys of a TagIndex, as they are encoded
+type tagEntries map[string]tagEntry
+
+// TagIndex maps tags to the cache keys they are attached to, so that all keys
+// for a tag can be purged together. Entries expire with the objects they
+// describe. The TagIndex of an IndexedClient is persisted with its index.
+type TagIndex struct {
+	mtx  sync.Mutex
+	keys map[string]*taggedKey
+	tags map[string]sets.Set[string]
+	adds int
+}
+
+// NewTagIndex returns a new, empty TagIndex
+func NewTagIndex() *TagIndex {
+	return &TagIndex{
+		keys: make(map[string]*taggedKey),
+		tags: make(map[string]sets.Set[string]),
+	}
+}
+
+// Add attaches the provided tags to the cache key, replacing any tags that
+// were previously attached to it. A zero expires value never expires.
+func (ti *TagIndex) Add(cacheKey string, expires time.Time, tags ...string) {
+	if len(tags) == 0 {
+		return
+	}
+	ti.mtx.Lock()
+	defer ti.mtx.Unlock()
+	ti.remove(cacheKey)
+	tk := &taggedKey{tags: make([]string, 0, len(tags)), expires: expires}
+	for _, tag := range tags {
+		if tag == "" || slices.Contains(tk.tags, tag) {
+			continue
+		}
+		tk.tags = append(tk.tags, tag)
+		s, ok := ti.tags[tag]
+		if !ok {
+			s = sets.NewStringSet()
+			ti.tags[tag] = s
+		}
+		s.Set(cacheKey)
+	}
+	ti.keys[cacheKey] = tk
+	ti.adds++
+	if ti.adds >= tagPruneInterval {
+		ti.adds = 0
+		ti.prune(time.Now())
+	}
+}
+
+// Clear drops every cache key from the index
+func (ti *TagIndex) Clear() {
+	ti.mtx.Lock()
+	defer ti.mtx.Unlock()
+	clear(ti.keys)
+	clear(ti.tags)
+}
+
+// Remove drops the cache keys from the index
+func (ti *TagIndex) Remove(cacheKeys ...string) {
+	ti.mtx.Lock()
+	defer ti.mtx.Unlock()
+	for _, k := range cacheKeys {
+		ti.remove(k)
+	}
+}
+
+func (ti *TagIndex) remove(cacheKey string) {
+	tk, ok := ti.keys[cacheKey]
+	if !ok {
+		return
+	}
+	for _, tag := range tk.tags {
+		if s, ok := ti.tags[tag]; ok {
+			s.Remove(cacheKey)
+			if len(s) == 0 {
+				delete(ti.tags, tag)
+			}
+		}
+	}
+	delete(ti.keys, cacheKey)
+}
+
+func (ti *TagIndex) prune(now time.Time) {
+	for k, tk := range ti.keys {
+		if tk.expired(now) {
+			ti.remove(k)
+		}
+	}
+}
+
+// Select returns the sorted list of unexpired cache keys that have all of
+// the provided tags and start with prefix. With no tags and an empty prefix,
+// every unexpired key in the index is returned.
+func (ti *TagIndex) Select(tags []string, prefix string) []string {
+	ti.mtx.Lock()
+	defer ti.mtx.Unlock()
+	now := time.Now()
+	var candidates sets.Set[string]
+	for _, tag := range tags {
+		s, ok := ti.tags[tag]
+		if !ok {
+			return nil
+		}
+		if candidates == nil || len(s) < len(candidates) {
+			candidates = s
+		}
+	}
+	var out []string
+	add := func(k string) {
+		if !strings.HasPrefix(k, prefix) {
+			return
+		}
+		if tk, ok := ti.keys[k]; ok && !tk.expired(now) && hasAllTags(ti.tags, k, tags) {
+			out = append(out, k)
+		}
+	}
+	if candidates == nil {
+		for k := range ti.keys {
+			add(k)
+		}
+	} else {
+		for k := range candidates {
+			add(k)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+func hasAllTags(lookup map[string]sets.Set[string], cacheKey string, tags []string) bool {
+	for _, tag := range tags {
+		if !lookup[tag].Contains(cacheKey) {
+			return false
+		}
+	}
+	return true
+}
+
+// Objects returns an Object for each unexpired cache key in the index that
+// starts with prefix, sorted by key. Only the Key and Expiration of the
+// Objects are set.
+func (ti *TagIndex) Objects(prefix string) []*Object {
+	ti.mtx.Lock()
+	defer ti.mtx.Unlock()
+	now := time.Now()
+	out := make([]*Object, 0)
+	for k, tk := range ti.keys {
+		if !strings.HasPrefix(k, prefix) || tk.expired(now) {
+			continue
+		}
+		o := &Object{Key: k}
+		if !tk.expires.IsZero() {
+			o.Expiration.Store(tk.expires)
+		}
+		out = append(out, o)
+	}
+	slices.SortFunc(out, func(a, b *Object) int { return strings.Compare(a.Key, b.Key) })
+	return out
+}
+
+// Tags returns the tags attached to the cache key, or nil if it has none
+func (ti *TagIndex) Tags(cacheKey string) []string {
+	ti.mtx.Lock()
+	defer ti.mtx.Unlock()
+	tk, ok := ti.keys[cacheKey]
+	if !ok || tk.expired(time.Now()) {
+		return nil
+	}
+	return slices.Clone(tk.tags)
+}
+
+// Len returns the number of cache keys in the index
+func (ti *TagIndex) Len() int {
+	ti.mtx.Lock()
+	defer ti.mtx.Unlock()
+	return len(ti.keys)
+}
+
+func (ti *TagIndex) entries() tagEntries {
+	ti.mtx.Lock()
+	defer ti.mtx.Unlock()
+	now := time.Now()
+	out := make(tagEntries, len(ti.keys))
+	for k, tk := range ti.keys {
+		if tk.expired(now) {
+			continue
+		}
+		var e tagEntry
+		e.Tags = tk.tags
+		if !tk.expires.IsZero() {
+			e.Expires = tk.expires.UnixNano()
+		}
+		out[k] = e
+	}
+	return out
+}
+
+// load replaces the contents of the index with the unexpired entries
+func (ti *TagIndex) load(entries tagEntries) {
+	ti.mtx.Lock()
+	defer ti.mtx.Unlock()
+	if ti.keys == nil {
+		ti.keys = make(map[string]*taggedKey, len(entries))
+		ti.tags = make(map[string]sets.Set[string])
+	}
+	clear(ti.keys)
+	clear(ti.tags)
+	now := time.Now()
+	for k, e := range entries {
+		tk := &taggedKey{tags: e.Tags}
+		if e.Expires != 0 {
+			tk.expires = time.Unix(0, e.Expires)
+		}
+		if tk.expired(now) || len(tk.tags) == 0 {
+			continue
+		}
+		for _, tag := range tk.tags {
+			s, ok := ti.tags[tag]
+			if !ok {
+				s = sets.NewStringSet()
+				ti.tags[tag] = s
+			}
+			s.Set(k)
+		}
+		ti.keys[k] = tk
+	}
+}
+
+func (ti *TagIndex) EncodeMsg(en *msgp.Writer) error {
+	return ti.entries().EncodeMsg(en)
+}
+
+func (ti *TagIndex) DecodeMsg(dc *msgp.Reader) error {
+	entries := tagEntries{}
+	if err := entries.DecodeMsg(dc); err != nil {
+		return err
+	}
+	ti.load(entries)
+	return nil
+}
+
+func (ti *TagIndex) MarshalMsg(b []byte) ([]byte, error) {
+	return ti.entries().MarshalMsg(b)
+}
+
+func (ti *TagIndex) UnmarshalMsg(bts []byte) ([]byte, error) {
+	entries := tagEntries{}
+	o, err := entries.UnmarshalMsg(bts)
+	if err != nil {
+		return o, err
+	}
+	ti.load(entries)
+	return o, nil
+}
+
+func (ti *TagIndex) Msgsize() int {
+	return ti.entries().Msgsize()
+}
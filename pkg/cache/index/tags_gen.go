@@ -0,0 +1,491 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+package index
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// DecodeMsg implements msgp.Decodable
+func (z *tagEntries) DecodeMsg(dc *msgp.Reader) (err error) {
+	var zb0004 uint32
+	zb0004, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if (*z) == nil {
+		(*z) = make(tagEntries, zb0004)
+	} else if len((*z)) > 0 {
+		clear((*z))
+	}
+	var field []byte
+	_ = field
+	for zb0004 > 0 {
+		zb0004--
+		var zb0001 string
+		zb0001, err = dc.ReadString()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		var zb0002 tagEntry
+		var zb0005 uint32
+		zb0005, err = dc.ReadMapHeader()
+		if err != nil {
+			err = msgp.WrapError(err, zb0001)
+			return
+		}
+		for zb0005 > 0 {
+			zb0005--
+			field, err = dc.ReadMapKeyPtr()
+			if err != nil {
+				err = msgp.WrapError(err, zb0001)
+				return
+			}
+			switch msgp.UnsafeString(field) {
+			case "tags":
+				var zb0006 uint32
+				zb0006, err = dc.ReadArrayHeader()
+				if err != nil {
+					err = msgp.WrapError(err, zb0001, "Tags")
+					return
+				}
+				if cap(zb0002.Tags) >= int(zb0006) {
+					zb0002.Tags = (zb0002.Tags)[:zb0006]
+				} else {
+					zb0002.Tags = make([]string, zb0006)
+				}
+				for zb0003 := range zb0002.Tags {
+					zb0002.Tags[zb0003], err = dc.ReadString()
+					if err != nil {
+						err = msgp.WrapError(err, zb0001, "Tags", zb0003)
+						return
+					}
+				}
+			case "expires":
+				zb0002.Expires, err = dc.ReadInt64()
+				if err != nil {
+					err = msgp.WrapError(err, zb0001, "Expires")
+					return
+				}
+			default:
+				err = dc.Skip()
+				if err != nil {
+					err = msgp.WrapError(err, zb0001)
+					return
+				}
+			}
+		}
+		(*z)[zb0001] = zb0002
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z tagEntries) EncodeMsg(en *msgp.Writer) (err error) {
+	err = en.WriteMapHeader(uint32(len(z)))
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0007, zb0008 := range z {
+		err = en.WriteString(zb0007)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		// check for omitted fields
+		zb0001Len := uint32(2)
+		var zb0001Mask uint8 /* 2 bits */
+		_ = zb0001Mask
+		if zb0008.Expires == 0 {
+			zb0001Len--
+			zb0001Mask |= 0x2
+		}
+		// variable map header, size zb0001Len
+		err = en.Append(0x80 | uint8(zb0001Len))
+		if err != nil {
+			return
+		}
+
+		// skip if no fields are to be emitted
+		if zb0001Len != 0 {
+			// write "tags"
+			err = en.Append(0xa4, 0x74, 0x61, 0x67, 0x73)
+			if err != nil {
+				return
+			}
+			err = en.WriteArrayHeader(uint32(len(zb0008.Tags)))
+			if err != nil {
+				err = msgp.WrapError(err, zb0007, "Tags")
+				return
+			}
+			for zb0009 := range zb0008.Tags {
+				err = en.WriteString(zb0008.Tags[zb0009])
+				if err != nil {
+					err = msgp.WrapError(err, zb0007, "Tags", zb0009)
+					return
+				}
+			}
+			if (zb0001Mask & 0x2) == 0 { // if not omitted
+				// write "expires"
+				err = en.Append(0xa7, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73)
+				if err != nil {
+					return
+				}
+				err = en.WriteInt64(zb0008.Expires)
+				if err != nil {
+					err = msgp.WrapError(err, zb0007, "Expires")
+					return
+				}
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z tagEntries) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	o = msgp.AppendMapHeader(o, uint32(len(z)))
+	for zb0007, zb0008 := range z {
+		o = msgp.AppendString(o, zb0007)
+		// check for omitted fields
+		zb0001Len := uint32(2)
+		var zb0001Mask uint8 /* 2 bits */
+		_ = zb0001Mask
+		if zb0008.Expires == 0 {
+			zb0001Len--
+			zb0001Mask |= 0x2
+		}
+		// variable map header, size zb0001Len
+		o = append(o, 0x80|uint8(zb0001Len))
+
+		// skip if no fields are to be emitted
+		if zb0001Len != 0 {
+			// string "tags"
+			o = append(o, 0xa4, 0x74, 0x61, 0x67, 0x73)
+			o = msgp.AppendArrayHeader(o, uint32(len(zb0008.Tags)))
+			for zb0009 := range zb0008.Tags {
+				o = msgp.AppendString(o, zb0008.Tags[zb0009])
+			}
+			if (zb0001Mask & 0x2) == 0 { // if not omitted
+				// string "expires"
+				o = append(o, 0xa7, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73)
+				o = msgp.AppendInt64(o, zb0008.Expires)
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *tagEntries) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0004 uint32
+	zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if (*z) == nil {
+		(*z) = make(tagEntries, zb0004)
+	} else if len((*z)) > 0 {
+		clear((*z))
+	}
+	var field []byte
+	_ = field
+	for zb0004 > 0 {
+		var zb0002 tagEntry
+		zb0004--
+		var zb0001 string
+		zb0001, bts, err = msgp.ReadStringBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		var zb0005 uint32
+		zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err, zb0001)
+			return
+		}
+		for zb0005 > 0 {
+			zb0005--
+			field, bts, err = msgp.ReadMapKeyZC(bts)
+			if err != nil {
+				err = msgp.WrapError(err, zb0001)
+				return
+			}
+			switch msgp.UnsafeString(field) {
+			case "tags":
+				var zb0006 uint32
+				zb0006, bts, err = msgp.ReadArrayHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001, "Tags")
+					return
+				}
+				if cap(zb0002.Tags) >= int(zb0006) {
+					zb0002.Tags = (zb0002.Tags)[:zb0006]
+				} else {
+					zb0002.Tags = make([]string, zb0006)
+				}
+				for zb0003 := range zb0002.Tags {
+					zb0002.Tags[zb0003], bts, err = msgp.ReadStringBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, zb0001, "Tags", zb0003)
+						return
+					}
+				}
+			case "expires":
+				zb0002.Expires, bts, err = msgp.ReadInt64Bytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001, "Expires")
+					return
+				}
+			default:
+				bts, err = msgp.Skip(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001)
+					return
+				}
+			}
+		}
+		(*z)[zb0001] = zb0002
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z tagEntries) Msgsize() (s int) {
+	s = msgp.MapHeaderSize
+	if z != nil {
+		for zb0007, zb0008 := range z {
+			_ = zb0008
+			s += msgp.StringPrefixSize + len(zb0007) + 1 + 5 + msgp.ArrayHeaderSize
+			for zb0009 := range zb0008.Tags {
+				s += msgp.StringPrefixSize + len(zb0008.Tags[zb0009])
+			}
+			s += 8 + msgp.Int64Size
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *tagEntry) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "tags":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Tags")
+				return
+			}
+			if cap(z.Tags) >= int(zb0002) {
+				z.Tags = (z.Tags)[:zb0002]
+			} else {
+				z.Tags = make([]string, zb0002)
+			}
+			for za0001 := range z.Tags {
+				z.Tags[za0001], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Tags", za0001)
+					return
+				}
+			}
+		case "expires":
+			z.Expires, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Expires")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *tagEntry) EncodeMsg(en *msgp.Writer) (err error) {
+	// check for omitted fields
+	zb0001Len := uint32(2)
+	var zb0001Mask uint8 /* 2 bits */
+	_ = zb0001Mask
+	if z.Expires == 0 {
+		zb0001Len--
+		zb0001Mask |= 0x2
+	}
+	// variable map header, size zb0001Len
+	err = en.Append(0x80 | uint8(zb0001Len))
+	if err != nil {
+		return
+	}
+
+	// skip if no fields are to be emitted
+	if zb0001Len != 0 {
+		// write "tags"
+		err = en.Append(0xa4, 0x74, 0x61, 0x67, 0x73)
+		if err != nil {
+			return
+		}
+		err = en.WriteArrayHeader(uint32(len(z.Tags)))
+		if err != nil {
+			err = msgp.WrapError(err, "Tags")
+			return
+		}
+		for za0001 := range z.Tags {
+			err = en.WriteString(z.Tags[za0001])
+			if err != nil {
+				err = msgp.WrapError(err, "Tags", za0001)
+				return
+			}
+		}
+		if (zb0001Mask & 0x2) == 0 { // if not omitted
+			// write "expires"
+			err = en.Append(0xa7, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73)
+			if err != nil {
+				return
+			}
+			err = en.WriteInt64(z.Expires)
+			if err != nil {
+				err = msgp.WrapError(err, "Expires")
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *tagEntry) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// check for omitted fields
+	zb0001Len := uint32(2)
+	var zb0001Mask uint8 /* 2 bits */
+	_ = zb0001Mask
+	if z.Expires == 0 {
+		zb0001Len--
+		zb0001Mask |= 0x2
+	}
+	// variable map header, size zb0001Len
+	o = append(o, 0x80|uint8(zb0001Len))
+
+	// skip if no fields are to be emitted
+	if zb0001Len != 0 {
+		// string "tags"
+		o = append(o, 0xa4, 0x74, 0x61, 0x67, 0x73)
+		o = msgp.AppendArrayHeader(o, uint32(len(z.Tags)))
+		for za0001 := range z.Tags {
+			o = msgp.AppendString(o, z.Tags[za0001])
+		}
+		if (zb0001Mask & 0x2) == 0 { // if not omitted
+			// string "expires"
+			o = append(o, 0xa7, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73)
+			o = msgp.AppendInt64(o, z.Expires)
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *tagEntry) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "tags":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Tags")
+				return
+			}
+			if cap(z.Tags) >= int(zb0002) {
+				z.Tags = (z.Tags)[:zb0002]
+			} else {
+				z.Tags = make([]string, zb0002)
+			}
+			for za0001 := range z.Tags {
+				z.Tags[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Tags", za0001)
+					return
+				}
+			}
+		case "expires":
+			z.Expires, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Expires")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *tagEntry) Msgsize() (s int) {
+	s = 1 + 5 + msgp.ArrayHeaderSize
+	for za0001 := range z.Tags {
+		s += msgp.StringPrefixSize + len(z.Tags[za0001])
+	}
+	s += 8 + msgp.Int64Size
+	return
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagIndex(t *testing.T) {
+	ti := NewTagIndex()
+	future := time.Now().Add(time.Hour)
+	ti.Add("prom1.a", future, BackendTag("prom1"), PathTag("/api/v1/query"), "dash-1")
+	ti.Add("prom1.b", future, BackendTag("prom1"), "dash-1", "dash-2")
+	ti.Add("prom2.a", time.Time{}, BackendTag("prom2"), "dash-2", "dash-2")
+	ti.Add("untagged", future)
+	require.Equal(t, 3, ti.Len())
+
+	t.Run("select", func(t *testing.T) {
+		require.Equal(t, []string{"prom1.a", "prom1.b"}, ti.Select([]string{"dash-1"}, ""))
+		require.Equal(t, []string{"prom1.b", "prom2.a"}, ti.Select([]string{"dash-2"}, ""))
+		require.Equal(t, []string{"prom1.b"},
+			ti.Select([]string{BackendTag("prom1"), "dash-2"}, ""))
+		require.Equal(t, []string{"prom1.a", "prom1.b"}, ti.Select(nil, "prom1."))
+		require.Equal(t, []string{"prom2.a"}, ti.Select([]string{"dash-2"}, "prom2."))
+		require.Equal(t, []string{"prom1.a", "prom1.b", "prom2.a"}, ti.Select(nil, ""))
+		require.Empty(t, ti.Select([]string{"dash-3"}, ""))
+		require.Equal(t, []string{BackendTag("prom2"), "dash-2"}, ti.Tags("prom2.a"))
+	})
+
+	t.Run("objects", func(t *testing.T) {
+		objs := ti.Objects("prom")
+		require.Len(t, objs, 3)
+		require.Equal(t, "prom1.a", objs[0].Key)
+		require.True(t, objs[0].Expiration.Load().Equal(future))
+		require.Equal(t, "prom2.a", objs[2].Key)
+		require.True(t, objs[2].Expiration.Load().IsZero())
+		require.Empty(t, ti.Objects("prom3."))
+	})
+
+	t.Run("retag", func(t *testing.T) {
+		ti.Add("prom1.a", future, BackendTag("prom1"))
+		require.Equal(t, []string{"prom1.b"}, ti.Select([]string{"dash-1"}, ""))
+		require.Empty(t, ti.Select([]string{PathTag("/api/v1/query")}, ""))
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		ti.Remove("prom1.b", "missing")
+		require.Empty(t, ti.Select([]string{"dash-1"}, ""))
+		require.Nil(t, ti.Tags("prom1.b"))
+		require.Equal(t, 2, ti.Len())
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		ti.Add("expired", time.Now().Add(-time.Second), "dash-2")
+		require.Equal(t, []string{"prom2.a"}, ti.Select([]string{"dash-2"}, ""))
+		require.Nil(t, ti.Tags("expired"))
+		for i := range tagPruneInterval {
+			ti.Add("k"+strconv.Itoa(i), future, "bulk")
+		}
+		require.Nil(t, ti.keys["expired"])
+		require.Equal(t, 2+tagPruneInterval, ti.Len())
+	})
+}
+
+func TestTagIndexMarshal(t *testing.T) {
+	ti := NewTagIndex()
+	future := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	ti.Add("a", future, "t1", "t2")
+	ti.Add("b", time.Time{}, "t2")
+	ti.Add("expired", time.Now().Add(-time.Second), "t2")
+	b, err := ti.MarshalMsg(nil)
+	require.NoError(t, err)
+
+	ti2 := &TagIndex{}
+	_, err = ti2.UnmarshalMsg(b)
+	require.NoError(t, err)
+	require.Equal(t, 2, ti2.Len())
+	require.Equal(t, []string{"a", "b"}, ti2.Select([]string{"t2"}, ""))
+	require.Equal(t, []string{"t1", "t2"}, ti2.Tags("a"))
+	objs := ti2.Objects("")
+	require.True(t, objs[0].Expiration.Load().Equal(future))
+	require.True(t, objs[1].Expiration.Load().IsZero())
+}
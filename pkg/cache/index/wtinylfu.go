@@ -0,0 +1,163 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache/index/options"
+	"github.com/trickstercache/trickster/v2/pkg/util/sets"
+	"github.com/trickstercache/trickster/v2/pkg/util/sketch"
+)
+
+// the segments of the W-TinyLFU policy that an object can be in
+const (
+	segmentNone uint32 = iota
+	segmentWindow
+	segmentProbation
+	segmentProtected
+)
+
+const (
+	// wTinyLFUWindowPercent is the share of the cached objects held in the
+	// admission window
+	wTinyLFUWindowPercent = 1
+	// wTinyLFUProtectedPercent is the share of the main cache held in its
+	// protected segment
+	wTinyLFUProtectedPercent = 80
+)
+
+// wTinyLFUPolicy evicts objects using Window TinyLFU. New objects enter a
+// small lru admission window. When the window is over its share of the cache,
+// its least-recently-accessed objects compete for admission to the main cache
+// with the least-recently-accessed objects of the main cache's probation
+// segment, and the object whose key is estimated to be requested less often
+// is evicted first. Objects in the probation segment that are accessed again
+// are promoted to the protected segment, which is evicted from last, and the
+// least-recently-accessed protected objects are demoted to probation when the
+// protected segment is over its share of the main cache.
+type wTinyLFUPolicy struct {
+	sketch *sketch.CountMin
+
+	mu sync.Mutex
+	// candidates are the window objects that competed for admission during
+	// the current reap cycle
+	candidates []*Object
+}
+
+func newWTinyLFUPolicy() *wTinyLFUPolicy {
+	return &wTinyLFUPolicy{sketch: sketch.NewCountMin(sketch.DefaultWidth, 0)}
+}
+
+func (p *wTinyLFUPolicy) Name() string {
+	return options.EvictionPolicyWTinyLFU
+}
+
+func (p *wTinyLFUPolicy) Touch(o *Object) {
+	p.sketch.Increment(o.Key)
+	switch o.segment.Load() {
+	case segmentNone:
+		o.segment.Store(segmentWindow)
+	case segmentProbation:
+		o.segment.Store(segmentProtected)
+	}
+}
+
+func (p *wTinyLFUPolicy) Order(objects []*Object) {
+	var window, probation, protected []*Object
+	for _, o := range objects {
+		switch o.segment.Load() {
+		case segmentWindow:
+			window = append(window, o)
+		case segmentProtected:
+			protected = append(protected, o)
+		default:
+			// objects loaded from a persisted index have no segment and are
+			// treated as established objects
+			probation = append(probation, o)
+		}
+	}
+	slices.SortFunc(window, objectAtimeCmp)
+	slices.SortFunc(probation, objectAtimeCmp)
+	slices.SortFunc(protected, objectAtimeCmp)
+
+	windowSize := max(len(objects)*wTinyLFUWindowPercent/100, 1)
+	var candidates []*Object
+	if n := len(window) - windowSize; n > 0 {
+		candidates, window = window[:n], window[n:]
+	}
+	protectedSize := (len(objects) - windowSize) * wTinyLFUProtectedPercent / 100
+	if n := len(protected) - protectedSize; n > 0 {
+		for _, o := range protected[:n] {
+			o.segment.Store(segmentProbation)
+		}
+		probation = append(probation, protected[:n]...)
+		slices.SortFunc(probation, objectAtimeCmp)
+		protected = protected[n:]
+	}
+
+	order := make([]*Object, 0, len(objects))
+	var i, j int
+	for i < len(candidates) && j < len(probation) {
+		if p.sketch.Estimate(candidates[i].Key) > p.sketch.Estimate(probation[j].Key) {
+			order = append(order, probation[j])
+			j++
+			continue
+		}
+		order = append(order, candidates[i])
+		i++
+	}
+	// candidates left without probation objects to compete with, as when the
+	// cache first reaches its limits, compete with each other
+	rest := candidates[i:]
+	freq := make(map[*Object]int, len(rest))
+	for _, o := range rest {
+		freq[o] = p.sketch.Estimate(o.Key)
+	}
+	slices.SortStableFunc(rest, func(a, b *Object) int {
+		return cmp.Compare(freq[a], freq[b])
+	})
+	order = append(order, rest...)
+	order = append(order, probation[j:]...)
+	order = append(order, window...)
+	order = append(order, protected...)
+	copy(objects, order)
+
+	p.mu.Lock()
+	p.candidates = candidates
+	p.mu.Unlock()
+}
+
+// Evicted admits the candidates that were not evicted to the probation segment
+func (p *wTinyLFUPolicy) Evicted(objects []*Object) {
+	p.mu.Lock()
+	candidates := p.candidates
+	p.candidates = nil
+	p.mu.Unlock()
+	if len(candidates) == 0 {
+		return
+	}
+	evicted := sets.NewStringSet()
+	evicted.SetAll(objectKeys(objects))
+	for _, o := range candidates {
+		if !evicted.Contains(o.Key) {
+			o.segment.Store(segmentProbation)
+		}
+	}
+}
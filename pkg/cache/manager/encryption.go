@@ -0,0 +1,216 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/metrics"
+	"github.com/trickstercache/trickster/v2/pkg/cache/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
+)
+
+// envelopeVersion is the first byte of each encrypted value. The envelope is:
+//
+//	version | key id length | key id | wrapped data key | encrypted value
+//
+// where each value is encrypted with its own random data key, and the data key
+// is encrypted (wrapped) with the key encryption key named by the key id.
+// Both are sealed with AES-GCM, each prefixed by its nonce.
+const envelopeVersion byte = 1
+
+const dataKeySize = 32
+
+var (
+	// ErrEncryptionUnavailable is returned when an encrypted cache is written
+	// but its keys could not be loaded
+	ErrEncryptionUnavailable = errors.New("cache encryption keys are unavailable")
+
+	errMalformedEnvelope = errors.New("malformed envelope")
+	errUnknownKeyID      = errors.New("unknown key id")
+)
+
+// encryptedClient wraps a cache.Client with AES-GCM envelope encryption of
+// stored values. Values that can't be decrypted are treated as cache misses.
+type encryptedClient struct {
+	cache.Client
+	name     string
+	provider string
+	activeID string
+	keks     map[string]cipher.AEAD
+}
+
+func newEncryptedClient(cli cache.Client, cfg *options.Options) *encryptedClient {
+	ec := &encryptedClient{
+		Client:   cli,
+		name:     cfg.Name,
+		provider: cfg.Provider,
+		activeID: cfg.Encryption.ActiveKeyID,
+	}
+	if cfg.Encryption.KeyMaterial == nil {
+		if err := cfg.Encryption.Validate(); err != nil {
+			logger.Error("cache encryption keys failed to load",
+				logging.Pairs{"cache": cfg.Name, "detail": err.Error()})
+			return ec
+		}
+	}
+	keks := make(map[string]cipher.AEAD, len(cfg.Encryption.KeyMaterial))
+	for id, k := range cfg.Encryption.KeyMaterial {
+		aead, err := newGCM(k)
+		if err != nil {
+			logger.Error("cache encryption keys failed to load",
+				logging.Pairs{"cache": cfg.Name, "keyID": id, "detail": err.Error()})
+			return ec
+		}
+		keks[id] = aead
+	}
+	ec.keks = keks
+	return ec
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(b)
+}
+
+// seal returns a new AEAD-sealed copy of plaintext, prefixed with its nonce
+func seal(aead cipher.AEAD, dst, plaintext, aad []byte) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	rand.Read(nonce)
+	return aead.Seal(append(dst, nonce...), nonce, plaintext, aad)
+}
+
+// open opens a nonce-prefixed, AEAD-sealed value of length n from the front of
+// b, and returns the plaintext and the remainder of b
+func open(aead cipher.AEAD, b []byte, n int, aad []byte) ([]byte, []byte, error) {
+	if n < 0 {
+		n = len(b)
+	}
+	if n < aead.NonceSize()+aead.Overhead() || len(b) < n {
+		return nil, nil, errMalformedEnvelope
+	}
+	ns := aead.NonceSize()
+	out, err := aead.Open(nil, b[:ns], b[ns:n], aad)
+	return out, b[n:], err
+}
+
+func (ec *encryptedClient) encrypt(cacheKey string, data []byte) ([]byte, error) {
+	kek, ok := ec.keks[ec.activeID]
+	if !ok {
+		return nil, ErrEncryptionUnavailable
+	}
+	dek := make([]byte, dataKeySize)
+	rand.Read(dek)
+	aead, err := newGCM(dek)
+	if err != nil {
+		return nil, err
+	}
+	kid := []byte(ec.activeID)
+	b := make([]byte, 0, 2+len(kid)+2*(kek.NonceSize()+kek.Overhead())+
+		dataKeySize+len(data))
+	b = append(b, envelopeVersion, byte(len(kid)))
+	b = append(b, kid...)
+	b = seal(kek, b, dek, kid)
+	// the cache key is authenticated so that values can't be swapped between keys
+	return seal(aead, b, data, []byte(cacheKey)), nil
+}
+
+func (ec *encryptedClient) decrypt(cacheKey string, b []byte) ([]byte, error) {
+	if len(b) < 2 || b[0] != envelopeVersion || len(b) < 2+int(b[1]) {
+		return nil, errMalformedEnvelope
+	}
+	kid := b[2 : 2+int(b[1])]
+	kek, ok := ec.keks[string(kid)]
+	if !ok {
+		return nil, errUnknownKeyID
+	}
+	dek, b, err := open(kek, b[2+len(kid):],
+		kek.NonceSize()+dataKeySize+kek.Overhead(), kid)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(dek)
+	if err != nil {
+		return nil, err
+	}
+	data, _, err := open(aead, b, -1, []byte(cacheKey))
+	return data, err
+}
+
+func (ec *encryptedClient) Store(cacheKey string, data []byte, ttl time.Duration) error {
+	b, err := ec.encrypt(cacheKey, data)
+	if err != nil {
+		return err
+	}
+	return ec.Client.Store(cacheKey, b, ttl)
+}
+
+func (ec *encryptedClient) Retrieve(cacheKey string) ([]byte, status.LookupStatus, error) {
+	b, s, err := ec.Client.Retrieve(cacheKey)
+	if err != nil || s != status.LookupStatusHit {
+		return b, s, err
+	}
+	data, err := ec.decrypt(cacheKey, b)
+	if err != nil {
+		logger.Debug("cache decrypt failed",
+			logging.Pairs{"key": cacheKey, "provider": ec.provider, "detail": err.Error()})
+		metrics.ObserveCacheEvent(ec.name, ec.provider, "decrypt_failure", err.Error())
+		return nil, status.LookupStatusKeyMiss, cache.ErrKNF
+	}
+	return data, s, nil
+}
+
+func (ec *encryptedClient) MultiStore(data map[string][]byte, ttl time.Duration) error {
+	enc := make(map[string][]byte, len(data))
+	for k, v := range data {
+		b, err := ec.encrypt(k, v)
+		if err != nil {
+			return err
+		}
+		enc[k] = b
+	}
+	return cache.MultiStore(ec.Client, enc, ttl)
+}
+
+func (ec *encryptedClient) MultiRetrieve(cacheKeys ...string) (map[string][]byte, error) {
+	out, err := cache.MultiRetrieve(ec.Client, cacheKeys...)
+	if err != nil {
+		return nil, err
+	}
+	for k, b := range out {
+		data, err := ec.decrypt(k, b)
+		if err != nil {
+			logger.Debug("cache decrypt failed",
+				logging.Pairs{"key": k, "provider": ec.provider, "detail": err.Error()})
+			metrics.ObserveCacheEvent(ec.name, ec.provider, "decrypt_failure", err.Error())
+			delete(out, k)
+			continue
+		}
+		out[k] = data
+	}
+	return out, nil
+}
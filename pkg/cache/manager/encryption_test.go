@@ -0,0 +1,109 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	eo "github.com/trickstercache/trickster/v2/pkg/cache/encryption/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/memory"
+	co "github.com/trickstercache/trickster/v2/pkg/cache/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	"github.com/trickstercache/trickster/v2/pkg/config/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testEncryptionKey(b byte) types.EnvString {
+	return types.EnvString(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32)))
+}
+
+func newEncryptedTestCache(t *testing.T, mc *memory.Cache, active string,
+	keys ...string,
+) *Manager {
+	t.Helper()
+	cfg := &co.Options{Name: "test", Provider: "memory", Encryption: eo.New()}
+	cfg.Encryption.ActiveKeyID = active
+	for i, id := range keys {
+		cfg.Encryption.Keys[id] = &eo.KeyOptions{Key: testEncryptionKey(byte(i + 1))}
+	}
+	return NewCache(mc, CacheOptions{}, cfg).(*Manager)
+}
+
+func TestEncryptedClient(t *testing.T) {
+	mc := memory.New("test", &co.Options{Provider: "memory"})
+	c := newEncryptedTestCache(t, mc, "k1", "k1")
+
+	t.Run("round trip", func(t *testing.T) {
+		require.NoError(t, c.Store("foo", []byte("bar"), 0))
+		b, s, err := c.Retrieve("foo")
+		require.NoError(t, err)
+		require.Equal(t, status.LookupStatusHit, s)
+		require.Equal(t, []byte("bar"), b)
+
+		// the stored value is the envelope, not the plaintext
+		raw, _, err := mc.Retrieve("foo")
+		require.NoError(t, err)
+		require.Equal(t, envelopeVersion, raw[0])
+		require.Equal(t, []byte("k1"), raw[2:4])
+		require.False(t, bytes.Contains(raw, []byte("bar")))
+	})
+
+	t.Run("rotation", func(t *testing.T) {
+		require.NoError(t, c.Store("rotated", []byte("old"), 0))
+		c2 := newEncryptedTestCache(t, mc, "k2", "k1", "k2")
+		b, s, err := c2.Retrieve("rotated")
+		require.NoError(t, err)
+		require.Equal(t, status.LookupStatusHit, s)
+		require.Equal(t, []byte("old"), b)
+		require.NoError(t, c2.Store("rotated", []byte("new"), 0))
+		raw, _, _ := mc.Retrieve("rotated")
+		require.Equal(t, []byte("k2"), raw[2:4])
+	})
+
+	t.Run("unknown key id is a miss", func(t *testing.T) {
+		require.NoError(t, c.Store("unknown", []byte("bar"), 0))
+		c2 := newEncryptedTestCache(t, mc, "k2", "k2")
+		_, s, err := c2.Retrieve("unknown")
+		require.ErrorIs(t, err, cache.ErrKNF)
+		require.Equal(t, status.LookupStatusKeyMiss, s)
+	})
+
+	t.Run("moved value is a miss", func(t *testing.T) {
+		require.NoError(t, c.Store("a", []byte("bar"), 0))
+		raw, _, _ := mc.Retrieve("a")
+		require.NoError(t, mc.Store("b", raw, 0))
+		_, s, err := c.Retrieve("b")
+		require.ErrorIs(t, err, cache.ErrKNF)
+		require.Equal(t, status.LookupStatusKeyMiss, s)
+	})
+
+	t.Run("malformed value is a miss", func(t *testing.T) {
+		require.NoError(t, mc.Store("plain", []byte("bar"), 0))
+		_, s, err := c.Retrieve("plain")
+		require.ErrorIs(t, err, cache.ErrKNF)
+		require.Equal(t, status.LookupStatusKeyMiss, s)
+	})
+
+	t.Run("unavailable keys", func(t *testing.T) {
+		c2 := newEncryptedTestCache(t, mc, "missing", "k1")
+		require.ErrorIs(t, c2.Store("foo", []byte("bar"), 0), ErrEncryptionUnavailable)
+	})
+}
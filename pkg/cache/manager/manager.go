@@ -18,6 +18,7 @@ package manager
 
 import (
 	"errors"
+	"slices"
 	"sync"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/cache/index"
 	"github.com/trickstercache/trickster/v2/pkg/cache/metrics"
 	"github.com/trickstercache/trickster/v2/pkg/cache/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/providers"
 	"github.com/trickstercache/trickster/v2/pkg/cache/status"
 	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
 	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
@@ -32,6 +34,13 @@ import (
 	"golang.org/x/sync/singleflight"
 )
 
+var (
+	_ cache.Tagger     = &Manager{}
+	_ cache.MultiCache = &Manager{}
+	_ cache.Leaser     = &Manager{}
+	_ index.Lister     = &Manager{}
+)
+
 // DefaultCloseDrainHardTimeout is the absolute upper bound a draining Close()
 // will wait for in-flight cache operations before invoking the underlying
 // client Close anyway. Prevents one stuck request from blocking reload forever.
@@ -55,12 +64,17 @@ func NewCache(cli cache.Client, cacheOpts CacheOptions, cacheConfig *options.Opt
 		originalCli: cli,
 		config:      cacheConfig,
 		opts:        cacheOpts,
+		tags:        index.NewTagIndex(),
+	}
+	if cacheConfig != nil && cacheConfig.Encryption != nil {
+		cm.Client = newEncryptedClient(cli, cacheConfig)
 	}
 	return cm
 }
 
 // Manager implements the cache.Cache interface for Trickster, providing an abstracted
-// cache layer with metrics, locking, and optional index / LRU-key-reaper.
+// cache layer with metrics, locking, optional encryption at rest, and optional
+// index / LRU-key-reaper.
 //
 // Manager also tracks in-flight Store/Retrieve/Remove operations so that
 // Close() can drain them before tearing down the underlying client. This is
@@ -74,6 +88,10 @@ type Manager struct {
 	sf          singleflight.Group
 	config      *options.Options
 	opts        CacheOptions
+	// tags maps the tags attached to objects written through this Manager
+	// to their cache keys, for tag-based purging, when the cache is not
+	// indexed. Indexed caches persist the tags with their index.
+	tags *index.TagIndex
 
 	// mu serializes acquire/release vs Close. WaitGroup forbids concurrent
 	// Add and Wait, so the closing flag and Add(1) live under one lock.
@@ -197,9 +215,166 @@ func (cm *Manager) Remove(cacheKeys ...string) error {
 	defer cm.release()
 	metrics.ObserveCacheDel(cm.config.Name, cm.config.Provider, float64(len(cacheKeys)))
 	logger.Debug("cache remove", logging.Pairs{"keys": cacheKeys, "provider": cm.config.Provider})
+	cm.tagIndex().Remove(cacheKeys...)
 	return cm.Client.Remove(cacheKeys...)
 }
 
+// MultiStore stores each value in data under its cache key for the ttl, in a
+// single batch when the cache client supports it
+func (cm *Manager) MultiStore(data map[string][]byte, ttl time.Duration) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if !cm.acquire() {
+		return ErrCacheClosed
+	}
+	defer cm.release()
+	for k, v := range data {
+		metrics.ObserveCacheOperation(cm.config.Name, cm.config.Provider, "set", "none", float64(len(v)))
+		logger.Debug("cache store", logging.Pairs{"key": k, "provider": cm.config.Provider})
+	}
+	return cache.MultiStore(cm.Client, data, ttl)
+}
+
+// MultiRetrieve returns the values of the cache keys that were found, in a
+// single batch when the cache client supports it
+func (cm *Manager) MultiRetrieve(cacheKeys ...string) (map[string][]byte, error) {
+	if len(cacheKeys) == 0 {
+		return map[string][]byte{}, nil
+	}
+	if !cm.acquire() {
+		return nil, ErrCacheClosed
+	}
+	defer cm.release()
+	out, err := cache.MultiRetrieve(cm.Client, cacheKeys...)
+	for _, k := range cacheKeys {
+		switch b, ok := out[k]; {
+		case err != nil:
+			cm.observeRetrieval(k, 0, status.LookupStatusError, err)
+		case ok:
+			cm.observeRetrieval(k, len(b), status.LookupStatusHit, nil)
+		default:
+			cm.observeRetrieval(k, 0, status.LookupStatusKeyMiss, cache.ErrKNF)
+		}
+	}
+	return out, err
+}
+
+// SupportsMulti returns true if the cache client stores and retrieves
+// multiple cache keys in a single batch. An indexed client does not, since
+// each key must pass through the index.
+func (cm *Manager) SupportsMulti() bool {
+	if _, ok := cm.Client.(*index.IndexedClient); ok {
+		return false
+	}
+	_, ok := cm.originalCli.(cache.MultiClient)
+	return ok
+}
+
+// AcquireLease takes the lease on the cache key for the ttl, when the cache
+// client supports leases
+func (cm *Manager) AcquireLease(cacheKey string, ttl time.Duration) (string, bool, error) {
+	l, ok := cm.originalCli.(cache.Leaser)
+	if !ok {
+		return "", false, cache.ErrLeasesUnsupported
+	}
+	if !cm.acquire() {
+		return "", false, ErrCacheClosed
+	}
+	defer cm.release()
+	return l.AcquireLease(cacheKey, ttl)
+}
+
+// ReleaseLease releases the lease on the cache key, if it is still held with the token
+func (cm *Manager) ReleaseLease(cacheKey, token string) error {
+	l, ok := cm.originalCli.(cache.Leaser)
+	if !ok {
+		return cache.ErrLeasesUnsupported
+	}
+	if !cm.acquire() {
+		return ErrCacheClosed
+	}
+	defer cm.release()
+	return l.ReleaseLease(cacheKey, token)
+}
+
+// tagIndex returns the tag index of the cache, which is persisted with the
+// cache index when the cache is indexed, and otherwise held by the Manager
+func (cm *Manager) tagIndex() *index.TagIndex {
+	if idx, ok := cm.Client.(*index.IndexedClient); ok {
+		return idx.TagIndex
+	}
+	return cm.tags
+}
+
+// tagIndexComplete returns true when the tag index includes every object in
+// the cache. The Manager's own tag index is held in memory, so it only does
+// for a memory cache that is not restored from a snapshot; the objects of
+// other caches can outlive it, or be written by other Trickster instances.
+func (cm *Manager) tagIndexComplete() bool {
+	if _, ok := cm.Client.(*index.IndexedClient); ok {
+		return true
+	}
+	return cm.config.Provider == providers.Memory &&
+		(cm.config.Memory == nil || cm.config.Memory.SnapshotPath == "")
+}
+
+// selectTagged returns the cache keys that have all of the provided tags and
+// start with prefix
+func (cm *Manager) selectTagged(tags []string, prefix string) ([]string, error) {
+	ti := cm.tagIndex()
+	if ti.Len() == 0 && !cm.tagIndexComplete() {
+		return nil, cache.ErrTagIndexUnavailable
+	}
+	return ti.Select(tags, prefix), nil
+}
+
+// Tag attaches the tags to the cache key in the cache's tag index
+func (cm *Manager) Tag(cacheKey string, ttl time.Duration, tags ...string) {
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+	cm.tagIndex().Add(cacheKey, expires, tags...)
+}
+
+// TaggedKeys returns the cache keys that have all of the provided tags and
+// start with prefix
+func (cm *Manager) TaggedKeys(tags []string, prefix string) ([]string, error) {
+	return cm.selectTagged(tags, prefix)
+}
+
+// PurgeTagged removes every cache key that has all of the provided tags and
+// starts with prefix, along with the chunks of those keys, and returns the
+// number of keys that were removed
+func (cm *Manager) PurgeTagged(tags []string, prefix string) (int, error) {
+	keys, err := cm.selectTagged(tags, prefix)
+	if err != nil || len(keys) == 0 {
+		return 0, err
+	}
+	n := len(keys)
+	ti := cm.tagIndex()
+	for _, k := range keys[:n] {
+		keys = append(keys, ti.Select([]string{index.ChunkTag(k)}, "")...)
+	}
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+	logger.Debug("cache purge tagged", logging.Pairs{"tags": tags,
+		"prefix": prefix, "keys": n, "chunks": len(keys) - n,
+		"provider": cm.config.Provider})
+	return n, cm.Remove(keys...)
+}
+
+// IndexedObjects returns the metadata of each object in the cache index whose
+// key starts with prefix. When the cache is not indexed, the keys in the
+// Manager's tag index are returned instead, along with false.
+func (cm *Manager) IndexedObjects(prefix string) ([]*index.Object, bool) {
+	if idx, ok := cm.Client.(*index.IndexedClient); ok {
+		return idx.Select(prefix), true
+	}
+	return cm.tagIndex().Objects(prefix), false
+}
+
 // Close marks the Manager as closing, waits for in-flight cache operations
 // to drain, then closes the underlying client. The drain wait is bounded by
 // closeDrainTimeout (default DefaultCloseDrainHardTimeout); if it elapses the
@@ -242,11 +417,17 @@ func (cm *Manager) Connect() error {
 		return err
 	}
 	if cm.opts.UseIndex {
+		// the index wraps the encrypted client, if any, so that its
+		// persisted index is also encrypted
+		cli := cm.originalCli
+		if ec, ok := cm.Client.(*encryptedClient); ok {
+			cli = ec
+		}
 		cm.Client = index.NewIndexedClient(
 			cm.config.Name,
 			cm.config.Provider,
 			cm.config.Index,
-			cm.originalCli,
+			cli,
 			func(ico *index.IndexedClientOptions) {
 				*ico = cm.opts.IndexCliOpts
 			},
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/index"
 	"github.com/trickstercache/trickster/v2/pkg/cache/memory"
 	co "github.com/trickstercache/trickster/v2/pkg/cache/options"
 	"github.com/trickstercache/trickster/v2/pkg/cache/status"
@@ -300,3 +301,251 @@ func TestManagerCloseConcurrent(t *testing.T) {
 		}
 	}
 }
+
+func TestManagerTags(t *testing.T) {
+	cacheConfig := co.Options{Provider: "memory"}
+	c := NewCache(memory.New("test", &cacheConfig), CacheOptions{}, &cacheConfig)
+	tg, ok := c.(cache.Tagger)
+	require.True(t, ok)
+	for _, k := range []string{"a.1", "a.2", "b.1"} {
+		require.NoError(t, c.Store(k, []byte(k), 0))
+	}
+	tg.Tag("a.1", time.Minute, "t1")
+	tg.Tag("a.2", time.Minute, "t1", "t2")
+	tg.Tag("b.1", 0, "t2")
+
+	n, err := tg.PurgeTagged([]string{"t2"}, "a.")
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	_, _, err = c.Retrieve("a.2")
+	require.ErrorIs(t, err, cache.ErrKNF)
+
+	// removing a key directly also drops it from the tag index
+	require.NoError(t, c.Remove("a.1"))
+	n, err = tg.PurgeTagged([]string{"t1"}, "")
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+
+	n, err = tg.PurgeTagged([]string{"t2"}, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	_, _, err = c.Retrieve("b.1")
+	require.ErrorIs(t, err, cache.ErrKNF)
+}
+
+func TestManagerTagIndex(t *testing.T) {
+	t.Run("chunks", func(t *testing.T) {
+		cacheConfig := co.Options{Provider: "memory"}
+		c := NewCache(memory.New("test", &cacheConfig), CacheOptions{}, &cacheConfig)
+		tg := c.(cache.Tagger)
+		for _, k := range []string{"a", "a.1", "a.2", "b"} {
+			require.NoError(t, c.Store(k, []byte(k), 0))
+		}
+		tg.Tag("a", time.Minute, "t1")
+		tg.Tag("a.1", time.Minute, index.ChunkTag("a"))
+		tg.Tag("a.2", time.Minute, index.ChunkTag("a"))
+		tg.Tag("b", time.Minute, "t1")
+
+		keys, err := tg.TaggedKeys([]string{"t1"}, "a")
+		require.NoError(t, err)
+		require.Equal(t, []string{"a"}, keys)
+		n, err := tg.PurgeTagged([]string{"t1"}, "a")
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		for _, k := range []string{"a", "a.1", "a.2"} {
+			_, _, err = c.Retrieve(k)
+			require.ErrorIs(t, err, cache.ErrKNF)
+		}
+		_, _, err = c.Retrieve("b")
+		require.NoError(t, err)
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		// the tags of a cache that outlives the Manager are not known when
+		// its tag index is empty
+		cacheConfig := co.Options{Provider: "redis"}
+		c := NewCache(memory.New("test", &cacheConfig), CacheOptions{}, &cacheConfig)
+		tg := c.(cache.Tagger)
+		_, err := tg.TaggedKeys([]string{"t1"}, "")
+		require.ErrorIs(t, err, cache.ErrTagIndexUnavailable)
+		_, err = tg.PurgeTagged([]string{"t1"}, "")
+		require.ErrorIs(t, err, cache.ErrTagIndexUnavailable)
+		tg.Tag("a", time.Minute, "t2")
+		keys, err := tg.TaggedKeys([]string{"t1"}, "")
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+
+	t.Run("indexed", func(t *testing.T) {
+		// the tags of an indexed cache are held by its index, which persists them
+		cacheConfig := co.New()
+		cacheConfig.Provider = "bbolt"
+		c := NewCache(memory.New("test", cacheConfig), CacheOptions{UseIndex: true}, cacheConfig)
+		require.NoError(t, c.Connect())
+		require.NoError(t, c.Store("a", []byte("a"), time.Minute))
+		c.(cache.Tagger).Tag("a", time.Minute, "t1")
+		idx := c.(*Manager).Client.(*index.IndexedClient)
+		require.Equal(t, []string{"t1"}, idx.TagIndex.Tags("a"))
+		require.Zero(t, c.(*Manager).tags.Len())
+	})
+}
+
+func TestManagerIndexedObjects(t *testing.T) {
+	t.Run("indexed", func(t *testing.T) {
+		cacheConfig := co.New()
+		c := NewCache(memory.New("test", cacheConfig), CacheOptions{UseIndex: true}, cacheConfig)
+		require.NoError(t, c.Connect())
+		require.NoError(t, c.Store("a.1", []byte("abc"), time.Minute))
+		require.NoError(t, c.Store("b.1", []byte("abc"), time.Minute))
+		objs, indexed := c.(index.Lister).IndexedObjects("a.")
+		require.True(t, indexed)
+		require.Len(t, objs, 1)
+		require.Equal(t, "a.1", objs[0].Key)
+		require.Equal(t, int64(3), objs[0].Size)
+	})
+
+	t.Run("tagged", func(t *testing.T) {
+		cacheConfig := co.Options{Provider: "memory"}
+		c := NewCache(memory.New("test", &cacheConfig), CacheOptions{}, &cacheConfig)
+		require.NoError(t, c.Store("a.1", []byte("abc"), time.Minute))
+		require.NoError(t, c.Store("a.2", []byte("abc"), time.Minute))
+		c.(cache.Tagger).Tag("a.1", time.Minute, "t1")
+		objs, indexed := c.(index.Lister).IndexedObjects("a.")
+		require.False(t, indexed)
+		require.Len(t, objs, 1)
+		require.Equal(t, "a.1", objs[0].Key)
+		require.False(t, objs[0].Expiration.Load().IsZero())
+	})
+}
+
+// multiClient is a memory cache client that counts its batch operations
+type multiClient struct {
+	*memory.Cache
+	stores, retrieves atomic.Int32
+}
+
+func (m *multiClient) MultiStore(data map[string][]byte, ttl time.Duration) error {
+	m.stores.Add(1)
+	for k, v := range data {
+		if err := m.Store(k, v, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *multiClient) MultiRetrieve(cacheKeys ...string) (map[string][]byte, error) {
+	m.retrieves.Add(1)
+	out := make(map[string][]byte, len(cacheKeys))
+	for _, k := range cacheKeys {
+		if b, _, err := m.Retrieve(k); err == nil {
+			out[k] = b
+		}
+	}
+	return out, nil
+}
+
+func TestManagerMulti(t *testing.T) {
+	data := map[string][]byte{"a": []byte("1"), "b": []byte("2")}
+
+	t.Run("fallback", func(t *testing.T) {
+		cfg := &co.Options{Name: "test", Provider: "memory"}
+		cm := NewCache(memory.New("test", cfg), CacheOptions{}, cfg).(*Manager)
+		require.False(t, cm.SupportsMulti())
+		require.NoError(t, cm.MultiStore(data, 0))
+		out, err := cm.MultiRetrieve("a", "b", "c")
+		require.NoError(t, err)
+		require.Equal(t, data, out)
+	})
+
+	t.Run("native", func(t *testing.T) {
+		cfg := &co.Options{Name: "test", Provider: "memory"}
+		mc := &multiClient{Cache: memory.New("test", cfg)}
+		cm := NewCache(mc, CacheOptions{}, cfg).(*Manager)
+		require.True(t, cm.SupportsMulti())
+		require.NoError(t, cm.MultiStore(data, 0))
+		out, err := cm.MultiRetrieve("a", "b", "c")
+		require.NoError(t, err)
+		require.Equal(t, data, out)
+		require.Equal(t, int32(1), mc.stores.Load())
+		require.Equal(t, int32(1), mc.retrieves.Load())
+	})
+
+	t.Run("encrypted", func(t *testing.T) {
+		mc := &multiClient{Cache: memory.New("test", &co.Options{Provider: "memory"})}
+		cm := newEncryptedTestCache(t, mc.Cache, "k1", "k1")
+		cm.Client = newEncryptedClient(mc, cm.config)
+		cm.originalCli = mc
+		require.True(t, cm.SupportsMulti())
+		require.NoError(t, cm.MultiStore(data, 0))
+		raw, _, err := mc.Retrieve("a")
+		require.NoError(t, err)
+		require.Equal(t, envelopeVersion, raw[0])
+		out, err := cm.MultiRetrieve("a", "b", "c")
+		require.NoError(t, err)
+		require.Equal(t, data, out)
+		require.Equal(t, int32(1), mc.retrieves.Load())
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		cfg := &co.Options{Name: "test", Provider: "memory"}
+		cm := NewCache(memory.New("test", cfg), CacheOptions{}, cfg).(*Manager)
+		require.NoError(t, cm.Close())
+		require.ErrorIs(t, cm.MultiStore(data, 0), ErrCacheClosed)
+		_, err := cm.MultiRetrieve("a")
+		require.ErrorIs(t, err, ErrCacheClosed)
+	})
+}
+
+type leaseClient struct {
+	*memory.Cache
+	mu     sync.Mutex
+	leases map[string]string
+}
+
+func (l *leaseClient) AcquireLease(cacheKey string, _ time.Duration) (string, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.leases[cacheKey]; ok {
+		return "", false, nil
+	}
+	l.leases[cacheKey] = "token-" + cacheKey
+	return l.leases[cacheKey], true, nil
+}
+
+func (l *leaseClient) ReleaseLease(cacheKey, token string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.leases[cacheKey] == token {
+		delete(l.leases, cacheKey)
+	}
+	return nil
+}
+
+func TestManagerLease(t *testing.T) {
+	t.Run("unsupported", func(t *testing.T) {
+		cfg := &co.Options{Name: "test", Provider: "memory"}
+		cm := NewCache(memory.New("test", cfg), CacheOptions{}, cfg).(*Manager)
+		_, _, err := cm.AcquireLease("a", time.Second)
+		require.ErrorIs(t, err, cache.ErrLeasesUnsupported)
+		require.ErrorIs(t, cm.ReleaseLease("a", "x"), cache.ErrLeasesUnsupported)
+	})
+
+	t.Run("supported", func(t *testing.T) {
+		cfg := &co.Options{Name: "test", Provider: "memory"}
+		lc := &leaseClient{Cache: memory.New("test", cfg), leases: map[string]string{}}
+		cm := NewCache(lc, CacheOptions{}, cfg).(*Manager)
+		token, ok, err := cm.AcquireLease("a", time.Second)
+		require.NoError(t, err)
+		require.True(t, ok)
+		_, ok, err = cm.AcquireLease("a", time.Second)
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.NoError(t, cm.ReleaseLease("a", token))
+		_, ok, _ = cm.AcquireLease("a", time.Second)
+		require.True(t, ok)
+		require.NoError(t, cm.Close())
+		_, _, err = cm.AcquireLease("b", time.Second)
+		require.ErrorIs(t, err, ErrCacheClosed)
+	})
+}
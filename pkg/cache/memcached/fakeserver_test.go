@@ -0,0 +1,151 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memcached
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeServer is a minimal in-process memcached server that implements the
+// subset of the text protocol used by the CacheClient
+type fakeServer struct {
+	ln          net.Listener
+	maxItemSize int
+
+	mu    sync.Mutex
+	items map[string]fakeItem
+	sets  int
+	// read is the number of value bytes returned by gets
+	read int
+}
+
+type fakeItem struct {
+	value []byte
+	flags uint32
+}
+
+func newFakeServer(t *testing.T, maxItemSize int) *fakeServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeServer{ln: ln, maxItemSize: maxItemSize,
+		items: make(map[string]fakeItem)}
+	go s.serve()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+func (s *fakeServer) Addr() string {
+	return s.ln.Addr().String()
+}
+
+func (s *fakeServer) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+func (s *fakeServer) Read() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read
+}
+
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeServer) handle(conn net.Conn) {
+	defer conn.Close()
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+	for {
+		line, err := rw.ReadString('\n')
+		if err != nil {
+			return
+		}
+		f := strings.Fields(line)
+		if len(f) == 0 {
+			continue
+		}
+		switch f[0] {
+		case "version":
+			fmt.Fprint(rw, "VERSION 1.6.0-fake\r\n")
+		case "get", "gets":
+			s.mu.Lock()
+			for _, k := range f[1:] {
+				if it, ok := s.items[k]; ok {
+					fmt.Fprintf(rw, "VALUE %s %d %d 0\r\n", k, it.flags, len(it.value))
+					rw.Write(it.value)
+					s.read += len(it.value)
+					fmt.Fprint(rw, "\r\n")
+				}
+			}
+			s.mu.Unlock()
+			fmt.Fprint(rw, "END\r\n")
+		case "set":
+			if len(f) < 5 {
+				fmt.Fprint(rw, "ERROR\r\n")
+				break
+			}
+			flags, _ := strconv.ParseUint(f[2], 10, 32)
+			n, _ := strconv.Atoi(f[4])
+			b := make([]byte, n+2)
+			if _, err := io.ReadFull(rw, b); err != nil {
+				return
+			}
+			if n > s.maxItemSize {
+				fmt.Fprint(rw, "SERVER_ERROR object too large for cache\r\n")
+				break
+			}
+			s.mu.Lock()
+			s.items[f[1]] = fakeItem{value: b[:n], flags: uint32(flags)}
+			s.sets++
+			s.mu.Unlock()
+			fmt.Fprint(rw, "STORED\r\n")
+		case "delete":
+			s.mu.Lock()
+			_, ok := s.items[f[1]]
+			delete(s.items, f[1])
+			s.mu.Unlock()
+			if ok {
+				fmt.Fprint(rw, "DELETED\r\n")
+			} else {
+				fmt.Fprint(rw, "NOT_FOUND\r\n")
+			}
+		default:
+			fmt.Fprint(rw, "ERROR\r\n")
+		}
+		if err := rw.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,312 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package memcached is the memcached implementation of the Trickster Cache
+package memcached
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+// CacheClient implements the cache.Client interface
+var _ cache.Client = &CacheClient{}
+
+const (
+	// maxKeyLength is the longest key memcached accepts, less room for the
+	// write token and suffixes used by the keys of split object parts and
+	// manifests
+	maxKeyLength = 250 - 32
+	// tokenLength is the byte length of the random token identifying the
+	// parts written by a single Store of a split object
+	tokenLength = 8
+	// itemOverhead is subtracted from the max item size to leave room for the
+	// key and item header, which count against memcached's item size limit
+	itemOverhead = 512
+	// flagSplit marks an item or manifest whose value is the write token and
+	// part count of an object that was split across multiple items
+	flagSplit = uint32(1)
+	// maxRelativeExpiration is the longest expiration memcached interprets as
+	// relative seconds; larger values are interpreted as unix timestamps
+	maxRelativeExpiration = 60 * 60 * 24 * 30
+)
+
+// ErrInvalidSplitItem is returned when the item referencing the parts of a split
+// object is corrupt
+var ErrInvalidSplitItem = errors.New("invalid memcached split item")
+
+// CacheClient represents a memcached cache client that conforms to the
+// cache.Client interface
+type CacheClient struct {
+	Name   string
+	Config *options.Options
+	client *memcache.Client
+}
+
+// New returns a new memcached CacheClient
+func New(name string, cfg *options.Options) *CacheClient {
+	return &CacheClient{
+		Name:   name,
+		Config: cfg,
+	}
+}
+
+// Connect connects to the configured memcached servers
+func (c *CacheClient) Connect() error {
+	mo := c.Config.Memcached
+	r, err := newRing(mo.Servers, mo.VirtualNodes)
+	if err != nil {
+		// with an empty ring, operations fail with memcache.ErrNoServers
+		r = &ring{}
+	}
+	c.client = memcache.NewFromSelector(r)
+	c.client.Timeout = time.Duration(mo.Timeout)
+	c.client.MaxIdleConns = mo.MaxIdleConns
+	if err != nil {
+		return err
+	}
+	return c.client.Ping()
+}
+
+// Store places the data into memcached using the provided Key and TTL.
+// Objects larger than the configured max item size are split across multiple
+// items, which are stored before the item that references them. The part keys
+// include a token unique to the write, so concurrent Stores of the same key
+// never interleave their parts. The token and part count of a split object are
+// also kept in a small manifest item, so that the parts of any object that is
+// replaced are removed once the new object is stored, without reading the
+// previous object itself. Parts left behind by a failed write expire with it.
+func (c *CacheClient) Store(cacheKey string, data []byte, ttl time.Duration) error {
+	k := itemKey(cacheKey)
+	exp := expiration(ttl)
+	prev, err := c.client.Get(manifestKey(k))
+	if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		return err
+	}
+	ps := c.partSize()
+	if len(data) <= ps {
+		err = c.client.Set(&memcache.Item{Key: k, Value: data, Expiration: exp})
+		if err == nil && prev != nil {
+			err = c.delete(manifestKey(k))
+		}
+	} else {
+		err = c.storeSplit(k, data, ps, exp)
+	}
+	if err != nil {
+		return err
+	}
+	return c.removeParts(k, prev)
+}
+
+func (c *CacheClient) storeSplit(k string, data []byte, ps int, exp int32) error {
+	b := make([]byte, tokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	token := hex.EncodeToString(b)
+	n := (len(data) + ps - 1) / ps
+	for i, pk := range partKeys(k, token, n) {
+		end := min((i+1)*ps, len(data))
+		err := c.client.Set(&memcache.Item{Key: pk, Value: data[i*ps : end],
+			Expiration: exp})
+		if err != nil {
+			return err
+		}
+	}
+	it := &memcache.Item{Key: k, Value: []byte(token + ":" + strconv.Itoa(n)),
+		Flags: flagSplit, Expiration: exp}
+	if err := c.client.Set(it); err != nil {
+		return err
+	}
+	it.Key = manifestKey(k)
+	return c.client.Set(it)
+}
+
+// Retrieve gets data from memcached using the provided Key. Because memcached
+// manages Object Expiration internally, expired objects are reported as misses.
+// A split object missing any of its parts is also reported as a miss.
+func (c *CacheClient) Retrieve(cacheKey string) ([]byte, status.LookupStatus, error) {
+	k := itemKey(cacheKey)
+	it, err := c.client.Get(k)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil, status.LookupStatusKeyMiss, cache.ErrKNF
+	}
+	if err != nil {
+		return nil, status.LookupStatusError, err
+	}
+	if it.Flags&flagSplit == 0 {
+		return it.Value, status.LookupStatusHit, nil
+	}
+	token, n, err := parseSplitItem(it)
+	if err != nil {
+		return nil, status.LookupStatusError, err
+	}
+	keys := partKeys(k, token, n)
+	items, err := c.client.GetMulti(keys)
+	if err != nil {
+		return nil, status.LookupStatusError, err
+	}
+	if len(items) != n {
+		return nil, status.LookupStatusKeyMiss, cache.ErrKNF
+	}
+	var size int
+	for _, pk := range keys {
+		size += len(items[pk].Value)
+	}
+	data := make([]byte, 0, size)
+	for _, pk := range keys {
+		data = append(data, items[pk].Value...)
+	}
+	return data, status.LookupStatusHit, nil
+}
+
+// Remove removes the objects for the provided cache keys, including the parts
+// and manifests of any split objects
+func (c *CacheClient) Remove(cacheKeys ...string) error {
+	for _, cacheKey := range cacheKeys {
+		k := itemKey(cacheKey)
+		mk := manifestKey(k)
+		it, err := c.client.Get(mk)
+		if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+			return err
+		}
+		if it != nil {
+			if err := c.removeParts(k, it); err != nil {
+				return err
+			}
+			if err := c.delete(mk); err != nil {
+				return err
+			}
+		}
+		if err := c.delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeParts removes the parts of the split object referenced by the item or
+// manifest, if any
+func (c *CacheClient) removeParts(k string, it *memcache.Item) error {
+	if it == nil || it.Flags&flagSplit == 0 {
+		return nil
+	}
+	token, n, err := parseSplitItem(it)
+	if err != nil {
+		return nil
+	}
+	for _, pk := range partKeys(k, token, n) {
+		if err := c.delete(pk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CacheClient) delete(k string) error {
+	err := c.client.Delete(k)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil
+	}
+	return err
+}
+
+// Close closes the memcached client's idle connections
+func (c *CacheClient) Close() error {
+	if c.client == nil {
+		return nil
+	}
+	return c.client.Close()
+}
+
+func (c *CacheClient) partSize() int {
+	return c.Config.Memcached.MaxItemSizeBytes - itemOverhead
+}
+
+// itemKey returns a memcached-safe key for the provided cache key. Keys that
+// are too long or contain whitespace or control characters are hashed.
+func itemKey(cacheKey string) string {
+	if len(cacheKey) <= maxKeyLength && isLegalKey(cacheKey) {
+		return cacheKey
+	}
+	h := sha256.Sum256([]byte(cacheKey))
+	return "trickster." + hex.EncodeToString(h[:])
+}
+
+func isLegalKey(k string) bool {
+	if k == "" {
+		return false
+	}
+	for i := 0; i < len(k); i++ {
+		if k[i] <= ' ' || k[i] == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// parseSplitItem returns the write token and part count of a split object
+func parseSplitItem(it *memcache.Item) (string, int, error) {
+	token, count, ok := strings.Cut(string(it.Value), ":")
+	if !ok || len(token) != tokenLength*2 {
+		return "", 0, ErrInvalidSplitItem
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil || n < 1 {
+		return "", 0, ErrInvalidSplitItem
+	}
+	return token, n, nil
+}
+
+// manifestKey returns the key of the item holding the write token and part
+// count of the split object stored under k
+func manifestKey(k string) string {
+	return k + ".parts"
+}
+
+func partKeys(k, token string, n int) []string {
+	keys := make([]string, n)
+	prefix := k + "." + token + ".part"
+	for i := range n {
+		keys[i] = prefix + strconv.Itoa(i)
+	}
+	return keys
+}
+
+// expiration converts a TTL to a memcached expiration value, which is
+// relative seconds up to 30 days, and an absolute unix timestamp beyond that
+func expiration(ttl time.Duration) int32 {
+	if ttl <= 0 {
+		return 0
+	}
+	secs := int64(math.Ceil(ttl.Seconds()))
+	if secs > maxRelativeExpiration {
+		return int32(time.Now().Add(ttl).Unix())
+	}
+	return int32(secs)
+}
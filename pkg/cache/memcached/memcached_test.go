@@ -0,0 +1,269 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memcached
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	mo "github.com/trickstercache/trickster/v2/pkg/cache/memcached/options"
+	co "github.com/trickstercache/trickster/v2/pkg/cache/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+func setupMemcachedCache(t *testing.T, servers ...*fakeServer) *CacheClient {
+	t.Helper()
+	mcfg := mo.New()
+	mcfg.Servers = make([]string, len(servers))
+	for i, s := range servers {
+		mcfg.Servers[i] = s.Addr()
+	}
+	mcfg.MaxItemSizeBytes = mo.MinMaxItemSizeBytes * 2
+	cfg := &co.Options{Provider: "memcached", Memcached: mcfg}
+	c := New("test", cfg)
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestConnect(t *testing.T) {
+	s := newFakeServer(t, mo.DefaultMaxItemSizeBytes)
+	setupMemcachedCache(t, s)
+
+	mcfg := mo.New()
+	mcfg.Servers = []string{"127.0.0.1:1"}
+	mcfg.Timeout = 0
+	c := New("test", &co.Options{Provider: "memcached", Memcached: mcfg})
+	if err := c.Connect(); err == nil {
+		t.Error("expected connect error")
+	}
+}
+
+func TestStoreRetrieveRemove(t *testing.T) {
+	s := newFakeServer(t, mo.DefaultMaxItemSizeBytes)
+	c := setupMemcachedCache(t, s)
+
+	if err := c.Store("cacheKey", []byte("data"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	b, ls, err := c.Retrieve("cacheKey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ls != status.LookupStatusHit || string(b) != "data" {
+		t.Errorf("unexpected result %s %s", ls, string(b))
+	}
+	if err := c.Remove("cacheKey", "missingKey"); err != nil {
+		t.Error(err)
+	}
+	_, ls, err = c.Retrieve("cacheKey")
+	if !errors.Is(err, cache.ErrKNF) || ls != status.LookupStatusKeyMiss {
+		t.Errorf("expected key miss, got %s %v", ls, err)
+	}
+}
+
+func TestStoreSplit(t *testing.T) {
+	s := newFakeServer(t, mo.MinMaxItemSizeBytes*2)
+	c := setupMemcachedCache(t, s)
+
+	data := bytes.Repeat([]byte("0123456789"), 3000)
+	if err := c.Store("splitKey", data, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() < 4 {
+		t.Errorf("expected object to be split, got %d items", s.Len())
+	}
+	b, ls, err := c.Retrieve("splitKey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ls != status.LookupStatusHit || !bytes.Equal(b, data) {
+		t.Errorf("unexpected result %s, %d bytes", ls, len(b))
+	}
+
+	// a missing part is a miss
+	s.mu.Lock()
+	for k := range s.items {
+		if strings.HasSuffix(k, ".part1") {
+			delete(s.items, k)
+		}
+	}
+	s.mu.Unlock()
+	if _, ls, err = c.Retrieve("splitKey"); !errors.Is(err, cache.ErrKNF) ||
+		ls != status.LookupStatusKeyMiss {
+		t.Errorf("expected key miss, got %s %v", ls, err)
+	}
+
+	if err := c.Remove("splitKey"); err != nil {
+		t.Error(err)
+	}
+	if s.Len() != 0 {
+		t.Errorf("expected all parts to be removed, got %d items", s.Len())
+	}
+}
+
+func TestStoreSplitReplace(t *testing.T) {
+	s := newFakeServer(t, mo.MinMaxItemSizeBytes*2)
+	c := setupMemcachedCache(t, s)
+
+	large := bytes.Repeat([]byte("a"), 30000)
+	small := bytes.Repeat([]byte("b"), 10000)
+	if err := c.Store("splitKey", large, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	// the parts of a concurrent write of the same key are kept separately
+	if err := c.storeSplit("splitKey", small, c.partSize(), 60); err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := c.Retrieve("splitKey")
+	if err != nil || !bytes.Equal(b, small) {
+		t.Errorf("unexpected result %d bytes %v", len(b), err)
+	}
+
+	// replacing an object removes the parts of the previous object
+	if err := c.Store("splitKey", large, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Store("splitKey", []byte("data"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 1+(len(large)+c.partSize()-1)/c.partSize() {
+		t.Errorf("expected only the parts replaced by the concurrent write, got %d items", s.Len())
+	}
+	if b, _, err := c.Retrieve("splitKey"); err != nil || string(b) != "data" {
+		t.Errorf("unexpected result %s %v", string(b), err)
+	}
+
+	if _, _, err := parseSplitItem(&memcache.Item{Value: []byte("3")}); !errors.Is(err, ErrInvalidSplitItem) {
+		t.Errorf("expected %v got %v", ErrInvalidSplitItem, err)
+	}
+}
+
+func TestStoreReplaceReadsManifest(t *testing.T) {
+	s := newFakeServer(t, mo.MinMaxItemSizeBytes*2)
+	c := setupMemcachedCache(t, s)
+
+	data := bytes.Repeat([]byte("a"), c.partSize())
+	if err := c.Store("key", data, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	// replacing an object reads only its manifest, never the previous value
+	if err := c.Store("key", data, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if s.Read() != 0 {
+		t.Errorf("expected no values to be read, got %d bytes", s.Read())
+	}
+	large := bytes.Repeat([]byte("b"), c.partSize()*3)
+	if err := c.Store("key", large, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Store("key", large, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if s.Read() > tokenLength*2+2 {
+		t.Errorf("expected only the manifest to be read, got %d bytes", s.Read())
+	}
+	if s.Len() != 1+1+3 {
+		t.Errorf("expected the item, manifest and parts, got %d items", s.Len())
+	}
+}
+
+func TestItemKey(t *testing.T) {
+	if k := itemKey("simple.key"); k != "simple.key" {
+		t.Errorf("expected unchanged key, got %s", k)
+	}
+	long := strings.Repeat("k", 300)
+	for _, k := range []string{long, "key with spaces", ""} {
+		ik := itemKey(k)
+		if !strings.HasPrefix(ik, "trickster.") || len(ik) > maxKeyLength {
+			t.Errorf("expected hashed key, got %s", ik)
+		}
+	}
+	s := newFakeServer(t, mo.DefaultMaxItemSizeBytes)
+	c := setupMemcachedCache(t, s)
+	if err := c.Store(long, []byte("data"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if b, _, err := c.Retrieve(long); err != nil || string(b) != "data" {
+		t.Errorf("unexpected result %s %v", string(b), err)
+	}
+}
+
+func TestExpiration(t *testing.T) {
+	if e := expiration(0); e != 0 {
+		t.Errorf("expected 0 got %d", e)
+	}
+	if e := expiration(500 * time.Millisecond); e != 1 {
+		t.Errorf("expected 1 got %d", e)
+	}
+	if e := expiration(time.Hour); e != 3600 {
+		t.Errorf("expected 3600 got %d", e)
+	}
+	ttl := 60 * 24 * time.Hour
+	if e := expiration(ttl); int64(e) < time.Now().Add(ttl).Unix()-1 {
+		t.Errorf("expected absolute expiration, got %d", e)
+	}
+}
+
+func TestRingDistribution(t *testing.T) {
+	s1 := newFakeServer(t, mo.DefaultMaxItemSizeBytes)
+	s2 := newFakeServer(t, mo.DefaultMaxItemSizeBytes)
+	c := setupMemcachedCache(t, s1, s2)
+	const n = 200
+	for i := range n {
+		if err := c.Store("key"+strconv.Itoa(i), []byte("v"), time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s1.Len() == 0 || s2.Len() == 0 || s1.Len()+s2.Len() != n {
+		t.Errorf("expected keys on both servers, got %d and %d", s1.Len(), s2.Len())
+	}
+
+	// removing a server only remaps the keys that it owned
+	servers := []string{"127.0.0.1:11211", "127.0.0.1:11212", "127.0.0.1:11213"}
+	r3, err := newRing(servers, mo.DefaultVirtualNodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, err := newRing(servers[:2], mo.DefaultVirtualNodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range n {
+		k := "key" + strconv.Itoa(i)
+		a3, _ := r3.PickServer(k)
+		a2, _ := r2.PickServer(k)
+		if a3.String() != servers[2] && a3.String() != a2.String() {
+			t.Errorf("key %s moved from %s to %s", k, a3, a2)
+		}
+	}
+
+	empty, _ := newRing(nil, mo.DefaultVirtualNodes)
+	if _, err := empty.PickServer("key"); err == nil {
+		t.Error("expected no servers error")
+	}
+}
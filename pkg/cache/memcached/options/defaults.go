@@ -0,0 +1,38 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import "time"
+
+const (
+	// DefaultServer is the default memcached server endpoint
+	DefaultServer = "memcached:11211"
+	// DefaultTimeout is the default socket read/write timeout
+	DefaultTimeout = 500 * time.Millisecond
+	// DefaultMaxIdleConns is the default maximum number of idle connections
+	// kept open to each server
+	DefaultMaxIdleConns = 8
+	// DefaultMaxItemSizeBytes is the default maximum item size, matching
+	// memcached's default 1 MiB slab page size
+	DefaultMaxItemSizeBytes = 1024 * 1024
+	// DefaultVirtualNodes is the default number of points per server on the
+	// consistent hash ring
+	DefaultVirtualNodes = 160
+
+	// MinMaxItemSizeBytes is the smallest supported MaxItemSizeBytes value
+	MinMaxItemSizeBytes = 4096
+)
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	// ErrNoServers is returned when no memcached servers are configured
+	ErrNoServers = errors.New("memcached cache requires at least one server")
+	// ErrInvalidMaxItemSize is returned when the max item size is too small
+	ErrInvalidMaxItemSize = errors.New("memcached max_item_size_bytes must be at least 4096")
+)
+
+// Options is a collection of Configurations for Connecting to Memcached
+type Options struct {
+	// Servers is the list of memcached servers as host:port, or as a path to a
+	// unix socket. Keys are distributed across the servers by consistent hashing
+	Servers []string `yaml:"servers,omitempty"`
+	// Timeout is the timeout for connecting to and reading from or writing to a server
+	Timeout timeconv.Duration `yaml:"timeout,omitempty"`
+	// MaxIdleConns is the maximum number of idle connections kept open to each server
+	MaxIdleConns int `yaml:"max_idle_conns,omitempty"`
+	// MaxItemSizeBytes is the largest item the servers will accept (memcached's -I
+	// setting). Objects larger than this are split into multiple items
+	MaxItemSizeBytes int `yaml:"max_item_size_bytes,omitempty"`
+	// VirtualNodes is the number of points each server is given on the
+	// consistent hash ring
+	VirtualNodes int `yaml:"virtual_nodes,omitempty"`
+}
+
+// New returns a new Memcached Options Reference with default values set
+func New() *Options {
+	return &Options{
+		Servers:          []string{DefaultServer},
+		Timeout:          timeconv.Duration(DefaultTimeout),
+		MaxIdleConns:     DefaultMaxIdleConns,
+		MaxItemSizeBytes: DefaultMaxItemSizeBytes,
+		VirtualNodes:     DefaultVirtualNodes,
+	}
+}
+
+// Clone returns a copy of the Options
+func (o *Options) Clone() *Options {
+	if o == nil {
+		return nil
+	}
+	out := *o
+	out.Servers = slices.Clone(o.Servers)
+	return &out
+}
+
+// Validate returns an error if the Options are invalid
+func (o *Options) Validate() error {
+	if len(o.Servers) == 0 {
+		return ErrNoServers
+	}
+	if o.MaxItemSizeBytes < MinMaxItemSizeBytes {
+		return ErrInvalidMaxItemSize
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = timeconv.Duration(DefaultTimeout)
+	}
+	if o.MaxIdleConns <= 0 {
+		o.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if o.VirtualNodes <= 0 {
+		o.VirtualNodes = DefaultVirtualNodes
+	}
+	return nil
+}
+
+func (o *Options) UnmarshalYAML(value *yaml.Node) error {
+	type loadOptions Options
+	lo := loadOptions(*(New()))
+	if err := value.Decode(&lo); err != nil {
+		return err
+	}
+	*o = Options(lo)
+	return nil
+}
+
+// Equal returns true if all values in the Options are identical
+func (o *Options) Equal(o2 *Options) bool {
+	if o2 == nil {
+		return o == nil
+	}
+	if o == nil {
+		return false
+	}
+	return slices.Equal(o.Servers, o2.Servers) &&
+		o.Timeout == o2.Timeout &&
+		o.MaxIdleConns == o2.MaxIdleConns &&
+		o.MaxItemSizeBytes == o2.MaxItemSizeBytes &&
+		o.VirtualNodes == o2.VirtualNodes
+}
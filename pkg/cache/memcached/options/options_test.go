@@ -0,0 +1,104 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestNew(t *testing.T) {
+	o := New()
+	if len(o.Servers) != 1 || o.Servers[0] != DefaultServer {
+		t.Errorf("unexpected servers %v", o.Servers)
+	}
+	if o.MaxItemSizeBytes != DefaultMaxItemSizeBytes {
+		t.Errorf("expected %d got %d", DefaultMaxItemSizeBytes, o.MaxItemSizeBytes)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	o := New()
+	if err := o.Validate(); err != nil {
+		t.Error(err)
+	}
+	o.Servers = nil
+	if err := o.Validate(); err != ErrNoServers {
+		t.Errorf("expected %v got %v", ErrNoServers, err)
+	}
+	o.Servers = []string{"a:11211"}
+	o.MaxItemSizeBytes = 100
+	if err := o.Validate(); err != ErrInvalidMaxItemSize {
+		t.Errorf("expected %v got %v", ErrInvalidMaxItemSize, err)
+	}
+	o.MaxItemSizeBytes = MinMaxItemSizeBytes
+	o.Timeout = 0
+	o.MaxIdleConns = 0
+	o.VirtualNodes = 0
+	if err := o.Validate(); err != nil {
+		t.Error(err)
+	}
+	if time.Duration(o.Timeout) != DefaultTimeout || o.MaxIdleConns != DefaultMaxIdleConns ||
+		o.VirtualNodes != DefaultVirtualNodes {
+		t.Errorf("expected defaults to be applied, got %+v", o)
+	}
+}
+
+func TestCloneEqual(t *testing.T) {
+	o := New()
+	o2 := o.Clone()
+	if !o.Equal(o2) {
+		t.Error("expected clone to be equal")
+	}
+	o2.Servers[0] = "x:11211"
+	if o.Servers[0] != DefaultServer {
+		t.Error("expected clone to have its own servers")
+	}
+	if o.Equal(o2) {
+		t.Error("expected servers difference to make options unequal")
+	}
+	if o.Equal(nil) {
+		t.Error("expected false for nil comparison")
+	}
+	if (*Options)(nil).Clone() != nil {
+		t.Error("expected nil clone")
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	const raw = `
+servers: [mc1:11211, mc2:11211]
+max_item_size_bytes: 2097152
+`
+	o := &Options{}
+	if err := yaml.Unmarshal([]byte(raw), o); err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Servers) != 2 || o.MaxItemSizeBytes != 2097152 {
+		t.Errorf("unexpected options %+v", o)
+	}
+	if o.Timeout != timeconv.Duration(DefaultTimeout) {
+		t.Errorf("expected default timeout, got %s", time.Duration(o.Timeout))
+	}
+	if err := yaml.Unmarshal([]byte("- boom"), o); err == nil {
+		t.Error("expected an error")
+	}
+}
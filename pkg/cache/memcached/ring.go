@@ -0,0 +1,102 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memcached
+
+import (
+	"cmp"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/cespare/xxhash/v2"
+)
+
+var _ memcache.ServerSelector = &ring{}
+
+// ring is a memcache.ServerSelector that distributes keys across servers
+// using a consistent hash ring, so that adding or removing a server only
+// remaps the keys that server owned
+type ring struct {
+	points []ringPoint
+	addrs  []net.Addr
+}
+
+type ringPoint struct {
+	hash uint64
+	addr net.Addr
+}
+
+// newRing returns a ring with vnodes points for each of the provided servers.
+// Servers containing a '/' are treated as unix socket paths.
+func newRing(servers []string, vnodes int) (*ring, error) {
+	r := &ring{
+		points: make([]ringPoint, 0, len(servers)*vnodes),
+		addrs:  make([]net.Addr, 0, len(servers)),
+	}
+	for _, s := range servers {
+		addr, err := resolveAddr(s)
+		if err != nil {
+			return nil, err
+		}
+		r.addrs = append(r.addrs, addr)
+		for i := range vnodes {
+			r.points = append(r.points, ringPoint{
+				hash: xxhash.Sum64String(s + "-" + strconv.Itoa(i)),
+				addr: addr,
+			})
+		}
+	}
+	slices.SortFunc(r.points, func(a, b ringPoint) int {
+		return cmp.Compare(a.hash, b.hash)
+	})
+	return r, nil
+}
+
+func resolveAddr(server string) (net.Addr, error) {
+	if strings.Contains(server, "/") {
+		return net.ResolveUnixAddr("unix", server)
+	}
+	return net.ResolveTCPAddr("tcp", server)
+}
+
+// PickServer returns the server owning the first ring point at or after the
+// hash of the key
+func (r *ring) PickServer(key string) (net.Addr, error) {
+	if len(r.points) == 0 {
+		return nil, memcache.ErrNoServers
+	}
+	h := xxhash.Sum64String(key)
+	i, _ := slices.BinarySearchFunc(r.points, h, func(p ringPoint, t uint64) int {
+		return cmp.Compare(p.hash, t)
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].addr, nil
+}
+
+// Each calls f for each server in the ring
+func (r *ring) Each(f func(net.Addr) error) error {
+	for _, a := range r.addrs {
+		if err := f(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
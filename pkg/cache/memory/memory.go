@@ -19,12 +19,17 @@
 package memory
 
 import (
+	"context"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/trickstercache/trickster/v2/pkg/cache"
 	memoryopts "github.com/trickstercache/trickster/v2/pkg/cache/memory/options"
 	"github.com/trickstercache/trickster/v2/pkg/cache/options"
 	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
 
 	"github.com/dgraph-io/ristretto/v2"
 )
@@ -40,6 +45,14 @@ type Cache struct {
 	Name   string
 	Config *options.Options
 	client *ristretto.Cache[string, any]
+
+	// objects tracks the metadata of each stored object when snapshots are
+	// enabled, since the underlying cache can't be enumerated
+	objects     *sync.Map
+	snapshotMtx sync.Mutex
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+	closed      atomic.Bool
 }
 
 // New returns a new memory cache as a Trickster Cache Interface type
@@ -89,25 +102,61 @@ func New(name string, cfg *options.Options) *Cache {
 		Config: cfg,
 		client: client,
 	}
+	if c.snapshotsEnabled() {
+		c.objects = &sync.Map{}
+	}
 	return c
 }
 
+func (c *Cache) snapshotsEnabled() bool {
+	return c.Config.Memory != nil && c.Config.Memory.SnapshotPath != ""
+}
+
 func (c *Cache) Remove(cacheKeys ...string) error {
 	for _, k := range cacheKeys {
 		c.client.Del(k)
 	}
 	// Wait for buffered deletes to complete to ensure synchronous semantics
 	c.client.Wait()
+	c.untrack(cacheKeys...)
 	return nil
 }
 
+// Close writes a snapshot of the cache when snapshots are enabled, and then
+// closes the Cache
 func (c *Cache) Close() error {
+	if !c.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	if c.cancel != nil {
+		c.cancel()
+		c.wg.Wait()
+	}
+	var err error
+	if c.snapshotsEnabled() {
+		err = c.WriteSnapshot()
+	}
 	c.client.Close()
-	return nil
+	return err
 }
 
-// Connect initializes the Cache
+// Connect initializes the Cache, restoring its contents from the snapshot
+// file when snapshots are enabled
 func (c *Cache) Connect() error {
+	if !c.snapshotsEnabled() || c.cancel != nil {
+		return nil
+	}
+	if err := c.restoreSnapshot(); err != nil {
+		// a missing or unreadable snapshot only means the cache starts cold
+		logger.Warn("memory cache snapshot was not restored",
+			logging.Pairs{"cacheName": c.Name, "error": err.Error()})
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	if i := time.Duration(c.Config.Memory.SnapshotInterval); i > 0 {
+		c.wg.Add(1)
+		go c.snapshotter(ctx, i)
+	}
 	return nil
 }
 
@@ -138,13 +187,23 @@ func (c *Cache) store(cacheKey string, byteData []byte, refData cache.ReferenceO
 	}
 
 	if value != nil {
+		var ok bool
 		if ttl > 0 {
-			c.client.SetWithTTL(cacheKey, value, 0, ttl) // 0 = use Cost function
+			ok = c.client.SetWithTTL(cacheKey, value, 0, ttl) // 0 = use Cost function
 		} else {
-			c.client.Set(cacheKey, value, 0) // 0 = use Cost function
+			ok = c.client.Set(cacheKey, value, 0) // 0 = use Cost function
 		}
 		// Wait for buffered write to complete to ensure synchronous semantics
 		c.client.Wait()
+		if ok {
+			var size int64
+			if byteData != nil {
+				size = int64(len(byteData))
+			} else {
+				size = int64(refData.Size())
+			}
+			c.track(cacheKey, size, ttl)
+		}
 	}
 
 	return nil
@@ -178,6 +237,7 @@ func (c *Cache) retrieve(cacheKey string) (any,
 ) {
 	record, ok := c.client.Get(cacheKey)
 	if ok {
+		c.touch(cacheKey)
 		return record, status.LookupStatusHit, nil
 	}
 	return nil, status.LookupStatusKeyMiss, cache.ErrKNF
@@ -17,6 +17,10 @@
 package options
 
 import (
+	"errors"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+
 	"go.yaml.in/yaml/v3"
 )
 
@@ -36,8 +40,17 @@ type Options struct {
 	// Recommended to use ~10x the number of unique keys you expect to hold for full utilization.
 	// Defaults to 500,000.
 	NumCounters int64 `yaml:"num_counters,omitempty"`
+	// SnapshotPath is the path of a local file that the cache contents are saved to when the
+	// cache is closed, and restored from when it is connected. Snapshots are disabled when empty.
+	SnapshotPath string `yaml:"snapshot_path,omitempty"`
+	// SnapshotInterval is how often the cache contents are also saved to the SnapshotPath
+	// while running. Zero disables periodic snapshots.
+	SnapshotInterval timeconv.Duration `yaml:"snapshot_interval,omitempty"`
 }
 
+// ErrInvalidSnapshotInterval is returned when the snapshot interval is negative
+var ErrInvalidSnapshotInterval = errors.New("memory snapshot_interval cannot be negative")
+
 // New returns a new Options with default values set.
 func New() *Options {
 	return &Options{
@@ -51,7 +64,16 @@ func (o *Options) Equal(o2 *Options) bool {
 	if o2 == nil {
 		return false
 	}
-	return o.MaxSizeBytes == o2.MaxSizeBytes && o.NumCounters == o2.NumCounters
+	return o.MaxSizeBytes == o2.MaxSizeBytes && o.NumCounters == o2.NumCounters &&
+		o.SnapshotPath == o2.SnapshotPath && o.SnapshotInterval == o2.SnapshotInterval
+}
+
+// Validate returns an error if the Options are invalid
+func (o *Options) Validate() error {
+	if o.SnapshotInterval < 0 {
+		return ErrInvalidSnapshotInterval
+	}
+	return nil
 }
 
 // UnmarshalYAML applies defaults before overlaying YAML-parsed values.
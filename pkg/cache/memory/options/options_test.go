@@ -59,6 +59,12 @@ func TestEqual(t *testing.T) {
 	if o.Equal(o3) {
 		t.Error("expected NumCounters difference to make options unequal")
 	}
+
+	o4 := New()
+	o4.SnapshotPath = "/tmp/snapshot"
+	if o.Equal(o4) {
+		t.Error("expected SnapshotPath difference to make options unequal")
+	}
 }
 
 func TestUnmarshalYAML(t *testing.T) {
@@ -107,3 +113,14 @@ func TestUnmarshalYAMLError(t *testing.T) {
 		t.Fatal("expected an error")
 	}
 }
+
+func TestValidate(t *testing.T) {
+	o := New()
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	o.SnapshotInterval = -1
+	if err := o.Validate(); err != ErrInvalidSnapshotInterval {
+		t.Errorf("expected %v, got %v", ErrInvalidSnapshotInterval, err)
+	}
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+//go:generate go tool msgp -unexported
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
+	"github.com/trickstercache/trickster/v2/pkg/util/safego"
+)
+
+// snapshotObject is the serialized form of a cached object and its metadata
+type snapshotObject struct {
+	Key        string    `msg:"key"`
+	Value      []byte    `msg:"value"`
+	Size       int64     `msg:"size"`
+	Expiration time.Time `msg:"expiration"`
+	LastWrite  time.Time `msg:"lastwrite"`
+	LastAccess time.Time `msg:"lastaccess"`
+}
+
+// snapshot is the serialized form of the cache
+type snapshot []*snapshotObject
+
+//msgp:ignore objectMeta
+
+// objectMeta is the tracked metadata of a stored object
+type objectMeta struct {
+	size       int64
+	expiration time.Time
+	lastWrite  time.Time
+	lastAccess atomic.Int64 // unix nanoseconds
+}
+
+// track records the metadata of a stored object, so that it can be found when
+// writing a snapshot
+func (c *Cache) track(cacheKey string, size int64, ttl time.Duration) {
+	if c.objects == nil {
+		return
+	}
+	now := time.Now()
+	m := &objectMeta{size: size, lastWrite: now}
+	m.lastAccess.Store(now.UnixNano())
+	if ttl > 0 {
+		m.expiration = now.Add(ttl)
+	}
+	c.objects.Store(cacheKey, m)
+}
+
+func (c *Cache) touch(cacheKey string) {
+	if c.objects == nil {
+		return
+	}
+	if m, ok := c.objects.Load(cacheKey); ok {
+		m.(*objectMeta).lastAccess.Store(time.Now().UnixNano())
+	}
+}
+
+func (c *Cache) untrack(cacheKeys ...string) {
+	if c.objects == nil {
+		return
+	}
+	for _, k := range cacheKeys {
+		c.objects.Delete(k)
+	}
+}
+
+// snapshotter periodically writes a snapshot of the cache
+func (c *Cache) snapshotter(ctx context.Context, interval time.Duration) {
+	defer c.wg.Done()
+	safego.Run(func(r any, stack []byte) {
+		logger.Error("memory cache snapshotter panic", logging.Pairs{
+			"cacheName": c.Name, "panic": r, "stack": string(stack),
+		})
+	}, func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.WriteSnapshot(); err != nil {
+					logger.Warn("memory cache snapshot failed",
+						logging.Pairs{"cacheName": c.Name, "error": err.Error()})
+				}
+			}
+		}
+	})
+}
+
+// WriteSnapshot writes the unexpired objects in the cache, along with their
+// index metadata, to the configured snapshot path. Objects stored by
+// reference are written only if they implement cache.ReferenceMarshaler,
+// and are restored as serialized values.
+func (c *Cache) WriteSnapshot() error {
+	if c.objects == nil {
+		return nil
+	}
+	c.snapshotMtx.Lock()
+	defer c.snapshotMtx.Unlock()
+	now := time.Now()
+	var out snapshot
+	var stale []string
+	c.objects.Range(func(k, v any) bool {
+		key, m := k.(string), v.(*objectMeta)
+		value, ok := c.client.Get(key)
+		if !ok || (!m.expiration.IsZero() && !m.expiration.After(now)) {
+			stale = append(stale, key)
+			return true
+		}
+		var b []byte
+		switch v := value.(type) {
+		case []byte:
+			b = v
+		case cache.ReferenceMarshaler:
+			var err error
+			if b, err = v.MarshalReference(); err != nil {
+				return true
+			}
+		default:
+			return true
+		}
+		out = append(out, &snapshotObject{
+			Key: key, Value: b, Size: int64(len(b)),
+			Expiration: m.expiration, LastWrite: m.lastWrite,
+			LastAccess: time.Unix(0, m.lastAccess.Load()),
+		})
+		return true
+	})
+	// objects that were evicted or expired are no longer tracked
+	c.untrack(stale...)
+	b, err := out.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+	path := c.Config.Memory.SnapshotPath
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// write to a temporary file and rename it, so that a partially-written
+	// snapshot never replaces a complete one
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	logger.Debug("memory cache snapshot written",
+		logging.Pairs{"cacheName": c.Name, "objects": len(out), "bytes": len(b)})
+	return nil
+}
+
+// restoreSnapshot loads the unexpired objects in the snapshot file into the
+// cache. The most recently accessed objects are restored first, and objects
+// that would exceed the cache's maximum size are dropped.
+func (c *Cache) restoreSnapshot() error {
+	b, err := os.ReadFile(c.Config.Memory.SnapshotPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var objs snapshot
+	if _, err := objs.UnmarshalMsg(b); err != nil {
+		return err
+	}
+	slices.SortFunc(objs, func(a, b *snapshotObject) int {
+		return b.LastAccess.Compare(a.LastAccess)
+	})
+	now := time.Now()
+	maxCost := c.client.MaxCost()
+	var size int64
+	var restored, expired int
+	for _, o := range objs {
+		var ttl time.Duration
+		if !o.Expiration.IsZero() {
+			if ttl = o.Expiration.Sub(now); ttl <= 0 {
+				expired++
+				continue
+			}
+		}
+		if size+o.Size > maxCost {
+			continue
+		}
+		if !c.client.SetWithTTL(o.Key, o.Value, 0, ttl) {
+			continue
+		}
+		size += o.Size
+		restored++
+		m := &objectMeta{size: o.Size, expiration: o.Expiration, lastWrite: o.LastWrite}
+		m.lastAccess.Store(o.LastAccess.UnixNano())
+		c.objects.Store(o.Key, m)
+	}
+	c.client.Wait()
+	logger.Info("memory cache snapshot restored",
+		logging.Pairs{
+			"cacheName": c.Name, "restored": restored, "expired": expired,
+			"dropped": len(objs) - restored - expired, "bytes": size,
+		})
+	return nil
+}
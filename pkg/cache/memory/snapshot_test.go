@@ -0,0 +1,159 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mo "github.com/trickstercache/trickster/v2/pkg/cache/memory/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/level"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+)
+
+type testMarshalingReference struct {
+	data string
+}
+
+func (r *testMarshalingReference) Size() int {
+	return len(r.data)
+}
+
+func (r *testMarshalingReference) MarshalReference() ([]byte, error) {
+	return []byte(r.data), nil
+}
+
+func newSnapshotCache(t *testing.T, path string, interval time.Duration) *Cache {
+	t.Helper()
+	cacheConfig := newCacheConfig()
+	cacheConfig.Memory = mo.New()
+	cacheConfig.Memory.SnapshotPath = path
+	cacheConfig.Memory.SnapshotInterval = timeconv.Duration(interval)
+	mc := New(t.Name(), &cacheConfig)
+	if err := mc.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = mc.Close() })
+	return mc
+}
+
+func TestCache_Snapshot(t *testing.T) {
+	logger.SetLogger(logging.ConsoleLogger(level.Error))
+	path := filepath.Join(t.TempDir(), "snapshots", "memory.snap")
+
+	mc := newSnapshotCache(t, path, 0)
+	if err := mc.Store("bytes", []byte("data"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.Store("expiring", []byte("data"), 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.Store("removed", []byte("data"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.StoreReference("marshaler", &testMarshalingReference{data: "ref"},
+		time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.StoreReference("reference", &testReferenceObject{},
+		time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.Remove("removed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	}
+	// a second close should not rewrite or fail
+	if err := mc.Close(); err != nil {
+		t.Error(err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	mc2 := newSnapshotCache(t, path, 0)
+	tests := []struct {
+		key    string
+		expect string
+		status status.LookupStatus
+	}{
+		{"bytes", "data", status.LookupStatusHit},
+		{"marshaler", "ref", status.LookupStatusHit},
+		{"expiring", "", status.LookupStatusKeyMiss},
+		{"removed", "", status.LookupStatusKeyMiss},
+		{"reference", "", status.LookupStatusKeyMiss},
+	}
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			b, ls, _ := mc2.Retrieve(test.key)
+			if ls != test.status {
+				t.Errorf("expected %s got %s", test.status, ls)
+			}
+			if string(b) != test.expect {
+				t.Errorf("expected %q got %q", test.expect, string(b))
+			}
+		})
+	}
+	if _, ok := mc2.objects.Load("bytes"); !ok {
+		t.Error("expected restored object to be tracked")
+	}
+}
+
+func TestCache_SnapshotInterval(t *testing.T) {
+	logger.SetLogger(logging.ConsoleLogger(level.Error))
+	path := filepath.Join(t.TempDir(), "memory.snap")
+
+	mc := newSnapshotCache(t, path, 10*time.Millisecond)
+	if err := mc.Store(cacheKey, []byte("data"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected a periodic snapshot to be written")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCache_SnapshotCorrupt(t *testing.T) {
+	logger.SetLogger(logging.ConsoleLogger(level.Error))
+	path := filepath.Join(t.TempDir(), "memory.snap")
+	if err := os.WriteFile(path, []byte("not a snapshot"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// a corrupt snapshot should not prevent the cache from connecting
+	mc := newSnapshotCache(t, path, 0)
+	if err := mc.Store(cacheKey, []byte("data"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if _, ls, _ := mc.Retrieve(cacheKey); ls != status.LookupStatusHit {
+		t.Errorf("expected %s got %s", status.LookupStatusHit, ls)
+	}
+}
@@ -51,8 +51,29 @@ func ObserveCacheEvent(cache, cacheProvider, event, reason string) {
 	metrics.CacheEvents.WithLabelValues(cache, cacheProvider, event, reason).Inc()
 }
 
+// ObserveCacheTierLookup records the lookup status of a single tier in a Tiered cache
+func ObserveCacheTierLookup(cache, tier, status string) {
+	metrics.CacheTierLookups.WithLabelValues(cache, tier, status).Inc()
+}
+
+// ObserveCachePeerRequest records the result of a request made by a Peer cache to a peer
+func ObserveCachePeerRequest(cache, operation, result string) {
+	metrics.CachePeerRequests.WithLabelValues(cache, operation, result).Inc()
+}
+
+// ObserveCachePeerMembers records the number of instances among which a Peer cache shards its cache keys
+func ObserveCachePeerMembers(cache string, count int) {
+	metrics.CachePeerMembers.WithLabelValues(cache).Set(float64(count))
+}
+
 // ObserveCacheSizeChange adjust counters and gauges as the cache size changes due to object operations
 func ObserveCacheSizeChange(cache, cacheProvider string, byteCount, objectCount int64) {
 	metrics.CacheObjects.WithLabelValues(cache, cacheProvider).Set(float64(objectCount))
 	metrics.CacheBytes.WithLabelValues(cache, cacheProvider).Set(float64(byteCount))
 }
+
+// ObserveCachePartitionSizeChange sets the usage gauges of a cache quota partition as its size changes
+func ObserveCachePartitionSizeChange(cache, cacheProvider, partition string, byteCount, objectCount int64) {
+	metrics.CachePartitionObjects.WithLabelValues(cache, cacheProvider, partition).Set(float64(objectCount))
+	metrics.CachePartitionBytes.WithLabelValues(cache, cacheProvider, partition).Set(float64(byteCount))
+}
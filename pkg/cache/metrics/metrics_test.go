@@ -56,3 +56,16 @@ func TestObserveCacheEvent(t *testing.T) {
 func TestObserveCacheSizeChange(t *testing.T) {
 	ObserveCacheSizeChange(testCacheName, testCacheProvider, 0, 0)
 }
+
+func TestObserveCacheTierLookup(t *testing.T) {
+	ObserveCacheTierLookup(testCacheName, "l1", "hit")
+}
+
+func TestObserveCachePeerRequest(t *testing.T) {
+	ObserveCachePeerRequest(testCacheName, "read", "ok")
+	ObserveCachePeerMembers(testCacheName, 3)
+}
+
+func TestObserveCachePartitionSizeChange(t *testing.T) {
+	ObserveCachePartitionSizeChange(testCacheName, testCacheProvider, "test-partition", 1, 1)
+}
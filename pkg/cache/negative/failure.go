@@ -0,0 +1,201 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package negative
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Failure identifies a class of transport-level upstream failure, for which
+// no HTTP response was received from the origin. Failure values are negative
+// so they can share a Lookup with HTTP status codes.
+type Failure int
+
+const (
+	// FailureNone indicates the error is not a cacheable transport failure
+	FailureNone Failure = 0
+	// FailureTimeout indicates the origin did not respond before the timeout
+	FailureTimeout Failure = -1
+	// FailureConnectionRefused indicates the origin refused the connection
+	FailureConnectionRefused Failure = -2
+	// FailureTLS indicates the TLS handshake with the origin failed
+	FailureTLS Failure = -3
+	// FailureDNS indicates the origin hostname could not be resolved
+	FailureDNS Failure = -4
+)
+
+var failureNames = map[Failure]string{
+	FailureTimeout:           "timeout",
+	FailureConnectionRefused: "connection_refused",
+	FailureTLS:               "tls",
+	FailureDNS:               "dns",
+}
+
+var failureValues = map[string]Failure{
+	"timeout":            FailureTimeout,
+	"connection_refused": FailureConnectionRefused,
+	"tls":                FailureTLS,
+	"dns":                FailureDNS,
+}
+
+func (f Failure) String() string {
+	if n, ok := failureNames[f]; ok {
+		return n
+	}
+	return "none"
+}
+
+// StatusCode returns the HTTP status code of the response served for the Failure
+func (f Failure) StatusCode() int {
+	if f == FailureTimeout {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusBadGateway
+}
+
+// Body returns the synthesized response body served for the Failure
+func (f Failure) Body() []byte {
+	return []byte("upstream transport failure: " + f.String() + "\n")
+}
+
+// Classify returns the Failure class of an error returned by an HTTP client,
+// or FailureNone if the error is not a cacheable transport failure, such as
+// when the client canceled the request
+func Classify(err error) Failure {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return FailureNone
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return FailureDNS
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return FailureConnectionRefused
+	}
+	if isTLSError(err) {
+		return FailureTLS
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return FailureTimeout
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return FailureTimeout
+	}
+	return FailureNone
+}
+
+func isTLSError(err error) bool {
+	var (
+		rhe tls.RecordHeaderError
+		ae  tls.AlertError
+		cve *tls.CertificateVerificationError
+		uae x509.UnknownAuthorityError
+		he  x509.HostnameError
+		cie x509.CertificateInvalidError
+	)
+	return errors.As(err, &rhe) || errors.As(err, &ae) || errors.As(err, &cve) ||
+		errors.As(err, &uae) || errors.As(err, &he) || errors.As(err, &cie)
+}
+
+// FailureTTL returns the TTL of the Failure in the Lookup, if present
+func (l Lookup) FailureTTL(f Failure) (time.Duration, bool) {
+	if f == FailureNone {
+		return 0, false
+	}
+	d, ok := l[int(f)]
+	return d, ok
+}
+
+// HasFailures returns true if the Lookup includes any transport Failures
+func (l Lookup) HasFailures() bool {
+	for k := range l {
+		if k < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// FailureCache is an in-process store of recent transport failures, keyed by
+// upstream origin, whose entries expire after their negative cache TTL
+type FailureCache struct {
+	mtx       sync.Mutex
+	entries   map[string]failureEntry
+	lastSweep time.Time
+}
+
+type failureEntry struct {
+	failure Failure
+	expires time.Time
+}
+
+// failureSweepInterval is how often expired entries are removed from a
+// FailureCache, so that entries for origins that are not requested again do
+// not accumulate
+const failureSweepInterval = time.Minute
+
+// NewFailureCache returns a new, empty FailureCache
+func NewFailureCache() *FailureCache {
+	return &FailureCache{
+		entries:   make(map[string]failureEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// Get returns the unexpired Failure stored for the key, or FailureNone
+func (c *FailureCache) Get(key string) Failure {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return FailureNone
+	}
+	if !time.Now().Before(e.expires) {
+		delete(c.entries, key)
+		return FailureNone
+	}
+	return e.failure
+}
+
+// Set stores the Failure for the key until the TTL elapses
+func (c *FailureCache) Set(key string, f Failure, ttl time.Duration) {
+	if f == FailureNone || ttl <= 0 {
+		return
+	}
+	now := time.Now()
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if now.Sub(c.lastSweep) >= failureSweepInterval {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.lastSweep = now
+	}
+	c.entries[key] = failureEntry{failure: f, expires: now.Add(ttl)}
+}
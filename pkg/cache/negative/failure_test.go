@@ -0,0 +1,107 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package negative
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	wrap := func(err error) error {
+		return &url.Error{Op: "Get", URL: "http://example.com/", Err: err}
+	}
+	cases := []struct {
+		name string
+		err  error
+		want Failure
+	}{
+		{"nil", nil, FailureNone},
+		{"canceled", wrap(context.Canceled), FailureNone},
+		{"other", wrap(errors.New("trouble")), FailureNone},
+		{"deadline", wrap(context.DeadlineExceeded), FailureTimeout},
+		{"io timeout", wrap(&net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}), FailureTimeout},
+		{"refused", wrap(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect",
+			syscall.ECONNREFUSED)}), FailureConnectionRefused},
+		{"tls", wrap(x509.UnknownAuthorityError{}), FailureTLS},
+		{"dns", wrap(&net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host",
+			Name: "example.com", IsNotFound: true}}), FailureDNS},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.want, Classify(c.err))
+		})
+	}
+}
+
+func TestFailureResponse(t *testing.T) {
+	require.Equal(t, http.StatusGatewayTimeout, FailureTimeout.StatusCode())
+	require.Equal(t, http.StatusBadGateway, FailureDNS.StatusCode())
+	require.Equal(t, "upstream transport failure: tls\n", string(FailureTLS.Body()))
+	require.Equal(t, "none", FailureNone.String())
+}
+
+func TestValidateAndCompileFailures(t *testing.T) {
+	l, err := ConfigLookup{"default": Config{
+		"404":                time.Second,
+		"timeout":            2 * time.Second,
+		"connection_refused": 3 * time.Second,
+	}}.ValidateAndCompile()
+	require.NoError(t, err)
+	lk := l.Get("default")
+	require.True(t, lk.HasFailures())
+	d, ok := lk.FailureTTL(FailureTimeout)
+	require.True(t, ok)
+	require.Equal(t, 2*time.Second, d)
+	_, ok = lk.FailureTTL(FailureDNS)
+	require.False(t, ok)
+	_, ok = lk.FailureTTL(FailureNone)
+	require.False(t, ok)
+	require.False(t, Lookup{404: time.Second}.HasFailures())
+}
+
+func TestFailureCache(t *testing.T) {
+	c := NewFailureCache()
+	require.Equal(t, FailureNone, c.Get("a"))
+	c.Set("a", FailureTimeout, time.Minute)
+	require.Equal(t, FailureTimeout, c.Get("a"))
+	c.Set("b", FailureDNS, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	require.Equal(t, FailureNone, c.Get("b"))
+	c.Set("c", FailureNone, time.Minute)
+	require.Equal(t, FailureNone, c.Get("c"))
+
+	// expired entries are swept on Set once the sweep interval has elapsed
+	for i := range 3 {
+		c.Set(fmt.Sprintf("x%d", i), FailureTLS, time.Nanosecond)
+	}
+	time.Sleep(time.Millisecond)
+	c.lastSweep = time.Now().Add(-failureSweepInterval)
+	c.Set("d", FailureTLS, time.Minute)
+	require.Len(t, c.entries, 2)
+}
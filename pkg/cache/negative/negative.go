@@ -15,7 +15,7 @@
  */
 
 // Package negative defines the Negative Cache
-// which is a simple lookup map of httpStatus to TTL
+// which is a simple lookup map of httpStatus or transport Failure to TTL
 package negative
 
 import (
@@ -33,12 +33,13 @@ func New() Config {
 	return Config{}
 }
 
-// Config is a collection of response codes and their TTLs
-// While the status code is numeric, it's deserialized here as a string for
-// maximum compatibility with templating in Helm
+// Config is a collection of response codes or transport failure names and
+// their TTLs. While the status code is numeric, it's deserialized here as a
+// string for maximum compatibility with templating in Helm
 type Config map[string]time.Duration
 
-// Lookup is a collection of response codes and their TTLs as Durations
+// Lookup is a collection of response codes and their TTLs as Durations.
+// Transport Failures are keyed by their (negative) Failure value
 type Lookup map[int]time.Duration
 
 // Lookups is a collection of Lookup maps
@@ -53,7 +54,8 @@ type ErrInvalidConfig struct {
 func NewErrInvalidConfig(negativeCacheName, code string) error {
 	return &ErrInvalidConfig{
 		error: fmt.Errorf(`invalid negative_cache config in %s: `+
-			`%s is not a valid HTTP status code >= 400 and < 600`,
+			`%s is not a valid HTTP status code >= 400 and < 600 `+
+			`or transport failure name`,
 			negativeCacheName, code),
 	}
 }
@@ -80,6 +82,10 @@ func (l ConfigLookup) ValidateAndCompile() (Lookups, error) {
 	for k, n := range l {
 		lk := make(Lookup)
 		for c, t := range n {
+			if f, ok := failureValues[c]; ok {
+				lk[int(f)] = t
+				continue
+			}
 			ci, err := strconv.Atoi(c)
 			if err != nil || ci < 400 || ci >= 600 {
 				return nil, NewErrInvalidConfig(k, c)
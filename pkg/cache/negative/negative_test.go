@@ -109,28 +109,28 @@ func TestConfigLookupValidateAndCompile(t *testing.T) {
 			input: ConfigLookup{
 				"default": Config{"a": time.Minute},
 			},
-			wantErr: `invalid negative_cache config in default: a is not a valid HTTP status code >= 400 and < 600`,
+			wantErr: `invalid negative_cache config in default: a is not a valid HTTP status code >= 400 and < 600 or transport failure name`,
 		},
 		{
 			name: "invalid code below 400",
 			input: ConfigLookup{
 				"default": Config{"399": time.Minute},
 			},
-			wantErr: `invalid negative_cache config in default: 399 is not a valid HTTP status code >= 400 and < 600`,
+			wantErr: `invalid negative_cache config in default: 399 is not a valid HTTP status code >= 400 and < 600 or transport failure name`,
 		},
 		{
 			name: "invalid code at or above 600",
 			input: ConfigLookup{
 				"default": Config{"600": time.Minute},
 			},
-			wantErr: `invalid negative_cache config in default: 600 is not a valid HTTP status code >= 400 and < 600`,
+			wantErr: `invalid negative_cache config in default: 600 is not a valid HTTP status code >= 400 and < 600 or transport failure name`,
 		},
 		{
 			name: "invalid code in named cache",
 			input: ConfigLookup{
 				"foo": Config{"1212": time.Minute},
 			},
-			wantErr: `invalid negative_cache config in foo: 1212 is not a valid HTTP status code >= 400 and < 600`,
+			wantErr: `invalid negative_cache config in foo: 1212 is not a valid HTTP status code >= 400 and < 600 or transport failure name`,
 		},
 	}
 
@@ -154,7 +154,7 @@ func TestNewErrInvalidConfig(t *testing.T) {
 	err := NewErrInvalidConfig("test-cache", "abc")
 	require.Error(t, err)
 	require.Equal(t,
-		`invalid negative_cache config in test-cache: abc is not a valid HTTP status code >= 400 and < 600`,
+		`invalid negative_cache config in test-cache: abc is not a valid HTTP status code >= 400 and < 600 or transport failure name`,
 		err.Error())
 	require.IsType(t, &ErrInvalidConfig{}, err)
 }
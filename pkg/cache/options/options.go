@@ -23,12 +23,17 @@ import (
 
 	badger "github.com/trickstercache/trickster/v2/pkg/cache/badger/options"
 	bbolt "github.com/trickstercache/trickster/v2/pkg/cache/bbolt/options"
+	encryption "github.com/trickstercache/trickster/v2/pkg/cache/encryption/options"
 	filesystem "github.com/trickstercache/trickster/v2/pkg/cache/filesystem/options"
 	index "github.com/trickstercache/trickster/v2/pkg/cache/index/options"
+	memcached "github.com/trickstercache/trickster/v2/pkg/cache/memcached/options"
 	memory "github.com/trickstercache/trickster/v2/pkg/cache/memory/options"
 	"github.com/trickstercache/trickster/v2/pkg/cache/options/defaults"
+	peer "github.com/trickstercache/trickster/v2/pkg/cache/peer/options"
 	"github.com/trickstercache/trickster/v2/pkg/cache/providers"
 	redis "github.com/trickstercache/trickster/v2/pkg/cache/redis/options"
+	s3 "github.com/trickstercache/trickster/v2/pkg/cache/s3/options"
+	tiered "github.com/trickstercache/trickster/v2/pkg/cache/tiered/options"
 	"github.com/trickstercache/trickster/v2/pkg/config/types"
 	"github.com/trickstercache/trickster/v2/pkg/util/pointers"
 	"github.com/trickstercache/trickster/v2/pkg/util/sets"
@@ -43,7 +48,7 @@ type Lookup map[string]*Options
 type Options struct {
 	// Name is the Name of the cache, taken from the Key in the Caches map[string]*CacheConfig
 	Name string `yaml:"-"`
-	// Provider represents the type of cache that we wish to use: "boltdb", "memory", "filesystem", or "redis"
+	// Provider represents the type of cache that we wish to use: "boltdb", "memory", "filesystem", "redis", "memcached", "s3", "tiered", or "peer"
 	Provider string `yaml:"provider,omitempty"`
 	// Index provides options for the Cache Index
 	Index *index.Options `yaml:"index,omitempty"`
@@ -57,6 +62,16 @@ type Options struct {
 	Badger *badger.Options `yaml:"badger,omitempty"`
 	// Memory provides options for Memory caching
 	Memory *memory.Options `yaml:"memory,omitempty"`
+	// Memcached provides options for Memcached caching
+	Memcached *memcached.Options `yaml:"memcached,omitempty"`
+	// S3 provides options for S3-compatible object storage caching
+	S3 *s3.Options `yaml:"s3,omitempty"`
+	// Tiered provides options for Tiered caching
+	Tiered *tiered.Options `yaml:"tiered,omitempty"`
+	// Peer provides options for Peer caching
+	Peer *peer.Options `yaml:"peer,omitempty"`
+	// Encryption provides options for encrypting cache values at rest
+	Encryption *encryption.Options `yaml:"encryption,omitempty"`
 
 	// Defines if the cache should use cache chunking. Splits cache objects into smaller, reliably-sized parts.
 	UseCacheChunking bool `yaml:"use_cache_chunking,omitempty"`
@@ -77,6 +92,20 @@ var _ types.ConfigOptions[Options] = &Options{}
 var (
 	restrictedNames = sets.New([]string{"", "none"})
 	ErrInvalidName  = errors.New("invalid cache name")
+	// ErrInvalidTier is returned when a tiered cache references an unknown cache
+	ErrInvalidTier = errors.New("invalid tier cache name")
+	// ErrNestedTier is returned when a tiered cache references itself, another
+	// tiered cache, or a peer cache
+	ErrNestedTier = errors.New("tiered cache cannot use a tiered or peer cache as a tier")
+	// ErrInvalidPeerCache is returned when a peer cache references an unknown cache
+	ErrInvalidPeerCache = errors.New("invalid peer cache local or hot cache name")
+	// ErrNestedPeerCache is returned when a peer cache references itself or another peer cache
+	ErrNestedPeerCache = errors.New("peer cache cannot use a peer cache as its local or hot cache")
+	// ErrNoPeers is returned when a peer cache has no peers, and none are configured in the mgmt config
+	ErrNoPeers = errors.New("peer cache requires 'peers'")
+	// ErrEncryptionUnsupported is returned when encryption is configured for a
+	// cache that holds objects by reference
+	ErrEncryptionUnsupported = errors.New("encryption is not supported by memory, tiered or peer caches")
 )
 
 // New will return a pointer to a CacheOptions with the default configuration settings
@@ -89,6 +118,10 @@ func New() *Options {
 		BBolt:                 bbolt.New(),
 		Badger:                badger.New(),
 		Memory:                memory.New(),
+		Memcached:             memcached.New(),
+		S3:                    s3.New(),
+		Tiered:                tiered.New(),
+		Peer:                  peer.New(),
 		Index:                 index.New(),
 		UseCacheChunking:      defaults.DefaultUseCacheChunking,
 		TimeseriesChunkFactor: defaults.DefaultTimeseriesChunkFactor,
@@ -104,7 +137,14 @@ func (o *Options) Clone() *Options {
 	out.BBolt = pointers.Clone(o.BBolt)
 	out.Badger = pointers.Clone(o.Badger)
 	out.Memory = pointers.Clone(o.Memory)
-	out.Index = pointers.Clone(o.Index)
+	out.Memcached = o.Memcached.Clone()
+	out.S3 = pointers.Clone(o.S3)
+	out.Tiered = o.Tiered.Clone()
+	out.Peer = o.Peer.Clone()
+	if o.Index != nil {
+		out.Index = o.Index.Clone()
+	}
+	out.Encryption = o.Encryption.Clone()
 	return out
 }
 
@@ -119,9 +159,16 @@ func (o *Options) Equal(o2 *Options) bool {
 		o.ProviderID != o2.ProviderID ||
 		o.UseCacheChunking != o2.UseCacheChunking ||
 		o.TimeseriesChunkFactor != o2.TimeseriesChunkFactor ||
-		o.ByterangeChunkSize != o2.ByterangeChunkSize {
+		o.ByterangeChunkSize != o2.ByterangeChunkSize ||
+		!o.Encryption.Equal(o2.Encryption) {
 		return false
 	}
+	if o.ProviderID == providers.TieredID {
+		return o.Tiered.Equal(o2.Tiered)
+	}
+	if o.ProviderID == providers.PeerID {
+		return o.Peer.Equal(o2.Peer)
+	}
 	if (o.Index == nil || o2.Index == nil) || !o.Index.Equal(o2.Index) {
 		return false
 	}
@@ -134,6 +181,10 @@ func (o *Options) Equal(o2 *Options) bool {
 		return o.BBolt.Equal(o2.BBolt)
 	case providers.BadgerDBID:
 		return o.Badger.Equal(o2.Badger)
+	case providers.MemcachedID:
+		return o.Memcached.Equal(o2.Memcached)
+	case providers.S3ID:
+		return o.S3.Equal(o2.S3)
 	default: // memory
 		return o.Memory.Equal(o2.Memory)
 	}
@@ -143,6 +194,40 @@ func (o *Options) Validate() (bool, error) {
 	if restrictedNames.Contains(o.Name) {
 		return false, ErrInvalidName
 	}
+	if o.ProviderID == providers.TieredID && o.Tiered != nil {
+		if err := o.Tiered.Validate(); err != nil {
+			return false, fmt.Errorf("cache %s: %w", o.Name, err)
+		}
+	}
+	if o.ProviderID == providers.PeerID && o.Peer != nil {
+		if err := o.Peer.Validate(); err != nil {
+			return false, fmt.Errorf("cache %s: %w", o.Name, err)
+		}
+	}
+	if o.ProviderID == providers.MemoryID && o.Memory != nil {
+		if err := o.Memory.Validate(); err != nil {
+			return false, fmt.Errorf("cache %s: %w", o.Name, err)
+		}
+	}
+	if o.ProviderID == providers.MemcachedID && o.Memcached != nil {
+		if err := o.Memcached.Validate(); err != nil {
+			return false, fmt.Errorf("cache %s: %w", o.Name, err)
+		}
+	}
+	if o.ProviderID == providers.S3ID && o.S3 != nil {
+		if err := o.S3.Validate(); err != nil {
+			return false, fmt.Errorf("cache %s: %w", o.Name, err)
+		}
+	}
+	if o.Encryption != nil {
+		if o.ProviderID == providers.MemoryID || o.ProviderID == providers.TieredID ||
+			o.ProviderID == providers.PeerID {
+			return false, fmt.Errorf("cache %s: %w", o.Name, ErrEncryptionUnsupported)
+		}
+		if err := o.Encryption.Validate(); err != nil {
+			return false, fmt.Errorf("cache %s: %w", o.Name, err)
+		}
+	}
 	if o.Index == nil {
 		return true, nil
 	}
@@ -152,6 +237,9 @@ func (o *Options) Validate() (bool, error) {
 	if o.Index.MaxSizeObjects > 0 && o.Index.MaxSizeBackoffObjects > o.Index.MaxSizeObjects {
 		return false, errMaxSizeBackoffObjectsTooBig
 	}
+	if err := o.Index.Validate(); err != nil {
+		return false, fmt.Errorf("cache %s: %w", o.Name, err)
+	}
 
 	return true, nil
 }
@@ -215,6 +303,34 @@ func (o *Options) Initialize(name string) error {
 	} else {
 		o.Memory = nil
 	}
+	if o.ProviderID == providers.MemcachedID {
+		if o.Memcached == nil {
+			o.Memcached = memcached.New()
+		}
+	} else {
+		o.Memcached = nil
+	}
+	if o.ProviderID == providers.S3ID {
+		if o.S3 == nil {
+			o.S3 = s3.New()
+		}
+	} else {
+		o.S3 = nil
+	}
+	if o.ProviderID == providers.TieredID {
+		if o.Tiered == nil {
+			o.Tiered = tiered.New()
+		}
+	} else {
+		o.Tiered = nil
+	}
+	if o.ProviderID == providers.PeerID {
+		if o.Peer == nil {
+			o.Peer = peer.New()
+		}
+	} else {
+		o.Peer = nil
+	}
 
 	o.UseCacheChunking = defaults.DefaultUseCacheChunking
 
@@ -233,6 +349,27 @@ func (o *Options) Initialize(name string) error {
 func (l Lookup) Initialize(activeCaches sets.Set[string]) ([]string, error) {
 	var warnings []string
 
+	// the local and hot caches of an active peer cache, and the tiers of an
+	// active tiered cache, are themselves active
+	for k := range activeCaches {
+		if o, ok := l[k]; ok && o != nil && o.Peer != nil &&
+			strings.TrimSpace(strings.ToLower(o.Provider)) == providers.Peer {
+			for _, n := range []string{o.Peer.Local, o.Peer.HotCache} {
+				if n != "" {
+					activeCaches.Set(n)
+				}
+			}
+		}
+	}
+	for k := range activeCaches {
+		if o, ok := l[k]; ok && o != nil && o.Tiered != nil &&
+			strings.TrimSpace(strings.ToLower(o.Provider)) == providers.Tiered {
+			for _, t := range o.Tiered.Tiers {
+				activeCaches.Set(t)
+			}
+		}
+	}
+
 	for k := range l {
 		if _, ok := activeCaches[k]; !ok {
 			delete(l, k)
@@ -278,10 +415,60 @@ func (l Lookup) Validate() error {
 		if err != nil {
 			return err
 		}
+		if o.ProviderID == providers.TieredID && o.Tiered != nil {
+			for _, t := range o.Tiered.Tiers {
+				to, ok := l[t]
+				if !ok || to == nil {
+					return fmt.Errorf("%w: %s in cache %s", ErrInvalidTier, t, k)
+				}
+				if t == k || to.ProviderID == providers.TieredID ||
+					to.ProviderID == providers.PeerID {
+					return fmt.Errorf("%w: %s in cache %s", ErrNestedTier, t, k)
+				}
+			}
+		}
+		if o.ProviderID == providers.PeerID && o.Peer != nil {
+			if o.Peer.Peers == nil {
+				return fmt.Errorf("cache %s: %w", k, ErrNoPeers)
+			}
+			for _, n := range []string{o.Peer.Local, o.Peer.HotCache} {
+				if n == "" {
+					continue
+				}
+				po, ok := l[n]
+				if !ok || po == nil {
+					return fmt.Errorf("%w: %s in cache %s", ErrInvalidPeerCache, n, k)
+				}
+				if n == k || po.ProviderID == providers.PeerID {
+					return fmt.Errorf("%w: %s in cache %s", ErrNestedPeerCache, n, k)
+				}
+			}
+		}
 	}
 	return nil
 }
 
+// TieredLast returns the names of the caches in the Lookup, with any
+// Tiered caches listed after all other caches so that their tiers
+// can be loaded before them, and any Peer caches listed last so that their
+// local and hot caches, which may be Tiered caches, are loaded before them
+func (l Lookup) TieredLast() []string {
+	out := make([]string, 0, len(l))
+	var tiered, peer []string
+	for k, o := range l {
+		if o != nil && o.ProviderID == providers.TieredID {
+			tiered = append(tiered, k)
+			continue
+		}
+		if o != nil && o.ProviderID == providers.PeerID {
+			peer = append(peer, k)
+			continue
+		}
+		out = append(out, k)
+	}
+	return append(append(out, tiered...), peer...)
+}
+
 func (o *Options) UnmarshalYAML(value *yaml.Node) error {
 	type loadOptions Options
 	lo := loadOptions(*(New()))
@@ -299,4 +486,8 @@ func (o *Options) ClearProviderOptions() {
 	o.BBolt = nil
 	o.Badger = nil
 	o.Memory = nil
+	o.Memcached = nil
+	o.S3 = nil
+	o.Tiered = nil
+	o.Peer = nil
 }
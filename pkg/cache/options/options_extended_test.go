@@ -17,9 +17,14 @@
 package options
 
 import (
+	"errors"
 	"testing"
 
+	encryption "github.com/trickstercache/trickster/v2/pkg/cache/encryption/options"
+	index "github.com/trickstercache/trickster/v2/pkg/cache/index/options"
+	memcached "github.com/trickstercache/trickster/v2/pkg/cache/memcached/options"
 	"github.com/trickstercache/trickster/v2/pkg/cache/providers"
+	peers "github.com/trickstercache/trickster/v2/pkg/proxy/peers/options"
 	"github.com/trickstercache/trickster/v2/pkg/util/sets"
 
 	"go.yaml.in/yaml/v3"
@@ -50,11 +55,37 @@ func TestValidate(t *testing.T) {
 		t.Fatalf("Validate backoff objects = (%v, %v)", ok, err)
 	}
 
+	o = New()
+	o.Name = "default"
+	o.Index.Quotas = map[string]*index.QuotaOptions{"prom": {Backend: "prom"}}
+	if ok, err := o.Validate(); ok || !errors.Is(err, index.ErrQuotaNoLimit) {
+		t.Fatalf("Validate quota = (%v, %v)", ok, err)
+	}
+
 	o = New()
 	o.Name = "default"
 	if ok, err := o.Validate(); !ok || err != nil {
 		t.Fatalf("Validate(default) = (%v, %v)", ok, err)
 	}
+
+	o = New()
+	o.Name = "default"
+	o.ProviderID = providers.MemcachedID
+	o.Memcached.Servers = nil
+	if ok, err := o.Validate(); ok || !errors.Is(err, memcached.ErrNoServers) {
+		t.Fatalf("Validate(memcached) = (%v, %v)", ok, err)
+	}
+
+	o = New()
+	o.Name = "default"
+	o.Encryption = encryption.New()
+	if ok, err := o.Validate(); ok || !errors.Is(err, ErrEncryptionUnsupported) {
+		t.Fatalf("Validate(memory encryption) = (%v, %v)", ok, err)
+	}
+	o.ProviderID = providers.RedisID
+	if ok, err := o.Validate(); ok || !errors.Is(err, encryption.ErrNoActiveKey) {
+		t.Fatalf("Validate(redis encryption) = (%v, %v)", ok, err)
+	}
 }
 
 func TestLookupValidate(t *testing.T) {
@@ -87,6 +118,30 @@ func TestEqualProviderBranches(t *testing.T) {
 		t.Fatal("expected redis endpoint difference to make options unequal")
 	}
 
+	mc := New()
+	mc.Provider = providers.Memcached
+	mc.ProviderID = providers.MemcachedID
+	if !mc.Equal(mc.Clone()) {
+		t.Fatal("memcached options should be equal to clone")
+	}
+	mc2 := mc.Clone()
+	mc2.Memcached.Servers[0] = "different:11211"
+	if mc.Equal(mc2) {
+		t.Fatal("expected memcached servers difference to make options unequal")
+	}
+
+	o3 := New()
+	o3.Provider = providers.S3
+	o3.ProviderID = providers.S3ID
+	if !o3.Equal(o3.Clone()) {
+		t.Fatal("s3 options should be equal to clone")
+	}
+	o4 := o3.Clone()
+	o4.S3.Bucket = "different"
+	if o3.Equal(o4) || o3.S3.Bucket == "different" {
+		t.Fatal("expected s3 bucket difference to make options unequal")
+	}
+
 	fs := New()
 	fs.Provider = providers.Filesystem
 	fs.ProviderID = providers.FilesystemID
@@ -179,3 +234,145 @@ caches:
 		t.Fatalf("unexpected cache options: %+v", c)
 	}
 }
+
+func TestLookupValidateTiered(t *testing.T) {
+	t.Parallel()
+
+	newTiered := func(tiers ...string) *Options {
+		o := New()
+		o.Provider = providers.Tiered
+		o.ProviderID = providers.TieredID
+		o.Tiered.Tiers = tiers
+		return o
+	}
+
+	l := Lookup{"l1": New(), "l2": New(), "tiered": newTiered("l1", "l2")}
+	if err := l.Validate(); err != nil {
+		t.Fatalf("Lookup.Validate: %v", err)
+	}
+	if names := l.TieredLast(); names[len(names)-1] != "tiered" {
+		t.Errorf("expected tiered cache to be last, got %v", names)
+	}
+
+	l = Lookup{"l1": New(), "tiered": newTiered("l1", "missing")}
+	if err := l.Validate(); !errors.Is(err, ErrInvalidTier) {
+		t.Errorf("expected %v got %v", ErrInvalidTier, err)
+	}
+
+	l = Lookup{"l1": New(), "tiered": newTiered("l1", "tiered")}
+	if err := l.Validate(); !errors.Is(err, ErrNestedTier) {
+		t.Errorf("expected %v got %v", ErrNestedTier, err)
+	}
+
+	l = Lookup{"tiered": newTiered()}
+	if err := l.Validate(); err == nil {
+		t.Error("expected error for tiered cache without tiers")
+	}
+}
+
+func TestLookupInitializeTieredActivatesTiers(t *testing.T) {
+	t.Parallel()
+
+	tc := New()
+	tc.Provider = providers.Tiered
+	tc.Tiered.Tiers = []string{"l1"}
+	l := Lookup{"l1": New(), "unused": New(), "tiered": tc}
+	if _, err := l.Initialize(sets.New([]string{"tiered"})); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := l["l1"]; !ok {
+		t.Error("expected tier l1 to remain active")
+	}
+	if _, ok := l["unused"]; ok {
+		t.Error("expected unused cache to be removed")
+	}
+	if l["tiered"].ProviderID != providers.TieredID || l["tiered"].Index != nil {
+		t.Errorf("unexpected tiered options %+v", l["tiered"])
+	}
+}
+
+func TestLookupValidatePeer(t *testing.T) {
+	t.Parallel()
+
+	newPeer := func(local, hot string) *Options {
+		o := New()
+		o.Provider = providers.Peer
+		o.ProviderID = providers.PeerID
+		o.Peer.Local = local
+		o.Peer.HotCache = hot
+		o.Peer.Peers = &peers.Options{Peers: []string{"trickster-1:8484"}, Secret: "s3cret"}
+		return o
+	}
+	tiered := New()
+	tiered.Provider = providers.Tiered
+	tiered.ProviderID = providers.TieredID
+	tiered.Tiered.Tiers = []string{"local"}
+
+	l := Lookup{"local": New(), "hot": New(), "tiered": tiered, "peer": newPeer("local", "hot")}
+	if err := l.Validate(); err != nil {
+		t.Fatalf("Lookup.Validate: %v", err)
+	}
+	if names := l.TieredLast(); names[len(names)-1] != "peer" || names[len(names)-2] != "tiered" {
+		t.Errorf("expected the peer cache to be last, after the tiered cache, got %v", names)
+	}
+
+	l = Lookup{"local": New(), "peer": newPeer("local", "missing")}
+	if err := l.Validate(); !errors.Is(err, ErrInvalidPeerCache) {
+		t.Errorf("expected %v got %v", ErrInvalidPeerCache, err)
+	}
+
+	l = Lookup{"local": New(), "peer": newPeer("peer", "")}
+	if err := l.Validate(); !errors.Is(err, ErrNestedPeerCache) {
+		t.Errorf("expected %v got %v", ErrNestedPeerCache, err)
+	}
+
+	l = Lookup{"local": New(), "peer": newPeer("local", ""), "tiered": tiered}
+	tiered.Tiered.Tiers = []string{"peer"}
+	if err := l.Validate(); !errors.Is(err, ErrNestedTier) {
+		t.Errorf("expected %v got %v", ErrNestedTier, err)
+	}
+
+	p := newPeer("local", "")
+	p.Peer.Peers = nil
+	l = Lookup{"local": New(), "peer": p}
+	if err := l.Validate(); !errors.Is(err, ErrNoPeers) {
+		t.Errorf("expected %v got %v", ErrNoPeers, err)
+	}
+
+	p = newPeer("local", "")
+	p.Name = "peer"
+	p.Encryption = encryption.New()
+	if _, err := p.Validate(); !errors.Is(err, ErrEncryptionUnsupported) {
+		t.Errorf("expected %v got %v", ErrEncryptionUnsupported, err)
+	}
+}
+
+func TestLookupInitializePeerActivatesCaches(t *testing.T) {
+	t.Parallel()
+
+	pc := New()
+	pc.Provider = providers.Peer
+	pc.Peer.Local = "tiered"
+	pc.Peer.HotCache = "hot"
+	tc := New()
+	tc.Provider = providers.Tiered
+	tc.Tiered.Tiers = []string{"l1"}
+	l := Lookup{"l1": New(), "hot": New(), "unused": New(), "tiered": tc, "peer": pc}
+	if _, err := l.Initialize(sets.New([]string{"peer"})); err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{"l1", "hot", "tiered"} {
+		if _, ok := l[k]; !ok {
+			t.Errorf("expected %s to remain active", k)
+		}
+	}
+	if _, ok := l["unused"]; ok {
+		t.Error("expected unused cache to be removed")
+	}
+	if l["peer"].ProviderID != providers.PeerID || l["peer"].Index != nil || l["peer"].Tiered != nil {
+		t.Errorf("unexpected peer options %+v", l["peer"])
+	}
+	if !l["peer"].Equal(l["peer"].Clone()) {
+		t.Error("expected clone to be equal")
+	}
+}
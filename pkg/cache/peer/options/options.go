@@ -0,0 +1,139 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"errors"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	peers "github.com/trickstercache/trickster/v2/pkg/proxy/peers/options"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// DefaultHotTTL is the default TTL of the copies of objects owned by other
+	// peers that are kept in the hot cache
+	DefaultHotTTL = time.Minute
+	// DefaultRefreshInterval is the default interval at which the peers are
+	// resolved, so that DNS and file-based membership changes are applied
+	DefaultRefreshInterval = 15 * time.Second
+	// DefaultMaxObjectSizeBytes is the default size limit of the objects read
+	// from and written to the peers
+	DefaultMaxObjectSizeBytes = 16 << 20
+)
+
+var (
+	// ErrNoLocal is returned when a Peer cache is configured without a local cache
+	ErrNoLocal = errors.New("peer cache requires a 'local' cache")
+	// ErrHotIsLocal is returned when the hot cache is also the local cache
+	ErrHotIsLocal = errors.New("peer cache 'hot_cache' cannot be the 'local' cache")
+	// ErrInvalidPeerCacheValue is returned when the hot TTL, refresh interval or
+	// max object size is negative
+	ErrInvalidPeerCacheValue = errors.New("peer cache hot_ttl, refresh_interval and max_object_size_bytes must be greater than or equal to 0")
+)
+
+// Options is a collection of Configurations for a Peer cache, which shards
+// cache keys across the Trickster peers
+type Options struct {
+	// Local is the name of the cache holding the objects owned by this
+	// instance, which is also used when the owning peer cannot be reached
+	Local string `yaml:"local,omitempty"`
+	// HotCache is the name of an optional cache holding copies of the objects
+	// owned by other peers that were recently read or written by this instance
+	HotCache string `yaml:"hot_cache,omitempty"`
+	// HotTTL is the TTL of the objects in the HotCache
+	HotTTL timeconv.Duration `yaml:"hot_ttl,omitempty"`
+	// RefreshInterval is how often the peers are resolved
+	RefreshInterval timeconv.Duration `yaml:"refresh_interval,omitempty"`
+	// MaxObjectSizeBytes is the size limit of the objects read from and
+	// written to the peers. Larger objects are rejected by the owner, and
+	// are read from the local cache instead when returned by a peer
+	MaxObjectSizeBytes int64 `yaml:"max_object_size_bytes,omitempty"`
+	// Peers provides the membership of the cluster. When omitted, the peers
+	// from the mgmt config are used
+	Peers *peers.Options `yaml:"peers,omitempty"`
+}
+
+// New returns a reference to a new Peer Options
+func New() *Options {
+	return &Options{
+		HotTTL:             timeconv.Duration(DefaultHotTTL),
+		RefreshInterval:    timeconv.Duration(DefaultRefreshInterval),
+		MaxObjectSizeBytes: DefaultMaxObjectSizeBytes,
+	}
+}
+
+// Clone returns a copy of the Options
+func (o *Options) Clone() *Options {
+	if o == nil {
+		return nil
+	}
+	out := *o
+	out.Peers = o.Peers.Clone()
+	return &out
+}
+
+// Validate returns an error if the Options are invalid
+func (o *Options) Validate() error {
+	if o.Local == "" {
+		return ErrNoLocal
+	}
+	if o.HotCache == o.Local {
+		return ErrHotIsLocal
+	}
+	if o.HotTTL < 0 || o.RefreshInterval < 0 || o.MaxObjectSizeBytes < 0 {
+		return ErrInvalidPeerCacheValue
+	}
+	if o.HotTTL == 0 {
+		o.HotTTL = timeconv.Duration(DefaultHotTTL)
+	}
+	if o.RefreshInterval == 0 {
+		o.RefreshInterval = timeconv.Duration(DefaultRefreshInterval)
+	}
+	if o.MaxObjectSizeBytes == 0 {
+		o.MaxObjectSizeBytes = DefaultMaxObjectSizeBytes
+	}
+	return o.Peers.Validate()
+}
+
+func (o *Options) UnmarshalYAML(value *yaml.Node) error {
+	type loadOptions Options
+	lo := loadOptions(*(New()))
+	if err := value.Decode(&lo); err != nil {
+		return err
+	}
+	*o = Options(lo)
+	return nil
+}
+
+// Equal returns true if all values in the Options are identical
+func (o *Options) Equal(o2 *Options) bool {
+	if o2 == nil {
+		return o == nil
+	}
+	if o == nil {
+		return false
+	}
+	return o.Local == o2.Local &&
+		o.HotCache == o2.HotCache &&
+		o.HotTTL == o2.HotTTL &&
+		o.RefreshInterval == o2.RefreshInterval &&
+		o.MaxObjectSizeBytes == o2.MaxObjectSizeBytes &&
+		o.Peers.Equal(o2.Peers)
+}
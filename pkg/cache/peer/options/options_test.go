@@ -0,0 +1,121 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	peers "github.com/trickstercache/trickster/v2/pkg/proxy/peers/options"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestNew(t *testing.T) {
+	o := New()
+	if time.Duration(o.HotTTL) != DefaultHotTTL {
+		t.Errorf("expected %s got %s", DefaultHotTTL, time.Duration(o.HotTTL))
+	}
+	if time.Duration(o.RefreshInterval) != DefaultRefreshInterval {
+		t.Errorf("expected %s got %s", DefaultRefreshInterval, time.Duration(o.RefreshInterval))
+	}
+	if o.MaxObjectSizeBytes != DefaultMaxObjectSizeBytes {
+		t.Errorf("expected %d got %d", DefaultMaxObjectSizeBytes, o.MaxObjectSizeBytes)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	o := New()
+	if err := o.Validate(); err != ErrNoLocal {
+		t.Errorf("expected %v got %v", ErrNoLocal, err)
+	}
+	o.Local = "l1"
+	o.HotCache = "l1"
+	if err := o.Validate(); err != ErrHotIsLocal {
+		t.Errorf("expected %v got %v", ErrHotIsLocal, err)
+	}
+	o.HotCache = "hot"
+	o.HotTTL = -1
+	if err := o.Validate(); err != ErrInvalidPeerCacheValue {
+		t.Errorf("expected %v got %v", ErrInvalidPeerCacheValue, err)
+	}
+	o.HotTTL = 0
+	o.MaxObjectSizeBytes = -1
+	if err := o.Validate(); err != ErrInvalidPeerCacheValue {
+		t.Errorf("expected %v got %v", ErrInvalidPeerCacheValue, err)
+	}
+	o.MaxObjectSizeBytes = 0
+	o.RefreshInterval = 0
+	if err := o.Validate(); err != nil {
+		t.Error(err)
+	}
+	if time.Duration(o.HotTTL) != DefaultHotTTL ||
+		time.Duration(o.RefreshInterval) != DefaultRefreshInterval ||
+		o.MaxObjectSizeBytes != DefaultMaxObjectSizeBytes {
+		t.Errorf("expected defaults, got %+v", o)
+	}
+	o.Peers = peers.New()
+	if err := o.Validate(); err != peers.ErrNoPeerSource {
+		t.Errorf("expected %v got %v", peers.ErrNoPeerSource, err)
+	}
+}
+
+func TestCloneEqual(t *testing.T) {
+	o := New()
+	o.Local = "l1"
+	o.Peers = &peers.Options{Peers: []string{"trickster-1:8484"}}
+	o2 := o.Clone()
+	if !o.Equal(o2) {
+		t.Error("expected clone to be equal")
+	}
+	o2.Peers.Peers[0] = "x"
+	if o.Peers.Peers[0] != "trickster-1:8484" {
+		t.Error("expected clone to have its own peers")
+	}
+	if o.Equal(o2) {
+		t.Error("expected peers difference to make options unequal")
+	}
+	if o.Equal(nil) {
+		t.Error("expected false for nil comparison")
+	}
+	if (*Options)(nil).Clone() != nil {
+		t.Error("expected nil clone")
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	const raw = `
+local: l1
+hot_cache: hot
+refresh_interval: 30s
+`
+	o := &Options{}
+	if err := yaml.Unmarshal([]byte(raw), o); err != nil {
+		t.Fatal(err)
+	}
+	if o.Local != "l1" || o.HotCache != "hot" ||
+		o.RefreshInterval != timeconv.Duration(30*time.Second) {
+		t.Errorf("unexpected options %+v", o)
+	}
+	if o.HotTTL != timeconv.Duration(DefaultHotTTL) {
+		t.Errorf("expected default hot ttl, got %s", time.Duration(o.HotTTL))
+	}
+	if err := yaml.Unmarshal([]byte("- boom"), o); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,360 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package peer is a Trickster Cache that shards cache keys across the
+// Trickster instances in a cluster. Each cache key is owned by one instance,
+// selected by rendezvous hashing over the peers, and objects owned by other
+// instances are read from and written to their owner over HTTP.
+package peer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/metrics"
+	"github.com/trickstercache/trickster/v2/pkg/cache/options"
+	peeropts "github.com/trickstercache/trickster/v2/pkg/cache/peer/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/peers"
+	"github.com/trickstercache/trickster/v2/pkg/util/safego"
+)
+
+// HandlerPath is the path on the management listener of each peer at which
+// the objects owned by its Peer caches are read, written and removed, as
+// HandlerPath/{cacheName}/{cacheKey}
+const HandlerPath = "/trickster/cache/peer/"
+
+// TTLParam is the query parameter providing the TTL of an object written to a peer
+const TTLParam = "ttl"
+
+// Cache implements the cache.Client interface
+var _ cache.Client = &Cache{}
+
+var (
+	// ErrCacheNotFound is returned by Connect when the local or hot cache does not exist
+	ErrCacheNotFound = errors.New("peer cache local or hot cache not found")
+	// ErrUnexpectedStatus is returned when a peer responds with an unexpected status code
+	ErrUnexpectedStatus = errors.New("unexpected peer response status")
+	// ErrObjectTooLarge is returned when a peer responds with an object larger
+	// than the max object size of the Peer cache
+	ErrObjectTooLarge = errors.New("peer object exceeds max_object_size_bytes")
+)
+
+// Cache defines a Peer Cache client that conforms to the Cache interface
+type Cache struct {
+	Name   string
+	Config *options.Options
+
+	lookup cache.Lookup
+	local  cache.Cache
+	hot    cache.Cache
+	group  *peers.Group
+	ring   atomic.Pointer[ring]
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// ring is the set of instances among which cache keys are sharded
+type ring struct {
+	nodes []*url.URL
+	names []string
+	self  string
+}
+
+// owner returns the base URL of the instance owning the cache key, or nil
+// when it is owned by this instance
+func (r *ring) owner(cacheKey string) *url.URL {
+	if r == nil || len(r.nodes) == 0 {
+		return nil
+	}
+	var best int
+	var bestScore uint64
+	for i, n := range r.names {
+		score := xxhash.Sum64String(n + "|" + cacheKey)
+		if i == 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if r.names[best] == r.self {
+		return nil
+	}
+	return r.nodes[best]
+}
+
+// New returns a new Peer cache whose local and hot caches will be resolved
+// from the provided lookup of already-loaded caches when Connect is called
+func New(name string, cfg *options.Options, lookup cache.Lookup) *Cache {
+	if cfg.Peer == nil {
+		cfg.Peer = peeropts.New()
+	}
+	return &Cache{Name: name, Config: cfg, lookup: lookup, stop: make(chan struct{})}
+}
+
+// Connect resolves the local and hot caches, which are owned by the lookup and
+// are expected to already be connected, and the peers. The peers are then
+// periodically resolved until the Cache is closed.
+func (c *Cache) Connect() error {
+	o := c.Config.Peer
+	local, ok := c.lookup[o.Local]
+	if !ok || local == nil {
+		return fmt.Errorf("%w: %s", ErrCacheNotFound, o.Local)
+	}
+	c.local = local
+	if o.HotCache != "" {
+		hot, ok := c.lookup[o.HotCache]
+		if !ok || hot == nil {
+			return fmt.Errorf("%w: %s", ErrCacheNotFound, o.HotCache)
+		}
+		c.hot = hot
+	}
+	c.group = peers.New(o.Peers)
+	if c.group == nil {
+		return nil
+	}
+	c.refresh()
+	safego.Go(func(r any, stack []byte) {
+		logger.Error("peer cache refresher panic", logging.Pairs{
+			"cacheName": c.Name, "panic": r, "stack": string(stack),
+		})
+	}, c.refresher)
+	return nil
+}
+
+func (c *Cache) refresher() {
+	t := time.NewTicker(time.Duration(c.Config.Peer.RefreshInterval))
+	defer t.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-t.C:
+			c.refresh()
+		}
+	}
+}
+
+// refresh resolves the peers and rebuilds the ring. When the peers cannot be
+// resolved at all, the previous ring is retained.
+func (c *Cache) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(),
+		time.Duration(c.Config.Peer.Peers.Timeout))
+	defer cancel()
+	nodes, self, err := c.group.Nodes(ctx)
+	if err != nil {
+		logger.Warn("peer cache failed to resolve peers",
+			logging.Pairs{"cacheName": c.Name, "error": err})
+		if len(nodes) == 0 {
+			return
+		}
+	}
+	if self == nil {
+		logger.Warn("peer cache could not identify this instance among its peers",
+			logging.Pairs{"cacheName": c.Name})
+	}
+	r := &ring{nodes: nodes, names: make([]string, len(nodes))}
+	for i, n := range nodes {
+		r.names[i] = n.String()
+	}
+	if self != nil {
+		r.self = self.String()
+	}
+	if prev := c.ring.Swap(r); prev == nil || !slices.Equal(prev.names, r.names) {
+		logger.Info("peer cache membership updated",
+			logging.Pairs{"cacheName": c.Name, "peers": r.names})
+		metrics.ObserveCachePeerMembers(c.Name, len(nodes))
+	}
+}
+
+// Close stops resolving the peers. The local and hot caches are owned and
+// closed by their own lookup.
+func (c *Cache) Close() error {
+	c.closeOnce.Do(func() { close(c.stop) })
+	return nil
+}
+
+// Local returns the cache holding the objects owned by this instance
+func (c *Cache) Local() cache.Cache {
+	return c.local
+}
+
+// Authorized returns true if the request carries the shared secret of the peers
+func (c *Cache) Authorized(r *http.Request) bool {
+	return c.group != nil && c.group.Authorized(r)
+}
+
+// MaxObjectSizeBytes returns the size limit of the objects read from and
+// written to the peers
+func (c *Cache) MaxObjectSizeBytes() int64 {
+	if n := c.Config.Peer.MaxObjectSizeBytes; n > 0 {
+		return n
+	}
+	return peeropts.DefaultMaxObjectSizeBytes
+}
+
+// Owner returns the base URL of the peer owning the cache key, or nil when the
+// cache key is owned by this instance
+func (c *Cache) Owner(cacheKey string) *url.URL {
+	return c.ring.Load().owner(cacheKey)
+}
+
+// hotTTL returns the TTL for a copy of an object in the hot cache
+func (c *Cache) hotTTL(ttl time.Duration) time.Duration {
+	if h := time.Duration(c.Config.Peer.HotTTL); ttl <= 0 || h < ttl {
+		return h
+	}
+	return ttl
+}
+
+// Store writes the object to the peer that owns the cache key, or to the local
+// cache when the owner is this instance or the owner cannot be reached
+func (c *Cache) Store(cacheKey string, data []byte, ttl time.Duration) error {
+	owner := c.Owner(cacheKey)
+	if owner == nil {
+		return c.local.Store(cacheKey, data, ttl)
+	}
+	if c.hot != nil {
+		c.hot.Store(cacheKey, data, c.hotTTL(ttl))
+	}
+	resp, err := c.do(http.MethodPut, owner, cacheKey, data, ttl)
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+			err = fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+		}
+	}
+	if err != nil {
+		c.fallback("write", owner, cacheKey, err)
+		return c.local.Store(cacheKey, data, ttl)
+	}
+	metrics.ObserveCachePeerRequest(c.Name, "write", "ok")
+	return nil
+}
+
+// Retrieve reads the object from the peer that owns the cache key, unless
+// there is a copy in the hot cache. When the owner is this instance, or the
+// owner cannot be reached, the object is read from the local cache.
+func (c *Cache) Retrieve(cacheKey string) ([]byte, status.LookupStatus, error) {
+	owner := c.Owner(cacheKey)
+	if owner == nil {
+		return c.local.Retrieve(cacheKey)
+	}
+	if c.hot != nil {
+		if b, s, err := c.hot.Retrieve(cacheKey); err == nil && s == status.LookupStatusHit {
+			return b, s, nil
+		}
+	}
+	resp, err := c.do(http.MethodGet, owner, cacheKey, nil, 0)
+	if err != nil {
+		c.fallback("read", owner, cacheKey, err)
+		return c.local.Retrieve(cacheKey)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		metrics.ObserveCachePeerRequest(c.Name, "read", "miss")
+		return nil, status.LookupStatusKeyMiss, cache.ErrKNF
+	default:
+		c.fallback("read", owner, cacheKey,
+			fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode))
+		return c.local.Retrieve(cacheKey)
+	}
+	limit := c.MaxObjectSizeBytes()
+	b, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err == nil && int64(len(b)) > limit {
+		err = ErrObjectTooLarge
+	}
+	if err != nil {
+		c.fallback("read", owner, cacheKey, err)
+		return c.local.Retrieve(cacheKey)
+	}
+	metrics.ObserveCachePeerRequest(c.Name, "read", "ok")
+	if c.hot != nil {
+		c.hot.Store(cacheKey, b, c.hotTTL(0))
+	}
+	return b, status.LookupStatusHit, nil
+}
+
+// Remove removes the objects from the peers that own them, and from the local
+// and hot caches, which may hold copies written while an owner was unreachable.
+// An error is returned for each object that its owner failed to remove.
+func (c *Cache) Remove(cacheKeys ...string) error {
+	var errs []error
+	for _, k := range cacheKeys {
+		owner := c.Owner(k)
+		if owner == nil {
+			continue
+		}
+		resp, err := c.do(http.MethodDelete, owner, k, nil, 0)
+		if err != nil {
+			c.fallback("remove", owner, k, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			metrics.ObserveCachePeerRequest(c.Name, "remove", "error")
+			errs = append(errs, fmt.Errorf("%w: %d removing %s from %s",
+				ErrUnexpectedStatus, resp.StatusCode, k, owner))
+			continue
+		}
+		metrics.ObserveCachePeerRequest(c.Name, "remove", "ok")
+	}
+	if c.hot != nil {
+		c.hot.Remove(cacheKeys...)
+	}
+	return errors.Join(append(errs, c.local.Remove(cacheKeys...))...)
+}
+
+func (c *Cache) fallback(operation string, owner *url.URL, cacheKey string, err error) {
+	metrics.ObserveCachePeerRequest(c.Name, operation, "fallback")
+	logger.Debug("peer cache request failed, using local cache",
+		logging.Pairs{"cacheName": c.Name, "peer": owner.String(),
+			"operation": operation, "key": cacheKey, "error": err})
+}
+
+// do sends a request for the cache key to the peer
+func (c *Cache) do(method string, owner *url.URL, cacheKey string,
+	data []byte, ttl time.Duration,
+) (*http.Response, error) {
+	u := *owner
+	u.Path = owner.Path + HandlerPath + c.Name + "/" + cacheKey
+	if ttl > 0 {
+		u.RawQuery = url.Values{TTLParam: {ttl.String()}}.Encode()
+	}
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	return c.group.Do(req)
+}
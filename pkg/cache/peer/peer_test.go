@@ -0,0 +1,365 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/manager"
+	"github.com/trickstercache/trickster/v2/pkg/cache/memory"
+	"github.com/trickstercache/trickster/v2/pkg/cache/options"
+	peeropts "github.com/trickstercache/trickster/v2/pkg/cache/peer/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/providers"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	"github.com/trickstercache/trickster/v2/pkg/config/types"
+	po "github.com/trickstercache/trickster/v2/pkg/proxy/peers/options"
+)
+
+const testSecret = "s3cret"
+
+func newMemoryCache(t *testing.T, name string) cache.Cache {
+	t.Helper()
+	cfg := &options.Options{Name: name, Provider: providers.Memory,
+		ProviderID: providers.MemoryID}
+	c := manager.NewCache(memory.New(name, cfg), manager.CacheOptions{}, cfg)
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+// instance is a Trickster instance in a test cluster
+type instance struct {
+	server *httptest.Server
+	cache  *Cache
+	local  cache.Cache
+	hot    cache.Cache
+	// removeStatus, when set, is the status returned for removals
+	removeStatus int
+}
+
+// ServeHTTP serves the local cache like the peer cache management handler
+func (i *instance) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !i.cache.Authorized(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, HandlerPath+i.cache.Name+"/")
+	switch r.Method {
+	case http.MethodGet:
+		b, _, err := i.local.Retrieve(key)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(b)
+	case http.MethodPut:
+		ttl, _ := time.ParseDuration(r.URL.Query().Get(TTLParam))
+		b, _ := io.ReadAll(r.Body)
+		i.local.Store(key, b, ttl)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if i.removeStatus != 0 {
+			w.WriteHeader(i.removeStatus)
+			return
+		}
+		i.local.Remove(key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// newCluster returns a cluster of n instances. The instances use the shared
+// testSecret, unless their secret is overridden by secrets.
+func newCluster(t *testing.T, n int, withHot bool, secrets ...string) []*instance {
+	t.Helper()
+	out := make([]*instance, n)
+	urls := make([]string, n)
+	for i := range out {
+		out[i] = &instance{}
+		out[i].server = httptest.NewServer(out[i])
+		t.Cleanup(out[i].server.Close)
+		urls[i] = out[i].server.URL
+	}
+	for i, in := range out {
+		in.local = newMemoryCache(t, fmt.Sprintf("local-%d", i))
+		lookup := cache.Lookup{"local": in.local}
+		cfg := &options.Options{Name: "peer", Provider: providers.Peer,
+			ProviderID: providers.PeerID, Peer: peeropts.New()}
+		cfg.Peer.Local = "local"
+		if withHot {
+			in.hot = newMemoryCache(t, fmt.Sprintf("hot-%d", i))
+			lookup["hot"] = in.hot
+			cfg.Peer.HotCache = "hot"
+		}
+		secret := testSecret
+		if i < len(secrets) && secrets[i] != "" {
+			secret = secrets[i]
+		}
+		cfg.Peer.Peers = &po.Options{Peers: urls, Self: urls[i], Secret: types.EnvString(secret)}
+		if err := cfg.Peer.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		in.cache = New("peer", cfg, lookup)
+		if err := in.cache.Connect(); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { in.cache.Close() })
+	}
+	return out
+}
+
+// ownerIndex returns the index of the instance owning the key
+func ownerIndex(t *testing.T, cl []*instance, key string) int {
+	t.Helper()
+	u := cl[0].cache.Owner(key)
+	if u == nil {
+		return 0
+	}
+	for i, in := range cl {
+		if in.server.URL == u.String() {
+			return i
+		}
+	}
+	t.Fatalf("unknown owner %s", u)
+	return -1
+}
+
+func TestRingOwner(t *testing.T) {
+	nodes := make([]*url.URL, 4)
+	for i := range nodes {
+		nodes[i], _ = url.Parse(fmt.Sprintf("http://trickster-%d:8484", i))
+	}
+	newRing := func(nodes []*url.URL) *ring {
+		r := &ring{nodes: nodes, names: make([]string, len(nodes)), self: "http://self"}
+		for i, n := range nodes {
+			r.names[i] = n.String()
+		}
+		return r
+	}
+	r4 := newRing(nodes)
+	r3 := newRing(nodes[:3])
+	counts := make(map[string]int)
+	var moved int
+	const keys = 10000
+	for i := range keys {
+		k := fmt.Sprintf("key-%d", i)
+		o4, o3 := r4.owner(k).String(), r3.owner(k).String()
+		counts[o4]++
+		if o4 != nodes[3].String() && o4 != o3 {
+			moved++
+		}
+	}
+	for _, n := range nodes {
+		if c := counts[n.String()]; c < keys/8 {
+			t.Errorf("expected an even distribution, got %d keys for %s", c, n)
+		}
+	}
+	if moved != 0 {
+		t.Errorf("expected only the keys of the removed node to move, %d others moved", moved)
+	}
+	if (*ring)(nil).owner("key") != nil {
+		t.Error("expected a nil ring to own nothing")
+	}
+	r := newRing(nodes[:1])
+	r.self = nodes[0].String()
+	if r.owner("key") != nil {
+		t.Error("expected keys owned by self to return nil")
+	}
+}
+
+func TestCacheCluster(t *testing.T) {
+	cl := newCluster(t, 3, true)
+	owners := make(map[int]bool)
+	for i := range 30 {
+		key := fmt.Sprintf("key-%d", i)
+		val := []byte("value-" + key)
+		if err := cl[0].cache.Store(key, val, time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		o := ownerIndex(t, cl, key)
+		owners[o] = true
+		for j, in := range cl {
+			_, _, err := in.local.Retrieve(key)
+			if (j == o) != (err == nil) {
+				t.Errorf("key %s owned by %d: unexpected local presence on %d: %v", key, o, j, err)
+			}
+		}
+		// every instance reads the object from its owner
+		for j, in := range cl {
+			b, s, err := in.cache.Retrieve(key)
+			if err != nil || s != status.LookupStatusHit || string(b) != string(val) {
+				t.Errorf("instance %d: unexpected retrieve %s %s %v", j, b, s, err)
+			}
+			if j != o {
+				if _, _, err := in.hot.Retrieve(key); err != nil {
+					t.Errorf("instance %d: expected a hot copy of %s", j, key)
+				}
+			}
+		}
+		if err := cl[2].cache.Remove(key); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := cl[o].local.Retrieve(key); err == nil {
+			t.Errorf("expected %s to be removed from its owner", key)
+		}
+	}
+	if len(owners) != 3 {
+		t.Errorf("expected keys to be owned by all 3 instances, got %v", owners)
+	}
+	if _, s, err := cl[1].cache.Retrieve("missing"); err != cache.ErrKNF ||
+		s != status.LookupStatusKeyMiss {
+		t.Errorf("expected a miss, got %s %v", s, err)
+	}
+}
+
+func TestCacheFallback(t *testing.T) {
+	cl := newCluster(t, 2, false)
+	var key string
+	for i := 0; key == ""; i++ {
+		if k := fmt.Sprintf("key-%d", i); ownerIndex(t, cl, k) == 1 {
+			key = k
+		}
+	}
+	cl[1].server.Close()
+	if err := cl[0].cache.Store(key, []byte("value"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl[0].local.Retrieve(key); err != nil {
+		t.Error("expected the object to be stored locally when the owner is down")
+	}
+	b, s, err := cl[0].cache.Retrieve(key)
+	if err != nil || s != status.LookupStatusHit || string(b) != "value" {
+		t.Errorf("expected the local copy, got %s %s %v", b, s, err)
+	}
+	if err := cl[0].cache.Remove(key); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl[0].local.Retrieve(key); err == nil {
+		t.Error("expected the local copy to be removed")
+	}
+}
+
+func TestCacheRemoveFailed(t *testing.T) {
+	cl := newCluster(t, 2, true)
+	var key string
+	for i := 0; key == ""; i++ {
+		if k := fmt.Sprintf("key-%d", i); ownerIndex(t, cl, k) == 1 {
+			key = k
+		}
+	}
+	if err := cl[0].cache.Store(key, []byte("value"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	cl[1].removeStatus = http.StatusInternalServerError
+	if err := cl[0].cache.Remove(key); !errors.Is(err, ErrUnexpectedStatus) {
+		t.Errorf("expected %v got %v", ErrUnexpectedStatus, err)
+	}
+	if _, _, err := cl[0].hot.Retrieve(key); err == nil {
+		t.Error("expected the hot copy to be removed")
+	}
+	if _, _, err := cl[1].local.Retrieve(key); err != nil {
+		t.Error("expected the owner to keep the object it failed to remove")
+	}
+}
+
+func TestCacheObjectTooLarge(t *testing.T) {
+	cl := newCluster(t, 2, false)
+	var key string
+	for i := 0; key == ""; i++ {
+		if k := fmt.Sprintf("key-%d", i); ownerIndex(t, cl, k) == 1 {
+			key = k
+		}
+	}
+	if err := cl[1].local.Store(key, []byte("too large"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	cl[0].cache.Config.Peer.MaxObjectSizeBytes = 4
+	if b, _, err := cl[0].cache.Retrieve(key); err == nil {
+		t.Errorf("expected the oversized object to be rejected, got %s", b)
+	}
+	cl[0].cache.Config.Peer.MaxObjectSizeBytes = 9
+	if b, _, err := cl[0].cache.Retrieve(key); err != nil || string(b) != "too large" {
+		t.Errorf("expected the object at the limit, got %s %v", b, err)
+	}
+}
+
+func TestCacheUnauthorized(t *testing.T) {
+	cl := newCluster(t, 2, false, "", "other")
+	var key string
+	for i := 0; key == ""; i++ {
+		if k := fmt.Sprintf("key-%d", i); ownerIndex(t, cl, k) == 1 {
+			key = k
+		}
+	}
+	if err := cl[0].cache.Store(key, []byte("value"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl[1].local.Retrieve(key); err == nil {
+		t.Error("expected the owner to reject the unauthorized write")
+	}
+	if _, _, err := cl[0].local.Retrieve(key); err != nil {
+		t.Error("expected the rejected write to fall back to the local cache")
+	}
+}
+
+func TestConnect(t *testing.T) {
+	cfg := &options.Options{Name: "peer", Provider: providers.Peer,
+		ProviderID: providers.PeerID}
+	c := New("peer", cfg, cache.Lookup{})
+	if cfg.Peer == nil {
+		t.Fatal("expected default peer options")
+	}
+	cfg.Peer.Local = "local"
+	if err := c.Connect(); err == nil {
+		t.Error("expected an error for a missing local cache")
+	}
+	c.lookup["local"] = newMemoryCache(t, "local")
+	cfg.Peer.HotCache = "hot"
+	if err := c.Connect(); err == nil {
+		t.Error("expected an error for a missing hot cache")
+	}
+	cfg.Peer.HotCache = ""
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	// without peers, every key is owned locally
+	if err := c.Store("key", []byte("value"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if c.Owner("key") != nil || c.Authorized(httptest.NewRequest(http.MethodGet, "/", nil)) {
+		t.Error("expected a peer cache without peers to own every key")
+	}
+	if _, _, err := c.Local().Retrieve("key"); err != nil {
+		t.Error(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Error(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Error(err)
+	}
+}
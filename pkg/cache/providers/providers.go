@@ -32,12 +32,24 @@ const (
 	BBoltID
 	// BadgerDBID indicates a BadgerDB cache
 	BadgerDBID
+	// TieredID indicates a Tiered cache composed of other named caches
+	TieredID
+	// MemcachedID indicates a Memcached cache
+	MemcachedID
+	// S3ID indicates an S3-compatible object storage cache
+	S3ID
+	// PeerID indicates a Peer cache that shards objects across Trickster peers
+	PeerID
 
 	Memory     = "memory"
 	Filesystem = "filesystem"
 	Redis      = "redis"
 	BBolt      = "bbolt"
 	BadgerDB   = "badger"
+	Tiered     = "tiered"
+	Memcached  = "memcached"
+	S3         = "s3"
+	Peer       = "peer"
 )
 
 // Names is a map of cache providers keyed by name
@@ -47,6 +59,10 @@ var Names = map[string]Provider{
 	Redis:      RedisID,
 	BBolt:      BBoltID,
 	BadgerDB:   BadgerDBID,
+	Tiered:     TieredID,
+	Memcached:  MemcachedID,
+	S3:         S3ID,
+	Peer:       PeerID,
 }
 
 // Values is a map of cache providers keyed by internal id
@@ -68,5 +84,7 @@ func (p Provider) String() string {
 // UsesIndex returns true if the providerName uses an index
 // providerName is expected to already be lowercase/no-space
 func UsesIndex(providerName string) bool {
-	return providerName != BadgerDB && providerName != Redis && providerName != Memory
+	return providerName != BadgerDB && providerName != Redis && providerName != Memory &&
+		providerName != Tiered && providerName != Memcached && providerName != S3 &&
+		providerName != Peer
 }
@@ -18,6 +18,7 @@ package redis
 
 import (
 	"crypto/tls"
+	"strings"
 	"time"
 
 	redis "github.com/redis/go-redis/v9"
@@ -96,3 +97,52 @@ func (c *CacheClient) clusterOpts() (*redis.ClusterOptions, error) {
 
 	return o, nil
 }
+
+// clusterSlots is the number of hash slots in a Redis Cluster
+const clusterSlots = 16384
+
+// keySlot returns the Redis Cluster hash slot of the key. When the key has a
+// non-empty hash tag (a substring between the first '{' and the next '}'), only
+// the hash tag is hashed.
+func keySlot(key string) int {
+	if s := strings.IndexByte(key, '{'); s >= 0 {
+		if e := strings.IndexByte(key[s+1:], '}'); e > 0 {
+			key = key[s+1 : s+1+e]
+		}
+	}
+	return int(crc16(key) % clusterSlots)
+}
+
+// crc16 returns the CRC-16/XMODEM checksum of s, as used by Redis Cluster
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// groupBySlot groups the keys by their Redis Cluster hash slot, preserving
+// the order in which each slot is first seen
+func groupBySlot(keys []string) [][]string {
+	var groups [][]string
+	idx := make(map[int]int)
+	for _, k := range keys {
+		slot := keySlot(k)
+		i, ok := idx[slot]
+		if !ok {
+			i = len(groups)
+			idx[slot] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], k)
+	}
+	return groups
+}
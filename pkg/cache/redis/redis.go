@@ -20,6 +20,8 @@ package redis
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -30,11 +32,26 @@ import (
 	redis "github.com/redis/go-redis/v9"
 )
 
-// CacheClient implements the cache.Client interface
-var _ cache.Client = &CacheClient{}
+// CacheClient implements the cache.Client, cache.MultiClient and cache.Leaser interfaces
+var (
+	_ cache.Client      = &CacheClient{}
+	_ cache.MultiClient = &CacheClient{}
+	_ cache.Leaser      = &CacheClient{}
+)
+
+const (
+	// Redis is the string "redis"
+	Redis = "redis"
+	// leaseSuffix is appended to a cache key to form the key of its lease
+	leaseSuffix = ".lease"
+)
 
-// Redis is the string "redis"
-const Redis = "redis"
+// releaseScript deletes a lease only when it is still held with the token, so
+// that a lease that expired and was taken by another caller is not released
+var releaseScript = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
 
 // CacheClient represents a redis cache client that conforms to the cache.Client interface
 type CacheClient struct {
@@ -43,6 +60,8 @@ type CacheClient struct {
 	client redis.Cmdable
 	closer func() error
 	ctx    context.Context
+	// cluster is true when client is a Redis Cluster client
+	cluster bool
 }
 
 func New(ctx context.Context, name string, cfg *options.Options) *CacheClient {
@@ -73,6 +92,7 @@ func (c *CacheClient) Connect() error {
 		client := redis.NewClusterClient(opts)
 		c.closer = client.Close
 		c.client = client
+		c.cluster = true
 	default:
 		opts, err := c.clientOpts()
 		if err != nil {
@@ -111,6 +131,72 @@ func (c *CacheClient) Retrieve(cacheKey string) ([]byte, status.LookupStatus, er
 	return nil, status.LookupStatusError, err
 }
 
+// MultiStore places each value in data into the Redis Cache using its cache key
+// and the provided TTL, in a single pipeline
+func (c *CacheClient) MultiStore(data map[string][]byte, ttl time.Duration) error {
+	if len(data) == 0 {
+		return nil
+	}
+	_, err := c.client.Pipelined(c.ctx, func(pipe redis.Pipeliner) error {
+		for k, v := range data {
+			pipe.Set(c.ctx, k, v, ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+// MultiRetrieve gets the values of the cache keys from the Redis Cache with
+// pipelined MGET commands. In a Redis Cluster, the keys are grouped into one
+// MGET per hash slot, since an MGET can only reference keys in a single slot.
+// Cache keys that were not found are omitted from the returned map.
+func (c *CacheClient) MultiRetrieve(cacheKeys ...string) (map[string][]byte, error) {
+	if len(cacheKeys) == 0 {
+		return map[string][]byte{}, nil
+	}
+	groups := [][]string{cacheKeys}
+	if c.cluster {
+		groups = groupBySlot(cacheKeys)
+	}
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.SliceCmd, len(groups))
+	for i, keys := range groups {
+		cmds[i] = pipe.MGet(c.ctx, keys...)
+	}
+	if _, err := pipe.Exec(c.ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	out := make(map[string][]byte, len(cacheKeys))
+	for i, cmd := range cmds {
+		for j, v := range cmd.Val() {
+			if s, ok := v.(string); ok {
+				out[groups[i][j]] = []byte(s)
+			}
+		}
+	}
+	return out, nil
+}
+
+// AcquireLease takes the lease on the cache key for the ttl with SET NX, so
+// that only one of the Trickster instances sharing the Redis cache holds it
+func (c *CacheClient) AcquireLease(cacheKey string, ttl time.Duration) (string, bool, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", false, err
+	}
+	token := hex.EncodeToString(b)
+	ok, err := c.client.SetNX(c.ctx, cacheKey+leaseSuffix, token, ttl).Result()
+	if err != nil || !ok {
+		return "", false, err
+	}
+	return token, true, nil
+}
+
+// ReleaseLease releases the lease on the cache key, if it is still held with the token
+func (c *CacheClient) ReleaseLease(cacheKey, token string) error {
+	return releaseScript.Run(c.ctx, c.client, []string{cacheKey + leaseSuffix}, token).Err()
+}
+
 func (c *CacheClient) Close() error {
 	return c.closer()
 }
@@ -431,3 +431,98 @@ func BenchmarkCache_BulkRemove(b *testing.B) {
 	}
 	b.ReportMetric(benchmarkKeyCount, "keys/op")
 }
+
+func TestRedisCache_MultiStoreRetrieve(t *testing.T) {
+	for _, ct := range []clientType{clientTypeStandard, clientTypeCluster} {
+		t.Run(ct.String(), func(t *testing.T) {
+			rc, close := setupRedisCache(ct)
+			defer close()
+			if err := rc.Connect(); err != nil {
+				t.Fatal(err)
+			}
+
+			data := map[string][]byte{
+				"chunk.0":        []byte("zero"),
+				"chunk.1":        []byte("one"),
+				"{chunk}.2":      []byte("two"),
+				"other.{chunk}3": []byte("three"),
+			}
+			if err := rc.MultiStore(data, time.Minute); err != nil {
+				t.Fatal(err)
+			}
+			out, err := rc.MultiRetrieve("chunk.0", "missing", "{chunk}.2",
+				"chunk.1", "other.{chunk}3")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(out) != len(data) {
+				t.Errorf("expected %d values, got %d", len(data), len(out))
+			}
+			for k, v := range data {
+				if string(out[k]) != string(v) {
+					t.Errorf("key %s: expected %q, got %q", k, v, out[k])
+				}
+			}
+			if _, ok := out["missing"]; ok {
+				t.Error("expected missing key to be omitted")
+			}
+		})
+	}
+}
+
+func TestRedisCache_Lease(t *testing.T) {
+	rc, close := setupRedisCache(clientTypeStandard)
+	defer close()
+	if err := rc.Connect(); err != nil {
+		t.Fatal(err)
+	}
+
+	token, ok, err := rc.AcquireLease(cacheKey, time.Minute)
+	if err != nil || !ok || token == "" {
+		t.Fatalf("expected lease to be acquired, got %q %t %v", token, ok, err)
+	}
+	if _, ok, err = rc.AcquireLease(cacheKey, time.Minute); err != nil || ok {
+		t.Fatalf("expected held lease not to be acquired, got %t %v", ok, err)
+	}
+	// a release with a stale token does not release the lease
+	if err = rc.ReleaseLease(cacheKey, "stale"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ = rc.AcquireLease(cacheKey, time.Minute); ok {
+		t.Fatal("expected lease to remain held after a stale release")
+	}
+	if err = rc.ReleaseLease(cacheKey, token); err != nil {
+		t.Fatal(err)
+	}
+	token2, ok, err := rc.AcquireLease(cacheKey, time.Minute)
+	if err != nil || !ok || token2 == token {
+		t.Fatalf("expected a new lease after release, got %q %t %v", token2, ok, err)
+	}
+	// the lease does not occupy the cache key itself
+	if _, _, err = rc.Retrieve(cacheKey); err == nil {
+		t.Error("expected cache key to be absent")
+	}
+}
+
+func TestKeySlot(t *testing.T) {
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{"123456789", 0x31C3},
+		{"foo", 12182},
+		{"{user1000}.following", keySlot("user1000")},
+		{"foo{bar}{zap}", keySlot("bar")},
+		{"foo{}{bar}", int(crc16("foo{}{bar}") % clusterSlots)},
+		{"foo{{bar}}zap", keySlot("{bar")},
+	}
+	for _, tc := range tests {
+		if got := keySlot(tc.key); got != tc.want {
+			t.Errorf("keySlot(%q) = %d, want %d", tc.key, got, tc.want)
+		}
+	}
+	groups := groupBySlot([]string{"{a}1", "{b}1", "{a}2"})
+	if len(groups) != 2 || len(groups[0]) != 2 || groups[0][1] != "{a}2" {
+		t.Errorf("unexpected groups %v", groups)
+	}
+}
@@ -26,19 +26,22 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/cache/bbolt"
 	"github.com/trickstercache/trickster/v2/pkg/cache/filesystem"
 	"github.com/trickstercache/trickster/v2/pkg/cache/manager"
+	"github.com/trickstercache/trickster/v2/pkg/cache/memcached"
 	"github.com/trickstercache/trickster/v2/pkg/cache/memory"
 	"github.com/trickstercache/trickster/v2/pkg/cache/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/peer"
 	"github.com/trickstercache/trickster/v2/pkg/cache/providers"
 	"github.com/trickstercache/trickster/v2/pkg/cache/redis"
+	"github.com/trickstercache/trickster/v2/pkg/cache/s3"
+	"github.com/trickstercache/trickster/v2/pkg/cache/tiered"
 	"github.com/trickstercache/trickster/v2/pkg/config"
 )
 
 // LoadCachesFromConfig iterates the Caching Config and Connects/Maps each Cache
 func LoadCachesFromConfig(conf *config.Config) cache.Lookup {
 	caches := make(cache.Lookup)
-	for k, v := range conf.Caches {
-		c := NewCache(k, v)
-		caches[k] = c
+	for _, k := range conf.Caches.TieredLast() {
+		caches[k] = NewCacheFromLookup(k, conf.Caches[k], caches)
 	}
 	return caches
 }
@@ -55,6 +58,15 @@ func CloseCaches(caches cache.Lookup) error {
 
 // NewCache returns a Cache object based on the provided config.CachingConfig
 func NewCache(cacheName string, cfg *options.Options) cache.Cache {
+	return NewCacheFromLookup(cacheName, cfg, nil)
+}
+
+// NewCacheFromLookup returns a Cache object based on the provided
+// config.CachingConfig. Tiered and Peer caches resolve the caches they are
+// composed of from the provided lookup, which must already hold them.
+func NewCacheFromLookup(cacheName string, cfg *options.Options,
+	caches cache.Lookup,
+) cache.Cache {
 	var c cache.Cache
 	co := manager.CacheOptions{
 		UseIndex: providers.UsesIndex(cfg.Provider),
@@ -72,6 +84,14 @@ func NewCache(cacheName string, cfg *options.Options) cache.Cache {
 		c = manager.NewCache(bbolt.New(cacheName, "", "", cfg), co, cfg)
 	case providers.BadgerDB:
 		c = manager.NewCache(badger.New(cacheName, cfg), co, cfg)
+	case providers.Memcached:
+		c = manager.NewCache(memcached.New(cacheName, cfg), co, cfg)
+	case providers.S3:
+		c = manager.NewCache(s3.New(context.Background(), cacheName, cfg), co, cfg)
+	case providers.Tiered:
+		c = manager.NewCache(tiered.New(cacheName, cfg, caches), co, cfg)
+	case providers.Peer:
+		c = manager.NewCache(peer.New(cacheName, cfg, caches), co, cfg)
 	default:
 		// Default to MemoryCache
 		co.IndexCliOpts.NeedsReapInterval = true
@@ -24,11 +24,14 @@ import (
 	bbo "github.com/trickstercache/trickster/v2/pkg/cache/bbolt/options"
 	flo "github.com/trickstercache/trickster/v2/pkg/cache/filesystem/options"
 	io "github.com/trickstercache/trickster/v2/pkg/cache/index/options"
+	mco "github.com/trickstercache/trickster/v2/pkg/cache/memcached/options"
 	co "github.com/trickstercache/trickster/v2/pkg/cache/options"
 	"github.com/trickstercache/trickster/v2/pkg/cache/providers"
 	ro "github.com/trickstercache/trickster/v2/pkg/cache/redis/options"
+	so "github.com/trickstercache/trickster/v2/pkg/cache/s3/options"
 	"github.com/trickstercache/trickster/v2/pkg/config"
 	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	ts3 "github.com/trickstercache/trickster/v2/pkg/testutil/s3"
 )
 
 func TestLoadCachesFromConfig(t *testing.T) {
@@ -48,6 +51,15 @@ func TestLoadCachesFromConfig(t *testing.T) {
 			cfg.BBolt.Filename = t.TempDir() + "/" + key + "-testcache"
 		case providers.BadgerDBID:
 			cfg.BBolt.Filename = t.TempDir() + "/" + key + "-testcache"
+		case providers.S3ID:
+			s := ts3.NewServer("trickster")
+			t.Cleanup(s.Close)
+			cfg.S3 = so.New()
+			cfg.S3.Endpoint = s.URL
+			cfg.S3.Bucket = "trickster"
+			cfg.S3.AccessKeyID = "test-key"
+			cfg.S3.SecretAccessKey = "test-secret"
+			cfg.S3.UsePathStyle = true
 		}
 	}
 
@@ -93,6 +105,7 @@ func newCacheConfig(t *testing.T, cacheProvider string) *co.Options {
 		Filesystem: &flo.Options{CachePath: fd},
 		BBolt:      &bbo.Options{Filename: "/tmp/test.db", Bucket: "trickster_test"},
 		Badger:     &bao.Options{Directory: bd, ValueDirectory: bd},
+		Memcached:  mco.New(),
 		Index: &io.Options{
 			ReapInterval:          timeconv.Duration(3 * time.Millisecond),
 			FlushInterval:         timeconv.Duration(5 * time.Millisecond),
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"errors"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/config/types"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	// ErrNoBucket is returned when no bucket is configured
+	ErrNoBucket = errors.New("s3 cache requires a bucket")
+	// ErrPartialCredentials is returned when only one of the access key id
+	// and secret access key is configured
+	ErrPartialCredentials = errors.New("s3 cache requires both access_key_id and secret_access_key")
+)
+
+// Options is a collection of Configurations for an S3-compatible object
+// storage cache
+type Options struct {
+	// Endpoint is the URL of the S3-compatible service. When empty, the AWS S3
+	// endpoint for the Region is used
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// Region is the region of the bucket
+	Region string `yaml:"region,omitempty"`
+	// Bucket is the name of the bucket in which cache objects are stored
+	Bucket string `yaml:"bucket,omitempty"`
+	// Prefix is prepended to the key of each cache object
+	Prefix string `yaml:"prefix,omitempty"`
+	// AccessKeyID is the access key used to authenticate. When empty, the AWS
+	// default credential chain (environment, shared config, IAM roles) is used
+	AccessKeyID types.EnvString `yaml:"access_key_id,omitempty"`
+	// SecretAccessKey is the secret key used to authenticate
+	SecretAccessKey types.EnvString `yaml:"secret_access_key,omitempty"`
+	// SessionToken is the optional session token used to authenticate
+	SessionToken types.EnvString `yaml:"session_token,omitempty"`
+	// UsePathStyle addresses the bucket in the URL path (endpoint/bucket/key)
+	// instead of as a subdomain, which most self-hosted services require
+	UsePathStyle bool `yaml:"use_path_style,omitempty"`
+	// Timeout is the timeout for each S3 operation
+	Timeout timeconv.Duration `yaml:"timeout,omitempty"`
+}
+
+// New returns a new S3 Options Reference with default values set
+func New() *Options {
+	return &Options{
+		Region:  DefaultRegion,
+		Prefix:  DefaultPrefix,
+		Timeout: timeconv.Duration(DefaultTimeout),
+	}
+}
+
+// Validate returns an error if the Options are invalid
+func (o *Options) Validate() error {
+	if o.Bucket == "" {
+		return ErrNoBucket
+	}
+	if (o.AccessKeyID == "") != (o.SecretAccessKey == "") {
+		return ErrPartialCredentials
+	}
+	if o.Region == "" {
+		o.Region = DefaultRegion
+	}
+	if time.Duration(o.Timeout) <= 0 {
+		o.Timeout = timeconv.Duration(DefaultTimeout)
+	}
+	return nil
+}
+
+func (o *Options) UnmarshalYAML(value *yaml.Node) error {
+	type loadOptions Options
+	lo := loadOptions(*(New()))
+	if err := value.Decode(&lo); err != nil {
+		return err
+	}
+	*o = Options(lo)
+	return nil
+}
+
+// Equal returns true if all values in the Options are identical
+func (o *Options) Equal(o2 *Options) bool {
+	if o2 == nil {
+		return o == nil
+	}
+	if o == nil {
+		return false
+	}
+	return *o == *o2
+}
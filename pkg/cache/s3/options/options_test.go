@@ -0,0 +1,90 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestNew(t *testing.T) {
+	o := New()
+	if o.Region != DefaultRegion || o.Prefix != DefaultPrefix {
+		t.Errorf("unexpected options %+v", o)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	o := New()
+	if err := o.Validate(); err != ErrNoBucket {
+		t.Errorf("expected %v got %v", ErrNoBucket, err)
+	}
+	o.Bucket = "trickster"
+	o.AccessKeyID = "key"
+	if err := o.Validate(); err != ErrPartialCredentials {
+		t.Errorf("expected %v got %v", ErrPartialCredentials, err)
+	}
+	o.SecretAccessKey = "secret"
+	o.Region = ""
+	o.Timeout = 0
+	if err := o.Validate(); err != nil {
+		t.Error(err)
+	}
+	if o.Region != DefaultRegion || time.Duration(o.Timeout) != DefaultTimeout {
+		t.Errorf("expected defaults to be applied, got %+v", o)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	o := New()
+	o2 := New()
+	if !o.Equal(o2) {
+		t.Error("expected options to be equal")
+	}
+	o2.UsePathStyle = true
+	if o.Equal(o2) {
+		t.Error("expected path style difference to make options unequal")
+	}
+	if o.Equal(nil) {
+		t.Error("expected false for nil comparison")
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	const raw = `
+endpoint: http://minio:9000
+bucket: trickster
+use_path_style: true
+`
+	o := &Options{}
+	if err := yaml.Unmarshal([]byte(raw), o); err != nil {
+		t.Fatal(err)
+	}
+	if o.Bucket != "trickster" || !o.UsePathStyle || o.Prefix != DefaultPrefix {
+		t.Errorf("unexpected options %+v", o)
+	}
+	if o.Timeout != timeconv.Duration(DefaultTimeout) {
+		t.Errorf("expected default timeout, got %s", time.Duration(o.Timeout))
+	}
+	if err := yaml.Unmarshal([]byte("- boom"), o); err == nil {
+		t.Error("expected an error")
+	}
+}
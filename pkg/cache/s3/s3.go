@@ -0,0 +1,194 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package s3 is the S3-compatible object storage implementation of the
+// Trickster Cache
+package s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// CacheClient implements the cache.Client interface
+var _ cache.Client = &CacheClient{}
+
+const (
+	// metaExpires is the object metadata key holding the object's expiration
+	// time, in unix milliseconds
+	metaExpires = "trickster-expires"
+	// maxDeleteBatch is the maximum number of keys in a multi-object delete
+	maxDeleteBatch = 1000
+)
+
+// CacheClient represents an S3-compatible object storage cache client that
+// conforms to the cache.Client interface
+type CacheClient struct {
+	Name   string
+	Config *options.Options
+	client *s3.Client
+	ctx    context.Context
+}
+
+// New returns a new S3 CacheClient
+func New(ctx context.Context, name string, cfg *options.Options) *CacheClient {
+	return &CacheClient{
+		Name:   name,
+		Config: cfg,
+		ctx:    ctx,
+	}
+}
+
+// Connect creates the S3 client and verifies that the bucket is accessible
+func (c *CacheClient) Connect() error {
+	so := c.Config.S3
+	lo := []func(*config.LoadOptions) error{config.WithRegion(so.Region)}
+	if so.AccessKeyID != "" {
+		lo = append(lo, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(string(so.AccessKeyID),
+				string(so.SecretAccessKey), string(so.SessionToken))))
+	}
+	awsCfg, err := config.LoadDefaultConfig(c.ctx, lo...)
+	if err != nil {
+		return err
+	}
+	c.client = s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if so.Endpoint != "" {
+			o.BaseEndpoint = aws.String(so.Endpoint)
+		}
+		o.UsePathStyle = so.UsePathStyle
+		// many S3-compatible services do not support the newer flexible checksums
+		o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+		o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+	})
+	ctx, cancel := c.opContext()
+	defer cancel()
+	_, err = c.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(so.Bucket)})
+	return err
+}
+
+// Store places the data into the bucket using the provided Key, with the
+// expiration time derived from the TTL stored as object metadata
+func (c *CacheClient) Store(cacheKey string, data []byte, ttl time.Duration) error {
+	in := &s3.PutObjectInput{
+		Bucket:        aws.String(c.Config.S3.Bucket),
+		Key:           aws.String(c.objectKey(cacheKey)),
+		Body:          bytes.NewReader(data),
+		ContentLength: aws.Int64(int64(len(data))),
+	}
+	if ttl > 0 {
+		in.Metadata = map[string]string{
+			metaExpires: strconv.FormatInt(time.Now().Add(ttl).UnixMilli(), 10),
+		}
+	}
+	ctx, cancel := c.opContext()
+	defer cancel()
+	_, err := c.client.PutObject(ctx, in)
+	return err
+}
+
+// Retrieve gets data from the bucket using the provided Key. Objects whose
+// expiration time has passed are reported as misses.
+func (c *CacheClient) Retrieve(cacheKey string) ([]byte, status.LookupStatus, error) {
+	ctx, cancel := c.opContext()
+	defer cancel()
+	out, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.Config.S3.Bucket),
+		Key:    aws.String(c.objectKey(cacheKey)),
+	})
+	if err != nil {
+		var nsk *types.NoSuchKey
+		if errors.As(err, &nsk) {
+			return nil, status.LookupStatusKeyMiss, cache.ErrKNF
+		}
+		return nil, status.LookupStatusError, err
+	}
+	defer out.Body.Close()
+	if v, ok := out.Metadata[metaExpires]; ok {
+		exp, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || time.Now().UnixMilli() >= exp {
+			return nil, status.LookupStatusKeyMiss, cache.ErrKNF
+		}
+	}
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, status.LookupStatusError, err
+	}
+	return data, status.LookupStatusHit, nil
+}
+
+// Remove removes the objects for the provided cache keys using multi-object
+// deletes of up to 1000 keys each
+func (c *CacheClient) Remove(cacheKeys ...string) error {
+	for len(cacheKeys) > 0 {
+		n := min(len(cacheKeys), maxDeleteBatch)
+		if err := c.removeBatch(cacheKeys[:n]); err != nil {
+			return err
+		}
+		cacheKeys = cacheKeys[n:]
+	}
+	return nil
+}
+
+func (c *CacheClient) removeBatch(cacheKeys []string) error {
+	objs := make([]types.ObjectIdentifier, len(cacheKeys))
+	for i, k := range cacheKeys {
+		objs[i] = types.ObjectIdentifier{Key: aws.String(c.objectKey(k))}
+	}
+	ctx, cancel := c.opContext()
+	defer cancel()
+	out, err := c.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+		Bucket: aws.String(c.Config.S3.Bucket),
+		Delete: &types.Delete{Objects: objs, Quiet: aws.Bool(true)},
+	})
+	if err != nil {
+		return err
+	}
+	if len(out.Errors) > 0 {
+		e := out.Errors[0]
+		return fmt.Errorf("s3 delete of %d objects failed, first: %s: %s",
+			len(out.Errors), aws.ToString(e.Key), aws.ToString(e.Message))
+	}
+	return nil
+}
+
+// Close is a no-op for the S3 cache
+func (c *CacheClient) Close() error {
+	return nil
+}
+
+func (c *CacheClient) objectKey(cacheKey string) string {
+	return c.Config.S3.Prefix + cacheKey
+}
+
+func (c *CacheClient) opContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(c.ctx, time.Duration(c.Config.S3.Timeout))
+}
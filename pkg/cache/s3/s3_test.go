@@ -0,0 +1,118 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	co "github.com/trickstercache/trickster/v2/pkg/cache/options"
+	so "github.com/trickstercache/trickster/v2/pkg/cache/s3/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	ts3 "github.com/trickstercache/trickster/v2/pkg/testutil/s3"
+)
+
+const testBucket = "trickster-test"
+
+func setupS3Cache(t *testing.T) (*CacheClient, *ts3.Server) {
+	t.Helper()
+	s := ts3.NewServer(testBucket)
+	t.Cleanup(s.Close)
+	cfg := so.New()
+	cfg.Endpoint = s.URL
+	cfg.Bucket = testBucket
+	cfg.AccessKeyID = "test-key"
+	cfg.SecretAccessKey = "test-secret"
+	cfg.UsePathStyle = true
+	c := New(context.Background(), "test", &co.Options{Provider: "s3", S3: cfg})
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c, s
+}
+
+func TestConnect(t *testing.T) {
+	c, _ := setupS3Cache(t)
+	c.Config.S3.Bucket = "missing"
+	if err := c.Connect(); err == nil {
+		t.Error("expected error for missing bucket")
+	}
+}
+
+func TestStoreRetrieve(t *testing.T) {
+	c, s := setupS3Cache(t)
+	if err := c.Store("cacheKey", []byte("data"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	o := s.Object(testBucket, so.DefaultPrefix+"cacheKey")
+	if o == nil {
+		t.Fatal("expected object to be stored under the prefix")
+	}
+	if _, ok := o.Metadata[metaExpires]; !ok {
+		t.Errorf("expected expiration metadata, got %v", o.Metadata)
+	}
+	b, ls, err := c.Retrieve("cacheKey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ls != status.LookupStatusHit || string(b) != "data" {
+		t.Errorf("unexpected result %s %s", ls, string(b))
+	}
+	_, ls, err = c.Retrieve("missingKey")
+	if !errors.Is(err, cache.ErrKNF) || ls != status.LookupStatusKeyMiss {
+		t.Errorf("expected key miss, got %s %v", ls, err)
+	}
+}
+
+func TestRetrieveExpired(t *testing.T) {
+	c, _ := setupS3Cache(t)
+	if err := c.Store("cacheKey", []byte("data"), time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	_, ls, err := c.Retrieve("cacheKey")
+	if !errors.Is(err, cache.ErrKNF) || ls != status.LookupStatusKeyMiss {
+		t.Errorf("expected key miss for expired object, got %s %v", ls, err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	c, s := setupS3Cache(t)
+	keys := make([]string, maxDeleteBatch+5)
+	for i := range keys {
+		keys[i] = "key" + strconv.Itoa(i)
+	}
+	for _, k := range keys[:10] {
+		if err := c.Store(k, []byte("data"), time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.Remove(keys...); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.Len(testBucket); n != 0 {
+		t.Errorf("expected all objects removed, got %d", n)
+	}
+	if n := s.DeleteRequests(); n != 2 {
+		t.Errorf("expected 2 multi-delete requests, got %d", n)
+	}
+}
@@ -49,8 +49,17 @@ const (
 	LookupStatusError
 	// LookupStatusProxyHit indicates that the request joined an existing proxy download of the same object
 	LookupStatusProxyHit
+	// LookupStatusStaleHit indicates the cached object exceeded the freshness lifetime but was
+	// served stale while it is revalidated in the background (stale-while-revalidate)
+	LookupStatusStaleHit
+	// LookupStatusStaleIfError indicates the cached object exceeded the freshness lifetime and
+	// was served stale because the upstream server failed to respond (stale-if-error)
+	LookupStatusStaleIfError
+	// LookupStatusAdmissionSkip indicates the object was not in cache and the upstream response
+	// was served but not cached because it did not satisfy the cache admission rules
+	LookupStatusAdmissionSkip
 	// maxLookupStatus is the maximum LookupStatus value
-	maxLookupStatus = LookupStatusProxyHit
+	maxLookupStatus = LookupStatusAdmissionSkip
 )
 
 // Return the maximum LookupStatus value
@@ -73,6 +82,9 @@ var cacheLookupStatusValues = []struct {
 	{LookupStatusNegativeCacheHit, "nchit"},
 	{LookupStatusError, "error"},
 	{LookupStatusProxyHit, "proxy-hit"},
+	{LookupStatusStaleHit, "stale-hit"},
+	{LookupStatusStaleIfError, "stale-error"},
+	{LookupStatusAdmissionSkip, "admission-skip"},
 }
 
 func (s LookupStatus) String() string {
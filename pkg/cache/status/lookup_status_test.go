@@ -29,6 +29,7 @@ func TestLookupStatusString(t *testing.T) {
 	}{
 		{LookupStatusHit, "hit"},
 		{LookupStatusKeyMiss, "kmiss"},
+		{LookupStatusAdmissionSkip, "admission-skip"},
 		{LookupStatus(99), "99"},
 	}
 	for _, c := range cases {
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// WritePolicyAll indicates that Store operations are written to every tier
+	WritePolicyAll = "all"
+	// WritePolicyL2Only indicates that Store operations skip the first tier,
+	// which is then populated only by promotion of lower-tier hits
+	WritePolicyL2Only = "l2_only"
+
+	// DefaultWritePolicy is the default write policy for a Tiered cache
+	DefaultWritePolicy = WritePolicyAll
+	// DefaultPromoteTTL is the default TTL applied to objects promoted from a
+	// lower tier into a higher tier, since the remaining TTL of the source
+	// object is not known
+	DefaultPromoteTTL = 5 * time.Minute
+)
+
+var (
+	// ErrNoTiers is returned when a Tiered cache is configured without tiers
+	ErrNoTiers = errors.New("tiered cache requires at least one tier")
+	// ErrInvalidWritePolicy is returned when the write policy is not supported
+	ErrInvalidWritePolicy = errors.New("invalid tiered cache write policy")
+	// ErrDuplicateTier is returned when the same cache is listed as a tier more than once
+	ErrDuplicateTier = errors.New("tiered cache tier is listed more than once")
+)
+
+// Options is a collection of Configurations for a Tiered cache, which reads
+// through an ordered list of other named caches
+type Options struct {
+	// Tiers is the ordered list of cache names that make up the Tiered cache,
+	// starting with the first (L1) tier
+	Tiers []string `yaml:"tiers,omitempty"`
+	// WritePolicy determines which tiers receive Store operations: 'all' or 'l2_only'
+	WritePolicy string `yaml:"write_policy,omitempty"`
+	// PromoteTTL is the TTL applied to objects when they are promoted from a
+	// lower tier into a higher tier following a read
+	PromoteTTL timeconv.Duration `yaml:"promote_ttl,omitempty"`
+}
+
+// New returns a reference to a new Tiered Options
+func New() *Options {
+	return &Options{
+		WritePolicy: DefaultWritePolicy,
+		PromoteTTL:  timeconv.Duration(DefaultPromoteTTL),
+	}
+}
+
+// Clone returns a copy of the Options
+func (o *Options) Clone() *Options {
+	if o == nil {
+		return nil
+	}
+	out := *o
+	out.Tiers = slices.Clone(o.Tiers)
+	return &out
+}
+
+// Validate returns an error if the Options are invalid
+func (o *Options) Validate() error {
+	if len(o.Tiers) == 0 {
+		return ErrNoTiers
+	}
+	switch o.WritePolicy {
+	case WritePolicyAll, WritePolicyL2Only:
+	case "":
+		o.WritePolicy = DefaultWritePolicy
+	default:
+		return ErrInvalidWritePolicy
+	}
+	seen := make(map[string]struct{}, len(o.Tiers))
+	for _, t := range o.Tiers {
+		if _, ok := seen[t]; ok {
+			return ErrDuplicateTier
+		}
+		seen[t] = struct{}{}
+	}
+	return nil
+}
+
+func (o *Options) UnmarshalYAML(value *yaml.Node) error {
+	type loadOptions Options
+	lo := loadOptions(*(New()))
+	if err := value.Decode(&lo); err != nil {
+		return err
+	}
+	*o = Options(lo)
+	return nil
+}
+
+// Equal returns true if all values in the Options are identical
+func (o *Options) Equal(o2 *Options) bool {
+	if o2 == nil {
+		return o == nil
+	}
+	if o == nil {
+		return false
+	}
+	return slices.Equal(o.Tiers, o2.Tiers) &&
+		o.WritePolicy == o2.WritePolicy &&
+		o.PromoteTTL == o2.PromoteTTL
+}
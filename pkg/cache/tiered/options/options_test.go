@@ -0,0 +1,102 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestNew(t *testing.T) {
+	o := New()
+	if o.WritePolicy != DefaultWritePolicy {
+		t.Errorf("expected %s got %s", DefaultWritePolicy, o.WritePolicy)
+	}
+	if time.Duration(o.PromoteTTL) != DefaultPromoteTTL {
+		t.Errorf("expected %s got %s", DefaultPromoteTTL, time.Duration(o.PromoteTTL))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	o := New()
+	if err := o.Validate(); err != ErrNoTiers {
+		t.Errorf("expected %v got %v", ErrNoTiers, err)
+	}
+	o.Tiers = []string{"l1", "l2"}
+	o.WritePolicy = ""
+	if err := o.Validate(); err != nil {
+		t.Error(err)
+	}
+	if o.WritePolicy != DefaultWritePolicy {
+		t.Errorf("expected %s got %s", DefaultWritePolicy, o.WritePolicy)
+	}
+	o.WritePolicy = "x"
+	if err := o.Validate(); err != ErrInvalidWritePolicy {
+		t.Errorf("expected %v got %v", ErrInvalidWritePolicy, err)
+	}
+	o.WritePolicy = WritePolicyL2Only
+	o.Tiers = []string{"l1", "l1"}
+	if err := o.Validate(); err != ErrDuplicateTier {
+		t.Errorf("expected %v got %v", ErrDuplicateTier, err)
+	}
+}
+
+func TestCloneEqual(t *testing.T) {
+	o := New()
+	o.Tiers = []string{"l1", "l2"}
+	o2 := o.Clone()
+	if !o.Equal(o2) {
+		t.Error("expected clone to be equal")
+	}
+	o2.Tiers[0] = "x"
+	if o.Tiers[0] != "l1" {
+		t.Error("expected clone to have its own tiers")
+	}
+	if o.Equal(o2) {
+		t.Error("expected tiers difference to make options unequal")
+	}
+	if o.Equal(nil) {
+		t.Error("expected false for nil comparison")
+	}
+	if (*Options)(nil).Clone() != nil {
+		t.Error("expected nil clone")
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	const raw = `
+tiers: [l1, l2]
+write_policy: l2_only
+`
+	o := &Options{}
+	if err := yaml.Unmarshal([]byte(raw), o); err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Tiers) != 2 || o.WritePolicy != WritePolicyL2Only {
+		t.Errorf("unexpected options %+v", o)
+	}
+	if o.PromoteTTL != timeconv.Duration(DefaultPromoteTTL) {
+		t.Errorf("expected default promote ttl, got %s", time.Duration(o.PromoteTTL))
+	}
+	if err := yaml.Unmarshal([]byte("- boom"), o); err == nil {
+		t.Error("expected an error")
+	}
+}
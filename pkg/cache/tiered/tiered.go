@@ -0,0 +1,230 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tiered is a Trickster Cache that composes other named caches into
+// an ordered set of tiers (e.g., a small memory L1 in front of a shared redis L2)
+package tiered
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/metrics"
+	"github.com/trickstercache/trickster/v2/pkg/cache/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/providers"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	tieredopts "github.com/trickstercache/trickster/v2/pkg/cache/tiered/options"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
+)
+
+var (
+	// Cache implements the cache.Client and cache.MemoryCache interfaces
+	_ cache.Client      = &Cache{}
+	_ cache.MemoryCache = &Cache{}
+)
+
+var (
+	// ErrTierNotFound is returned by Connect when a configured tier does not exist
+	ErrTierNotFound = errors.New("tiered cache tier not found")
+	// ErrNotSerializable is returned when a reference must be written to a
+	// tier that requires serialization, but it does not implement cache.ReferenceMarshaler
+	ErrNotSerializable = errors.New("reference object cannot be serialized for a non-memory tier")
+)
+
+type tier struct {
+	name     string
+	c        cache.Cache
+	isMemory bool
+}
+
+// Cache defines a Tiered Cache client that conforms to the Cache interface
+type Cache struct {
+	Name   string
+	Config *options.Options
+
+	lookup cache.Lookup
+	tiers  []tier
+}
+
+// New returns a new Tiered cache whose tiers will be resolved from the provided
+// lookup of already-loaded caches when Connect is called
+func New(name string, cfg *options.Options, lookup cache.Lookup) *Cache {
+	if cfg.Tiered == nil {
+		cfg.Tiered = tieredopts.New()
+	}
+	return &Cache{Name: name, Config: cfg, lookup: lookup}
+}
+
+// Connect resolves the configured tiers. The tiers are owned by the lookup
+// and are expected to already be connected.
+func (c *Cache) Connect() error {
+	tiers := make([]tier, 0, len(c.Config.Tiered.Tiers))
+	for _, name := range c.Config.Tiered.Tiers {
+		tc, ok := c.lookup[name]
+		if !ok || tc == nil {
+			return fmt.Errorf("%w: %s", ErrTierNotFound, name)
+		}
+		tiers = append(tiers, tier{
+			name:     name,
+			c:        tc,
+			isMemory: tc.Configuration().Provider == providers.Memory,
+		})
+	}
+	c.tiers = tiers
+	return nil
+}
+
+// Close is a no-op, since the tiers are owned and closed by their own lookup
+func (c *Cache) Close() error {
+	return nil
+}
+
+// writeTiers returns the tiers that receive Store operations per the write policy
+func (c *Cache) writeTiers() []tier {
+	if c.Config.Tiered.WritePolicy == tieredopts.WritePolicyL2Only && len(c.tiers) > 1 {
+		return c.tiers[1:]
+	}
+	return c.tiers
+}
+
+// Store places the object in the cache tiers designated by the write policy
+func (c *Cache) Store(cacheKey string, data []byte, ttl time.Duration) error {
+	var errs []error
+	for _, t := range c.writeTiers() {
+		if err := t.c.Store(cacheKey, data, ttl); err != nil {
+			errs = append(errs, fmt.Errorf("tier %s: %w", t.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// StoreReference places the object by reference in memory tiers, and in
+// serialized form in any other tiers designated by the write policy
+func (c *Cache) StoreReference(cacheKey string, data cache.ReferenceObject, ttl time.Duration) error {
+	var errs []error
+	for _, t := range c.writeTiers() {
+		if err := t.storeValue(cacheKey, data, ttl); err != nil {
+			errs = append(errs, fmt.Errorf("tier %s: %w", t.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// storeValue writes a []byte or cache.ReferenceObject value to the tier in
+// the most suitable form for its provider
+func (t tier) storeValue(cacheKey string, value any, ttl time.Duration) error {
+	switch v := value.(type) {
+	case []byte:
+		return t.c.Store(cacheKey, v, ttl)
+	case cache.ReferenceObject:
+		if t.isMemory {
+			return t.c.(cache.MemoryCache).StoreReference(cacheKey, v, ttl)
+		}
+		rm, ok := v.(cache.ReferenceMarshaler)
+		if !ok {
+			return ErrNotSerializable
+		}
+		b, err := rm.MarshalReference()
+		if err != nil {
+			return err
+		}
+		return t.c.Store(cacheKey, b, ttl)
+	}
+	return nil
+}
+
+// retrieveValue looks up the object in the tier, returning the value as
+// stored: a []byte, or a reference when the tier is a memory cache
+func (t tier) retrieveValue(cacheKey string) (any, status.LookupStatus, error) {
+	if t.isMemory {
+		return t.c.(cache.MemoryCache).RetrieveReference(cacheKey)
+	}
+	return t.c.Retrieve(cacheKey)
+}
+
+// retrieve walks the tiers in order until the object is found, and then
+// promotes it into each of the higher tiers that missed
+func (c *Cache) retrieve(cacheKey string) (any, status.LookupStatus, error) {
+	for i, t := range c.tiers {
+		v, s, err := t.retrieveValue(cacheKey)
+		metrics.ObserveCacheTierLookup(c.Name, t.name, s.String())
+		if err != nil || v == nil ||
+			(s != status.LookupStatusHit && s != status.LookupStatusProxyHit) {
+			if err != nil && !errors.Is(err, cache.ErrKNF) {
+				logger.Debug("tiered cache tier lookup failed",
+					logging.Pairs{"name": c.Name, "tier": t.name,
+						"key": cacheKey, "error": err.Error()})
+			}
+			continue
+		}
+		if i > 0 {
+			c.promote(cacheKey, v, i)
+		}
+		return v, status.LookupStatusHit, nil
+	}
+	return nil, status.LookupStatusKeyMiss, cache.ErrKNF
+}
+
+// promote writes a value found in tier n into each of the tiers above it
+func (c *Cache) promote(cacheKey string, value any, n int) {
+	ttl := time.Duration(c.Config.Tiered.PromoteTTL)
+	for _, t := range c.tiers[:n] {
+		if err := t.storeValue(cacheKey, value, ttl); err != nil {
+			logger.Debug("tiered cache promotion failed",
+				logging.Pairs{"name": c.Name, "tier": t.name,
+					"key": cacheKey, "error": err.Error()})
+		}
+	}
+}
+
+// Retrieve looks for an object in each tier and returns it (or an error if not found)
+func (c *Cache) Retrieve(cacheKey string) ([]byte, status.LookupStatus, error) {
+	v, s, err := c.retrieve(cacheKey)
+	if err != nil {
+		return nil, s, err
+	}
+	switch o := v.(type) {
+	case []byte:
+		return o, s, nil
+	case cache.ReferenceMarshaler:
+		b, err := o.MarshalReference()
+		if err != nil {
+			return nil, status.LookupStatusError, err
+		}
+		return b, s, nil
+	}
+	return nil, status.LookupStatusKeyMiss, cache.ErrKNF
+}
+
+// RetrieveReference looks for an object in each tier and returns it (or an
+// error if not found). Objects found in a non-memory tier are returned as []byte.
+func (c *Cache) RetrieveReference(cacheKey string) (any, status.LookupStatus, error) {
+	return c.retrieve(cacheKey)
+}
+
+// Remove removes the objects from all tiers
+func (c *Cache) Remove(cacheKeys ...string) error {
+	var errs []error
+	for _, t := range c.tiers {
+		if err := t.c.Remove(cacheKeys...); err != nil {
+			errs = append(errs, fmt.Errorf("tier %s: %w", t.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tiered
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/manager"
+	"github.com/trickstercache/trickster/v2/pkg/cache/memory"
+	"github.com/trickstercache/trickster/v2/pkg/cache/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/providers"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	tieredopts "github.com/trickstercache/trickster/v2/pkg/cache/tiered/options"
+)
+
+// byteCache is a minimal non-memory cache.Client, standing in for redis et al.
+type byteCache struct {
+	data map[string][]byte
+}
+
+func (b *byteCache) Connect() error { return nil }
+func (b *byteCache) Close() error   { return nil }
+func (b *byteCache) Store(k string, d []byte, _ time.Duration) error {
+	b.data[k] = d
+	return nil
+}
+
+func (b *byteCache) Retrieve(k string) ([]byte, status.LookupStatus, error) {
+	if d, ok := b.data[k]; ok {
+		return d, status.LookupStatusHit, nil
+	}
+	return nil, status.LookupStatusKeyMiss, cache.ErrKNF
+}
+
+func (b *byteCache) Remove(keys ...string) error {
+	for _, k := range keys {
+		delete(b.data, k)
+	}
+	return nil
+}
+
+type testReference struct {
+	value string
+}
+
+func (r *testReference) Size() int { return len(r.value) }
+func (r *testReference) MarshalReference() ([]byte, error) {
+	return []byte(r.value), nil
+}
+
+func newTestTiered(t *testing.T, writePolicy string) (*Cache, cache.Cache, *byteCache) {
+	t.Helper()
+	l1cfg := &options.Options{Name: "l1", Provider: providers.Memory,
+		ProviderID: providers.MemoryID}
+	l1 := manager.NewCache(memory.New("l1", l1cfg), manager.CacheOptions{}, l1cfg)
+	if err := l1.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = l1.Close() })
+	bc := &byteCache{data: map[string][]byte{}}
+	l2cfg := &options.Options{Name: "l2", Provider: providers.Redis,
+		ProviderID: providers.RedisID}
+	l2 := manager.NewCache(bc, manager.CacheOptions{}, l2cfg)
+	if err := l2.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &options.Options{Name: "tiered", Provider: providers.Tiered,
+		ProviderID: providers.TieredID, Tiered: tieredopts.New()}
+	cfg.Tiered.Tiers = []string{"l1", "l2"}
+	cfg.Tiered.WritePolicy = writePolicy
+	c := New("tiered", cfg, cache.Lookup{"l1": l1, "l2": l2})
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	return c, l1, bc
+}
+
+func TestConnectMissingTier(t *testing.T) {
+	cfg := &options.Options{Tiered: tieredopts.New()}
+	cfg.Tiered.Tiers = []string{"missing"}
+	c := New("tiered", cfg, cache.Lookup{})
+	if err := c.Connect(); !errors.Is(err, ErrTierNotFound) {
+		t.Errorf("expected %v got %v", ErrTierNotFound, err)
+	}
+}
+
+func TestStoreAll(t *testing.T) {
+	c, l1, l2 := newTestTiered(t, tieredopts.WritePolicyAll)
+	if err := c.Store("key", []byte("value"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := l1.(cache.MemoryCache).RetrieveReference("key"); err != nil {
+		t.Error("expected key in l1:", err)
+	}
+	if string(l2.data["key"]) != "value" {
+		t.Error("expected key in l2")
+	}
+}
+
+func TestStoreL2OnlyAndPromote(t *testing.T) {
+	c, l1, l2 := newTestTiered(t, tieredopts.WritePolicyL2Only)
+	if err := c.Store("key", []byte("value"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := l1.(cache.MemoryCache).RetrieveReference("key"); err == nil {
+		t.Error("expected key to be absent from l1")
+	}
+	if string(l2.data["key"]) != "value" {
+		t.Error("expected key in l2")
+	}
+	b, s, err := c.Retrieve("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != status.LookupStatusHit || string(b) != "value" {
+		t.Errorf("unexpected result %s %s", s, string(b))
+	}
+	// the l2 hit should have been promoted into l1
+	v, _, err := l1.(cache.MemoryCache).RetrieveReference("key")
+	if err != nil {
+		t.Fatal("expected promoted key in l1:", err)
+	}
+	if b, ok := v.([]byte); !ok || string(b) != "value" {
+		t.Errorf("unexpected promoted value %v", v)
+	}
+}
+
+func TestStoreReference(t *testing.T) {
+	c, l1, l2 := newTestTiered(t, tieredopts.WritePolicyAll)
+	ref := &testReference{value: "reference"}
+	if err := c.StoreReference("key", ref, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	v, _, err := l1.(cache.MemoryCache).RetrieveReference("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != ref {
+		t.Error("expected l1 to hold the original reference")
+	}
+	if string(l2.data["key"]) != "reference" {
+		t.Error("expected serialized reference in l2")
+	}
+	v, s, err := c.RetrieveReference("key")
+	if err != nil || s != status.LookupStatusHit || v != ref {
+		t.Errorf("unexpected result %v %s %v", v, s, err)
+	}
+	b, _, err := c.Retrieve("key")
+	if err != nil || string(b) != "reference" {
+		t.Errorf("unexpected result %s %v", string(b), err)
+	}
+}
+
+func TestStoreReferenceNotSerializable(t *testing.T) {
+	c, _, _ := newTestTiered(t, tieredopts.WritePolicyAll)
+	err := c.StoreReference("key", &unmarshalableReference{}, time.Minute)
+	if !errors.Is(err, ErrNotSerializable) {
+		t.Errorf("expected %v got %v", ErrNotSerializable, err)
+	}
+}
+
+type unmarshalableReference struct{}
+
+func (r *unmarshalableReference) Size() int { return 1 }
+
+func TestRetrieveMissAndRemove(t *testing.T) {
+	c, l1, l2 := newTestTiered(t, tieredopts.WritePolicyAll)
+	if _, s, err := c.Retrieve("key"); !errors.Is(err, cache.ErrKNF) ||
+		s != status.LookupStatusKeyMiss {
+		t.Errorf("expected key miss, got %s %v", s, err)
+	}
+	if err := c.Store("key", []byte("value"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Remove("key"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := l1.(cache.MemoryCache).RetrieveReference("key"); err == nil {
+		t.Error("expected key to be removed from l1")
+	}
+	if _, ok := l2.data["key"]; ok {
+		t.Error("expected key to be removed from l2")
+	}
+	if err := c.Close(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package options defines the configuration of Cache Warmers, which
+// periodically request configured queries to keep their cached objects fresh
+package options
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+	"time"
+
+	ct "github.com/trickstercache/trickster/v2/pkg/config/types"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/methods"
+	"github.com/trickstercache/trickster/v2/pkg/util/pointers"
+	"github.com/trickstercache/trickster/v2/pkg/util/sets"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// DefaultInterval is the default interval between runs of a Cache Warmer
+	DefaultInterval = 5 * time.Minute
+	// DefaultTimeout is the default time allowed for a Cache Warmer's request
+	DefaultTimeout = 30 * time.Second
+)
+
+var (
+	// ErrInvalidName is returned when a Cache Warmer's name is reserved
+	ErrInvalidName = errors.New("invalid warmer name")
+	// ErrMissingBackendName is returned when a Cache Warmer has no backend_name
+	ErrMissingBackendName = errors.New("warmer backend_name is required")
+	// ErrInvalidPath is returned when a Cache Warmer's path is not absolute
+	ErrInvalidPath = errors.New("warmer path must begin with /")
+	// ErrInvalidMethod is returned when a Cache Warmer's method is not supported
+	ErrInvalidMethod = errors.New("warmer method must be GET, HEAD or POST")
+	// ErrInvalidInterval is returned when a Cache Warmer's interval, jitter or
+	// timeout are invalid
+	ErrInvalidInterval = errors.New("warmer interval and timeout must be greater than 0, " +
+		"and jitter must be greater than or equal to 0 and less than interval")
+
+	restrictedNames = sets.New([]string{"", "none"})
+)
+
+// Options configures a Cache Warmer, which requests a query through a
+// backend's handlers on an interval. The path, params, headers and body may
+// include relative time tokens, such as {{now-6h}}, which are resolved when
+// each request is made.
+type Options struct {
+	// Name is the name of the Cache Warmer, populated from the Lookup key
+	Name string `yaml:"-"`
+	// BackendName is the name of the backend that handles the request
+	BackendName string `yaml:"backend_name,omitempty"`
+	// Method is the HTTP method of the request; default is GET
+	Method string `yaml:"method,omitempty"`
+	// Path is the path of the request, as served by the backend
+	Path string `yaml:"path,omitempty"`
+	// Params are the query parameters of the request
+	Params map[string]string `yaml:"params,omitempty"`
+	// Headers are the headers of the request
+	Headers ct.EnvStringMap `yaml:"headers,omitempty"`
+	// Body is the body of the request
+	Body string `yaml:"body,omitempty"`
+	// Interval is the time between runs of the Cache Warmer
+	Interval timeconv.Duration `yaml:"interval,omitempty"`
+	// Jitter is the maximum random delay added to each Interval, so that
+	// warmers configured with the same Interval do not run together
+	Jitter timeconv.Duration `yaml:"jitter,omitempty"`
+	// Timeout is the time allowed for the request to complete
+	Timeout timeconv.Duration `yaml:"timeout,omitempty"`
+}
+
+// Lookup is a map of Options keyed by Options Name
+type Lookup map[string]*Options
+
+// New returns a new Cache Warmer Options with default values
+func New() *Options {
+	return &Options{
+		Method:   http.MethodGet,
+		Interval: timeconv.Duration(DefaultInterval),
+		Timeout:  timeconv.Duration(DefaultTimeout),
+	}
+}
+
+// Clone returns an exact copy of the Options
+func (o *Options) Clone() *Options {
+	if o == nil {
+		return nil
+	}
+	out := pointers.Clone(o)
+	out.Params = maps.Clone(o.Params)
+	out.Headers = maps.Clone(o.Headers)
+	return out
+}
+
+// CloneYAMLSafe returns a clone with the header values redacted
+func (o *Options) CloneYAMLSafe() *Options {
+	out := o.Clone()
+	if out == nil {
+		return nil
+	}
+	for k := range out.Headers {
+		out.Headers[k] = "*****"
+	}
+	return out
+}
+
+// Equal returns true if all values in the Options are identical
+func (o *Options) Equal(o2 *Options) bool {
+	if o == nil || o2 == nil {
+		return o == o2
+	}
+	return o.Name == o2.Name &&
+		o.BackendName == o2.BackendName &&
+		o.Method == o2.Method &&
+		o.Path == o2.Path &&
+		maps.Equal(o.Params, o2.Params) &&
+		maps.Equal(o.Headers, o2.Headers) &&
+		o.Body == o2.Body &&
+		o.Interval == o2.Interval &&
+		o.Jitter == o2.Jitter &&
+		o.Timeout == o2.Timeout
+}
+
+// Validate returns an error if the Options are invalid
+func (o *Options) Validate() error {
+	if restrictedNames.Contains(o.Name) {
+		return ErrInvalidName
+	}
+	if o.BackendName == "" {
+		return ErrMissingBackendName
+	}
+	if !strings.HasPrefix(o.Path, "/") {
+		return ErrInvalidPath
+	}
+	if o.Method == "" {
+		o.Method = http.MethodGet
+	}
+	o.Method = strings.ToUpper(o.Method)
+	if o.Method != http.MethodGet && o.Method != http.MethodHead &&
+		o.Method != http.MethodPost {
+		return ErrInvalidMethod
+	}
+	if !methods.HasBody(o.Method) && o.Body != "" {
+		return ErrInvalidMethod
+	}
+	if o.Interval <= 0 || o.Timeout <= 0 || o.Jitter < 0 || o.Jitter >= o.Interval {
+		return ErrInvalidInterval
+	}
+	// ensures every time token in the request is valid
+	if _, err := o.NewRequest(time.Now()); err != nil {
+		return fmt.Errorf("invalid warmer %s: %w", o.Name, err)
+	}
+	return nil
+}
+
+// Validate validates each Options in the Lookup
+func (l Lookup) Validate() error {
+	for k, o := range l {
+		if o == nil {
+			return fmt.Errorf("empty warmer found: %s", k)
+		}
+		o.Name = k
+		if err := o.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalYAML applies defaults before decoding a warmer configuration block.
+func (o *Options) UnmarshalYAML(value *yaml.Node) error {
+	type loadOptions Options
+	lo := loadOptions(*(New()))
+	if err := value.Decode(&lo); err != nil {
+		return err
+	}
+	*o = Options(lo)
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+)
+
+func testOptions() *Options {
+	o := New()
+	o.Name = "example"
+	o.BackendName = "prom"
+	o.Path = "/api/v1/query_range"
+	return o
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	var l Lookup
+	err := yaml.Unmarshal([]byte(`
+example:
+  backend_name: prom
+  path: /api/v1/query_range
+  params:
+    query: up
+    start: '{{now-6h}}'
+  headers:
+    Authorization: Bearer abc
+  interval: 1m
+  jitter: 10s
+`), &l)
+	require.NoError(t, err)
+	require.NoError(t, l.Validate())
+	o := l["example"]
+	require.Equal(t, "example", o.Name)
+	require.Equal(t, http.MethodGet, o.Method)
+	require.Equal(t, timeconv.Duration(time.Minute), o.Interval)
+	require.Equal(t, timeconv.Duration(10*time.Second), o.Jitter)
+	require.Equal(t, timeconv.Duration(DefaultTimeout), o.Timeout)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*Options)
+		expected error
+	}{
+		{"valid", func(*Options) {}, nil},
+		{"reserved name", func(o *Options) { o.Name = "none" }, ErrInvalidName},
+		{"no backend", func(o *Options) { o.BackendName = "" }, ErrMissingBackendName},
+		{"relative path", func(o *Options) { o.Path = "api" }, ErrInvalidPath},
+		{"bad method", func(o *Options) { o.Method = http.MethodDelete }, ErrInvalidMethod},
+		{"body without post", func(o *Options) { o.Body = "q=up" }, ErrInvalidMethod},
+		{"no interval", func(o *Options) { o.Interval = 0 }, ErrInvalidInterval},
+		{"no timeout", func(o *Options) { o.Timeout = 0 }, ErrInvalidInterval},
+		{"negative jitter", func(o *Options) { o.Jitter = -1 }, ErrInvalidInterval},
+		{"jitter exceeds interval", func(o *Options) { o.Jitter = o.Interval }, ErrInvalidInterval},
+		{"bad time token", func(o *Options) { o.Path = "/{{now-}}" }, ErrInvalidTimeToken},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := testOptions()
+			test.modify(o)
+			require.ErrorIs(t, o.Validate(), test.expected)
+		})
+	}
+
+	o := testOptions()
+	o.Method = "post"
+	require.NoError(t, o.Validate())
+	require.Equal(t, http.MethodPost, o.Method)
+
+	require.Error(t, Lookup{"example": nil}.Validate())
+}
+
+func TestCloneEqual(t *testing.T) {
+	o := testOptions()
+	o.Params = map[string]string{"query": "up"}
+	o.Headers = map[string]string{"Authorization": "Bearer abc"}
+	o2 := o.Clone()
+	require.True(t, o.Equal(o2))
+	o2.Params["query"] = "down"
+	require.False(t, o.Equal(o2))
+	require.Equal(t, "up", o.Params["query"])
+
+	safe := o.CloneYAMLSafe()
+	require.Equal(t, "*****", safe.Headers["Authorization"])
+	require.Equal(t, "Bearer abc", o.Headers["Authorization"])
+
+	var n *Options
+	require.Nil(t, n.Clone())
+	require.True(t, n.Equal(nil))
+	require.False(t, o.Equal(nil))
+}
+
+func TestExpandTimeTokens(t *testing.T) {
+	now := time.Unix(1700000000, 500000000)
+	tests := []struct {
+		in, expected string
+		err          error
+	}{
+		{"up", "up", nil},
+		{"{{now}}", "1700000000", nil},
+		{"{{ now - 6h }}", "1699978400", nil},
+		{"{{now+1d}}", "1700086400", nil},
+		{"{{now-1h:ms}}", "1699996400500", nil},
+		{"{{now:ns}}", "1700000000500000000", nil},
+		{"{{now-1m:rfc3339}}", "2023-11-14T22:12:20Z", nil},
+		{"time > {{now-1h}} and time < {{now}}", "time > 1699996400 and time < 1700000000", nil},
+		{"{{now", "", ErrInvalidTimeToken},
+		{"{{later}}", "", ErrInvalidTimeToken},
+		{"{{now6h}}", "", ErrInvalidTimeToken},
+		{"{{now-6x}}", "", ErrInvalidTimeToken},
+		{"{{now:us}}", "", ErrInvalidTimeToken},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			out, err := ExpandTimeTokens(test.in, now)
+			require.ErrorIs(t, err, test.err)
+			require.Equal(t, test.expected, out)
+		})
+	}
+}
+
+func TestNewRequest(t *testing.T) {
+	o := testOptions()
+	o.Method = http.MethodPost
+	o.Params = map[string]string{"start": "{{now-1h}}"}
+	o.Headers = map[string]string{"X-Start": "{{now-1h}}"}
+	o.Body = "query=up&end={{now}}"
+	r, err := o.NewRequest(time.Unix(1700000000, 0))
+	require.NoError(t, err)
+	require.Equal(t, http.MethodPost, r.Method)
+	require.Equal(t, "/api/v1/query_range", r.URL.Path)
+	require.Equal(t, "1699996400", r.URL.Query().Get("start"))
+	require.Equal(t, "1699996400", r.Header.Get("X-Start"))
+	b, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+	require.Equal(t, "query=up&end=1700000000", string(b))
+
+	o.Headers["X-Start"] = "{{bad}}"
+	_, err = o.NewRequest(time.Now())
+	require.ErrorIs(t, err, ErrInvalidTimeToken)
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+)
+
+const (
+	tokenStart = "{{"
+	tokenEnd   = "}}"
+	tokenNow   = "now"
+)
+
+// time token formats
+const (
+	formatSeconds      = "s"
+	formatMilliseconds = "ms"
+	formatNanoseconds  = "ns"
+	formatRFC3339      = "rfc3339"
+)
+
+// ErrInvalidTimeToken is returned when a relative time token can't be parsed
+var ErrInvalidTimeToken = errors.New("invalid time token")
+
+// NewRequest returns the Cache Warmer's request, with any relative time tokens
+// resolved against now
+func (o *Options) NewRequest(now time.Time) (*http.Request, error) {
+	path, err := ExpandTimeTokens(o.Path, now)
+	if err != nil {
+		return nil, err
+	}
+	q := make(url.Values, len(o.Params))
+	for k, v := range o.Params {
+		if v, err = ExpandTimeTokens(v, now); err != nil {
+			return nil, err
+		}
+		q.Set(k, v)
+	}
+	body, err := ExpandTimeTokens(o.Body, now)
+	if err != nil {
+		return nil, err
+	}
+	u := &url.URL{Scheme: "http", Host: "localhost", Path: path, RawQuery: q.Encode()}
+	r, err := http.NewRequest(o.Method, u.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range o.Headers {
+		if v, err = ExpandTimeTokens(v, now); err != nil {
+			return nil, err
+		}
+		r.Header.Set(k, v)
+	}
+	return r, nil
+}
+
+// ExpandTimeTokens replaces each relative time token in s with its time,
+// relative to now. Tokens are formatted as {{now[+|-duration][:format]}},
+// such as {{now-6h}}, and are replaced with a Unix timestamp in seconds unless
+// a format of ms, ns or rfc3339 is provided.
+func ExpandTimeTokens(s string, now time.Time) (string, error) {
+	if !strings.Contains(s, tokenStart) {
+		return s, nil
+	}
+	var sb strings.Builder
+	for {
+		before, rest, ok := strings.Cut(s, tokenStart)
+		sb.WriteString(before)
+		if !ok {
+			return sb.String(), nil
+		}
+		token, after, ok := strings.Cut(rest, tokenEnd)
+		if !ok {
+			return "", ErrInvalidTimeToken
+		}
+		v, err := resolveTimeToken(strings.ReplaceAll(token, " ", ""), now)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(v)
+		s = after
+	}
+}
+
+func resolveTimeToken(token string, now time.Time) (string, error) {
+	token, format, _ := strings.Cut(token, ":")
+	offset, ok := strings.CutPrefix(token, tokenNow)
+	if !ok {
+		return "", ErrInvalidTimeToken
+	}
+	t := now
+	if offset != "" {
+		if offset[0] != '+' && offset[0] != '-' {
+			return "", ErrInvalidTimeToken
+		}
+		d, err := timeconv.ParseDuration(offset[1:])
+		if err != nil {
+			return "", ErrInvalidTimeToken
+		}
+		if offset[0] == '-' {
+			d = -d
+		}
+		t = t.Add(d)
+	}
+	switch format {
+	case "", formatSeconds:
+		return strconv.FormatInt(t.Unix(), 10), nil
+	case formatMilliseconds:
+		return strconv.FormatInt(t.UnixMilli(), 10), nil
+	case formatNanoseconds:
+		return strconv.FormatInt(t.UnixNano(), 10), nil
+	case formatRFC3339:
+		return t.UTC().Format(time.RFC3339), nil
+	}
+	return "", ErrInvalidTimeToken
+}
@@ -0,0 +1,270 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package warmer runs Cache Warmers, which request configured queries through
+// their backends' handlers on an interval, to keep the cached objects fresh
+package warmer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	"github.com/trickstercache/trickster/v2/pkg/cache/warmer/options"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
+	"github.com/trickstercache/trickster/v2/pkg/observability/metrics"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+)
+
+// run results
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+	resultError   = "error"
+)
+
+// ErrWarmerNotFound is returned when running a Cache Warmer that is not configured
+var ErrWarmerNotFound = errors.New("warmer not found")
+
+// Result describes a single run of a Cache Warmer
+type Result struct {
+	Warmer  string `json:"warmer"`
+	Backend string `json:"backend"`
+	// Status is the HTTP status of the response
+	Status int `json:"status,omitempty"`
+	// Result is the X-Trickster-Result header of the response, which
+	// describes how the request was served from the cache
+	Result   string `json:"result,omitempty"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Scheduler runs a set of Cache Warmers on their intervals, or on demand,
+// limiting the number of their requests that run at once
+type Scheduler struct {
+	warmers map[string]*warmer
+	sem     chan struct{}
+
+	mtx    sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type warmer struct {
+	o       *options.Options
+	backend backends.Backend
+}
+
+// New returns a Scheduler for the Cache Warmers whose backends are in b
+func New(l options.Lookup, b backends.Backends, concurrencyLimit int) *Scheduler {
+	s := &Scheduler{
+		warmers: make(map[string]*warmer, len(l)),
+		sem:     make(chan struct{}, max(concurrencyLimit, 1)),
+	}
+	for name, o := range l {
+		if o == nil {
+			continue
+		}
+		be, ok := b[o.BackendName]
+		if !ok || be == nil || be.Router() == nil {
+			logger.Warn("cache warmer backend not found",
+				logging.Pairs{"warmer": name, "backendName": o.BackendName})
+			continue
+		}
+		s.warmers[name] = &warmer{o: o, backend: be}
+	}
+	return s
+}
+
+// Names returns the sorted names of the Scheduler's Cache Warmers
+func (s *Scheduler) Names() []string {
+	if s == nil {
+		return nil
+	}
+	out := make([]string, 0, len(s.warmers))
+	for name := range s.warmers {
+		out = append(out, name)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Start starts running each Cache Warmer on its interval
+func (s *Scheduler) Start() {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, w := range s.warmers {
+		s.wg.Go(func() { s.loop(ctx, w) })
+	}
+}
+
+// Stop stops running the Cache Warmers, cancelling any in-flight requests,
+// and waits for them to return
+func (s *Scheduler) Stop() {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+	s.cancel = nil
+}
+
+// Run runs the named Cache Warmer once, and returns the result
+func (s *Scheduler) Run(ctx context.Context, name string) (*Result, error) {
+	if s == nil {
+		return nil, ErrWarmerNotFound
+	}
+	w, ok := s.warmers[name]
+	if !ok {
+		return nil, ErrWarmerNotFound
+	}
+	return s.run(ctx, w), nil
+}
+
+func (s *Scheduler) loop(ctx context.Context, w *warmer) {
+	// the first run is delayed by up to the jitter, so that warmers don't all
+	// run together at startup
+	delay := jitter(time.Duration(w.o.Jitter))
+	for {
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+		s.runSafe(ctx, w)
+		delay = time.Duration(w.o.Interval) + jitter(time.Duration(w.o.Jitter))
+	}
+}
+
+// runSafe runs the Cache Warmer, recovering from any panic in the handlers so
+// that its loop continues
+func (s *Scheduler) runSafe(ctx context.Context, w *warmer) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("cache warmer panic", logging.Pairs{
+				"warmer": w.o.Name,
+				"panic":  fmt.Sprintf("%v", r),
+			})
+			metrics.WarmerRuns.WithLabelValues(w.o.Name, w.o.BackendName, resultError).Inc()
+		}
+	}()
+	s.run(ctx, w)
+}
+
+func (s *Scheduler) run(ctx context.Context, w *warmer) *Result {
+	res := &Result{Warmer: w.o.Name, Backend: w.o.BackendName}
+	select {
+	case s.sem <- struct{}{}:
+		defer func() { <-s.sem }()
+	case <-ctx.Done():
+		res.Error = ctx.Err().Error()
+		return res
+	}
+	start := time.Now()
+	result := resultSuccess
+	r, err := w.o.NewRequest(start)
+	if err == nil {
+		ctx, cancel := context.WithTimeout(ctx, time.Duration(w.o.Timeout))
+		defer cancel()
+		rw := &responseWriter{header: make(http.Header)}
+		w.backend.Router().ServeHTTP(rw, r.WithContext(ctx))
+		res.Status = rw.Status()
+		res.Result = rw.header.Get(headers.NameTricksterResult)
+		err = ctx.Err()
+	}
+	elapsed := time.Since(start)
+	res.Duration = elapsed.String()
+	switch {
+	case err != nil:
+		result = resultError
+		res.Error = err.Error()
+	case res.Status >= http.StatusBadRequest:
+		result = resultFailure
+	}
+	metrics.WarmerRuns.WithLabelValues(w.o.Name, w.o.BackendName, result).Inc()
+	metrics.WarmerRunDuration.WithLabelValues(w.o.Name, w.o.BackendName).
+		Observe(elapsed.Seconds())
+	pairs := logging.Pairs{"warmer": w.o.Name, "backendName": w.o.BackendName,
+		"status": res.Status, "result": res.Result, "duration": res.Duration}
+	if result == resultSuccess {
+		logger.Debug("cache warmer run complete", pairs)
+	} else {
+		if res.Error != "" {
+			pairs["error"] = res.Error
+		}
+		logger.Warn("cache warmer run failed", pairs)
+	}
+	return res
+}
+
+// jitter returns a random duration in [0, limit)
+func jitter(limit time.Duration) time.Duration {
+	if limit <= 0 {
+		return 0
+	}
+	return rand.N(limit)
+}
+
+// responseWriter discards the body of a Cache Warmer's response
+type responseWriter struct {
+	header http.Header
+	status int
+}
+
+func (rw *responseWriter) Header() http.Header {
+	return rw.header
+}
+
+func (rw *responseWriter) WriteHeader(status int) {
+	if rw.status == 0 {
+		rw.status = status
+	}
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	rw.WriteHeader(http.StatusOK)
+	return len(b), nil
+}
+
+// Status returns the status of the response
+func (rw *responseWriter) Status() int {
+	if rw.status == 0 {
+		return http.StatusOK
+	}
+	return rw.status
+}
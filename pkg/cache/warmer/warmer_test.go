@@ -0,0 +1,189 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package warmer
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/warmer/options"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testBackends(t *testing.T, h http.HandlerFunc) backends.Backends {
+	t.Helper()
+	b, err := backends.New("test", bo.New(), nil, h, nil)
+	require.NoError(t, err)
+	return backends.Backends{"test": b}
+}
+
+func testOptions(name string) *options.Options {
+	o := options.New()
+	o.Name = name
+	o.BackendName = "test"
+	o.Path = "/api/v1/query_range"
+	return o
+}
+
+func TestRun(t *testing.T) {
+	var got *http.Request
+	var body string
+	b := testBackends(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set(headers.NameTricksterResult, "engine=DeltaProxyCache; status=kmiss")
+		w.Write([]byte("ok"))
+	})
+	o := testOptions("example")
+	o.Method = http.MethodPost
+	o.Params = map[string]string{"query": "up", "start": "{{now-6h}}", "end": "{{now}}"}
+	o.Headers = map[string]string{"Authorization": "Bearer abc"}
+	o.Body = "end={{now:ms}}"
+	s := New(options.Lookup{"example": o}, b, 1)
+	require.Equal(t, []string{"example"}, s.Names())
+
+	before := time.Now()
+	res, err := s.Run(context.Background(), "example")
+	require.NoError(t, err)
+	require.Equal(t, "example", res.Warmer)
+	require.Equal(t, "test", res.Backend)
+	require.Equal(t, http.StatusOK, res.Status)
+	require.Equal(t, "engine=DeltaProxyCache; status=kmiss", res.Result)
+	require.Empty(t, res.Error)
+
+	require.Equal(t, http.MethodPost, got.Method)
+	require.Equal(t, "/api/v1/query_range", got.URL.Path)
+	require.Equal(t, "up", got.URL.Query().Get("query"))
+	require.Equal(t, "Bearer abc", got.Header.Get("Authorization"))
+	start, err := strconv.ParseInt(got.URL.Query().Get("start"), 10, 64)
+	require.NoError(t, err)
+	end, err := strconv.ParseInt(got.URL.Query().Get("end"), 10, 64)
+	require.NoError(t, err)
+	require.Equal(t, int64(6*time.Hour/time.Second), end-start)
+	require.GreaterOrEqual(t, end, before.Unix())
+	require.Contains(t, body, "end=")
+
+	_, err = s.Run(context.Background(), "missing")
+	require.ErrorIs(t, err, ErrWarmerNotFound)
+}
+
+func TestRunFailures(t *testing.T) {
+	b := testBackends(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	bad := testOptions("bad")
+	slow := testOptions("slow")
+	slow.Path = "/slow"
+	slow.Timeout = timeconv.Duration(10 * time.Millisecond)
+	s := New(options.Lookup{"bad": bad, "slow": slow}, b, 1)
+
+	res, err := s.Run(context.Background(), "bad")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadGateway, res.Status)
+	require.Empty(t, res.Error)
+
+	res, err = s.Run(context.Background(), "slow")
+	require.NoError(t, err)
+	require.Equal(t, context.DeadlineExceeded.Error(), res.Error)
+}
+
+func TestNewSkipsUnknownBackends(t *testing.T) {
+	b := testBackends(t, func(http.ResponseWriter, *http.Request) {})
+	o := testOptions("example")
+	o.BackendName = "missing"
+	s := New(options.Lookup{"example": o, "empty": nil}, b, 1)
+	require.Empty(t, s.Names())
+}
+
+func TestStartStop(t *testing.T) {
+	var runs, active, maxActive atomic.Int32
+	b := testBackends(t, func(http.ResponseWriter, *http.Request) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			m := maxActive.Load()
+			if n <= m || maxActive.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		runs.Add(1)
+	})
+	l := make(options.Lookup)
+	for _, name := range []string{"w1", "w2", "w3"} {
+		o := testOptions(name)
+		o.Interval = timeconv.Duration(5 * time.Millisecond)
+		o.Jitter = timeconv.Duration(time.Millisecond)
+		l[name] = o
+	}
+	s := New(l, b, 1)
+	s.Start()
+	// a second start is a no-op
+	s.Start()
+	require.Eventually(t, func() bool { return runs.Load() >= 6 },
+		5*time.Second, 5*time.Millisecond)
+	s.Stop()
+	s.Stop()
+	stopped := runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, stopped, runs.Load())
+	require.Equal(t, int32(1), maxActive.Load())
+}
+
+func TestNilScheduler(t *testing.T) {
+	var s *Scheduler
+	s.Start()
+	s.Stop()
+	require.Nil(t, s.Names())
+	_, err := s.Run(context.Background(), "example")
+	require.ErrorIs(t, err, ErrWarmerNotFound)
+}
+
+func TestRunCancelledWhileWaiting(t *testing.T) {
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	b := testBackends(t, func(http.ResponseWriter, *http.Request) {
+		<-release
+	})
+	s := New(options.Lookup{"example": testOptions("example")}, b, 1)
+	wg.Go(func() { s.Run(context.Background(), "example") })
+	require.Eventually(t, func() bool { return len(s.sem) == 1 },
+		time.Second, time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	res, err := s.Run(ctx, "example")
+	require.NoError(t, err)
+	require.Equal(t, context.Canceled.Error(), res.Error)
+	close(release)
+	wg.Wait()
+}
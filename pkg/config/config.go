@@ -29,6 +29,7 @@ import (
 	rule "github.com/trickstercache/trickster/v2/pkg/backends/rule/options"
 	"github.com/trickstercache/trickster/v2/pkg/cache/negative"
 	cache "github.com/trickstercache/trickster/v2/pkg/cache/options"
+	warmer "github.com/trickstercache/trickster/v2/pkg/cache/warmer/options"
 	"github.com/trickstercache/trickster/v2/pkg/config/listener"
 	"github.com/trickstercache/trickster/v2/pkg/config/mgmt"
 	yamlencoding "github.com/trickstercache/trickster/v2/pkg/encoding/yaml"
@@ -75,6 +76,9 @@ type Config struct {
 	MgmtConfig *mgmt.Options `yaml:"mgmt,omitempty"`
 	// Authenticators provides configurations for Authenticating users
 	Authenticators auth.Lookup `yaml:"authenticators,omitempty"`
+	// Warmers provides configurations for Cache Warmers, which request
+	// queries on an interval to keep their cached objects fresh
+	Warmers warmer.Lookup `yaml:"warmers,omitempty"`
 
 	// Flags contains a compiled version of the CLI flags
 	Flags *Flags `yaml:"-"`
@@ -353,6 +357,13 @@ func (c *Config) Clone() *Config {
 		}
 	}
 
+	if len(c.Warmers) > 0 {
+		nc.Warmers = make(warmer.Lookup, len(c.Warmers))
+		for k, v := range c.Warmers {
+			nc.Warmers[k] = v.Clone()
+		}
+	}
+
 	return nc
 }
 
@@ -424,11 +435,31 @@ func (c *Config) String() string {
 		cp.Authenticators[k] = o.CloneYAMLSafe()
 	}
 
-	// strip Redis password
+	for k, o := range cp.Warmers {
+		cp.Warmers[k] = o.CloneYAMLSafe()
+	}
+
+	// strip Redis password and S3 credentials
 	for k, v := range cp.Caches {
 		if v != nil && cp.Caches[k].Redis != nil && cp.Caches[k].Redis.Password != "" {
 			cp.Caches[k].Redis.Password = "*****"
 		}
+		if v != nil && cp.Caches[k].S3 != nil {
+			if cp.Caches[k].S3.SecretAccessKey != "" {
+				cp.Caches[k].S3.SecretAccessKey = "*****"
+			}
+			if cp.Caches[k].S3.SessionToken != "" {
+				cp.Caches[k].S3.SessionToken = "*****"
+			}
+		}
+		if v != nil && v.Encryption != nil {
+			v.Encryption.KeyMaterial = nil
+			for _, ko := range v.Encryption.Keys {
+				if ko != nil && ko.Key != "" {
+					ko.Key = "*****"
+				}
+			}
+		}
 	}
 
 	bytes, err := yamlencoding.Marshal(cp)
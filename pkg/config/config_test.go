@@ -25,6 +25,7 @@ import (
 
 	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
 	rule "github.com/trickstercache/trickster/v2/pkg/backends/rule/options"
+	encryption "github.com/trickstercache/trickster/v2/pkg/cache/encryption/options"
 	ct "github.com/trickstercache/trickster/v2/pkg/config/types"
 	tracing "github.com/trickstercache/trickster/v2/pkg/observability/tracing/options"
 	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
@@ -93,6 +94,9 @@ func TestString(t *testing.T) {
 	c1.Backends["default"].Paths = append(c1.Backends["default"].Paths, &po.Options{Path: "test"})
 
 	c1.Caches["default"].Redis.Password = "plaintext-password"
+	c1.Caches["default"].S3.SecretAccessKey = "plaintext-secret-key"
+	c1.Caches["default"].Encryption = &encryption.Options{ActiveKeyID: "k1",
+		Keys: map[string]*encryption.KeyOptions{"k1": {Key: "plaintext-encryption-key"}}}
 	c1.Authenticators = auth.Lookup{
 		"basic": {
 			Users: ct.EnvStringMap{
@@ -112,11 +116,18 @@ func TestString(t *testing.T) {
 			t.Errorf("missing redacted authenticator user %q in config:\n%s", want, s)
 		}
 	}
-	for _, sensitive := range []string{"alice", "alice-password", "bob", "bob-password"} {
+	for _, sensitive := range []string{"alice", "alice-password", "bob", "bob-password",
+		"plaintext-secret-key", "plaintext-encryption-key"} {
 		if strings.Contains(s, sensitive) {
 			t.Errorf("config contains sensitive authenticator value %q:\n%s", sensitive, s)
 		}
 	}
+	if c1.Caches["default"].S3.SecretAccessKey != "plaintext-secret-key" {
+		t.Error("String mutated the original s3 secret access key")
+	}
+	if c1.Caches["default"].Encryption.Keys["k1"].Key != "plaintext-encryption-key" {
+		t.Error("String mutated the original cache encryption key")
+	}
 	if c1.Authenticators["basic"].Users["alice"] != "alice-password" {
 		t.Error("String mutated the original authenticator users")
 	}
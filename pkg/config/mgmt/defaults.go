@@ -35,6 +35,18 @@ const (
 	DefaultPurgeByKeyHandlerPath = "/trickster/purge/key/"
 	// DefaultPurgeByPathHandlerPath defines the default path for the Cache Purge (by Path) Handler
 	DefaultPurgeByPathHandlerPath = "/trickster/purge/path/"
+	// DefaultPurgeByTagHandlerPath defines the default path for the Cache Purge (by Tag, Backend or Key Prefix) Handler
+	DefaultPurgeByTagHandlerPath = "/trickster/purge/tags"
+	// DefaultPurgeByExtentHandlerPath defines the default path for the Cached Timeseries Extent Invalidation Handler
+	DefaultPurgeByExtentHandlerPath = "/trickster/purge/extent"
+	// DefaultCacheKeysHandlerPath defines the default path for the Cache Key Listing Handler
+	DefaultCacheKeysHandlerPath = "/trickster/cache/keys"
+	// DefaultCacheObjectHandlerPath defines the default path for the Cache Object Inspection Handler
+	DefaultCacheObjectHandlerPath = "/trickster/cache/object"
+	// DefaultWarmHandlerPath defines the default path for the Cache Warmer Handler
+	DefaultWarmHandlerPath = "/trickster/warm/"
+	// DefaultWarmerConcurrencyLimit is the default number of Cache Warmer requests that run at once
+	DefaultWarmerConcurrencyLimit = 4
 	// DefaultPprofListenerName defines the default Pprof Listener Name
 	DefaultPprofListenerName = ListenerNameBoth
 	// DefaultDrainTimeout is the default time that is allowed for an old configuration's requests to drain
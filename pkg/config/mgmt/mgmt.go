@@ -18,8 +18,10 @@ package mgmt
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	peers "github.com/trickstercache/trickster/v2/pkg/proxy/peers/options"
 	"github.com/trickstercache/trickster/v2/pkg/util/pointers"
 )
 
@@ -45,6 +47,20 @@ type Options struct {
 	PurgeByKeyHandlerPath string `yaml:"purge_by_key_path,omitempty"`
 	// PurgeByKeyHandlerPath provides the base Cache Purge-by-Path Handler path
 	PurgeByPathHandlerPath string `yaml:"purge_by_path_path,omitempty"`
+	// PurgeByTagHandlerPath provides the Cache Purge-by-Tag Handler path, which also
+	// purges by Backend or Key Prefix
+	PurgeByTagHandlerPath string `yaml:"purge_by_tag_path,omitempty"`
+	// PurgeByExtentHandlerPath provides the Handler path for invalidating a time range
+	// of cached timeseries objects
+	PurgeByExtentHandlerPath string `yaml:"purge_by_extent_path,omitempty"`
+	// CacheKeysHandlerPath provides the Handler path for listing the objects in the caches
+	CacheKeysHandlerPath string `yaml:"cache_keys_path,omitempty"`
+	// CacheObjectHandlerPath provides the Handler path for inspecting the metadata of a cached object
+	CacheObjectHandlerPath string `yaml:"cache_object_path,omitempty"`
+	// WarmHandlerPath provides the base Handler path for running a Cache Warmer on demand
+	WarmHandlerPath string `yaml:"warm_handler_path,omitempty"`
+	// WarmerConcurrencyLimit limits the number of Cache Warmer requests that run at once
+	WarmerConcurrencyLimit int `yaml:"warmer_concurrency_limit,omitempty"`
 	// PprofListener provides the name of the http listener that will host the pprof debugging routes
 	// Options are: "metrics", "mgmt", "both", or "off"; default is both
 	PprofListener string `yaml:"pprof_listener,omitempty"`
@@ -62,6 +78,9 @@ type Options struct {
 	// AutoReloadInterval controls how often Trickster checks its effective configuration
 	// sources for changes. A zero value disables automatic reloads.
 	AutoReloadInterval timeconv.Duration `yaml:"auto_reload_interval,omitempty"`
+	// Peers provides the other Trickster instances in the cluster, to which purges
+	// are broadcast
+	Peers *peers.Options `yaml:"peers,omitempty"`
 }
 
 // ErrInvalidPprofListenerName returns an error for invalid pprof listener name
@@ -73,21 +92,30 @@ var ErrInvalidConfigHandlerListenerName = errors.New("invalid config handler lis
 // ErrInvalidAutoReloadInterval indicates that the configured interval is negative.
 var ErrInvalidAutoReloadInterval = errors.New("auto reload interval cannot be negative")
 
+// ErrInvalidWarmerConcurrencyLimit indicates that the configured limit is negative.
+var ErrInvalidWarmerConcurrencyLimit = errors.New("warmer concurrency limit cannot be negative")
+
 // New returns a new Options references with Default Values set
 func New() *Options {
 	return &Options{
-		ListenPort:             DefaultPort,
-		ListenAddress:          DefaultAddress,
-		ConfigHandlerPath:      DefaultConfigHandlerPath,
-		ConfigHandlerListener:  DefaultConfigHandlerListenerName,
-		PingHandlerPath:        DefaultPingHandlerPath,
-		HealthHandlerPath:      DefaultHealthHandlerPath,
-		PurgeByKeyHandlerPath:  DefaultPurgeByKeyHandlerPath,
-		PurgeByPathHandlerPath: DefaultPurgeByPathHandlerPath,
-		PprofListener:          DefaultPprofListenerName,
-		ReloadHandlerPath:      DefaultReloadHandlerPath,
-		ReloadDrainTimeout:     timeconv.Duration(DefaultDrainTimeout),
-		ReloadRateLimit:        timeconv.Duration(DefaultRateLimit),
+		ListenPort:               DefaultPort,
+		ListenAddress:            DefaultAddress,
+		ConfigHandlerPath:        DefaultConfigHandlerPath,
+		ConfigHandlerListener:    DefaultConfigHandlerListenerName,
+		PingHandlerPath:          DefaultPingHandlerPath,
+		HealthHandlerPath:        DefaultHealthHandlerPath,
+		PurgeByKeyHandlerPath:    DefaultPurgeByKeyHandlerPath,
+		PurgeByPathHandlerPath:   DefaultPurgeByPathHandlerPath,
+		PurgeByTagHandlerPath:    DefaultPurgeByTagHandlerPath,
+		PurgeByExtentHandlerPath: DefaultPurgeByExtentHandlerPath,
+		CacheKeysHandlerPath:     DefaultCacheKeysHandlerPath,
+		CacheObjectHandlerPath:   DefaultCacheObjectHandlerPath,
+		WarmHandlerPath:          DefaultWarmHandlerPath,
+		WarmerConcurrencyLimit:   DefaultWarmerConcurrencyLimit,
+		PprofListener:            DefaultPprofListenerName,
+		ReloadHandlerPath:        DefaultReloadHandlerPath,
+		ReloadDrainTimeout:       timeconv.Duration(DefaultDrainTimeout),
+		ReloadRateLimit:          timeconv.Duration(DefaultRateLimit),
 	}
 }
 
@@ -95,6 +123,18 @@ func (o *Options) Validate() error {
 	if o.AutoReloadInterval < 0 {
 		return ErrInvalidAutoReloadInterval
 	}
+	if err := o.Peers.Validate(); err != nil {
+		return err
+	}
+	if o.WarmerConcurrencyLimit < 0 {
+		return ErrInvalidWarmerConcurrencyLimit
+	}
+	if o.WarmerConcurrencyLimit == 0 {
+		o.WarmerConcurrencyLimit = DefaultWarmerConcurrencyLimit
+	}
+	if o.WarmHandlerPath != "" && !strings.HasSuffix(o.WarmHandlerPath, "/") {
+		o.WarmHandlerPath += "/"
+	}
 
 	switch o.ConfigHandlerListener {
 	case ListenerNameMetrics, ListenerNameMgmt, ListenerNameOff, ListenerNameBoth:
@@ -115,5 +155,10 @@ func (o *Options) Validate() error {
 }
 
 func (o *Options) Clone() *Options {
-	return pointers.Clone(o)
+	if o == nil {
+		return nil
+	}
+	out := pointers.Clone(o)
+	out.Peers = o.Peers.Clone()
+	return out
 }
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	peers "github.com/trickstercache/trickster/v2/pkg/proxy/peers/options"
 
 	"go.yaml.in/yaml/v3"
 )
@@ -85,6 +86,27 @@ func TestValidatePprofListenerNames(t *testing.T) {
 	}
 }
 
+func TestValidateWarmerOptions(t *testing.T) {
+	c := New()
+	c.WarmerConcurrencyLimit = -1
+	if err := c.Validate(); !errors.Is(err, ErrInvalidWarmerConcurrencyLimit) {
+		t.Errorf("error = %v; want %v", err, ErrInvalidWarmerConcurrencyLimit)
+	}
+
+	c = New()
+	c.WarmerConcurrencyLimit = 0
+	c.WarmHandlerPath = "/warm"
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if c.WarmerConcurrencyLimit != DefaultWarmerConcurrencyLimit {
+		t.Errorf("limit = %d; want %d", c.WarmerConcurrencyLimit, DefaultWarmerConcurrencyLimit)
+	}
+	if c.WarmHandlerPath != "/warm/" {
+		t.Errorf("path = %s; want /warm/", c.WarmHandlerPath)
+	}
+}
+
 func TestReloadOptionsYAML(t *testing.T) {
 	o := New()
 	const yml = `reload_handler_path: /reload
@@ -127,4 +149,22 @@ func TestClone(t *testing.T) {
 	if o.ListenPort != 9999 {
 		t.Fatal("mutating clone should not affect original")
 	}
+	o.Peers = &peers.Options{Peers: []string{"trickster-1:8484"}}
+	clone = o.Clone()
+	clone.Peers.Peers[0] = "trickster-2:8484"
+	if o.Peers.Peers[0] != "trickster-1:8484" {
+		t.Fatal("mutating clone peers should not affect original")
+	}
+}
+
+func TestValidatePeers(t *testing.T) {
+	o := New()
+	o.Peers = &peers.Options{Peers: []string{"trickster-1:8484"}}
+	if err := o.Validate(); !errors.Is(err, peers.ErrNoPeerSecret) {
+		t.Errorf("error = %v; want %v", err, peers.ErrNoPeerSecret)
+	}
+	o.Peers.Secret = "s3cret"
+	if err := o.Validate(); err != nil {
+		t.Error(err)
+	}
 }
@@ -26,11 +26,13 @@ import (
 	rule "github.com/trickstercache/trickster/v2/pkg/backends/rule/options"
 	cache "github.com/trickstercache/trickster/v2/pkg/cache/options"
 	cp "github.com/trickstercache/trickster/v2/pkg/cache/providers"
+	warmer "github.com/trickstercache/trickster/v2/pkg/cache/warmer/options"
 	listenerconfig "github.com/trickstercache/trickster/v2/pkg/config/listener"
 	"github.com/trickstercache/trickster/v2/pkg/config/mgmt"
 	tracing "github.com/trickstercache/trickster/v2/pkg/observability/tracing/options"
 	tp "github.com/trickstercache/trickster/v2/pkg/observability/tracing/providers"
 	auth "github.com/trickstercache/trickster/v2/pkg/proxy/authenticator/options"
+	peers "github.com/trickstercache/trickster/v2/pkg/proxy/peers/options"
 	rwopts "github.com/trickstercache/trickster/v2/pkg/proxy/request/rewriter/options"
 )
 
@@ -65,7 +67,23 @@ func (c *Config) SanitizedClone() *Config {
 		newName := cacheNameMap[oldName]
 		if opts != nil {
 			opts.Name = newName
-			sanitizeRedisEndpoints(opts)
+			sanitizeCacheEndpoints(opts)
+			if opts.Tiered != nil {
+				for i, t := range opts.Tiered.Tiers {
+					if newTierName, ok := cacheNameMap[t]; ok {
+						opts.Tiered.Tiers[i] = newTierName
+					}
+				}
+			}
+			if opts.Peer != nil {
+				if newLocalName, ok := cacheNameMap[opts.Peer.Local]; ok {
+					opts.Peer.Local = newLocalName
+				}
+				if newHotName, ok := cacheNameMap[opts.Peer.HotCache]; ok {
+					opts.Peer.HotCache = newHotName
+				}
+				sanitizePeers(opts.Peer.Peers)
+			}
 		}
 		renamedCaches[newName] = opts
 	}
@@ -137,10 +155,18 @@ func (c *Config) SanitizedClone() *Config {
 
 	sanitizeRequestRewriters(cp.RequestRewriters)
 
+	if cp.MgmtConfig != nil {
+		sanitizePeers(cp.MgmtConfig.Peers)
+	}
+
 	for _, opts := range cp.Rules {
 		sanitizeRuleReferences(opts, backendNameMap)
 	}
 
+	for _, opts := range cp.Warmers {
+		sanitizeWarmer(opts, backendNameMap)
+	}
+
 	return cp
 }
 
@@ -273,18 +299,52 @@ func anonymizedTracingProviderName(provider string) string {
 	return provider
 }
 
-func sanitizeRedisEndpoints(opts *cache.Options) {
-	if opts == nil || opts.Redis == nil {
+func sanitizeCacheEndpoints(opts *cache.Options) {
+	if opts == nil {
 		return
 	}
-	if opts.Redis.Endpoint != "" {
-		opts.Redis.Endpoint = sanitizedEndpoint
+	if opts.Redis != nil {
+		if opts.Redis.Endpoint != "" {
+			opts.Redis.Endpoint = sanitizedEndpoint
+		}
+		for i, endpoint := range opts.Redis.Endpoints {
+			if endpoint != "" {
+				opts.Redis.Endpoints[i] = sanitizedEndpoint
+			}
+		}
+	}
+	if opts.Memcached != nil {
+		for i, server := range opts.Memcached.Servers {
+			if server != "" {
+				opts.Memcached.Servers[i] = sanitizedEndpoint
+			}
+		}
+	}
+	if opts.S3 != nil {
+		if opts.S3.Endpoint != "" {
+			opts.S3.Endpoint = sanitizedEndpoint
+		}
+	}
+}
+
+func sanitizePeers(opts *peers.Options) {
+	if opts == nil {
+		return
 	}
-	for i, endpoint := range opts.Redis.Endpoints {
-		if endpoint != "" {
-			opts.Redis.Endpoints[i] = sanitizedEndpoint
+	for i, peer := range opts.Peers {
+		if peer != "" {
+			opts.Peers[i] = sanitizedEndpoint
 		}
 	}
+	if opts.DNSName != "" {
+		opts.DNSName = sanitizedEndpoint
+	}
+	if opts.Self != "" {
+		opts.Self = sanitizedEndpoint
+	}
+	if opts.Secret != "" {
+		opts.Secret = sanitizedSecret
+	}
 }
 
 func sanitizePathAuthenticatorReferences(opts *bo.Options, authNameMap map[string]string) {
@@ -340,6 +400,18 @@ func sanitizeRuleReferences(opts *rule.Options, backendNameMap map[string]string
 	}
 }
 
+func sanitizeWarmer(opts *warmer.Options, backendNameMap map[string]string) {
+	if opts == nil {
+		return
+	}
+	if newName, ok := backendNameMap[opts.BackendName]; ok {
+		opts.BackendName = newName
+	}
+	for k := range opts.Headers {
+		opts.Headers[k] = sanitizedSecret
+	}
+}
+
 func sanitizeAuthenticatorUsers(opts *auth.Options) {
 	userNames := sortedKeys(opts.Users)
 	users := make(map[string]string, len(opts.Users))
@@ -123,6 +123,13 @@ request_rewriters:
       - [header, set, Host, header.private.example]
       - [header, replace, host, old-header.private.example, new-header.private.example]
       - [header, set, X-Private-Host, should-remain.private.example]
+mgmt:
+  peers:
+    peers:
+      - http://peer-a.private.example:8484
+    dns_name: peers.private.example
+    self: http://peer-self.private.example:8484
+    secret: secret-peers
 `)
 	if err != nil {
 		t.Fatalf("Could not load configuration: %s", err.Error())
@@ -226,6 +233,10 @@ request_rewriters:
 		"private-org",
 		"private-env",
 		"private-ha-shard",
+		"peer-a.private.example",
+		"peers.private.example",
+		"peer-self.private.example",
+		"secret-peers",
 	} {
 		if strings.Contains(out, privateValue) {
 			t.Errorf("expected sanitized config not to contain %q; got:\n%s", privateValue, out)
@@ -268,6 +279,9 @@ request_rewriters:
 	if conf.Rules["route-rule"].CaseOptions[0].NextRoute != "prom-b" {
 		t.Errorf("expected original rule case backend reference to remain unchanged")
 	}
+	if conf.MgmtConfig.Peers.Secret != "secret-peers" {
+		t.Errorf("expected original peers secret to remain unchanged")
+	}
 }
 
 func TestSanitizedCloneEdgeCases(t *testing.T) {
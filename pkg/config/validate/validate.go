@@ -26,6 +26,7 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/backends/alb"
 	"github.com/trickstercache/trickster/v2/pkg/backends/rule"
 	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/providers"
 	"github.com/trickstercache/trickster/v2/pkg/config"
 	"github.com/trickstercache/trickster/v2/pkg/config/listener"
 	"github.com/trickstercache/trickster/v2/pkg/config/mgmt"
@@ -77,6 +78,9 @@ func Validate(c *config.Config) error {
 	if err := Backends(c); err != nil {
 		return err
 	}
+	if err := Warmers(c); err != nil {
+		return err
+	}
 	return Listeners(c)
 }
 
@@ -105,6 +109,15 @@ func Caches(c *config.Config) error {
 	if c == nil || len(c.Caches) == 0 {
 		return nil
 	}
+	// peer caches without their own peers use the peers from the mgmt config
+	if c.MgmtConfig != nil && c.MgmtConfig.Peers != nil {
+		for _, o := range c.Caches {
+			if o != nil && o.ProviderID == providers.PeerID && o.Peer != nil &&
+				o.Peer.Peers == nil {
+				o.Peer.Peers = c.MgmtConfig.Peers.Clone()
+			}
+		}
+	}
 	return c.Caches.Validate()
 }
 
@@ -127,6 +140,21 @@ func Authenticators(c *config.Config) error {
 	return c.Authenticators.Validate(ar.IsRegistered)
 }
 
+func Warmers(c *config.Config) error {
+	if c == nil || len(c.Warmers) == 0 {
+		return nil
+	}
+	if err := c.Warmers.Validate(); err != nil {
+		return err
+	}
+	for name, o := range c.Warmers {
+		if _, ok := c.Backends[o.BackendName]; !ok {
+			return fmt.Errorf("warmer %q references undefined backend %q", name, o.BackendName)
+		}
+	}
+	return nil
+}
+
 func Backends(c *config.Config) error {
 	if c == nil {
 		return errors.ErrNoValidBackends
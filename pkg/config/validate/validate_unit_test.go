@@ -24,6 +24,7 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
 	rule "github.com/trickstercache/trickster/v2/pkg/backends/rule/options"
 	co "github.com/trickstercache/trickster/v2/pkg/cache/options"
+	wo "github.com/trickstercache/trickster/v2/pkg/cache/warmer/options"
 	"github.com/trickstercache/trickster/v2/pkg/config"
 	"github.com/trickstercache/trickster/v2/pkg/errors"
 	lo "github.com/trickstercache/trickster/v2/pkg/observability/logging/options"
@@ -133,6 +134,36 @@ func TestRewritersRulesAndAuthenticators(t *testing.T) {
 	}
 }
 
+func TestWarmers(t *testing.T) {
+	t.Parallel()
+
+	if err := Warmers(nil); err != nil {
+		t.Fatalf("Warmers(nil) = %v", err)
+	}
+
+	c := config.NewConfig()
+	w := wo.New()
+	w.BackendName = "default"
+	w.Path = "/api/v1/query_range"
+	w.Params = map[string]string{"start": "{{now-6h}}", "end": "{{now}}"}
+	c.Warmers = wo.Lookup{"example": w}
+	if err := Warmers(c); err != nil {
+		t.Fatalf("Warmers(valid) = %v", err)
+	}
+
+	w.BackendName = "missing"
+	if err := Warmers(c); err == nil ||
+		!strings.Contains(err.Error(), `references undefined backend "missing"`) {
+		t.Fatalf("Warmers(undefined backend) = %v", err)
+	}
+
+	w.BackendName = "default"
+	w.Params["start"] = "{{later}}"
+	if err := Warmers(c); err == nil {
+		t.Fatal("expected invalid time token error")
+	}
+}
+
 func TestTracersRejectsInvalidProtocol(t *testing.T) {
 	t.Parallel()
 
@@ -127,6 +127,14 @@ func Start(ctx context.Context, args ...string) error {
 	if si.Listeners != nil {
 		si.Listeners.Shutdown(0)
 	}
+	// close the caches so that any providers persisting their state on
+	// close (e.g., memory cache snapshots) have the chance to do so
+	for k, c := range si.Caches {
+		if err := c.Close(); err != nil {
+			logger.Warn("error closing cache during shutdown",
+				logging.Pairs{"cache": k, "error": err.Error()})
+		}
+	}
 	return nil
 }
 
@@ -20,6 +20,7 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/backends"
 	"github.com/trickstercache/trickster/v2/pkg/backends/healthcheck"
 	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/warmer"
 	"github.com/trickstercache/trickster/v2/pkg/config"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/listener"
 )
@@ -28,6 +29,7 @@ type ServerInstance struct {
 	Config           *config.Config
 	Caches           cache.Lookup
 	HealthChecker    healthcheck.HealthChecker
+	Warmers          *warmer.Scheduler
 	Backends         backends.Backends
 	Listeners        *listener.Group
 	OnConfigReloaded func(*config.Config)
@@ -24,6 +24,9 @@ import (
 	"time"
 
 	"github.com/trickstercache/trickster/v2/pkg/backends"
+	pc "github.com/trickstercache/trickster/v2/pkg/cache/peer"
+	"github.com/trickstercache/trickster/v2/pkg/cache/providers"
+	"github.com/trickstercache/trickster/v2/pkg/cache/warmer"
 	"github.com/trickstercache/trickster/v2/pkg/config"
 	listenerconfig "github.com/trickstercache/trickster/v2/pkg/config/listener"
 	"github.com/trickstercache/trickster/v2/pkg/config/mgmt"
@@ -33,8 +36,12 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/observability/pprof"
 	"github.com/trickstercache/trickster/v2/pkg/observability/tracing"
 	ch "github.com/trickstercache/trickster/v2/pkg/proxy/handlers/trickster/config"
+	ih "github.com/trickstercache/trickster/v2/pkg/proxy/handlers/trickster/inspect"
+	peerh "github.com/trickstercache/trickster/v2/pkg/proxy/handlers/trickster/peer"
 	ph "github.com/trickstercache/trickster/v2/pkg/proxy/handlers/trickster/purge"
+	wh "github.com/trickstercache/trickster/v2/pkg/proxy/handlers/trickster/warm"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/listener"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/peers"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/router"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/router/lm"
 )
@@ -52,7 +59,7 @@ type desiredListener struct {
 func applyListenerConfigs(conf, oldConf *config.Config,
 	listenerRouters map[string]router.Router, reloadHandler http.Handler,
 	metricsRouter router.Router, tracers tracing.Tracers, backends backends.Backends,
-	errorFunc func(), lg *listener.Group,
+	warmers *warmer.Scheduler, errorFunc func(), lg *listener.Group,
 ) {
 	if conf == nil || len(conf.Listeners) == 0 {
 		return
@@ -72,8 +79,32 @@ func applyListenerConfigs(conf, oldConf *config.Config,
 	}
 	managementRouter.RegisterRoute(conf.MgmtConfig.ReloadHandlerPath, nil, nil,
 		false, reloadHandler)
+	pg := peers.New(conf.MgmtConfig.Peers)
+	if pg != nil {
+		// peers broadcast purges-by-key to the management listener
+		managementRouter.RegisterRoute(conf.MgmtConfig.PurgeByKeyHandlerPath, nil,
+			[]string{http.MethodDelete}, true,
+			http.HandlerFunc(ph.Broadcast(pg, ph.KeyHandler(conf.MgmtConfig.PurgeByKeyHandlerPath, backends))))
+	}
 	managementRouter.RegisterRoute(conf.MgmtConfig.PurgeByPathHandlerPath, nil, nil,
-		true, http.HandlerFunc(ph.PathHandler(conf.MgmtConfig.PurgeByPathHandlerPath, &backends)))
+		true, http.HandlerFunc(ph.Broadcast(pg, ph.PathHandler(conf.MgmtConfig.PurgeByPathHandlerPath, &backends))))
+	managementRouter.RegisterRoute(conf.MgmtConfig.PurgeByTagHandlerPath, nil, nil,
+		false, http.HandlerFunc(ph.Broadcast(pg, ph.TagHandler(&backends))))
+	managementRouter.RegisterRoute(conf.MgmtConfig.PurgeByExtentHandlerPath, nil, nil,
+		false, http.HandlerFunc(ph.Broadcast(pg, ph.ExtentHandler(&backends))))
+	if usesPeerCache(conf) {
+		managementRouter.RegisterRoute(pc.HandlerPath, nil, nil,
+			true, http.HandlerFunc(peerh.CacheHandler(pc.HandlerPath, &backends)))
+	}
+	managementRouter.RegisterRoute(conf.MgmtConfig.CacheKeysHandlerPath, nil, nil,
+		false, http.HandlerFunc(ih.KeysHandler(&backends)))
+	managementRouter.RegisterRoute(conf.MgmtConfig.CacheObjectHandlerPath, nil, nil,
+		false, http.HandlerFunc(ih.ObjectHandler(&backends)))
+	if conf.MgmtConfig.WarmHandlerPath != "" {
+		managementRouter.RegisterRoute(conf.MgmtConfig.WarmHandlerPath, nil,
+			[]string{http.MethodGet, http.MethodPost}, true,
+			http.HandlerFunc(wh.Handler(conf.MgmtConfig.WarmHandlerPath, warmers)))
+	}
 	if listenerEnabledOn(conf.MgmtConfig.PprofListener, mgmt.ListenerNameMgmt) {
 		pprof.RegisterRoutes(mgmt.ListenerNameMgmt, managementRouter)
 	}
@@ -186,6 +217,15 @@ func listenerNeedsRestart(old, current desiredListener) bool {
 		old.options.ReadHeaderTimeout != current.options.ReadHeaderTimeout
 }
 
+func usesPeerCache(conf *config.Config) bool {
+	for _, o := range conf.Caches {
+		if o != nil && o.ProviderID == providers.PeerID {
+			return true
+		}
+	}
+	return false
+}
+
 func registerConfigRoutes(conf *config.Config, r router.Router) {
 	r.RegisterRoute(conf.MgmtConfig.ConfigHandlerPath, nil, nil,
 		false, http.HandlerFunc(ch.HandlerFunc(conf)))
@@ -121,7 +121,7 @@ func TestApplyListenerConfigsReloadReconciliation(t *testing.T) {
 
 	firstRouter := markerRouter("first")
 	applyListenerConfigs(conf, nil, map[string]router.Router{"custom": firstRouter},
-		http.NotFoundHandler(), lm.NewRouter(), nil, nil, nil, group)
+		http.NotFoundHandler(), lm.NewRouter(), nil, nil, nil, nil, group)
 	key := listenerKey("custom", false)
 	waitForListener(t, group, key)
 	original := group.Get(key)
@@ -131,7 +131,7 @@ func TestApplyListenerConfigsReloadReconciliation(t *testing.T) {
 	secondConf := conf.Clone()
 	secondRouter := markerRouter("second")
 	applyListenerConfigs(secondConf, conf, map[string]router.Router{"custom": secondRouter},
-		http.NotFoundHandler(), lm.NewRouter(), nil, nil, nil, group)
+		http.NotFoundHandler(), lm.NewRouter(), nil, nil, nil, nil, group)
 	if group.Get(key) != original {
 		t.Errorf("unchanged listener socket was restarted")
 	}
@@ -141,7 +141,7 @@ func TestApplyListenerConfigsReloadReconciliation(t *testing.T) {
 	thirdConf := secondConf.Clone()
 	thirdConf.Listeners["custom"].ListenPort = secondPort
 	applyListenerConfigs(thirdConf, secondConf, map[string]router.Router{"custom": secondRouter},
-		http.NotFoundHandler(), lm.NewRouter(), nil, nil, nil, group)
+		http.NotFoundHandler(), lm.NewRouter(), nil, nil, nil, nil, group)
 	waitForListener(t, group, key)
 	if group.Get(key) == original {
 		t.Errorf("changed listener port did not restart the socket")
@@ -204,10 +204,10 @@ func TestApplyListenerConfigsNoListeners(t *testing.T) {
 	group := listener.NewGroup()
 	t.Cleanup(func() { _ = group.Shutdown(0) })
 	// nil and empty configs are no-ops
-	applyListenerConfigs(nil, nil, nil, nil, nil, nil, nil, nil, group)
+	applyListenerConfigs(nil, nil, nil, nil, nil, nil, nil, nil, nil, group)
 	conf := config.NewConfig()
 	conf.Listeners = nil
-	applyListenerConfigs(conf, nil, nil, nil, nil, nil, nil, nil, group)
+	applyListenerConfigs(conf, nil, nil, nil, nil, nil, nil, nil, nil, group)
 }
 
 // TestApplyListenerConfigsManagementRoutes covers the config-handler and pprof
@@ -223,7 +223,7 @@ func TestApplyListenerConfigsManagementRoutes(t *testing.T) {
 
 	metricsRouter := lm.NewRouter()
 	applyListenerConfigs(conf, nil, nil, http.NotFoundHandler(), metricsRouter,
-		nil, nil, nil, group)
+		nil, nil, nil, nil, group)
 
 	for _, path := range []string{"/metrics", conf.MgmtConfig.ConfigHandlerPath} {
 		r := httptest.NewRequest(http.MethodGet, path, nil)
@@ -252,7 +252,7 @@ func TestApplyListenerConfigsTLS(t *testing.T) {
 
 	routers := map[string]router.Router{listenerconfig.DefaultFrontendName: markerRouter("tls")}
 	applyListenerConfigs(conf, nil, routers, http.NotFoundHandler(), lm.NewRouter(),
-		nil, nil, nil, group)
+		nil, nil, nil, nil, group)
 	waitForListener(t, group, key)
 	l := group.Get(key)
 	if l == nil {
@@ -264,7 +264,7 @@ func TestApplyListenerConfigsTLS(t *testing.T) {
 	second := conf.Clone()
 	second.Listeners[listenerconfig.DefaultFrontendName].ServeTLS = true
 	applyListenerConfigs(second, conf, routers, http.NotFoundHandler(), lm.NewRouter(),
-		nil, nil, nil, group)
+		nil, nil, nil, nil, group)
 	if group.Get(key) != l {
 		t.Error("an unchanged TLS listener should not be restarted")
 	}
@@ -290,7 +290,7 @@ func TestApplyListenerConfigsTLSCertError(t *testing.T) {
 
 	// an unloadable key pair is logged and the listener is skipped
 	applyListenerConfigs(conf, nil, routers, http.NotFoundHandler(), lm.NewRouter(),
-		nil, nil, nil, group)
+		nil, nil, nil, nil, group)
 	if group.Get(key) != nil {
 		t.Error("a listener with unloadable certificates should not start")
 	}
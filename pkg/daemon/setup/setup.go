@@ -34,6 +34,7 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/cache/manager"
 	"github.com/trickstercache/trickster/v2/pkg/cache/providers"
 	"github.com/trickstercache/trickster/v2/pkg/cache/registry"
+	"github.com/trickstercache/trickster/v2/pkg/cache/warmer"
 	"github.com/trickstercache/trickster/v2/pkg/config"
 	"github.com/trickstercache/trickster/v2/pkg/config/mgmt"
 	dr "github.com/trickstercache/trickster/v2/pkg/config/reload"
@@ -50,6 +51,7 @@ import (
 	ph "github.com/trickstercache/trickster/v2/pkg/proxy/handlers/trickster/purge"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/handlers/trickster/reload"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/listener"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/peers"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/router"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/router/lm"
 	"github.com/trickstercache/trickster/v2/pkg/routing"
@@ -197,10 +199,11 @@ func ApplyConfig(si *instance.ServerInstance, newConf *config.Config,
 	if !strings.HasSuffix(newConf.MgmtConfig.PurgeByKeyHandlerPath, "/") {
 		newConf.MgmtConfig.PurgeByKeyHandlerPath += "/"
 	}
+	pg := peers.New(newConf.MgmtConfig.Peers)
 	for _, r := range listenerRouters {
 		r.RegisterRoute(newConf.MgmtConfig.PurgeByKeyHandlerPath, nil,
 			[]string{http.MethodDelete}, true,
-			http.HandlerFunc(ph.KeyHandler(newConf.MgmtConfig.PurgeByKeyHandlerPath, clients)))
+			http.HandlerFunc(ph.Broadcast(pg, ph.KeyHandler(newConf.MgmtConfig.PurgeByKeyHandlerPath, clients))))
 	}
 
 	if si.Backends != nil {
@@ -222,7 +225,14 @@ func ApplyConfig(si *instance.ServerInstance, newConf *config.Config,
 	alb.StartALBPools(clients, si.HealthChecker.Statuses())
 	routing.RegisterDefaultBackendRoutesForListeners(listenerRouters, newConf, clients, tracers)
 	routing.RegisterHealthHandler(mr, newConf.MgmtConfig.HealthHandlerPath, si.HealthChecker, clients)
-	applyListenerConfigs(newConf, si.Config, listenerRouters, rh, mr, tracers, clients, errorFunc, lg)
+	warmers := warmer.New(newConf.Warmers, clients, newConf.MgmtConfig.WarmerConcurrencyLimit)
+	applyListenerConfigs(newConf, si.Config, listenerRouters, rh, mr, tracers, clients, warmers,
+		errorFunc, lg)
+
+	// the previous generation's warmers run until the new configuration is applied
+	si.Warmers.Stop()
+	warmers.Start()
+	si.Warmers = warmers
 
 	metrics.LastReloadSuccessfulTimestamp.Set(float64(time.Now().Unix()))
 	metrics.LastReloadSuccessful.Set(1)
@@ -291,13 +301,24 @@ func applyCachingConfig(si *instance.ServerInstance,
 	caches := make(cache.Lookup)
 
 	if si.Config == nil || si.Caches == nil {
-		for k, v := range newConf.Caches {
-			caches[k] = registry.NewCache(k, v)
+		for _, k := range newConf.Caches.TieredLast() {
+			caches[k] = registry.NewCacheFromLookup(k, newConf.Caches[k], caches)
 		}
 		return caches
 	}
 
-	for k, v := range newConf.Caches {
+	for _, k := range newConf.Caches.TieredLast() {
+		v := newConf.Caches[k]
+		// tiered and peer caches hold no state of their own, and are always
+		// rebuilt so that they reference the current generation of the caches
+		// they are composed of, and peer caches rebalance onto the current peers
+		if v.ProviderID == providers.TieredID || v.ProviderID == providers.PeerID {
+			if w, ok := si.Caches[k]; ok {
+				closeOldCache(k, w, time.Duration(newConf.MgmtConfig.ReloadDrainTimeout))
+			}
+			caches[k] = registry.NewCacheFromLookup(k, v, caches)
+			continue
+		}
 		if w, ok := si.Caches[k]; ok {
 			ocfg := w.Configuration()
 
@@ -334,7 +355,7 @@ func applyCachingConfig(si *instance.ServerInstance,
 		}
 
 		// the newly-named cache is not in the old config or couldn't be reused, so make it anew
-		caches[k] = registry.NewCache(k, v)
+		caches[k] = registry.NewCacheFromLookup(k, v, caches)
 	}
 
 	// close caches that existed in the old config but are absent from the new
@@ -36,6 +36,7 @@ const (
 	albSubsystem      = "alb"
 	healthSubsystem   = "healthcheck"
 	sqlSubsystem      = "sql"
+	warmerSubsystem   = "warmer"
 )
 
 // Default histogram buckets used by trickster
@@ -262,6 +263,61 @@ var (
 		[]string{"cache_name", "provider"},
 	)
 
+	// CachePartitionObjects is a Gauge representing the number of objects in a quota partition of a Trickster cache
+	CachePartitionObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Subsystem: cacheSubsystem,
+			Name:      "partition_usage_objects",
+			Help:      "Number of objects in a quota partition of a Trickster cache.",
+		},
+		[]string{"cache_name", "provider", "partition"},
+	)
+
+	// CachePartitionBytes is a Gauge representing the number of bytes in a quota partition of a Trickster cache
+	CachePartitionBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Subsystem: cacheSubsystem,
+			Name:      "partition_usage_bytes",
+			Help:      "Number of bytes in a quota partition of a Trickster cache.",
+		},
+		[]string{"cache_name", "provider", "partition"},
+	)
+
+	// CacheTierLookups is a Counter of lookups performed against each tier of a Tiered cache
+	CacheTierLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Subsystem: cacheSubsystem,
+			Name:      "tier_lookups_total",
+			Help:      "Count of lookups performed against each tier of a Trickster tiered cache, by lookup status.",
+		},
+		[]string{"cache_name", "tier", "status"},
+	)
+
+	// CachePeerRequests is a Counter of requests made by a Peer cache to the peers owning its cache keys
+	CachePeerRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Subsystem: cacheSubsystem,
+			Name:      "peer_requests_total",
+			Help:      "Count of requests made by a Trickster peer cache to the peers owning its cache keys, by operation and result.",
+		},
+		[]string{"cache_name", "operation", "result"},
+	)
+
+	// CachePeerMembers is a Gauge of the number of instances among which a Peer cache shards its cache keys
+	CachePeerMembers = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Subsystem: cacheSubsystem,
+			Name:      "peer_members",
+			Help:      "Number of Trickster instances among which a peer cache shards its cache keys.",
+		},
+		[]string{"cache_name"},
+	)
+
 	// ProxyMaxConnections is a Gauge representing the max number of active concurrent connections in the server
 	ProxyMaxConnections = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -333,6 +389,53 @@ var (
 		[]string{"backend_name"},
 	)
 
+	// ProxyAdmissionSkips is a Counter of upstream responses that were not cached
+	// because they did not satisfy the cache admission rules
+	ProxyAdmissionSkips = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Subsystem: proxySubsystem,
+			Name:      "admission_skips_total",
+			Help:      "Count of upstream responses not cached due to the cache admission rules.",
+		},
+		[]string{"backend_name", "provider", "reason"},
+	)
+
+	// ProxyForwardingLeases is a Counter of cache misses coordinated with the other
+	// Trickster instances sharing a cache through Distributed Collapsed Forwarding
+	ProxyForwardingLeases = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Subsystem: proxySubsystem,
+			Name:      "forwarding_leases_total",
+			Help:      "Count of cache misses coordinated across Trickster instances with distributed collapsed forwarding leases, by result.",
+		},
+		[]string{"backend_name", "provider", "result"},
+	)
+
+	// WarmerRuns is a Counter of Cache Warmer runs, by result
+	WarmerRuns = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Subsystem: warmerSubsystem,
+			Name:      "runs_total",
+			Help:      "Count of cache warmer runs, by result.",
+		},
+		[]string{"warmer", "backend_name", "result"},
+	)
+
+	// WarmerRunDuration is a Histogram of the time taken by Cache Warmer runs, in seconds
+	WarmerRunDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricNamespace,
+			Subsystem: warmerSubsystem,
+			Name:      "run_duration_seconds",
+			Help:      "Duration of cache warmer runs, in seconds.",
+			Buckets:   defaultBuckets,
+		},
+		[]string{"warmer", "backend_name"},
+	)
+
 	// SQLQueryAnalysis counts SQL analyzer classifications using bounded mode,
 	// dialect, and reason labels. Parse failures and OPC fallback are represented
 	// by the invalid_sql reason and object cache mode respectively.
@@ -588,6 +691,11 @@ func init() {
 	prometheus.MustRegister(CacheBytes)
 	prometheus.MustRegister(CacheMaxObjects)
 	prometheus.MustRegister(CacheMaxBytes)
+	prometheus.MustRegister(CachePartitionObjects)
+	prometheus.MustRegister(CachePartitionBytes)
+	prometheus.MustRegister(CacheTierLookups)
+	prometheus.MustRegister(CachePeerRequests)
+	prometheus.MustRegister(CachePeerMembers)
 	prometheus.MustRegister(BuildInfo)
 	prometheus.MustRegister(LastReloadSuccessful)
 	prometheus.MustRegister(LastReloadSuccessfulTimestamp)
@@ -596,6 +704,10 @@ func init() {
 	prometheus.MustRegister(ReloadFailuresTotal)
 	prometheus.MustRegister(ReloadDurationSeconds)
 	prometheus.MustRegister(ProxyQueryRangeRejections)
+	prometheus.MustRegister(ProxyAdmissionSkips)
+	prometheus.MustRegister(ProxyForwardingLeases)
+	prometheus.MustRegister(WarmerRuns)
+	prometheus.MustRegister(WarmerRunDuration)
 	prometheus.MustRegister(SQLQueryAnalysis)
 	prometheus.MustRegister(SQLQueryRewriteFailures)
 }
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlanalyzer
+
+import "time"
+
+// Cadence is the step and phase of a query's time buckets. A bucket starts
+// at every instant whose distance from Phase is a multiple of Step.
+type Cadence struct {
+	Step  time.Duration
+	Phase time.Duration
+}
+
+// Aligned reports whether the value is the start of a bucket.
+func (c Cadence) Aligned(value time.Time) bool {
+	if c.Step <= 0 {
+		return false
+	}
+	return (value.UnixNano()-c.Phase.Nanoseconds())%c.Step.Nanoseconds() == 0
+}
+
+// Floor returns the start of the bucket that includes the value.
+func (c Cadence) Floor(value time.Time) time.Time {
+	if c.Step <= 0 {
+		return value
+	}
+	step := c.Step.Nanoseconds()
+	offset := (value.UnixNano() - c.Phase.Nanoseconds()) % step
+	if offset < 0 {
+		offset += step
+	}
+	return value.Add(-time.Duration(offset))
+}
+
+// Ceil returns the start of the first bucket at or after the value.
+func (c Cadence) Ceil(value time.Time) time.Time {
+	floor := c.Floor(value)
+	if floor.Equal(value) {
+		return floor
+	}
+	return floor.Add(c.Step)
+}
+
+// InvertComparator returns the comparator that keeps a predicate's meaning
+// when its operands are swapped, so a bound written as X < col reads as
+// col > X. Other operators are returned unchanged.
+func InvertComparator(op string) string {
+	switch op {
+	case ">":
+		return "<"
+	case ">=":
+		return "<="
+	case "<":
+		return ">"
+	case "<=":
+		return ">="
+	}
+	return op
+}
+
+// NormalizeLower returns the first bucket included by a lower bound. A bound
+// on the raw time column must be inclusive and aligned; a bound on the bucket
+// output may use either comparator. ok is false when the bound is unsafe.
+func NormalizeLower(b Bound, onBucket bool, c Cadence) (Bound, bool) {
+	if !onBucket {
+		if !b.Inclusive || !c.Aligned(b.Value) {
+			return Bound{}, false
+		}
+		return Bound{Value: b.Value, Inclusive: true}, true
+	}
+	first := c.Ceil(b.Value)
+	if !b.Inclusive && first.Equal(b.Value) {
+		first = first.Add(c.Step)
+	}
+	return Bound{Value: first, Inclusive: true}, true
+}
+
+// NormalizeUpper returns the upper bound of a range. A bound on the raw time
+// column is returned as an aligned exclusive bound; an inclusive raw bound is
+// accepted only when precision is positive and the bound is one precision
+// unit before a bucket boundary. A bound on the bucket output is returned as
+// the last included bucket. ok is false when the bound is unsafe.
+func NormalizeUpper(b Bound, onBucket bool, c Cadence,
+	precision time.Duration) (Bound, bool) {
+	if !onBucket {
+		value := b.Value
+		if b.Inclusive {
+			if precision <= 0 {
+				return Bound{}, false
+			}
+			value = value.Add(precision)
+		}
+		if !c.Aligned(value) {
+			return Bound{}, false
+		}
+		return Bound{Value: value, Inclusive: false}, true
+	}
+	last := c.Floor(b.Value)
+	if !b.Inclusive && last.Equal(b.Value) {
+		last = last.Add(-c.Step)
+	}
+	return Bound{Value: last, Inclusive: true}, true
+}
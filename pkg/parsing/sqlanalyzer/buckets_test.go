@@ -0,0 +1,116 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlanalyzer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCadence(t *testing.T) {
+	c := Cadence{Step: time.Minute, Phase: 15 * time.Second}
+	tests := []struct {
+		value   int64
+		aligned bool
+		floor   int64
+		ceil    int64
+	}{
+		{75, true, 75, 75},
+		{100, false, 75, 135},
+		{15, true, 15, 15},
+		{-30, false, -45, 15},
+	}
+	for _, test := range tests {
+		value := time.Unix(test.value, 0)
+		if got := c.Aligned(value); got != test.aligned {
+			t.Errorf("Aligned(%d) = %t, want %t", test.value, got, test.aligned)
+		}
+		if got := c.Floor(value).Unix(); got != test.floor {
+			t.Errorf("Floor(%d) = %d, want %d", test.value, got, test.floor)
+		}
+		if got := c.Ceil(value).Unix(); got != test.ceil {
+			t.Errorf("Ceil(%d) = %d, want %d", test.value, got, test.ceil)
+		}
+	}
+	if (Cadence{}).Aligned(time.Unix(0, 0)) {
+		t.Error("zero cadence reported an aligned value")
+	}
+}
+
+func TestInvertComparator(t *testing.T) {
+	for input, want := range map[string]string{
+		">": "<", ">=": "<=", "<": ">", "<=": ">=", "=": "=",
+	} {
+		if got := InvertComparator(input); got != want {
+			t.Errorf("InvertComparator(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestNormalizeBounds(t *testing.T) {
+	c := Cadence{Step: time.Minute}
+	at := func(seconds int64) time.Time { return time.Unix(seconds, 0) }
+	lower := []struct {
+		name     string
+		bound    Bound
+		onBucket bool
+		want     int64
+		ok       bool
+	}{
+		{"raw inclusive aligned", Bound{at(120), true}, false, 120, true},
+		{"raw exclusive", Bound{at(120), false}, false, 0, false},
+		{"raw unaligned", Bound{at(130), true}, false, 0, false},
+		{"bucket inclusive unaligned", Bound{at(130), true}, true, 180, true},
+		{"bucket exclusive aligned", Bound{at(120), false}, true, 180, true},
+		{"bucket exclusive unaligned", Bound{at(130), false}, true, 180, true},
+	}
+	for _, test := range lower {
+		got, ok := NormalizeLower(test.bound, test.onBucket, c)
+		if ok != test.ok || (ok && (got.Value.Unix() != test.want || !got.Inclusive)) {
+			t.Errorf("%s: NormalizeLower() = (%+v, %t), want (%d inclusive, %t)",
+				test.name, got, ok, test.want, test.ok)
+		}
+	}
+
+	upper := []struct {
+		name      string
+		bound     Bound
+		onBucket  bool
+		precision time.Duration
+		want      time.Time
+		inclusive bool
+		ok        bool
+	}{
+		{"raw exclusive aligned", Bound{at(240), false}, false, 0, at(240), false, true},
+		{"raw exclusive unaligned", Bound{at(250), false}, false, 0, time.Time{}, false, false},
+		{"raw inclusive without precision", Bound{at(240), true}, false, 0, time.Time{}, false, false},
+		{
+			"raw inclusive one precision unit early",
+			Bound{at(240).Add(-time.Microsecond), true}, false, time.Microsecond, at(240), false, true,
+		},
+		{"bucket inclusive unaligned", Bound{at(250), true}, true, 0, at(240), true, true},
+		{"bucket exclusive aligned", Bound{at(240), false}, true, 0, at(180), true, true},
+		{"bucket exclusive unaligned", Bound{at(250), false}, true, 0, at(240), true, true},
+	}
+	for _, test := range upper {
+		got, ok := NormalizeUpper(test.bound, test.onBucket, c, test.precision)
+		if ok != test.ok || (ok && (!got.Value.Equal(test.want) || got.Inclusive != test.inclusive)) {
+			t.Errorf("%s: NormalizeUpper() = (%+v, %t), want (%s, %t, %t)",
+				test.name, got, ok, test.want, test.inclusive, test.ok)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlanalyzer
+
+import (
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/level"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
+	"github.com/trickstercache/trickster/v2/pkg/observability/metrics"
+)
+
+// ObserveAnalysis records the cache eligibility of an analyzed statement in
+// the SQL query analysis metric and the debug log.
+func ObserveAnalysis(backendName, dialect string, analysis Analysis) {
+	reason := string(analysis.Reason)
+	if reason == "" {
+		reason = "unknown"
+	}
+	mode := analysis.Mode.String()
+	metrics.SQLQueryAnalysis.WithLabelValues(backendName, dialect, mode, reason).Inc()
+	if logger.Level() == level.Debug {
+		logger.Debug("sql query cache eligibility analyzed", logging.Pairs{
+			"backend_name": backendName,
+			"dialect":      dialect,
+			"cache_mode":   mode,
+			"reason":       reason,
+		})
+	}
+}
+
+// ObserveRewriteFailure records a failure to rewrite a statement for an
+// origin extent in the SQL query rewrite failure metric and the error log.
+func ObserveRewriteFailure(backendName, dialect, reason string) {
+	metrics.SQLQueryRewriteFailures.WithLabelValues(backendName, dialect, reason).Inc()
+	logger.Error("sql query extent rewrite failed", logging.Pairs{
+		"backend_name": backendName,
+		"dialect":      dialect,
+		"reason":       reason,
+	})
+}
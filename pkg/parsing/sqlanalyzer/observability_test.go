@@ -0,0 +1,70 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlanalyzer
+
+import (
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
+	"github.com/trickstercache/trickster/v2/pkg/observability/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObserveAnalysis(t *testing.T) {
+	tests := []struct {
+		name     string
+		analysis Analysis
+		mode     string
+		reason   string
+	}{
+		{
+			name:     "delta eligible",
+			analysis: Analysis{Mode: CacheModeDelta, Reason: ReasonDeltaCacheable},
+			mode:     "delta", reason: string(ReasonDeltaCacheable),
+		},
+		{
+			name:     "missing reason",
+			analysis: Analysis{Mode: CacheModeObject},
+			mode:     "object", reason: "unknown",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			counter := metrics.SQLQueryAnalysis.WithLabelValues("test", "test", test.mode, test.reason)
+			before := testutil.ToFloat64(counter)
+			ObserveAnalysis("test", "test", test.analysis)
+			if got := testutil.ToFloat64(counter); got != before+1 {
+				t.Errorf("analysis counter = %f, want %f", got, before+1)
+			}
+		})
+	}
+}
+
+func TestObserveRewriteFailure(t *testing.T) {
+	originalLogger := logger.Logger()
+	logger.SetLogger(logging.NoopLogger())
+	defer logger.SetLogger(originalLogger)
+
+	counter := metrics.SQLQueryRewriteFailures.WithLabelValues("test", "test", "render_error")
+	before := testutil.ToFloat64(counter)
+	ObserveRewriteFailure("test", "test", "render_error")
+	if got := testutil.ToFloat64(counter); got != before+1 {
+		t.Errorf("rewrite-failure counter = %f, want %f", got, before+1)
+	}
+}
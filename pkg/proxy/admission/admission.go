@@ -0,0 +1,85 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package admission decides whether responses are admitted to the cache.
+package admission
+
+import (
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/admission/options"
+)
+
+// Reasons that a response is not admitted to the cache
+const (
+	ReasonMinRequests = "min_requests"
+	ReasonMinLatency  = "min_latency"
+	ReasonMinSize     = "min_size"
+	ReasonUserAgent   = "user_agent"
+)
+
+// Candidate describes a response that is eligible to be cached
+type Candidate struct {
+	// Key is the cache key of the response
+	Key string
+	// UserAgent is the User-Agent of the request
+	UserAgent string
+	// Latency is the time the upstream took to respond
+	Latency time.Duration
+	// Size is the size of the response body in bytes, or -1 when it is unknown
+	Size int64
+}
+
+// Record records a request for the cache key, for use by the min_requests rule
+func Record(o *options.Options, key string) {
+	if o == nil || o.MinRequests <= 0 {
+		return
+	}
+	if w := o.Requests(); w != nil {
+		w.Increment(key)
+	}
+}
+
+// Admit returns true if the candidate satisfies the admission rules, and
+// otherwise returns false and the reason the candidate was not admitted
+func Admit(o *options.Options, c Candidate) (bool, string) {
+	if o == nil {
+		return true, ""
+	}
+	for _, re := range o.DenyUserAgentPatterns {
+		if re.MatchString(c.UserAgent) {
+			return false, ReasonUserAgent
+		}
+	}
+	if o.MinRequests > 0 {
+		if w := o.Requests(); w != nil && w.Estimate(c.Key) < o.MinRequests {
+			return false, ReasonMinRequests
+		}
+	}
+	if o.MinLatency > 0 && c.Latency < time.Duration(o.MinLatency) {
+		return false, ReasonMinLatency
+	}
+	if !AdmitSize(o, c.Size) {
+		return false, ReasonMinSize
+	}
+	return true, ""
+}
+
+// AdmitSize returns true if a response body of the provided size satisfies
+// the min_size_bytes rule. A size of -1 (unknown) is admitted.
+func AdmitSize(o *options.Options, size int64) bool {
+	return o == nil || o.MinSizeBytes <= 0 || size < 0 || size >= o.MinSizeBytes
+}
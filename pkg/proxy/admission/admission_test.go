@@ -0,0 +1,72 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admission
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/admission/options"
+)
+
+func TestAdmit(t *testing.T) {
+	o := options.New()
+	o.MinRequests = 2
+	o.MinLatency = timeconv.Duration(100 * time.Millisecond)
+	o.MinSizeBytes = 64
+	o.DenyUserAgents = []string{"^curl/"}
+	if err := o.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	c := Candidate{Key: "key", UserAgent: "Grafana/11.0",
+		Latency: 200 * time.Millisecond, Size: 128}
+	Record(o, c.Key)
+	if ok, reason := Admit(o, c); ok || reason != ReasonMinRequests {
+		t.Errorf("Admit() = %t, %q, want false, %q", ok, reason, ReasonMinRequests)
+	}
+	Record(o, c.Key)
+
+	tests := []struct {
+		name   string
+		modify func(*Candidate)
+		want   string
+	}{
+		{name: "admitted", modify: func(*Candidate) {}},
+		{name: "unknown size", modify: func(c *Candidate) { c.Size = -1 }},
+		{name: "user agent", modify: func(c *Candidate) { c.UserAgent = "curl/8.0" },
+			want: ReasonUserAgent},
+		{name: "latency", modify: func(c *Candidate) { c.Latency = time.Millisecond },
+			want: ReasonMinLatency},
+		{name: "size", modify: func(c *Candidate) { c.Size = 10 }, want: ReasonMinSize},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cc := c
+			tc.modify(&cc)
+			ok, reason := Admit(o, cc)
+			if ok != (tc.want == "") || reason != tc.want {
+				t.Errorf("Admit() = %t, %q, want %q", ok, reason, tc.want)
+			}
+		})
+	}
+
+	if ok, _ := Admit(nil, c); !ok {
+		t.Error("expected nil options to admit")
+	}
+}
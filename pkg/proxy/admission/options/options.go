@@ -0,0 +1,137 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package options defines configurable rules for admitting responses to the cache.
+package options
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	"github.com/trickstercache/trickster/v2/pkg/util/pointers"
+	"github.com/trickstercache/trickster/v2/pkg/util/sketch"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// DefaultWindow is the default period in which requests are counted for MinRequests
+const DefaultWindow = 5 * time.Minute
+
+var (
+	// ErrInvalidAdmissionValue is returned when an admission rule is negative
+	ErrInvalidAdmissionValue = errors.New("admission values must be greater than or equal to 0")
+	// ErrInvalidUserAgentPattern is returned when a deny_user_agents entry is not a valid regular expression
+	ErrInvalidUserAgentPattern = errors.New("invalid deny_user_agents pattern")
+)
+
+// Options defines the rules a response must satisfy to be admitted to the cache.
+// Responses that do not satisfy the rules are served but not cached.
+type Options struct {
+	// MinRequests is the number of times a cache key must be requested within
+	// the Window before its response is cached
+	MinRequests int `yaml:"min_requests,omitempty"`
+	// Window is the period in which requests are counted for MinRequests
+	Window timeconv.Duration `yaml:"window,omitempty"`
+	// MinLatency is the minimum time the upstream must take to respond for the
+	// response to be cached
+	MinLatency timeconv.Duration `yaml:"min_latency,omitempty"`
+	// MinSizeBytes is the minimum size of a response body for it to be cached
+	MinSizeBytes int64 `yaml:"min_size_bytes,omitempty"`
+	// DenyUserAgents is a list of regular expressions matching the User-Agents
+	// whose responses are never cached
+	DenyUserAgents []string `yaml:"deny_user_agents,omitempty"`
+
+	//  Synthetic Values
+
+	// DenyUserAgentPatterns are the compiled DenyUserAgents, populated by Validate
+	DenyUserAgentPatterns []*regexp.Regexp `yaml:"-"`
+
+	requests *sketch.Window
+}
+
+// New returns a new Admission Options Reference with default values set
+func New() *Options {
+	return &Options{Window: timeconv.Duration(DefaultWindow)}
+}
+
+// Clone returns an exact copy of the Options. The clone shares the request
+// counts of the subject Options.
+func (o *Options) Clone() *Options {
+	if o == nil {
+		return nil
+	}
+	out := pointers.Clone(o)
+	out.DenyUserAgents = slices.Clone(o.DenyUserAgents)
+	out.DenyUserAgentPatterns = slices.Clone(o.DenyUserAgentPatterns)
+	return out
+}
+
+// Equal returns true if all values in the Options are identical
+func (o *Options) Equal(o2 *Options) bool {
+	if o == nil || o2 == nil {
+		return o == o2
+	}
+	return o.MinRequests == o2.MinRequests &&
+		o.Window == o2.Window &&
+		o.MinLatency == o2.MinLatency &&
+		o.MinSizeBytes == o2.MinSizeBytes &&
+		slices.Equal(o.DenyUserAgents, o2.DenyUserAgents)
+}
+
+// Validate returns an error if the Options are invalid, and otherwise compiles
+// DenyUserAgents into DenyUserAgentPatterns
+func (o *Options) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.MinRequests < 0 || o.Window < 0 || o.MinLatency < 0 || o.MinSizeBytes < 0 {
+		return ErrInvalidAdmissionValue
+	}
+	patterns := make([]*regexp.Regexp, 0, len(o.DenyUserAgents))
+	for _, p := range o.DenyUserAgents {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidUserAgentPattern, p)
+		}
+		patterns = append(patterns, re)
+	}
+	o.DenyUserAgentPatterns = patterns
+	if o.MinRequests > 0 && o.requests == nil {
+		o.requests = sketch.NewWindow(sketch.DefaultWidth, time.Duration(o.Window))
+	}
+	return nil
+}
+
+// Requests returns the counts of requests by cache key in the current window,
+// which is nil when MinRequests is not set or the Options have not been validated
+func (o *Options) Requests() *sketch.Window {
+	return o.requests
+}
+
+// UnmarshalYAML applies defaults before decoding an admission configuration block.
+func (o *Options) UnmarshalYAML(value *yaml.Node) error {
+	type loadOptions Options
+	lo := loadOptions(*(New()))
+	if err := value.Decode(&lo); err != nil {
+		return err
+	}
+	*o = Options(lo)
+	return nil
+}
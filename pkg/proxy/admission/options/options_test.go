@@ -0,0 +1,94 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestOptionsYAML(t *testing.T) {
+	const conf = `
+min_requests: 3
+min_latency: 250ms
+min_size_bytes: 1024
+deny_user_agents:
+  - '^curl/'
+`
+	o := &Options{}
+	if err := yaml.Unmarshal([]byte(conf), o); err != nil {
+		t.Fatal(err)
+	}
+	if o.Window != timeconv.Duration(DefaultWindow) {
+		t.Errorf("Window = %v, want %v", o.Window, DefaultWindow)
+	}
+	if o.MinRequests != 3 || o.MinLatency != timeconv.Duration(250*time.Millisecond) ||
+		o.MinSizeBytes != 1024 || len(o.DenyUserAgents) != 1 {
+		t.Errorf("unexpected options: %+v", o)
+	}
+	if o.Requests() != nil {
+		t.Error("expected nil request counts before Validate")
+	}
+	if err := o.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if o.Requests() == nil || len(o.DenyUserAgentPatterns) != 1 {
+		t.Error("expected Validate to populate the synthetic values")
+	}
+	o2 := o.Clone()
+	if !o.Equal(o2) {
+		t.Error("expected clone to be equal")
+	}
+	if o2.Requests() != o.Requests() {
+		t.Error("expected clone to share request counts")
+	}
+	o2.DenyUserAgents[0] = "^wget/"
+	if o.DenyUserAgents[0] != "^curl/" || o.Equal(o2) {
+		t.Error("clone mutated subject deny_user_agents")
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *Options
+		wantErr error
+	}{
+		{name: "nil"},
+		{name: "default", options: New()},
+		{name: "negative min_requests", options: &Options{MinRequests: -1},
+			wantErr: ErrInvalidAdmissionValue},
+		{name: "negative window", options: &Options{Window: -1},
+			wantErr: ErrInvalidAdmissionValue},
+		{name: "negative min_size_bytes", options: &Options{MinSizeBytes: -1},
+			wantErr: ErrInvalidAdmissionValue},
+		{name: "invalid pattern", options: &Options{DenyUserAgents: []string{"("}},
+			wantErr: ErrInvalidUserAgentPattern},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.options.Validate(); !errors.Is(err, tc.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
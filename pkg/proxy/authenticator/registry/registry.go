@@ -59,7 +59,9 @@ func NewObserverFromProviderName(backendProvider string, data map[string]any) (t
 	var a types.Authenticator
 	var err error
 	switch backendProvider {
-	case providers.Prometheus, providers.ReverseProxy, providers.Proxy,
+	case providers.Prometheus, providers.Loki, providers.Graphite,
+		providers.MySQL, providers.Postgres,
+		providers.ReverseProxy, providers.Proxy,
 		providers.ReverseProxyCache, providers.ReverseProxyCacheShort,
 		providers.ReverseProxyShort:
 		a, err = basic.New(data)
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	"github.com/trickstercache/trickster/v2/pkg/observability/metrics"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/admission"
+	admo "github.com/trickstercache/trickster/v2/pkg/proxy/admission/options"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+)
+
+// admissionOptions returns the cache admission rules for the request. The path
+// configuration takes precedence over the backend configuration.
+func admissionOptions(rsc *request.Resources) *admo.Options {
+	if rsc == nil {
+		return nil
+	}
+	if pc := rsc.PathConfig; pc != nil && pc.Admission != nil {
+		return pc.Admission
+	}
+	if o := rsc.BackendOptions; o != nil {
+		return o.Admission
+	}
+	return nil
+}
+
+// recordAdmissionSkip increments the admission skip metric for the request
+func recordAdmissionSkip(rsc *request.Resources, reason string) {
+	if rsc == nil || rsc.BackendOptions == nil {
+		return
+	}
+	o := rsc.BackendOptions
+	metrics.ProxyAdmissionSkips.WithLabelValues(o.Name, o.Provider, reason).Inc()
+}
+
+// checkAdmission applies the cache admission rules to an upstream response
+// that would be written to the cache as a new object, and disables the write
+// if the response is not admitted
+func (pr *proxyRequest) checkAdmission() {
+	if !pr.writeToCache || pr.cacheStatus != status.LookupStatusKeyMiss ||
+		pr.staleDocument != nil {
+		return
+	}
+	ao := admissionOptions(pr.rsc)
+	if ao == nil {
+		return
+	}
+	size := int64(-1)
+	if pr.upstreamResponse != nil {
+		size = pr.upstreamResponse.ContentLength
+	}
+	if ok, reason := admission.Admit(ao, admission.Candidate{
+		Key:       pr.primaryKey,
+		UserAgent: pr.UserAgent(),
+		Latency:   pr.upstreamLatency,
+		Size:      size,
+	}); !ok {
+		pr.skipAdmission(reason)
+	}
+}
+
+// checkAdmissionSize applies the min_size_bytes rule to a response body whose
+// size was unknown until it was read, and returns false if it is not admitted.
+// Since the response headers were already sent to the client, the request's
+// result status is left unchanged, so that it matches the client's response.
+func (pr *proxyRequest) checkAdmissionSize(size int) bool {
+	if pr.cacheStatus != status.LookupStatusKeyMiss || pr.staleDocument != nil {
+		return true
+	}
+	if admission.AdmitSize(admissionOptions(pr.rsc), int64(size)) {
+		return true
+	}
+	pr.writeToCache = false
+	recordAdmissionSkip(pr.rsc, admission.ReasonMinSize)
+	return false
+}
+
+func (pr *proxyRequest) skipAdmission(reason string) {
+	pr.writeToCache = false
+	pr.admissionSkipped = true
+	recordAdmissionSkip(pr.rsc, reason)
+}
+
+// resultStatus returns the lookup status to report for the request
+func (pr *proxyRequest) resultStatus() status.LookupStatus {
+	if pr.admissionSkipped {
+		return status.LookupStatusAdmissionSkip
+	}
+	return pr.cacheStatus
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	admo "github.com/trickstercache/trickster/v2/pkg/proxy/admission/options"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+func newTestAdmission(t *testing.T, f func(*admo.Options)) *admo.Options {
+	t.Helper()
+	ao := admo.New()
+	f(ao)
+	if err := ao.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return ao
+}
+
+func TestObjectProxyCacheAdmission(t *testing.T) {
+	skip := status.LookupStatusAdmissionSkip.String()
+
+	t.Run("min requests", func(t *testing.T) {
+		_, r, rsc := setupStaleTestHarness(t, "max-age=60")
+		rsc.BackendOptions.Admission = newTestAdmission(t, func(ao *admo.Options) {
+			ao.MinRequests = 2
+		})
+		expectOPC(t, r, http.StatusOK, "v1", skip)
+		expectOPC(t, r, http.StatusOK, "v2", "kmiss")
+		expectOPC(t, r, http.StatusOK, "v2", "hit")
+	})
+
+	t.Run("min size", func(t *testing.T) {
+		_, r, rsc := setupStaleTestHarness(t, "max-age=60")
+		rsc.BackendOptions.Admission = newTestAdmission(t, func(ao *admo.Options) {
+			ao.MinSizeBytes = 10
+		})
+		expectOPC(t, r, http.StatusOK, "v1", skip)
+		expectOPC(t, r, http.StatusOK, "v2", skip)
+	})
+
+	t.Run("min size of unknown length", func(t *testing.T) {
+		_, r, rsc := setupStaleTestHarness(t, "max-age=60")
+		rsc.BackendOptions.Admission = newTestAdmission(t, func(ao *admo.Options) {
+			ao.MinSizeBytes = 10
+		})
+		// the origin streams its response, so its size is only known once the
+		// headers have been sent, and the reported status remains a miss
+		var requests atomic.Int32
+		o := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set(headers.NameCacheControl, "max-age=60")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			w.Write([]byte("v" + strconv.Itoa(int(requests.Add(1)))))
+		}))
+		t.Cleanup(o.Close)
+		r.URL.Host = o.Listener.Addr().String()
+		expectOPC(t, r, http.StatusOK, "v1", "kmiss")
+		w := httptest.NewRecorder()
+		if _, s := fetchViaObjectProxyCache(w, r); s != status.LookupStatusKeyMiss {
+			t.Errorf("expected %s got %s", status.LookupStatusKeyMiss, s)
+		}
+		if err := testResultHeaderPartMatch(w.Header(), map[string]string{"status": "kmiss"}); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("deny user agent", func(t *testing.T) {
+		_, r, rsc := setupStaleTestHarness(t, "max-age=60")
+		rsc.BackendOptions.Admission = newTestAdmission(t, func(ao *admo.Options) {
+			ao.DenyUserAgents = []string{"^curl/"}
+		})
+		r.Header.Set("User-Agent", "curl/8.0")
+		expectOPC(t, r, http.StatusOK, "v1", skip)
+		r.Header.Set("User-Agent", "Grafana/11.0")
+		expectOPC(t, r, http.StatusOK, "v2", "kmiss")
+		r.Header.Set("User-Agent", "curl/8.0")
+		expectOPC(t, r, http.StatusOK, "v2", "hit")
+	})
+
+	t.Run("path overrides backend", func(t *testing.T) {
+		_, r, rsc := setupStaleTestHarness(t, "max-age=60")
+		rsc.BackendOptions.Admission = newTestAdmission(t, func(ao *admo.Options) {
+			ao.MinSizeBytes = 10
+		})
+		rsc.PathConfig.Admission = admo.New()
+		expectOPC(t, r, http.StatusOK, "v1", "kmiss")
+		expectOPC(t, r, http.StatusOK, "v1", "hit")
+	})
+}
+
+func TestDeltaProxyCacheAdmission(t *testing.T) {
+	ts, _, r, rsc, err := setupTestHarnessDPC()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeTestHarness(ts, r)
+
+	client := rsc.BackendClient.(*TestClient)
+	o := rsc.BackendOptions
+	o.FastForwardDisable = true
+	o.Admission = newTestAdmission(t, func(ao *admo.Options) {
+		ao.MinRequests = 2
+	})
+
+	step := 300 * time.Second
+	end := time.Now().Add(-12 * time.Hour)
+	extr := timeseries.Extent{Start: end.Add(-18 * time.Hour), End: end}
+	r.URL.Path = "/prometheus/api/v1/query_range"
+	r.URL.RawQuery = fmt.Sprintf("step=%d&start=%d&end=%d&query=%s",
+		int(step.Seconds()), extr.Start.Unix(), extr.End.Unix(), queryReturnsOKNoLatency)
+
+	for _, want := range []string{status.LookupStatusAdmissionSkip.String(), "kmiss", "hit"} {
+		w := httptest.NewRecorder()
+		client.QueryRangeHandler(w, r)
+		resp := w.Result()
+		if err := testStatusCodeMatch(resp.StatusCode, http.StatusOK); err != nil {
+			t.Error(err)
+		}
+		if err := testResultHeaderPartMatch(resp.Header, map[string]string{"status": want}); err != nil {
+			t.Error(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
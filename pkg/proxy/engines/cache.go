@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/index"
 	"github.com/trickstercache/trickster/v2/pkg/cache/status"
 	tspan "github.com/trickstercache/trickster/v2/pkg/observability/tracing/span"
 	tc "github.com/trickstercache/trickster/v2/pkg/proxy/context"
@@ -34,6 +35,7 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/proxy/ranges/byterange"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
 	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
 	"github.com/trickstercache/trickster/v2/pkg/util/sets"
 
 	"github.com/andybalholm/brotli"
@@ -43,8 +45,26 @@ import (
 
 const (
 	providerMemory = "memory"
+	providerTiered = "tiered"
 )
 
+// storesReferences returns true if the cache accepts HTTPDocuments by
+// reference via the cache.MemoryCache interface
+func storesReferences(c cache.Cache) bool {
+	p := c.Configuration().Provider
+	return p == providerMemory || p == providerTiered
+}
+
+// multiCache returns the cache as a cache.MultiCache when its provider stores
+// and retrieves multiple cache keys in a single batch
+func multiCache(c cache.Cache) (cache.MultiCache, bool) {
+	if storesReferences(c) {
+		return nil, false
+	}
+	mc, ok := c.(cache.MultiCache)
+	return mc, ok && mc.SupportsMulti()
+}
+
 type queryResult struct {
 	queryKey     string
 	d            *HTTPDocument
@@ -54,7 +74,7 @@ type queryResult struct {
 
 func queryConcurrent(_ context.Context, c cache.Cache, key string) *queryResult {
 	qr := &queryResult{queryKey: key, d: &HTTPDocument{}}
-	if c.Configuration().Provider == providerMemory {
+	if storesReferences(c) {
 		mc := c.(cache.MemoryCache)
 		var ifc any
 		ifc, qr.lookupStatus, qr.err = mc.RetrieveReference(key)
@@ -63,13 +83,14 @@ func queryConcurrent(_ context.Context, c cache.Cache, key string) *queryResult
 			return qr
 		}
 
-		if ifc == nil {
-			return qr
-		}
-		if d, ok := ifc.(*HTTPDocument); ok {
+		switch v := ifc.(type) {
+		case *HTTPDocument:
 			// memory cache returns a shared reference; copy so QueryCache
 			// can safely mutate fields like timeseries, isFulfillment, etc.
-			qr.d = d.ShallowCopy()
+			qr.d = v.ShallowCopy()
+		case []byte:
+			// tiered caches return serialized documents from non-memory tiers
+			qr.err = qr.d.unmarshalCacheBytes(v)
 		}
 	} else {
 		var b []byte
@@ -79,30 +100,49 @@ func queryConcurrent(_ context.Context, c cache.Cache, key string) *queryResult
 			(qr.lookupStatus != status.LookupStatusHit && qr.lookupStatus != status.LookupStatusProxyHit) {
 			return qr
 		}
+		qr.err = qr.d.unmarshalCacheBytes(b)
+	}
+	return qr
+}
 
-		var inflate bool
-		// check and remove compression bit
-		if len(b) > 0 {
-			if b[0] == 1 {
-				inflate = true
-			}
-			b = b[1:]
+// unmarshalCacheBytes populates the document from its cached byte
+// representation: a compression flag byte followed by the msgpack encoding
+func (d *HTTPDocument) unmarshalCacheBytes(b []byte) error {
+	var inflate bool
+	// check and remove compression bit
+	if len(b) > 0 {
+		if b[0] == 1 {
+			inflate = true
 		}
+		b = b[1:]
+	}
 
-		if inflate {
-			// tl.Debug(rsc.Logger, "decompressing cached data", tl.Pairs{"cacheKey": key})
-			decoder := brotli.NewReader(bytes.NewReader(b))
-			b, qr.err = io.ReadAll(decoder)
-			if qr.err != nil {
-				return qr
-			}
+	if inflate {
+		decoder := brotli.NewReader(bytes.NewReader(b))
+		var err error
+		b, err = io.ReadAll(decoder)
+		if err != nil {
+			return err
 		}
-		_, qr.err = qr.d.UnmarshalMsg(b)
-		if qr.err != nil {
-			return qr
+	}
+	_, err := d.UnmarshalMsg(b)
+	return err
+}
+
+// MarshalReference implements cache.ReferenceMarshaler, returning the
+// uncompressed byte representation of the document as read by QueryCache.
+// Timeseries chunks held by reference have no Body, so their timeseries is
+// marshaled into the Body of the returned representation.
+func (d *HTTPDocument) MarshalReference() ([]byte, error) {
+	if len(d.Body) == 0 && d.timeseries != nil {
+		b, err := dataset.MarshalDataSet(d.timeseries, nil, 0)
+		if err != nil {
+			return nil, err
 		}
+		d = d.ShallowCopy()
+		d.Body = b
 	}
-	return qr
+	return d.MarshalMsg([]byte{0})
 }
 
 // QueryCache queries the cache for an HTTPDocument and returns it
@@ -126,6 +166,11 @@ func QueryCache(ctx context.Context, c cache.Cache, key string,
 		tspan.SetAttributes(rsc.Tracer, span, attribute.String("cache.status", qr.lookupStatus.String()))
 		return qr.d, qr.lookupStatus, ranges, qr.err
 	}
+	// vary manifests are never chunked; the caller selects the variant to query
+	if qr.d.isVaryManifest() {
+		tspan.SetAttributes(rsc.Tracer, span, attribute.String("cache.status", qr.lookupStatus.String()))
+		return qr.d, qr.lookupStatus, ranges, nil
+	}
 	if unmarshal != nil {
 		qr.d.timeseries, _ = unmarshal(qr.d.Body, nil)
 	}
@@ -209,7 +254,7 @@ func writeConcurrent(_ context.Context, c cache.Cache, key string, d *HTTPDocume
 	var err error
 
 	// for memory cache, don't serialize the document, since we can retrieve it by reference.
-	if c.Configuration().Provider == providerMemory {
+	if storesReferences(c) {
 		mc := c.(cache.MemoryCache)
 
 		if d != nil {
@@ -227,29 +272,51 @@ func writeConcurrent(_ context.Context, c cache.Cache, key string, d *HTTPDocume
 	}
 
 	// for non-memory, we have to serialize the document to a byte slice to store
-	b, err = d.MarshalMsg(nil)
+	b, err = d.marshalCacheBytes(compress)
 	if err != nil {
 		return err
 	}
+	return c.Store(key, b, ttl)
+}
+
+// marshalCacheBytes returns the cached byte representation of the document:
+// a compression flag byte followed by the msgpack encoding
+func (d *HTTPDocument) marshalCacheBytes(compress bool) ([]byte, error) {
+	b, err := d.MarshalMsg(nil)
+	if err != nil {
+		return nil, err
+	}
 
 	// skip compression for small payloads where overhead exceeds benefit
 	if compress && len(b) >= 512 {
 		buf := bytes.NewBuffer([]byte{1})
 		encoder := brotli.NewWriter(buf)
 		if _, err = encoder.Write(b); err != nil {
-			return err
+			return nil, err
 		}
 		if err = encoder.Close(); err != nil {
-			return err
+			return nil, err
 		}
-		b = buf.Bytes()
-	} else {
-		buf := make([]byte, len(b)+1)
-		copy(buf[1:], b)
-		b = buf
+		return buf.Bytes(), nil
 	}
+	buf := make([]byte, len(b)+1)
+	copy(buf[1:], b)
+	return buf, nil
+}
 
-	return c.Store(key, b, ttl)
+// shouldCompress returns true if the document, having the provided
+// Content-Encoding, should be compressed when it is written to the cache
+func shouldCompress(d *HTTPDocument, ce string, compressTypes sets.Set[string]) bool {
+	if (ce != "" && ce != "identity") ||
+		(d.CachingPolicy != nil && d.CachingPolicy.NoTransform) {
+		return false
+	}
+	mt, _, err := mime.ParseMediaType(d.ContentType)
+	if err != nil {
+		return false
+	}
+	_, ok := compressTypes[mt]
+	return ok
 }
 
 // WriteCache writes an HTTPDocument to the cache
@@ -275,31 +342,22 @@ func WriteCache(ctx context.Context, c cache.Cache, key string, d *HTTPDocument,
 
 	var b []byte
 	var err error
-	var compress bool
-
-	if (ce == "" || ce == "identity") &&
-		(d.CachingPolicy == nil || !d.CachingPolicy.NoTransform) {
-		if mt, _, err := mime.ParseMediaType(d.ContentType); err == nil {
-			if _, ok := compressTypes[mt]; ok {
-				compress = true
-			}
-		}
-	}
+	compress := shouldCompress(d, ce, compressTypes)
 
 	opts := rsc.BackendOptions
+	var chunker ChunkWriter
 	if c.Configuration().UseCacheChunking {
 		rsc.Lock()
 		trq := rsc.TimeRangeQuery
 		rsc.Unlock()
 		if trq != nil {
 			// Use timeseries chunking
-			chunker := NewTimeseriesChunkWriter(c, key, trq, marshal)
-			err = executeChunking(ctx, c, key, d, compress, ttl, chunker, opts)
+			chunker = NewTimeseriesChunkWriter(c, key, trq, marshal)
 		} else {
 			// Use byterange chunking
-			chunker := NewByterangeChunkWriter(c, key, d)
-			err = executeChunking(ctx, c, key, d, compress, ttl, chunker, opts)
+			chunker = NewByterangeChunkWriter(c, key, d)
 		}
+		err = executeChunking(ctx, c, key, d, compress, ttl, chunker, opts)
 	} else {
 		if marshal != nil {
 			d.Body, err = marshal(d.timeseries, nil, 0)
@@ -321,6 +379,17 @@ func WriteCache(ctx context.Context, c cache.Cache, key string, d *HTTPDocument,
 		}
 		return err
 	}
+	if tg, ok := c.(cache.Tagger); ok && len(d.Tags) > 0 {
+		tg.Tag(key, ttl, d.Tags...)
+		// chunks are tagged with their object's key, so that they are
+		// purged along with it
+		if chunker != nil {
+			chunkTag := index.ChunkTag(key)
+			for _, subkey := range chunker.SubKeys() {
+				tg.Tag(subkey, ttl, chunkTag)
+			}
+		}
+	}
 	if span != nil {
 		span.AddEvent(
 			"Cache Write",
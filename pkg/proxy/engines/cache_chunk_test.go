@@ -25,7 +25,10 @@ import (
 	"testing"
 	"time"
 
+	cp "github.com/trickstercache/trickster/v2/pkg/cache/providers"
+	ro "github.com/trickstercache/trickster/v2/pkg/cache/redis/options"
 	cr "github.com/trickstercache/trickster/v2/pkg/cache/registry"
+	so "github.com/trickstercache/trickster/v2/pkg/cache/s3/options"
 	"github.com/trickstercache/trickster/v2/pkg/config"
 	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
 	tc "github.com/trickstercache/trickster/v2/pkg/proxy/context"
@@ -33,8 +36,11 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/proxy/ranges/byterange"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
 	tu "github.com/trickstercache/trickster/v2/pkg/testutil"
+	ts3 "github.com/trickstercache/trickster/v2/pkg/testutil/s3"
 	"github.com/trickstercache/trickster/v2/pkg/timeseries"
 	"github.com/trickstercache/trickster/v2/pkg/util/sets"
+
+	"github.com/alicebob/miniredis/v2"
 )
 
 func TestMultiPartByteRangeChunks(t *testing.T) {
@@ -609,3 +615,115 @@ func TestChunkQueryMissingKey(t *testing.T) {
 		t.Error("expected error for nonexistent key, got nil")
 	}
 }
+
+func TestCacheHitRangeRequestChunksS3(t *testing.T) {
+	logger.SetLogger(testLogger)
+	s := ts3.NewServer("trickster")
+	defer s.Close()
+	conf, err := config.Load([]string{"-origin-url", "http://1", "-provider", "test"})
+	if err != nil {
+		t.Fatalf("Could not load configuration: %s", err.Error())
+	}
+	cfg := conf.Caches["default"]
+	cfg.Provider = cp.S3
+	cfg.ProviderID = cp.S3ID
+	cfg.Index = nil
+	cfg.S3 = so.New()
+	cfg.S3.Endpoint = s.URL
+	cfg.S3.Bucket = "trickster"
+	cfg.S3.AccessKeyID = "test-key"
+	cfg.S3.SecretAccessKey = "test-secret"
+	cfg.S3.UsePathStyle = true
+	cfg.ByterangeChunkSize = 16
+	caches := cr.LoadCachesFromConfig(conf)
+	defer cr.CloseCaches(caches)
+	cache, ok := caches["default"]
+	if !ok {
+		t.Fatal("could not load cache")
+	}
+	cache.Configuration().UseCacheChunking = true
+
+	resp := &http.Response{}
+	resp.Header = make(http.Header)
+	resp.Header.Add(headers.NameContentLength, strconv.Itoa(len(testRangeBody)))
+	resp.StatusCode = 200
+	d := DocumentFromHTTPResponse(resp, []byte(testRangeBody), nil)
+	ctx := context.Background()
+	ctx = tc.WithResources(ctx, &request.Resources{BackendOptions: conf.Backends["default"], Tracer: tu.NewTestTracer()})
+
+	err = WriteCache(ctx, cache, "testKey", d, time.Duration(60)*time.Second, sets.New([]string{headers.ValueTextPlain}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the meta document and one object per 16-byte chunk
+	if n, expected := s.Len("trickster"), (len(testRangeBody)+15)/16+1; n != expected {
+		t.Errorf("expected %d objects got %d", expected, n)
+	}
+
+	ranges := byterange.Ranges{byterange.Range{Start: 10, End: 40}}
+	d2, _, deltas, err := QueryCache(ctx, cache, "testKey", ranges, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(d2.Body[10:41]) != testRangeBody[10:41] {
+		t.Errorf("expected %s got %s", testRangeBody[10:41], string(d2.Body[10:41]))
+	}
+	if len(deltas) > 0 {
+		t.Errorf("updated query range was expected to be empty: %v", deltas)
+	}
+}
+
+func TestCacheHitRangeRequestChunksRedis(t *testing.T) {
+	logger.SetLogger(testLogger)
+	s := miniredis.RunT(t)
+	conf, err := config.Load([]string{"-origin-url", "http://1", "-provider", "test"})
+	if err != nil {
+		t.Fatalf("Could not load configuration: %s", err.Error())
+	}
+	cfg := conf.Caches["default"]
+	cfg.Provider = cp.Redis
+	cfg.ProviderID = cp.RedisID
+	cfg.Index = nil
+	cfg.Redis = ro.New()
+	cfg.Redis.Endpoint = s.Addr()
+	cfg.ByterangeChunkSize = 16
+	caches := cr.LoadCachesFromConfig(conf)
+	defer cr.CloseCaches(caches)
+	cache, ok := caches["default"]
+	if !ok {
+		t.Fatal("could not load cache")
+	}
+	cache.Configuration().UseCacheChunking = true
+	if _, ok := multiCache(cache); !ok {
+		t.Fatal("expected redis cache to support batch operations")
+	}
+
+	resp := &http.Response{}
+	resp.Header = make(http.Header)
+	resp.Header.Add(headers.NameContentLength, strconv.Itoa(len(testRangeBody)))
+	resp.StatusCode = 200
+	d := DocumentFromHTTPResponse(resp, []byte(testRangeBody), nil)
+	ctx := context.Background()
+	ctx = tc.WithResources(ctx, &request.Resources{BackendOptions: conf.Backends["default"], Tracer: tu.NewTestTracer()})
+
+	err = WriteCache(ctx, cache, "testKey", d, time.Duration(60)*time.Second, sets.New([]string{headers.ValueTextPlain}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the meta document and one key per 16-byte chunk
+	if n, expected := len(s.Keys()), (len(testRangeBody)+15)/16+1; n != expected {
+		t.Errorf("expected %d keys got %d", expected, n)
+	}
+
+	ranges := byterange.Ranges{byterange.Range{Start: 10, End: 40}}
+	d2, _, deltas, err := QueryCache(ctx, cache, "testKey", ranges, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(d2.Body[10:41]) != testRangeBody[10:41] {
+		t.Errorf("expected %s got %s", testRangeBody[10:41], string(d2.Body[10:41]))
+	}
+	if len(deltas) > 0 {
+		t.Errorf("updated query range was expected to be empty: %v", deltas)
+	}
+}
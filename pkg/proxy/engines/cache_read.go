@@ -69,6 +69,11 @@ func executeChunkQuery(ctx context.Context, c cache.Cache, iterator ChunkQueryIt
 	}
 	eg.SetLimit(limit)
 
+	// retrieve all chunks in a single batch when the cache supports it
+	if mc, ok := multiCache(c); ok {
+		return executeMultiChunkQuery(&eg, c, mc, iterator, processor)
+	}
+
 	// Early cancellation context
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -128,6 +133,47 @@ func executeChunkQuery(ctx context.Context, c cache.Cache, iterator ChunkQueryIt
 	return processor.Finalize()
 }
 
+// executeMultiChunkQuery retrieves all chunks from the cache in a single batch,
+// and processes the chunks that were found concurrently
+func executeMultiChunkQuery(eg *errgroup.Group, c cache.Cache, mc cache.MultiCache,
+	iterator ChunkQueryIterator, processor ChunkQueryProcessor,
+) error {
+	var keys []string
+	iterator.IterateChunks(func(_ int, subkey string) bool {
+		keys = append(keys, subkey)
+		return true
+	})
+	res, err := mc.MultiRetrieve(keys...)
+	if err != nil {
+		logger.Error("chunk query failed",
+			logging.Pairs{"error": err, "chunks": len(keys)})
+		return err
+	}
+	for index, subkey := range keys {
+		b, ok := res[subkey]
+		if !ok {
+			continue
+		}
+		eg.Go(func() error {
+			qr := &queryResult{queryKey: subkey, d: &HTTPDocument{},
+				lookupStatus: status.LookupStatusHit}
+			if qr.err = qr.d.unmarshalCacheBytes(b); qr.err != nil {
+				logger.Error("chunk query failed",
+					logging.Pairs{
+						"error": qr.err, "chunkIdx": index,
+						"key": subkey, "cacheQueryStatus": qr.lookupStatus,
+					})
+				return qr.err
+			}
+			return processor.ProcessChunk(index, subkey, qr, c)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	return processor.Finalize()
+}
+
 // TimeseriesChunkQueryIterator implements ChunkQueryIterator for timeseries chunks (reading)
 type TimeseriesChunkQueryIterator struct {
 	key   string
@@ -161,7 +207,9 @@ type TimeseriesChunkQueryProcessor struct {
 }
 
 func (tcp *TimeseriesChunkQueryProcessor) ProcessChunk(index int, subkey string, qr *queryResult, c cache.Cache) error {
-	if c.Configuration().Provider != providerMemory {
+	// memory chunks are held by reference unless restored from a snapshot
+	if c.Configuration().Provider != providerMemory ||
+		(qr.d.timeseries == nil && len(qr.d.Body) > 0) {
 		var err error
 		qr.d.timeseries, err = tcp.unmarshal(qr.d.Body, nil)
 		if err != nil {
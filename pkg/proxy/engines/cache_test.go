@@ -28,9 +28,14 @@ import (
 	"testing"
 	"time"
 
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/manager"
 	co "github.com/trickstercache/trickster/v2/pkg/cache/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/providers"
 	cr "github.com/trickstercache/trickster/v2/pkg/cache/registry"
 	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	"github.com/trickstercache/trickster/v2/pkg/cache/tiered"
 	"github.com/trickstercache/trickster/v2/pkg/config"
 	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
 	tc "github.com/trickstercache/trickster/v2/pkg/proxy/context"
@@ -38,6 +43,8 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/proxy/ranges/byterange"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
 	tu "github.com/trickstercache/trickster/v2/pkg/testutil"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
 	"github.com/trickstercache/trickster/v2/pkg/util/sets"
 )
 
@@ -545,3 +552,77 @@ func TestWriteConcurrentCompressionThreshold(t *testing.T) {
 		}
 	})
 }
+
+func TestMarshalReference(t *testing.T) {
+	ext := timeseries.Extent{Start: time.Unix(0, 0), End: time.Unix(600, 0)}
+	d := &HTTPDocument{
+		StatusCode: 200,
+		IsChunk:    true,
+		timeseries: &dataset.DataSet{ExtentList: timeseries.ExtentList{ext}},
+	}
+	b, err := d.MarshalReference()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Body) != 0 {
+		t.Error("expected the referenced document to be unmodified")
+	}
+	d2 := &HTTPDocument{}
+	if err := d2.unmarshalCacheBytes(b); err != nil {
+		t.Fatal(err)
+	}
+	if !d2.IsChunk || d2.StatusCode != 200 {
+		t.Errorf("unexpected document %+v", d2)
+	}
+	ts, err := dataset.UnmarshalDataSet(d2.Body, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if el := ts.Extents(); len(el) != 1 || !el[0].Start.Equal(ext.Start) ||
+		!el[0].End.Equal(ext.End) {
+		t.Errorf("expected %s got %s", ext, el)
+	}
+}
+
+func TestQueryCacheTiered(t *testing.T) {
+	logger.SetLogger(testLogger)
+	const expected = "1234"
+
+	sc := newSpyCache()
+	cfg := co.New()
+	cfg.Name = "tiered"
+	cfg.Provider = providers.Tiered
+	cfg.ProviderID = providers.TieredID
+	cfg.Tiered.Tiers = []string{"l2"}
+	c := manager.NewCache(tiered.New(cfg.Name, cfg, cache.Lookup{"l2": sc}),
+		manager.CacheOptions{}, cfg)
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := &http.Response{Header: make(http.Header), StatusCode: 200}
+	d := DocumentFromHTTPResponse(resp, []byte(expected), nil)
+	d.ContentType = headers.ValueTextPlain
+
+	ctx := tc.WithResources(context.Background(),
+		&request.Resources{BackendOptions: bo.New(), Tracer: tu.NewTestTracer()})
+	err := WriteCache(ctx, c, "testKey", d, time.Minute, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the non-memory tier receives the serialized document
+	if b := sc.stored["testKey"]; len(b) == 0 || b[0] != 0 {
+		t.Fatal("expected serialized document in the non-memory tier")
+	}
+
+	d2, s, _, err := QueryCache(ctx, c, "testKey", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != status.LookupStatusHit {
+		t.Errorf("expected %s got %s", status.LookupStatusHit, s)
+	}
+	if string(d2.Body) != expected {
+		t.Errorf("expected %s got %s", expected, string(d2.Body))
+	}
+}
@@ -58,6 +58,9 @@ type ChunkWriter interface {
 
 	// GetMeta returns the metadata document to be stored separately.
 	GetMeta(d CacheableDocument) any
+
+	// SubKeys returns the cache keys of the chunks, in the order they are iterated.
+	SubKeys() []string
 }
 
 // TimeseriesChunkWriter handles timeseries chunking operations when writing to cache
@@ -86,7 +89,20 @@ func (tc *TimeseriesChunkWriter) ChunkCount() int {
 }
 
 func (tc *TimeseriesChunkWriter) GetMeta(d CacheableDocument) any {
-	return d.GetMeta()
+	meta := d.GetMeta()
+	meta.StepNS = tc.trq.Step.Nanoseconds()
+	return meta
+}
+
+func (tc *TimeseriesChunkWriter) SubKeys() []string {
+	out := make([]string, 0, tc.cct)
+	for chunkStart := tc.cext.Start; chunkStart.Before(tc.cext.End); chunkStart = chunkStart.Add(tc.csize) {
+		out = append(out, getSubKey(tc.key, timeseries.Extent{
+			Start: chunkStart,
+			End:   chunkStart.Add(tc.csize - tc.trq.Step),
+		}))
+	}
+	return out
 }
 
 func (tc *TimeseriesChunkWriter) IterateChunks(
@@ -152,6 +168,17 @@ func (bc *ByterangeChunkWriter) ChunkCount() int {
 	return int(bc.cct) + 1 // +1 for meta
 }
 
+func (bc *ByterangeChunkWriter) SubKeys() []string {
+	out := make([]string, 0, bc.cct)
+	for chunkStart := bc.crs; chunkStart < bc.cre; chunkStart += bc.size {
+		out = append(out, bc.key+byterange.Range{
+			Start: chunkStart,
+			End:   chunkStart + bc.size - 1,
+		}.String())
+	}
+	return out
+}
+
 func (bc *ByterangeChunkWriter) IterateChunks(
 	d CacheableDocument,
 	writeFunc func(int, string, any) error,
@@ -189,6 +216,11 @@ func executeChunking(ctx context.Context, c cache.Cache, key string, d Cacheable
 	}
 	eg.SetLimit(limit)
 
+	// write all chunks in a single batch when the cache supports it
+	if mc, ok := multiCache(c); ok {
+		return executeMultiChunking(&eg, mc, key, d, compress, ttl, chunker)
+	}
+
 	// 1. Iterate over chunks and start concurrent writes
 	err := chunker.IterateChunks(d, func(index int, subkey string, chunkData any) error {
 		// This is the core concurrent write logic
@@ -226,3 +258,46 @@ func executeChunking(ctx context.Context, c cache.Cache, key string, d Cacheable
 	}
 	return errors.Join(cr...)
 }
+
+// executeMultiChunking serializes the chunks and the metadata document
+// concurrently, and writes them to the cache in a single batch
+func executeMultiChunking(eg *errgroup.Group, mc cache.MultiCache, key string, d CacheableDocument,
+	compress bool, ttl time.Duration, chunker ChunkWriter,
+) error {
+	cct := chunker.ChunkCount()
+	keys := make([]string, cct)
+	values := make([][]byte, cct)
+	marshal := func(index int, subkey string, chunkData any) {
+		eg.Go(func() error {
+			httpDoc, ok := chunkData.(*HTTPDocument)
+			if !ok {
+				return errors.New("invalid chunk data type")
+			}
+			b, err := httpDoc.marshalCacheBytes(compress)
+			if err != nil {
+				return err
+			}
+			keys[index], values[index] = subkey, b
+			return nil
+		})
+	}
+
+	err := chunker.IterateChunks(d, func(index int, subkey string, chunkData any) error {
+		marshal(index, subkey, chunkData)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// The last index is reserved for the metadata document.
+	marshal(cct-1, key, chunker.GetMeta(d))
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	data := make(map[string][]byte, cct)
+	for i, k := range keys {
+		data[k] = values[i]
+	}
+	return mc.MultiStore(data, ttl)
+}
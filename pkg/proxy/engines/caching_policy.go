@@ -46,6 +46,10 @@ type CachingPolicy struct {
 	IfNoneMatchResult    bool `msg:"-"`
 
 	FreshnessLifetime int `msg:"freshness_lifetime"`
+	// StaleWhileRevalidate and StaleIfError are the number of seconds beyond the
+	// FreshnessLifetime that the object may be served stale, per RFC 5861
+	StaleWhileRevalidate int `msg:"stale_while_revalidate"`
+	StaleIfError         int `msg:"stale_if_error"`
 
 	LastModified time.Time `msg:"last_modified"`
 	Expires      time.Time `msg:"expires"`
@@ -94,6 +98,8 @@ func (cp *CachingPolicy) Merge(src *CachingPolicy) {
 
 	cp.IsFresh = src.IsFresh
 	cp.FreshnessLifetime = src.FreshnessLifetime
+	cp.StaleWhileRevalidate = src.StaleWhileRevalidate
+	cp.StaleIfError = src.StaleIfError
 	cp.CanRevalidate = src.CanRevalidate
 	cp.MustRevalidate = src.MustRevalidate
 	cp.LastModified = src.LastModified
@@ -112,12 +118,26 @@ func (cp *CachingPolicy) TTL(multiplier float64, maxDur time.Duration) time.Dura
 	if cp.CanRevalidate {
 		ttl *= time.Duration(multiplier)
 	}
+	// retain the object for as long as it may be served stale
+	if st := time.Duration(cp.FreshnessLifetime+
+		max(cp.StaleWhileRevalidate, cp.StaleIfError)) * time.Second; st > ttl {
+		ttl = st
+	}
 	if ttl > maxDur {
 		ttl = maxDur
 	}
 	return ttl
 }
 
+// CanServeStale returns true if the object, having exceeded its freshness
+// lifetime, is still within the provided stale window (in seconds)
+func (cp *CachingPolicy) CanServeStale(window int, now time.Time) bool {
+	if window <= 0 || cp.MustRevalidate || cp.IsNegativeCache || cp.NoCache {
+		return false
+	}
+	return !cp.LocalDate.Add(time.Duration(cp.FreshnessLifetime+window) * time.Second).Before(now)
+}
+
 func (cp *CachingPolicy) String() string {
 	return fmt.Sprintf(`{ "is_fresh":%t, "no_cache":%t, "no_transform":%t, 
 	"freshness_lifetime":%d, "can_revalidate":%t, "must_revalidate":%t,`+
@@ -289,6 +309,16 @@ func (cp *CachingPolicy) parseCacheControlDirectives(directives string) {
 		if d == headers.ValueNoTransform {
 			cp.NoTransform = true
 		}
+		if d == headers.ValueStaleWhileRevalidate && dsub != "" {
+			if secs, err := strconv.Atoi(dsub); err == nil && secs > 0 {
+				cp.StaleWhileRevalidate = secs
+			}
+		}
+		if d == headers.ValueStaleIfError && dsub != "" {
+			if secs, err := strconv.Atoi(dsub); err == nil && secs > 0 {
+				cp.StaleIfError = secs
+			}
+		}
 	}
 }
 
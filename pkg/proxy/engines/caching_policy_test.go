@@ -373,3 +373,53 @@ func TestParseCacheControlNoTransform(t *testing.T) {
 		t.Errorf("expected FreshnessLifetime=300, got %d", cp.FreshnessLifetime)
 	}
 }
+
+func TestParseCacheControlStale(t *testing.T) {
+	h := http.Header{
+		headers.NameCacheControl: []string{
+			"max-age=60, stale-while-revalidate=30, stale-if-error=600",
+		},
+	}
+	cp := GetResponseCachingPolicy(200, nil, h)
+	if cp.StaleWhileRevalidate != 30 {
+		t.Errorf("expected StaleWhileRevalidate=30, got %d", cp.StaleWhileRevalidate)
+	}
+	if cp.StaleIfError != 600 {
+		t.Errorf("expected StaleIfError=600, got %d", cp.StaleIfError)
+	}
+	// the object is retained for as long as it may be served stale
+	if ttl := cp.TTL(1, time.Hour); ttl != 660*time.Second {
+		t.Errorf("expected ttl %s got %s", 660*time.Second, ttl)
+	}
+	if ttl := cp.TTL(1, time.Minute); ttl != time.Minute {
+		t.Errorf("expected ttl %s got %s", time.Minute, ttl)
+	}
+}
+
+func TestCanServeStale(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		cp     *CachingPolicy
+		window int
+		want   bool
+	}{
+		{"within window", &CachingPolicy{LocalDate: now.Add(-90 * time.Second),
+			FreshnessLifetime: 60}, 60, true},
+		{"outside window", &CachingPolicy{LocalDate: now.Add(-180 * time.Second),
+			FreshnessLifetime: 60}, 60, false},
+		{"no window", &CachingPolicy{LocalDate: now.Add(-90 * time.Second),
+			FreshnessLifetime: 60}, 0, false},
+		{"must revalidate", &CachingPolicy{LocalDate: now.Add(-90 * time.Second),
+			FreshnessLifetime: 60, MustRevalidate: true}, 60, false},
+		{"negative cache", &CachingPolicy{LocalDate: now.Add(-90 * time.Second),
+			FreshnessLifetime: 60, IsNegativeCache: true}, 60, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cp.CanServeStale(tt.window, now); got != tt.want {
+				t.Errorf("expected %t got %t", tt.want, got)
+			}
+		})
+	}
+}
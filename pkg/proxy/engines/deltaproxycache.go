@@ -39,6 +39,7 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
 	"github.com/trickstercache/trickster/v2/pkg/observability/metrics"
 	tspan "github.com/trickstercache/trickster/v2/pkg/observability/tracing/span"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/admission"
 	tctx "github.com/trickstercache/trickster/v2/pkg/proxy/context"
 	tpe "github.com/trickstercache/trickster/v2/pkg/proxy/errors"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/handlers/trickster/failures"
@@ -282,6 +283,8 @@ func DeltaProxyCacheRequest(w http.ResponseWriter, r *http.Request, modeler *tim
 		return
 	}
 	key := ComposeCacheKey(o.Name, o.CacheKeyPrefix, "dpc", pr.DeriveCacheKey(""))
+	ao := admissionOptions(rsc)
+	admission.Record(ao, key)
 
 	coReq := GetRequestCachingPolicy(r.Header)
 
@@ -333,6 +336,15 @@ func DeltaProxyCacheRequest(w http.ResponseWriter, r *http.Request, modeler *tim
 			var severeFault bool
 
 			doc, cacheStatus, _, err = QueryCache(ctx, cache, key, nil, modeler.CacheUnmarshaler)
+			// on a miss, collapse the origin fetch with other Trickster instances
+			// sharing the cache, when Distributed Collapsed Forwarding is enabled
+			if cacheStatus == status.LookupStatusKeyMiss && errors.Is(err, tc.ErrKNF) {
+				release := awaitLease(ctx, rsc, cache, key, func() bool {
+					doc, cacheStatus, _, err = QueryCache(ctx, cache, key, nil, modeler.CacheUnmarshaler)
+					return cacheStatus != status.LookupStatusKeyMiss || !errors.Is(err, tc.ErrKNF)
+				})
+				defer release()
+			}
 			if cacheStatus == status.LookupStatusKeyMiss && errors.Is(err, tc.ErrKNF) {
 				cts, doc, elapsed, failedExts, severeFault = fetchTimeseries(pr, trq, client, modeler)
 				if len(failedExts) > 0 && severeFault {
@@ -482,12 +494,25 @@ func DeltaProxyCacheRequest(w http.ResponseWriter, r *http.Request, modeler *tim
 				default:
 					cts.CropToRange(timeseries.Extent{End: now, Start: OldestRetainedTimestamp})
 				}
+				// Don't cache new datasets that do not satisfy the admission rules
+				if cacheStatus == status.LookupStatusKeyMiss {
+					if ok, reason := admission.Admit(ao, admission.Candidate{
+						Key:       key,
+						UserAgent: r.UserAgent(),
+						Latency:   elapsed,
+						Size:      cts.Size(),
+					}); !ok {
+						cacheStatus = status.LookupStatusAdmissionSkip
+						recordAdmissionSkip(rsc, reason)
+					}
+				}
 				// Don't cache datasets with empty extents
 				// (everything was cropped so there is nothing to cache)
-				if len(cts.Extents()) > 0 {
+				if cacheStatus != status.LookupStatusAdmissionSkip && len(cts.Extents()) > 0 {
 					doc.timeseries = cts
+					doc.Tags = cacheTags(r, rsc, doc)
 					if werr := WriteCache(ctx, cache, key, doc, time.Duration(o.TimeseriesTTL),
-						o.CompressibleTypes, modeler.CacheMarshaler); werr != nil {
+						o.CompressibleTypes, modeler.CacheMarshalerFor(o.TimeseriesCacheEncoding)); werr != nil {
 						logger.Error("error writing object to cache",
 							logging.Pairs{
 								"backendName": o.Name,
@@ -501,6 +526,9 @@ func DeltaProxyCacheRequest(w http.ResponseWriter, r *http.Request, modeler *tim
 			}
 
 			uncachedValueCount := rts.ValueCount() - cts.ValueCount()
+			if cacheStatus == status.LookupStatusAdmissionSkip {
+				uncachedValueCount = rts.ValueCount()
+			}
 
 			ffStatus := fetchFastForward(ctx, r, o, cc, cache, client, rsc,
 				rlo, trq, normalizedNow, modeler, rts)
@@ -53,6 +53,17 @@ type HTTPDocument struct {
 	RangeParts byterange.MultipartByteRanges `msg:"-"`
 	// StoredRangeParts is a version of RangeParts that can be exported to MessagePack
 	StoredRangeParts map[string]*byterange.MultipartByteRange `msg:"range_parts"`
+	// Tags is the list of tags attached to this document for tag-based purging
+	Tags []string `msg:"tags"`
+	// StepNS is the step of the timeseries query that wrote a chunked timeseries object, in
+	// nanoseconds. It is set only in the object's meta document, so that its chunk keys
+	// can be derived without the originating query.
+	StepNS int64 `msg:"step"`
+	// Vary is the list of request header names in the origin's Vary response header. It is
+	// set only in an object's vary manifest, which is stored under the object's primary key
+	// and lists the secondary keys of the object's cached Variants, oldest first.
+	Vary     []string `msg:"vary"`
+	Variants []string `msg:"variants"`
 
 	rangePartsLoaded bool
 	isFulfillment    bool
@@ -72,6 +83,7 @@ func (d *HTTPDocument) GetMeta() *HTTPDocument {
 		ContentType:   d.ContentType,
 		Ranges:        d.Ranges.Clone(),
 		RangeParts:    nil,
+		Tags:          slices.Clone(d.Tags),
 	}
 	if d.CachingPolicy != nil {
 		dd.CachingPolicy = d.CachingPolicy.Clone()
@@ -161,6 +173,10 @@ func (d *HTTPDocument) ShallowCopy() *HTTPDocument {
 		Ranges:           d.Ranges,
 		RangeParts:       d.RangeParts,
 		StoredRangeParts: d.StoredRangeParts,
+		Tags:             d.Tags,
+		StepNS:           d.StepNS,
+		Vary:             d.Vary,
+		Variants:         d.Variants,
 		rangePartsLoaded: d.rangePartsLoaded,
 		isFulfillment:    d.isFulfillment,
 		isLoaded:         d.isLoaded,
@@ -188,6 +188,69 @@ func (z *HTTPDocument) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 				z.StoredRangeParts[za0004] = za0005
 			}
+		case "tags":
+			var zb0005 uint32
+			zb0005, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Tags")
+				return
+			}
+			if cap(z.Tags) >= int(zb0005) {
+				z.Tags = (z.Tags)[:zb0005]
+			} else {
+				z.Tags = make([]string, zb0005)
+			}
+			for za0006 := range z.Tags {
+				z.Tags[za0006], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Tags", za0006)
+					return
+				}
+			}
+		case "step":
+			z.StepNS, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "StepNS")
+				return
+			}
+		case "vary":
+			var zb0006 uint32
+			zb0006, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Vary")
+				return
+			}
+			if cap(z.Vary) >= int(zb0006) {
+				z.Vary = (z.Vary)[:zb0006]
+			} else {
+				z.Vary = make([]string, zb0006)
+			}
+			for za0007 := range z.Vary {
+				z.Vary[za0007], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Vary", za0007)
+					return
+				}
+			}
+		case "variants":
+			var zb0007 uint32
+			zb0007, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Variants")
+				return
+			}
+			if cap(z.Variants) >= int(zb0007) {
+				z.Variants = (z.Variants)[:zb0007]
+			} else {
+				z.Variants = make([]string, zb0007)
+			}
+			for za0008 := range z.Variants {
+				z.Variants[za0008], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Variants", za0008)
+					return
+				}
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -201,9 +264,9 @@ func (z *HTTPDocument) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *HTTPDocument) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 11
+	// map header, size 15
 	// write "is_meta"
-	err = en.Append(0x8b, 0xa7, 0x69, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x61)
+	err = en.Append(0x8f, 0xa7, 0x69, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x61)
 	if err != nil {
 		return
 	}
@@ -357,15 +420,76 @@ func (z *HTTPDocument) EncodeMsg(en *msgp.Writer) (err error) {
 			}
 		}
 	}
+	// write "tags"
+	err = en.Append(0xa4, 0x74, 0x61, 0x67, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Tags)))
+	if err != nil {
+		err = msgp.WrapError(err, "Tags")
+		return
+	}
+	for za0006 := range z.Tags {
+		err = en.WriteString(z.Tags[za0006])
+		if err != nil {
+			err = msgp.WrapError(err, "Tags", za0006)
+			return
+		}
+	}
+	// write "step"
+	err = en.Append(0xa4, 0x73, 0x74, 0x65, 0x70)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.StepNS)
+	if err != nil {
+		err = msgp.WrapError(err, "StepNS")
+		return
+	}
+	// write "vary"
+	err = en.Append(0xa4, 0x76, 0x61, 0x72, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Vary)))
+	if err != nil {
+		err = msgp.WrapError(err, "Vary")
+		return
+	}
+	for za0007 := range z.Vary {
+		err = en.WriteString(z.Vary[za0007])
+		if err != nil {
+			err = msgp.WrapError(err, "Vary", za0007)
+			return
+		}
+	}
+	// write "variants"
+	err = en.Append(0xa8, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Variants)))
+	if err != nil {
+		err = msgp.WrapError(err, "Variants")
+		return
+	}
+	for za0008 := range z.Variants {
+		err = en.WriteString(z.Variants[za0008])
+		if err != nil {
+			err = msgp.WrapError(err, "Variants", za0008)
+			return
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *HTTPDocument) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 11
+	// map header, size 15
 	// string "is_meta"
-	o = append(o, 0x8b, 0xa7, 0x69, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x61)
+	o = append(o, 0x8f, 0xa7, 0x69, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x61)
 	o = msgp.AppendBool(o, z.IsMeta)
 	// string "is_chunk"
 	o = append(o, 0xa8, 0x69, 0x73, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b)
@@ -428,6 +552,27 @@ func (z *HTTPDocument) MarshalMsg(b []byte) (o []byte, err error) {
 			}
 		}
 	}
+	// string "tags"
+	o = append(o, 0xa4, 0x74, 0x61, 0x67, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Tags)))
+	for za0006 := range z.Tags {
+		o = msgp.AppendString(o, z.Tags[za0006])
+	}
+	// string "step"
+	o = append(o, 0xa4, 0x73, 0x74, 0x65, 0x70)
+	o = msgp.AppendInt64(o, z.StepNS)
+	// string "vary"
+	o = append(o, 0xa4, 0x76, 0x61, 0x72, 0x79)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Vary)))
+	for za0007 := range z.Vary {
+		o = msgp.AppendString(o, z.Vary[za0007])
+	}
+	// string "variants"
+	o = append(o, 0xa8, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Variants)))
+	for za0008 := range z.Variants {
+		o = msgp.AppendString(o, z.Variants[za0008])
+	}
 	return
 }
 
@@ -594,6 +739,69 @@ func (z *HTTPDocument) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 				z.StoredRangeParts[za0004] = za0005
 			}
+		case "tags":
+			var zb0005 uint32
+			zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Tags")
+				return
+			}
+			if cap(z.Tags) >= int(zb0005) {
+				z.Tags = (z.Tags)[:zb0005]
+			} else {
+				z.Tags = make([]string, zb0005)
+			}
+			for za0006 := range z.Tags {
+				z.Tags[za0006], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Tags", za0006)
+					return
+				}
+			}
+		case "step":
+			z.StepNS, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "StepNS")
+				return
+			}
+		case "vary":
+			var zb0006 uint32
+			zb0006, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Vary")
+				return
+			}
+			if cap(z.Vary) >= int(zb0006) {
+				z.Vary = (z.Vary)[:zb0006]
+			} else {
+				z.Vary = make([]string, zb0006)
+			}
+			for za0007 := range z.Vary {
+				z.Vary[za0007], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Vary", za0007)
+					return
+				}
+			}
+		case "variants":
+			var zb0007 uint32
+			zb0007, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Variants")
+				return
+			}
+			if cap(z.Variants) >= int(zb0007) {
+				z.Variants = (z.Variants)[:zb0007]
+			} else {
+				z.Variants = make([]string, zb0007)
+			}
+			for za0008 := range z.Variants {
+				z.Variants[za0008], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Variants", za0008)
+					return
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -636,5 +844,17 @@ func (z *HTTPDocument) Msgsize() (s int) {
 			}
 		}
 	}
+	s += 5 + msgp.ArrayHeaderSize
+	for za0006 := range z.Tags {
+		s += msgp.StringPrefixSize + len(z.Tags[za0006])
+	}
+	s += 5 + msgp.Int64Size + 5 + msgp.ArrayHeaderSize
+	for za0007 := range z.Vary {
+		s += msgp.StringPrefixSize + len(z.Vary[za0007])
+	}
+	s += 9 + msgp.ArrayHeaderSize
+	for za0008 := range z.Variants {
+		s += msgp.StringPrefixSize + len(z.Variants[za0008])
+	}
 	return
 }
@@ -23,11 +23,13 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/trickstercache/trickster/v2/pkg/cache/negative"
 	"github.com/trickstercache/trickster/v2/pkg/cache/status"
 	"github.com/trickstercache/trickster/v2/pkg/encoding/profile"
 	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
@@ -230,8 +232,8 @@ func PrepareFetchReader(r *http.Request) (io.ReadCloser, *http.Response, int64)
 		r.Header.Set(headers.NameAcceptEncoding, ep.SupportedHeaderVal)
 	}
 
+	var body []byte
 	if r.Body != nil && r.GetBody == nil {
-		var body []byte
 		var err error
 		if o.MaxObjectSizeBytes > 0 {
 			body, err = request.GetBody(r, int64(o.MaxObjectSizeBytes))
@@ -262,8 +264,32 @@ func PrepareFetchReader(r *http.Request) (io.ReadCloser, *http.Response, int64)
 		}
 	}
 
+	// serve a cached transport failure, if the origin failed recently
+	nc := negativeCache(rsc)
+	if nc.HasFailures() {
+		f := transportFailures.Get(transportFailureKey(o.Name, r.URL, negative.FailureNone))
+		if f == negative.FailureNone {
+			f = transportFailures.Get(transportFailureKey(o.Name, r.URL, negative.FailureTimeout))
+		}
+		if f != negative.FailureNone {
+			logger.Debug("serving transport failure from negative cache",
+				logging.Pairs{"url": r.URL.String(), "backendName": o.Name,
+					"failure": f.String()})
+			rc, resp := transportFailureResponse(r, f)
+			if pc != nil {
+				headers.UpdateHeaders(resp.Header, pc.ResponseHeaders)
+			}
+			setHTTPStatusSpanAttributes(rsc.Tracer, resp.StatusCode, span, doSpan)
+			return rc, resp, resp.ContentLength
+		}
+	}
+
 	resp, err := o.HTTPClient.Do(r)
 	if err != nil {
+		f := negative.Classify(err)
+		if ttl, ok := nc.FailureTTL(f); ok {
+			transportFailures.Set(transportFailureKey(o.Name, r.URL, f), f, ttl)
+		}
 		if rsc == nil || !rsc.Cancelable || !errors.Is(err, context.Canceled) {
 			logger.Error("error downloading url",
 				logging.Pairs{"url": r.URL.String(), "detail": err.Error()})
@@ -364,6 +390,54 @@ func PrepareFetchReader(r *http.Request) (io.ReadCloser, *http.Response, int64)
 	return rc, resp, originalLen
 }
 
+// transportFailures holds the recent transport failures of upstream requests
+// whose negative cache includes transport failure TTLs
+var transportFailures = negative.NewFailureCache()
+
+// negativeCache returns the negative cache of the request's path, if it has
+// one, or else the negative cache of the request's backend
+func negativeCache(rsc *request.Resources) negative.Lookup {
+	if rsc == nil {
+		return nil
+	}
+	if rsc.PathConfig != nil && rsc.PathConfig.NegativeCache != nil {
+		return rsc.PathConfig.NegativeCache
+	}
+	if rsc.BackendOptions == nil {
+		return nil
+	}
+	return rsc.BackendOptions.NegativeCache
+}
+
+// transportFailureKey identifies the origin of an upstream request in the
+// transportFailures. Refused connections and TLS and DNS failures affect every
+// request to the origin, so they are keyed by the backend and origin. Timeouts
+// are also keyed by the request path, so that a slow endpoint does not fail
+// requests to the origin's other paths.
+func transportFailureKey(backendName string, u *url.URL, f negative.Failure) string {
+	k := backendName + "|" + u.Scheme + "://" + u.Host
+	if f == negative.FailureTimeout {
+		k += u.Path
+	}
+	return k
+}
+
+// transportFailureResponse synthesizes the response for a cached transport
+// failure. The response is marked no-store so it is not cached as an object.
+func transportFailureResponse(r *http.Request, f negative.Failure) (io.ReadCloser, *http.Response) {
+	b := f.Body()
+	resp := &http.Response{
+		StatusCode: f.StatusCode(),
+		Request:    r,
+		Header: http.Header{
+			headers.NameContentType:  []string{headers.ValueTextPlain},
+			headers.NameCacheControl: []string{headers.ValueNoStore},
+		},
+		ContentLength: int64(len(b)),
+	}
+	return io.NopCloser(bytes.NewReader(b)), resp
+}
+
 // Respond sends an HTTP Response down to the requesting client
 func Respond(w io.Writer, code int, header http.Header, body io.Reader) {
 	PrepareResponseWriter(w, code, header)
@@ -20,11 +20,15 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/trickstercache/trickster/v2/pkg/cache/negative"
 	"github.com/trickstercache/trickster/v2/pkg/config"
 	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
 	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
@@ -496,6 +500,92 @@ func TestPrepareFetchReaderErr(t *testing.T) {
 	}
 }
 
+func TestPrepareFetchReaderTransportFailureCache(t *testing.T) {
+	logger.SetLogger(testLogger)
+	conf, err := config.Load([]string{
+		"-origin-url", "http://example.com/",
+		"-provider", "test", "-log-level", "debug",
+	})
+	if err != nil {
+		t.Fatalf("Could not load configuration: %s", err.Error())
+	}
+
+	o := conf.Backends["default"]
+	mrt := &mockRoundTripper{err: &net.OpError{Op: "dial", Net: "tcp",
+		Err: syscall.ECONNREFUSED}}
+	o.HTTPClient = &http.Client{Transport: mrt}
+
+	fetch := func(pc *po.Options, query string) (*http.Response, string) {
+		r := httptest.NewRequest("GET", "http://example.com/transport-failure?"+query, nil)
+		r = r.WithContext(tc.WithResources(r.Context(),
+			request.NewResources(o, pc, nil, nil, nil, tu.NewTestTracer())))
+		rc, resp, _ := PrepareFetchReader(r)
+		var b []byte
+		if rc != nil {
+			b, _ = io.ReadAll(rc)
+		}
+		return resp, string(b)
+	}
+
+	// without transport failures in the negative cache, each request goes upstream
+	fetch(&po.Options{}, "")
+	fetch(&po.Options{}, "")
+	if len(mrt.reqs) != 2 {
+		t.Fatalf("expected 2 upstream requests got %d", len(mrt.reqs))
+	}
+
+	// the path negative cache takes precedence over the backend's
+	pc := &po.Options{NegativeCache: negative.Lookup{
+		int(negative.FailureConnectionRefused): time.Minute}}
+	resp, _ := fetch(pc, "q=1")
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected %d got %d", http.StatusBadGateway, resp.StatusCode)
+	}
+	// the failure applies to other requests to the same origin
+	resp, body := fetch(pc, "q=2")
+	if len(mrt.reqs) != 3 {
+		t.Errorf("expected 3 upstream requests got %d", len(mrt.reqs))
+	}
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected %d got %d", http.StatusBadGateway, resp.StatusCode)
+	}
+	if body != string(negative.FailureConnectionRefused.Body()) {
+		t.Errorf("unexpected body: %s", body)
+	}
+	if v := resp.Header.Get(headers.NameCacheControl); v != headers.ValueNoStore {
+		t.Errorf("expected %s got %s", headers.ValueNoStore, v)
+	}
+}
+
+func TestTransportFailureKey(t *testing.T) {
+	u := func(s string) *url.URL {
+		out, _ := url.Parse(s)
+		return out
+	}
+	a := transportFailureKey("test", u("http://example.com/a?q=1"), negative.FailureConnectionRefused)
+	if k := transportFailureKey("test", u("http://example.com/b?q=2"),
+		negative.FailureNone); k != a {
+		t.Errorf("expected %s got %s", a, k)
+	}
+	if k := transportFailureKey("other", u("http://example.com/a?q=1"),
+		negative.FailureConnectionRefused); k == a {
+		t.Error("expected different backends to have different keys")
+	}
+	if k := transportFailureKey("test", u("https://example.com/a?q=1"),
+		negative.FailureConnectionRefused); k == a {
+		t.Error("expected different origins to have different keys")
+	}
+	b := transportFailureKey("test", u("http://example.com/a?q=1"), negative.FailureTimeout)
+	if k := transportFailureKey("test", u("http://example.com/a?q=2"),
+		negative.FailureTimeout); k != b {
+		t.Errorf("expected %s got %s", b, k)
+	}
+	if k := transportFailureKey("test", u("http://example.com/b?q=1"),
+		negative.FailureTimeout); k == b {
+		t.Error("expected timeouts on different paths to have different keys")
+	}
+}
+
 type mockRoundTripper struct {
 	resp *http.Response
 	err  error
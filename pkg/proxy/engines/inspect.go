@@ -0,0 +1,141 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"context"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/index"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+// ObjectInfo describes a cached HTTPDocument, without its body
+type ObjectInfo struct {
+	Key           string              `json:"key" yaml:"key"`
+	IsMeta        bool                `json:"isMeta,omitempty" yaml:"isMeta,omitempty"`
+	IsChunk       bool                `json:"isChunk,omitempty" yaml:"isChunk,omitempty"`
+	StatusCode    int                 `json:"statusCode" yaml:"statusCode"`
+	Status        string              `json:"status,omitempty" yaml:"status,omitempty"`
+	ContentType   string              `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	ContentLength int64               `json:"contentLength" yaml:"contentLength"`
+	BodySize      int                 `json:"bodySize" yaml:"bodySize"`
+	Headers       map[string][]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Tags          []string            `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Ranges        []string            `json:"ranges,omitempty" yaml:"ranges,omitempty"`
+	Step          string              `json:"step,omitempty" yaml:"step,omitempty"`
+	Extents       []ExtentInfo        `json:"extents,omitempty" yaml:"extents,omitempty"`
+	Chunks        []string            `json:"chunks,omitempty" yaml:"chunks,omitempty"`
+}
+
+// ExtentInfo describes a cached time range of a timeseries object
+type ExtentInfo struct {
+	Start string `json:"start" yaml:"start"`
+	End   string `json:"end" yaml:"end"`
+}
+
+// InspectObject returns the metadata of the HTTPDocument cached under key.
+// The chunks of a chunked object are listed from the cache's index. When the
+// cache is not indexed, the chunks of a chunked timeseries object are instead
+// looked up for the extent e, if it is not zero. The cached extents of
+// timeseries objects, including those of their listed chunks, are decoded
+// with the modeler when they aren't held by reference.
+func InspectObject(ctx context.Context, c cache.Cache, modeler *timeseries.Modeler,
+	key string, e timeseries.Extent,
+) (*ObjectInfo, error) {
+	qr := queryConcurrent(ctx, c, key)
+	if qr.err != nil {
+		return nil, qr.err
+	}
+	d := qr.d
+	oi := &ObjectInfo{
+		Key:           key,
+		IsMeta:        d.IsMeta,
+		IsChunk:       d.IsChunk,
+		StatusCode:    d.StatusCode,
+		Status:        d.Status,
+		ContentType:   d.ContentType,
+		ContentLength: d.ContentLength,
+		BodySize:      len(d.Body),
+		Headers:       d.SafeHeaderClone(),
+		Tags:          d.Tags,
+	}
+	for _, r := range d.Ranges {
+		oi.Ranges = append(oi.Ranges, r.String())
+	}
+	step := time.Duration(d.StepNS)
+	if step > 0 {
+		oi.Step = step.String()
+	}
+	var el timeseries.ExtentList
+	if !d.IsMeta {
+		if ts, _ := documentTimeseries(d, modeler); ts != nil {
+			el = ts.Extents()
+		}
+	} else {
+		for _, subkey := range chunkKeys(c, key, step, e) {
+			cqr := queryConcurrent(ctx, c, subkey)
+			if cqr.err != nil {
+				continue
+			}
+			oi.Chunks = append(oi.Chunks, subkey)
+			if ts, _ := documentTimeseries(cqr.d, modeler); ts != nil {
+				el = el.Merge(ts.Extents(), step)
+			}
+		}
+	}
+	for _, x := range el {
+		oi.Extents = append(oi.Extents, ExtentInfo{
+			Start: x.Start.UTC().Format(time.RFC3339),
+			End:   x.End.UTC().Format(time.RFC3339),
+		})
+	}
+	return oi, nil
+}
+
+// chunkKeys returns the candidate chunk keys of the meta document cached
+// under key
+func chunkKeys(c cache.Cache, key string, step time.Duration, e timeseries.Extent) []string {
+	if l, ok := c.(index.Lister); ok {
+		if objs, indexed := l.IndexedObjects(key); indexed {
+			out := make([]string, 0, len(objs))
+			for _, o := range objs {
+				if o.Key != key {
+					out = append(out, o.Key)
+				}
+			}
+			return out
+		}
+	}
+	if step <= 0 || e.Start.IsZero() {
+		return nil
+	}
+	return timeseriesChunkKeys(c, key, step, e)
+}
+
+// documentTimeseries returns the timeseries held by d, unmarshaling its body
+// with the modeler if needed
+func documentTimeseries(d *HTTPDocument, modeler *timeseries.Modeler) (timeseries.Timeseries, error) {
+	if d.timeseries != nil {
+		return d.timeseries, nil
+	}
+	if len(d.Body) == 0 || modeler == nil || modeler.CacheUnmarshaler == nil {
+		return nil, nil
+	}
+	return modeler.CacheUnmarshaler(d.Body, nil)
+}
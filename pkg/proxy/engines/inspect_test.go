@@ -0,0 +1,92 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/index"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectObject(t *testing.T) {
+	for _, chunked := range []bool{false, true} {
+		t.Run(fmt.Sprintf("chunked=%t", chunked), func(t *testing.T) {
+			ts, _, r, rsc, err := setupTestHarnessDPC()
+			require.NoError(t, err)
+			defer closeTestHarness(ts, r)
+
+			c := rsc.CacheClient
+			c.Configuration().UseCacheChunking = chunked
+			client := rsc.BackendClient.(*TestClient)
+			o := rsc.BackendOptions
+			o.FastForwardDisable = true
+
+			step := 300 * time.Second
+			end := time.Now().Add(-12 * time.Hour).Truncate(step)
+			ext := timeseries.Extent{Start: end.Add(-6 * time.Hour), End: end}
+			r.URL.Path = "/prometheus/api/v1/query_range"
+			r.URL.RawQuery = fmt.Sprintf("step=%d&start=%d&end=%d&query=%s",
+				int(step.Seconds()), ext.Start.Unix(), ext.End.Unix(), queryReturnsOKNoLatency)
+			client.QueryRangeHandler(httptest.NewRecorder(), r)
+			time.Sleep(10 * time.Millisecond)
+
+			keys, err := c.(cache.Tagger).TaggedKeys([]string{index.BackendTag(o.Name)}, "")
+			require.NoError(t, err)
+			require.Len(t, keys, 1)
+
+			oi, err := InspectObject(t.Context(), c, client.testModeler(), keys[0], ext)
+			require.NoError(t, err)
+			require.Equal(t, keys[0], oi.Key)
+			require.Equal(t, 200, oi.StatusCode)
+			require.Contains(t, oi.Tags, index.BackendTag(o.Name))
+			require.Equal(t, []ExtentInfo{{
+				Start: ext.Start.UTC().Format(time.RFC3339),
+				End:   ext.End.UTC().Format(time.RFC3339),
+			}}, oi.Extents)
+			require.Equal(t, chunked, oi.IsMeta)
+			if chunked {
+				require.Equal(t, step.String(), oi.Step)
+				require.NotEmpty(t, oi.Chunks)
+			} else {
+				require.Empty(t, oi.Chunks)
+			}
+			// chunks are tagged with the key of their object
+			chunkKeys, err := c.(cache.Tagger).TaggedKeys([]string{index.ChunkTag(keys[0])}, "")
+			require.NoError(t, err)
+			require.Len(t, chunkKeys, len(oi.Chunks))
+
+			// without an extent, the chunks of an object in an unindexed
+			// cache can't be found
+			oi, err = InspectObject(t.Context(), c, nil, keys[0], timeseries.Extent{})
+			require.NoError(t, err)
+			require.Empty(t, oi.Chunks)
+			if chunked {
+				require.Empty(t, oi.Extents)
+			}
+
+			_, err = InspectObject(t.Context(), c, nil, "missing", timeseries.Extent{})
+			require.ErrorIs(t, err, cache.ErrKNF)
+		})
+	}
+}
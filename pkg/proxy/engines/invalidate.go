@@ -0,0 +1,210 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"context"
+	"errors"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/dataset"
+)
+
+// ErrInvalidExtent is returned when an invalidation extent ends before it starts
+var ErrInvalidExtent = errors.New("invalid extent: end is before start")
+
+// TimeseriesModeler is implemented by backends that cache timeseries objects
+type TimeseriesModeler interface {
+	Modeler() *timeseries.Modeler
+}
+
+// ParseTime parses a unix epoch timestamp in seconds, or an RFC3339 timestamp
+func ParseTime(s string) (time.Time, error) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*1e9)), nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// InvalidateExtent removes the time range e from each Delta Proxy Cache object
+// cached under keys, so that the next request for the object refetches only
+// that range from the origin. When query is not nil, only objects whose cached
+// query statement matches it are modified. Objects left without any data are
+// removed from the cache. InvalidateExtent returns the number of cache objects,
+// including chunks, that were rewritten or removed.
+func InvalidateExtent(ctx context.Context, c cache.Cache, o *bo.Options,
+	modeler *timeseries.Modeler, keys []string, e timeseries.Extent,
+	query *regexp.Regexp,
+) (int, error) {
+	if e.End.Before(e.Start) {
+		return 0, ErrInvalidExtent
+	}
+	var n int
+	var errs []error
+	for _, key := range keys {
+		k, err := invalidateKeyExtent(ctx, c, o, modeler, key, e, query)
+		n += k
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return n, errors.Join(errs...)
+}
+
+func invalidateKeyExtent(ctx context.Context, c cache.Cache, o *bo.Options,
+	modeler *timeseries.Modeler, key string, e timeseries.Extent,
+	query *regexp.Regexp,
+) (int, error) {
+	qr := queryConcurrent(ctx, c, key)
+	if qr.err != nil {
+		if errors.Is(qr.err, cache.ErrKNF) {
+			return 0, nil
+		}
+		return 0, qr.err
+	}
+	if !qr.d.IsMeta || qr.d.StepNS <= 0 {
+		return invalidateDocumentExtent(ctx, c, o, modeler, key, qr.d, e, query)
+	}
+	// the object is chunked, so invalidate each chunk that overlaps the extent
+	var n int
+	var errs []error
+	for _, subkey := range timeseriesChunkKeys(c, key, time.Duration(qr.d.StepNS), e) {
+		cqr := queryConcurrent(ctx, c, subkey)
+		if cqr.err != nil {
+			if !errors.Is(cqr.err, cache.ErrKNF) {
+				errs = append(errs, cqr.err)
+			}
+			continue
+		}
+		k, err := invalidateDocumentExtent(ctx, c, o, modeler, subkey, cqr.d, e, query)
+		n += k
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return n, errors.Join(errs...)
+}
+
+// timeseriesChunkKeys returns the keys of the chunks of the chunked timeseries
+// object cached under key that overlap the extent e
+func timeseriesChunkKeys(c cache.Cache, key string, step time.Duration,
+	e timeseries.Extent,
+) []string {
+	csize := step * time.Duration(c.Configuration().TimeseriesChunkFactor)
+	if csize <= 0 {
+		return nil
+	}
+	var out []string
+	for cs := e.Start.Truncate(csize); !cs.After(e.End); cs = cs.Add(csize) {
+		out = append(out, getSubKey(key, timeseries.Extent{Start: cs, End: cs.Add(csize - step)}))
+	}
+	return out
+}
+
+func invalidateDocumentExtent(ctx context.Context, c cache.Cache, o *bo.Options,
+	modeler *timeseries.Modeler, key string, d *HTTPDocument, e timeseries.Extent,
+	query *regexp.Regexp,
+) (int, error) {
+	ts, err := documentTimeseries(d, modeler)
+	if err != nil || ts == nil {
+		return 0, err
+	}
+	if query != nil && !query.MatchString(cachedStatement(ts)) {
+		return 0, nil
+	}
+	nts, ok := removeExtent(ts, e)
+	if !ok {
+		return 0, nil
+	}
+	if nts == nil {
+		return 1, c.Remove(key)
+	}
+	d.timeseries = nts
+	// match WriteCache, which always marshals whole documents, but leaves
+	// chunk bodies unmarshaled in memory caches
+	if !d.IsChunk || c.Configuration().Provider != providerMemory {
+		if modeler == nil || modeler.CacheMarshaler == nil {
+			return 0, timeseries.ErrUnknownFormat
+		}
+		marshal := modeler.CacheMarshalerFor(o.TimeseriesCacheEncoding)
+		if d.Body, err = marshal(nts, nil, 0); err != nil {
+			return 0, err
+		}
+	}
+	ce := d.SafeHeaderClone().Get(headers.NameContentEncoding)
+	return 1, writeConcurrent(ctx, c, key, d,
+		shouldCompress(d, ce, o.CompressibleTypes), time.Duration(o.TimeseriesTTL))
+}
+
+// cachedStatement returns the query statement stored with the timeseries, if any
+func cachedStatement(ts timeseries.Timeseries) string {
+	if ds, ok := ts.(*dataset.DataSet); ok && ds.TimeRangeQuery != nil {
+		return ds.TimeRangeQuery.Statement
+	}
+	return ""
+}
+
+// removeExtent returns a copy of ts without the data and extents that fall
+// within e, which is widened to the step boundaries of ts. The returned bool
+// is false when ts has no data within e, and the returned Timeseries is nil
+// when no data remains outside of e.
+func removeExtent(ts timeseries.Timeseries, e timeseries.Extent) (timeseries.Timeseries, bool) {
+	step := ts.Step()
+	if step > 0 {
+		start, end := e.Start.Truncate(step), e.End.Truncate(step)
+		if end.Before(e.End) {
+			end = end.Add(step)
+		}
+		e = timeseries.Extent{Start: start, End: end}
+	} else {
+		step = time.Nanosecond
+	}
+	el := ts.Extents()
+	if len(el) == 0 || el.OutsideOf(e) {
+		return nil, false
+	}
+	rel := timeseries.ExtentList{e}
+	kept := el.Remove(rel, step)
+	if extentsEqual(el, kept) {
+		return nil, false
+	}
+	if len(kept) == 0 {
+		return nil, true
+	}
+	out := ts.CroppedClone(kept[0])
+	for _, k := range kept[1:] {
+		out.Merge(true, ts.CroppedClone(k))
+	}
+	out.SetExtents(kept)
+	out.SetVolatileExtents(ts.VolatileExtents().Remove(rel, step))
+	return out, true
+}
+
+func extentsEqual(a, b timeseries.ExtentList) bool {
+	return slices.EqualFunc(a, b, func(x, y timeseries.Extent) bool {
+		return x.Start.Equal(y.Start) && x.End.Equal(y.End)
+	})
+}
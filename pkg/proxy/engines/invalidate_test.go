@@ -0,0 +1,118 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	mockprom "github.com/trickstercache/mockster/pkg/mocks/prometheus"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/index"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidateExtent(t *testing.T) {
+	for _, chunked := range []bool{false, true} {
+		t.Run(fmt.Sprintf("chunked=%t", chunked), func(t *testing.T) {
+			ts, _, r, rsc, err := setupTestHarnessDPC()
+			require.NoError(t, err)
+			defer closeTestHarness(ts, r)
+
+			c := rsc.CacheClient
+			c.Configuration().UseCacheChunking = chunked
+			client := rsc.BackendClient.(*TestClient)
+			o := rsc.BackendOptions
+			o.FastForwardDisable = true
+
+			step := 300 * time.Second
+			end := time.Now().Add(-12 * time.Hour).Truncate(step)
+			ext := timeseries.Extent{Start: end.Add(-18 * time.Hour), End: end}
+			expected, _, _ := mockprom.GetTimeSeriesData(queryReturnsOKNoLatency,
+				ext.Start, ext.End, step)
+			r.URL.Path = "/prometheus/api/v1/query_range"
+			r.URL.RawQuery = fmt.Sprintf("step=%d&start=%d&end=%d&query=%s",
+				int(step.Seconds()), ext.Start.Unix(), ext.End.Unix(), queryReturnsOKNoLatency)
+
+			fetch := func(status string) {
+				t.Helper()
+				w := httptest.NewRecorder()
+				client.QueryRangeHandler(w, r)
+				resp := w.Result()
+				b, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.NoError(t, testStringMatch(string(b), expected))
+				require.NoError(t, testStatusCodeMatch(resp.StatusCode, http.StatusOK))
+				require.NoError(t, testResultHeaderPartMatch(resp.Header,
+					map[string]string{"status": status}))
+				time.Sleep(10 * time.Millisecond)
+			}
+			fetch("kmiss")
+			fetch("hit")
+
+			keys, err := c.(cache.Tagger).TaggedKeys([]string{index.BackendTag(o.Name)},
+				ComposeCacheKey(o.Name, o.CacheKeyPrefix, "dpc", ""))
+			require.NoError(t, err)
+			require.Len(t, keys, 1)
+			modeler := client.testModeler()
+
+			// a query matcher that doesn't match the cached query leaves it intact
+			n, err := InvalidateExtent(t.Context(), c, o, modeler, keys,
+				timeseries.Extent{Start: ext.Start.Add(time.Hour), End: ext.Start.Add(2 * time.Hour)},
+				regexp.MustCompile("^other_query"))
+			require.NoError(t, err)
+			require.Equal(t, 0, n)
+			fetch("hit")
+
+			// invalidating a window in the middle of the object refetches only that window
+			n, err = InvalidateExtent(t.Context(), c, o, modeler, keys,
+				timeseries.Extent{Start: ext.Start.Add(time.Hour), End: ext.Start.Add(2 * time.Hour)},
+				regexp.MustCompile("^some_query_here"))
+			require.NoError(t, err)
+			require.Positive(t, n)
+			fetch("phit")
+			fetch("hit")
+
+			// invalidating an extent with no cached data is a no-op
+			n, err = InvalidateExtent(t.Context(), c, o, modeler, keys,
+				timeseries.Extent{Start: ext.End.Add(time.Hour), End: ext.End.Add(2 * time.Hour)}, nil)
+			require.NoError(t, err)
+			require.Equal(t, 0, n)
+
+			// invalidating the whole object removes it
+			n, err = InvalidateExtent(t.Context(), c, o, modeler, keys, ext, nil)
+			require.NoError(t, err)
+			require.Positive(t, n)
+			if !chunked {
+				_, _, err = c.(cache.MemoryCache).RetrieveReference(keys[0])
+				require.ErrorIs(t, err, cache.ErrKNF)
+			}
+			fetch("kmiss")
+
+			_, err = InvalidateExtent(t.Context(), c, o, modeler, keys,
+				timeseries.Extent{Start: ext.End, End: ext.Start}, nil)
+			require.ErrorIs(t, err, ErrInvalidExtent)
+		})
+	}
+}
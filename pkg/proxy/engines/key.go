@@ -44,6 +44,17 @@ func ComposeCacheKey(name, prefix, engine, suffix string) string {
 	return name + "." + prefix + "." + engine + "." + suffix
 }
 
+// variantCacheKey derives the secondary key under which the variant of the
+// object at key is cached, as selected by the request's values for the headers
+// named in the object's Vary response header
+func variantCacheKey(key string, vary []string, h http.Header) string {
+	vals := make([]string, len(vary))
+	for i, name := range vary {
+		vals[i] = fmt.Sprintf("%s.%s.", name, strings.Join(h.Values(name), ","))
+	}
+	return key + ".variant." + md5.Checksum(strings.Join(vals, ""))
+}
+
 // DeriveCacheKey calculates a query-specific keyname based on the user request
 func (pr *proxyRequest) DeriveCacheKey(extra string) string {
 	pc := pr.rsc.PathConfig
@@ -619,3 +619,20 @@ func composeKey(engine string, o *bo.Options, derived string) string {
 	}
 	return ""
 }
+
+func TestVariantCacheKey(t *testing.T) {
+	vary := []string{"Accept-Encoding", "Accept-Language"}
+	h1 := http.Header{"Accept-Encoding": {"gzip"}, "Accept-Language": {"en"}}
+	h2 := http.Header{"Accept-Encoding": {"gzip"}, "Accept-Language": {"fr"}}
+	k1 := variantCacheKey("key", vary, h1)
+	if !strings.HasPrefix(k1, "key.variant.") {
+		t.Errorf("unexpected variant key: %s", k1)
+	}
+	if k1 == variantCacheKey("key", vary, h2) {
+		t.Error("expected distinct variant keys for distinct header values")
+	}
+	h1.Set("X-Unrelated", "1")
+	if k1 != variantCacheKey("key", vary, h1) {
+		t.Error("expected headers not named by vary to be ignored")
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"context"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
+	"github.com/trickstercache/trickster/v2/pkg/observability/metrics"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+)
+
+// the results of a Distributed Collapsed Forwarding lease, as reported in the
+// forwarding leases metric
+const (
+	leaseAcquired = "acquired"
+	leaseCacheHit = "cache_hit"
+	leaseTimeout  = "timeout"
+	leaseError    = "error"
+)
+
+// recordLease increments the forwarding leases metric for the request
+func recordLease(rsc *request.Resources, result string) {
+	o := rsc.BackendOptions
+	metrics.ProxyForwardingLeases.WithLabelValues(o.Name, o.Provider, result).Inc()
+}
+
+// awaitLease coordinates a cache miss on the key with the other Trickster
+// instances sharing the cache, when the backend uses Distributed Collapsed
+// Forwarding. When this instance takes the lease on the key, it fetches the
+// object from the origin, and calls the returned func to release the lease
+// once the object is cached. When the lease is held by another instance, found
+// is polled until it reports that the object is now cached, or until the lease
+// expires, after which the caller fetches the object from the origin itself.
+func awaitLease(ctx context.Context, rsc *request.Resources, c cache.Cache,
+	key string, found func() bool,
+) func() {
+	noop := func() {}
+	if rsc == nil || rsc.BackendOptions == nil || rsc.BackendOptions.DistributedForwarding == nil {
+		return noop
+	}
+	l, ok := c.(cache.Leaser)
+	if !ok {
+		return noop
+	}
+	o := rsc.BackendOptions.DistributedForwarding
+	ttl := time.Duration(o.LeaseTTL)
+	deadline := time.Now().Add(ttl)
+	ticker := time.NewTicker(time.Duration(o.PollInterval))
+	defer ticker.Stop()
+	for {
+		token, acquired, err := l.AcquireLease(key, ttl)
+		if err != nil {
+			logger.Warn("could not acquire forwarding lease",
+				logging.Pairs{"key": key, "detail": err.Error()})
+			recordLease(rsc, leaseError)
+			return noop
+		}
+		if acquired {
+			recordLease(rsc, leaseAcquired)
+			return func() {
+				if err := l.ReleaseLease(key, token); err != nil {
+					logger.Warn("could not release forwarding lease",
+						logging.Pairs{"key": key, "detail": err.Error()})
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return noop
+		case <-ticker.C:
+		}
+		if found() {
+			recordLease(rsc, leaseCacheHit)
+			return noop
+		}
+		if time.Now().After(deadline) {
+			recordLease(rsc, leaseTimeout)
+			return noop
+		}
+	}
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/manager"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	fwdo "github.com/trickstercache/trickster/v2/pkg/proxy/forwarding/options"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+// leaseTestCache is a cache whose forwarding leases may be held by another
+// Trickster instance
+type leaseTestCache struct {
+	*manager.Manager
+	mu sync.Mutex
+	// heldElsewhere is true when the lease is held by another instance
+	heldElsewhere bool
+	// onHeld is called the first time a lease held by another instance is requested
+	onHeld             func()
+	acquired, released atomic.Int32
+	lastKey            string
+	lastRef            cache.ReferenceObject
+}
+
+func (c *leaseTestCache) AcquireLease(_ string, _ time.Duration) (string, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.heldElsewhere {
+		if c.onHeld != nil {
+			go c.onHeld()
+			c.onHeld = nil
+		}
+		return "", false, nil
+	}
+	c.acquired.Add(1)
+	return "token", true, nil
+}
+
+func (c *leaseTestCache) ReleaseLease(_, token string) error {
+	if token == "token" {
+		c.released.Add(1)
+	}
+	return nil
+}
+
+func (c *leaseTestCache) StoreReference(cacheKey string, data cache.ReferenceObject,
+	ttl time.Duration,
+) error {
+	c.mu.Lock()
+	c.lastKey, c.lastRef = cacheKey, data
+	c.mu.Unlock()
+	return c.Manager.StoreReference(cacheKey, data, ttl)
+}
+
+func TestObjectProxyCacheDistributedForwarding(t *testing.T) {
+	setup := func(t *testing.T) (*staleTestOrigin, *http.Request, *leaseTestCache) {
+		t.Helper()
+		o, r, rsc := setupStaleTestHarness(t, "max-age=60")
+		lc := &leaseTestCache{Manager: rsc.CacheClient.(*manager.Manager)}
+		rsc.CacheClient = lc
+		rsc.BackendOptions.DistributedForwarding = &fwdo.Options{
+			LeaseTTL:     timeconv.Duration(500 * time.Millisecond),
+			PollInterval: timeconv.Duration(10 * time.Millisecond),
+		}
+		return o, r, lc
+	}
+
+	t.Run("acquired", func(t *testing.T) {
+		_, r, lc := setup(t)
+		expectOPC(t, r, http.StatusOK, "v1", "kmiss")
+		if lc.acquired.Load() != 1 || lc.released.Load() != 1 {
+			t.Errorf("expected the lease to be acquired and released, got %d %d",
+				lc.acquired.Load(), lc.released.Load())
+		}
+		expectOPC(t, r, http.StatusOK, "v1", "hit")
+		if lc.acquired.Load() != 1 {
+			t.Error("expected no lease for a cache hit")
+		}
+	})
+
+	t.Run("cached by lease holder", func(t *testing.T) {
+		o, r, lc := setup(t)
+		expectOPC(t, r, http.StatusOK, "v1", "kmiss")
+		lc.mu.Lock()
+		key, ref := lc.lastKey, lc.lastRef
+		lc.heldElsewhere = true
+		// the lease holder caches the object while this instance waits
+		lc.onHeld = func() {
+			time.Sleep(50 * time.Millisecond)
+			lc.Manager.StoreReference(key, ref, time.Minute)
+		}
+		lc.mu.Unlock()
+		if err := lc.Remove(key); err != nil {
+			t.Fatal(err)
+		}
+		expectOPC(t, r, http.StatusOK, "v1", "hit")
+		if n := o.requests.Load(); n != 1 {
+			t.Errorf("expected 1 origin request, got %d", n)
+		}
+	})
+
+	t.Run("lease timeout", func(t *testing.T) {
+		o, r, lc := setup(t)
+		lc.heldElsewhere = true
+		start := time.Now()
+		expectOPC(t, r, http.StatusOK, "v1", "kmiss")
+		if time.Since(start) < 500*time.Millisecond {
+			t.Error("expected to wait for the lease to expire")
+		}
+		if n := o.requests.Load(); n != 1 {
+			t.Errorf("expected 1 origin request, got %d", n)
+		}
+	})
+}
+
+func TestDeltaProxyCacheDistributedForwarding(t *testing.T) {
+	ts, _, r, rsc, err := setupTestHarnessDPC()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeTestHarness(ts, r)
+
+	lc := &leaseTestCache{Manager: rsc.CacheClient.(*manager.Manager)}
+	rsc.CacheClient = lc
+	client := rsc.BackendClient.(*TestClient)
+	o := rsc.BackendOptions
+	o.FastForwardDisable = true
+	o.DistributedForwarding = fwdo.New()
+
+	step := 300 * time.Second
+	end := time.Now().Add(-12 * time.Hour)
+	extr := timeseries.Extent{Start: end.Add(-18 * time.Hour), End: end}
+	r.URL.Path = "/prometheus/api/v1/query_range"
+	r.URL.RawQuery = fmt.Sprintf("step=%d&start=%d&end=%d&query=%s",
+		int(step.Seconds()), extr.Start.Unix(), extr.End.Unix(), queryReturnsOKNoLatency)
+
+	for _, want := range []string{"kmiss", "hit"} {
+		w := httptest.NewRecorder()
+		client.QueryRangeHandler(w, r)
+		if err := testResultHeaderPartMatch(w.Result().Header,
+			map[string]string{"status": want}); err != nil {
+			t.Error(err)
+		}
+	}
+	if lc.acquired.Load() != 1 || lc.released.Load() != 1 {
+		t.Errorf("expected one lease to be acquired and released, got %d %d",
+			lc.acquired.Load(), lc.released.Load())
+	}
+}
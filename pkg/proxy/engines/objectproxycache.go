@@ -29,6 +29,7 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
 	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
 	tspan "github.com/trickstercache/trickster/v2/pkg/observability/tracing/span"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/admission"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/errors"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/forwarding"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
@@ -125,7 +126,21 @@ func handleCachePartialHit(pr *proxyRequest) error {
 func confirmTrueCacheHit(pr *proxyRequest) (bool, error) {
 	pr.cachingPolicy.Merge(pr.cacheDocument.CachingPolicy)
 
-	if (!pr.checkCacheFreshness()) && (pr.cachingPolicy.CanRevalidate) {
+	if !pr.checkCacheFreshness() && (pr.cacheStatus == status.LookupStatusHit ||
+		pr.cacheStatus == status.LookupStatusProxyHit) {
+		now := time.Now()
+		swr, sie := pr.staleWindows(pr.cachingPolicy)
+		if pr.cachingPolicy.CanServeStale(swr, now) {
+			revalidateInBackground(pr)
+			pr.staleDocument = pr.cacheDocument
+			return false, serveStale(pr, status.LookupStatusStaleHit)
+		}
+		if pr.cachingPolicy.CanServeStale(sie, now) {
+			pr.staleDocument = pr.cacheDocument
+		}
+	}
+
+	if (!pr.cachingPolicy.IsFresh) && (pr.cachingPolicy.CanRevalidate) {
 		return false, handleCacheRevalidation(pr)
 	}
 	if !pr.cachingPolicy.IsFresh {
@@ -205,6 +220,9 @@ func handleCacheRevalidationResponse(pr *proxyRequest) error {
 	}
 
 	pr.revalidation = RevalStatusFailed
+	if pr.staleDocument != nil && isUpstreamError(pr.upstreamResponse) {
+		return serveStale(pr, status.LookupStatusStaleIfError)
+	}
 	pr.cacheStatus = status.LookupStatusKeyMiss
 	return handleAllWrites(pr)
 }
@@ -242,9 +260,11 @@ func handleTrueCacheHit(pr *proxyRequest) error {
 func handleCacheKeyMiss(pr *proxyRequest) error {
 	pc := pr.rsc.PathConfig
 
-	// if we're using PCF, handle that separately
+	// if we're using PCF, handle that separately, unless a stale object
+	// may be needed in place of an upstream error
 	if !methods.HasBody(pr.Method) && !pr.wantsRanges && pc != nil &&
-		pc.CollapsedForwardingType == forwarding.CFTypeProgressive {
+		pc.CollapsedForwardingType == forwarding.CFTypeProgressive &&
+		pr.staleDocument == nil {
 		if err := handlePCF(pr); !stderrors.Is(err, errors.ErrPCFContentLength) {
 			return err
 		}
@@ -255,6 +275,9 @@ func handleCacheKeyMiss(pr *proxyRequest) error {
 	if err := handleUpstreamTransactions(pr); err != nil {
 		return err
 	}
+	if pr.staleDocument != nil && isUpstreamError(pr.upstreamResponse) {
+		return serveStale(pr, status.LookupStatusStaleIfError)
+	}
 	return handleAllWrites(pr)
 }
 
@@ -280,11 +303,14 @@ func serveOPCResult(pr *proxyRequest, result *opcResult) error {
 }
 
 func handleUpstreamTransactions(pr *proxyRequest) error {
+	start := time.Now()
 	if err := pr.makeUpstreamRequests(); err != nil {
 		return err
 	}
+	pr.upstreamLatency = time.Since(start)
 	pr.reconstituteResponses()
 	pr.determineCacheability()
+	pr.checkAdmission()
 	return nil
 }
 
@@ -312,7 +338,9 @@ func handlePCF(pr *proxyRequest) error {
 	setResourceSpanAttributes(pr.rsc, span)
 	pr.upstreamRequest = pr.upstreamRequest.WithContext(ctx)
 
+	start := time.Now()
 	reader, resp, contentLength := PrepareFetchReader(pr.upstreamRequest)
+	pr.upstreamLatency = time.Since(start)
 	pr.upstreamResponse = resp
 
 	pr.writeResponseHeader()
@@ -334,8 +362,9 @@ func handlePCF(pr *proxyRequest) error {
 		}
 
 		pr.cachingPolicy.Merge(GetResponseCachingPolicy(pr.upstreamResponse.StatusCode,
-			pr.rsc.BackendOptions.NegativeCache, pr.upstreamResponse.Header))
+			negativeCache(pr.rsc), pr.upstreamResponse.Header))
 		pr.determineCacheability()
+		pr.checkAdmission()
 
 		goWithRecover("opc.pcf.copy", func() {
 			defer func() {
@@ -378,6 +407,9 @@ func handleAllWrites(pr *proxyRequest) error {
 			} else {
 				d.Body = pr.cacheBuffer.Bytes()
 			}
+			if pr.upstreamResponse.ContentLength < 0 && !pr.checkAdmissionSize(pr.cacheBuffer.Len()) {
+				return nil
+			}
 		}
 		if err := pr.store(); err != nil {
 			return err
@@ -433,14 +465,18 @@ func fetchViaObjectProxyCache(w io.Writer, r *http.Request) (*http.Response, sta
 	pr.cachingPolicy = GetRequestCachingPolicy(pr.Header)
 
 	pr.key = ComposeCacheKey(o.Name, o.CacheKeyPrefix, "opc", pr.DeriveCacheKey(""))
+	pr.primaryKey = pr.key
+	admission.Record(admissionOptions(rsc), pr.primaryKey)
+	// the key of the request's variant, if the object is known to vary
+	key := pr.hintedKey()
 
 	// if a PCF entry exists, or the client requested no-cache for this object, proxy out to it
-	pcfResult, pcfExists := reqs.Load(pr.key)
+	pcfResult, pcfExists := reqs.Load(key)
 	pr.isPCF = !methods.HasBody(pr.Method) && pcfExists && !pr.wantsRanges
 
 	if pr.isPCF || pr.cachingPolicy.NoCache {
 		if pr.cachingPolicy.NoCache {
-			cc.Remove(pr.key)
+			cc.Remove(key)
 			tspan.SetAttributes(rsc.Tracer, span, attribute.String("cache.status", status.LookupStatusProxyOnly.String()))
 			return nil, status.LookupStatusProxyOnly
 		}
@@ -462,7 +498,7 @@ func fetchViaObjectProxyCache(w io.Writer, r *http.Request) (*http.Response, sta
 
 	// deduplicate cache lookup + handler work per cache key via singleflight.
 	// the executor writes its own response and returns an opcResult for any waiters.
-	sfKey := pr.key
+	sfKey := key
 	if pr.wantsRanges {
 		sfKey += "|" + pr.wantedRanges.String()
 	}
@@ -497,7 +533,23 @@ func fetchViaObjectProxyCache(w io.Writer, r *http.Request) (*http.Response, sta
 		}
 
 		var err error
-		pr.cacheDocument, pr.cacheStatus, pr.neededRanges, err = QueryCache(pr.upstreamRequest.Context(), cc, pr.key, pr.wantedRanges, nil)
+		ctx := pr.upstreamRequest.Context()
+		lookup := func() {
+			pr.cacheDocument, pr.cacheStatus, pr.neededRanges, err = QueryCache(ctx, cc, pr.key, pr.wantedRanges, nil)
+			if err == nil && pr.cacheDocument.isVaryManifest() {
+				pr.cacheDocument, pr.cacheStatus, pr.neededRanges, err = queryVariant(ctx, pr, cc, pr.cacheDocument)
+			}
+		}
+		lookup()
+		// on a miss, collapse the origin fetch with other Trickster instances
+		// sharing the cache, when Distributed Collapsed Forwarding is enabled
+		if stderrors.Is(err, cache.ErrKNF) {
+			release := awaitLease(ctx, rsc, cc, pr.primaryKey, func() bool {
+				lookup()
+				return !stderrors.Is(err, cache.ErrKNF)
+			})
+			defer release()
+		}
 		if err == nil || stderrors.Is(err, cache.ErrKNF) {
 			f := cacheResponseHandler(pr.cacheStatus)
 			if f == nil {
@@ -535,6 +587,7 @@ func fetchViaObjectProxyCache(w io.Writer, r *http.Request) (*http.Response, sta
 			body:        append([]byte(nil), body...),
 			elapsed:     float64(time.Since(pr.started).Milliseconds()) / 1000.0,
 			cacheStatus: pr.cacheStatus,
+			key:         pr.key,
 		}, nil
 	})
 
@@ -550,6 +603,11 @@ func fetchViaObjectProxyCache(w io.Writer, r *http.Request) (*http.Response, sta
 
 	// only serve the shared result for waiters; the executor already wrote its response
 	if !isExecutor {
+		// the executor learned that the object varies and served a different
+		// variant than this request selects, so look up this request's variant
+		if result.key != "" && result.key != pr.hintedKey() {
+			return fetchViaObjectProxyCache(w, r)
+		}
 		if err := serveOPCResult(pr, result); err != nil {
 			tspan.SetAttributes(rsc.Tracer, span, attribute.String("cache.status", status.LookupStatusError.String()))
 			return nil, status.LookupStatusError
@@ -567,11 +625,12 @@ func fetchViaObjectProxyCache(w io.Writer, r *http.Request) (*http.Response, sta
 		pr.cacheStatus = result.cacheStatus
 	}
 
-	recordOPCResult(pr, pr.cacheStatus, pr.upstreamResponse.StatusCode, r.URL.Path, result.elapsed, pr.upstreamResponse.Header)
-	tspan.SetAttributes(rsc.Tracer, span, attribute.String("cache.status", pr.cacheStatus.String()))
+	cacheStatus := pr.resultStatus()
+	recordOPCResult(pr, cacheStatus, pr.upstreamResponse.StatusCode, r.URL.Path, result.elapsed, pr.upstreamResponse.Header)
+	tspan.SetAttributes(rsc.Tracer, span, attribute.String("cache.status", cacheStatus.String()))
 	setHTTPStatusSpanAttributes(rsc.Tracer, pr.upstreamResponse.StatusCode, span)
 
-	return pr.upstreamResponse, pr.cacheStatus
+	return pr.upstreamResponse, cacheStatus
 }
 
 // ObjectProxyCacheRequest provides a Basic HTTP Reverse Proxy/Cache
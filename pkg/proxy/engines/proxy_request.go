@@ -79,12 +79,18 @@ type proxyRequest struct {
 
 	// cache state
 	cacheDocument *HTTPDocument
+	staleDocument *HTTPDocument
 	cacheBuffer   *bytes.Buffer
 	cacheStatus   status.LookupStatus
 	cachingPolicy *CachingPolicy
 	key           string
+	primaryKey    string
 	writeToCache  bool
 
+	// cache admission
+	upstreamLatency  time.Duration
+	admissionSkipped bool
+
 	// range handling
 	wantedRanges      byterange.Ranges
 	neededRanges      byterange.Ranges
@@ -142,6 +148,7 @@ func (pr *proxyRequest) Clone() *proxyRequest {
 		upstreamRequest:    cloneRequestWithSpan(pr.upstreamRequest),
 		cacheDocument:      pr.cacheDocument,
 		key:                pr.key,
+		primaryKey:         pr.primaryKey,
 		cacheStatus:        pr.cacheStatus,
 		writeToCache:       pr.writeToCache,
 		wantsRanges:        pr.wantsRanges,
@@ -416,7 +423,7 @@ func (pr *proxyRequest) stripConditionalHeaders() {
 
 func (pr *proxyRequest) writeResponseHeader() {
 	pr.mapLock.Lock()
-	headers.SetResultsHeader(pr.upstreamResponse.Header, "ObjectProxyCache", pr.cacheStatus.String(), "", nil, nil)
+	headers.SetResultsHeader(pr.upstreamResponse.Header, "ObjectProxyCache", pr.resultStatus().String(), "", nil, nil)
 	pr.mapLock.Unlock()
 }
 
@@ -470,8 +477,14 @@ func (pr *proxyRequest) writeResponseBody() {
 func (pr *proxyRequest) determineCacheability() {
 	resp := pr.upstreamResponse
 
+	// a response that varies on '*' can't be selected by any later request
+	var varyAll bool
+	if resp != nil {
+		_, varyAll = parseVary(resp.Header)
+	}
+
 	if resp != nil && resp.StatusCode >= 400 {
-		pr.writeToCache = pr.cachingPolicy.IsNegativeCache
+		pr.writeToCache = pr.cachingPolicy.IsNegativeCache && !varyAll
 		resp.Header.Del(headers.NameCacheControl)
 		resp.Header.Del(headers.NameExpires)
 		resp.Header.Del(headers.NameLastModified)
@@ -493,6 +506,11 @@ func (pr *proxyRequest) determineCacheability() {
 		}
 	}
 
+	if varyAll {
+		pr.writeToCache = false
+		return
+	}
+
 	if pr.rsc.AlternateCacheTTL > 0 {
 		pr.writeToCache = true
 		pr.cachingPolicy = &CachingPolicy{
@@ -537,8 +555,23 @@ func (pr *proxyRequest) store() error {
 		rf = 1
 	}
 
+	if pr.rsc.AlternateCacheTTL == 0 && !pr.cachingPolicy.IsNegativeCache {
+		pr.cachingPolicy.StaleWhileRevalidate, pr.cachingPolicy.StaleIfError =
+			pr.staleWindows(pr.cachingPolicy)
+	}
+
+	if pr.primaryKey == "" {
+		pr.primaryKey = pr.key
+	}
+	key, ok, err := pr.variantStoreKey(d)
+	if err != nil || !ok {
+		return err
+	}
+	pr.key = key
+
 	d.CachingPolicy = pr.cachingPolicy
-	err := WriteCache(pr.upstreamRequest.Context(), pr.rsc.CacheClient, pr.key, d,
+	d.Tags = cacheTags(pr.Request, pr.rsc, d)
+	err = WriteCache(pr.upstreamRequest.Context(), pr.rsc.CacheClient, pr.key, d,
 		pr.cachingPolicy.TTL(rf, time.Duration(o.MaxTTL)), o.CompressibleTypes, nil)
 	if err != nil {
 		return err
@@ -631,7 +664,8 @@ func (pr *proxyRequest) prepareResponse() {
 		}
 		resp.Header.Del(headers.NameContentRange)
 		if pr.cacheStatus == status.LookupStatusHit || pr.cacheStatus == status.LookupStatusRevalidated ||
-			pr.cacheStatus == status.LookupStatusPartialHit {
+			pr.cacheStatus == status.LookupStatusPartialHit || pr.cacheStatus == status.LookupStatusStaleHit ||
+			pr.cacheStatus == status.LookupStatusStaleIfError {
 			pr.responseBody = d.Body
 		}
 	}
@@ -804,7 +838,7 @@ func (pr *proxyRequest) reconstituteResponses() {
 	if pr.upstreamResponse.StatusCode != http.StatusNotModified {
 		pr.mapLock.Lock()
 		pr.cachingPolicy.Merge(GetResponseCachingPolicy(pr.upstreamResponse.StatusCode,
-			pr.rsc.BackendOptions.NegativeCache, pr.upstreamResponse.Header))
+			negativeCache(pr.rsc), pr.upstreamResponse.Header))
 		pr.mapLock.Unlock()
 	}
 }
@@ -66,6 +66,8 @@ type opcResult struct {
 	body        []byte
 	elapsed     float64
 	cacheStatus status.LookupStatus
+	// key is the cache key of the object variant the executor served
+	key string
 }
 
 // dpcResult is the shared result returned to singleflight waiters for DPC.
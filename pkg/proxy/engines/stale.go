@@ -0,0 +1,101 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
+)
+
+// staleRevalidations tracks the cache keys with a background revalidation in
+// progress, so that concurrent stale hits trigger only one revalidation
+var staleRevalidations sync.Map
+
+// staleWindows returns the stale-while-revalidate and stale-if-error windows,
+// in seconds, for the provided caching policy. Directives from the origin take
+// precedence over the path configuration, which takes precedence over the
+// backend configuration.
+func (pr *proxyRequest) staleWindows(cp *CachingPolicy) (int, int) {
+	swr, sie := cp.StaleWhileRevalidate, cp.StaleIfError
+	var cswr, csie time.Duration
+	if o := pr.rsc.BackendOptions; o != nil {
+		cswr, csie = time.Duration(o.StaleWhileRevalidate), time.Duration(o.StaleIfError)
+	}
+	if pc := pr.rsc.PathConfig; pc != nil {
+		if pc.StaleWhileRevalidate > 0 {
+			cswr = time.Duration(pc.StaleWhileRevalidate)
+		}
+		if pc.StaleIfError > 0 {
+			csie = time.Duration(pc.StaleIfError)
+		}
+	}
+	if swr == 0 {
+		swr = int(cswr.Seconds())
+	}
+	if sie == 0 {
+		sie = int(csie.Seconds())
+	}
+	return swr, sie
+}
+
+// isUpstreamError returns true if the upstream response permits serving a
+// stale object under stale-if-error; transport failures surface as a 502
+func isUpstreamError(resp *http.Response) bool {
+	return resp == nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// serveStale responds to the client with the stale cache document
+func serveStale(pr *proxyRequest, ls status.LookupStatus) error {
+	if rc, ok := pr.upstreamReader.(io.Closer); ok {
+		rc.Close()
+	}
+	d := pr.staleDocument
+	pr.cacheDocument = d
+	pr.cachingPolicy.Merge(d.CachingPolicy)
+	pr.cacheStatus = ls
+	pr.writeToCache = false
+	return handleTrueCacheHit(pr)
+}
+
+// revalidateInBackground revalidates the stale cache document against the
+// origin without blocking the client request
+func revalidateInBackground(pr *proxyRequest) {
+	if _, loaded := staleRevalidations.LoadOrStore(pr.key, struct{}{}); loaded {
+		return
+	}
+	bg := pr.Clone()
+	bg.responseWriter = io.Discard
+	bg.cachingPolicy = pr.cachingPolicy.Clone()
+	bg.cachingPolicy.ResetClientConditionals()
+	bg.cacheDocument = pr.cacheDocument.ShallowCopy()
+	// if the revalidation fails, retain the stale document rather than
+	// replacing it with the error response
+	bg.staleDocument = bg.cacheDocument
+	goWithRecover("opc.staleRevalidation", func() {
+		defer staleRevalidations.Delete(bg.key)
+		if err := handleCacheRevalidation(bg); err != nil {
+			logger.Warn("background revalidation failed",
+				logging.Pairs{"key": bg.key, "detail": err.Error()})
+		}
+	})
+}
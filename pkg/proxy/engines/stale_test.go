@@ -0,0 +1,145 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+)
+
+// staleTestOrigin is an origin whose responses carry a version number that
+// increments with each request, and whose status code can be changed
+type staleTestOrigin struct {
+	*httptest.Server
+	requests     atomic.Int32
+	code         atomic.Int32
+	cacheControl string
+}
+
+func newStaleTestOrigin(cacheControl string) *staleTestOrigin {
+	o := &staleTestOrigin{cacheControl: cacheControl}
+	o.code.Store(http.StatusOK)
+	o.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := o.requests.Add(1)
+		if o.cacheControl != "" {
+			w.Header().Set(headers.NameCacheControl, o.cacheControl)
+		}
+		w.WriteHeader(int(o.code.Load()))
+		w.Write([]byte("v" + strconv.Itoa(int(n))))
+	}))
+	return o
+}
+
+func setupStaleTestHarness(t *testing.T, cacheControl string) (*staleTestOrigin,
+	*http.Request, *request.Resources,
+) {
+	t.Helper()
+	ts, _, r, rsc, err := setupTestHarnessOPC("", "test", http.StatusOK, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { closeTestHarness(ts, r) })
+	o := newStaleTestOrigin(cacheControl)
+	t.Cleanup(o.Close)
+	u, _ := url.Parse(o.URL)
+	r.URL.Scheme, r.URL.Host = u.Scheme, u.Host
+	return o, r, rsc
+}
+
+func expectOPC(t *testing.T, r *http.Request, code int, body, status string) {
+	t.Helper()
+	_, e := testFetchOPC(r, code, body, map[string]string{"status": status})
+	for _, err := range e {
+		t.Error(err)
+	}
+}
+
+func TestObjectProxyCacheStaleWhileRevalidate(t *testing.T) {
+	o, r, _ := setupStaleTestHarness(t, "max-age=1, stale-while-revalidate=60")
+
+	expectOPC(t, r, http.StatusOK, "v1", "kmiss")
+	expectOPC(t, r, http.StatusOK, "v1", "hit")
+	time.Sleep(1100 * time.Millisecond)
+
+	// the stale object is served while it is refreshed in the background
+	expectOPC(t, r, http.StatusOK, "v1", "stale-hit")
+	deadline := time.Now().Add(2 * time.Second)
+	for o.requests.Load() < 2 || isRevalidating() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected a background revalidation")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	expectOPC(t, r, http.StatusOK, "v2", "hit")
+}
+
+func isRevalidating() bool {
+	var ok bool
+	staleRevalidations.Range(func(_, _ any) bool {
+		ok = true
+		return false
+	})
+	return ok
+}
+
+func TestObjectProxyCacheStaleIfError(t *testing.T) {
+	t.Run("origin directive", func(t *testing.T) {
+		o, r, _ := setupStaleTestHarness(t, "max-age=1, stale-if-error=60")
+		expectOPC(t, r, http.StatusOK, "v1", "kmiss")
+		time.Sleep(1100 * time.Millisecond)
+		o.code.Store(http.StatusServiceUnavailable)
+		expectOPC(t, r, http.StatusOK, "v1", "stale-error")
+		// a successful response replaces the stale object
+		o.code.Store(http.StatusOK)
+		expectOPC(t, r, http.StatusOK, "v3", "kmiss")
+	})
+
+	t.Run("backend config with transport error", func(t *testing.T) {
+		o, r, rsc := setupStaleTestHarness(t, "max-age=1")
+		rsc.BackendOptions.StaleIfError = timeconv.Duration(time.Minute)
+		expectOPC(t, r, http.StatusOK, "v1", "kmiss")
+		time.Sleep(1100 * time.Millisecond)
+		o.Close()
+		expectOPC(t, r, http.StatusOK, "v1", "stale-error")
+	})
+
+	t.Run("path config", func(t *testing.T) {
+		o, r, rsc := setupStaleTestHarness(t, "max-age=1")
+		rsc.PathConfig.StaleIfError = timeconv.Duration(time.Minute)
+		expectOPC(t, r, http.StatusOK, "v1", "kmiss")
+		time.Sleep(1100 * time.Millisecond)
+		o.code.Store(http.StatusInternalServerError)
+		expectOPC(t, r, http.StatusOK, "v1", "stale-error")
+	})
+
+	t.Run("outside window", func(t *testing.T) {
+		o, r, _ := setupStaleTestHarness(t, "max-age=1")
+		expectOPC(t, r, http.StatusOK, "v1", "kmiss")
+		time.Sleep(1100 * time.Millisecond)
+		o.code.Store(http.StatusServiceUnavailable)
+		expectOPC(t, r, http.StatusServiceUnavailable, "v2", "kmiss")
+	})
+}
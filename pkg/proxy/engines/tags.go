@@ -0,0 +1,51 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache/index"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+)
+
+// cacheTags returns the tags to attach to a document that is being written to
+// the cache on behalf of the request: the backend and request path tags, any
+// tags configured for the path, and the values of the backend's surrogate key
+// header in the upstream response
+func cacheTags(r *http.Request, rsc *request.Resources, d *HTTPDocument) []string {
+	if rsc == nil || rsc.BackendOptions == nil {
+		return nil
+	}
+	o := rsc.BackendOptions
+	tags := []string{index.BackendTag(o.Name)}
+	if r != nil && r.URL != nil {
+		tags = append(tags, index.PathTag(r.URL.Path))
+	}
+	if rsc.PathConfig != nil {
+		tags = append(tags, rsc.PathConfig.CacheTags...)
+	}
+	if o.SurrogateKeyHeader != "" && d != nil {
+		d.headerLock.Lock()
+		for _, v := range http.Header(d.Headers).Values(o.SurrogateKeyHeader) {
+			tags = append(tags, strings.Fields(v)...)
+		}
+		d.headerLock.Unlock()
+	}
+	return tags
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/index"
+	po "github.com/trickstercache/trickster/v2/pkg/proxy/paths/options"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheTags(t *testing.T) {
+	require.Nil(t, cacheTags(nil, nil, nil))
+
+	o := &bo.Options{Name: "prom1", SurrogateKeyHeader: "Surrogate-Key"}
+	rsc := &request.Resources{
+		BackendOptions: o,
+		PathConfig:     &po.Options{CacheTags: []string{"static"}},
+	}
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/query?query=up", nil)
+	d := &HTTPDocument{Headers: http.Header{
+		"Surrogate-Key": {"dash-1  dash-2", "dash-3"},
+	}}
+	require.Equal(t, []string{
+		index.BackendTag("prom1"), index.PathTag("/api/v1/query"),
+		"static", "dash-1", "dash-2", "dash-3",
+	}, cacheTags(r, rsc, d))
+
+	o.SurrogateKeyHeader = ""
+	require.Equal(t, []string{
+		index.BackendTag("prom1"), index.PathTag("/api/v1/query"), "static",
+	}, cacheTags(r, rsc, d))
+}
+
+func TestObjectProxyCacheRequestTags(t *testing.T) {
+	hdrs := map[string]string{
+		"Cache-Control": "max-age=60",
+		"Surrogate-Key": "dash-1 dash-2",
+	}
+	ts, _, r, rsc, err := setupTestHarnessOPC("", "test", http.StatusOK, hdrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeTestHarness(ts, r)
+	rsc.BackendOptions.SurrogateKeyHeader = "Surrogate-Key"
+
+	_, e := testFetchOPC(r, http.StatusOK, "test", map[string]string{"status": "kmiss"})
+	for _, err = range e {
+		t.Error(err)
+	}
+
+	tg, ok := rsc.CacheClient.(cache.Tagger)
+	require.True(t, ok)
+	n, err := tg.PurgeTagged([]string{"dash-2",
+		index.BackendTag(rsc.BackendOptions.Name)}, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	_, e = testFetchOPC(r, http.StatusOK, "test", map[string]string{"status": "kmiss"})
+	for _, err = range e {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,239 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"container/list"
+	"context"
+	"hash/fnv"
+	"net/http"
+	"net/textproto"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/ranges/byterange"
+)
+
+const (
+	// maxVaryHints is the number of objects whose Vary header names are
+	// remembered, after which the least recently used hint is dropped
+	maxVaryHints = 8192
+	// manifestLockStripes is the number of locks that vary manifest updates are
+	// serialized across
+	manifestLockStripes = 64
+)
+
+// varyHints maps the primary keys of objects known to have a vary manifest to
+// their Vary header names, so that concurrent requests for different variants
+// of an object are not collapsed together. The manifest in the cache remains
+// authoritative for variant selection, so a dropped hint only costs a lookup.
+var varyHints = newVaryHintCache(maxVaryHints)
+
+// manifestLocks serialize the read-modify-write of the vary manifests, so that
+// concurrent misses for different variants of an object don't lose variants
+var manifestLocks [manifestLockStripes]sync.Mutex
+
+// varyHintCache is a size-bounded LRU of the Vary header names of objects
+type varyHintCache struct {
+	mtx      sync.Mutex
+	capacity int
+	order    *list.List
+	hints    map[string]*list.Element
+}
+
+type varyHint struct {
+	key  string
+	vary []string
+}
+
+func newVaryHintCache(capacity int) *varyHintCache {
+	return &varyHintCache{
+		capacity: capacity,
+		order:    list.New(),
+		hints:    make(map[string]*list.Element),
+	}
+}
+
+// Load returns the Vary header names of the object at key, if known
+func (c *varyHintCache) Load(key string) ([]string, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	el, ok := c.hints[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*varyHint).vary, true
+}
+
+// Store records the Vary header names of the object at key, dropping the least
+// recently used hint when the cache is full
+func (c *varyHintCache) Store(key string, vary []string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if el, ok := c.hints[key]; ok {
+		el.Value.(*varyHint).vary = vary
+		c.order.MoveToFront(el)
+		return
+	}
+	c.hints[key] = c.order.PushFront(&varyHint{key: key, vary: vary})
+	if c.order.Len() > c.capacity {
+		el := c.order.Back()
+		c.order.Remove(el)
+		delete(c.hints, el.Value.(*varyHint).key)
+	}
+}
+
+// Delete removes the hint for the object at key
+func (c *varyHintCache) Delete(key string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if el, ok := c.hints[key]; ok {
+		c.order.Remove(el)
+		delete(c.hints, key)
+	}
+}
+
+// Clear removes all hints
+func (c *varyHintCache) Clear() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.order.Init()
+	clear(c.hints)
+}
+
+// manifestLock returns the lock serializing updates to the vary manifest
+// cached under primaryKey
+func manifestLock(primaryKey string) *sync.Mutex {
+	h := fnv.New32a()
+	h.Write([]byte(primaryKey))
+	return &manifestLocks[h.Sum32()%manifestLockStripes]
+}
+
+// isVaryManifest returns true if the document is a vary manifest
+func (d *HTTPDocument) isVaryManifest() bool {
+	return len(d.Vary) > 0
+}
+
+// parseVary returns the sorted, canonicalized header names in the Vary values
+// of the provided header, and true if the Vary values include "*"
+func parseVary(h http.Header) ([]string, bool) {
+	var names []string
+	for _, v := range h.Values(headers.NameVary) {
+		for name := range strings.SplitSeq(v, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if name == "*" {
+				return nil, true
+			}
+			names = append(names, textproto.CanonicalMIMEHeaderKey(name))
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names), false
+}
+
+// hintedKey returns the key of the request's variant when the object at the
+// request's primary key is known to vary, and the primary key otherwise
+func (pr *proxyRequest) hintedKey() string {
+	if vary, ok := varyHints.Load(pr.primaryKey); ok {
+		return variantCacheKey(pr.primaryKey, vary, pr.Header)
+	}
+	return pr.primaryKey
+}
+
+// queryVariant queries the cache for the request's variant of the object
+// described by the vary manifest m. A variant that is not listed in the
+// manifest is a miss, since it may predate an eviction or purge of the object.
+func queryVariant(ctx context.Context, pr *proxyRequest, c cache.Cache,
+	m *HTTPDocument,
+) (*HTTPDocument, status.LookupStatus, byterange.Ranges, error) {
+	varyHints.Store(pr.primaryKey, m.Vary)
+	pr.key = variantCacheKey(pr.primaryKey, m.Vary, pr.Header)
+	if !slices.Contains(m.Variants, pr.key) {
+		return &HTTPDocument{}, status.LookupStatusKeyMiss, pr.wantedRanges, cache.ErrKNF
+	}
+	return QueryCache(ctx, c, pr.key, pr.wantedRanges, nil)
+}
+
+// variantStoreKey returns the key under which the document should be written to
+// the cache. When the document's response varies, its variant is recorded in
+// the object's vary manifest, evicting the oldest variants beyond the backend's
+// limit. The returned bool is false if the document must not be cached.
+func (pr *proxyRequest) variantStoreKey(d *HTTPDocument) (string, bool, error) {
+	d.headerLock.Lock()
+	vary, wildcard := parseVary(http.Header(d.Headers))
+	d.headerLock.Unlock()
+	if wildcard {
+		return "", false, nil
+	}
+	if len(vary) == 0 {
+		varyHints.Delete(pr.primaryKey)
+		return pr.primaryKey, true, nil
+	}
+
+	key := variantCacheKey(pr.primaryKey, vary, pr.Header)
+	if err := recordVariant(pr.upstreamRequest.Context(), pr.rsc.CacheClient,
+		pr.rsc.BackendOptions, pr.primaryKey, vary, key); err != nil {
+		return "", false, err
+	}
+	varyHints.Store(pr.primaryKey, vary)
+	return key, true, nil
+}
+
+// recordVariant adds the variant key to the vary manifest cached under
+// primaryKey, evicting the oldest variants beyond the backend's limit. Updates
+// to a manifest are serialized within this Trickster instance; with Distributed
+// Collapsed Forwarding, they are also made while holding the forwarding lease
+// on primaryKey, so instances sharing the cache don't overwrite each other.
+func recordVariant(ctx context.Context, c cache.Cache, o *options.Options,
+	primaryKey string, vary []string, key string,
+) error {
+	mtx := manifestLock(primaryKey)
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	var variants []string
+	if qr := queryConcurrent(ctx, c, primaryKey); qr.err == nil &&
+		qr.d.isVaryManifest() && slices.Equal(qr.d.Vary, vary) {
+		variants = slices.DeleteFunc(slices.Clone(qr.d.Variants),
+			func(k string) bool { return k == key })
+	}
+	variants = append(variants, key)
+
+	limit := o.MaxVaryVariants
+	if limit <= 0 {
+		limit = options.DefaultMaxVaryVariants
+	}
+	if n := len(variants) - limit; n > 0 {
+		for _, k := range variants[:n] {
+			c.Remove(k)
+		}
+		variants = variants[n:]
+	}
+
+	m := &HTTPDocument{Vary: vary, Variants: variants}
+	return writeConcurrent(ctx, c, primaryKey, m, false, time.Duration(o.MaxTTL))
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package engines
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/request"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testVaryHeader = "Accept-Language"
+
+// setupVaryTestHarness returns a request to an origin that varies its response
+// on the provided vary value, and whose response body is the request's
+// Accept-Language value followed by the origin's request count
+func setupVaryTestHarness(t *testing.T, vary string) (*http.Request, *request.Resources) {
+	t.Helper()
+	ts, _, r, rsc, err := setupTestHarnessOPC("", "test", http.StatusOK, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { closeTestHarness(ts, r) })
+	t.Cleanup(varyHints.Clear)
+	var requests atomic.Int32
+	o := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		w.Header().Set(headers.NameCacheControl, "max-age=60")
+		w.Header().Set(headers.NameVary, vary)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(r.Header.Get(testVaryHeader) + strconv.Itoa(int(n))))
+	}))
+	t.Cleanup(o.Close)
+	u, _ := url.Parse(o.URL)
+	r.URL.Scheme, r.URL.Host = u.Scheme, u.Host
+	return r, rsc
+}
+
+func expectVariant(t *testing.T, r *http.Request, lang, body, status string) {
+	t.Helper()
+	r.Header.Set(testVaryHeader, lang)
+	expectOPC(t, r, http.StatusOK, body, status)
+}
+
+func TestObjectProxyCacheVary(t *testing.T) {
+	t.Run("variants", func(t *testing.T) {
+		r, _ := setupVaryTestHarness(t, "accept-language, Accept-Encoding")
+		expectVariant(t, r, "en", "en1", "kmiss")
+		expectVariant(t, r, "fr", "fr2", "kmiss")
+		expectVariant(t, r, "en", "en1", "hit")
+		expectVariant(t, r, "fr", "fr2", "hit")
+	})
+
+	t.Run("max variants", func(t *testing.T) {
+		r, rsc := setupVaryTestHarness(t, testVaryHeader)
+		rsc.BackendOptions.MaxVaryVariants = 2
+		expectVariant(t, r, "en", "en1", "kmiss")
+		expectVariant(t, r, "fr", "fr2", "kmiss")
+		// the oldest stored variant, en, is evicted
+		expectVariant(t, r, "de", "de3", "kmiss")
+		expectVariant(t, r, "fr", "fr2", "hit")
+		expectVariant(t, r, "en", "en4", "kmiss")
+		expectVariant(t, r, "de", "de3", "hit")
+	})
+
+	t.Run("wildcard", func(t *testing.T) {
+		r, _ := setupVaryTestHarness(t, "*")
+		expectVariant(t, r, "en", "en1", "kmiss")
+		expectVariant(t, r, "en", "en2", "kmiss")
+	})
+}
+
+func TestParseVary(t *testing.T) {
+	h := http.Header{}
+	names, all := parseVary(h)
+	require.Empty(t, names)
+	require.False(t, all)
+
+	h.Add(headers.NameVary, "accept-encoding, Accept-Language")
+	h.Add(headers.NameVary, "Accept-Encoding,,origin")
+	names, all = parseVary(h)
+	require.Equal(t, []string{"Accept-Encoding", "Accept-Language", "Origin"}, names)
+	require.False(t, all)
+
+	h.Add(headers.NameVary, "*")
+	_, all = parseVary(h)
+	require.True(t, all)
+}
+
+func TestVaryHintCache(t *testing.T) {
+	c := newVaryHintCache(2)
+	c.Store("a", []string{"Accept"})
+	c.Store("b", []string{"Origin"})
+	// loading a makes b the least recently used hint
+	_, ok := c.Load("a")
+	require.True(t, ok)
+	c.Store("c", []string{"Accept-Language"})
+	_, ok = c.Load("b")
+	require.False(t, ok)
+	vary, ok := c.Load("a")
+	require.True(t, ok)
+	require.Equal(t, []string{"Accept"}, vary)
+	require.Len(t, c.hints, 2)
+
+	c.Delete("a")
+	_, ok = c.Load("a")
+	require.False(t, ok)
+	c.Clear()
+	require.Empty(t, c.hints)
+	require.Zero(t, c.order.Len())
+}
+
+// slowCache delays writes, widening the window in which concurrent vary
+// manifest updates could interleave
+type slowCache struct {
+	cache.Cache
+}
+
+func (c slowCache) StoreReference(key string, data cache.ReferenceObject,
+	ttl time.Duration,
+) error {
+	time.Sleep(time.Millisecond)
+	return c.Cache.(cache.MemoryCache).StoreReference(key, data, ttl)
+}
+
+func (c slowCache) RetrieveReference(key string) (any, status.LookupStatus, error) {
+	return c.Cache.(cache.MemoryCache).RetrieveReference(key)
+}
+
+func TestRecordVariantConcurrent(t *testing.T) {
+	const n = 32
+	for _, limit := range []int{n, 4} {
+		t.Run(strconv.Itoa(limit), func(t *testing.T) {
+			r, rsc := setupVaryTestHarness(t, testVaryHeader)
+			rsc.BackendOptions.MaxVaryVariants = limit
+			c := slowCache{rsc.CacheClient}
+			vary := []string{testVaryHeader}
+			start := make(chan struct{})
+			var wg sync.WaitGroup
+			for i := range n {
+				wg.Go(func() {
+					<-start
+					err := recordVariant(r.Context(), c, rsc.BackendOptions,
+						"primary", vary, "primary.variant."+strconv.Itoa(i))
+					require.NoError(t, err)
+				})
+			}
+			close(start)
+			wg.Wait()
+			qr := queryConcurrent(r.Context(), c, "primary")
+			require.NoError(t, qr.err)
+			require.Len(t, qr.d.Variants, min(n, limit))
+		})
+	}
+}
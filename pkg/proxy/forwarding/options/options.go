@@ -0,0 +1,115 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package options defines the configuration of Distributed Collapsed Forwarding,
+// which collapses cache misses across the Trickster instances sharing a cache
+package options
+
+import (
+	"errors"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	"github.com/trickstercache/trickster/v2/pkg/util/pointers"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// DefaultLeaseTTL is the default time a lease on a cache key is held
+	// before it expires
+	DefaultLeaseTTL = 5 * time.Second
+	// DefaultPollInterval is the default interval at which instances waiting
+	// on a lease check the cache for the object
+	DefaultPollInterval = 50 * time.Millisecond
+)
+
+var (
+	// ErrInvalidDistributedForwardingValue is returned when the lease TTL or poll interval is negative
+	ErrInvalidDistributedForwardingValue = errors.New("distributed_forwarding lease_ttl and poll_interval must be greater than or equal to 0")
+	// ErrInvalidPollInterval is returned when the poll interval is not shorter than the lease TTL
+	ErrInvalidPollInterval = errors.New("distributed_forwarding poll_interval must be less than lease_ttl")
+)
+
+// Options configures Distributed Collapsed Forwarding. On a cache miss, an
+// instance takes a lease on the cache key in the shared cache before fetching
+// the object from the origin. The other instances poll the cache for the
+// object until the lease expires, and then fetch it from the origin directly.
+type Options struct {
+	// LeaseTTL is the time a lease is held before it expires, which is also
+	// the longest time an instance waits for another to fetch the object
+	LeaseTTL timeconv.Duration `yaml:"lease_ttl,omitempty"`
+	// PollInterval is the interval at which instances waiting on a lease
+	// check the cache for the object
+	PollInterval timeconv.Duration `yaml:"poll_interval,omitempty"`
+}
+
+// New returns a new Distributed Collapsed Forwarding Options Reference with default values set
+func New() *Options {
+	return &Options{
+		LeaseTTL:     timeconv.Duration(DefaultLeaseTTL),
+		PollInterval: timeconv.Duration(DefaultPollInterval),
+	}
+}
+
+// Clone returns an exact copy of the Options
+func (o *Options) Clone() *Options {
+	if o == nil {
+		return nil
+	}
+	return pointers.Clone(o)
+}
+
+// Equal returns true if all values in the Options are identical
+func (o *Options) Equal(o2 *Options) bool {
+	if o == nil || o2 == nil {
+		return o == o2
+	}
+	return o.LeaseTTL == o2.LeaseTTL &&
+		o.PollInterval == o2.PollInterval
+}
+
+// Validate returns an error if the Options are invalid, and otherwise applies
+// the default values to any that are unset
+func (o *Options) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.LeaseTTL < 0 || o.PollInterval < 0 {
+		return ErrInvalidDistributedForwardingValue
+	}
+	if o.LeaseTTL == 0 {
+		o.LeaseTTL = timeconv.Duration(DefaultLeaseTTL)
+	}
+	if o.PollInterval == 0 {
+		o.PollInterval = timeconv.Duration(DefaultPollInterval)
+	}
+	if o.PollInterval >= o.LeaseTTL {
+		return ErrInvalidPollInterval
+	}
+	return nil
+}
+
+// UnmarshalYAML applies defaults before decoding a distributed_forwarding configuration block.
+func (o *Options) UnmarshalYAML(value *yaml.Node) error {
+	type loadOptions Options
+	lo := loadOptions(*(New()))
+	if err := value.Decode(&lo); err != nil {
+		return err
+	}
+	*o = Options(lo)
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestOptionsYAML(t *testing.T) {
+	const conf = `
+lease_ttl: 10s
+`
+	o := &Options{}
+	if err := yaml.Unmarshal([]byte(conf), o); err != nil {
+		t.Fatal(err)
+	}
+	if o.LeaseTTL != timeconv.Duration(10*time.Second) {
+		t.Errorf("LeaseTTL = %v, want 10s", o.LeaseTTL)
+	}
+	if o.PollInterval != timeconv.Duration(DefaultPollInterval) {
+		t.Errorf("PollInterval = %v, want %v", o.PollInterval, DefaultPollInterval)
+	}
+	o2 := o.Clone()
+	if !o.Equal(o2) {
+		t.Error("expected clone to be equal")
+	}
+	o2.PollInterval = timeconv.Duration(time.Second)
+	if o.Equal(o2) {
+		t.Error("expected poll_interval difference to make options unequal")
+	}
+	if o.Equal(nil) || (*Options)(nil).Clone() != nil {
+		t.Error("unexpected nil handling")
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *Options
+		wantErr error
+	}{
+		{name: "nil"},
+		{name: "default", options: New()},
+		{name: "unset", options: &Options{}},
+		{name: "negative lease_ttl", options: &Options{LeaseTTL: -1},
+			wantErr: ErrInvalidDistributedForwardingValue},
+		{name: "negative poll_interval", options: &Options{PollInterval: -1},
+			wantErr: ErrInvalidDistributedForwardingValue},
+		{name: "poll_interval exceeds lease_ttl",
+			options: &Options{LeaseTTL: timeconv.Duration(time.Second),
+				PollInterval: timeconv.Duration(2 * time.Second)},
+			wantErr: ErrInvalidPollInterval},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.options.Validate(); !errors.Is(err, tc.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+	o := &Options{}
+	if err := o.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if !o.Equal(New()) {
+		t.Errorf("expected Validate to apply defaults, got %+v", o)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loki
+
+import (
+	"testing"
+
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	co "github.com/trickstercache/trickster/v2/pkg/cache/options"
+)
+
+func TestNewAccelerator(t *testing.T) {
+	h, err := NewAccelerator("http://127.0.0.1:3100/loki")
+	if err != nil {
+		t.Fatalf("NewAccelerator: %v", err)
+	}
+	if h == nil {
+		t.Fatal("expected non-nil handler")
+	}
+}
+
+func TestNewAcceleratorWithOptions(t *testing.T) {
+	o := bo.New()
+	o.Name = "loki-test"
+	c := co.New()
+	c.Name = "loki-cache"
+	h, err := NewAcceleratorWithOptions("http://127.0.0.1:3100", o, c)
+	if err != nil {
+		t.Fatalf("NewAcceleratorWithOptions: %v", err)
+	}
+	if h == nil {
+		t.Fatal("expected non-nil handler")
+	}
+}
+
+func TestNewAcceleratorBadURL(t *testing.T) {
+	_, err := NewAccelerator("http://[::1")
+	if err == nil {
+		t.Fatal("expected URL parse error")
+	}
+}
@@ -0,0 +1,254 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package inspect provides read-only management handlers for listing the
+// objects in Trickster's caches and inspecting the metadata of a cached object
+package inspect
+
+import (
+	"encoding/json"
+	"errors"
+	"html"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/index"
+	yamlencoding "github.com/trickstercache/trickster/v2/pkg/encoding/yaml"
+	proxyengines "github.com/trickstercache/trickster/v2/pkg/proxy/engines"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+// DefaultKeysLimit is the default maximum number of objects listed per cache
+const DefaultKeysLimit = 1000
+
+type objectSummary struct {
+	Key        string `json:"key" yaml:"key"`
+	Size       int64  `json:"size,omitempty" yaml:"size,omitempty"`
+	LastWrite  string `json:"lastWrite,omitempty" yaml:"lastWrite,omitempty"`
+	LastAccess string `json:"lastAccess,omitempty" yaml:"lastAccess,omitempty"`
+	Expiration string `json:"expiration,omitempty" yaml:"expiration,omitempty"`
+}
+
+type cacheKeys struct {
+	Name      string          `json:"name" yaml:"name"`
+	Provider  string          `json:"provider" yaml:"provider"`
+	Indexed   bool            `json:"indexed" yaml:"indexed"`
+	Count     int             `json:"count" yaml:"count"`
+	Truncated bool            `json:"truncated,omitempty" yaml:"truncated,omitempty"`
+	Objects   []objectSummary `json:"objects" yaml:"objects"`
+}
+
+type keyList struct {
+	Caches []cacheKeys `json:"caches" yaml:"caches"`
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func writeValidationError(w http.ResponseWriter, code int, errorMsg string) {
+	w.Header().Set(headers.NameContentType, headers.ValueTextPlain)
+	w.Header().Set(headers.NameCacheControl, headers.ValueNoCache)
+	w.WriteHeader(code)
+	w.Write([]byte(errorMsg))
+}
+
+// writeResult writes v as YAML when the client provides an 'Accept:
+// application/yaml' header or a ?yaml query param, and as JSON otherwise
+func writeResult(w http.ResponseWriter, r *http.Request, v any) {
+	var b []byte
+	var err error
+	var ct string
+	if headers.AcceptsYAML(r) || r.URL.Query().Has("yaml") {
+		b, err = yamlencoding.Marshal(v)
+		ct = headers.ValueTextYAML
+	} else {
+		b, err = json.Marshal(v)
+		ct = headers.ValueApplicationJSON
+	}
+	if err != nil {
+		writeValidationError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set(headers.NameContentType, ct)
+	w.Header().Set(headers.NameCacheControl, headers.ValueNoCache)
+	w.WriteHeader(http.StatusOK)
+	w.Write(b)
+}
+
+// backendCache returns the named backend and its cache, or writes an error
+// response and returns false
+func backendCache(w http.ResponseWriter, from *backends.Backends,
+	backendName string,
+) (backends.Backend, cache.Cache, bool) {
+	backend := from.Get(backendName)
+	if backend == nil {
+		writeValidationError(w, http.StatusBadRequest,
+			"Backend "+html.EscapeString(backendName)+" doesn't exist.")
+		return nil, nil, false
+	}
+	c := backend.Cache()
+	if c == nil {
+		writeValidationError(w, http.StatusBadRequest,
+			"Backend "+html.EscapeString(backendName)+" doesn't have a cache.")
+		return nil, nil, false
+	}
+	return backend, c, true
+}
+
+// KeysHandler lists the objects in the caches, with their size, last write,
+// last access and expiration times when the cache is indexed. The list can be
+// limited to the objects written by a 'backend', or to those whose keys start
+// with a 'prefix'. At most 'limit' objects are listed per cache.
+func KeysHandler(from *backends.Backends) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		qp := r.URL.Query()
+		limit := DefaultKeysLimit
+		if l := qp.Get("limit"); l != "" {
+			var err error
+			if limit, err = strconv.Atoi(l); err != nil || limit < 1 {
+				writeValidationError(w, http.StatusBadRequest, "invalid limit")
+				return
+			}
+		}
+		prefix := qp.Get("prefix")
+		var caches []cache.Cache
+		var backendPrefix string
+		if backendName := qp.Get("backend"); backendName != "" {
+			backend, c, ok := backendCache(w, from, backendName)
+			if !ok {
+				return
+			}
+			caches = []cache.Cache{c}
+			cfg := backend.Configuration()
+			backendPrefix = proxyengines.ComposeCacheKey(cfg.Name, cfg.CacheKeyPrefix, "", "")
+		} else {
+			for _, name := range slices.Sorted(maps.Keys(*from)) {
+				if c := (*from)[name].Cache(); c != nil && !slices.Contains(caches, c) {
+					caches = append(caches, c)
+				}
+			}
+		}
+		// query the index with the longer of the two prefixes, and filter by both
+		qprefix := backendPrefix
+		if len(prefix) > len(qprefix) {
+			qprefix = prefix
+		}
+		out := &keyList{Caches: make([]cacheKeys, 0, len(caches))}
+		for _, c := range caches {
+			cfg := c.Configuration()
+			ck := cacheKeys{Name: cfg.Name, Provider: cfg.Provider, Objects: []objectSummary{}}
+			if l, ok := c.(index.Lister); ok {
+				var objs []*index.Object
+				objs, ck.Indexed = l.IndexedObjects(qprefix)
+				for _, o := range objs {
+					if !strings.HasPrefix(o.Key, prefix) ||
+						!strings.HasPrefix(o.Key, backendPrefix) {
+						continue
+					}
+					ck.Count++
+					if len(ck.Objects) == limit {
+						ck.Truncated = true
+						continue
+					}
+					ck.Objects = append(ck.Objects, objectSummary{
+						Key:        o.Key,
+						Size:       o.Size,
+						LastWrite:  formatTime(o.LastWrite.Load()),
+						LastAccess: formatTime(o.LastAccess.Load()),
+						Expiration: formatTime(o.Expiration.Load()),
+					})
+				}
+			}
+			out.Caches = append(out.Caches, ck)
+		}
+		writeResult(w, r, out)
+	}
+}
+
+// ObjectHandler returns the metadata of the object cached by a 'backend' under
+// a 'key', without its body. The key must be one written by the backend. For timeseries backends, the cached extents are
+// included. The chunks of a chunked object are listed from the cache index;
+// for caches without an index, the chunks of a chunked timeseries object are
+// looked up between the optional 'start' and 'end' times.
+func ObjectHandler(from *backends.Backends) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		qp := r.URL.Query()
+		backendName, key := qp.Get("backend"), qp.Get("key")
+		if backendName == "" || key == "" {
+			writeValidationError(w, http.StatusBadRequest, "Usage: "+r.URL.Path+
+				"?backend={backend}&key={key}[&start={start}&end={end}]\n")
+			return
+		}
+		var e timeseries.Extent
+		if s, en := qp.Get("start"), qp.Get("end"); s != "" || en != "" {
+			var err error
+			if e.Start, err = proxyengines.ParseTime(s); err != nil {
+				writeValidationError(w, http.StatusBadRequest,
+					"invalid start: "+html.EscapeString(err.Error()))
+				return
+			}
+			if e.End, err = proxyengines.ParseTime(en); err != nil {
+				writeValidationError(w, http.StatusBadRequest,
+					"invalid end: "+html.EscapeString(err.Error()))
+				return
+			}
+			if e.End.Before(e.Start) {
+				writeValidationError(w, http.StatusBadRequest,
+					proxyengines.ErrInvalidExtent.Error())
+				return
+			}
+		}
+		backend, c, ok := backendCache(w, from, backendName)
+		if !ok {
+			return
+		}
+		// only the objects cached by the backend can be inspected through it
+		cfg := backend.Configuration()
+		if !strings.HasPrefix(key,
+			proxyengines.ComposeCacheKey(cfg.Name, cfg.CacheKeyPrefix, "", "")) {
+			writeValidationError(w, http.StatusBadRequest, "Key "+html.EscapeString(key)+
+				" is not cached by backend "+html.EscapeString(backendName)+".")
+			return
+		}
+		var modeler *timeseries.Modeler
+		if tm, ok := backend.(proxyengines.TimeseriesModeler); ok {
+			modeler = tm.Modeler()
+		}
+		oi, err := proxyengines.InspectObject(r.Context(), c, modeler, key, e)
+		if errors.Is(err, cache.ErrKNF) {
+			writeValidationError(w, http.StatusNotFound,
+				"Key "+html.EscapeString(key)+" is not in the cache.")
+			return
+		}
+		if err != nil {
+			writeValidationError(w, http.StatusInternalServerError,
+				html.EscapeString(err.Error()))
+			return
+		}
+		writeResult(w, r, oi)
+	}
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inspect
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/manager"
+	"github.com/trickstercache/trickster/v2/pkg/cache/memory"
+	co "github.com/trickstercache/trickster/v2/pkg/cache/options"
+	proxyengines "github.com/trickstercache/trickster/v2/pkg/proxy/engines"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBackend struct {
+	backends.Backend
+	cfg   *bo.Options
+	cache cache.Cache
+}
+
+func (f *fakeBackend) Configuration() *bo.Options { return f.cfg }
+func (f *fakeBackend) Cache() cache.Cache         { return f.cache }
+
+// newTestCache returns an indexed cache holding a document for each key
+func newTestCache(t *testing.T, name string, keys ...string) cache.Cache {
+	t.Helper()
+	cfg := co.New()
+	cfg.Name = name
+	cfg.Provider = "filesystem"
+	c := manager.NewCache(memory.New(name, cfg), manager.CacheOptions{UseIndex: true}, cfg)
+	require.NoError(t, c.Connect())
+	t.Cleanup(func() { c.Close() })
+	for _, k := range keys {
+		d := &proxyengines.HTTPDocument{
+			StatusCode:    http.StatusOK,
+			Status:        "200 OK",
+			ContentType:   headers.ValueApplicationJSON,
+			ContentLength: 2,
+			Body:          []byte("{}"),
+			Headers:       map[string][]string{headers.NameContentType: {headers.ValueApplicationJSON}},
+			Tags:          []string{"t1"},
+		}
+		b, err := d.MarshalReference()
+		require.NoError(t, err)
+		require.NoError(t, c.Store(k, b, time.Hour))
+	}
+	return c
+}
+
+func TestKeysHandler(t *testing.T) {
+	c1 := newTestCache(t, "c1", "a.h.opc.1", "a.h.opc.2", "b.h.opc.1")
+	c2 := newTestCache(t, "c2", "c.h.opc.1")
+	bes := backends.Backends{
+		"a":        &fakeBackend{cfg: &bo.Options{Name: "a", CacheKeyPrefix: "h"}, cache: c1},
+		"b":        &fakeBackend{cfg: &bo.Options{Name: "b", CacheKeyPrefix: "h"}, cache: c1},
+		"c":        &fakeBackend{cfg: &bo.Options{Name: "c", CacheKeyPrefix: "h"}, cache: c2},
+		"no-cache": &fakeBackend{cfg: &bo.Options{Name: "no-cache"}},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		code  int
+		keys  map[string][]string
+	}{
+		{
+			name: "all",
+			code: http.StatusOK,
+			keys: map[string][]string{
+				"c1": {"a.h.opc.1", "a.h.opc.2", "b.h.opc.1"},
+				"c2": {"c.h.opc.1"},
+			},
+		},
+		{
+			name:  "backend",
+			query: "?backend=a",
+			code:  http.StatusOK,
+			keys:  map[string][]string{"c1": {"a.h.opc.1", "a.h.opc.2"}},
+		},
+		{
+			name:  "backend and prefix",
+			query: "?backend=a&prefix=a.h.opc.2",
+			code:  http.StatusOK,
+			keys:  map[string][]string{"c1": {"a.h.opc.2"}},
+		},
+		{
+			name:  "backend and mismatched prefix",
+			query: "?backend=a&prefix=b.",
+			code:  http.StatusOK,
+			keys:  map[string][]string{"c1": {}},
+		},
+		{
+			name:  "prefix",
+			query: "?prefix=b.",
+			code:  http.StatusOK,
+			keys:  map[string][]string{"c1": {"b.h.opc.1"}, "c2": {}},
+		},
+		{
+			name:  "limit",
+			query: "?backend=a&limit=1",
+			code:  http.StatusOK,
+			keys:  map[string][]string{"c1": {"a.h.opc.1"}},
+		},
+		{
+			name:  "invalid limit",
+			query: "?limit=0",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "missing backend",
+			query: "?backend=missing",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "no cache",
+			query: "?backend=no-cache",
+			code:  http.StatusBadRequest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/trickster/cache/keys"+test.query, nil)
+			KeysHandler(&bes)(w, r)
+			require.Equal(t, test.code, w.Code, w.Body.String())
+			if test.code != http.StatusOK {
+				return
+			}
+			require.Equal(t, headers.ValueApplicationJSON, w.Header().Get(headers.NameContentType))
+			var kl keyList
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &kl))
+			require.Len(t, kl.Caches, len(test.keys))
+			for _, ck := range kl.Caches {
+				require.True(t, ck.Indexed)
+				keys := make([]string, len(ck.Objects))
+				for i, o := range ck.Objects {
+					keys[i] = o.Key
+					require.Positive(t, o.Size)
+					require.NotEmpty(t, o.LastAccess)
+					require.NotEmpty(t, o.Expiration)
+				}
+				require.Equal(t, test.keys[ck.Name], keys)
+				require.Equal(t, ck.Count > len(keys), ck.Truncated)
+			}
+		})
+	}
+}
+
+func TestObjectHandler(t *testing.T) {
+	c := newTestCache(t, "c1", "a.h.opc.1")
+	bes := backends.Backends{
+		"a": &fakeBackend{cfg: &bo.Options{Name: "a", CacheKeyPrefix: "h"}, cache: c},
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		accept string
+		code   int
+	}{
+		{name: "json", query: "?backend=a&key=a.h.opc.1", code: http.StatusOK},
+		{name: "yaml", query: "?backend=a&key=a.h.opc.1&yaml", code: http.StatusOK},
+		{
+			name: "yaml accept header", query: "?backend=a&key=a.h.opc.1",
+			accept: headers.ValueApplicationYAML, code: http.StatusOK,
+		},
+		{name: "with extent", query: "?backend=a&key=a.h.opc.1&start=0&end=60", code: http.StatusOK},
+		{name: "usage error", query: "?backend=a", code: http.StatusBadRequest},
+		{name: "invalid start", query: "?backend=a&key=k&start=x&end=60", code: http.StatusBadRequest},
+		{name: "reversed extent", query: "?backend=a&key=k&start=60&end=0", code: http.StatusBadRequest},
+		{name: "missing backend", query: "?backend=missing&key=k", code: http.StatusBadRequest},
+		{name: "missing key", query: "?backend=a&key=a.h.opc.2", code: http.StatusNotFound},
+		{name: "other backend key", query: "?backend=a&key=b.h.opc.1", code: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/trickster/cache/object"+test.query, nil)
+			if test.accept != "" {
+				r.Header.Set(headers.NameAccept, test.accept)
+			}
+			ObjectHandler(&bes)(w, r)
+			require.Equal(t, test.code, w.Code, w.Body.String())
+			if test.code != http.StatusOK {
+				return
+			}
+			body := w.Body.String()
+			if strings.Contains(test.name, "yaml") {
+				require.Equal(t, headers.ValueTextYAML, w.Header().Get(headers.NameContentType))
+				require.Contains(t, body, "key: a.h.opc.1")
+				return
+			}
+			var oi proxyengines.ObjectInfo
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &oi))
+			require.Equal(t, "a.h.opc.1", oi.Key)
+			require.Equal(t, http.StatusOK, oi.StatusCode)
+			require.Equal(t, headers.ValueApplicationJSON, oi.ContentType)
+			require.Equal(t, 2, oi.BodySize)
+			require.Equal(t, []string{"t1"}, oi.Tags)
+			require.Equal(t, []string{headers.ValueApplicationJSON}, oi.Headers[headers.NameContentType])
+		})
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package peer provides the management handler through which Trickster peers
+// read, write and remove the objects owned by this instance's Peer caches
+package peer
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	"github.com/trickstercache/trickster/v2/pkg/cache/manager"
+	pc "github.com/trickstercache/trickster/v2/pkg/cache/peer"
+	"github.com/trickstercache/trickster/v2/pkg/cache/status"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+)
+
+// findCache returns the Peer cache with the provided name that is used by any
+// of the backends
+func findCache(from *backends.Backends, name string) *pc.Cache {
+	for _, b := range *from {
+		c := b.Cache()
+		if c == nil || c.Configuration() == nil || c.Configuration().Name != name {
+			continue
+		}
+		if m, ok := c.(*manager.Manager); ok {
+			if p, ok := m.Client.(*pc.Cache); ok {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// CacheHandler serves the objects owned by this instance's Peer caches to its
+// peers, which read, write and remove them with GET, PUT and DELETE requests
+// to {pathPrefix}{cacheName}/{cacheKey}. Requests must carry the shared
+// secret of the Peer cache's peers, and are served from its local cache.
+func CacheHandler(pathPrefix string,
+	from *backends.Backends,
+) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set(headers.NameCacheControl, headers.ValueNoCache)
+		name, key, ok := strings.Cut(strings.TrimPrefix(req.URL.Path, pathPrefix), "/")
+		if !ok || name == "" || key == "" {
+			http.NotFound(w, req)
+			return
+		}
+		c := findCache(from, name)
+		if c == nil {
+			http.NotFound(w, req)
+			return
+		}
+		if !c.Authorized(req) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		local := c.Local()
+		switch req.Method {
+		case http.MethodGet:
+			b, s, err := local.Retrieve(key)
+			if err != nil || s != status.LookupStatusHit {
+				http.NotFound(w, req)
+				return
+			}
+			w.Header().Set(headers.NameContentType, headers.ValueApplicationOctetStream)
+			w.WriteHeader(http.StatusOK)
+			w.Write(b)
+		case http.MethodPut:
+			var ttl time.Duration
+			if v := req.URL.Query().Get(pc.TTLParam); v != "" {
+				d, err := time.ParseDuration(v)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				ttl = d
+			}
+			b, err := io.ReadAll(http.MaxBytesReader(w, req.Body, c.MaxObjectSizeBytes()))
+			if err != nil {
+				if mbe := (*http.MaxBytesError)(nil); errors.As(err, &mbe) {
+					w.WriteHeader(http.StatusRequestEntityTooLarge)
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if err := local.Store(key, b, ttl); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			if err := local.Remove(key); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
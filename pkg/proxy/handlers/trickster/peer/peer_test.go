@@ -0,0 +1,148 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peer
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/manager"
+	"github.com/trickstercache/trickster/v2/pkg/cache/memory"
+	"github.com/trickstercache/trickster/v2/pkg/cache/options"
+	pc "github.com/trickstercache/trickster/v2/pkg/cache/peer"
+	peeropts "github.com/trickstercache/trickster/v2/pkg/cache/peer/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/providers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	po "github.com/trickstercache/trickster/v2/pkg/proxy/peers/options"
+)
+
+const testSecret = "s3cret"
+
+// fakeBackend exposes just the Backend surface CacheHandler touches.
+type fakeBackend struct {
+	backends.Backend
+	cfg   *bo.Options
+	cache cache.Cache
+}
+
+func (f *fakeBackend) Configuration() *bo.Options { return f.cfg }
+func (f *fakeBackend) Cache() cache.Cache         { return f.cache }
+
+func newPeerCache(t *testing.T) (cache.Cache, cache.Cache) {
+	t.Helper()
+	lcfg := &options.Options{Name: "local", Provider: providers.Memory,
+		ProviderID: providers.MemoryID}
+	local := manager.NewCache(memory.New("local", lcfg), manager.CacheOptions{}, lcfg)
+	if err := local.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = local.Close() })
+	cfg := &options.Options{Name: "peer", Provider: providers.Peer,
+		ProviderID: providers.PeerID, Peer: peeropts.New()}
+	cfg.Peer.Local = "local"
+	cfg.Peer.Peers = &po.Options{Peers: []string{"http://trickster-1:8484"},
+		Self: "http://trickster-1:8484", Secret: testSecret}
+	if err := cfg.Peer.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	c := manager.NewCache(pc.New("peer", cfg, cache.Lookup{"local": local}),
+		manager.CacheOptions{}, cfg)
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c, local
+}
+
+func TestCacheHandler(t *testing.T) {
+	const pathPrefix = pc.HandlerPath
+	c, local := newPeerCache(t)
+	bes := backends.Backends{
+		"backend-a": &fakeBackend{cfg: &bo.Options{Name: "backend-a"}, cache: c},
+	}
+	h := CacheHandler(pathPrefix, &bes)
+
+	serve := func(method, path, secret, body string) *httptest.ResponseRecorder {
+		var r *http.Request
+		if body != "" {
+			r = httptest.NewRequest(method, path, strings.NewReader(body))
+		} else {
+			r = httptest.NewRequest(method, path, nil)
+		}
+		if secret != "" {
+			r.Header.Set(headers.NameTricksterPeerToken, secret)
+		}
+		w := httptest.NewRecorder()
+		h(w, r)
+		return w
+	}
+
+	if w := serve(http.MethodPut, pathPrefix+"peer/key1?ttl=1m", testSecret, "value"); w.Code != http.StatusNoContent {
+		t.Fatalf("put status = %d", w.Code)
+	}
+	if b, _, err := local.Retrieve("key1"); err != nil || string(b) != "value" {
+		t.Fatalf("expected stored value in local cache, got %q %v", b, err)
+	}
+
+	w := serve(http.MethodGet, pathPrefix+"peer/key1", testSecret, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("get status = %d", w.Code)
+	}
+	if b, _ := io.ReadAll(w.Body); string(b) != "value" {
+		t.Errorf("expected value got %q", b)
+	}
+	if got := w.Header().Get(headers.NameContentType); got != headers.ValueApplicationOctetStream {
+		t.Errorf("Content-Type = %q", got)
+	}
+
+	if w := serve(http.MethodDelete, pathPrefix+"peer/key1", testSecret, ""); w.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d", w.Code)
+	}
+
+	c.(*manager.Manager).Client.(*pc.Cache).Config.Peer.MaxObjectSizeBytes = 4
+	if w := serve(http.MethodPut, pathPrefix+"peer/key1", testSecret, "value"); w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("oversized put status = %d", w.Code)
+	}
+	if _, _, err := local.Retrieve("key1"); err == nil {
+		t.Error("expected the oversized object not to be stored")
+	}
+
+	tests := []struct {
+		name, method, path, secret string
+		want                       int
+	}{
+		{"miss", http.MethodGet, pathPrefix + "peer/key1", testSecret, http.StatusNotFound},
+		{"unauthorized", http.MethodGet, pathPrefix + "peer/key1", "wrong", http.StatusUnauthorized},
+		{"missing cache", http.MethodGet, pathPrefix + "missing/key1", testSecret, http.StatusNotFound},
+		{"missing key", http.MethodGet, pathPrefix + "peer", testSecret, http.StatusNotFound},
+		{"bad ttl", http.MethodPut, pathPrefix + "peer/key1?ttl=x", testSecret, http.StatusBadRequest},
+		{"bad method", http.MethodPost, pathPrefix + "peer/key1", testSecret, http.StatusMethodNotAllowed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if w := serve(test.method, test.path, test.secret, ""); w.Code != test.want {
+				t.Errorf("status = %d, want %d", w.Code, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package purge
+
+import (
+	"fmt"
+	"html"
+	"maps"
+	"net/http"
+
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
+	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/peers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/response/capture"
+)
+
+// Broadcast wraps a purge handler so that a purge made by a client is also
+// made on each peer in the Group, with the result from each peer appended to
+// the response. A purge broadcast by a peer is only made locally, and is
+// rejected unless it carries the Group's shared secret. When the Group is nil,
+// the handler is returned unwrapped.
+func Broadcast(g *peers.Group,
+	h func(http.ResponseWriter, *http.Request),
+) func(http.ResponseWriter, *http.Request) {
+	if g == nil {
+		return h
+	}
+	return func(w http.ResponseWriter, req *http.Request) {
+		if peers.IsPeerRequest(req) {
+			if !g.Authorized(req) {
+				logger.Warn("rejected unauthorized peer purge",
+					logging.Pairs{"path": req.URL.Path, "remoteAddr": req.RemoteAddr})
+				w.Header().Set(headers.NameContentType, headers.ValueTextPlain)
+				w.Header().Set(headers.NameCacheControl, headers.ValueNoCache)
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte("unauthorized peer request\n"))
+				return
+			}
+			h(w, req)
+			return
+		}
+		cw := capture.NewCaptureResponseWriter()
+		h(cw, req)
+		maps.Copy(w.Header(), cw.Header())
+		w.Header().Del(headers.NameContentLength)
+		w.WriteHeader(cw.StatusCode())
+		w.Write(cw.Body())
+		if cw.StatusCode() < 200 || cw.StatusCode() >= 300 {
+			return
+		}
+		results, err := g.Broadcast(req)
+		if err != nil {
+			logger.Warn("failed to resolve peers for purge",
+				logging.Pairs{"path": req.URL.Path, "error": err})
+			w.Write(fmt.Appendf(nil, "peers: %s\n", html.EscapeString(err.Error())))
+		}
+		for _, r := range results {
+			if r.OK() {
+				w.Write(fmt.Appendf(nil, "peer: %s | ok\n", html.EscapeString(r.Peer)))
+				continue
+			}
+			detail := http.StatusText(r.StatusCode)
+			if r.Err != nil {
+				detail = r.Err.Error()
+			}
+			logger.Warn("failed to purge on peer",
+				logging.Pairs{"peer": r.Peer, "path": req.URL.Path, "detail": detail})
+			w.Write(fmt.Appendf(nil, "peer: %s | failed | %s\n",
+				html.EscapeString(r.Peer), html.EscapeString(detail)))
+		}
+	}
+}
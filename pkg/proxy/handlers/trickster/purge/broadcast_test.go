@@ -0,0 +1,119 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package purge
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/peers"
+	po "github.com/trickstercache/trickster/v2/pkg/proxy/peers/options"
+)
+
+func newPeerGroup(t *testing.T, urls ...string) *peers.Group {
+	t.Helper()
+	o := &po.Options{Peers: urls, Secret: "s3cret"}
+	if err := o.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return peers.New(o)
+}
+
+func TestBroadcast(t *testing.T) {
+	const pathPrefix = "/trickster/purge/key/"
+	const key = "object-key"
+
+	newBackends := func() (backends.Backends, *memCache) {
+		c := newMemCache()
+		c.Store(key, []byte("v"), 0)
+		return backends.Backends{
+			"backend-a": &fakeBackend{cfg: &bo.Options{Name: "backend-a"}, cache: c},
+		}, c
+	}
+
+	// the peer's own group is only used to authenticate the broadcast
+	peerBackends, peerCache := newBackends()
+	peer := httptest.NewServer(http.HandlerFunc(Broadcast(newPeerGroup(t, "unused:8484"),
+		KeyHandler(pathPrefix, peerBackends))))
+	defer peer.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	localBackends, localCache := newBackends()
+	h := Broadcast(newPeerGroup(t, peer.URL, down.URL), KeyHandler(pathPrefix, localBackends))
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodDelete, pathPrefix+"backend-a/"+key, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d body=%s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		"purged: backend-a | " + key + "\n",
+		"peer: " + peer.URL + " | ok\n",
+		"peer: " + down.URL + " | failed | ",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected body to contain %q, got %q", want, body)
+		}
+	}
+	if _, _, err := localCache.Retrieve(key); err == nil {
+		t.Error("expected key to be purged locally")
+	}
+	if _, _, err := peerCache.Retrieve(key); err == nil {
+		t.Error("expected key to be purged on the peer")
+	}
+
+	t.Run("local failure is not broadcast", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodDelete, pathPrefix+"missing/"+key, nil))
+		if w.Code != http.StatusBadRequest || strings.Contains(w.Body.String(), "peer:") {
+			t.Errorf("status = %d body=%s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("unauthorized peer", func(t *testing.T) {
+		peerCache.Store(key, []byte("v"), 0)
+		r, _ := http.NewRequest(http.MethodDelete, peer.URL+pathPrefix+"backend-a/"+key, nil)
+		r.Header.Set(headers.NameTricksterPeerToken, "wrong")
+		resp, err := http.DefaultClient.Do(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("status = %d", resp.StatusCode)
+		}
+		if _, _, err := peerCache.Retrieve(key); err != nil {
+			t.Error("expected key to remain cached on the peer")
+		}
+	})
+
+	t.Run("no peers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		Broadcast(nil, KeyHandler(pathPrefix, localBackends))(w,
+			httptest.NewRequest(http.MethodDelete, pathPrefix+"backend-a/"+key, nil))
+		if w.Code != http.StatusOK || strings.Contains(w.Body.String(), "peer:") {
+			t.Errorf("status = %d body=%s", w.Code, w.Body.String())
+		}
+	})
+}
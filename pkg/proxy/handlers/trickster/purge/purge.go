@@ -17,18 +17,25 @@
 package purge
 
 import (
+	"errors"
 	"fmt"
 	"html"
+	"maps"
 	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/trickstercache/trickster/v2/pkg/backends"
 	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/index"
 	"github.com/trickstercache/trickster/v2/pkg/checksum/md5"
 	"github.com/trickstercache/trickster/v2/pkg/observability/logging"
 	"github.com/trickstercache/trickster/v2/pkg/observability/logging/logger"
 	proxyengines "github.com/trickstercache/trickster/v2/pkg/proxy/engines"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
 )
 
 // writeValidationError writes a standardized validation error response
@@ -47,6 +54,33 @@ func writePurgeResult(w http.ResponseWriter, backendName, target string) {
 		html.EscapeString(backendName), html.EscapeString(target)))
 }
 
+// writeTagIndexUnavailable writes the response for a tag-based request that
+// could not be completed, because the tag indexes of the named caches are
+// unavailable
+func writeTagIndexUnavailable(w http.ResponseWriter, backendName, target string,
+	cacheNames []string,
+) {
+	w.Header().Set(headers.NameContentType, headers.ValueTextPlain)
+	w.Header().Set(headers.NameCacheControl, headers.ValueNoCache)
+	w.WriteHeader(http.StatusServiceUnavailable)
+	w.Write(fmt.Appendf(nil, "purged: %s | %s\n%s: %s\n",
+		html.EscapeString(backendName), html.EscapeString(target),
+		cache.ErrTagIndexUnavailable.Error(),
+		html.EscapeString(strings.Join(cacheNames, ", "))))
+}
+
+// writeInvalidationFailed writes the response for an extent invalidation
+// that failed for some of its objects, so the caller can tell that the
+// invalidation is partial
+func writeInvalidationFailed(w http.ResponseWriter, backendName, target string, err error) {
+	w.Header().Set(headers.NameContentType, headers.ValueTextPlain)
+	w.Header().Set(headers.NameCacheControl, headers.ValueNoCache)
+	w.WriteHeader(http.StatusInternalServerError)
+	w.Write(fmt.Appendf(nil, "purged: %s | %s\nfailed to invalidate: %s\n",
+		html.EscapeString(backendName), html.EscapeString(target),
+		html.EscapeString(err.Error())))
+}
+
 // validateBackend checks if the backend exists and writes an error response if not
 // Returns true if valid, false if invalid (and error response was written)
 func validateBackend(w http.ResponseWriter, backend backends.Backend, backendName string) bool {
@@ -146,3 +180,158 @@ func PathHandler(pathPrefix string,
 		writePurgeResult(w, backendName, purgePath)
 	}
 }
+
+// TagHandler purges every object from the cache(s) that matches all of the
+// provided query parameters: one or more 'tag' values, a 'backend' name, or a
+// key 'prefix'. When no backend is provided, the caches of all backends are
+// purged.
+func TagHandler(from *backends.Backends) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		qp := req.URL.Query()
+		tags := slices.DeleteFunc(qp["tag"], func(t string) bool { return t == "" })
+		backendName := qp.Get("backend")
+		prefix := qp.Get("prefix")
+		if len(tags) == 0 && backendName == "" && prefix == "" {
+			writeValidationError(w, "Usage: "+req.URL.Path+
+				"?tag={tag}&backend={backend}&prefix={keyPrefix}\n")
+			return
+		}
+		var caches []cache.Cache
+		scope := "*"
+		if backendName != "" {
+			backend := from.Get(backendName)
+			if !validateBackend(w, backend, backendName) {
+				return
+			}
+			c := backend.Cache()
+			if !validateCache(w, c, backendName) {
+				return
+			}
+			caches = []cache.Cache{c}
+			tags = append(tags, index.BackendTag(backendName))
+			scope = backendName
+		} else {
+			for _, name := range slices.Sorted(maps.Keys(*from)) {
+				if c := (*from)[name].Cache(); c != nil && !slices.Contains(caches, c) {
+					caches = append(caches, c)
+				}
+			}
+		}
+		logger.Debug("purging tagged cache items",
+			logging.Pairs{"backend": scope, "tags": tags, "prefix": prefix})
+		var total int
+		var unavailable []string
+		for _, c := range caches {
+			tg, ok := c.(cache.Tagger)
+			if !ok {
+				continue
+			}
+			n, err := tg.PurgeTagged(tags, prefix)
+			if errors.Is(err, cache.ErrTagIndexUnavailable) {
+				unavailable = append(unavailable, c.Configuration().Name)
+			} else if err != nil {
+				logger.Warn("failed to purge tagged cache items",
+					logging.Pairs{"cache": c.Configuration().Name, "error": err})
+			}
+			total += n
+		}
+		if len(unavailable) > 0 {
+			writeTagIndexUnavailable(w, scope, strconv.Itoa(total)+" keys", unavailable)
+			return
+		}
+		writePurgeResult(w, scope, strconv.Itoa(total)+" keys")
+	}
+}
+
+// ExtentHandler removes a time range from the timeseries objects that a
+// backend has cached using the Delta Proxy Cache, so that only that time range
+// is refetched from the origin. The 'backend', 'start' and 'end' query
+// parameters are required. The objects can be limited to a single cache 'key',
+// to objects having one or more 'tag' values, or to objects whose cached query
+// statement matches the 'query' regular expression.
+func ExtentHandler(from *backends.Backends) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		qp := req.URL.Query()
+		backendName := qp.Get("backend")
+		if backendName == "" || qp.Get("start") == "" || qp.Get("end") == "" {
+			writeValidationError(w, "Usage: "+req.URL.Path+
+				"?backend={backend}&start={start}&end={end}"+
+				"[&key={key}][&tag={tag}][&query={regexp}]\n")
+			return
+		}
+		var e timeseries.Extent
+		var err error
+		if e.Start, err = proxyengines.ParseTime(qp.Get("start")); err != nil {
+			writeValidationError(w, "invalid start: "+html.EscapeString(err.Error()))
+			return
+		}
+		if e.End, err = proxyengines.ParseTime(qp.Get("end")); err != nil {
+			writeValidationError(w, "invalid end: "+html.EscapeString(err.Error()))
+			return
+		}
+		if e.End.Before(e.Start) {
+			writeValidationError(w, proxyengines.ErrInvalidExtent.Error())
+			return
+		}
+		var query *regexp.Regexp
+		if q := qp.Get("query"); q != "" {
+			if query, err = regexp.Compile(q); err != nil {
+				writeValidationError(w, "invalid query: "+html.EscapeString(err.Error()))
+				return
+			}
+		}
+		backend := from.Get(backendName)
+		if !validateBackend(w, backend, backendName) {
+			return
+		}
+		tm, ok := backend.(proxyengines.TimeseriesModeler)
+		if !ok || tm.Modeler() == nil {
+			writeValidationError(w, "Backend "+html.EscapeString(backendName)+
+				" doesn't cache timeseries.")
+			return
+		}
+		c := backend.Cache()
+		if !validateCache(w, c, backendName) {
+			return
+		}
+		cfg := backend.Configuration()
+		prefix := proxyengines.ComposeCacheKey(cfg.Name, cfg.CacheKeyPrefix, "dpc", "")
+		var keys []string
+		if key := qp.Get("key"); key != "" {
+			// only the objects cached by the backend can be invalidated through it
+			if !strings.HasPrefix(key, prefix) {
+				writeValidationError(w, "Key "+html.EscapeString(key)+
+					" is not cached by backend "+html.EscapeString(backendName)+".")
+				return
+			}
+			keys = []string{key}
+		} else {
+			// without a key, the objects are selected from the tag index
+			tg, ok := c.(cache.Tagger)
+			if !ok {
+				writeValidationError(w, "Backend "+html.EscapeString(backendName)+
+					" doesn't index its cache keys; provide a key.")
+				return
+			}
+			tags := slices.DeleteFunc(qp["tag"], func(t string) bool { return t == "" })
+			keys, err = tg.TaggedKeys(append(tags, index.BackendTag(backendName)), prefix)
+			if err != nil {
+				writeTagIndexUnavailable(w, backendName, e.String()+" | 0 objects",
+					[]string{c.Configuration().Name})
+				return
+			}
+		}
+		logger.Debug("invalidating cached extent",
+			logging.Pairs{"backend": backendName, "extent": e.String(), "keys": len(keys)})
+		n, err := proxyengines.InvalidateExtent(req.Context(), c, cfg, tm.Modeler(),
+			keys, e, query)
+		target := e.String() + " | " + strconv.Itoa(n) + " objects"
+		if err != nil {
+			logger.Warn("failed to invalidate cached extent",
+				logging.Pairs{"backend": backendName, "extent": e.String(), "error": err})
+			writeInvalidationFailed(w, backendName, target, err)
+			return
+		}
+		writePurgeResult(w, backendName, target)
+	}
+}
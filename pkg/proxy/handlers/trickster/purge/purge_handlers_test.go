@@ -19,10 +19,17 @@ package purge
 import (
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/trickstercache/trickster/v2/pkg/backends"
 	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache"
+	"github.com/trickstercache/trickster/v2/pkg/cache/index"
+	"github.com/trickstercache/trickster/v2/pkg/cache/manager"
+	"github.com/trickstercache/trickster/v2/pkg/cache/options"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
 )
 
 func TestKeyHandler(t *testing.T) {
@@ -135,3 +142,249 @@ func TestPathHandlerValidation(t *testing.T) {
 		}
 	})
 }
+
+func TestTagHandler(t *testing.T) {
+	t.Parallel()
+
+	const pathPrefix = "/trickster/purge/tags"
+	newTaggedCache := func() cache.Cache {
+		c := manager.NewCache(newMemCache(), manager.CacheOptions{},
+			&options.Options{Name: "test", Provider: "memory"})
+		tg := c.(cache.Tagger)
+		for k, tags := range map[string][]string{
+			"a.x.1": {index.BackendTag("a"), "dash-1"},
+			"a.x.2": {index.BackendTag("a"), "dash-2"},
+			"b.y.1": {index.BackendTag("b"), "dash-1"},
+		} {
+			if err := c.Store(k, []byte("v"), 0); err != nil {
+				t.Fatal(err)
+			}
+			tg.Tag(k, time.Minute, tags...)
+		}
+		return c
+	}
+	exists := func(c cache.Cache, key string) bool {
+		_, _, err := c.Retrieve(key)
+		return err == nil
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		code     int
+		body     string
+		expected []string
+	}{
+		{
+			name:     "tag across backends",
+			query:    "?tag=dash-1",
+			code:     http.StatusOK,
+			body:     "purged: * | 2 keys\n",
+			expected: []string{"a.x.2"},
+		},
+		{
+			name:     "tag within backend",
+			query:    "?tag=dash-1&backend=a",
+			code:     http.StatusOK,
+			body:     "purged: a | 1 keys\n",
+			expected: []string{"a.x.2", "b.y.1"},
+		},
+		{
+			name:     "backend",
+			query:    "?backend=b",
+			code:     http.StatusOK,
+			body:     "purged: b | 1 keys\n",
+			expected: []string{"a.x.1", "a.x.2"},
+		},
+		{
+			name:     "prefix",
+			query:    "?prefix=a.x.",
+			code:     http.StatusOK,
+			body:     "purged: * | 2 keys\n",
+			expected: []string{"b.y.1"},
+		},
+		{
+			name:     "unknown tag",
+			query:    "?tag=dash-3",
+			code:     http.StatusOK,
+			body:     "purged: * | 0 keys\n",
+			expected: []string{"a.x.1", "a.x.2", "b.y.1"},
+		},
+		{
+			name:     "usage error",
+			code:     http.StatusBadRequest,
+			expected: []string{"a.x.1", "a.x.2", "b.y.1"},
+		},
+		{
+			name:     "missing backend",
+			query:    "?backend=missing",
+			code:     http.StatusBadRequest,
+			expected: []string{"a.x.1", "a.x.2", "b.y.1"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTaggedCache()
+			bes := backends.Backends{
+				"a":        &fakeBackend{cfg: &bo.Options{Name: "a"}, cache: c},
+				"b":        &fakeBackend{cfg: &bo.Options{Name: "b"}, cache: c},
+				"no-cache": &fakeBackend{cfg: &bo.Options{Name: "no-cache"}},
+			}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, pathPrefix+test.query, nil)
+			TagHandler(&bes)(w, r)
+			if w.Code != test.code {
+				t.Fatalf("status = %d body=%s", w.Code, w.Body.String())
+			}
+			if test.body != "" && w.Body.String() != test.body {
+				t.Errorf("body = %q, want %q", w.Body.String(), test.body)
+			}
+			for _, k := range []string{"a.x.1", "a.x.2", "b.y.1"} {
+				if want := slices.Contains(test.expected, k); exists(c, k) != want {
+					t.Errorf("key %s exists = %t, want %t", k, !want, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTagHandlerUnavailable(t *testing.T) {
+	t.Parallel()
+	// a cache that outlives its tag index, like Redis, can't be purged by tag
+	// when the index is empty
+	c := manager.NewCache(newMemCache(), manager.CacheOptions{},
+		&options.Options{Name: "shared", Provider: "redis"})
+	bes := backends.Backends{"a": &fakeBackend{cfg: &bo.Options{Name: "a"}, cache: c}}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/trickster/purge/tags?tag=dash-1", nil)
+	TagHandler(&bes)(w, r)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d body=%s", w.Code, w.Body.String())
+	}
+	if want := "purged: * | 0 keys\n" + cache.ErrTagIndexUnavailable.Error() +
+		": shared\n"; w.Body.String() != want {
+		t.Errorf("body = %q, want %q", w.Body.String(), want)
+	}
+}
+
+type fakeTimeseriesBackend struct {
+	fakeBackend
+	modeler *timeseries.Modeler
+}
+
+func (f *fakeTimeseriesBackend) Modeler() *timeseries.Modeler { return f.modeler }
+
+func TestExtentHandler(t *testing.T) {
+	t.Parallel()
+
+	const pathPrefix = "/trickster/purge/extent"
+	c := manager.NewCache(newMemCache(), manager.CacheOptions{},
+		&options.Options{Name: "test", Provider: "filesystem"})
+	bes := backends.Backends{
+		"ts": &fakeTimeseriesBackend{
+			fakeBackend: fakeBackend{cfg: &bo.Options{Name: "ts"}, cache: c},
+			modeler:     &timeseries.Modeler{},
+		},
+		"mem": &fakeTimeseriesBackend{
+			fakeBackend: fakeBackend{cfg: &bo.Options{Name: "mem"},
+				cache: manager.NewCache(newMemCache(), manager.CacheOptions{},
+					&options.Options{Name: "mem", Provider: "memory"})},
+			modeler: &timeseries.Modeler{},
+		},
+		"rpc":      &fakeBackend{cfg: &bo.Options{Name: "rpc"}, cache: c},
+		"no-cache": &fakeTimeseriesBackend{fakeBackend: fakeBackend{cfg: &bo.Options{Name: "no-cache"}}, modeler: &timeseries.Modeler{}},
+	}
+	if err := c.Store("ts..dpc.corrupt", []byte("not a cached document"), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		code  int
+		body  string
+	}{
+		{
+			name:  "unix seconds",
+			query: "?backend=mem&start=0&end=60",
+			code:  http.StatusOK,
+			body:  "purged: mem | 0-60000 | 0 objects\n",
+		},
+		{
+			name:  "tag index unavailable",
+			query: "?backend=ts&start=0&end=60",
+			code:  http.StatusServiceUnavailable,
+			body: "purged: ts | 0-60000 | 0 objects\n" +
+				cache.ErrTagIndexUnavailable.Error() + ": test\n",
+		},
+		{
+			name:  "rfc3339",
+			query: "?backend=ts&start=1970-01-01T00:00:00Z&end=1970-01-01T00:01:00Z&key=ts..dpc.k",
+			code:  http.StatusOK,
+			body:  "purged: ts | 0-60000 | 0 objects\n",
+		},
+		{
+			name:  "other backend key",
+			query: "?backend=ts&start=0&end=60&key=mem..dpc.k",
+			code:  http.StatusBadRequest,
+			body:  "Key mem..dpc.k is not cached by backend ts.",
+		},
+		{
+			name:  "invalidation failure",
+			query: "?backend=ts&start=0&end=60&key=ts..dpc.corrupt",
+			code:  http.StatusInternalServerError,
+		},
+		{
+			name: "usage error",
+			code: http.StatusBadRequest,
+		},
+		{
+			name:  "missing end",
+			query: "?backend=ts&start=0",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "invalid start",
+			query: "?backend=ts&start=yesterday&end=60",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "reversed extent",
+			query: "?backend=ts&start=60&end=0",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "invalid query",
+			query: "?backend=ts&start=0&end=60&query=(",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "missing backend",
+			query: "?backend=missing&start=0&end=60",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "not a timeseries backend",
+			query: "?backend=rpc&start=0&end=60",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "no cache",
+			query: "?backend=no-cache&start=0&end=60",
+			code:  http.StatusBadRequest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, pathPrefix+test.query, nil)
+			ExtentHandler(&bes)(w, r)
+			if w.Code != test.code {
+				t.Fatalf("status = %d body=%s", w.Code, w.Body.String())
+			}
+			if test.body != "" && w.Body.String() != test.body {
+				t.Errorf("body = %q, want %q", w.Body.String(), test.body)
+			}
+		})
+	}
+}
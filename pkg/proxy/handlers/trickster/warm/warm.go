@@ -0,0 +1,71 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package warm provides the management handler for running Cache Warmers on demand
+package warm
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/trickstercache/trickster/v2/pkg/cache/warmer"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+)
+
+type warmerList struct {
+	Warmers []string `json:"warmers"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler runs a Cache Warmer once in response to a POST request to
+// {pathPrefix}{warmerName}, and responds with the result after the warmer's
+// request completes. A GET request to pathPrefix lists the Cache Warmers.
+func Handler(pathPrefix string, s *warmer.Scheduler) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		name := strings.TrimPrefix(req.URL.Path, pathPrefix)
+		switch {
+		case name == "" && req.Method == http.MethodGet:
+			writeJSON(w, http.StatusOK, warmerList{Warmers: s.Names()})
+		case name != "" && req.Method == http.MethodPost:
+			res, err := s.Run(req.Context(), name)
+			if errors.Is(err, warmer.ErrWarmerNotFound) {
+				writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+				return
+			}
+			status := http.StatusOK
+			if res.Error != "" {
+				status = http.StatusBadGateway
+			}
+			writeJSON(w, status, res)
+		default:
+			writeJSON(w, http.StatusMethodNotAllowed,
+				errorResponse{Error: http.StatusText(http.StatusMethodNotAllowed)})
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	b, _ := json.Marshal(v)
+	w.Header().Set(headers.NameContentType, headers.ValueApplicationJSON)
+	w.Header().Set(headers.NameCacheControl, headers.ValueNoCache)
+	w.WriteHeader(status)
+	w.Write(b)
+}
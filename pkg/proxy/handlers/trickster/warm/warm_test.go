@@ -0,0 +1,99 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package warm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/backends"
+	bo "github.com/trickstercache/trickster/v2/pkg/backends/options"
+	"github.com/trickstercache/trickster/v2/pkg/cache/warmer"
+	"github.com/trickstercache/trickster/v2/pkg/cache/warmer/options"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testPath = "/trickster/warm/"
+
+func testScheduler(t *testing.T) *warmer.Scheduler {
+	t.Helper()
+	b, err := backends.New("test", bo.New(), nil,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/fail" {
+				<-r.Context().Done()
+			}
+		}), nil)
+	require.NoError(t, err)
+	l := options.Lookup{}
+	for name, path := range map[string]string{"example": "/api/v1/query", "fail": "/fail"} {
+		o := options.New()
+		o.Name = name
+		o.BackendName = "test"
+		o.Path = path
+		if name == "fail" {
+			o.Timeout = timeconv.Duration(10 * time.Millisecond)
+		}
+		l[name] = o
+	}
+	return warmer.New(l, backends.Backends{"test": b}, 1)
+}
+
+func TestHandler(t *testing.T) {
+	h := Handler(testPath, testScheduler(t))
+
+	t.Run("list", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodGet, testPath, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var l warmerList
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &l))
+		require.Equal(t, []string{"example", "fail"}, l.Warmers)
+	})
+
+	t.Run("run", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodPost, testPath+"example", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var res warmer.Result
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		require.Equal(t, "example", res.Warmer)
+		require.Equal(t, http.StatusOK, res.Status)
+	})
+
+	t.Run("run error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodPost, testPath+"fail", nil))
+		require.Equal(t, http.StatusBadGateway, w.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodPost, testPath+"missing", nil))
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodGet, testPath+"example", nil))
+		require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
@@ -33,6 +33,8 @@ const (
 	ValueApplicationCSV = "application/csv"
 	// ValueApplicationJSON represents the HTTP Header Value of "application/json"
 	ValueApplicationJSON = "application/json"
+	// ValueApplicationOctetStream represents the HTTP Header Value of "application/octet-stream"
+	ValueApplicationOctetStream = "application/octet-stream"
 	// ValueApplicationYAML represents the HTTP Header Value of "application/yaml"
 	ValueApplicationYAML = "application/yaml"
 	// ValueApplicationFlux represents the HTTP Header Value of "application/vnd.flux"
@@ -61,6 +63,10 @@ const (
 	ValuePublic = "public"
 	// ValueSharedMaxAge represents the HTTP Header Value of "s-maxage"
 	ValueSharedMaxAge = "s-maxage"
+	// ValueStaleIfError represents the HTTP Header Value of "stale-if-error"
+	ValueStaleIfError = "stale-if-error"
+	// ValueStaleWhileRevalidate represents the HTTP Header Value of "stale-while-revalidate"
+	ValueStaleWhileRevalidate = "stale-while-revalidate"
 	// ValueTextPlain represents the HTTP Header Value of "text/plain"
 	ValueTextPlain = "text/plain"
 	// ValueTextYAML represents the HTTP Header Value of "text/yaml"
@@ -96,6 +102,8 @@ const (
 	NameContentRange = "Content-Range"
 	// NameTricksterResult represents the HTTP Header Name of "X-Trickster-Result"
 	NameTricksterResult = "X-Trickster-Result"
+	// NameTricksterPeerToken represents the HTTP Header Name of "X-Trickster-Peer-Token"
+	NameTricksterPeerToken = "X-Trickster-Peer-Token"
 	// NameAcceptEncoding represents the HTTP Header Name of "Accept-Encoding"
 	NameAcceptEncoding = "Accept-Encoding"
 	// NameAcceptLanguage represents the HTTP Header Name of "Accept-Language"
@@ -22,7 +22,7 @@ import (
 	"github.com/trickstercache/trickster/v2/pkg/util/sets"
 )
 
-var sensitiveCredentials = sets.New([]string{NameAuthorization})
+var sensitiveCredentials = sets.New([]string{NameAuthorization, NameTricksterPeerToken})
 
 // HideAuthorizationCredentials replaces any sensitive HTTP header values with 5
 // asterisks sensitive headers are defined in the sensitiveCredentials map
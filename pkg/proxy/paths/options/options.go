@@ -25,7 +25,10 @@ import (
 
 	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
 	"github.com/trickstercache/trickster/v2/pkg/cache/key"
+	"github.com/trickstercache/trickster/v2/pkg/cache/negative"
 	"github.com/trickstercache/trickster/v2/pkg/config/types"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	admo "github.com/trickstercache/trickster/v2/pkg/proxy/admission/options"
 	autho "github.com/trickstercache/trickster/v2/pkg/proxy/authenticator/options"
 	corso "github.com/trickstercache/trickster/v2/pkg/proxy/cors/options"
 	"github.com/trickstercache/trickster/v2/pkg/proxy/forwarding"
@@ -56,6 +59,13 @@ type Options struct {
 	// CacheKeyFormFields provides the list of http request body fields to be included
 	// in the hash for each request's cache key
 	CacheKeyFormFields []string `yaml:"cache_key_form_fields,omitempty"`
+	// CacheTags provides the list of tags to attach to each object cached for this path,
+	// which can be used to purge the objects together
+	CacheTags []string `yaml:"cache_tags,omitempty"`
+	// StaleWhileRevalidate, when set, overrides the backend's stale_while_revalidate for this path
+	StaleWhileRevalidate timeconv.Duration `yaml:"stale_while_revalidate,omitempty"`
+	// StaleIfError, when set, overrides the backend's stale_if_error for this path
+	StaleIfError timeconv.Duration `yaml:"stale_if_error,omitempty"`
 	// RequestHeaders is a map of headers that will be added to requests to the upstream Origin for this path
 	RequestHeaders types.EnvStringMap `yaml:"request_headers,omitempty"`
 	// RequestParams is a map of parameters that will be added to requests to the upstream Origin for this path
@@ -64,6 +74,8 @@ type Options struct {
 	ResponseHeaders types.EnvStringMap `yaml:"response_headers,omitempty"`
 	// CORS overrides the backend CORS response-header policy for this path
 	CORS *corso.Options `yaml:"cors,omitempty"`
+	// Admission overrides the backend cache admission rules for this path
+	Admission *admo.Options `yaml:"admission,omitempty"`
 	// ResponseCode sets a custom response code to be sent to downstream clients for this path.
 	ResponseCode int `yaml:"response_code,omitempty"`
 	// ResponseBody sets a custom response body to be sent to the donstream client for this path.
@@ -77,6 +89,9 @@ type Options struct {
 	NoMetrics bool `yaml:"no_metrics,omitempty"`
 	// AuthenticatorName specifies the name of the optional Authenticator to attach to this Path
 	AuthenticatorName string `yaml:"authenticator_name,omitempty"`
+	// NegativeCacheName is the name of the Negative Cache Config to be used by this path,
+	// instead of the backend's
+	NegativeCacheName string `yaml:"negative_cache_name,omitempty"`
 
 	// Handler is the HTTP Handler represented by the Path's HandlerName
 	Handler http.Handler `yaml:"-"`
@@ -93,6 +108,8 @@ type Options struct {
 	ReqRewriter rewriter.RewriteInstructions `yaml:"-"`
 	// AuthOptions is the authenticator as indicated by AuthenticatorName
 	AuthOptions *autho.Options `yaml:"-"`
+	// NegativeCache is the negative cache as indicated by NegativeCacheName
+	NegativeCache negative.Lookup `yaml:"-"`
 }
 
 // List is a slice of *Options
@@ -132,10 +149,12 @@ func (o *Options) Clone() *Options {
 	if o.CORS != nil {
 		out.CORS = o.CORS.Clone()
 	}
+	out.Admission = o.Admission.Clone()
 	out.Methods = slices.Clone(o.Methods)
 	out.CacheKeyParams = slices.Clone(o.CacheKeyParams)
 	out.CacheKeyHeaders = slices.Clone(o.CacheKeyHeaders)
 	out.CacheKeyFormFields = slices.Clone(o.CacheKeyFormFields)
+	out.CacheTags = slices.Clone(o.CacheTags)
 
 	out.ResponseBody = pointers.Clone(o.ResponseBody)
 	if out.ResponseBody != nil {
@@ -144,6 +163,9 @@ func (o *Options) Clone() *Options {
 	if o.AuthOptions != nil {
 		out.AuthOptions = o.AuthOptions.Clone()
 	}
+	if o.NegativeCache != nil {
+		out.NegativeCache = maps.Clone(o.NegativeCache)
+	}
 	return out
 }
 
@@ -216,6 +238,10 @@ func (o *Options) Validate() (bool, error) {
 			return false, fmt.Errorf("invalid collapsed_forwarding name: %s", o.CollapsedForwardingName)
 		}
 	}
+	if o.StaleWhileRevalidate < 0 || o.StaleIfError < 0 {
+		return false, fmt.Errorf("invalid stale_while_revalidate or stale_if_error for path %s: "+
+			"value must be greater than or equal to 0", o.Path)
+	}
 	if o.ResponseCode != 0 && (o.ResponseCode < 100 || o.ResponseCode >= 600) {
 		return false, fmt.Errorf("invalid response_code: %d (must be between 100 and 599)", o.ResponseCode)
 	}
@@ -224,6 +250,9 @@ func (o *Options) Validate() (bool, error) {
 			return false, err
 		}
 	}
+	if err := o.Admission.Validate(); err != nil {
+		return false, fmt.Errorf("invalid admission for path %s: %w", o.Path, err)
+	}
 	return true, nil
 }
 
@@ -20,8 +20,10 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/trickstercache/trickster/v2/pkg/backends/providers"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
 
 	"github.com/stretchr/testify/require"
 )
@@ -220,6 +222,16 @@ func TestValidate(t *testing.T) {
 			}(),
 			expectedError: "invalid response_code: 999 (must be between 100 and 599)",
 		},
+		{
+			name: "negative stale duration",
+			options: func() *Options {
+				o := New()
+				o.StaleWhileRevalidate = timeconv.Duration(-time.Second)
+				_ = o.Initialize("")
+				return o
+			}(),
+			expectedError: "invalid stale_while_revalidate or stale_if_error for path /",
+		},
 		{
 			name: "valid response code",
 			options: func() *Options {
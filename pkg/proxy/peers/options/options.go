@@ -0,0 +1,189 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package options defines the configuration of the Trickster peers that
+// receive broadcast management operations, such as cache purges.
+package options
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/config/types"
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+	"github.com/trickstercache/trickster/v2/pkg/util/pointers"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// DefaultScheme is the default scheme of peers provided without one
+	DefaultScheme = "http"
+	// DefaultDNSPort is the default management port of peers discovered via DNS
+	DefaultDNSPort = 8484
+	// DefaultTimeout is the default time allowed for a peer to respond
+	DefaultTimeout = 5 * time.Second
+)
+
+var (
+	// ErrNoPeerSource is returned when none of 'peers', 'dns_name' or 'file' are provided
+	ErrNoPeerSource = errors.New("peers require at least one of 'peers', 'dns_name' or 'file'")
+	// ErrNoPeerSecret is returned when no shared secret is provided
+	ErrNoPeerSecret = errors.New("peers require a 'secret'")
+	// ErrInvalidPeer is returned when a peer is not a valid URL or host:port
+	ErrInvalidPeer = errors.New("invalid peer")
+	// ErrInvalidPeerScheme is returned when the scheme is not http or https
+	ErrInvalidPeerScheme = errors.New("peer scheme must be http or https")
+	// ErrInvalidPeerValue is returned when the port or timeout is negative
+	ErrInvalidPeerValue = errors.New("peer dns_port and timeout must be greater than or equal to 0")
+)
+
+// Options defines the membership of the Trickster peers and how they are
+// reached. Peers are the management listeners of other Trickster instances,
+// and can be provided as a static list, discovered by DNS, or read from a file.
+// Any combination of sources may be used.
+type Options struct {
+	// Peers is a static list of peer base URLs or host:port pairs
+	Peers []string `yaml:"peers,omitempty"`
+	// DNSName is a hostname that resolves to the address of each peer, such as
+	// a Kubernetes headless service
+	DNSName string `yaml:"dns_name,omitempty"`
+	// DNSPort is the management port of the peers discovered via DNSName
+	DNSPort int `yaml:"dns_port,omitempty"`
+	// File is the path to a file listing one peer per line. It is re-read each
+	// time the peers are resolved, so membership can change without a reload
+	File string `yaml:"file,omitempty"`
+	// Scheme is the scheme used for peers provided without one
+	Scheme string `yaml:"scheme,omitempty"`
+	// Self is the base URL by which peers reach this instance. It is excluded
+	// from the resolved peers
+	Self string `yaml:"self,omitempty"`
+	// Secret is the shared secret that authenticates requests between peers
+	Secret types.EnvString `yaml:"secret,omitempty"`
+	// Timeout is the time allowed for a peer to respond
+	Timeout timeconv.Duration `yaml:"timeout,omitempty"`
+}
+
+// New returns a new Peers Options Reference with default values set
+func New() *Options {
+	return &Options{
+		DNSPort: DefaultDNSPort,
+		Scheme:  DefaultScheme,
+		Timeout: timeconv.Duration(DefaultTimeout),
+	}
+}
+
+// Clone returns an exact copy of the Options
+func (o *Options) Clone() *Options {
+	if o == nil {
+		return nil
+	}
+	out := pointers.Clone(o)
+	out.Peers = slices.Clone(o.Peers)
+	return out
+}
+
+// Equal returns true if all values in the Options are identical
+func (o *Options) Equal(o2 *Options) bool {
+	if o == nil || o2 == nil {
+		return o == o2
+	}
+	return slices.Equal(o.Peers, o2.Peers) &&
+		o.DNSName == o2.DNSName &&
+		o.DNSPort == o2.DNSPort &&
+		o.File == o2.File &&
+		o.Scheme == o2.Scheme &&
+		o.Self == o2.Self &&
+		o.Secret == o2.Secret &&
+		o.Timeout == o2.Timeout
+}
+
+// Validate returns an error if the Options are invalid
+func (o *Options) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if len(o.Peers) == 0 && o.DNSName == "" && o.File == "" {
+		return ErrNoPeerSource
+	}
+	if o.Secret == "" {
+		return ErrNoPeerSecret
+	}
+	if o.DNSPort < 0 || o.Timeout < 0 {
+		return ErrInvalidPeerValue
+	}
+	switch o.Scheme {
+	case "":
+		o.Scheme = DefaultScheme
+	case "http", "https":
+	default:
+		return ErrInvalidPeerScheme
+	}
+	if o.DNSPort == 0 {
+		o.DNSPort = DefaultDNSPort
+	}
+	if o.Timeout == 0 {
+		o.Timeout = timeconv.Duration(DefaultTimeout)
+	}
+	for _, p := range o.Peers {
+		if _, err := o.ParsePeer(p); err != nil {
+			return err
+		}
+	}
+	if o.Self != "" {
+		if _, err := o.ParsePeer(o.Self); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParsePeer returns the base URL of the peer, which is a URL or a host:port
+// pair. The Scheme is applied to a peer provided without one.
+func (o *Options) ParsePeer(peer string) (*url.URL, error) {
+	peer = strings.TrimSpace(peer)
+	if !strings.Contains(peer, "://") {
+		scheme := o.Scheme
+		if scheme == "" {
+			scheme = DefaultScheme
+		}
+		peer = scheme + "://" + peer
+	}
+	u, err := url.Parse(peer)
+	if err != nil || u.Host == "" ||
+		(u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPeer, peer)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u, nil
+}
+
+// UnmarshalYAML applies defaults before decoding a peers configuration block.
+func (o *Options) UnmarshalYAML(value *yaml.Node) error {
+	type loadOptions Options
+	lo := loadOptions(*(New()))
+	if err := value.Decode(&lo); err != nil {
+		return err
+	}
+	*o = Options(lo)
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/timeconv"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestOptionsYAML(t *testing.T) {
+	const conf = `
+peers:
+  - http://trickster-1:8484
+  - trickster-2:8484
+dns_name: trickster-headless
+secret: s3cret
+`
+	o := &Options{}
+	if err := yaml.Unmarshal([]byte(conf), o); err != nil {
+		t.Fatal(err)
+	}
+	if o.DNSPort != DefaultDNSPort || o.Scheme != DefaultScheme ||
+		o.Timeout != timeconv.Duration(DefaultTimeout) {
+		t.Errorf("expected defaults to be applied: %+v", o)
+	}
+	if len(o.Peers) != 2 || o.DNSName != "trickster-headless" || o.Secret != "s3cret" {
+		t.Errorf("unexpected options: %+v", o)
+	}
+	if err := o.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	o2 := o.Clone()
+	if !o.Equal(o2) {
+		t.Error("expected clone to be equal")
+	}
+	o2.Peers[0] = "http://trickster-3:8484"
+	if o.Peers[0] != "http://trickster-1:8484" || o.Equal(o2) {
+		t.Error("clone mutated subject peers")
+	}
+	if (*Options)(nil).Clone() != nil || !(*Options)(nil).Equal(nil) || o.Equal(nil) {
+		t.Error("unexpected nil handling")
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *Options
+		wantErr error
+	}{
+		{name: "nil"},
+		{name: "static", options: &Options{Peers: []string{"a:8484"}, Secret: "x"}},
+		{name: "file", options: &Options{File: "/tmp/peers", Secret: "x"}},
+		{name: "no source", options: &Options{Secret: "x"},
+			wantErr: ErrNoPeerSource},
+		{name: "no secret", options: &Options{DNSName: "peers"},
+			wantErr: ErrNoPeerSecret},
+		{name: "negative port", options: &Options{DNSName: "peers", DNSPort: -1, Secret: "x"},
+			wantErr: ErrInvalidPeerValue},
+		{name: "negative timeout", options: &Options{DNSName: "peers", Timeout: -1, Secret: "x"},
+			wantErr: ErrInvalidPeerValue},
+		{name: "invalid scheme", options: &Options{DNSName: "peers", Scheme: "ftp", Secret: "x"},
+			wantErr: ErrInvalidPeerScheme},
+		{name: "invalid peer", options: &Options{Peers: []string{"ftp://a"}, Secret: "x"},
+			wantErr: ErrInvalidPeer},
+		{name: "invalid self", options: &Options{DNSName: "peers", Self: "http://", Secret: "x"},
+			wantErr: ErrInvalidPeer},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.options.Validate()
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("error = %v, want %v", err, test.wantErr)
+			}
+			if err == nil && test.options != nil &&
+				(test.options.Scheme != DefaultScheme || test.options.DNSPort != DefaultDNSPort ||
+					test.options.Timeout != timeconv.Duration(DefaultTimeout)) {
+				t.Errorf("expected defaults to be applied: %+v", test.options)
+			}
+		})
+	}
+}
+
+func TestParsePeer(t *testing.T) {
+	o := &Options{Scheme: "https"}
+	tests := []struct {
+		peer, want string
+	}{
+		{peer: "trickster-1:8484", want: "https://trickster-1:8484"},
+		{peer: " http://trickster-1:8484/ ", want: "http://trickster-1:8484"},
+		{peer: "http://trickster-1:8484/mgmt/?x=1", want: "http://trickster-1:8484/mgmt"},
+		{peer: "10.0.0.1:8484", want: "https://10.0.0.1:8484"},
+	}
+	for _, test := range tests {
+		u, err := o.ParsePeer(test.peer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.String() != test.want {
+			t.Errorf("ParsePeer(%q) = %s, want %s", test.peer, u, test.want)
+		}
+	}
+	if _, err := o.ParsePeer(""); !errors.Is(err, ErrInvalidPeer) {
+		t.Errorf("error = %v, want %v", err, ErrInvalidPeer)
+	}
+}
@@ -0,0 +1,260 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package peers resolves the membership of a cluster of Trickster instances
+// and broadcasts management requests to them.
+package peers
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/peers/options"
+)
+
+// these are overridden in tests
+var (
+	lookupHost     = net.DefaultResolver.LookupHost
+	interfaceAddrs = net.InterfaceAddrs
+)
+
+// Group is the set of peers of this Trickster instance
+type Group struct {
+	options *options.Options
+	client  *http.Client
+	self    *url.URL
+}
+
+// Result is the outcome of a broadcast request to a peer
+type Result struct {
+	// Peer is the base URL of the peer
+	Peer string
+	// StatusCode is the HTTP status code of the peer's response
+	StatusCode int
+	// Err is the error encountered when making the request, if any
+	Err error
+}
+
+// OK returns true when the peer responded with a 2xx status code
+func (r Result) OK() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// New returns a new Group for the validated Options, or nil if the Options are nil
+func New(o *options.Options) *Group {
+	if o == nil {
+		return nil
+	}
+	g := &Group{
+		options: o,
+		client:  &http.Client{Timeout: time.Duration(o.Timeout)},
+	}
+	if o.Self != "" {
+		g.self, _ = o.ParsePeer(o.Self)
+	}
+	return g
+}
+
+// Members returns the base URL of each peer, excluding this instance. When a
+// source cannot be resolved, the peers from the other sources are returned
+// along with the error.
+func (g *Group) Members(ctx context.Context) ([]*url.URL, error) {
+	members, _, err := g.resolve(ctx)
+	return members, err
+}
+
+// Nodes returns the base URL of each instance in the cluster, including this
+// instance, in order of URL, along with the base URL of this instance. The
+// base URL of this instance is the Self option, or else the address of this
+// host that was discovered via DNS, and is nil when neither is available.
+func (g *Group) Nodes(ctx context.Context) ([]*url.URL, *url.URL, error) {
+	nodes, self, err := g.resolve(ctx)
+	if self != nil {
+		nodes = append(nodes, self)
+	}
+	slices.SortFunc(nodes, func(a, b *url.URL) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return nodes, self, err
+}
+
+func (g *Group) resolve(ctx context.Context) ([]*url.URL, *url.URL, error) {
+	o := g.options
+	entries := slices.Clone(o.Peers)
+	var errs []error
+	self := g.self
+	if o.DNSName != "" {
+		hosts, local, err := g.resolveDNS(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		entries = append(entries, hosts...)
+		if self == nil && local != "" {
+			self, _ = o.ParsePeer(local)
+		}
+	}
+	if o.File != "" {
+		lines, err := readFile(o.File)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		entries = append(entries, lines...)
+	}
+	out := make([]*url.URL, 0, len(entries))
+	seen := make(map[string]struct{}, len(entries))
+	if self != nil {
+		seen[self.String()] = struct{}{}
+	}
+	for _, e := range entries {
+		u, err := o.ParsePeer(e)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		k := u.String()
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, u)
+	}
+	return out, self, errors.Join(errs...)
+}
+
+// resolveDNS returns the host:port of each address of the DNS name, except
+// for the first address of this host, which is returned separately
+func (g *Group) resolveDNS(ctx context.Context) ([]string, string, error) {
+	addrs, err := lookupHost(ctx, g.options.DNSName)
+	if err != nil {
+		return nil, "", err
+	}
+	local := localAddrs()
+	port := strconv.Itoa(g.options.DNSPort)
+	out := make([]string, 0, len(addrs))
+	var self string
+	for _, a := range addrs {
+		if _, ok := local[a]; ok {
+			if self == "" {
+				self = net.JoinHostPort(a, port)
+			}
+			continue
+		}
+		out = append(out, net.JoinHostPort(a, port))
+	}
+	return out, self, nil
+}
+
+func localAddrs() map[string]struct{} {
+	out := make(map[string]struct{})
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		return out
+	}
+	for _, a := range addrs {
+		if n, ok := a.(*net.IPNet); ok {
+			out[n.IP.String()] = struct{}{}
+		}
+	}
+	return out
+}
+
+// readFile returns each peer listed in the file, skipping blank lines and
+// lines beginning with #
+func readFile(path string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for line := range strings.Lines(string(b)) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		out = append(out, line)
+	}
+	return out, nil
+}
+
+// Broadcast sends a copy of the request, without its body, to the same path
+// and query on each peer, and returns the Result from each peer in order of
+// the peer's URL.
+func (g *Group) Broadcast(r *http.Request) ([]Result, error) {
+	members, err := g.Members(r.Context())
+	results := make([]Result, len(members))
+	var wg sync.WaitGroup
+	for i, u := range members {
+		wg.Go(func() {
+			results[i] = g.send(r, u)
+		})
+	}
+	wg.Wait()
+	slices.SortFunc(results, func(a, b Result) int {
+		return strings.Compare(a.Peer, b.Peer)
+	})
+	return results, err
+}
+
+func (g *Group) send(r *http.Request, base *url.URL) Result {
+	target := *base
+	target.Path = base.Path + r.URL.Path
+	target.RawPath = ""
+	target.RawQuery = r.URL.RawQuery
+	res := Result{Peer: base.String()}
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, target.String(), nil)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	resp, err := g.Do(req)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	resp.Body.Close()
+	res.StatusCode = resp.StatusCode
+	return res
+}
+
+// Do sends the request to a peer, authenticated with the Group's shared secret
+func (g *Group) Do(req *http.Request) (*http.Response, error) {
+	req.Header.Set(headers.NameTricksterPeerToken, string(g.options.Secret))
+	return g.client.Do(req)
+}
+
+// IsPeerRequest returns true if the request was broadcast by a peer
+func IsPeerRequest(r *http.Request) bool {
+	return r.Header.Get(headers.NameTricksterPeerToken) != ""
+}
+
+// Authorized returns true if the request carries the Group's shared secret
+func (g *Group) Authorized(r *http.Request) bool {
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get(headers.NameTricksterPeerToken)),
+		[]byte(g.options.Secret)) == 1
+}
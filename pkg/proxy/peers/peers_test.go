@@ -0,0 +1,214 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peers
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/proxy/headers"
+	"github.com/trickstercache/trickster/v2/pkg/proxy/peers/options"
+)
+
+func newOptions(t *testing.T, o *options.Options) *options.Options {
+	t.Helper()
+	if o.Secret == "" {
+		o.Secret = "s3cret"
+	}
+	if err := o.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func memberStrings(t *testing.T, g *Group) []string {
+	t.Helper()
+	members, err := g.Members(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make([]string, len(members))
+	for i, u := range members {
+		out[i] = u.String()
+	}
+	return out
+}
+
+func TestNew(t *testing.T) {
+	if New(nil) != nil {
+		t.Error("expected nil group for nil options")
+	}
+}
+
+func TestMembers(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "peers")
+	if err := os.WriteFile(f, []byte("# peers\nhttp://b:8484\n\nc:8484\nhttp://a:8484\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	g := New(newOptions(t, &options.Options{
+		Peers: []string{"http://a:8484", "self:8484"},
+		File:  f,
+		Self:  "http://self:8484/",
+	}))
+	got := memberStrings(t, g)
+	want := []string{"http://a:8484", "http://b:8484", "http://c:8484"}
+	if !slices.Equal(got, want) {
+		t.Errorf("members = %v, want %v", got, want)
+	}
+
+	// the file is re-read on each call
+	if err := os.WriteFile(f, []byte("http://d:8484\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got = memberStrings(t, g)
+	want = []string{"http://a:8484", "http://d:8484"}
+	if !slices.Equal(got, want) {
+		t.Errorf("members = %v, want %v", got, want)
+	}
+
+	// a missing file is reported, while the static peers are still returned
+	if err := os.Remove(f); err != nil {
+		t.Fatal(err)
+	}
+	members, err := g.Members(context.Background())
+	if err == nil || len(members) != 1 {
+		t.Errorf("expected static members and an error, got %v %v", members, err)
+	}
+}
+
+func TestNodes(t *testing.T) {
+	g := New(newOptions(t, &options.Options{
+		Peers: []string{"http://c:8484", "self:8484", "http://a:8484"},
+		Self:  "self:8484",
+	}))
+	nodes, self, err := g.Nodes(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if self == nil || self.String() != "http://self:8484" {
+		t.Errorf("unexpected self %v", self)
+	}
+	got := make([]string, len(nodes))
+	for i, u := range nodes {
+		got[i] = u.String()
+	}
+	want := []string{"http://a:8484", "http://c:8484", "http://self:8484"}
+	if !slices.Equal(got, want) {
+		t.Errorf("nodes = %v, want %v", got, want)
+	}
+}
+
+func TestMembersDNS(t *testing.T) {
+	lookupHost = func(_ context.Context, host string) ([]string, error) {
+		if host != "trickster-headless" {
+			return nil, errors.New("no such host")
+		}
+		return []string{"10.0.0.1", "10.0.0.2", "fd00::3"}, nil
+	}
+	interfaceAddrs = func() ([]net.Addr, error) {
+		return []net.Addr{&net.IPNet{IP: net.ParseIP("10.0.0.2"), Mask: net.CIDRMask(32, 32)}}, nil
+	}
+	t.Cleanup(func() {
+		lookupHost = net.DefaultResolver.LookupHost
+		interfaceAddrs = net.InterfaceAddrs
+	})
+	g := New(newOptions(t, &options.Options{DNSName: "trickster-headless", DNSPort: 9090}))
+	got := memberStrings(t, g)
+	want := []string{"http://10.0.0.1:9090", "http://[fd00::3]:9090"}
+	if !slices.Equal(got, want) {
+		t.Errorf("members = %v, want %v", got, want)
+	}
+
+	g = New(newOptions(t, &options.Options{DNSName: "missing", Peers: []string{"a:8484"}}))
+	members, err := g.Members(context.Background())
+	if err == nil || len(members) != 1 {
+		t.Errorf("expected static members and an error, got %v %v", members, err)
+	}
+}
+
+func TestBroadcast(t *testing.T) {
+	var gotPath, gotQuery, gotToken, gotMethod string
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotQuery = r.Method, r.URL.Path, r.URL.RawQuery
+		gotToken = r.Header.Get(headers.NameTricksterPeerToken)
+	}))
+	defer ok.Close()
+	failed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer failed.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	g := New(newOptions(t, &options.Options{
+		Peers: []string{ok.URL + "/mgmt", failed.URL, closed.URL},
+	}))
+	r := httptest.NewRequest(http.MethodDelete,
+		"http://trickster:8484/trickster/purge/tags?tag=a&backend=b", nil)
+	results, err := g.Broadcast(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for _, res := range results {
+		switch res.Peer {
+		case ok.URL + "/mgmt":
+			if !res.OK() {
+				t.Errorf("expected peer %s to succeed: %+v", res.Peer, res)
+			}
+		case failed.URL:
+			if res.OK() || res.StatusCode != http.StatusUnauthorized {
+				t.Errorf("expected peer %s to fail: %+v", res.Peer, res)
+			}
+		case closed.URL:
+			if res.OK() || res.Err == nil {
+				t.Errorf("expected peer %s to error: %+v", res.Peer, res)
+			}
+		default:
+			t.Errorf("unexpected peer %s", res.Peer)
+		}
+	}
+	if gotMethod != http.MethodDelete || gotPath != "/mgmt/trickster/purge/tags" ||
+		gotQuery != "tag=a&backend=b" || gotToken != "s3cret" {
+		t.Errorf("unexpected peer request: %s %s?%s token=%s", gotMethod, gotPath, gotQuery, gotToken)
+	}
+}
+
+func TestAuthorized(t *testing.T) {
+	g := New(newOptions(t, &options.Options{Peers: []string{"a:8484"}}))
+	r := httptest.NewRequest(http.MethodDelete, "/trickster/purge/tags", nil)
+	if IsPeerRequest(r) || g.Authorized(r) {
+		t.Error("expected a client request to not be an authorized peer request")
+	}
+	r.Header.Set(headers.NameTricksterPeerToken, "wrong")
+	if !IsPeerRequest(r) || g.Authorized(r) {
+		t.Error("expected an unauthorized peer request")
+	}
+	r.Header.Set(headers.NameTricksterPeerToken, "s3cret")
+	if !g.Authorized(r) {
+		t.Error("expected an authorized peer request")
+	}
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package s3 provides an in-process stand-in for an S3-compatible object
+// storage service, for use in tests. It supports path-style addressing and
+// the subset of the API used by the Trickster S3 cache.
+package s3
+
+import (
+	"encoding/xml"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const metaPrefix = "X-Amz-Meta-"
+
+// Server is a stand-in S3-compatible server
+type Server struct {
+	*httptest.Server
+	mu      sync.Mutex
+	buckets map[string]map[string]*Object
+	deletes int
+}
+
+// Object is an object stored in the Server
+type Object struct {
+	Body     []byte
+	Metadata map[string]string
+}
+
+// NewServer starts and returns a new Server with the provided buckets
+func NewServer(buckets ...string) *Server {
+	s := &Server{buckets: make(map[string]map[string]*Object)}
+	for _, b := range buckets {
+		s.buckets[b] = make(map[string]*Object)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Object returns a copy of the object stored in the bucket with the key, or nil
+func (s *Server) Object(bucket, key string) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if o, ok := s.buckets[bucket][key]; ok {
+		return &Object{Body: slices.Clone(o.Body), Metadata: maps.Clone(o.Metadata)}
+	}
+	return nil
+}
+
+// Len returns the number of objects stored in the bucket
+func (s *Server) Len(bucket string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buckets[bucket])
+}
+
+// DeleteRequests returns the number of multi-object delete requests received
+func (s *Server) DeleteRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deletes
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.buckets[bucket]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	switch {
+	case key == "" && r.Method == http.MethodHead:
+		w.WriteHeader(http.StatusOK)
+	case key == "" && r.Method == http.MethodPost && r.URL.Query().Has("delete"):
+		s.deleteObjects(w, r, b)
+	case r.Method == http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		o := &Object{Body: body, Metadata: make(map[string]string)}
+		for k, v := range r.Header {
+			if name, ok := strings.CutPrefix(k, metaPrefix); ok && len(v) > 0 {
+				o.Metadata[strings.ToLower(name)] = v[0]
+			}
+		}
+		b[key] = o
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		o, ok := b[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		for k, v := range o.Metadata {
+			w.Header().Set(metaPrefix+k, v)
+		}
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(o.Body)
+		}
+	case r.Method == http.MethodDelete:
+		delete(b, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+type deleteRequest struct {
+	Objects []struct {
+		Key string `xml:"Key"`
+	} `xml:"Object"`
+}
+
+type deleteResult struct {
+	XMLName xml.Name `xml:"DeleteResult"`
+	Deleted []struct {
+		Key string `xml:"Key"`
+	} `xml:"Deleted"`
+}
+
+func (s *Server) deleteObjects(w http.ResponseWriter, r *http.Request,
+	b map[string]*Object,
+) {
+	var req deleteRequest
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "MalformedXML")
+		return
+	}
+	s.deletes++
+	var res deleteResult
+	res.Deleted = req.Objects
+	for _, o := range req.Objects {
+		delete(b, o.Key)
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	xml.NewEncoder(w).Encode(res)
+}
+
+func writeError(w http.ResponseWriter, code int, s3Code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(code)
+	xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}{Code: s3Code, Message: s3Code})
+}
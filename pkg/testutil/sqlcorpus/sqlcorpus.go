@@ -0,0 +1,99 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sqlcorpus runs SQL dialect compatibility corpora and benchmarks, so
+// each dialect test only maintains its query shapes and expected outcomes.
+package sqlcorpus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/parsing/sqlanalyzer"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+// Case is a query shape with its expected classification. Step and Phase are
+// checked only for delta-cacheable cases.
+type Case struct {
+	Name   string
+	Query  string
+	Mode   sqlanalyzer.CacheMode
+	Reason sqlanalyzer.AnalysisReason
+	Step   time.Duration
+	Phase  time.Duration
+}
+
+// Run analyzes each case at now and fails the test when the cache mode,
+// reason, plan presence, or delta cadence differs from the expectation.
+func Run(t *testing.T, analyzer sqlanalyzer.DialectAnalyzer, now time.Time, cases []Case) {
+	t.Helper()
+	for _, test := range cases {
+		t.Run(test.Name, func(t *testing.T) {
+			analysis := analyzer.Analyze(test.Query, now)
+			if analysis.Mode != test.Mode || analysis.Reason != test.Reason {
+				t.Fatalf("analysis = (%s, %s, %v), want (%s, %s)",
+					analysis.Mode.String(), analysis.Reason, analysis.Err,
+					test.Mode.String(), test.Reason)
+			}
+			if test.Mode != sqlanalyzer.CacheModeDelta {
+				if analysis.Plan != nil {
+					t.Fatal("non-delta analysis produced a query plan")
+				}
+				return
+			}
+			if analysis.Err != nil || analysis.Plan == nil {
+				t.Fatalf("delta analysis = %+v", analysis)
+			}
+			if analysis.Plan.Step != test.Step || analysis.Plan.Phase != test.Phase {
+				t.Errorf("cadence = (%s, %s), want (%s, %s)",
+					analysis.Plan.Step, analysis.Plan.Phase, test.Step, test.Phase)
+			}
+		})
+	}
+}
+
+// BenchmarkAnalyze measures parsing and semantic analysis of the query.
+func BenchmarkAnalyze(b *testing.B, analyzer sqlanalyzer.DialectAnalyzer, query string) {
+	now := time.Unix(1_700_000_000, 0)
+	b.ReportAllocs()
+	for b.Loop() {
+		analysis := analyzer.Analyze(query, now)
+		if analysis.Err != nil {
+			b.Fatal(analysis.Err)
+		}
+	}
+}
+
+// BenchmarkRenderExtent measures cache-miss rendering of the query's plan for
+// the extent, independently from initial parsing and analysis.
+func BenchmarkRenderExtent(b *testing.B, analyzer sqlanalyzer.DialectAnalyzer, query string,
+	extent timeseries.Extent) {
+	analysis := analyzer.Analyze(query, time.Unix(1_700_000_000, 0))
+	if analysis.Err != nil {
+		b.Fatal(analysis.Err)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		rendered, err := analysis.Plan.RenderExtent(extent)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if rendered == "" {
+			b.Fatal("empty rendered statement")
+		}
+	}
+}
@@ -0,0 +1,622 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dataset
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+	"strconv"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/epoch"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+// The columnar encoding stores the points of each Series as columns, rather
+// than as a list of Points each carrying its own []any value slice. Timestamps
+// are stored as delta-of-deltas, and float values, including the numeric
+// strings used by the Prometheus model, are stored with Gorilla XOR compression.
+// Values of any other type are stored with msgp.
+//
+// An encoded DataSet starts with columnarMagic, which is never the first byte
+// of a msgp-encoded DataSet, followed by the format version, so that objects
+// in either encoding can be read during a rollout.
+
+const (
+	// columnarMagic is a byte that msgp never produces
+	columnarMagic = 0xc1
+	// columnarVersion is the current version of the columnar encoding
+	columnarVersion = 1
+)
+
+// series layouts
+const (
+	layoutColumnar = iota
+	layoutPoints
+)
+
+// column kinds
+const (
+	columnFloat = iota
+	columnNumericString
+	columnMsgp
+)
+
+// point size modes
+const (
+	sizesConstant = iota
+	sizesVarying
+)
+
+var (
+	// ErrUnsupportedEncodingVersion is returned when a columnar-encoded DataSet
+	// was written with an unknown version of the encoding
+	ErrUnsupportedEncodingVersion = errors.New("unsupported dataset encoding version")
+	// ErrInvalidColumnarData is returned when a columnar-encoded DataSet is malformed
+	ErrInvalidColumnarData = errors.New("invalid columnar dataset")
+)
+
+// IsColumnar returns true if b holds a DataSet in the columnar encoding
+func IsColumnar(b []byte) bool {
+	return len(b) > 0 && b[0] == columnarMagic
+}
+
+// MarshalDataSetColumnar marshals the dataset into the columnar encoding
+func MarshalDataSetColumnar(ts timeseries.Timeseries, _ *timeseries.RequestOptions,
+	_ int,
+) ([]byte, error) {
+	ds, ok := ts.(*DataSet)
+	if !ok || ds == nil {
+		return nil, timeseries.ErrUnknownFormat
+	}
+	// the DataSet is encoded with msgp, less its points
+	meta := &DataSet{
+		Status:             ds.Status,
+		ExtentList:         ds.ExtentList,
+		Error:              ds.Error,
+		ErrorType:          ds.ErrorType,
+		Warnings:           ds.Warnings,
+		TimeRangeQuery:     ds.TimeRangeQuery,
+		VolatileExtentList: ds.VolatileExtentList,
+		Results:            make(Results, len(ds.Results)),
+	}
+	if ds.TimeRangeQuery != nil {
+		ds.TimeRangeQuery.StepNS = ds.TimeRangeQuery.Step.Nanoseconds()
+	}
+	for i, r := range ds.Results {
+		if r == nil {
+			continue
+		}
+		mr := &Result{StatementID: r.StatementID, Error: r.Error, Name: r.Name,
+			SeriesList: make(SeriesList, len(r.SeriesList))}
+		for j, s := range r.SeriesList {
+			if s != nil {
+				mr.SeriesList[j] = &Series{Header: s.Header, PointSize: s.PointSize}
+			}
+		}
+		meta.Results[i] = mr
+	}
+	b := []byte{columnarMagic, columnarVersion}
+	mb, err := meta.MarshalMsg(nil)
+	if err != nil {
+		return nil, err
+	}
+	b = appendBlock(b, mb)
+	for _, r := range ds.Results {
+		if r == nil {
+			continue
+		}
+		for _, s := range r.SeriesList {
+			if s == nil {
+				continue
+			}
+			if b, err = appendPoints(b, s.Points); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b, nil
+}
+
+// unmarshalColumnar unmarshals a DataSet from the columnar encoding
+func unmarshalColumnar(b []byte) (*DataSet, error) {
+	if len(b) < 2 || b[0] != columnarMagic {
+		return nil, ErrInvalidColumnarData
+	}
+	if b[1] != columnarVersion {
+		return nil, ErrUnsupportedEncodingVersion
+	}
+	r := &byteReader{b: b[2:]}
+	ds := &DataSet{}
+	if _, err := ds.UnmarshalMsg(r.block()); err != nil {
+		return nil, err
+	}
+	for _, res := range ds.Results {
+		if res == nil {
+			continue
+		}
+		for _, s := range res.SeriesList {
+			if s == nil {
+				continue
+			}
+			p, err := readPoints(r)
+			if err != nil {
+				return nil, err
+			}
+			s.Points = p
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return ds, nil
+}
+
+// appendPoints appends the encoded points of a series to b
+func appendPoints(b []byte, pts Points) ([]byte, error) {
+	b = binary.AppendUvarint(b, uint64(len(pts)))
+	if len(pts) == 0 {
+		return b, nil
+	}
+	width := len(pts[0].Values)
+	for _, p := range pts {
+		if len(p.Values) != width {
+			// points of differing widths can't be stored as columns
+			pb, err := pts.MarshalMsg(nil)
+			if err != nil {
+				return nil, err
+			}
+			return appendBlock(append(b, layoutPoints), pb), nil
+		}
+	}
+	b = append(b, layoutColumnar)
+	b = binary.AppendUvarint(b, uint64(width))
+	b = appendBlock(b, encodeTimestamps(pts))
+	b = appendSizes(b, pts)
+	for col := range width {
+		kind, cb, err := encodeColumn(pts, col)
+		if err != nil {
+			return nil, err
+		}
+		b = appendBlock(append(b, kind), cb)
+	}
+	return b, nil
+}
+
+// readPoints reads the encoded points of a series
+func readPoints(r *byteReader) (Points, error) {
+	n := int(r.uvarint())
+	if r.err != nil || n == 0 {
+		return nil, r.err
+	}
+	// every point takes at least one bit
+	if n > len(r.b)*8 {
+		return nil, ErrInvalidColumnarData
+	}
+	switch r.byte() {
+	case layoutPoints:
+		var pts Points
+		if _, err := pts.UnmarshalMsg(r.block()); err != nil {
+			return nil, err
+		}
+		return pts, r.err
+	case layoutColumnar:
+	default:
+		return nil, ErrInvalidColumnarData
+	}
+	width := int(r.uvarint())
+	if width > len(r.b) {
+		return nil, ErrInvalidColumnarData
+	}
+	pts := make(Points, n)
+	if err := decodeTimestamps(r.block(), pts); err != nil {
+		return nil, err
+	}
+	readSizes(r, pts)
+	if width > 0 {
+		for i := range pts {
+			pts[i].Values = make([]any, width)
+		}
+	}
+	for col := range width {
+		kind := r.byte()
+		if err := decodeColumn(kind, r.block(), pts, col); err != nil {
+			return nil, err
+		}
+	}
+	return pts, r.err
+}
+
+// appendSizes appends the Size of each point, or a single Size when they are
+// all the same
+func appendSizes(b []byte, pts Points) []byte {
+	size := pts[0].Size
+	constant := true
+	for _, p := range pts {
+		if p.Size != size {
+			constant = false
+			break
+		}
+	}
+	if constant {
+		return binary.AppendVarint(append(b, sizesConstant), int64(size))
+	}
+	b = append(b, sizesVarying)
+	for _, p := range pts {
+		b = binary.AppendVarint(b, int64(p.Size))
+	}
+	return b
+}
+
+// readSizes reads the Size of each point
+func readSizes(r *byteReader, pts Points) {
+	switch r.byte() {
+	case sizesConstant:
+		size := int(r.varint())
+		for i := range pts {
+			pts[i].Size = size
+		}
+	case sizesVarying:
+		for i := range pts {
+			pts[i].Size = int(r.varint())
+		}
+	default:
+		r.fail()
+	}
+}
+
+// encodeTimestamps encodes the point epochs as delta-of-deltas, using the
+// variable-length bit prefixes of the Gorilla encoding
+func encodeTimestamps(pts Points) []byte {
+	w := &bitWriter{}
+	w.writeBits(uint64(pts[0].Epoch), 64)
+	var prevDelta int64
+	for i := 1; i < len(pts); i++ {
+		delta := int64(pts[i].Epoch) - int64(pts[i-1].Epoch)
+		dod := delta - prevDelta
+		prevDelta = delta
+		switch {
+		case dod == 0:
+			w.writeBit(false)
+		case dod >= -64 && dod <= 63:
+			w.writeBits(0b10, 2)
+			w.writeBits(uint64(dod), 7)
+		case dod >= -256 && dod <= 255:
+			w.writeBits(0b110, 3)
+			w.writeBits(uint64(dod), 9)
+		case dod >= -2048 && dod <= 2047:
+			w.writeBits(0b1110, 4)
+			w.writeBits(uint64(dod), 12)
+		default:
+			w.writeBits(0b1111, 4)
+			w.writeBits(uint64(dod), 64)
+		}
+	}
+	return w.b
+}
+
+// decodeTimestamps decodes the point epochs into pts
+func decodeTimestamps(b []byte, pts Points) error {
+	r := &bitReader{b: b}
+	t := int64(r.readBits(64))
+	pts[0].Epoch = epoch.Epoch(t)
+	var delta int64
+	for i := 1; i < len(pts); i++ {
+		var dod int64
+		switch {
+		case !r.readBit():
+		case !r.readBit():
+			dod = signExtend(r.readBits(7), 7)
+		case !r.readBit():
+			dod = signExtend(r.readBits(9), 9)
+		case !r.readBit():
+			dod = signExtend(r.readBits(12), 12)
+		default:
+			dod = int64(r.readBits(64))
+		}
+		delta += dod
+		t += delta
+		pts[i].Epoch = epoch.Epoch(t)
+	}
+	if r.err {
+		return ErrInvalidColumnarData
+	}
+	return nil
+}
+
+// signExtend returns the n-bit two's complement value v as an int64
+func signExtend(v uint64, n uint) int64 {
+	shift := 64 - n
+	return int64(v<<shift) >> shift
+}
+
+// encodeColumn encodes the values at index col of each point. Float columns,
+// and columns of strings that are exactly the shortest representation of a
+// float, are compressed; any other column is encoded with msgp.
+func encodeColumn(pts Points, col int) (byte, []byte, error) {
+	kind := byte(columnFloat)
+	if _, ok := pts[0].Values[col].(string); ok {
+		kind = columnNumericString
+	}
+	floats := make([]float64, len(pts))
+	for i, p := range pts {
+		f, ok := columnFloatValue(p.Values[col], kind)
+		if !ok {
+			kind = columnMsgp
+			break
+		}
+		floats[i] = f
+	}
+	if kind != columnMsgp {
+		return kind, encodeFloats(floats), nil
+	}
+	var b []byte
+	var err error
+	for _, p := range pts {
+		if b, err = msgp.AppendIntf(b, p.Values[col]); err != nil {
+			return 0, nil, err
+		}
+	}
+	return kind, b, nil
+}
+
+// columnFloatValue returns v as a float64 if it can be stored in a column of
+// the kind without loss
+func columnFloatValue(v any, kind byte) (float64, bool) {
+	switch kind {
+	case columnFloat:
+		f, ok := v.(float64)
+		return f, ok
+	case columnNumericString:
+		s, ok := v.(string)
+		if !ok {
+			return 0, false
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || formatNumericString(f) != s {
+			return 0, false
+		}
+		return f, true
+	}
+	return 0, false
+}
+
+// formatNumericString formats a float as the Prometheus model does
+func formatNumericString(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// decodeColumn decodes the values at index col of each point
+func decodeColumn(kind byte, b []byte, pts Points, col int) error {
+	switch kind {
+	case columnFloat, columnNumericString:
+		floats, err := decodeFloats(b, len(pts))
+		if err != nil {
+			return err
+		}
+		for i, f := range floats {
+			if kind == columnFloat {
+				pts[i].Values[col] = f
+			} else {
+				pts[i].Values[col] = formatNumericString(f)
+			}
+		}
+	case columnMsgp:
+		var err error
+		for i := range pts {
+			if pts[i].Values[col], b, err = msgp.ReadIntfBytes(b); err != nil {
+				return err
+			}
+		}
+	default:
+		return ErrInvalidColumnarData
+	}
+	return nil
+}
+
+// encodeFloats compresses the floats with Gorilla XOR compression
+func encodeFloats(floats []float64) []byte {
+	w := &bitWriter{}
+	prev := math.Float64bits(floats[0])
+	w.writeBits(prev, 64)
+	// leading and trailing are the zero bits around the previous XOR's meaningful
+	// bits, and are invalid until the first non-zero XOR is written
+	leading, trailing := uint8(0xff), uint8(0)
+	for _, f := range floats[1:] {
+		v := math.Float64bits(f)
+		xor := v ^ prev
+		prev = v
+		if xor == 0 {
+			w.writeBit(false)
+			continue
+		}
+		w.writeBit(true)
+		l := uint8(min(bits.LeadingZeros64(xor), 31))
+		t := uint8(bits.TrailingZeros64(xor))
+		if leading != 0xff && l >= leading && t >= trailing {
+			// the meaningful bits fit in the previous window
+			w.writeBit(false)
+			w.writeBits(xor>>trailing, int(64-leading-trailing))
+			continue
+		}
+		leading, trailing = l, t
+		w.writeBit(true)
+		w.writeBits(uint64(leading), 5)
+		// a window of 64 meaningful bits is written as 0
+		w.writeBits(uint64(64-leading-trailing), 6)
+		w.writeBits(xor>>trailing, int(64-leading-trailing))
+	}
+	return w.b
+}
+
+// decodeFloats decodes n floats compressed with encodeFloats
+func decodeFloats(b []byte, n int) ([]float64, error) {
+	r := &bitReader{b: b}
+	out := make([]float64, n)
+	v := r.readBits(64)
+	out[0] = math.Float64frombits(v)
+	var leading, trailing uint8
+	for i := 1; i < n; i++ {
+		if r.readBit() {
+			if r.readBit() {
+				leading = uint8(r.readBits(5))
+				m := uint8(r.readBits(6))
+				if m == 0 {
+					m = 64
+				}
+				trailing = 64 - leading - m
+			}
+			v ^= r.readBits(int(64-leading-trailing)) << trailing
+		}
+		out[i] = math.Float64frombits(v)
+	}
+	if r.err {
+		return nil, ErrInvalidColumnarData
+	}
+	return out, nil
+}
+
+// appendBlock appends the length of block and block to b
+func appendBlock(b, block []byte) []byte {
+	return append(binary.AppendUvarint(b, uint64(len(block))), block...)
+}
+
+// byteReader reads the byte-aligned sections of the columnar encoding
+type byteReader struct {
+	b   []byte
+	err error
+}
+
+func (r *byteReader) fail() {
+	if r.err == nil {
+		r.err = ErrInvalidColumnarData
+	}
+	r.b = nil
+}
+
+func (r *byteReader) byte() byte {
+	if len(r.b) == 0 {
+		r.fail()
+		return 0xff
+	}
+	v := r.b[0]
+	r.b = r.b[1:]
+	return v
+}
+
+func (r *byteReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.b)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *byteReader) varint() int64 {
+	v, n := binary.Varint(r.b)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *byteReader) block() []byte {
+	n := r.uvarint()
+	if n > uint64(len(r.b)) {
+		r.fail()
+		return nil
+	}
+	v := r.b[:n]
+	r.b = r.b[n:]
+	return v
+}
+
+// bitWriter writes a stream of bits, most significant bit first
+type bitWriter struct {
+	b []byte
+	// free is the number of unwritten bits in the last byte of b
+	free uint8
+}
+
+func (w *bitWriter) writeBit(bit bool) {
+	if w.free == 0 {
+		w.b = append(w.b, 0)
+		w.free = 8
+	}
+	w.free--
+	if bit {
+		w.b[len(w.b)-1] |= 1 << w.free
+	}
+}
+
+// writeBits writes the low n bits of v
+func (w *bitWriter) writeBits(v uint64, n int) {
+	for n > 0 {
+		if w.free == 0 {
+			w.b = append(w.b, 0)
+			w.free = 8
+		}
+		k := min(n, int(w.free))
+		chunk := byte(v>>(n-k)) & byte(1<<k-1)
+		w.free -= uint8(k)
+		w.b[len(w.b)-1] |= chunk << w.free
+		n -= k
+	}
+}
+
+// bitReader reads a stream of bits written by a bitWriter
+type bitReader struct {
+	b []byte
+	// pos is the index of the next bit to read
+	pos int
+	err bool
+}
+
+func (r *bitReader) readBit() bool {
+	if r.pos >= len(r.b)*8 {
+		r.err = true
+		return false
+	}
+	bit := r.b[r.pos/8]&(1<<(7-r.pos%8)) != 0
+	r.pos++
+	return bit
+}
+
+// readBits reads n bits into the low bits of the result
+func (r *bitReader) readBits(n int) uint64 {
+	var v uint64
+	for n > 0 {
+		if r.pos >= len(r.b)*8 {
+			r.err = true
+			return 0
+		}
+		avail := 8 - r.pos%8
+		k := min(n, avail)
+		chunk := uint64(r.b[r.pos/8]>>(avail-k)) & (1<<k - 1)
+		v = v<<k | chunk
+		r.pos += k
+		n -= k
+	}
+	return v
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dataset
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/epoch"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testColumnarDataSet returns a DataSet with a single series holding the
+// provided points
+func testColumnarDataSet(pts Points) *DataSet {
+	sh := testSeriesHeader()
+	sh.CalculateHash()
+	return &DataSet{
+		Status: "success",
+		Results: []*Result{{
+			SeriesList: []*Series{{Header: sh, Points: pts, PointSize: pts.Size()}},
+		}},
+		ExtentList:     timeseries.ExtentList{timeseries.Extent{Start: time.Unix(5, 0), End: time.Unix(10, 0)}},
+		TimeRangeQuery: &timeseries.TimeRangeQuery{Step: time.Duration(5 * timeseries.Second)},
+	}
+}
+
+// requireColumnarRoundTrip checks that the columnar encoding of ds decodes to
+// the same DataSet as its msgpack encoding
+func requireColumnarRoundTrip(t *testing.T, ds *DataSet) []byte {
+	t.Helper()
+	mb, err := MarshalDataSet(ds, nil, 0)
+	require.NoError(t, err)
+	cb, err := MarshalDataSetColumnar(ds, nil, 0)
+	require.NoError(t, err)
+	require.True(t, IsColumnar(cb))
+	require.False(t, IsColumnar(mb))
+	expected, err := UnmarshalDataSet(mb, nil)
+	require.NoError(t, err)
+	got, err := UnmarshalDataSet(cb, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+	return cb
+}
+
+func TestMarshalDataSetColumnar(t *testing.T) {
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := MarshalDataSetColumnar(nil, nil, 0)
+		require.ErrorIs(t, err, timeseries.ErrUnknownFormat)
+	})
+
+	t.Run("test datasets", func(t *testing.T) {
+		requireColumnarRoundTrip(t, testDataSet())
+		// includes nil results and series
+		requireColumnarRoundTrip(t, testDataSet2())
+		requireColumnarRoundTrip(t, genTestDataSet(3, 2))
+		requireColumnarRoundTrip(t, &DataSet{Error: "bad query", ErrorType: "bad_data"})
+	})
+
+	t.Run("floats", func(t *testing.T) {
+		floats := []float64{0, 1.5, 1.5, -2.25, 1e300, math.SmallestNonzeroFloat64,
+			math.Inf(1), math.Inf(-1), math.MaxFloat64, 0, 42}
+		pts := make(Points, len(floats))
+		for i, f := range floats {
+			pts[i] = Point{Epoch: epoch.Epoch(i) * epoch.Epoch(time.Second),
+				Size: 24, Values: []any{f, float64(i)}}
+		}
+		requireColumnarRoundTrip(t, testColumnarDataSet(pts))
+	})
+
+	t.Run("irregular timestamps", func(t *testing.T) {
+		offsets := []int64{0, 1, 2, 3, 70, 71, 400, 401, 3000, 3001,
+			1 << 40, 1<<40 + 1, 5, -1 << 50, 0}
+		pts := make(Points, len(offsets))
+		for i, o := range offsets {
+			pts[i] = Point{Epoch: epoch.Epoch(1700000000000000000 + o),
+				Size: 24, Values: []any{float64(i)}}
+		}
+		requireColumnarRoundTrip(t, testColumnarDataSet(pts))
+	})
+
+	t.Run("numeric strings", func(t *testing.T) {
+		values := []string{"1", "1.5", "0.1", "-3", "NaN", "+Inf", "-Inf",
+			"12345.6789", "0.30000000000000004"}
+		pts := make(Points, len(values))
+		for i, v := range values {
+			pts[i] = Point{Epoch: epoch.Epoch(i) * epoch.Epoch(time.Minute),
+				Size: len(v) + 32, Values: []any{v}}
+		}
+		requireColumnarRoundTrip(t, testColumnarDataSet(pts))
+	})
+
+	t.Run("non-canonical strings", func(t *testing.T) {
+		pts := Points{
+			{Epoch: 1, Size: 8, Values: []any{"1.50"}},
+			{Epoch: 2, Size: 8, Values: []any{"1e3"}},
+			{Epoch: 3, Size: 8, Values: []any{"host-a"}},
+		}
+		requireColumnarRoundTrip(t, testColumnarDataSet(pts))
+	})
+
+	t.Run("mixed values", func(t *testing.T) {
+		pts := Points{
+			{Epoch: 1, Size: 8, Values: []any{int64(1), "a", true, nil, 1.5}},
+			{Epoch: 2, Size: 9, Values: []any{int64(2), "b", false, 2.5, "2"}},
+			{Epoch: 3, Size: 10, Values: []any{int64(3), "c", true, nil, 3.5}},
+		}
+		requireColumnarRoundTrip(t, testColumnarDataSet(pts))
+	})
+
+	t.Run("ragged widths", func(t *testing.T) {
+		pts := Points{
+			{Epoch: 1, Size: 8, Values: []any{1.0}},
+			{Epoch: 2, Size: 8, Values: []any{1.0, 2.0}},
+			{Epoch: 3, Size: 8},
+		}
+		requireColumnarRoundTrip(t, testColumnarDataSet(pts))
+	})
+
+	t.Run("no values", func(t *testing.T) {
+		pts := Points{{Epoch: 1, Size: 8}, {Epoch: 2, Size: 8}}
+		requireColumnarRoundTrip(t, testColumnarDataSet(pts))
+	})
+}
+
+func TestUnmarshalColumnarInvalid(t *testing.T) {
+	b, err := MarshalDataSetColumnar(testColumnarDataSet(Points{
+		{Epoch: 1, Size: 8, Values: []any{"1", 1.5, int64(3)}},
+		{Epoch: 2, Size: 9, Values: []any{"2", 2.5, int64(4)}},
+	}), nil, 0)
+	require.NoError(t, err)
+
+	t.Run("unsupported version", func(t *testing.T) {
+		vb := append([]byte{}, b...)
+		vb[1] = columnarVersion + 1
+		_, err := UnmarshalDataSet(vb, nil)
+		require.ErrorIs(t, err, ErrUnsupportedEncodingVersion)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		for i := 1; i < len(b); i++ {
+			_, err := UnmarshalDataSet(b[:i], nil)
+			require.Error(t, err, "truncated to %d bytes", i)
+		}
+	})
+}
+
+// genEncodingBenchmarkDataset returns a DataSet of series with regularly-spaced
+// points, holding either float64 values or Prometheus-style numeric strings
+func genEncodingBenchmarkDataset(seriesct, pointct int, numericStrings bool) *DataSet {
+	sl := make(SeriesList, seriesct)
+	for i := range sl {
+		sh := testSeriesHeader()
+		sh.Name = "series" + strconv.Itoa(i)
+		pts := make(Points, pointct)
+		v := float64(i * 100)
+		for j := range pts {
+			// a slowly-changing gauge, as is typical of monitoring data
+			if j%4 == 0 {
+				v += 0.25
+			}
+			var val any = v
+			size := 24
+			if numericStrings {
+				s := strconv.FormatFloat(v, 'f', -1, 64)
+				val = s
+				size = len(s) + 32
+			}
+			pts[j] = Point{Epoch: epoch.Epoch(1700000000+j*15) * epoch.Epoch(time.Second),
+				Size: size, Values: []any{val}}
+		}
+		sl[i] = &Series{Header: sh, Points: pts, PointSize: pts.Size()}
+	}
+	return &DataSet{
+		Status:         "success",
+		Results:        []*Result{{SeriesList: sl}},
+		TimeRangeQuery: &timeseries.TimeRangeQuery{Step: 15 * time.Second},
+	}
+}
+
+var encodingBenchmarks = []struct {
+	name    string
+	marshal timeseries.MarshalerFunc
+}{
+	{timeseries.CacheEncodingMsgpack, MarshalDataSet},
+	{timeseries.CacheEncodingColumnar, MarshalDataSetColumnar},
+}
+
+func BenchmarkMarshalDataSetEncodings(b *testing.B) {
+	for _, numericStrings := range []bool{false, true} {
+		ds := genEncodingBenchmarkDataset(10, 1000, numericStrings)
+		for _, eb := range encodingBenchmarks {
+			b.Run(eb.name+"/numeric-strings="+strconv.FormatBool(numericStrings),
+				func(b *testing.B) {
+					var out []byte
+					for b.Loop() {
+						out, _ = eb.marshal(ds, nil, 0)
+					}
+					b.ReportMetric(float64(len(out)), "encoded-bytes")
+				})
+		}
+	}
+}
+
+func BenchmarkUnmarshalDataSetEncodings(b *testing.B) {
+	for _, numericStrings := range []bool{false, true} {
+		ds := genEncodingBenchmarkDataset(10, 1000, numericStrings)
+		for _, eb := range encodingBenchmarks {
+			b.Run(eb.name+"/numeric-strings="+strconv.FormatBool(numericStrings),
+				func(b *testing.B) {
+					enc, err := eb.marshal(ds, nil, 0)
+					if err != nil {
+						b.Fatal(err)
+					}
+					for b.Loop() {
+						if _, err := UnmarshalDataSet(enc, nil); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+		}
+	}
+}
@@ -756,10 +756,19 @@ func (ds *DataSet) Sort() {
 	}
 }
 
-// UnmarshalDataSet unmarshals the dataset from a msgpack-formatted byte slice
+// UnmarshalDataSet unmarshals the dataset from a msgpack- or columnar-formatted
+// byte slice
 func UnmarshalDataSet(b []byte, trq *timeseries.TimeRangeQuery) (timeseries.Timeseries, error) {
 	ds := &DataSet{}
-	_, err := ds.UnmarshalMsg(b)
+	var err error
+	if IsColumnar(b) {
+		var cds *DataSet
+		if cds, err = unmarshalColumnar(b); err == nil {
+			ds = cds
+		}
+	} else {
+		_, err = ds.UnmarshalMsg(b)
+	}
 	if err == nil {
 		if ds.TimeRangeQuery != nil {
 			ds.TimeRangeQuery.Step = time.Duration(ds.TimeRangeQuery.StepNS)
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dataset
+
+import (
+	"slices"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+)
+
+// Row is a Point of a Series, as written in a row of a tabular query result
+type Row struct {
+	Series *Series
+	Point  *Point
+}
+
+// Rows returns the Points of the DataSet's Series as Rows, in Series order
+func (ds *DataSet) Rows() []Row {
+	var sl SeriesList
+	for _, r := range ds.Results {
+		sl = append(sl, r.SeriesList...)
+	}
+	return sl.Rows()
+}
+
+// Rows returns the Points of the SeriesList as Rows, in Series order
+func (sl SeriesList) Rows() []Row {
+	var n int
+	for _, s := range sl {
+		if s != nil {
+			n += len(s.Points)
+		}
+	}
+	rows := make([]Row, 0, n)
+	for _, s := range sl {
+		if s == nil {
+			continue
+		}
+		for i := range s.Points {
+			rows = append(rows, Row{Series: s, Point: &s.Points[i]})
+		}
+	}
+	return rows
+}
+
+// SortRowsByTime sorts the Rows by time, keeping the Series order of Rows
+// with the same timestamp
+func SortRowsByTime(rows []Row) {
+	slices.SortStableFunc(rows, func(a, b Row) int {
+		switch {
+		case a.Point.Epoch < b.Point.Epoch:
+			return -1
+		case a.Point.Epoch > b.Point.Epoch:
+			return 1
+		}
+		return 0
+	})
+}
+
+// Value returns the value of the Row for the tag or value field, or nil if it
+// is null. Timestamps are not returned, since each output format has its own
+// representation of the Point's Epoch.
+func (r Row) Value(fd timeseries.FieldDefinition) any {
+	switch fd.Role {
+	case timeseries.RoleTimestamp:
+		return nil
+	case timeseries.RoleTag:
+		if v, ok := r.Series.Header.Tags[fd.Name]; ok {
+			return v
+		}
+		return nil
+	}
+	for j, vfd := range r.Series.Header.ValueFieldsList {
+		if vfd.Name == fd.Name && j < len(r.Point.Values) {
+			return r.Point.Values[j]
+		}
+	}
+	return nil
+}
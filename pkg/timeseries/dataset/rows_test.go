@@ -0,0 +1,70 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dataset
+
+import (
+	"testing"
+
+	"github.com/trickstercache/trickster/v2/pkg/timeseries"
+	"github.com/trickstercache/trickster/v2/pkg/timeseries/epoch"
+)
+
+func TestRows(t *testing.T) {
+	tfd := timeseries.FieldDefinition{Name: "t", Role: timeseries.RoleTimestamp}
+	host := timeseries.FieldDefinition{Name: "host", Role: timeseries.RoleTag}
+	val := timeseries.FieldDefinition{Name: "v", Role: timeseries.RoleValue}
+	newSeries := func(tag string, epochs ...int) *Series {
+		s := &Series{Header: SeriesHeader{Tags: Tags{"host": tag},
+			ValueFieldsList: timeseries.FieldDefinitions{val}}}
+		for _, e := range epochs {
+			s.Points = append(s.Points, Point{Epoch: epoch.Epoch(e), Values: []any{int64(e)}})
+		}
+		return s
+	}
+	ds := &DataSet{Results: Results{
+		{SeriesList: SeriesList{newSeries("a", 3, 1), nil}},
+		{SeriesList: SeriesList{newSeries("b", 1, 2)}},
+	}}
+	rows := ds.Rows()
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows got %d", len(rows))
+	}
+	if rows[0].Value(host) != "a" || rows[2].Value(host) != "b" {
+		t.Error("expected rows in series order")
+	}
+	SortRowsByTime(rows)
+	var got []any
+	for _, r := range rows {
+		got = append(got, r.Value(host), r.Value(val))
+	}
+	want := []any{"a", int64(1), "b", int64(1), "b", int64(2), "a", int64(3)}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v got %v", want, got)
+		}
+	}
+	if v := rows[0].Value(tfd); v != nil {
+		t.Errorf("expected nil timestamp value got %v", v)
+	}
+	if v := rows[0].Value(timeseries.FieldDefinition{Name: "zone",
+		Role: timeseries.RoleTag}); v != nil {
+		t.Errorf("expected nil for a missing tag got %v", v)
+	}
+	if v := rows[0].Value(timeseries.FieldDefinition{Name: "x"}); v != nil {
+		t.Errorf("expected nil for a missing value got %v", v)
+	}
+}
@@ -28,6 +28,25 @@ type Modeler struct {
 	WireMarshalWriter     MarshalWriterFunc     `msg:"-"`
 	CacheUnmarshaler      UnmarshalerFunc       `msg:"-"`
 	CacheMarshaler        MarshalerFunc         `msg:"-"`
+	// ColumnarCacheMarshaler, when set, marshals a Timeseries into a compact
+	// columnar encoding that CacheUnmarshaler is also able to read
+	ColumnarCacheMarshaler MarshalerFunc `msg:"-"`
+}
+
+const (
+	// CacheEncodingMsgpack is the default cache encoding for Timeseries
+	CacheEncodingMsgpack = "msgpack"
+	// CacheEncodingColumnar is the compact columnar cache encoding for Timeseries
+	CacheEncodingColumnar = "columnar"
+)
+
+// CacheMarshalerFor returns the cache marshaler for the provided cache encoding,
+// falling back to CacheMarshaler when the Modeler has no columnar marshaler
+func (m *Modeler) CacheMarshalerFor(encoding string) MarshalerFunc {
+	if encoding == CacheEncodingColumnar && m.ColumnarCacheMarshaler != nil {
+		return m.ColumnarCacheMarshaler
+	}
+	return m.CacheMarshaler
 }
 
 // UnmarshalerFunc describes a function that unmarshals a Timeseries
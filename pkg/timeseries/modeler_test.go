@@ -33,3 +33,30 @@ func TestNewModeler(t *testing.T) {
 		t.Error("expected non-nil CacheUnmarshaler")
 	}
 }
+
+func TestCacheMarshalerFor(t *testing.T) {
+	var calls []string
+	marshaler := func(name string) MarshalerFunc {
+		return func(Timeseries, *RequestOptions, int) ([]byte, error) {
+			calls = append(calls, name)
+			return nil, nil
+		}
+	}
+
+	m := &Modeler{CacheMarshaler: marshaler(CacheEncodingMsgpack)}
+	m.CacheMarshalerFor(CacheEncodingColumnar)(nil, nil, 0)
+
+	m.ColumnarCacheMarshaler = marshaler(CacheEncodingColumnar)
+	m.CacheMarshalerFor(CacheEncodingColumnar)(nil, nil, 0)
+	m.CacheMarshalerFor("")(nil, nil, 0)
+
+	expected := []string{CacheEncodingMsgpack, CacheEncodingColumnar, CacheEncodingMsgpack}
+	if len(calls) != len(expected) {
+		t.Fatalf("expected %v got %v", expected, calls)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Errorf("expected %v got %v", expected, calls)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sketch provides probabilistic data structures for estimating the
+// frequency of keys in a fixed amount of memory
+package sketch
+
+import (
+	"math/bits"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+const (
+	// depth is the number of counter rows, each indexed by a different hash
+	depth = 4
+	// maxCount is the value at which counters saturate
+	maxCount = 255
+	// DefaultWidth is the default number of counters in each row
+	DefaultWidth = 1 << 16
+)
+
+// CountMin is a count-min sketch of key frequencies. The counts are halved
+// each time the number of increments reaches the sample size, so that the
+// estimates favor recent activity over activity in the distant past.
+type CountMin struct {
+	mu         sync.Mutex
+	rows       [depth][]uint8
+	mask       uint64
+	samples    int
+	sampleSize int
+}
+
+// NewCountMin returns a new CountMin with the provided row width, which is
+// rounded up to a power of two, and sample size. A sampleSize of 0 defaults
+// to ten times the width.
+func NewCountMin(width, sampleSize int) *CountMin {
+	if width < 1 {
+		width = DefaultWidth
+	}
+	width = 1 << bits.Len(uint(width-1))
+	if sampleSize < 1 {
+		sampleSize = width * 10
+	}
+	c := &CountMin{mask: uint64(width - 1), sampleSize: sampleSize}
+	for i := range c.rows {
+		c.rows[i] = make([]uint8, width)
+	}
+	return c
+}
+
+// indexes returns the counter index for the key in each row, using double
+// hashing to derive the row hashes from a single 64-bit hash
+func (c *CountMin) indexes(key string) [depth]uint64 {
+	h := xxhash.Sum64String(key)
+	h1, h2 := h, h>>32|h<<32
+	var out [depth]uint64
+	for i := range out {
+		out[i] = (h1 + uint64(i)*h2) & c.mask
+	}
+	return out
+}
+
+// Increment records an occurrence of the key and returns its new estimated count
+func (c *CountMin) Increment(key string) int {
+	idx := c.indexes(key)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	est := maxCount
+	for i, j := range idx {
+		if c.rows[i][j] < maxCount {
+			c.rows[i][j]++
+		}
+		est = min(est, int(c.rows[i][j]))
+	}
+	c.samples++
+	if c.samples >= c.sampleSize {
+		c.age()
+	}
+	return est
+}
+
+// Estimate returns the estimated count of the key
+func (c *CountMin) Estimate(key string) int {
+	idx := c.indexes(key)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	est := maxCount
+	for i, j := range idx {
+		est = min(est, int(c.rows[i][j]))
+	}
+	return est
+}
+
+// Reset clears all counts
+func (c *CountMin) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.rows {
+		clear(c.rows[i])
+	}
+	c.samples = 0
+}
+
+// age halves each counter and the sample count. c.mu must be held.
+func (c *CountMin) age() {
+	for i := range c.rows {
+		for j := range c.rows[i] {
+			c.rows[i][j] >>= 1
+		}
+	}
+	c.samples /= 2
+}
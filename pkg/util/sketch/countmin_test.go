@@ -0,0 +1,70 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sketch
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestCountMin(t *testing.T) {
+	c := NewCountMin(1000, 1000)
+	if len(c.rows[0]) != 1024 {
+		t.Errorf("expected %d got %d", 1024, len(c.rows[0]))
+	}
+	for i := range 5 {
+		if n := c.Increment("hot"); n != i+1 {
+			t.Errorf("expected %d got %d", i+1, n)
+		}
+	}
+	c.Increment("cold")
+	if n := c.Estimate("hot"); n != 5 {
+		t.Errorf("expected %d got %d", 5, n)
+	}
+	if n := c.Estimate("cold"); n != 1 {
+		t.Errorf("expected %d got %d", 1, n)
+	}
+	if n := c.Estimate("missing"); n != 0 {
+		t.Errorf("expected %d got %d", 0, n)
+	}
+
+	// reaching the sample size halves the counts
+	for i := range 994 {
+		c.Increment("other" + strconv.Itoa(i))
+	}
+	if n := c.Estimate("hot"); n != 2 {
+		t.Errorf("expected %d got %d", 2, n)
+	}
+
+	c.Reset()
+	if n := c.Estimate("hot"); n != 0 {
+		t.Errorf("expected %d got %d", 0, n)
+	}
+}
+
+func TestCountMinSaturates(t *testing.T) {
+	c := NewCountMin(0, 0)
+	if len(c.rows[0]) != DefaultWidth || c.sampleSize != DefaultWidth*10 {
+		t.Error("expected default width and sample size")
+	}
+	for range maxCount + 10 {
+		c.Increment("key")
+	}
+	if n := c.Estimate("key"); n != maxCount {
+		t.Errorf("expected %d got %d", maxCount, n)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sketch
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Window counts the occurrences of keys within a fixed window of time, using
+// a count-min sketch that is cleared at the end of each window
+type Window struct {
+	mu     sync.Mutex
+	sketch *CountMin
+	size   time.Duration
+	start  time.Time
+}
+
+// NewWindow returns a new Window with the provided row width and window size
+func NewWindow(width int, size time.Duration) *Window {
+	return &Window{
+		sketch: NewCountMin(width, math.MaxInt),
+		size:   size,
+		start:  time.Now(),
+	}
+}
+
+// Increment records an occurrence of the key and returns its estimated count
+// in the current window
+func (w *Window) Increment(key string) int {
+	w.roll()
+	return w.sketch.Increment(key)
+}
+
+// Estimate returns the estimated count of the key in the current window
+func (w *Window) Estimate(key string) int {
+	w.roll()
+	return w.sketch.Estimate(key)
+}
+
+// roll clears the sketch when the current window has ended
+func (w *Window) roll() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if now := time.Now(); w.size > 0 && now.Sub(w.start) >= w.size {
+		w.sketch.Reset()
+		w.start = now
+	}
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright 2026 The Trickster Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sketch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindow(t *testing.T) {
+	w := NewWindow(1024, time.Hour)
+	w.Increment("key")
+	if n := w.Increment("key"); n != 2 {
+		t.Errorf("expected %d got %d", 2, n)
+	}
+	if n := w.Estimate("key"); n != 2 {
+		t.Errorf("expected %d got %d", 2, n)
+	}
+	// the counts are cleared when the window ends
+	w.start = time.Now().Add(-2 * time.Hour)
+	if n := w.Estimate("key"); n != 0 {
+		t.Errorf("expected %d got %d", 0, n)
+	}
+}